@@ -62,6 +62,40 @@ type ACL struct {
 	node     string
 	operator string
 	quota    string
+
+	// nodeRules are the node policies restricted to the nodes matching their
+	// selectors
+	nodeRules []*nodeRule
+
+	// workloadNamespace and workloadJob are the namespace and ID of the job
+	// a workload identity may read
+	workloadNamespace string
+	workloadJob       string
+}
+
+// nodeRule is a node policy restricted to the nodes matching its selectors
+type nodeRule struct {
+	policy     string
+	class      string
+	datacenter string
+	meta       map[string]string
+}
+
+// match returns whether a node matches the selectors of the rule
+func (r *nodeRule) match(class, datacenter string, meta map[string]string) bool {
+	if r.class != "" && !glob.Glob(r.class, class) {
+		return false
+	}
+	if r.datacenter != "" && !glob.Glob(r.datacenter, datacenter) {
+		return false
+	}
+	for k, pattern := range r.meta {
+		v, ok := meta[k]
+		if !ok || !glob.Glob(pattern, v) {
+			return false
+		}
+	}
+	return true
 }
 
 // maxPrivilege returns the policy which grants the most privilege
@@ -184,8 +218,17 @@ func NewACL(management bool, policies []*Policy) (*ACL, error) {
 		if policy.Agent != nil {
 			acl.agent = maxPrivilege(acl.agent, policy.Agent.Policy)
 		}
-		if policy.Node != nil {
-			acl.node = maxPrivilege(acl.node, policy.Node.Policy)
+		if node := policy.Node; node != nil {
+			if node.HasSelectors() {
+				acl.nodeRules = append(acl.nodeRules, &nodeRule{
+					policy:     node.Policy,
+					class:      node.Class,
+					datacenter: node.Datacenter,
+					meta:       node.Meta,
+				})
+			} else {
+				acl.node = maxPrivilege(acl.node, node.Policy)
+			}
 		}
 		if policy.Operator != nil {
 			acl.operator = maxPrivilege(acl.operator, policy.Operator.Policy)
@@ -399,11 +442,13 @@ func (a *ACL) AllowAgentWrite() bool {
 	}
 }
 
-// AllowNodeRead checks if read operations are allowed for a node
+// AllowNodeRead checks if read operations are allowed for all nodes
 func (a *ACL) AllowNodeRead() bool {
 	switch {
 	case a.management:
 		return true
+	case a.denyAnyNodeRule():
+		return false
 	case a.node == PolicyWrite:
 		return true
 	case a.node == PolicyRead:
@@ -413,11 +458,13 @@ func (a *ACL) AllowNodeRead() bool {
 	}
 }
 
-// AllowNodeWrite checks if write operations are allowed for a node
+// AllowNodeWrite checks if write operations are allowed for all nodes
 func (a *ACL) AllowNodeWrite() bool {
 	switch {
 	case a.management:
 		return true
+	case a.denyAnyNodeRule():
+		return false
 	case a.node == PolicyWrite:
 		return true
 	default:
@@ -425,6 +472,89 @@ func (a *ACL) AllowNodeWrite() bool {
 	}
 }
 
+// AllowAnyNodeRead checks if read operations are allowed for all nodes or for
+// the nodes matching the selectors of a node policy
+func (a *ACL) AllowAnyNodeRead() bool {
+	return a.management || a.node == PolicyRead || a.node == PolicyWrite ||
+		a.allowAnyNodeRule(PolicyRead, PolicyWrite)
+}
+
+// AllowAnyNodeWrite checks if write operations are allowed for all nodes or
+// for the nodes matching the selectors of a node policy
+func (a *ACL) AllowAnyNodeWrite() bool {
+	return a.management || a.node == PolicyWrite || a.allowAnyNodeRule(PolicyWrite)
+}
+
+// allowAnyNodeRule checks if a node rule grants one of the policies, unless
+// all nodes are denied
+func (a *ACL) allowAnyNodeRule(policies ...string) bool {
+	if a.node == PolicyDeny {
+		return false
+	}
+	for _, r := range a.nodeRules {
+		for _, p := range policies {
+			if r.policy == p {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// denyAnyNodeRule checks if a node rule denies the nodes matching its
+// selectors, in which case not all nodes are allowed
+func (a *ACL) denyAnyNodeRule() bool {
+	for _, r := range a.nodeRules {
+		if r.policy == PolicyDeny {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowNodeReadFor checks if read operations are allowed for the node with the
+// given class, datacenter and meta
+func (a *ACL) AllowNodeReadFor(class, datacenter string, meta map[string]string) bool {
+	policy := a.nodePolicyFor(class, datacenter, meta)
+	switch {
+	case a.management:
+		return true
+	case policy == PolicyWrite:
+		return true
+	case policy == PolicyRead:
+		return true
+	default:
+		return false
+	}
+}
+
+// AllowNodeWriteFor checks if write operations are allowed for the node with
+// the given class, datacenter and meta
+func (a *ACL) AllowNodeWriteFor(class, datacenter string, meta map[string]string) bool {
+	policy := a.nodePolicyFor(class, datacenter, meta)
+	switch {
+	case a.management:
+		return true
+	case policy == PolicyWrite:
+		return true
+	default:
+		return false
+	}
+}
+
+// nodePolicyFor returns the policy granting the most privilege for the node
+// with the given class, datacenter and meta, from the node policy for all
+// nodes and the node rules the node matches
+func (a *ACL) nodePolicyFor(class, datacenter string, meta map[string]string) string {
+	policy := a.node
+	for _, r := range a.nodeRules {
+		if r.match(class, datacenter, meta) {
+			policy = maxPrivilege(policy, r.policy)
+		}
+	}
+	return policy
+}
+
 // AllowOperatorRead checks if read operations are allowed for a operator
 func (a *ACL) AllowOperatorRead() bool {
 	switch {
@@ -477,6 +607,27 @@ func (a *ACL) AllowQuotaWrite() bool {
 	}
 }
 
+// NewWorkloadIdentityACL returns the ACL of a workload identity, which may only
+// read the job of its task.
+func NewWorkloadIdentityACL(namespace, jobID string) (*ACL, error) {
+	acl, err := NewACL(false, nil)
+	if err != nil {
+		return nil, err
+	}
+	acl.workloadNamespace = namespace
+	acl.workloadJob = jobID
+	return acl, nil
+}
+
+// AllowJobRead checks if the job of a namespace may be read, either with the
+// read-job capability on the namespace or as a workload identity of the job.
+func (a *ACL) AllowJobRead(ns, jobID string) bool {
+	if a.AllowNsOp(ns, NamespaceCapabilityReadJob) {
+		return true
+	}
+	return a.workloadJob != "" && a.workloadNamespace == ns && a.workloadJob == jobID
+}
+
 // IsManagement checks if this represents a management token
 func (a *ACL) IsManagement() bool {
 	return a.management
@@ -460,3 +460,108 @@ func TestACL_matchingCapabilitySet_difference(t *testing.T) {
 	}
 
 }
+
+func TestACL_NodeRules(t *testing.T) {
+	assert := assert.New(t)
+
+	// A read policy for all nodes and a write policy for the nodes of a team
+	p1, err := Parse(`node { policy = "read" }`)
+	assert.Nil(err)
+	p2, err := Parse(`
+node {
+	policy = "write"
+	class = "team-a-*"
+	meta {
+		team = "a"
+	}
+}`)
+	assert.Nil(err)
+	acl, err := NewACL(false, []*Policy{p1, p2})
+	assert.Nil(err)
+
+	assert.True(acl.AllowNodeRead())
+	assert.False(acl.AllowNodeWrite())
+	assert.True(acl.AllowAnyNodeRead())
+	assert.True(acl.AllowAnyNodeWrite())
+
+	teamA := map[string]string{"team": "a"}
+	assert.True(acl.AllowNodeReadFor("team-a-large", "dc1", teamA))
+	assert.True(acl.AllowNodeWriteFor("team-a-large", "dc1", teamA))
+	assert.True(acl.AllowNodeReadFor("team-b", "dc1", teamA))
+	assert.False(acl.AllowNodeWriteFor("team-b", "dc1", teamA))
+	assert.False(acl.AllowNodeWriteFor("team-a-large", "dc1", map[string]string{"team": "b"}))
+	assert.False(acl.AllowNodeWriteFor("team-a-large", "dc1", nil))
+
+	// Only the nodes of a datacenter can be read
+	p3, err := Parse(`node { policy = "read" datacenter = "dc1" }`)
+	assert.Nil(err)
+	acl, err = NewACL(false, []*Policy{p3})
+	assert.Nil(err)
+
+	assert.False(acl.AllowNodeRead())
+	assert.True(acl.AllowAnyNodeRead())
+	assert.False(acl.AllowAnyNodeWrite())
+	assert.True(acl.AllowNodeReadFor("", "dc1", nil))
+	assert.False(acl.AllowNodeWriteFor("", "dc1", nil))
+	assert.False(acl.AllowNodeReadFor("", "dc2", nil))
+
+	// Deny takes precedence for the matching nodes
+	p4, err := Parse(`node { policy = "deny" datacenter = "dc2" }`)
+	assert.Nil(err)
+	acl, err = NewACL(false, []*Policy{p1, p4})
+	assert.Nil(err)
+
+	assert.False(acl.AllowNodeRead())
+	assert.True(acl.AllowAnyNodeRead())
+	assert.True(acl.AllowNodeReadFor("", "dc1", nil))
+	assert.False(acl.AllowNodeReadFor("", "dc2", nil))
+
+	// Writing all nodes isn't allowed once the nodes of a datacenter are denied
+	p6, err := Parse(`node { policy = "write" }`)
+	assert.Nil(err)
+	acl, err = NewACL(false, []*Policy{p6, p4})
+	assert.Nil(err)
+
+	assert.False(acl.AllowNodeWrite())
+	assert.True(acl.AllowAnyNodeWrite())
+	assert.True(acl.AllowNodeWriteFor("", "dc1", nil))
+	assert.False(acl.AllowNodeWriteFor("", "dc2", nil))
+
+	// Denying all nodes overrides the node rules
+	p5, err := Parse(`node { policy = "deny" }`)
+	assert.Nil(err)
+	acl, err = NewACL(false, []*Policy{p2, p5})
+	assert.Nil(err)
+
+	assert.False(acl.AllowAnyNodeRead())
+	assert.False(acl.AllowAnyNodeWrite())
+	assert.False(acl.AllowNodeWriteFor("team-a-large", "dc1", teamA))
+
+	// Management tokens are allowed everything
+	assert.True(ManagementACL.AllowAnyNodeWrite())
+	assert.True(ManagementACL.AllowNodeWriteFor("", "", nil))
+}
+
+func TestACL_AllowJobRead(t *testing.T) {
+	assert := assert.New(t)
+
+	// A workload identity may only read the job of its task
+	acl, err := NewWorkloadIdentityACL("default", "web")
+	assert.Nil(err)
+
+	assert.True(acl.AllowJobRead("default", "web"))
+	assert.False(acl.AllowJobRead("default", "api"))
+	assert.False(acl.AllowJobRead("other", "web"))
+	assert.False(acl.AllowNsOp("default", NamespaceCapabilityReadJob))
+	assert.False(acl.AllowNamespace("default"))
+
+	// The read-job capability allows reading every job of the namespace
+	p, err := Parse(`namespace "default" { capabilities = ["read-job"] }`)
+	assert.Nil(err)
+	acl, err = NewACL(false, []*Policy{p})
+	assert.Nil(err)
+
+	assert.True(acl.AllowJobRead("default", "api"))
+	assert.False(acl.AllowJobRead("other", "api"))
+	assert.True(ManagementACL.AllowJobRead("other", "api"))
+}
@@ -32,6 +32,7 @@ const (
 	NamespaceCapabilityAllocExec        = "alloc-exec"
 	NamespaceCapabilityAllocNodeExec    = "alloc-node-exec"
 	NamespaceCapabilityAllocLifecycle   = "alloc-lifecycle"
+	NamespaceCapabilityScaleJob         = "scale-job"
 	NamespaceCapabilitySentinelOverride = "sentinel-override"
 )
 
@@ -94,8 +95,20 @@ type AgentPolicy struct {
 	Policy string
 }
 
+// NodePolicy is the policy for client nodes. When any of the selectors is set,
+// the policy only applies to the nodes matching all of them. Class,
+// Datacenter and the values of Meta may be glob patterns.
 type NodePolicy struct {
-	Policy string
+	Policy     string
+	Class      string
+	Datacenter string
+	Meta       map[string]string
+}
+
+// HasSelectors returns whether the node policy is restricted to the nodes
+// matching its selectors
+func (n *NodePolicy) HasSelectors() bool {
+	return n.Class != "" || n.Datacenter != "" || len(n.Meta) != 0
 }
 
 type OperatorPolicy struct {
@@ -122,7 +135,8 @@ func isNamespaceCapabilityValid(cap string) bool {
 	case NamespaceCapabilityDeny, NamespaceCapabilityListJobs, NamespaceCapabilityReadJob,
 		NamespaceCapabilitySubmitJob, NamespaceCapabilityDispatchJob, NamespaceCapabilityReadLogs,
 		NamespaceCapabilityReadFS, NamespaceCapabilityAllocLifecycle,
-		NamespaceCapabilityAllocExec, NamespaceCapabilityAllocNodeExec:
+		NamespaceCapabilityAllocExec, NamespaceCapabilityAllocNodeExec,
+		NamespaceCapabilityScaleJob:
 		return true
 	// Separate the enterprise-only capabilities
 	case NamespaceCapabilitySentinelOverride:
@@ -153,6 +167,7 @@ func expandNamespacePolicy(policy string) []string {
 			NamespaceCapabilityReadFS,
 			NamespaceCapabilityAllocExec,
 			NamespaceCapabilityAllocLifecycle,
+			NamespaceCapabilityScaleJob,
 		}
 	default:
 		return nil
@@ -250,8 +265,15 @@ func Parse(rules string) (*Policy, error) {
 		return nil, fmt.Errorf("Invalid agent policy: %#v", p.Agent)
 	}
 
-	if p.Node != nil && !isPolicyValid(p.Node.Policy) {
-		return nil, fmt.Errorf("Invalid node policy: %#v", p.Node)
+	if p.Node != nil {
+		if !isPolicyValid(p.Node.Policy) {
+			return nil, fmt.Errorf("Invalid node policy: %#v", p.Node)
+		}
+		for k := range p.Node.Meta {
+			if k == "" {
+				return nil, fmt.Errorf("Invalid node meta selector: %#v", p.Node)
+			}
+		}
 	}
 
 	if p.Operator != nil && !isPolicyValid(p.Operator.Policy) {
@@ -82,6 +82,7 @@ func TestParse(t *testing.T) {
 							NamespaceCapabilityReadFS,
 							NamespaceCapabilityAllocExec,
 							NamespaceCapabilityAllocLifecycle,
+							NamespaceCapabilityScaleJob,
 						},
 					},
 					{
@@ -142,6 +143,39 @@ func TestParse(t *testing.T) {
 			"Invalid node policy",
 			nil,
 		},
+		{
+			`
+			node {
+				policy = "write"
+				class = "team-a-*"
+				datacenter = "dc1"
+				meta {
+					team = "a"
+				}
+			}
+			`,
+			"",
+			&Policy{
+				Node: &NodePolicy{
+					Policy:     PolicyWrite,
+					Class:      "team-a-*",
+					Datacenter: "dc1",
+					Meta:       map[string]string{"team": "a"},
+				},
+			},
+		},
+		{
+			`
+			node {
+				policy = "write"
+				meta {
+					"" = "a"
+				}
+			}
+			`,
+			"Invalid node meta selector",
+			nil,
+		},
 		{
 			`
 			operator {
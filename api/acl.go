@@ -182,6 +182,19 @@ type ACLToken struct {
 	CreateTime  time.Time
 	CreateIndex uint64
 	ModifyIndex uint64
+
+	// Roles are the ACL roles this token ties to. Either the ID or the name
+	// of each role may be given when creating or updating the token.
+	Roles []*ACLTokenRoleLink `json:",omitempty"`
+
+	// ExpirationTime is the time after which the token can no longer be
+	// used, nil if the token never expires.
+	ExpirationTime *time.Time `json:",omitempty"`
+
+	// ExpirationTTL is the duration the token can be used for after its
+	// creation. It is used to compute ExpirationTime when the token is
+	// created.
+	ExpirationTTL time.Duration `json:",omitempty"`
 }
 
 type ACLTokenListStub struct {
@@ -193,4 +206,396 @@ type ACLTokenListStub struct {
 	CreateTime  time.Time
 	CreateIndex uint64
 	ModifyIndex uint64
+
+	Roles          []*ACLTokenRoleLink `json:",omitempty"`
+	ExpirationTime *time.Time          `json:",omitempty"`
+}
+
+// ACLTokenRoleLink links an ACL token to an ACL role.
+type ACLTokenRoleLink struct {
+	ID   string
+	Name string
+}
+
+// ACLRoles is used to query the ACL role endpoints.
+type ACLRoles struct {
+	client *Client
+}
+
+// ACLRoles returns a new handle on the ACL roles.
+func (c *Client) ACLRoles() *ACLRoles {
+	return &ACLRoles{client: c}
+}
+
+// List is used to dump all of the roles.
+func (a *ACLRoles) List(q *QueryOptions) ([]*ACLRoleListStub, *QueryMeta, error) {
+	var resp []*ACLRoleListStub
+	qm, err := a.client.query("/v1/acl/roles", &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp, qm, nil
+}
+
+// Create is used to create a role
+func (a *ACLRoles) Create(role *ACLRole, q *WriteOptions) (*ACLRole, *WriteMeta, error) {
+	if role.ID != "" {
+		return nil, nil, fmt.Errorf("cannot specify ID")
+	}
+	var resp ACLRole
+	wm, err := a.client.write("/v1/acl/role", role, &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, wm, nil
+}
+
+// Update is used to update an existing role
+func (a *ACLRoles) Update(role *ACLRole, q *WriteOptions) (*ACLRole, *WriteMeta, error) {
+	if role.ID == "" {
+		return nil, nil, fmt.Errorf("missing role ID")
+	}
+	var resp ACLRole
+	wm, err := a.client.write("/v1/acl/role/"+role.ID, role, &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, wm, nil
+}
+
+// Delete is used to delete a role
+func (a *ACLRoles) Delete(roleID string, q *WriteOptions) (*WriteMeta, error) {
+	if roleID == "" {
+		return nil, fmt.Errorf("missing role ID")
+	}
+	wm, err := a.client.delete("/v1/acl/role/"+roleID, nil, q)
+	if err != nil {
+		return nil, err
+	}
+	return wm, nil
+}
+
+// Info is used to query a role by ID
+func (a *ACLRoles) Info(roleID string, q *QueryOptions) (*ACLRole, *QueryMeta, error) {
+	if roleID == "" {
+		return nil, nil, fmt.Errorf("missing role ID")
+	}
+	var resp ACLRole
+	qm, err := a.client.query("/v1/acl/role/"+roleID, &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, qm, nil
+}
+
+// InfoByName is used to query a role by name
+func (a *ACLRoles) InfoByName(roleName string, q *QueryOptions) (*ACLRole, *QueryMeta, error) {
+	if roleName == "" {
+		return nil, nil, fmt.Errorf("missing role name")
+	}
+	var resp ACLRole
+	qm, err := a.client.query("/v1/acl/role/name/"+roleName, &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, qm, nil
+}
+
+// ACLRole groups a set of ACL policies under a name, so that tokens can be
+// linked to the role rather than to each of its policies.
+type ACLRole struct {
+	ID          string
+	Name        string
+	Description string
+	Policies    []*ACLRolePolicyLink
+	CreateIndex uint64
+	ModifyIndex uint64
+}
+
+// ACLRolePolicyLink links an ACL role to an ACL policy by name.
+type ACLRolePolicyLink struct {
+	Name string
+}
+
+// ACLRoleListStub is used to for listing ACL roles
+type ACLRoleListStub struct {
+	ID          string
+	Name        string
+	Description string
+	Policies    []*ACLRolePolicyLink
+	CreateIndex uint64
+	ModifyIndex uint64
+}
+
+// ACLAuthMethods is used to query the ACL auth method endpoints and to log in
+// with an auth method.
+type ACLAuthMethods struct {
+	client *Client
+}
+
+// ACLAuthMethods returns a new handle on the ACL auth methods.
+func (c *Client) ACLAuthMethods() *ACLAuthMethods {
+	return &ACLAuthMethods{client: c}
+}
+
+// List is used to dump all of the auth methods. It doesn't require a token.
+func (a *ACLAuthMethods) List(q *QueryOptions) ([]*ACLAuthMethodListStub, *QueryMeta, error) {
+	var resp []*ACLAuthMethodListStub
+	qm, err := a.client.query("/v1/acl/auth-methods", &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp, qm, nil
+}
+
+// Upsert is used to create or update an auth method
+func (a *ACLAuthMethods) Upsert(method *ACLAuthMethod, q *WriteOptions) (*ACLAuthMethod, *WriteMeta, error) {
+	if method == nil || method.Name == "" {
+		return nil, nil, fmt.Errorf("missing auth method name")
+	}
+	var resp ACLAuthMethod
+	wm, err := a.client.write("/v1/acl/auth-method/"+method.Name, method, &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, wm, nil
+}
+
+// Delete is used to delete an auth method and its binding rules
+func (a *ACLAuthMethods) Delete(methodName string, q *WriteOptions) (*WriteMeta, error) {
+	if methodName == "" {
+		return nil, fmt.Errorf("missing auth method name")
+	}
+	wm, err := a.client.delete("/v1/acl/auth-method/"+methodName, nil, q)
+	if err != nil {
+		return nil, err
+	}
+	return wm, nil
+}
+
+// Info is used to query a specific auth method
+func (a *ACLAuthMethods) Info(methodName string, q *QueryOptions) (*ACLAuthMethod, *QueryMeta, error) {
+	if methodName == "" {
+		return nil, nil, fmt.Errorf("missing auth method name")
+	}
+	var resp ACLAuthMethod
+	qm, err := a.client.query("/v1/acl/auth-method/"+methodName, &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, qm, nil
+}
+
+// Login exchanges a JWT issued by the identity provider of a JWT auth method
+// for a short-lived ACL token.
+func (a *ACLAuthMethods) Login(req *ACLLoginRequest, q *WriteOptions) (*ACLToken, *WriteMeta, error) {
+	if req == nil || req.AuthMethodName == "" {
+		return nil, nil, fmt.Errorf("missing auth method name")
+	}
+	var resp ACLToken
+	wm, err := a.client.write("/v1/acl/login", req, &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, wm, nil
+}
+
+// GetAuthURL returns the URL of the OIDC provider of an OIDC auth method the
+// user authenticates at.
+func (a *ACLAuthMethods) GetAuthURL(req *ACLOIDCAuthURLRequest, q *WriteOptions) (string, *WriteMeta, error) {
+	if req == nil || req.AuthMethodName == "" {
+		return "", nil, fmt.Errorf("missing auth method name")
+	}
+	var resp ACLOIDCAuthURLResponse
+	wm, err := a.client.write("/v1/acl/oidc/auth-url", req, &resp, q)
+	if err != nil {
+		return "", nil, err
+	}
+	return resp.AuthURL, wm, nil
+}
+
+// CompleteAuth exchanges the authorization code the OIDC provider redirected
+// the user with for a short-lived ACL token.
+func (a *ACLAuthMethods) CompleteAuth(req *ACLOIDCCompleteAuthRequest, q *WriteOptions) (*ACLToken, *WriteMeta, error) {
+	if req == nil || req.AuthMethodName == "" {
+		return nil, nil, fmt.Errorf("missing auth method name")
+	}
+	var resp ACLToken
+	wm, err := a.client.write("/v1/acl/oidc/complete-auth", req, &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, wm, nil
+}
+
+const (
+	// ACLAuthMethodTypeJWT is the type of auth methods that verify JWTs
+	// issued by an identity provider.
+	ACLAuthMethodTypeJWT = "JWT"
+
+	// ACLAuthMethodTypeOIDC is the type of auth methods that log users in
+	// through the authorization code flow of an OIDC provider.
+	ACLAuthMethodTypeOIDC = "OIDC"
+)
+
+// ACLAuthMethod configures how identities of an identity provider can log in
+// to Nomad to obtain an ACL token.
+type ACLAuthMethod struct {
+	Name          string
+	Type          string
+	TokenLocality string
+	MaxTokenTTL   time.Duration
+	Config        *ACLAuthMethodConfig
+	CreateIndex   uint64
+	ModifyIndex   uint64
+}
+
+// ACLAuthMethodConfig is the identity provider specific configuration of an
+// auth method.
+type ACLAuthMethodConfig struct {
+	OIDCDiscoveryURL    string
+	OIDCClientID        string
+	OIDCClientSecret    string
+	OIDCScopes          []string
+	AllowedRedirectURIs []string
+	JWKSURL             string
+	BoundIssuer         []string
+	BoundAudiences      []string
+	SigningAlgs         []string
+	ClockSkewLeeway     time.Duration
+	ClaimMappings       map[string]string
+	ListClaimMappings   map[string]string
+}
+
+// ACLAuthMethodListStub is used to for listing ACL auth methods
+type ACLAuthMethodListStub struct {
+	Name        string
+	Type        string
+	CreateIndex uint64
+	ModifyIndex uint64
+}
+
+// ACLLoginRequest is used to log in with a JWT auth method.
+type ACLLoginRequest struct {
+	AuthMethodName string
+	LoginToken     string
+}
+
+// ACLOIDCAuthURLRequest is used to start the login with an OIDC auth method.
+// The state and nonce are generated by the caller and must be presented
+// again to complete the login.
+type ACLOIDCAuthURLRequest struct {
+	AuthMethodName string
+	RedirectURI    string
+	State          string
+	Nonce          string
+}
+
+// ACLOIDCAuthURLResponse is the response of GetAuthURL
+type ACLOIDCAuthURLResponse struct {
+	AuthURL string
+}
+
+// ACLOIDCCompleteAuthRequest is used to complete the login with an OIDC auth
+// method.
+type ACLOIDCCompleteAuthRequest struct {
+	AuthMethodName string
+	RedirectURI    string
+	Code           string
+	Nonce          string
+}
+
+// ACLBindingRules is used to query the ACL binding rule endpoints.
+type ACLBindingRules struct {
+	client *Client
+}
+
+// ACLBindingRules returns a new handle on the ACL binding rules.
+func (c *Client) ACLBindingRules() *ACLBindingRules {
+	return &ACLBindingRules{client: c}
+}
+
+// List is used to dump all of the binding rules.
+func (a *ACLBindingRules) List(q *QueryOptions) ([]*ACLBindingRuleListStub, *QueryMeta, error) {
+	var resp []*ACLBindingRuleListStub
+	qm, err := a.client.query("/v1/acl/binding-rules", &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp, qm, nil
+}
+
+// Create is used to create a binding rule
+func (a *ACLBindingRules) Create(rule *ACLBindingRule, q *WriteOptions) (*ACLBindingRule, *WriteMeta, error) {
+	if rule.ID != "" {
+		return nil, nil, fmt.Errorf("cannot specify ID")
+	}
+	var resp ACLBindingRule
+	wm, err := a.client.write("/v1/acl/binding-rule", rule, &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, wm, nil
+}
+
+// Update is used to update an existing binding rule
+func (a *ACLBindingRules) Update(rule *ACLBindingRule, q *WriteOptions) (*ACLBindingRule, *WriteMeta, error) {
+	if rule.ID == "" {
+		return nil, nil, fmt.Errorf("missing binding rule ID")
+	}
+	var resp ACLBindingRule
+	wm, err := a.client.write("/v1/acl/binding-rule/"+rule.ID, rule, &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, wm, nil
+}
+
+// Delete is used to delete a binding rule
+func (a *ACLBindingRules) Delete(ruleID string, q *WriteOptions) (*WriteMeta, error) {
+	if ruleID == "" {
+		return nil, fmt.Errorf("missing binding rule ID")
+	}
+	wm, err := a.client.delete("/v1/acl/binding-rule/"+ruleID, nil, q)
+	if err != nil {
+		return nil, err
+	}
+	return wm, nil
+}
+
+// Info is used to query a binding rule by ID
+func (a *ACLBindingRules) Info(ruleID string, q *QueryOptions) (*ACLBindingRule, *QueryMeta, error) {
+	if ruleID == "" {
+		return nil, nil, fmt.Errorf("missing binding rule ID")
+	}
+	var resp ACLBindingRule
+	qm, err := a.client.query("/v1/acl/binding-rule/"+ruleID, &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, qm, nil
+}
+
+// ACLBindingRule grants identities of an auth method whose claims match the
+// selector the policy or role named by BindName.
+type ACLBindingRule struct {
+	ID          string
+	Description string
+	AuthMethod  string
+	Selector    string
+	BindType    string
+	BindName    string
+	CreateIndex uint64
+	ModifyIndex uint64
+}
+
+// ACLBindingRuleListStub is used to for listing ACL binding rules
+type ACLBindingRuleListStub struct {
+	ID          string
+	Description string
+	AuthMethod  string
+	BindType    string
+	BindName    string
+	CreateIndex uint64
+	ModifyIndex uint64
 }
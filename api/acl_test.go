@@ -2,8 +2,10 @@ package api
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestACLPolicies_ListUpsert(t *testing.T) {
@@ -235,3 +237,151 @@ func TestACLTokens_Delete(t *testing.T) {
 	assert.Nil(t, err)
 	assertWriteMeta(t, wm)
 }
+
+func TestACLRoles_CRUD(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	c, s, _ := makeACLClient(t, nil, nil)
+	defer s.Stop()
+	ar := c.ACLRoles()
+
+	// Register the policy of the role
+	policy := &ACLPolicy{
+		Name: "test",
+		Rules: `namespace "default" {
+			policy = "read"
+		}
+		`,
+	}
+	_, err := c.ACLPolicies().Upsert(policy, nil)
+	require.NoError(err)
+
+	// Create the role
+	role := &ACLRole{
+		Name:     "test-role",
+		Policies: []*ACLRolePolicyLink{{Name: policy.Name}},
+	}
+	created, wm, err := ar.Create(role, nil)
+	require.NoError(err)
+	assertWriteMeta(t, wm)
+	require.NotEmpty(created.ID)
+	require.Equal(role.Name, created.Name)
+
+	// List the roles
+	result, qm, err := ar.List(nil)
+	require.NoError(err)
+	assertQueryMeta(t, qm)
+	require.Len(result, 1)
+
+	// Update the role
+	created.Description = "updated"
+	updated, _, err := ar.Update(created, nil)
+	require.NoError(err)
+	require.Equal("updated", updated.Description)
+
+	// Query the role by ID and by name
+	out, qm, err := ar.Info(created.ID, nil)
+	require.NoError(err)
+	assertQueryMeta(t, qm)
+	require.Equal(updated, out)
+
+	out, _, err = ar.InfoByName(created.Name, nil)
+	require.NoError(err)
+	require.Equal(updated, out)
+
+	// Create a token linked to the role by name
+	token, _, err := c.ACLTokens().Create(&ACLToken{
+		Type:  "client",
+		Roles: []*ACLTokenRoleLink{{Name: created.Name}},
+	}, nil)
+	require.NoError(err)
+	require.Equal([]*ACLTokenRoleLink{{ID: created.ID, Name: created.Name}}, token.Roles)
+
+	// Delete the role
+	wm, err = ar.Delete(created.ID, nil)
+	require.NoError(err)
+	assertWriteMeta(t, wm)
+
+	result, _, err = ar.List(nil)
+	require.NoError(err)
+	require.Empty(result)
+}
+
+func TestACLAuthMethods_CRUD(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	c, s, _ := makeACLClient(t, nil, nil)
+	defer s.Stop()
+	am := c.ACLAuthMethods()
+	br := c.ACLBindingRules()
+
+	// Create the auth method
+	method := &ACLAuthMethod{
+		Name:          "example-idp",
+		Type:          ACLAuthMethodTypeJWT,
+		TokenLocality: "local",
+		MaxTokenTTL:   time.Hour,
+		Config: &ACLAuthMethodConfig{
+			JWKSURL:           "https://idp.example.com/jwks",
+			BoundAudiences:    []string{"nomad"},
+			ListClaimMappings: map[string]string{"groups": "groups"},
+		},
+	}
+	created, wm, err := am.Upsert(method, nil)
+	require.NoError(err)
+	assertWriteMeta(t, wm)
+	require.Equal(method.Name, created.Name)
+	require.Equal(time.Hour, created.MaxTokenTTL)
+
+	// List the auth methods
+	methods, qm, err := am.List(nil)
+	require.NoError(err)
+	assertQueryMeta(t, qm)
+	require.Len(methods, 1)
+	require.Equal(ACLAuthMethodTypeJWT, methods[0].Type)
+
+	// Query the auth method
+	out, qm, err := am.Info(method.Name, nil)
+	require.NoError(err)
+	assertQueryMeta(t, qm)
+	require.Equal(created, out)
+
+	// Create a binding rule
+	rule := &ACLBindingRule{
+		AuthMethod: method.Name,
+		Selector:   `"engineering" in list.groups`,
+		BindType:   "policy",
+		BindName:   "engineering",
+	}
+	createdRule, wm, err := br.Create(rule, nil)
+	require.NoError(err)
+	assertWriteMeta(t, wm)
+	require.NotEmpty(createdRule.ID)
+
+	// Update the binding rule
+	createdRule.Description = "updated"
+	updatedRule, _, err := br.Update(createdRule, nil)
+	require.NoError(err)
+	require.Equal("updated", updatedRule.Description)
+
+	rules, qm, err := br.List(nil)
+	require.NoError(err)
+	assertQueryMeta(t, qm)
+	require.Len(rules, 1)
+
+	outRule, _, err := br.Info(createdRule.ID, nil)
+	require.NoError(err)
+	require.Equal(updatedRule, outRule)
+
+	// Deleting the auth method deletes its binding rules
+	wm, err = am.Delete(method.Name, nil)
+	require.NoError(err)
+	assertWriteMeta(t, wm)
+
+	methods, _, err = am.List(nil)
+	require.NoError(err)
+	require.Empty(methods)
+	rules, _, err = br.List(nil)
+	require.NoError(err)
+	require.Empty(rules)
+}
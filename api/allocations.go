@@ -394,6 +394,7 @@ type AllocationMetric struct {
 	AllocationTime    time.Duration
 	CoalescedFailures int
 	ScoreMetaData     []*NodeScoreMeta
+	NodeExplanations  map[string]*NodeExplanation
 }
 
 // NodeScoreMeta is used to serialize node scoring metadata
@@ -404,6 +405,17 @@ type NodeScoreMeta struct {
 	NormScore float64
 }
 
+// NodeExplanation describes why a node was filtered, exhausted or how it was
+// scored when explaining a job plan.
+type NodeExplanation struct {
+	NodeID    string
+	NodeName  string
+	Filtered  string
+	Exhausted string
+	Scores    map[string]float64
+	NormScore float64
+}
+
 // AllocationListStub is used to return a subset of an allocation
 // during list operations.
 type AllocationListStub struct {
@@ -460,11 +472,13 @@ type AllocatedSharedResources struct {
 }
 
 type AllocatedCpuResources struct {
-	CpuShares int64
+	CpuShares     int64
+	ReservedCores []uint16
 }
 
 type AllocatedMemoryResources struct {
-	MemoryMB int64
+	MemoryMB    int64
+	MemoryMaxMB int64
 }
 
 // AllocIndexSort reverse sorts allocs by CreateIndex.
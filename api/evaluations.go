@@ -30,6 +30,30 @@ func (e *Evaluations) PrefixList(prefix string) ([]*Evaluation, *QueryMeta, erro
 	return e.List(&QueryOptions{Prefix: prefix})
 }
 
+// Blocked is used to list the evaluations blocked waiting for cluster
+// capacity, the longest waiting first.
+func (e *Evaluations) Blocked(q *QueryOptions) ([]*BlockedEvaluation, *QueryMeta, error) {
+	var resp []*BlockedEvaluation
+	qm, err := e.client.query("/v1/evaluations/blocked", &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp, qm, nil
+}
+
+// Delete is used to delete the evaluations with the given IDs. The eval broker
+// must be paused through the scheduler configuration to delete evaluations.
+func (e *Evaluations) Delete(evalIDs []string, w *WriteOptions) (*WriteMeta, error) {
+	req := &EvalDeleteRequest{
+		EvalIDs: evalIDs,
+	}
+	wm, err := e.client.deleteWithBody("/v1/evaluations", req, nil, w)
+	if err != nil {
+		return nil, err
+	}
+	return wm, nil
+}
+
 // Info is used to query a single evaluation by its ID.
 func (e *Evaluations) Info(evalID string, q *QueryOptions) (*Evaluation, *QueryMeta, error) {
 	var resp Evaluation
@@ -84,6 +108,32 @@ type Evaluation struct {
 	ModifyTime           int64
 }
 
+// EvalDeleteRequest is used to delete evaluations.
+type EvalDeleteRequest struct {
+	EvalIDs []string
+	WriteRequest
+}
+
+// BlockedEvaluation is used to serialize an evaluation blocked waiting for
+// cluster capacity.
+type BlockedEvaluation struct {
+	ID                   string
+	Namespace            string
+	JobID                string
+	Type                 string
+	TriggeredBy          string
+	Priority             int
+	PreviousEval         string
+	FailedTGAllocs       map[string]*AllocationMetric
+	ClassEligibility     map[string]bool
+	EscapedComputedClass bool
+	QuotaLimitReached    string
+	CreateIndex          uint64
+	ModifyIndex          uint64
+	CreateTime           int64
+	ModifyTime           int64
+}
+
 // EvalIndexSort is a wrapper to sort evaluations by CreateIndex.
 // We reverse the test so that we get the highest index first.
 type EvalIndexSort []*Evaluation
@@ -0,0 +1,185 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// Topic is the category of objects an Event is about.
+type Topic string
+
+const (
+	TopicDeployment Topic = "Deployment"
+	TopicEvaluation Topic = "Evaluation"
+	TopicAllocation Topic = "Allocation"
+	TopicJob        Topic = "Job"
+	TopicNode       Topic = "Node"
+	TopicAll        Topic = "*"
+)
+
+// Events are the events published by a Raft index.
+type Events struct {
+	Index  uint64
+	Events []Event
+}
+
+// IsHeartbeat returns whether the message is a heartbeat sent by the server to
+// keep the stream open.
+func (e *Events) IsHeartbeat() bool {
+	return e.Index == 0 && len(e.Events) == 0
+}
+
+// Event is a change to the state of the cluster.
+type Event struct {
+	Topic      Topic
+	Type       string
+	Key        string
+	Namespace  string
+	FilterKeys []string
+	Index      uint64
+	Payload    map[string]interface{}
+}
+
+// Job returns the job of an event of TopicJob.
+func (e *Event) Job() (*Job, error) {
+	var payload struct{ Job *Job }
+	if err := e.decodePayload(TopicJob, &payload); err != nil {
+		return nil, err
+	}
+	return payload.Job, nil
+}
+
+// Evaluation returns the evaluation of an event of TopicEvaluation.
+func (e *Event) Evaluation() (*Evaluation, error) {
+	var payload struct{ Evaluation *Evaluation }
+	if err := e.decodePayload(TopicEvaluation, &payload); err != nil {
+		return nil, err
+	}
+	return payload.Evaluation, nil
+}
+
+// Allocation returns the allocation of an event of TopicAllocation. The job of
+// the allocation is not set.
+func (e *Event) Allocation() (*Allocation, error) {
+	var payload struct{ Allocation *Allocation }
+	if err := e.decodePayload(TopicAllocation, &payload); err != nil {
+		return nil, err
+	}
+	return payload.Allocation, nil
+}
+
+// Deployment returns the deployment of an event of TopicDeployment.
+func (e *Event) Deployment() (*Deployment, error) {
+	var payload struct{ Deployment *Deployment }
+	if err := e.decodePayload(TopicDeployment, &payload); err != nil {
+		return nil, err
+	}
+	return payload.Deployment, nil
+}
+
+// Node returns the node of an event of TopicNode.
+func (e *Event) Node() (*Node, error) {
+	var payload struct{ Node *Node }
+	if err := e.decodePayload(TopicNode, &payload); err != nil {
+		return nil, err
+	}
+	return payload.Node, nil
+}
+
+func (e *Event) decodePayload(topic Topic, out interface{}) error {
+	if e.Topic != topic {
+		return fmt.Errorf("event of topic %q has no %s payload", e.Topic, topic)
+	}
+
+	buf, err := json.Marshal(e.Payload)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(buf, out)
+}
+
+// EventStream is used to stream the events of the cluster.
+type EventStream struct {
+	client *Client
+}
+
+// EventStream returns a handle to the event stream endpoint.
+func (c *Client) EventStream() *EventStream {
+	return &EventStream{client: c}
+}
+
+// Stream streams the events of the given topics, mapped to the keys of the
+// objects to receive the events of. The "*" key matches every object of a
+// topic and every event is streamed when no topic is given. When index is not
+// zero, the events published after this index that are still buffered by the
+// server are streamed first. The stream ends when stopCh is closed or an error
+// occurs.
+func (e *EventStream) Stream(stopCh <-chan struct{}, topics map[Topic][]string, index uint64, q *QueryOptions) (<-chan *Events, <-chan error) {
+	errCh := make(chan error, 1)
+	r, err := e.client.newRequest("GET", "/v1/event/stream")
+	if err != nil {
+		errCh <- err
+		return nil, errCh
+	}
+
+	r.setQueryOptions(q)
+	r.params.Del("index")
+	if index != 0 {
+		r.params.Set("index", strconv.FormatUint(index, 10))
+	}
+	for topic, keys := range topics {
+		for _, key := range keys {
+			r.params.Add("topic", fmt.Sprintf("%s:%s", topic, key))
+		}
+	}
+
+	_, resp, err := requireOK(e.client.doRequest(r))
+	if err != nil {
+		errCh <- err
+		return nil, errCh
+	}
+
+	eventsCh := make(chan *Events, 10)
+	go func() {
+		defer resp.Body.Close()
+		defer close(eventsCh)
+
+		// Unblock the decoder when the stream is stopped
+		doneCh := make(chan struct{})
+		defer close(doneCh)
+		go func() {
+			select {
+			case <-stopCh:
+				resp.Body.Close()
+			case <-doneCh:
+			}
+		}()
+
+		dec := json.NewDecoder(resp.Body)
+		for {
+			var events Events
+			if err := dec.Decode(&events); err != nil {
+				select {
+				case <-stopCh:
+				default:
+					errCh <- err
+				}
+				return
+			}
+
+			// Discard heartbeats
+			if events.IsHeartbeat() {
+				continue
+			}
+
+			select {
+			case eventsCh <- &events:
+			case <-stopCh:
+				return
+			}
+		}
+	}()
+
+	return eventsCh, errCh
+}
@@ -279,6 +279,7 @@ func (j *Jobs) PeriodicForce(jobID string, q *WriteOptions) (string, *WriteMeta,
 type PlanOptions struct {
 	Diff           bool
 	PolicyOverride bool
+	Explain        bool
 }
 
 func (j *Jobs) Plan(job *Job, diff bool, q *WriteOptions) (*JobPlanResponse, *WriteMeta, error) {
@@ -298,6 +299,7 @@ func (j *Jobs) PlanOpts(job *Job, opts *PlanOptions, q *WriteOptions) (*JobPlanR
 	if opts != nil {
 		req.Diff = opts.Diff
 		req.PolicyOverride = opts.PolicyOverride
+		req.Explain = opts.Explain
 	}
 
 	var resp JobPlanResponse
@@ -369,6 +371,44 @@ func (j *Jobs) Stable(jobID string, version uint64, stable bool,
 	return &resp, wm, nil
 }
 
+// Scale is used to set the count of a task group of a job and record the
+// scaling event. If count is nil, only the scaling event is recorded.
+func (j *Jobs) Scale(jobID, group string, count *int, message string, isError bool, meta map[string]interface{},
+	q *WriteOptions) (*JobRegisterResponse, *WriteMeta, error) {
+
+	var count64 *int64
+	if count != nil {
+		count64 = int64ToPtr(int64(*count))
+	}
+	req := &ScalingRequest{
+		Count: count64,
+		Target: map[string]string{
+			"Job":   jobID,
+			"Group": group,
+		},
+		Error:   isError,
+		Message: message,
+		Meta:    meta,
+	}
+	var resp JobRegisterResponse
+	qm, err := j.client.write("/v1/job/"+url.PathEscape(jobID)+"/scale", req, &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, qm, nil
+}
+
+// ScaleStatus is used to retrieve the scaling status of the task groups of
+// a job
+func (j *Jobs) ScaleStatus(jobID string, q *QueryOptions) (*JobScaleStatusResponse, *QueryMeta, error) {
+	var resp JobScaleStatusResponse
+	qm, err := j.client.query("/v1/job/"+url.PathEscape(jobID)+"/scale", &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, qm, nil
+}
+
 // periodicForceResponse is used to deserialize a force response
 type periodicForceResponse struct {
 	EvalID string
@@ -648,37 +688,39 @@ type ParameterizedJobConfig struct {
 
 // Job is used to serialize a job.
 type Job struct {
-	Stop              *bool
-	Region            *string
-	Namespace         *string
-	ID                *string
-	ParentID          *string
-	Name              *string
-	Type              *string
-	Priority          *int
-	AllAtOnce         *bool `mapstructure:"all_at_once"`
-	Datacenters       []string
-	Constraints       []*Constraint
-	Affinities        []*Affinity
-	TaskGroups        []*TaskGroup
-	Update            *UpdateStrategy
-	Spreads           []*Spread
-	Periodic          *PeriodicConfig
-	ParameterizedJob  *ParameterizedJobConfig
-	Dispatched        bool
-	Payload           []byte
-	Reschedule        *ReschedulePolicy
-	Migrate           *MigrateStrategy
-	Meta              map[string]string
-	VaultToken        *string `mapstructure:"vault_token"`
-	Status            *string
-	StatusDescription *string
-	Stable            *bool
-	Version           *uint64
-	SubmitTime        *int64
-	CreateIndex       *uint64
-	ModifyIndex       *uint64
-	JobModifyIndex    *uint64
+	Stop                  *bool
+	Region                *string
+	Namespace             *string
+	ID                    *string
+	ParentID              *string
+	Name                  *string
+	Type                  *string
+	Priority              *int
+	AllAtOnce             *bool `mapstructure:"all_at_once"`
+	Datacenters           []string
+	Constraints           []*Constraint
+	Affinities            []*Affinity
+	TaskGroups            []*TaskGroup
+	Update                *UpdateStrategy
+	Spreads               []*Spread
+	DatacenterPreferences []*DatacenterPreference
+	SchedulerAlgorithm    SchedulerAlgorithm `mapstructure:"scheduler_algorithm"`
+	Periodic              *PeriodicConfig
+	ParameterizedJob      *ParameterizedJobConfig
+	Dispatched            bool
+	Payload               []byte
+	Reschedule            *ReschedulePolicy
+	Migrate               *MigrateStrategy
+	Meta                  map[string]string
+	VaultToken            *string `mapstructure:"vault_token"`
+	Status                *string
+	StatusDescription     *string
+	Stable                *bool
+	Version               *uint64
+	SubmitTime            *int64
+	CreateIndex           *uint64
+	ModifyIndex           *uint64
+	JobModifyIndex        *uint64
 }
 
 // IsPeriodic returns whether a job is periodic.
@@ -765,6 +807,9 @@ func (j *Job) Canonicalize() {
 	for _, a := range j.Affinities {
 		a.Canonicalize()
 	}
+	for _, p := range j.DatacenterPreferences {
+		p.Canonicalize()
+	}
 }
 
 // LookupTaskGroup finds a task group by name
@@ -813,6 +858,7 @@ type TaskGroupSummary struct {
 	Running  int
 	Starting int
 	Lost     int
+	Unknown  int
 }
 
 // JobListStub is used to return a subset of information about
@@ -920,6 +966,12 @@ func (j *Job) AddSpread(s *Spread) *Job {
 	return j
 }
 
+// AddDatacenterPreference is used to add a datacenter preference to a job.
+func (j *Job) AddDatacenterPreference(p *DatacenterPreference) *Job {
+	j.DatacenterPreferences = append(j.DatacenterPreferences, p)
+	return j
+}
+
 type WriteRequest struct {
 	// The target region for this write
 	Region string
@@ -1021,6 +1073,7 @@ type JobPlanRequest struct {
 	Job            *Job
 	Diff           bool
 	PolicyOverride bool
+	Explain        bool
 	WriteRequest
 }
 
@@ -1030,6 +1083,7 @@ type JobPlanResponse struct {
 	Diff               *JobDiff
 	Annotations        *PlanAnnotations
 	FailedTGAllocs     map[string]*AllocationMetric
+	Explanations       map[string]*AllocationMetric
 	NextPeriodicLaunch time.Time
 
 	// Warnings contains any warnings about the given job. These may include
@@ -1336,6 +1336,39 @@ func TestJobs_JobSummary(t *testing.T) {
 	}
 }
 
+func TestJobs_ScaleAction(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	c, s := makeClient(t, nil, nil)
+	defer s.Stop()
+	jobs := c.Jobs()
+
+	job := testJob()
+	job.Type = stringToPtr(JobTypeService)
+	groupName := *job.TaskGroups[0].Name
+	_, _, err := jobs.Register(job, nil)
+	require.NoError(err)
+
+	// Scale the task group
+	resp, wm, err := jobs.Scale(*job.ID, groupName, intToPtr(3), "need more", false, nil, nil)
+	require.NoError(err)
+	assertWriteMeta(t, wm)
+	require.NotEmpty(resp.EvalID)
+
+	out, _, err := jobs.Info(*job.ID, nil)
+	require.NoError(err)
+	require.Equal(3, *out.TaskGroups[0].Count)
+
+	// Check the scaling status
+	status, qm, err := jobs.ScaleStatus(*job.ID, nil)
+	require.NoError(err)
+	assertQueryMeta(t, qm)
+	require.Equal(3, status.TaskGroups[groupName].Desired)
+	require.Len(status.TaskGroups[groupName].Events, 1)
+	require.Equal("need more", status.TaskGroups[groupName].Events[0].Message)
+}
+
 func TestJobs_NewBatchJob(t *testing.T) {
 	t.Parallel()
 	job := NewBatchJob("job1", "myjob", "global", 5)
@@ -479,7 +479,8 @@ type NodeResources struct {
 }
 
 type NodeCpuResources struct {
-	CpuShares int64
+	CpuShares          int64
+	ReservableCpuCores []uint16
 }
 
 type NodeMemoryResources struct {
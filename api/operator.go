@@ -1,6 +1,14 @@
 package api
 
-import "strconv"
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"hash"
+	"io"
+	"strconv"
+	"strings"
+)
 
 // Operator can be used to perform low-level operator tasks for Nomad.
 type Operator struct {
@@ -109,11 +117,30 @@ func (op *Operator) RaftRemovePeerByID(id string, q *WriteOptions) error {
 	return nil
 }
 
+// SchedulerAlgorithm is an enum string that encapsulates the valid options
+// for the scheduler algorithm.
+type SchedulerAlgorithm string
+
+const (
+	SchedulerAlgorithmBinpack SchedulerAlgorithm = "binpack"
+	SchedulerAlgorithmSpread  SchedulerAlgorithm = "spread"
+)
+
 type SchedulerConfiguration struct {
+	// SchedulerAlgorithm lets you select between available scheduling algorithms.
+	SchedulerAlgorithm SchedulerAlgorithm
+
 	// PreemptionConfig specifies whether to enable eviction of lower
 	// priority jobs to place higher priority jobs.
 	PreemptionConfig PreemptionConfig
 
+	// MemoryOversubscriptionEnabled specifies whether tasks may set a
+	// memory_max limit above the memory reserved for them.
+	MemoryOversubscriptionEnabled bool
+
+	// PauseEvalBroker stops the leader from processing evaluations.
+	PauseEvalBroker bool
+
 	// CreateIndex/ModifyIndex store the create/modify indexes of this configuration.
 	CreateIndex uint64
 	ModifyIndex uint64
@@ -176,3 +203,141 @@ func (op *Operator) SchedulerCASConfiguration(conf *SchedulerConfiguration, q *W
 
 	return &out, wm, nil
 }
+
+// SchedulerRebalanceRequest is used to compute a plan of allocation migrations
+// that reduces the fragmentation of the cluster and optionally apply it.
+type SchedulerRebalanceRequest struct {
+	// DryRun computes the rebalance plan without applying it.
+	DryRun bool
+
+	// MaxMigrations is the maximum number of allocations the plan migrates.
+	// The server default is used if it is zero.
+	MaxMigrations int
+}
+
+// RebalanceMigration is a proposed migration of an allocation that is part
+// of a rebalance plan.
+type RebalanceMigration struct {
+	AllocID          string
+	AllocName        string
+	Namespace        string
+	JobID            string
+	TaskGroup        string
+	SourceNodeID     string
+	TargetNodeID     string
+	ScoreImprovement float64
+}
+
+// SchedulerRebalanceResponse is the response object used when rebalancing
+// the cluster.
+type SchedulerRebalanceResponse struct {
+	// Migrations is the rebalance plan.
+	Migrations []*RebalanceMigration
+
+	// EvalIDs are the IDs of the evaluations created to carry out the
+	// migrations. It is empty for dry runs.
+	EvalIDs []string
+
+	WriteMeta
+}
+
+// SchedulerRebalance is used to compute a plan of allocation migrations that
+// reduces the fragmentation of the cluster, and apply it unless the request
+// is a dry run.
+func (op *Operator) SchedulerRebalance(req *SchedulerRebalanceRequest, q *WriteOptions) (*SchedulerRebalanceResponse, *WriteMeta, error) {
+	var out SchedulerRebalanceResponse
+	wm, err := op.c.write("/v1/operator/scheduler/rebalance", req, &out, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &out, wm, nil
+}
+
+// Snapshot is used to capture a snapshot of the cluster state. The returned
+// reader verifies the snapshot against the checksum sent by the server and
+// returns an error from Read if they don't match. The caller must close the
+// returned reader.
+func (op *Operator) Snapshot(q *QueryOptions) (io.ReadCloser, error) {
+	r, err := op.c.newRequest("GET", "/v1/operator/snapshot")
+	if err != nil {
+		return nil, err
+	}
+	r.setQueryOptions(q)
+	_, resp, err := requireOK(op.c.doRequest(r))
+	if err != nil {
+		return nil, err
+	}
+
+	digest := resp.Header.Get("Digest")
+	cr, err := newChecksumValidatingReader(resp.Body, digest)
+	if err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+
+	return cr, nil
+}
+
+// SnapshotRestore is used to restore the cluster state from a snapshot
+// previously captured with Snapshot.
+func (op *Operator) SnapshotRestore(in io.Reader, q *WriteOptions) (*WriteMeta, error) {
+	r, err := op.c.newRequest("PUT", "/v1/operator/snapshot")
+	if err != nil {
+		return nil, err
+	}
+	r.setWriteOptions(q)
+	r.body = in
+	rtt, resp, err := requireOK(op.c.doRequest(r))
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+
+	wm := &WriteMeta{RequestTime: rtt}
+	parseWriteMeta(resp, wm)
+	return wm, nil
+}
+
+// checksumValidatingReader is an io.ReadCloser that computes the checksum of
+// the data read and compares it against the expected checksum once the
+// underlying reader is exhausted.
+type checksumValidatingReader struct {
+	r        io.ReadCloser
+	hash     hash.Hash
+	checksum string
+}
+
+// newChecksumValidatingReader returns a reader that validates the data read
+// against the given digest, of the form "sha-256=<base64 encoded sum>".
+func newChecksumValidatingReader(r io.ReadCloser, digest string) (io.ReadCloser, error) {
+	parts := strings.SplitN(digest, "=", 2)
+	if len(parts) != 2 || parts[0] != "sha-256" {
+		return nil, fmt.Errorf("unsupported snapshot digest %q", digest)
+	}
+
+	return &checksumValidatingReader{
+		r:        r,
+		hash:     sha256.New(),
+		checksum: parts[1],
+	}, nil
+}
+
+func (r *checksumValidatingReader) Read(b []byte) (int, error) {
+	n, err := r.r.Read(b)
+	if n > 0 {
+		r.hash.Write(b[:n])
+	}
+
+	if err == io.EOF {
+		actual := base64.StdEncoding.EncodeToString(r.hash.Sum(nil))
+		if actual != r.checksum {
+			return n, fmt.Errorf("snapshot checksum mismatch: expected %q but found %q", r.checksum, actual)
+		}
+	}
+
+	return n, err
+}
+
+func (r *checksumValidatingReader) Close() error {
+	return r.r.Close()
+}
@@ -1,8 +1,15 @@
 package api
 
 import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"io"
+	"io/ioutil"
 	"strings"
 	"testing"
+
+	"github.com/stretchr/testify/require"
 )
 
 func TestOperator_RaftGetConfiguration(t *testing.T) {
@@ -51,3 +58,49 @@ func TestOperator_RaftRemovePeerByID(t *testing.T) {
 		t.Fatalf("err: %v", err)
 	}
 }
+
+func TestOperator_Snapshot(t *testing.T) {
+	t.Parallel()
+	c, s := makeClient(t, nil, nil)
+	defer s.Stop()
+
+	operator := c.Operator()
+
+	// Take a snapshot and read it all out so the checksum is validated.
+	snap, err := operator.Snapshot(nil)
+	require.NoError(t, err)
+	var archive bytes.Buffer
+	_, err = io.Copy(&archive, snap)
+	require.NoError(t, err)
+	require.NoError(t, snap.Close())
+	require.NotZero(t, archive.Len())
+
+	// Restore the snapshot back into the cluster.
+	_, err = operator.SnapshotRestore(&archive, nil)
+	require.NoError(t, err)
+}
+
+func TestOperator_checksumValidatingReader(t *testing.T) {
+	t.Parallel()
+	data := []byte("snapshot data")
+	sum := sha256.Sum256(data)
+	digest := "sha-256=" + base64.StdEncoding.EncodeToString(sum[:])
+
+	// A matching checksum reads cleanly.
+	r, err := newChecksumValidatingReader(ioutil.NopCloser(bytes.NewReader(data)), digest)
+	require.NoError(t, err)
+	out, err := ioutil.ReadAll(r)
+	require.NoError(t, err)
+	require.Equal(t, data, out)
+
+	// A mismatched checksum errors once the data is exhausted.
+	r, err = newChecksumValidatingReader(ioutil.NopCloser(bytes.NewReader([]byte("corrupt"))), digest)
+	require.NoError(t, err)
+	_, err = ioutil.ReadAll(r)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "checksum mismatch")
+
+	// Unsupported digests are rejected.
+	_, err = newChecksumValidatingReader(ioutil.NopCloser(bytes.NewReader(data)), "md5=abc")
+	require.Error(t, err)
+}
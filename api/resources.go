@@ -7,11 +7,13 @@ import (
 // Resources encapsulates the required resources of
 // a given task or task group.
 type Resources struct {
-	CPU      *int
-	MemoryMB *int `mapstructure:"memory"`
-	DiskMB   *int `mapstructure:"disk"`
-	Networks []*NetworkResource
-	Devices  []*RequestedDevice
+	CPU         *int
+	Cores       *int
+	MemoryMB    *int `mapstructure:"memory"`
+	MemoryMaxMB *int `mapstructure:"memory_max"`
+	DiskMB      *int `mapstructure:"disk"`
+	Networks    []*NetworkResource
+	Devices     []*RequestedDevice
 
 	// COMPAT(0.10)
 	// XXX Deprecated. Please do not use. The field will be removed in Nomad
@@ -24,7 +26,8 @@ type Resources struct {
 // where they are not provided.
 func (r *Resources) Canonicalize() {
 	defaultResources := DefaultResources()
-	if r.CPU == nil {
+	// Only set the default CPU if the task doesn't reserve cores
+	if r.CPU == nil && r.Cores == nil {
 		r.CPU = defaultResources.CPU
 	}
 	if r.MemoryMB == nil {
@@ -69,9 +72,15 @@ func (r *Resources) Merge(other *Resources) {
 	if other.CPU != nil {
 		r.CPU = other.CPU
 	}
+	if other.Cores != nil {
+		r.Cores = other.Cores
+	}
 	if other.MemoryMB != nil {
 		r.MemoryMB = other.MemoryMB
 	}
+	if other.MemoryMaxMB != nil {
+		r.MemoryMaxMB = other.MemoryMaxMB
+	}
 	if other.DiskMB != nil {
 		r.DiskMB = other.DiskMB
 	}
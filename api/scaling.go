@@ -0,0 +1,106 @@
+package api
+
+// Scaling is used to query scaling-related API endpoints
+type Scaling struct {
+	client *Client
+}
+
+// Scaling returns a handle on the scaling endpoints.
+func (c *Client) Scaling() *Scaling {
+	return &Scaling{client: c}
+}
+
+// ListPolicies is used to list the scaling policies of the jobs in the
+// namespace.
+func (s *Scaling) ListPolicies(q *QueryOptions) ([]*ScalingPolicyListStub, *QueryMeta, error) {
+	var resp []*ScalingPolicyListStub
+	qm, err := s.client.query("/v1/scaling/policies", &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp, qm, nil
+}
+
+// GetPolicy is used to query a specific scaling policy.
+func (s *Scaling) GetPolicy(ID string, q *QueryOptions) (*ScalingPolicy, *QueryMeta, error) {
+	var policy ScalingPolicy
+	qm, err := s.client.query("/v1/scaling/policy/"+ID, &policy, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &policy, qm, nil
+}
+
+// ScalingPolicy is the user-specified API object for an autoscaling policy
+type ScalingPolicy struct {
+	ID          string
+	Target      map[string]string
+	Policy      map[string]interface{}
+	Min         *int64
+	Max         int64
+	Enabled     *bool
+	CreateIndex uint64
+	ModifyIndex uint64
+}
+
+// Canonicalize sets the defaults of the scaling policy of a task group with
+// the given count.
+func (p *ScalingPolicy) Canonicalize(count int) {
+	if p.Enabled == nil {
+		p.Enabled = boolToPtr(true)
+	}
+	if p.Min == nil {
+		p.Min = int64ToPtr(int64(count))
+	}
+}
+
+// ScalingPolicyListStub is used to return a subset of scaling policy
+// information for the scaling policy list
+type ScalingPolicyListStub struct {
+	ID          string
+	Enabled     bool
+	Target      map[string]string
+	CreateIndex uint64
+	ModifyIndex uint64
+}
+
+// ScalingRequest is the payload for a generic scaling action
+type ScalingRequest struct {
+	Count          *int64
+	Target         map[string]string
+	Message        string
+	Error          bool
+	Meta           map[string]interface{}
+	PolicyOverride bool
+	WriteRequest
+}
+
+// ScalingEvent is a scaling event recorded against a task group
+type ScalingEvent struct {
+	Time        int64
+	Count       *int64
+	Message     string
+	Error       bool
+	Meta        map[string]interface{}
+	EvalID      *string
+	CreateIndex uint64
+}
+
+// JobScaleStatusResponse is the scaling status of the task groups of a job
+type JobScaleStatusResponse struct {
+	JobID          string
+	JobCreateIndex uint64
+	JobModifyIndex uint64
+	JobStopped     bool
+	TaskGroups     map[string]TaskGroupScaleStatus
+}
+
+// TaskGroupScaleStatus is the scaling status of a task group
+type TaskGroupScaleStatus struct {
+	Desired   int
+	Placed    int
+	Running   int
+	Healthy   int
+	Unhealthy int
+	Events    []ScalingEvent
+}
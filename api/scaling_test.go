@@ -0,0 +1,77 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestScalingPolicies_ListPolicies(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	c, s := makeClient(t, nil, nil)
+	defer s.Stop()
+	scaling := c.Scaling()
+	jobs := c.Jobs()
+
+	// Check that we don't have any scaling policies before registering a job
+	policies, _, err := scaling.ListPolicies(nil)
+	require.NoError(err)
+	require.Empty(policies)
+
+	// Register a job with a scaling policy
+	job := testJob()
+	job.Type = stringToPtr(JobTypeService)
+	job.TaskGroups[0].Scaling = &ScalingPolicy{
+		Max: 100,
+	}
+	_, _, err = jobs.Register(job, nil)
+	require.NoError(err)
+
+	// Check that we have a scaling policy now
+	policies, _, err = scaling.ListPolicies(nil)
+	require.NoError(err)
+	require.Len(policies, 1)
+
+	policy := policies[0]
+	require.NotEmpty(policy.ID)
+	require.True(policy.Enabled)
+	require.Equal(*job.ID, policy.Target["Job"])
+	require.Equal(*job.TaskGroups[0].Name, policy.Target["Group"])
+}
+
+func TestScalingPolicies_GetPolicy(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	c, s := makeClient(t, nil, nil)
+	defer s.Stop()
+	scaling := c.Scaling()
+	jobs := c.Jobs()
+
+	// Register a job with a scaling policy
+	job := testJob()
+	job.Type = stringToPtr(JobTypeService)
+	job.TaskGroups[0].Scaling = &ScalingPolicy{
+		Min:    int64ToPtr(1),
+		Max:    100,
+		Policy: map[string]interface{}{"key": "value"},
+	}
+	_, _, err := jobs.Register(job, nil)
+	require.NoError(err)
+
+	policies, _, err := scaling.ListPolicies(nil)
+	require.NoError(err)
+	require.Len(policies, 1)
+
+	// Query the policy
+	policy, qm, err := scaling.GetPolicy(policies[0].ID, nil)
+	require.NoError(err)
+	assertQueryMeta(t, qm)
+	require.Equal(policies[0].ID, policy.ID)
+	require.EqualValues(1, *policy.Min)
+	require.EqualValues(100, policy.Max)
+	require.True(*policy.Enabled)
+	require.Equal("value", policy.Policy["key"])
+}
@@ -245,6 +245,7 @@ type Spread struct {
 	Attribute    string
 	Weight       *int8
 	SpreadTarget []*SpreadTarget
+	Nested       *Spread
 }
 
 // SpreadTarget is used to serialize target allocation spread percentages
@@ -274,6 +275,26 @@ func (s *Spread) Canonicalize() {
 	}
 }
 
+// DatacenterPreference is used to serialize the preference for placing
+// allocations in a datacenter
+type DatacenterPreference struct {
+	Datacenter string
+	Weight     *int8
+}
+
+func NewDatacenterPreference(datacenter string, weight int8) *DatacenterPreference {
+	return &DatacenterPreference{
+		Datacenter: datacenter,
+		Weight:     int8ToPtr(weight),
+	}
+}
+
+func (p *DatacenterPreference) Canonicalize() {
+	if p.Weight == nil {
+		p.Weight = int8ToPtr(50)
+	}
+}
+
 // EphemeralDisk is an ephemeral disk object
 type EphemeralDisk struct {
 	Sticky  *bool
@@ -396,21 +417,24 @@ func (vm *VolumeMount) Canonicalize() {
 
 // TaskGroup is the unit of scheduling.
 type TaskGroup struct {
-	Name             *string
-	Count            *int
-	Constraints      []*Constraint
-	Affinities       []*Affinity
-	Tasks            []*Task
-	Spreads          []*Spread
-	Volumes          map[string]*VolumeRequest
-	RestartPolicy    *RestartPolicy
-	ReschedulePolicy *ReschedulePolicy
-	EphemeralDisk    *EphemeralDisk
-	Update           *UpdateStrategy
-	Migrate          *MigrateStrategy
-	Networks         []*NetworkResource
-	Meta             map[string]string
-	Services         []*Service
+	Name                  *string
+	Count                 *int
+	Constraints           []*Constraint
+	Affinities            []*Affinity
+	Tasks                 []*Task
+	Spreads               []*Spread
+	DatacenterPreferences []*DatacenterPreference
+	Volumes               map[string]*VolumeRequest
+	RestartPolicy         *RestartPolicy
+	ReschedulePolicy      *ReschedulePolicy
+	EphemeralDisk         *EphemeralDisk
+	Update                *UpdateStrategy
+	Migrate               *MigrateStrategy
+	Networks              []*NetworkResource
+	Meta                  map[string]string
+	Services              []*Service
+	MaxClientDisconnect   *time.Duration `mapstructure:"max_client_disconnect"`
+	Scaling               *ScalingPolicy
 }
 
 // NewTaskGroup creates a new TaskGroup.
@@ -488,6 +512,10 @@ func (g *TaskGroup) Canonicalize(job *Job) {
 		g.Migrate.Canonicalize()
 	}
 
+	if g.Scaling != nil {
+		g.Scaling.Canonicalize(*g.Count)
+	}
+
 	var defaultRestartPolicy *RestartPolicy
 	switch *job.Type {
 	case "service", "system":
@@ -521,6 +549,9 @@ func (g *TaskGroup) Canonicalize(job *Job) {
 	for _, a := range g.Affinities {
 		a.Canonicalize()
 	}
+	for _, p := range g.DatacenterPreferences {
+		p.Canonicalize()
+	}
 	for _, n := range g.Networks {
 		n.Canonicalize()
 	}
@@ -568,6 +599,13 @@ func (g *TaskGroup) AddSpread(s *Spread) *TaskGroup {
 	return g
 }
 
+// AddDatacenterPreference is used to add a new datacenter preference to a
+// task group.
+func (g *TaskGroup) AddDatacenterPreference(p *DatacenterPreference) *TaskGroup {
+	g.DatacenterPreferences = append(g.DatacenterPreferences, p)
+	return g
+}
+
 // LogConfig provides configuration for log rotation
 type LogConfig struct {
 	MaxFiles      *int `mapstructure:"max_files"`
@@ -595,6 +633,18 @@ type DispatchPayloadConfig struct {
 	File string
 }
 
+// TaskLifecycle configures when a task runs relative to the main tasks of its
+// group.
+type TaskLifecycle struct {
+	Hook    string `mapstructure:"hook"`
+	Sidecar bool   `mapstructure:"sidecar"`
+}
+
+// Empty returns true if the lifecycle has no user provided values.
+func (l *TaskLifecycle) Empty() bool {
+	return l == nil || l.Hook == ""
+}
+
 // Task is a single process in a task group.
 type Task struct {
 	Name            string
@@ -618,6 +668,7 @@ type Task struct {
 	ShutdownDelay   time.Duration `mapstructure:"shutdown_delay"`
 	KillSignal      string        `mapstructure:"kill_signal"`
 	Kind            string
+	Lifecycle       *TaskLifecycle
 }
 
 func (t *Task) Canonicalize(tg *TaskGroup, job *Job) {
@@ -642,6 +693,9 @@ func (t *Task) Canonicalize(tg *TaskGroup, job *Job) {
 	for _, tmpl := range t.Templates {
 		tmpl.Canonicalize()
 	}
+	if t.Lifecycle.Empty() {
+		t.Lifecycle = nil
+	}
 	for _, s := range t.Services {
 		s.Canonicalize(t, tg, job)
 	}
@@ -842,6 +896,7 @@ const (
 	TaskSignaling              = "Signaling"
 	TaskRestartSignal          = "Restart Signaled"
 	TaskLeaderDead             = "Leader Task Dead"
+	TaskMainDead               = "Main Tasks Dead"
 	TaskBuildingTaskDir        = "Building Task Directory"
 )
 
@@ -83,11 +83,6 @@ func testQuotaSpec() *QuotaSpec {
 // conversions utils only used for testing
 // added here to avoid linter warning
 
-// int64ToPtr returns the pointer to an int
-func int64ToPtr(i int64) *int64 {
-	return &i
-}
-
 // float64ToPtr returns the pointer to an float64
 func float64ToPtr(f float64) *float64 {
 	return &f
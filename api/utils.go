@@ -21,6 +21,11 @@ func intToPtr(i int) *int {
 	return &i
 }
 
+// int64ToPtr returns the pointer to an int64
+func int64ToPtr(i int64) *int64 {
+	return &i
+}
+
 // uint64ToPtr returns the pointer to an uint64
 func uint64ToPtr(u uint64) *uint64 {
 	return &u
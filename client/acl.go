@@ -14,6 +14,10 @@ const (
 	// so we keep the hot policies cached to reduce the ACL token resolution time.
 	policyCacheSize = 64
 
+	// roleCacheSize is the number of ACL roles to keep cached. Roles have a fetching cost
+	// so we keep the hot roles cached to reduce the ACL token resolution time.
+	roleCacheSize = 64
+
 	// aclCacheSize is the number of ACL objects to keep cached. ACLs have a parsing and
 	// construction cost, so we keep the hot objects cached to reduce the ACL token resolution time.
 	aclCacheSize = 64
@@ -32,6 +36,9 @@ type clientACLResolver struct {
 	// policyCache is used to maintain the fetched policy objects
 	policyCache *lru.TwoQueueCache
 
+	// roleCache is used to maintain the fetched role objects
+	roleCache *lru.TwoQueueCache
+
 	// tokenCache is used to maintain the fetched token objects
 	tokenCache *lru.TwoQueueCache
 }
@@ -48,6 +55,10 @@ func (c *clientACLResolver) init() error {
 	if err != nil {
 		return err
 	}
+	c.roleCache, err = lru.New2Q(roleCacheSize)
+	if err != nil {
+		return err
+	}
 	c.tokenCache, err = lru.New2Q(tokenCacheSize)
 	if err != nil {
 		return err
@@ -55,10 +66,11 @@ func (c *clientACLResolver) init() error {
 	return nil
 }
 
-// cachedACLValue is used to manage ACL Token or Policy TTLs
+// cachedACLValue is used to manage ACL Token, Policy or Role TTLs
 type cachedACLValue struct {
 	Token     *structs.ACLToken
 	Policy    *structs.ACLPolicy
+	Role      *structs.ACLRole
 	CacheTime time.Time
 }
 
@@ -89,14 +101,21 @@ func (c *Client) resolveTokenAndACL(secretID string) (*acl.ACL, *structs.ACLToke
 	if token == nil {
 		return nil, nil, structs.ErrTokenNotFound
 	}
+	if token.IsExpired(time.Now().UTC()) {
+		return nil, nil, structs.ErrTokenExpired
+	}
 
 	// Check if this is a management token
 	if token.Type == structs.ACLManagementToken {
 		return acl.ManagementACL, token, nil
 	}
 
-	// Resolve the policies
-	policies, err := c.resolvePolicies(token.SecretID, token.Policies)
+	// Resolve the policies, including those of the roles
+	policyNames, err := c.resolveTokenPolicyNames(token)
+	if err != nil {
+		return nil, nil, err
+	}
+	policies, err := c.resolvePolicies(token.SecretID, policyNames)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -222,3 +241,107 @@ func (c *Client) resolvePolicies(secretID string, policies []string) ([]*structs
 	// Return the valid policies
 	return out, nil
 }
+
+// resolveTokenPolicyNames returns the names of the policies granted to a
+// token, either directly or through its roles.
+func (c *Client) resolveTokenPolicyNames(token *structs.ACLToken) ([]string, error) {
+	if len(token.Roles) == 0 {
+		return token.Policies, nil
+	}
+
+	roleIDs := make([]string, 0, len(token.Roles))
+	for _, link := range token.Roles {
+		roleIDs = append(roleIDs, link.ID)
+	}
+	roles, err := c.resolveRoles(token.SecretID, roleIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]struct{}, len(token.Policies))
+	var names []string
+	add := func(name string) {
+		if _, ok := seen[name]; !ok {
+			seen[name] = struct{}{}
+			names = append(names, name)
+		}
+	}
+	for _, policyName := range token.Policies {
+		add(policyName)
+	}
+	for _, role := range roles {
+		for _, policy := range role.Policies {
+			add(policy.Name)
+		}
+	}
+	return names, nil
+}
+
+// resolveRoles is used to translate a set of ACL role IDs into the objects.
+// Roles are cached and refreshed like policies, sharing their TTL. If a
+// server cannot be reached, the cache TTL will be ignored to gracefully
+// handle outages.
+func (c *Client) resolveRoles(secretID string, roleIDs []string) ([]*structs.ACLRole, error) {
+	var out []*structs.ACLRole
+	var expired []*structs.ACLRole
+	var missing []string
+
+	// Scan the cache for each role
+	for _, roleID := range roleIDs {
+		// Lookup the role in the cache
+		raw, ok := c.roleCache.Get(roleID)
+		if !ok {
+			missing = append(missing, roleID)
+			continue
+		}
+
+		// Check if the cached value is valid or expired
+		cached := raw.(*cachedACLValue)
+		if cached.Age() <= c.config.ACLPolicyTTL {
+			out = append(out, cached.Role)
+		} else {
+			expired = append(expired, cached.Role)
+		}
+	}
+
+	// Hot-path if we have no missing or expired roles
+	if len(missing)+len(expired) == 0 {
+		return out, nil
+	}
+
+	// Lookup the missing and expired roles
+	fetch := missing
+	for _, r := range expired {
+		fetch = append(fetch, r.ID)
+	}
+	req := structs.ACLRoleSetRequest{
+		RoleIDs: fetch,
+		QueryOptions: structs.QueryOptions{
+			Region:     c.Region(),
+			AuthToken:  secretID,
+			AllowStale: true,
+		},
+	}
+	var resp structs.ACLRoleSetResponse
+	if err := c.RPC("ACL.GetRolesByID", &req, &resp); err != nil {
+		// If we encounter an error but have cached roles, mask the error and extend the cache
+		if len(missing) == 0 {
+			c.logger.Warn("failed to resolve roles, using expired cached value", "error", err)
+			out = append(out, expired...)
+			return out, nil
+		}
+		return nil, err
+	}
+
+	// Handle each output
+	for _, role := range resp.Roles {
+		c.roleCache.Add(role.ID, &cachedACLValue{
+			Role:      role,
+			CacheTime: time.Now(),
+		})
+		out = append(out, role)
+	}
+
+	// Return the valid roles
+	return out, nil
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/testutil"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestClient_ACL_resolveTokenValue(t *testing.T) {
@@ -101,6 +102,46 @@ func TestClient_ACL_resolvePolicies(t *testing.T) {
 	}
 }
 
+func TestClient_ACL_ResolveToken_Roles(t *testing.T) {
+	s1, _, _, cleanupS1 := testACLServer(t, nil)
+	defer cleanupS1()
+	testutil.WaitForLeader(t, s1.RPC)
+
+	c1, cleanup := TestClient(t, func(c *config.Config) {
+		c.RPCHandler = s1
+		c.ACLEnabled = true
+	})
+	defer cleanup()
+
+	// Create a token granted a policy through a role
+	policy := mock.ACLPolicy()
+	policy.Rules = `node { policy = "read" }`
+	require.NoError(t, s1.State().UpsertACLPolicies(100, []*structs.ACLPolicy{policy}))
+	role := mock.ACLRole()
+	role.Policies = []*structs.ACLRolePolicyLink{{Name: policy.Name}}
+	require.NoError(t, s1.State().UpsertACLRoles(110, []*structs.ACLRole{role}))
+	token := mock.ACLToken()
+	token.Policies = nil
+	token.Roles = []*structs.ACLTokenRoleLink{{ID: role.ID, Name: role.Name}}
+	require.NoError(t, s1.State().UpsertACLTokens(120, []*structs.ACLToken{token}))
+
+	// Test the client resolution
+	out, err := c1.ResolveToken(token.SecretID)
+	require.NoError(t, err)
+	require.True(t, out.AllowNodeRead())
+	require.False(t, out.AllowNodeWrite())
+
+	// Test caching
+	roles, err := c1.resolveRoles(token.SecretID, []string{role.ID})
+	require.NoError(t, err)
+	require.Len(t, roles, 1)
+	roles2, err := c1.resolveRoles(token.SecretID, []string{role.ID})
+	require.NoError(t, err)
+	if roles[0] != roles2[0] {
+		t.Fatalf("bad caching")
+	}
+}
+
 func TestClient_ACL_ResolveToken_Disabled(t *testing.T) {
 	s1, _, cleanupS1 := testServer(t, nil)
 	defer cleanupS1()
@@ -37,8 +37,11 @@ func (a *Allocations) GarbageCollectAll(args *nstructs.NodeSpecificRequest, repl
 	// Check node write permissions
 	if aclObj, err := a.c.ResolveToken(args.AuthToken); err != nil {
 		return err
-	} else if aclObj != nil && !aclObj.AllowNodeWrite() {
-		return nstructs.ErrPermissionDenied
+	} else if aclObj != nil {
+		node := a.c.Node()
+		if !aclObj.AllowNodeWriteFor(node.NodeClass, node.Datacenter, node.Meta) {
+			return nstructs.ErrPermissionDenied
+		}
 	}
 
 	a.c.CollectAllAllocs()
@@ -58,6 +58,10 @@ var (
 	// directory
 	TaskSecrets = "secrets"
 
+	// TaskIdentityFile is the name of the file holding the workload identity
+	// token of the task inside its secrets directory
+	TaskIdentityFile = "nomad_token"
+
 	// TaskDirs is the set of directories created in each tasks directory.
 	TaskDirs = map[string]os.FileMode{TmpDirName: os.ModeSticky | 0777}
 
@@ -78,6 +78,10 @@ type Tracker struct {
 	// taskHealth contains the health state for each task
 	taskHealth map[string]*taskHealthState
 
+	// ephemeralTasks is the set of lifecycle tasks that are not expected to
+	// be running for the allocation to be healthy
+	ephemeralTasks map[string]struct{}
+
 	logger hclog.Logger
 }
 
@@ -104,8 +108,13 @@ func NewTracker(parentCtx context.Context, logger hclog.Logger, alloc *structs.A
 	}
 
 	t.taskHealth = make(map[string]*taskHealthState, len(t.tg.Tasks))
+	t.ephemeralTasks = make(map[string]struct{})
 	for _, task := range t.tg.Tasks {
 		t.taskHealth[task.Name] = &taskHealthState{task: task}
+
+		if isEphemeralTask(task) {
+			t.ephemeralTasks[task.Name] = struct{}{}
+		}
 	}
 
 	for _, task := range t.tg.Tasks {
@@ -249,9 +258,20 @@ func (t *Tracker) watchTaskEvents() {
 
 		// Detect if the alloc is unhealthy or if all tasks have started yet
 		latestStartTime := time.Time{}
-		for _, state := range alloc.TaskStates {
+		for taskName, state := range alloc.TaskStates {
 			// One of the tasks has failed so we can exit watching
-			if state.Failed || !state.FinishedAt.IsZero() {
+			if state.Failed {
+				t.setTaskHealth(false, true)
+				return
+			}
+
+			// Ephemeral lifecycle tasks may be pending or completed
+			if _, ok := t.ephemeralTasks[taskName]; ok {
+				continue
+			}
+
+			// One of the tasks has exited so we can exit watching
+			if !state.FinishedAt.IsZero() {
 				t.setTaskHealth(false, true)
 				return
 			}
@@ -440,6 +460,9 @@ func (t *taskHealthState) event(deadline time.Time, minHealthyTime time.Duration
 		if t.state.Failed {
 			return "Unhealthy because of failed task", true
 		}
+		if isEphemeralTask(t.task) {
+			return "", false
+		}
 		if t.state.State != structs.TaskStateRunning {
 			return "Task not running by deadline", true
 		}
@@ -480,3 +503,9 @@ func (t *taskHealthState) event(deadline time.Time, minHealthyTime time.Duration
 
 	return "", false
 }
+
+// isEphemeralTask returns true if the task is a lifecycle task that is not
+// expected to keep running alongside the main tasks.
+func isEphemeralTask(task *structs.Task) bool {
+	return task.Lifecycle != nil && !task.Lifecycle.Sidecar
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/hashicorp/nomad/client/consul"
 	"github.com/hashicorp/nomad/client/devicemanager"
 	cinterfaces "github.com/hashicorp/nomad/client/interfaces"
+	"github.com/hashicorp/nomad/client/lib/cgutil"
 	"github.com/hashicorp/nomad/client/pluginmanager/drivermanager"
 	cstate "github.com/hashicorp/nomad/client/state"
 	cstructs "github.com/hashicorp/nomad/client/structs"
@@ -138,10 +139,18 @@ type allocRunner struct {
 	// event handlers
 	driverManager drivermanager.Manager
 
+	// cpusetManager is used to lookup the cores of the node that aren't
+	// reserved by an allocation
+	cpusetManager cgutil.CpusetManager
+
 	// serversContactedCh is passed to TaskRunners so they can detect when
 	// servers have been contacted for the first time in case of a failed
 	// restore.
 	serversContactedCh chan struct{}
+
+	// taskHookCoordinator is used to gate the start of task runners
+	// according to their lifecycle hooks.
+	taskHookCoordinator *taskHookCoordinator
 }
 
 // NewAllocRunner returns a new allocation runner.
@@ -173,6 +182,7 @@ func NewAllocRunner(config *Config) (*allocRunner, error) {
 		prevAllocMigrator:        config.PrevAllocMigrator,
 		devicemanager:            config.DeviceManager,
 		driverManager:            config.DriverManager,
+		cpusetManager:            config.CpusetManager,
 		serversContactedCh:       config.ServersContactedCh,
 	}
 
@@ -185,6 +195,8 @@ func NewAllocRunner(config *Config) (*allocRunner, error) {
 	// Create alloc dir
 	ar.allocDir = allocdir.NewAllocDir(ar.logger, filepath.Join(config.ClientConfig.AllocDir, alloc.ID))
 
+	ar.taskHookCoordinator = newTaskHookCoordinator(ar.logger, tg.Tasks)
+
 	// Initialize the runners hooks.
 	if err := ar.initRunnerHooks(config.ClientConfig); err != nil {
 		return nil, err
@@ -202,19 +214,21 @@ func NewAllocRunner(config *Config) (*allocRunner, error) {
 func (ar *allocRunner) initTaskRunners(tasks []*structs.Task) error {
 	for _, task := range tasks {
 		config := &taskrunner.Config{
-			Alloc:               ar.alloc,
-			ClientConfig:        ar.clientConfig,
-			Task:                task,
-			TaskDir:             ar.allocDir.NewTaskDir(task.Name),
-			Logger:              ar.logger,
-			StateDB:             ar.stateDB,
-			StateUpdater:        ar,
-			Consul:              ar.consulClient,
-			Vault:               ar.vaultClient,
-			DeviceStatsReporter: ar.deviceStatsReporter,
-			DeviceManager:       ar.devicemanager,
-			DriverManager:       ar.driverManager,
-			ServersContactedCh:  ar.serversContactedCh,
+			Alloc:                ar.alloc,
+			ClientConfig:         ar.clientConfig,
+			Task:                 task,
+			TaskDir:              ar.allocDir.NewTaskDir(task.Name),
+			Logger:               ar.logger,
+			StateDB:              ar.stateDB,
+			StateUpdater:         ar,
+			Consul:               ar.consulClient,
+			Vault:                ar.vaultClient,
+			DeviceStatsReporter:  ar.deviceStatsReporter,
+			DeviceManager:        ar.devicemanager,
+			DriverManager:        ar.driverManager,
+			CpusetManager:        ar.cpusetManager,
+			ServersContactedCh:   ar.serversContactedCh,
+			StartConditionMetCtx: ar.taskHookCoordinator.startConditionForTask(task),
 		}
 
 		// Create, but do not Run, the task runner
@@ -352,12 +366,17 @@ func (ar *allocRunner) Restore() error {
 	ar.stateLock.Unlock()
 
 	// Restore task runners
-	for _, tr := range ar.tasks {
+	states := make(map[string]*structs.TaskState, len(ar.tasks))
+	for name, tr := range ar.tasks {
 		if err := tr.Restore(); err != nil {
 			return err
 		}
+		states[name] = tr.TaskState()
 	}
 
+	// Unblock lifecycle phases that had already started
+	ar.taskHookCoordinator.taskStateUpdated(states)
+
 	return nil
 }
 
@@ -431,6 +450,12 @@ func (ar *allocRunner) handleTaskStateUpdates() {
 			state := tr.TaskState()
 			states[name] = state
 
+			// Poststop tasks are run once the others have stopped so
+			// they neither trigger nor receive kills
+			if tr.IsPoststopTask() {
+				continue
+			}
+
 			// Capture live task runners in case we need to kill them
 			if state.State != structs.TaskStateDead {
 				liveRunners = append(liveRunners, tr)
@@ -453,12 +478,22 @@ func (ar *allocRunner) handleTaskStateUpdates() {
 			}
 		}
 
+		// If only sidecars are left running, the main tasks have
+		// completed and the sidecars should be stopped
+		mainDead := false
+		if killEvent == nil && len(liveRunners) > 0 && hasSidecarTasks(ar.tasks) && !hasNonSidecarTasks(liveRunners) {
+			killEvent = structs.NewTaskEvent(structs.TaskMainDead)
+			mainDead = true
+		}
+
 		// If there's a kill event set and live runners, kill them
 		if killEvent != nil && len(liveRunners) > 0 {
 
 			// Log kill reason
 			if leaderFailed {
 				ar.logger.Debug("leader task dead, destroying all tasks", "leader_task", killTask)
+			} else if mainDead {
+				ar.logger.Debug("main tasks dead, destroying all sidecar tasks")
 			} else {
 				ar.logger.Debug("task failure, destroying all tasks", "failed_task", killTask)
 			}
@@ -482,6 +517,9 @@ func (ar *allocRunner) handleTaskStateUpdates() {
 			}
 		}
 
+		// Unblock task runners whose lifecycle start condition is met
+		ar.taskHookCoordinator.taskStateUpdated(states)
+
 		// Get the client allocation
 		calloc := ar.clientAlloc(states)
 
@@ -493,9 +531,10 @@ func (ar *allocRunner) handleTaskStateUpdates() {
 	}
 }
 
-// killTasks kills all task runners, leader (if there is one) first. Errors are
-// logged except taskrunner.ErrTaskNotRunning which is ignored. Task states
-// after Kill has been called are returned.
+// killTasks kills all task runners, leader (if there is one) first. Poststop
+// tasks are not killed as they are run once the other tasks have stopped.
+// Errors are logged except taskrunner.ErrTaskNotRunning which is ignored. Task
+// states after Kill has been called are returned.
 func (ar *allocRunner) killTasks() map[string]*structs.TaskState {
 	var mu sync.Mutex
 	states := make(map[string]*structs.TaskState, len(ar.tasks))
@@ -525,6 +564,14 @@ func (ar *allocRunner) killTasks() map[string]*structs.TaskState {
 			continue
 		}
 
+		if tr.IsPoststopTask() {
+			state := tr.TaskState()
+			mu.Lock()
+			states[name] = state
+			mu.Unlock()
+			continue
+		}
+
 		wg.Add(1)
 		go func(name string, tr *taskrunner.TaskRunner) {
 			defer wg.Done()
@@ -745,6 +792,11 @@ func (ar *allocRunner) handleAllocUpdate(update *structs.Allocation) {
 		ar.killTasks()
 	}
 
+	// If the servers marked the alloc as unknown while the client was
+	// disconnected, resend its state
+	if !update.TerminalStatus() && update.ClientStatus == structs.AllocClientStatusUnknown {
+		ar.TaskStateUpdated()
+	}
 }
 
 func (ar *allocRunner) Listener() *cstructs.AllocListener {
@@ -141,6 +141,154 @@ func TestAllocRunner_TaskLeader_KillTG(t *testing.T) {
 	})
 }
 
+// TestAllocRunner_Lifecycle_Prestart asserts that ephemeral prestart tasks
+// complete before the main task starts and that sidecars are stopped once
+// the main task exits.
+func TestAllocRunner_Lifecycle_Prestart(t *testing.T) {
+	t.Parallel()
+
+	alloc := mock.LifecycleAlloc()
+
+	mainTask := alloc.Job.TaskGroups[0].Tasks[0]
+	mainTask.Config["run_for"] = "100ms"
+
+	sideTask := alloc.Job.TaskGroups[0].Tasks[1]
+	sideTask.KillTimeout = 10 * time.Millisecond
+	sideTask.Config["run_for"] = "100s"
+
+	initTask := alloc.Job.TaskGroups[0].Tasks[2]
+	initTask.Config["run_for"] = "100ms"
+
+	conf, cleanup := testAllocRunnerConfig(t, alloc)
+	defer cleanup()
+	ar, err := NewAllocRunner(conf)
+	require.NoError(t, err)
+	defer destroy(ar)
+	go ar.Run()
+
+	upd := conf.StateUpdater.(*MockStateUpdater)
+	testutil.WaitForResult(func() (bool, error) {
+		last := upd.Last()
+		if last == nil {
+			return false, fmt.Errorf("No updates")
+		}
+		if last.ClientStatus != structs.AllocClientStatusComplete {
+			return false, fmt.Errorf("got status %v; want %v", last.ClientStatus, structs.AllocClientStatusComplete)
+		}
+
+		for _, name := range []string{mainTask.Name, sideTask.Name, initTask.Name} {
+			state := last.TaskStates[name]
+			if state.State != structs.TaskStateDead {
+				return false, fmt.Errorf("task %q: got state %v; want %v", name, state.State, structs.TaskStateDead)
+			}
+			if state.Failed {
+				return false, fmt.Errorf("task %q failed", name)
+			}
+		}
+
+		// The main task must have started after the init task completed
+		mainState := last.TaskStates[mainTask.Name]
+		initState := last.TaskStates[initTask.Name]
+		if mainState.StartedAt.Before(initState.FinishedAt) {
+			return false, fmt.Errorf("main task started at %v before init task finished at %v",
+				mainState.StartedAt, initState.FinishedAt)
+		}
+
+		// The sidecar must have been killed because the main task exited
+		found := false
+		for _, e := range last.TaskStates[sideTask.Name].Events {
+			if e.Type == structs.TaskMainDead {
+				found = true
+			}
+		}
+		if !found {
+			return false, fmt.Errorf("Did not find event %v", structs.TaskMainDead)
+		}
+
+		return true, nil
+	}, func(err error) {
+		t.Fatalf("err: %v", err)
+	})
+}
+
+// TestAllocRunner_Lifecycle_Poststop_StopAlloc asserts that poststop tasks are
+// run once the main tasks are killed because the alloc was stopped.
+func TestAllocRunner_Lifecycle_Poststop_StopAlloc(t *testing.T) {
+	t.Parallel()
+
+	alloc := mock.Alloc()
+	tr := alloc.AllocatedResources.Tasks[alloc.Job.TaskGroups[0].Tasks[0].Name]
+	alloc.Job.Type = structs.JobTypeBatch
+
+	mainTask := alloc.Job.TaskGroups[0].Tasks[0]
+	mainTask.Driver = "mock_driver"
+	mainTask.Config = map[string]interface{}{
+		"run_for": "100s",
+	}
+
+	postTask := mainTask.Copy()
+	postTask.Name = "post"
+	postTask.Lifecycle = &structs.TaskLifecycleConfig{
+		Hook: structs.TaskLifecycleHookPoststop,
+	}
+	postTask.Config = map[string]interface{}{
+		"run_for": "10ms",
+	}
+	alloc.Job.TaskGroups[0].Tasks = append(alloc.Job.TaskGroups[0].Tasks, postTask)
+	alloc.AllocatedResources.Tasks[postTask.Name] = tr
+
+	conf, cleanup := testAllocRunnerConfig(t, alloc)
+	defer cleanup()
+	ar, err := NewAllocRunner(conf)
+	require.NoError(t, err)
+	defer destroy(ar)
+	go ar.Run()
+
+	// Wait for the main task to start
+	upd := conf.StateUpdater.(*MockStateUpdater)
+	testutil.WaitForResult(func() (bool, error) {
+		last := upd.Last()
+		if last == nil {
+			return false, fmt.Errorf("No updates")
+		}
+		if state := last.TaskStates[mainTask.Name]; state == nil || state.State != structs.TaskStateRunning {
+			return false, fmt.Errorf("main task is not running yet")
+		}
+		return true, nil
+	}, func(err error) {
+		t.Fatalf("err: %v", err)
+	})
+
+	// Stop alloc
+	update := alloc.Copy()
+	update.DesiredStatus = structs.AllocDesiredStatusStop
+	ar.Update(update)
+
+	// Wait for the poststop task to run to completion
+	testutil.WaitForResult(func() (bool, error) {
+		last := upd.Last()
+		if last == nil {
+			return false, fmt.Errorf("No updates")
+		}
+		state := last.TaskStates[postTask.Name]
+		if state == nil || state.State != structs.TaskStateDead {
+			return false, fmt.Errorf("poststop task is not dead yet")
+		}
+		if state.Failed {
+			return false, fmt.Errorf("poststop task failed")
+		}
+		if state.StartedAt.IsZero() {
+			return false, fmt.Errorf("poststop task never started")
+		}
+		if state.StartedAt.Before(last.TaskStates[mainTask.Name].FinishedAt) {
+			return false, fmt.Errorf("poststop task started before the main task finished")
+		}
+		return true, nil
+	}, func(err error) {
+		t.Fatalf("err: %v", err)
+	})
+}
+
 // TestAllocRunner_TaskLeader_StopTG asserts that when stopping an alloc with a
 // leader the leader is stopped before other tasks.
 func TestAllocRunner_TaskLeader_StopTG(t *testing.T) {
@@ -7,6 +7,7 @@ import (
 	"github.com/hashicorp/nomad/client/consul"
 	"github.com/hashicorp/nomad/client/devicemanager"
 	"github.com/hashicorp/nomad/client/interfaces"
+	"github.com/hashicorp/nomad/client/lib/cgutil"
 	"github.com/hashicorp/nomad/client/pluginmanager/drivermanager"
 	cstate "github.com/hashicorp/nomad/client/state"
 	"github.com/hashicorp/nomad/client/vaultclient"
@@ -52,6 +53,9 @@ type Config struct {
 	// DriverManager handles dispensing of driver plugins
 	DriverManager drivermanager.Manager
 
+	// CpusetManager tracks the cores reserved by the allocations of the node
+	CpusetManager cgutil.CpusetManager
+
 	// ServersContactedCh is closed when the first GetClientAllocs call to
 	// servers succeeds and allocs are synced.
 	ServersContactedCh chan struct{}
@@ -0,0 +1,200 @@
+package allocrunner
+
+import (
+	"context"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/client/allocrunner/taskrunner"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// taskHookCoordinator helps coordinate when tasks of an allocation can be
+// started according to their lifecycle hooks:
+//
+//  * prestart tasks are started immediately
+//  * main tasks are started once all prestart sidecars are running and all
+//    ephemeral prestart tasks have completed successfully
+//  * poststart tasks are started once all main tasks are running
+//  * poststop tasks are started once all main tasks have stopped
+type taskHookCoordinator struct {
+	logger hclog.Logger
+
+	// closedCh is a closed channel used to start prestart tasks immediately
+	closedCh chan struct{}
+
+	// Each context gates the task runners of a lifecycle phase. A task
+	// runner waits until the context of its phase is cancelled.
+	mainTaskCtx            context.Context
+	mainTaskCtxCancel      context.CancelFunc
+	poststartTaskCtx       context.Context
+	poststartTaskCtxCancel context.CancelFunc
+	poststopTaskCtx        context.Context
+	poststopTaskCtxCancel  context.CancelFunc
+
+	prestartSidecar   map[string]struct{}
+	prestartEphemeral map[string]struct{}
+	mainTasksPending  map[string]struct{}
+	mainTasksRunning  map[string]struct{}
+}
+
+func newTaskHookCoordinator(logger hclog.Logger, tasks []*structs.Task) *taskHookCoordinator {
+	closedCh := make(chan struct{})
+	close(closedCh)
+
+	mainTaskCtx, mainCancelFn := context.WithCancel(context.Background())
+	poststartTaskCtx, poststartCancelFn := context.WithCancel(context.Background())
+	poststopTaskCtx, poststopCancelFn := context.WithCancel(context.Background())
+
+	c := &taskHookCoordinator{
+		logger:                 logger,
+		closedCh:               closedCh,
+		mainTaskCtx:            mainTaskCtx,
+		mainTaskCtxCancel:      mainCancelFn,
+		poststartTaskCtx:       poststartTaskCtx,
+		poststartTaskCtxCancel: poststartCancelFn,
+		poststopTaskCtx:        poststopTaskCtx,
+		poststopTaskCtxCancel:  poststopCancelFn,
+		prestartSidecar:        map[string]struct{}{},
+		prestartEphemeral:      map[string]struct{}{},
+		mainTasksPending:       map[string]struct{}{},
+		mainTasksRunning:       map[string]struct{}{},
+	}
+	c.setTasks(tasks)
+	return c
+}
+
+func (c *taskHookCoordinator) setTasks(tasks []*structs.Task) {
+	for _, task := range tasks {
+		if task.Lifecycle == nil {
+			c.mainTasksPending[task.Name] = struct{}{}
+			c.mainTasksRunning[task.Name] = struct{}{}
+			continue
+		}
+
+		switch task.Lifecycle.Hook {
+		case structs.TaskLifecycleHookPrestart:
+			if task.Lifecycle.Sidecar {
+				c.prestartSidecar[task.Name] = struct{}{}
+			} else {
+				c.prestartEphemeral[task.Name] = struct{}{}
+			}
+		case structs.TaskLifecycleHookPoststart, structs.TaskLifecycleHookPoststop:
+			// Gated on the main tasks
+		default:
+			c.logger.Error("invalid lifecycle hook", "task", task.Name, "hook", task.Lifecycle.Hook)
+		}
+	}
+
+	c.cancelSatisfiedPhases()
+}
+
+// cancelSatisfiedPhases unblocks every lifecycle phase whose start
+// condition has been met.
+func (c *taskHookCoordinator) cancelSatisfiedPhases() {
+	if !c.hasPrestartTasks() {
+		c.mainTaskCtxCancel()
+	}
+	if !c.hasPendingMainTasks() {
+		c.poststartTaskCtxCancel()
+	}
+	if !c.hasRunningMainTasks() {
+		c.poststopTaskCtxCancel()
+	}
+}
+
+func (c *taskHookCoordinator) hasPrestartTasks() bool {
+	return len(c.prestartSidecar)+len(c.prestartEphemeral) > 0
+}
+
+func (c *taskHookCoordinator) hasPendingMainTasks() bool {
+	return len(c.mainTasksPending) > 0
+}
+
+func (c *taskHookCoordinator) hasRunningMainTasks() bool {
+	return len(c.mainTasksRunning) > 0
+}
+
+// startConditionForTask returns a channel that is closed once the task is
+// allowed to start.
+func (c *taskHookCoordinator) startConditionForTask(task *structs.Task) <-chan struct{} {
+	if task.Lifecycle == nil {
+		return c.mainTaskCtx.Done()
+	}
+
+	switch task.Lifecycle.Hook {
+	case structs.TaskLifecycleHookPrestart:
+		return c.closedCh
+	case structs.TaskLifecycleHookPoststart:
+		return c.poststartTaskCtx.Done()
+	case structs.TaskLifecycleHookPoststop:
+		return c.poststopTaskCtx.Done()
+	default:
+		return c.mainTaskCtx.Done()
+	}
+}
+
+// taskStateUpdated updates the coordinator with the latest task states and
+// unblocks lifecycle phases whose start condition has been met.
+//
+// This is not thread safe! It must only be called from one goroutine per
+// alloc runner.
+func (c *taskHookCoordinator) taskStateUpdated(states map[string]*structs.TaskState) {
+	for task := range c.prestartSidecar {
+		st := states[task]
+		if st == nil || st.StartedAt.IsZero() {
+			continue
+		}
+
+		delete(c.prestartSidecar, task)
+	}
+
+	for task := range c.prestartEphemeral {
+		st := states[task]
+		if st == nil || !st.Successful() {
+			continue
+		}
+
+		delete(c.prestartEphemeral, task)
+	}
+
+	for task := range c.mainTasksPending {
+		st := states[task]
+		if st == nil || (st.StartedAt.IsZero() && st.State != structs.TaskStateDead) {
+			continue
+		}
+
+		delete(c.mainTasksPending, task)
+	}
+
+	for task := range c.mainTasksRunning {
+		st := states[task]
+		if st == nil || st.State != structs.TaskStateDead {
+			continue
+		}
+
+		delete(c.mainTasksRunning, task)
+	}
+
+	c.cancelSatisfiedPhases()
+}
+
+// hasNonSidecarTasks returns true if any of the task runners is not a
+// sidecar.
+func hasNonSidecarTasks(tasks []*taskrunner.TaskRunner) bool {
+	for _, tr := range tasks {
+		if !tr.IsSidecar() {
+			return true
+		}
+	}
+	return false
+}
+
+// hasSidecarTasks returns true if any of the task runners is a sidecar.
+func hasSidecarTasks(tasks map[string]*taskrunner.TaskRunner) bool {
+	for _, tr := range tasks {
+		if tr.IsSidecar() {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,170 @@
+package allocrunner
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/stretchr/testify/require"
+)
+
+func isChannelClosed(ch <-chan struct{}) bool {
+	select {
+	case <-ch:
+		return true
+	default:
+		return false
+	}
+}
+
+func TestTaskHookCoordinator_OnlyMainApp(t *testing.T) {
+	alloc := mock.Alloc()
+	tasks := alloc.Job.TaskGroups[0].Tasks
+	task := tasks[0]
+	logger := testlog.HCLogger(t)
+
+	coord := newTaskHookCoordinator(logger, tasks)
+
+	require.Truef(t, isChannelClosed(coord.startConditionForTask(task)), "%s channel was open, should be closed", task.Name)
+}
+
+func TestTaskHookCoordinator_PrestartRunsBeforeMain(t *testing.T) {
+	logger := testlog.HCLogger(t)
+
+	alloc := mock.LifecycleAlloc()
+	tasks := alloc.Job.TaskGroups[0].Tasks
+
+	mainTask := tasks[0]
+	sideTask := tasks[1]
+	initTask := tasks[2]
+
+	coord := newTaskHookCoordinator(logger, tasks)
+	initCh := coord.startConditionForTask(initTask)
+	sideCh := coord.startConditionForTask(sideTask)
+	mainCh := coord.startConditionForTask(mainTask)
+
+	require.Truef(t, isChannelClosed(initCh), "%s channel was open, should be closed", initTask.Name)
+	require.Truef(t, isChannelClosed(sideCh), "%s channel was open, should be closed", sideTask.Name)
+	require.Falsef(t, isChannelClosed(mainCh), "%s channel was closed, should be open", mainTask.Name)
+
+	// The sidecar started but the ephemeral init task is still running
+	states := map[string]*structs.TaskState{
+		mainTask.Name: {
+			State:  structs.TaskStatePending,
+			Failed: false,
+		},
+		initTask.Name: {
+			State:     structs.TaskStateRunning,
+			Failed:    false,
+			StartedAt: time.Now(),
+		},
+		sideTask.Name: {
+			State:     structs.TaskStateRunning,
+			Failed:    false,
+			StartedAt: time.Now(),
+		},
+	}
+	coord.taskStateUpdated(states)
+	require.Falsef(t, isChannelClosed(mainCh), "%s channel was closed, should be open", mainTask.Name)
+
+	// The ephemeral init task completed successfully
+	states[initTask.Name] = &structs.TaskState{
+		State:      structs.TaskStateDead,
+		Failed:     false,
+		StartedAt:  time.Now(),
+		FinishedAt: time.Now(),
+	}
+	coord.taskStateUpdated(states)
+	require.Truef(t, isChannelClosed(mainCh), "%s channel was open, should be closed", mainTask.Name)
+}
+
+func TestTaskHookCoordinator_FailedInitTask(t *testing.T) {
+	logger := testlog.HCLogger(t)
+
+	alloc := mock.LifecycleAlloc()
+	tasks := alloc.Job.TaskGroups[0].Tasks
+
+	mainTask := tasks[0]
+	sideTask := tasks[1]
+	initTask := tasks[2]
+
+	coord := newTaskHookCoordinator(logger, tasks)
+	mainCh := coord.startConditionForTask(mainTask)
+
+	states := map[string]*structs.TaskState{
+		mainTask.Name: {
+			State:  structs.TaskStatePending,
+			Failed: false,
+		},
+		initTask.Name: {
+			State:      structs.TaskStateDead,
+			Failed:     true,
+			StartedAt:  time.Now(),
+			FinishedAt: time.Now(),
+		},
+		sideTask.Name: {
+			State:     structs.TaskStateRunning,
+			Failed:    false,
+			StartedAt: time.Now(),
+		},
+	}
+	coord.taskStateUpdated(states)
+	require.Falsef(t, isChannelClosed(mainCh), "%s channel was closed, should be open", mainTask.Name)
+}
+
+func TestTaskHookCoordinator_PoststartPoststop(t *testing.T) {
+	logger := testlog.HCLogger(t)
+
+	alloc := mock.LifecycleAlloc()
+	tasks := alloc.Job.TaskGroups[0].Tasks[:1]
+
+	mainTask := tasks[0]
+	postStartTask := mainTask.Copy()
+	postStartTask.Name = "poststart"
+	postStartTask.Lifecycle = &structs.TaskLifecycleConfig{
+		Hook: structs.TaskLifecycleHookPoststart,
+	}
+	postStopTask := mainTask.Copy()
+	postStopTask.Name = "poststop"
+	postStopTask.Lifecycle = &structs.TaskLifecycleConfig{
+		Hook: structs.TaskLifecycleHookPoststop,
+	}
+	tasks = append(tasks, postStartTask, postStopTask)
+
+	coord := newTaskHookCoordinator(logger, tasks)
+	mainCh := coord.startConditionForTask(mainTask)
+	postStartCh := coord.startConditionForTask(postStartTask)
+	postStopCh := coord.startConditionForTask(postStopTask)
+
+	require.Truef(t, isChannelClosed(mainCh), "%s channel was open, should be closed", mainTask.Name)
+	require.Falsef(t, isChannelClosed(postStartCh), "%s channel was closed, should be open", postStartTask.Name)
+	require.Falsef(t, isChannelClosed(postStopCh), "%s channel was closed, should be open", postStopTask.Name)
+
+	// The main task is running
+	states := map[string]*structs.TaskState{
+		mainTask.Name: {
+			State:     structs.TaskStateRunning,
+			StartedAt: time.Now(),
+		},
+		postStartTask.Name: {
+			State: structs.TaskStatePending,
+		},
+		postStopTask.Name: {
+			State: structs.TaskStatePending,
+		},
+	}
+	coord.taskStateUpdated(states)
+	require.Truef(t, isChannelClosed(postStartCh), "%s channel was open, should be closed", postStartTask.Name)
+	require.Falsef(t, isChannelClosed(postStopCh), "%s channel was closed, should be open", postStopTask.Name)
+
+	// The main task has stopped
+	states[mainTask.Name] = &structs.TaskState{
+		State:      structs.TaskStateDead,
+		StartedAt:  time.Now(),
+		FinishedAt: time.Now(),
+	}
+	coord.taskStateUpdated(states)
+	require.Truef(t, isChannelClosed(postStopCh), "%s channel was open, should be closed", postStopTask.Name)
+}
@@ -0,0 +1,54 @@
+package taskrunner
+
+import (
+	"context"
+	"io/ioutil"
+	"path/filepath"
+
+	hclog "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/client/allocdir"
+	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
+	"github.com/hashicorp/nomad/client/taskenv"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// identityHook exposes the workload identity token signed by the servers for
+// the task through a file in its secrets dir and an environment variable.
+type identityHook struct {
+	token string
+
+	logger hclog.Logger
+}
+
+func newIdentityHook(alloc *structs.Allocation, taskName string, logger hclog.Logger) *identityHook {
+	h := &identityHook{
+		token: alloc.SignedIdentities[taskName],
+	}
+	h.logger = logger.Named(h.Name())
+	return h
+}
+
+func (*identityHook) Name() string {
+	return "identity"
+}
+
+func (h *identityHook) Prestart(ctx context.Context, req *interfaces.TaskPrestartRequest, resp *interfaces.TaskPrestartResponse) error {
+	if h.token == "" {
+		// No workload identity was signed, such as when ACLs are disabled
+		resp.Done = true
+		return nil
+	}
+
+	tokenPath := filepath.Join(req.TaskDir.SecretsDir, allocdir.TaskIdentityFile)
+	if err := ioutil.WriteFile(tokenPath, []byte(h.token), 0666); err != nil {
+		return err
+	}
+
+	h.logger.Trace("workload identity written", "path", tokenPath)
+
+	resp.Env = map[string]string{
+		taskenv.WorkloadToken: h.token,
+	}
+	resp.Done = true
+	return nil
+}
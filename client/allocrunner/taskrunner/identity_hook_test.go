@@ -0,0 +1,84 @@
+package taskrunner
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/nomad/client/allocdir"
+	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
+	"github.com/hashicorp/nomad/client/taskenv"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// Statically assert the identity hook implements the expected interfaces
+var _ interfaces.TaskPrestartHook = (*identityHook)(nil)
+
+// TestTaskRunner_IdentityHook_NoIdentity asserts that the hook is a noop and
+// is marked as done if no workload identity was signed for the task.
+func TestTaskRunner_IdentityHook_NoIdentity(t *testing.T) {
+	t.Parallel()
+
+	require := require.New(t)
+	ctx := context.Background()
+	logger := testlog.HCLogger(t)
+	allocDir := allocdir.NewAllocDir(logger, "nomadtest_noidentity")
+	defer allocDir.Destroy()
+
+	alloc := mock.BatchAlloc()
+	task := alloc.Job.TaskGroups[0].Tasks[0]
+	taskDir := allocDir.NewTaskDir(task.Name)
+	require.NoError(taskDir.Build(false, nil))
+
+	h := newIdentityHook(alloc, task.Name, logger)
+
+	req := interfaces.TaskPrestartRequest{
+		Task:    task,
+		TaskDir: taskDir,
+	}
+	resp := interfaces.TaskPrestartResponse{}
+
+	require.NoError(h.Prestart(ctx, &req, &resp))
+	require.True(resp.Done)
+	require.Empty(resp.Env)
+	_, err := os.Stat(filepath.Join(taskDir.SecretsDir, allocdir.TaskIdentityFile))
+	require.True(os.IsNotExist(err))
+}
+
+// TestTaskRunner_IdentityHook_Ok asserts that the workload identity of the
+// task is written to its secrets dir and environment.
+func TestTaskRunner_IdentityHook_Ok(t *testing.T) {
+	t.Parallel()
+
+	require := require.New(t)
+	ctx := context.Background()
+	logger := testlog.HCLogger(t)
+	allocDir := allocdir.NewAllocDir(logger, "nomadtest_identityok")
+	defer allocDir.Destroy()
+
+	alloc := mock.BatchAlloc()
+	task := alloc.Job.TaskGroups[0].Tasks[0]
+	alloc.SignedIdentities = map[string]string{task.Name: "header.payload.signature"}
+	taskDir := allocDir.NewTaskDir(task.Name)
+	require.NoError(taskDir.Build(false, nil))
+
+	h := newIdentityHook(alloc, task.Name, logger)
+
+	req := interfaces.TaskPrestartRequest{
+		Task:    task,
+		TaskDir: taskDir,
+	}
+	resp := interfaces.TaskPrestartResponse{}
+
+	require.NoError(h.Prestart(ctx, &req, &resp))
+	require.True(resp.Done)
+	require.Equal("header.payload.signature", resp.Env[taskenv.WorkloadToken])
+
+	data, err := ioutil.ReadFile(filepath.Join(taskDir.SecretsDir, allocdir.TaskIdentityFile))
+	require.NoError(err)
+	require.Equal("header.payload.signature", string(data))
+}
@@ -20,11 +20,16 @@ const (
 	ReasonDelay               = "Exceeded allowed attempts, applying a delay"
 )
 
-func NewRestartTracker(policy *structs.RestartPolicy, jobType string) *RestartTracker {
+func NewRestartTracker(policy *structs.RestartPolicy, jobType string, tlc *structs.TaskLifecycleConfig) *RestartTracker {
 	onSuccess := true
 	if jobType == structs.JobTypeBatch {
 		onSuccess = false
 	}
+
+	// Lifecycle tasks run to completion unless they are sidecars
+	if tlc != nil {
+		onSuccess = tlc.Sidecar
+	}
 	return &RestartTracker{
 		startTime: time.Now(),
 		onSuccess: onSuccess,
@@ -20,6 +20,7 @@ import (
 	"github.com/hashicorp/nomad/client/consul"
 	"github.com/hashicorp/nomad/client/devicemanager"
 	cinterfaces "github.com/hashicorp/nomad/client/interfaces"
+	"github.com/hashicorp/nomad/client/lib/cgutil"
 	"github.com/hashicorp/nomad/client/pluginmanager/drivermanager"
 	cstate "github.com/hashicorp/nomad/client/state"
 	cstructs "github.com/hashicorp/nomad/client/structs"
@@ -190,6 +191,10 @@ type TaskRunner struct {
 	// handlers
 	driverManager drivermanager.Manager
 
+	// cpusetManager is used to lookup the cores of the node that aren't
+	// reserved by an allocation
+	cpusetManager cgutil.CpusetManager
+
 	// maxEvents is the capacity of the TaskEvents on the TaskState.
 	// Defaults to defaultMaxEvents but overrideable for testing.
 	maxEvents int
@@ -203,6 +208,10 @@ type TaskRunner struct {
 	// closed.
 	waitOnServers bool
 
+	// startConditionMetCtx is closed when the task's lifecycle start
+	// condition has been met and the task may be started.
+	startConditionMetCtx <-chan struct{}
+
 	networkIsolationLock sync.Mutex
 	networkIsolationSpec *drivers.NetworkIsolationSpec
 }
@@ -235,9 +244,17 @@ type Config struct {
 	// handlers
 	DriverManager drivermanager.Manager
 
+	// CpusetManager is used to lookup the cores of the node that aren't
+	// reserved by an allocation
+	CpusetManager cgutil.CpusetManager
+
 	// ServersContactedCh is closed when the first GetClientAllocs call to
 	// servers succeeds and allocs are synced.
 	ServersContactedCh chan struct{}
+
+	// StartConditionMetCtx is closed when the task's lifecycle start
+	// condition has been met. If nil the task is started immediately.
+	StartConditionMetCtx <-chan struct{}
 }
 
 func NewTaskRunner(config *Config) (*TaskRunner, error) {
@@ -285,10 +302,19 @@ func NewTaskRunner(config *Config) (*TaskRunner, error) {
 		waitCh:              make(chan struct{}),
 		devicemanager:       config.DeviceManager,
 		driverManager:       config.DriverManager,
+		cpusetManager:       config.CpusetManager,
 		maxEvents:           defaultMaxEvents,
 		serversContactedCh:  config.ServersContactedCh,
 	}
 
+	// Tasks without a start condition may be started immediately
+	tr.startConditionMetCtx = config.StartConditionMetCtx
+	if tr.startConditionMetCtx == nil {
+		startCh := make(chan struct{})
+		close(startCh)
+		tr.startConditionMetCtx = startCh
+	}
+
 	// Create the logger based on the allocation ID
 	tr.logger = config.Logger.Named("task_runner").With("task", config.Task.Name)
 
@@ -326,7 +352,7 @@ func NewTaskRunner(config *Config) (*TaskRunner, error) {
 		tr.logger.Error("alloc missing task group")
 		return nil, fmt.Errorf("alloc missing task group")
 	}
-	tr.restartTracker = restarts.NewRestartTracker(tg.RestartPolicy, tr.alloc.Job.Type)
+	tr.restartTracker = restarts.NewRestartTracker(tg.RestartPolicy, tr.alloc.Job.Type, tr.task.Lifecycle)
 
 	// Get the driver
 	if err := tr.initDriver(); err != nil {
@@ -460,8 +486,20 @@ func (tr *TaskRunner) Run() {
 		}
 	}
 
+	// Wait for the task's lifecycle start condition to be met
+	select {
+	case <-tr.startConditionMetCtx:
+		tr.logger.Debug("lifecycle start condition has been met, proceeding")
+	case <-tr.killCtx.Done():
+	case <-tr.shutdownCtx.Done():
+		return
+	}
+
+	// Poststop tasks are started once the main tasks have stopped, which is
+	// usually because the allocation was stopped, so they still run when the
+	// allocation is terminal and are only stopped by kill and shutdown.
 MAIN:
-	for !tr.Alloc().TerminalStatus() {
+	for tr.IsPoststopTask() || !tr.Alloc().TerminalStatus() {
 		select {
 		case <-tr.killCtx.Done():
 			break MAIN
@@ -912,6 +950,7 @@ func (tr *TaskRunner) buildTaskConfig() *drivers.TaskConfig {
 				MemoryLimitBytes: taskResources.Memory.MemoryMB * 1024 * 1024,
 				CPUShares:        taskResources.Cpu.CpuShares,
 				PercentTicks:     float64(taskResources.Cpu.CpuShares) / float64(tr.clientConfig.Node.NodeResources.Cpu.CpuShares),
+				CpusetCPUs:       tr.cpuset(taskResources),
 			},
 		},
 		Devices:          tr.hookResources.getDevices(),
@@ -927,6 +966,19 @@ func (tr *TaskRunner) buildTaskConfig() *drivers.TaskConfig {
 	}
 }
 
+// cpuset returns the cpuset the task is pinned to. Tasks that reserve cores
+// are pinned to them, while the others share the cores of the node that
+// aren't reserved.
+func (tr *TaskRunner) cpuset(taskResources *structs.AllocatedTaskResources) string {
+	if len(taskResources.Cpu.ReservedCores) > 0 {
+		return cgutil.FormatCpuset(taskResources.Cpu.ReservedCores)
+	}
+	if tr.cpusetManager == nil {
+		return ""
+	}
+	return cgutil.FormatCpuset(tr.cpusetManager.SharedCores())
+}
+
 // Restore task runner state. Called by AllocRunner.Restore after NewTaskRunner
 // but before Run so no locks need to be acquired.
 func (tr *TaskRunner) Restore() error {
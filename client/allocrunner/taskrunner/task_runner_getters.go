@@ -28,6 +28,18 @@ func (tr *TaskRunner) IsLeader() bool {
 	return tr.taskLeader
 }
 
+// IsPoststopTask returns true if this task is a poststop task in its task
+// group.
+func (tr *TaskRunner) IsPoststopTask() bool {
+	return tr.Task().IsPoststop()
+}
+
+// IsSidecar returns true if this task is a lifecycle sidecar task in its
+// task group.
+func (tr *TaskRunner) IsSidecar() bool {
+	return tr.Task().IsSidecar()
+}
+
 func (tr *TaskRunner) Task() *structs.Task {
 	tr.taskLock.RLock()
 	defer tr.taskLock.RUnlock()
@@ -63,6 +63,7 @@ func (tr *TaskRunner) initHooks() {
 		newTaskDirHook(tr, hookLogger),
 		newLogMonHook(tr.logmonHookConfig, hookLogger),
 		newDispatchHook(alloc, hookLogger),
+		newIdentityHook(alloc, task.Name, hookLogger),
 		newVolumeHook(tr, hookLogger),
 		newArtifactHook(tr, hookLogger),
 		newStatsHook(tr, tr.clientConfig.StatsCollectionInterval, hookLogger),
@@ -132,9 +133,9 @@ func (tr *TaskRunner) emitHookError(err error, hookName string) {
 // prestart is used to run the runners prestart hooks.
 func (tr *TaskRunner) prestart() error {
 	// Determine if the allocation is terminaland we should avoid running
-	// prestart hooks.
+	// prestart hooks. Poststop tasks run once the allocation is stopped.
 	alloc := tr.Alloc()
-	if alloc.TerminalStatus() {
+	if alloc.TerminalStatus() && !tr.IsPoststopTask() {
 		tr.logger.Trace("skipping prestart hooks since allocation is terminal")
 		return nil
 	}
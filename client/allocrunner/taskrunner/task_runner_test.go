@@ -19,6 +19,7 @@ import (
 	"github.com/hashicorp/nomad/client/consul"
 	consulapi "github.com/hashicorp/nomad/client/consul"
 	"github.com/hashicorp/nomad/client/devicemanager"
+	"github.com/hashicorp/nomad/client/lib/cgutil"
 	"github.com/hashicorp/nomad/client/pluginmanager/drivermanager"
 	cstate "github.com/hashicorp/nomad/client/state"
 	ctestutil "github.com/hashicorp/nomad/client/testutil"
@@ -2115,3 +2116,37 @@ func TestTaskRunner_BaseLabels(t *testing.T) {
 	require.Equal(alloc.ID, labels["alloc_id"])
 	require.Equal(alloc.Namespace, labels["namespace"])
 }
+
+// TestTaskRunner_Cpuset asserts that tasks reserving cores are pinned to them
+// and that the other tasks are pinned to the cores that aren't reserved.
+func TestTaskRunner_Cpuset(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	alloc := mock.BatchAlloc()
+	task := alloc.Job.TaskGroups[0].Tasks[0]
+	task.Driver = "mock_driver"
+
+	config, cleanup := testTaskRunnerConfig(t, alloc, task.Name)
+	defer cleanup()
+
+	reserving := mock.Alloc()
+	reserving.AllocatedResources.Tasks["web"].Cpu.ReservedCores = []uint16{1, 2}
+	config.CpusetManager = cgutil.NewCpusetManager(testlog.HCLogger(t), []uint16{0, 1, 2, 3})
+	config.CpusetManager.AddAlloc(reserving)
+
+	tr, err := NewTaskRunner(config)
+	require.NoError(err)
+
+	// The shared task excludes the reserved cores
+	taskResources := alloc.AllocatedResources.Tasks[task.Name]
+	require.Equal("0,3", tr.cpuset(taskResources))
+
+	// The cores are shared again once released
+	config.CpusetManager.RemoveAlloc(reserving.ID)
+	require.Equal("0,1,2,3", tr.cpuset(taskResources))
+
+	// Tasks reserving cores are pinned to them
+	taskResources.Cpu.ReservedCores = []uint16{3}
+	require.Equal("3", tr.cpuset(taskResources))
+}
@@ -28,6 +28,7 @@ import (
 	consulApi "github.com/hashicorp/nomad/client/consul"
 	"github.com/hashicorp/nomad/client/devicemanager"
 	"github.com/hashicorp/nomad/client/fingerprint"
+	"github.com/hashicorp/nomad/client/lib/cgutil"
 	"github.com/hashicorp/nomad/client/pluginmanager"
 	"github.com/hashicorp/nomad/client/pluginmanager/drivermanager"
 	"github.com/hashicorp/nomad/client/servers"
@@ -260,6 +261,10 @@ type Client struct {
 	// drivermanager is responsible for managing driver plugins
 	drivermanager drivermanager.Manager
 
+	// cpusetManager keeps the tasks that don't reserve cores off of the
+	// cores reserved by allocations
+	cpusetManager cgutil.CpusetManager
+
 	// baseLabels are used when emitting tagged metrics. All client metrics will
 	// have these tags, and optionally more.
 	baseLabels []metrics.Label
@@ -375,6 +380,13 @@ func NewClient(cfg *config.Config, consulCatalog consul.CatalogAPI, consulServic
 		return nil, fmt.Errorf("fingerprinting failed: %v", err)
 	}
 
+	// Setup the cpuset manager with the fingerprinted cores
+	var cores []uint16
+	if nr := c.GetConfig().Node.NodeResources; nr != nil {
+		cores = nr.Cpu.ReservableCpuCores
+	}
+	c.cpusetManager = cgutil.NewCpusetManager(c.logger, cores)
+
 	// Build the white/blacklists of drivers.
 	allowlistDrivers := cfg.ReadStringListToMap("driver.whitelist")
 	blocklistDrivers := cfg.ReadStringListToMap("driver.blacklist")
@@ -1047,6 +1059,7 @@ func (c *Client) restoreState() error {
 			PrevAllocMigrator:   prevAllocMigrator,
 			DeviceManager:       c.devicemanager,
 			DriverManager:       c.drivermanager,
+			CpusetManager:       c.cpusetManager,
 			ServersContactedCh:  c.serversContactedCh,
 		}
 		c.configLock.RUnlock()
@@ -1068,6 +1081,11 @@ func (c *Client) restoreState() error {
 			continue
 		}
 
+		// Keep the cores reserved by the alloc while it is running
+		if !alloc.Terminated() {
+			c.cpusetManager.AddAlloc(alloc)
+		}
+
 		//XXX is this locking necessary?
 		c.allocLock.Lock()
 		c.allocs[alloc.ID] = ar
@@ -1784,6 +1802,9 @@ func (c *Client) AllocStateUpdated(alloc *structs.Allocation) {
 			// waiting for eligible allocs.
 			c.garbageCollector.Trigger()
 		}
+
+		// The tasks have stopped so their reserved cores can be shared
+		c.cpusetManager.RemoveAlloc(alloc.ID)
 	}
 
 	// Strip all the information that can be reconstructed at the server.  Only
@@ -2216,6 +2237,7 @@ func (c *Client) removeAlloc(allocID string) {
 
 	// Stop tracking alloc runner as it's been GC'd by the server
 	delete(c.allocs, allocID)
+	c.cpusetManager.RemoveAlloc(allocID)
 
 	// Ensure the GC has a reference and then collect. Collecting through the GC
 	// applies rate limiting
@@ -2298,6 +2320,7 @@ func (c *Client) addAlloc(alloc *structs.Allocation, migrateToken string) error
 		PrevAllocMigrator:   prevAllocMigrator,
 		DeviceManager:       c.devicemanager,
 		DriverManager:       c.drivermanager,
+		CpusetManager:       c.cpusetManager,
 	}
 	c.configLock.RUnlock()
 
@@ -2306,6 +2329,9 @@ func (c *Client) addAlloc(alloc *structs.Allocation, migrateToken string) error
 		return err
 	}
 
+	// Reserve the cores of the alloc before its tasks are started
+	c.cpusetManager.AddAlloc(alloc)
+
 	// Store the alloc runner.
 	c.allocs[alloc.ID] = ar
 
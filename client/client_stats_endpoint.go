@@ -20,8 +20,11 @@ func (s *ClientStats) Stats(args *nstructs.NodeSpecificRequest, reply *structs.C
 	// Check node read permissions
 	if aclObj, err := s.c.ResolveToken(args.AuthToken); err != nil {
 		return err
-	} else if aclObj != nil && !aclObj.AllowNodeRead() {
-		return nstructs.ErrPermissionDenied
+	} else if aclObj != nil {
+		node := s.c.Node()
+		if !aclObj.AllowNodeReadFor(node.NodeClass, node.Datacenter, node.Meta) {
+			return nstructs.ErrPermissionDenied
+		}
 	}
 
 	clientStats := s.c.StatsReporter()
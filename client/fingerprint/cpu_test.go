@@ -52,6 +52,10 @@ func TestCPUFingerprint(t *testing.T) {
 	if response.NodeResources == nil || response.NodeResources.Cpu.CpuShares == 0 {
 		t.Fatalf("Expected to find CPU Resources")
 	}
+
+	if len(response.NodeResources.Cpu.ReservableCpuCores) == 0 {
+		t.Fatalf("Expected to find reservable CPU cores")
+	}
 }
 
 // TestCPUFingerprint_OverrideCompute asserts that setting cpu_total_compute in
@@ -0,0 +1,139 @@
+package cgutil
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	hclog "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// SharedCpusetCgroup is the cgroup, relative to the root of the cpuset
+// hierarchy, of the tasks that don't reserve cores. Its cpuset is kept to the
+// cores of the node that aren't reserved by an allocation.
+const SharedCpusetCgroup = "nomad/shared"
+
+// CpusetManager tracks the cores reserved by the allocations of the node so
+// that the tasks that don't reserve cores are kept off of them.
+type CpusetManager interface {
+	// AddAlloc marks the cores reserved by the tasks of the allocation as in
+	// use.
+	AddAlloc(alloc *structs.Allocation)
+
+	// RemoveAlloc releases the cores reserved by the allocation.
+	RemoveAlloc(allocID string)
+
+	// SharedCores returns the cores of the node that aren't reserved by an
+	// allocation.
+	SharedCores() []uint16
+}
+
+// cpusetManager implements CpusetManager by keeping the cpuset of the shared
+// cgroup in sync with the reserved cores.
+type cpusetManager struct {
+	logger hclog.Logger
+
+	// cores are the cores of the node
+	cores []uint16
+
+	// allocs are the cores reserved by each allocation
+	allocs map[string][]uint16
+
+	// shared are the cores that aren't reserved
+	shared []uint16
+
+	mu sync.Mutex
+}
+
+// NewCpusetManager returns a CpusetManager for a node with the given cores.
+func NewCpusetManager(logger hclog.Logger, cores []uint16) CpusetManager {
+	m := &cpusetManager{
+		logger: logger.Named("cpuset_manager"),
+		cores:  cores,
+		allocs: make(map[string][]uint16),
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.update()
+	return m
+}
+
+func (m *cpusetManager) AddAlloc(alloc *structs.Allocation) {
+	if alloc == nil || alloc.AllocatedResources == nil {
+		return
+	}
+
+	var cores []uint16
+	for _, tr := range alloc.AllocatedResources.Tasks {
+		cores = append(cores, tr.Cpu.ReservedCores...)
+	}
+	if len(cores) == 0 {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.allocs[alloc.ID] = cores
+	m.update()
+}
+
+func (m *cpusetManager) RemoveAlloc(allocID string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.allocs[allocID]; !ok {
+		return
+	}
+	delete(m.allocs, allocID)
+	m.update()
+}
+
+func (m *cpusetManager) SharedCores() []uint16 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	shared := make([]uint16, len(m.shared))
+	copy(shared, m.shared)
+	return shared
+}
+
+// update recomputes the shared cores and applies them to the shared cgroup if
+// they changed. It must be called with the lock held.
+func (m *cpusetManager) update() {
+	reserved := make(map[uint16]struct{})
+	for _, cores := range m.allocs {
+		for _, core := range cores {
+			reserved[core] = struct{}{}
+		}
+	}
+
+	shared := make([]uint16, 0, len(m.cores))
+	for _, core := range m.cores {
+		if _, ok := reserved[core]; !ok {
+			shared = append(shared, core)
+		}
+	}
+	sort.Slice(shared, func(i, j int) bool { return shared[i] < shared[j] })
+
+	if m.shared != nil && FormatCpuset(shared) == FormatCpuset(m.shared) {
+		return
+	}
+	m.shared = shared
+
+	if err := m.applySharedCpuset(); err != nil {
+		m.logger.Warn("failed to update the cpuset of the shared cgroup", "cpuset", FormatCpuset(shared), "error", err)
+	}
+}
+
+// FormatCpuset returns the cpuset list format of the given cores, e.g.
+// "0,1,3". An empty string is returned if there are no cores.
+func FormatCpuset(cores []uint16) string {
+	ids := make([]string, len(cores))
+	for i, core := range cores {
+		ids[i] = strconv.Itoa(int(core))
+	}
+	return strings.Join(ids, ",")
+}
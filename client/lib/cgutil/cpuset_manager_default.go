@@ -0,0 +1,14 @@
+//+build !linux
+
+package cgutil
+
+// applySharedCpuset is a noop as cpusets are only supported on Linux.
+func (m *cpusetManager) applySharedCpuset() error {
+	return nil
+}
+
+// SharedCpusetCpus returns an empty string as cpusets are only supported on
+// Linux.
+func SharedCpusetCpus() (string, error) {
+	return "", nil
+}
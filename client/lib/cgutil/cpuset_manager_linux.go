@@ -0,0 +1,107 @@
+package cgutil
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/opencontainers/runc/libcontainer/cgroups"
+)
+
+// applySharedCpuset writes the shared cores to the shared cgroup and to the
+// cgroups of the tasks in it. Nothing is done if the cpuset cgroup isn't
+// mounted.
+func (m *cpusetManager) applySharedCpuset() error {
+	root, err := cgroups.FindCgroupMountpoint("", "cpuset")
+	if err != nil {
+		m.logger.Debug("cpuset cgroup not mounted, not pinning shared tasks", "error", err)
+		return nil
+	}
+
+	shared := filepath.Join(root, SharedCpusetCgroup)
+	if err := ensureCpuset(root, shared); err != nil {
+		return err
+	}
+
+	// The cpuset of a cgroup must contain the cpusets of its children, so
+	// widen the shared cgroup to all the cores before its children are
+	// changed and only then narrow it.
+	if err := writeCpuset(shared, FormatCpuset(m.cores)); err != nil {
+		return err
+	}
+
+	cpus := FormatCpuset(m.shared)
+	children, err := ioutil.ReadDir(shared)
+	if err != nil {
+		return err
+	}
+	for _, child := range children {
+		if !child.IsDir() {
+			continue
+		}
+		if err := writeCpuset(filepath.Join(shared, child.Name()), cpus); err != nil {
+			m.logger.Warn("failed to update the cpuset of a shared task", "cgroup", child.Name(), "error", err)
+		}
+	}
+
+	return writeCpuset(shared, cpus)
+}
+
+// SharedCpusetCpus returns the cpuset of the shared cgroup, or an empty string
+// if it doesn't exist.
+func SharedCpusetCpus() (string, error) {
+	root, err := cgroups.FindCgroupMountpoint("", "cpuset")
+	if err != nil {
+		return "", nil
+	}
+
+	b, err := ioutil.ReadFile(filepath.Join(root, SharedCpusetCgroup, "cpuset.cpus"))
+	if os.IsNotExist(err) {
+		return "", nil
+	} else if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(b)), nil
+}
+
+// ensureCpuset creates the cgroup at path and any missing parent below root,
+// copying the cpus and mems of their parent as a cgroup can't hold tasks
+// until they are set.
+func ensureCpuset(root, path string) error {
+	if path == root {
+		return nil
+	}
+
+	parent := filepath.Dir(path)
+	if err := ensureCpuset(root, parent); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(path, 0755); err != nil {
+		return err
+	}
+
+	for _, file := range []string{"cpuset.cpus", "cpuset.mems"} {
+		current, err := ioutil.ReadFile(filepath.Join(path, file))
+		if err != nil {
+			return err
+		}
+		if strings.TrimSpace(string(current)) != "" {
+			continue
+		}
+
+		value, err := ioutil.ReadFile(filepath.Join(parent, file))
+		if err != nil {
+			return err
+		}
+		if err := ioutil.WriteFile(filepath.Join(path, file), value, 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeCpuset sets the cpus of the cgroup at path.
+func writeCpuset(path, cpus string) error {
+	return ioutil.WriteFile(filepath.Join(path, "cpuset.cpus"), []byte(cpus), 0644)
+}
@@ -0,0 +1,58 @@
+package cgutil
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCpusetManager_SharedCores(t *testing.T) {
+	require := require.New(t)
+
+	m := NewCpusetManager(testlog.HCLogger(t), []uint16{0, 1, 2, 3})
+	require.Equal([]uint16{0, 1, 2, 3}, m.SharedCores())
+
+	// Allocs that don't reserve cores leave every core shared
+	m.AddAlloc(mock.Alloc())
+	require.Equal([]uint16{0, 1, 2, 3}, m.SharedCores())
+
+	// Reserved cores are excluded from the shared cores
+	alloc1 := mock.Alloc()
+	alloc1.AllocatedResources.Tasks["web"].Cpu.ReservedCores = []uint16{1}
+	m.AddAlloc(alloc1)
+	require.Equal([]uint16{0, 2, 3}, m.SharedCores())
+
+	alloc2 := mock.Alloc()
+	alloc2.AllocatedResources.Tasks["web"].Cpu.ReservedCores = []uint16{0, 3}
+	m.AddAlloc(alloc2)
+	require.Equal([]uint16{2}, m.SharedCores())
+
+	// Released cores are shared again
+	m.RemoveAlloc(alloc1.ID)
+	require.Equal([]uint16{1, 2}, m.SharedCores())
+
+	m.RemoveAlloc(alloc2.ID)
+	require.Equal([]uint16{0, 1, 2, 3}, m.SharedCores())
+}
+
+func TestFormatCpuset(t *testing.T) {
+	require.Equal(t, "", FormatCpuset(nil))
+	require.Equal(t, "0,1,3", FormatCpuset([]uint16{0, 1, 3}))
+}
+
+func TestCpusetManager_AddAlloc_NoResources(t *testing.T) {
+	m := NewCpusetManager(testlog.HCLogger(t), []uint16{0, 1})
+
+	alloc := mock.Alloc()
+	alloc.AllocatedResources = nil
+	m.AddAlloc(alloc)
+	m.AddAlloc(nil)
+	require.Equal(t, []uint16{0, 1}, m.SharedCores())
+
+	// Removing an unknown alloc is a noop
+	m.RemoveAlloc(uuid.Generate())
+	require.Equal(t, []uint16{0, 1}, m.SharedCores())
+}
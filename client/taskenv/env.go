@@ -92,6 +92,10 @@ const (
 	// VaultToken is the environment variable for passing the Vault token
 	VaultToken = "VAULT_TOKEN"
 
+	// WorkloadToken is the environment variable for passing the workload
+	// identity token of the task
+	WorkloadToken = "NOMAD_TOKEN"
+
 	// VaultNamespace is the environment variable for passing the Vault namespace, if applicable
 	VaultNamespace = "VAULT_NAMESPACE"
 )
@@ -100,14 +100,25 @@ func formatKVACLToken(token *api.ACLToken) string {
 
 	// Special case the policy output
 	if token.Type == "management" {
-		output = append(output, "Policies|n/a")
+		output = append(output, "Policies|n/a", "Roles|n/a")
 	} else {
 		output = append(output, fmt.Sprintf("Policies|%v", token.Policies))
+
+		roles := make([]string, 0, len(token.Roles))
+		for _, link := range token.Roles {
+			roles = append(roles, link.Name)
+		}
+		output = append(output, fmt.Sprintf("Roles|%v", roles))
 	}
 
 	// Add the generic output
 	output = append(output,
 		fmt.Sprintf("Create Time|%v", token.CreateTime),
+	)
+	if token.ExpirationTime != nil {
+		output = append(output, fmt.Sprintf("Expiry Time|%v", *token.ExpirationTime))
+	}
+	output = append(output,
 		fmt.Sprintf("Create Index|%d", token.CreateIndex),
 		fmt.Sprintf("Modify Index|%d", token.ModifyIndex),
 	)
@@ -0,0 +1,96 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/mitchellh/cli"
+)
+
+type ACLRoleCommand struct {
+	Meta
+}
+
+func (f *ACLRoleCommand) Help() string {
+	helpText := `
+Usage: nomad acl role <subcommand> [options] [args]
+
+  This command groups subcommands for interacting with ACL roles. An ACL role
+  groups a set of ACL policies under a single name. Tokens may then be linked
+  to roles instead of listing each policy individually, and will be granted
+  the capabilities of every policy the role references.
+
+  Create an ACL role:
+
+      $ nomad acl role create -name=<name> -policy=<policy>
+
+  List ACL roles:
+
+      $ nomad acl role list
+
+  Inspect an ACL role:
+
+      $ nomad acl role info <role_id>
+
+  Please see the individual subcommand help for detailed usage information.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (f *ACLRoleCommand) Synopsis() string {
+	return "Interact with ACL roles"
+}
+
+func (f *ACLRoleCommand) Name() string { return "acl role" }
+
+func (f *ACLRoleCommand) Run(args []string) int {
+	return cli.RunResultHelp
+}
+
+// formatKVACLRole returns a K/V formatted ACL role
+func formatKVACLRole(role *api.ACLRole) string {
+	output := []string{
+		fmt.Sprintf("ID|%s", role.ID),
+		fmt.Sprintf("Name|%s", role.Name),
+		fmt.Sprintf("Description|%s", role.Description),
+		fmt.Sprintf("Policies|%s", strings.Join(aclRolePolicyNames(role.Policies), ",")),
+		fmt.Sprintf("Create Index|%d", role.CreateIndex),
+		fmt.Sprintf("Modify Index|%d", role.ModifyIndex),
+	}
+	return formatKV(output)
+}
+
+// formatACLRoles returns a formatted list of ACL roles
+func formatACLRoles(roles []*api.ACLRoleListStub) string {
+	if len(roles) == 0 {
+		return "No roles found"
+	}
+
+	output := make([]string, 0, len(roles)+1)
+	output = append(output, "ID|Name|Description|Policies")
+	for _, r := range roles {
+		output = append(output, fmt.Sprintf("%s|%s|%s|%s",
+			r.ID, r.Name, r.Description, strings.Join(aclRolePolicyNames(r.Policies), ",")))
+	}
+
+	return formatList(output)
+}
+
+// aclRolePolicyNames returns the policy names referenced by the given links
+func aclRolePolicyNames(links []*api.ACLRolePolicyLink) []string {
+	names := make([]string, 0, len(links))
+	for _, link := range links {
+		names = append(names, link.Name)
+	}
+	return names
+}
+
+// aclRolePolicyLinks builds the policy links for the given policy names
+func aclRolePolicyLinks(names []string) []*api.ACLRolePolicyLink {
+	links := make([]*api.ACLRolePolicyLink, 0, len(names))
+	for _, name := range names {
+		links = append(links, &api.ACLRolePolicyLink{Name: name})
+	}
+	return links
+}
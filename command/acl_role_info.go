@@ -0,0 +1,112 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/posener/complete"
+)
+
+type ACLRoleInfoCommand struct {
+	Meta
+}
+
+func (c *ACLRoleInfoCommand) Help() string {
+	helpText := `
+Usage: nomad acl role info [options] <role_id>
+
+  Info is used to fetch information on an existing ACL role.
+
+General Options:
+
+  ` + generalOptionsUsage() + `
+
+Info Options:
+
+  -by-name
+    Look up the ACL role using its name rather than its ID.
+
+  -json
+    Output the ACL role in a JSON format.
+
+  -t
+    Format and display the ACL role using a Go template.
+`
+
+	return strings.TrimSpace(helpText)
+}
+
+func (c *ACLRoleInfoCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-by-name": complete.PredictNothing,
+			"-json":    complete.PredictNothing,
+			"-t":       complete.PredictAnything,
+		})
+}
+
+func (c *ACLRoleInfoCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *ACLRoleInfoCommand) Synopsis() string {
+	return "Fetch info on an existing ACL role"
+}
+
+func (c *ACLRoleInfoCommand) Name() string { return "acl role info" }
+
+func (c *ACLRoleInfoCommand) Run(args []string) int {
+	var byName, json bool
+	var tmpl string
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.BoolVar(&byName, "by-name", false, "")
+	flags.BoolVar(&json, "json", false, "")
+	flags.StringVar(&tmpl, "t", "", "")
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we got exactly one argument
+	args = flags.Args()
+	if l := len(args); l != 1 {
+		c.Ui.Error("This command takes one argument: <role_id>")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	// Get the HTTP client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	// Fetch info on the role
+	var role *api.ACLRole
+	if byName {
+		role, _, err = client.ACLRoles().InfoByName(args[0], nil)
+	} else {
+		role, _, err = client.ACLRoles().Info(args[0], nil)
+	}
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error fetching info on ACL role: %s", err))
+		return 1
+	}
+
+	if json || len(tmpl) > 0 {
+		out, err := Format(json, tmpl, role)
+		if err != nil {
+			c.Ui.Error(err.Error())
+			return 1
+		}
+
+		c.Ui.Output(out)
+		return 0
+	}
+
+	c.Ui.Output(formatKVACLRole(role))
+	return 0
+}
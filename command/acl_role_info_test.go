@@ -0,0 +1,56 @@
+package command
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/command/agent"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+)
+
+func TestACLRoleInfoCommand(t *testing.T) {
+	require := require.New(t)
+	t.Parallel()
+	config := func(c *agent.Config) {
+		c.ACL.Enabled = true
+	}
+
+	srv, _, url := testServer(t, true, config)
+	state := srv.Agent.Server().State()
+	defer srv.Shutdown()
+
+	// Bootstrap an initial ACL token
+	token := srv.RootToken
+	require.NotNil(token, "failed to bootstrap ACL token")
+
+	// Create a test ACLRole
+	role := mock.ACLRole()
+	require.NoError(state.UpsertACLRoles(1000, []*structs.ACLRole{role}))
+
+	ui := new(cli.MockUi)
+	cmd := &ACLRoleInfoCommand{Meta: Meta{Ui: ui, flagAddress: url}}
+
+	// Attempt to read the role without a valid token
+	invalidToken := mock.ACLToken()
+	code := cmd.Run([]string{"-address=" + url, "-token=" + invalidToken.SecretID, role.ID})
+	require.Equal(1, code)
+
+	// Read the role by ID
+	code = cmd.Run([]string{"-address=" + url, "-token=" + token.SecretID, role.ID})
+	require.Equal(0, code, ui.ErrorWriter.String())
+	require.Contains(ui.OutputWriter.String(), role.Name)
+	ui.OutputWriter.Reset()
+
+	// Read the role by name
+	code = cmd.Run([]string{"-address=" + url, "-token=" + token.SecretID, "-by-name", role.Name})
+	require.Equal(0, code, ui.ErrorWriter.String())
+	require.Contains(ui.OutputWriter.String(), role.ID)
+	ui.OutputWriter.Reset()
+
+	// Read the role as JSON
+	code = cmd.Run([]string{"-address=" + url, "-token=" + token.SecretID, "-json", role.ID})
+	require.Equal(0, code, ui.ErrorWriter.String())
+	require.Contains(ui.OutputWriter.String(), "CreateIndex")
+}
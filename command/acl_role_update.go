@@ -0,0 +1,134 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/posener/complete"
+)
+
+type ACLRoleUpdateCommand struct {
+	Meta
+}
+
+func (c *ACLRoleUpdateCommand) Help() string {
+	helpText := `
+Usage: nomad acl role update [options] <role_id>
+
+  Update is used to update an existing ACL role. Requires a management token.
+
+General Options:
+
+  ` + generalOptionsUsage() + `
+
+Update Options:
+
+  -name=""
+    Sets the name of the ACL role.
+
+  -description=""
+    Sets the human readable description for the ACL role.
+
+  -policy=""
+    Specifies a policy to link to the role. Can be specified multiple times.
+    If specified, the given policies replace all policies currently linked to
+    the role.
+
+  -json
+    Output the ACL role in a JSON format.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *ACLRoleUpdateCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-name":        complete.PredictAnything,
+			"-description": complete.PredictAnything,
+			"-policy":      complete.PredictAnything,
+			"-json":        complete.PredictNothing,
+		})
+}
+
+func (c *ACLRoleUpdateCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *ACLRoleUpdateCommand) Synopsis() string {
+	return "Update an existing ACL role"
+}
+
+func (c *ACLRoleUpdateCommand) Name() string { return "acl role update" }
+
+func (c *ACLRoleUpdateCommand) Run(args []string) int {
+	var name, description string
+	var json bool
+	var policies []string
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.StringVar(&name, "name", "", "")
+	flags.StringVar(&description, "description", "", "")
+	flags.BoolVar(&json, "json", false, "")
+	flags.Var((funcVar)(func(s string) error {
+		policies = append(policies, s)
+		return nil
+	}), "policy", "")
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we got exactly one argument
+	args = flags.Args()
+	if l := len(args); l != 1 {
+		c.Ui.Error("This command takes one argument: <role_id>")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	roleID := args[0]
+
+	// Get the HTTP client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	// Get the specified role
+	role, _, err := client.ACLRoles().Info(roleID, nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error fetching ACL role: %s", err))
+		return 1
+	}
+
+	// Apply the requested changes
+	if name != "" {
+		role.Name = name
+	}
+	if description != "" {
+		role.Description = description
+	}
+	if len(policies) != 0 {
+		role.Policies = aclRolePolicyLinks(policies)
+	}
+
+	// Update the role
+	updated, _, err := client.ACLRoles().Update(role, nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error updating ACL role: %s", err))
+		return 1
+	}
+
+	if json {
+		out, err := Format(json, "", updated)
+		if err != nil {
+			c.Ui.Error(err.Error())
+			return 1
+		}
+		c.Ui.Output(out)
+		return 0
+	}
+
+	c.Ui.Output(formatKVACLRole(updated))
+	return 0
+}
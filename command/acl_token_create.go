@@ -3,6 +3,7 @@ package command
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/nomad/api"
 	"github.com/posener/complete"
@@ -36,6 +37,19 @@ Create Options:
   -policy=""
     Specifies a policy to associate with the token. Can be specified multiple times,
     but only with client type tokens.
+
+  -role-id=""
+    Specifies the ID of an ACL role to link to the token. Can be specified
+    multiple times, but only with client type tokens.
+
+  -role-name=""
+    Specifies the name of an ACL role to link to the token. Can be specified
+    multiple times, but only with client type tokens.
+
+  -ttl=""
+    Specifies the duration after which the token expires, such as "1h". By
+    default the token never expires. Expired tokens can no longer be used
+    and are eventually deleted.
 `
 	return strings.TrimSpace(helpText)
 }
@@ -43,10 +57,13 @@ Create Options:
 func (c *ACLTokenCreateCommand) AutocompleteFlags() complete.Flags {
 	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
 		complete.Flags{
-			"name":   complete.PredictAnything,
-			"type":   complete.PredictAnything,
-			"global": complete.PredictNothing,
-			"policy": complete.PredictAnything,
+			"name":      complete.PredictAnything,
+			"type":      complete.PredictAnything,
+			"global":    complete.PredictNothing,
+			"policy":    complete.PredictAnything,
+			"role-id":   complete.PredictAnything,
+			"role-name": complete.PredictAnything,
+			"ttl":       complete.PredictAnything,
 		})
 }
 
@@ -63,7 +80,9 @@ func (c *ACLTokenCreateCommand) Name() string { return "acl token create" }
 func (c *ACLTokenCreateCommand) Run(args []string) int {
 	var name, tokenType string
 	var global bool
+	var ttl time.Duration
 	var policies []string
+	var roles []*api.ACLTokenRoleLink
 	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
 	flags.Usage = func() { c.Ui.Output(c.Help()) }
 	flags.StringVar(&name, "name", "", "")
@@ -73,6 +92,15 @@ func (c *ACLTokenCreateCommand) Run(args []string) int {
 		policies = append(policies, s)
 		return nil
 	}), "policy", "")
+	flags.Var((funcVar)(func(s string) error {
+		roles = append(roles, &api.ACLTokenRoleLink{ID: s})
+		return nil
+	}), "role-id", "")
+	flags.Var((funcVar)(func(s string) error {
+		roles = append(roles, &api.ACLTokenRoleLink{Name: s})
+		return nil
+	}), "role-name", "")
+	flags.DurationVar(&ttl, "ttl", 0, "")
 	if err := flags.Parse(args); err != nil {
 		return 1
 	}
@@ -85,12 +113,19 @@ func (c *ACLTokenCreateCommand) Run(args []string) int {
 		return 1
 	}
 
+	if ttl < 0 {
+		c.Ui.Error("TTL must not be negative")
+		return 1
+	}
+
 	// Setup the token
 	tk := &api.ACLToken{
-		Name:     name,
-		Type:     tokenType,
-		Policies: policies,
-		Global:   global,
+		Name:          name,
+		Type:          tokenType,
+		Policies:      policies,
+		Roles:         roles,
+		Global:        global,
+		ExpirationTTL: ttl,
 	}
 
 	// Get the HTTP client
@@ -6,8 +6,11 @@ import (
 	"testing"
 
 	"github.com/hashicorp/nomad/command/agent"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/mitchellh/cli"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestACLTokenCreateCommand(t *testing.T) {
@@ -43,3 +46,64 @@ func TestACLTokenCreateCommand(t *testing.T) {
 		t.Fatalf("bad: %v", out)
 	}
 }
+
+func TestACLTokenCreateCommand_TTL(t *testing.T) {
+	require := require.New(t)
+	t.Parallel()
+	config := func(c *agent.Config) {
+		c.ACL.Enabled = true
+	}
+
+	srv, _, url := testServer(t, true, config)
+	defer srv.Shutdown()
+
+	// Bootstrap an initial ACL token
+	token := srv.RootToken
+	require.NotNil(token, "failed to bootstrap ACL token")
+
+	ui := new(cli.MockUi)
+	cmd := &ACLTokenCreateCommand{Meta: Meta{Ui: ui, flagAddress: url}}
+
+	// Request to create a new token with a negative TTL
+	code := cmd.Run([]string{"-address=" + url, "-token=" + token.SecretID, "-ttl=-1h"})
+	require.Equal(1, code)
+	require.Contains(ui.ErrorWriter.String(), "TTL must not be negative")
+
+	// Request to create a new token with a TTL
+	code = cmd.Run([]string{"-address=" + url, "-token=" + token.SecretID, "-policy=foo", "-ttl=1h"})
+	require.Equal(0, code)
+	require.Contains(ui.OutputWriter.String(), "Expiry Time")
+}
+
+func TestACLTokenCreateCommand_Roles(t *testing.T) {
+	require := require.New(t)
+	t.Parallel()
+	config := func(c *agent.Config) {
+		c.ACL.Enabled = true
+	}
+
+	srv, _, url := testServer(t, true, config)
+	state := srv.Agent.Server().State()
+	defer srv.Shutdown()
+
+	// Bootstrap an initial ACL token
+	token := srv.RootToken
+	require.NotNil(token, "failed to bootstrap ACL token")
+
+	// Create a role to link the token to
+	role := mock.ACLRole()
+	require.NoError(state.UpsertACLRoles(1000, []*structs.ACLRole{role}))
+
+	ui := new(cli.MockUi)
+	cmd := &ACLTokenCreateCommand{Meta: Meta{Ui: ui, flagAddress: url}}
+
+	// Request to create a new token linked to an unknown role
+	code := cmd.Run([]string{"-address=" + url, "-token=" + token.SecretID, "-role-name=unknown"})
+	require.Equal(1, code)
+	require.Contains(ui.ErrorWriter.String(), "cannot find role")
+
+	// Request to create a new token linked to the role by ID
+	code = cmd.Run([]string{"-address=" + url, "-token=" + token.SecretID, "-role-id=" + role.ID})
+	require.Equal(0, code, ui.ErrorWriter.String())
+	require.Contains(ui.OutputWriter.String(), role.Name)
+}
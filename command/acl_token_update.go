@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/hashicorp/nomad/api"
 	"github.com/posener/complete"
 )
 
@@ -35,6 +36,14 @@ Update Options:
   -policy=""
     Specifies a policy to associate with the token. Can be specified multiple times,
     but only with client type tokens.
+
+  -role-id=""
+    Specifies the ID of an ACL role to link to the token. Can be specified
+    multiple times, but only with client type tokens.
+
+  -role-name=""
+    Specifies the name of an ACL role to link to the token. Can be specified
+    multiple times, but only with client type tokens.
 `
 
 	return strings.TrimSpace(helpText)
@@ -43,10 +52,12 @@ Update Options:
 func (c *ACLTokenUpdateCommand) AutocompleteFlags() complete.Flags {
 	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
 		complete.Flags{
-			"name":   complete.PredictAnything,
-			"type":   complete.PredictAnything,
-			"global": complete.PredictNothing,
-			"policy": complete.PredictAnything,
+			"name":      complete.PredictAnything,
+			"type":      complete.PredictAnything,
+			"global":    complete.PredictNothing,
+			"policy":    complete.PredictAnything,
+			"role-id":   complete.PredictAnything,
+			"role-name": complete.PredictAnything,
 		})
 }
 
@@ -64,6 +75,7 @@ func (c *ACLTokenUpdateCommand) Run(args []string) int {
 	var name, tokenType string
 	var global bool
 	var policies []string
+	var roles []*api.ACLTokenRoleLink
 	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
 	flags.Usage = func() { c.Ui.Output(c.Help()) }
 	flags.StringVar(&name, "name", "", "")
@@ -73,6 +85,14 @@ func (c *ACLTokenUpdateCommand) Run(args []string) int {
 		policies = append(policies, s)
 		return nil
 	}), "policy", "")
+	flags.Var((funcVar)(func(s string) error {
+		roles = append(roles, &api.ACLTokenRoleLink{ID: s})
+		return nil
+	}), "role-id", "")
+	flags.Var((funcVar)(func(s string) error {
+		roles = append(roles, &api.ACLTokenRoleLink{Name: s})
+		return nil
+	}), "role-name", "")
 	if err := flags.Parse(args); err != nil {
 		return 1
 	}
@@ -119,6 +139,10 @@ func (c *ACLTokenUpdateCommand) Run(args []string) int {
 		token.Policies = policies
 	}
 
+	if len(roles) != 0 {
+		token.Roles = roles
+	}
+
 	// Update the token
 	updatedToken, _, err := client.ACLTokens().Update(token, nil)
 	if err != nil {
@@ -5,9 +5,11 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"github.com/hashicorp/nomad/lib/auth"
 	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestHTTP_ACLPolicyList(t *testing.T) {
@@ -448,3 +450,260 @@ func TestHTTP_ACLTokenDelete(t *testing.T) {
 		assert.Nil(t, out)
 	})
 }
+
+func TestHTTP_ACLRoleCRUD(t *testing.T) {
+	t.Parallel()
+	httpACLTest(t, nil, func(s *TestAgent) {
+		require := require.New(t)
+
+		// Create the policy of the role
+		p1 := mock.ACLPolicy()
+		args := structs.ACLPolicyUpsertRequest{
+			Policies: []*structs.ACLPolicy{p1},
+			WriteRequest: structs.WriteRequest{
+				Region:    "global",
+				AuthToken: s.RootToken.SecretID,
+			},
+		}
+		var resp structs.GenericResponse
+		require.NoError(s.Agent.RPC("ACL.UpsertPolicies", &args, &resp))
+
+		// Create the role
+		r1 := mock.ACLRole()
+		r1.ID = ""
+		r1.Policies = []*structs.ACLRolePolicyLink{{Name: p1.Name}}
+		req, err := http.NewRequest("PUT", "/v1/acl/role", encodeReq(r1))
+		require.NoError(err)
+		respW := httptest.NewRecorder()
+		setToken(req, s.RootToken)
+		obj, err := s.Server.ACLRoleSpecificRequest(respW, req)
+		require.NoError(err)
+		require.NotEmpty(respW.HeaderMap.Get("X-Nomad-Index"))
+		created := obj.(*structs.ACLRole)
+		require.NotEmpty(created.ID)
+		require.Equal(r1.Name, created.Name)
+
+		// List the roles
+		req, err = http.NewRequest("GET", "/v1/acl/roles", nil)
+		require.NoError(err)
+		respW = httptest.NewRecorder()
+		setToken(req, s.RootToken)
+		obj, err = s.Server.ACLRolesRequest(respW, req)
+		require.NoError(err)
+		require.NotEmpty(respW.HeaderMap.Get("X-Nomad-Index"))
+		require.Len(obj.([]*structs.ACLRoleListStub), 1)
+
+		// Read the role by ID
+		req, err = http.NewRequest("GET", "/v1/acl/role/"+created.ID, nil)
+		require.NoError(err)
+		respW = httptest.NewRecorder()
+		setToken(req, s.RootToken)
+		obj, err = s.Server.ACLRoleSpecificRequest(respW, req)
+		require.NoError(err)
+		require.Equal(created, obj.(*structs.ACLRole))
+
+		// Read the role by name
+		req, err = http.NewRequest("GET", "/v1/acl/role/name/"+created.Name, nil)
+		require.NoError(err)
+		respW = httptest.NewRecorder()
+		setToken(req, s.RootToken)
+		obj, err = s.Server.ACLRoleSpecificRequest(respW, req)
+		require.NoError(err)
+		require.Equal(created, obj.(*structs.ACLRole))
+
+		// Updating with a mismatched ID should fail
+		req, err = http.NewRequest("PUT", "/v1/acl/role/"+created.ID, encodeReq(r1))
+		require.NoError(err)
+		respW = httptest.NewRecorder()
+		setToken(req, s.RootToken)
+		_, err = s.Server.ACLRoleSpecificRequest(respW, req)
+		require.Error(err)
+		require.Contains(err.Error(), "does not match")
+
+		// Delete the role
+		req, err = http.NewRequest("DELETE", "/v1/acl/role/"+created.ID, nil)
+		require.NoError(err)
+		respW = httptest.NewRecorder()
+		setToken(req, s.RootToken)
+		obj, err = s.Server.ACLRoleSpecificRequest(respW, req)
+		require.NoError(err)
+		require.Nil(obj)
+
+		out, err := s.Agent.server.State().ACLRoleByID(nil, created.ID)
+		require.NoError(err)
+		require.Nil(out)
+
+		// Reading it again should be a 404
+		req, err = http.NewRequest("GET", "/v1/acl/role/"+created.ID, nil)
+		require.NoError(err)
+		respW = httptest.NewRecorder()
+		setToken(req, s.RootToken)
+		_, err = s.Server.ACLRoleSpecificRequest(respW, req)
+		require.Error(err)
+		require.Contains(err.Error(), "not found")
+	})
+}
+
+func TestHTTP_ACLAuthMethodCRUD(t *testing.T) {
+	t.Parallel()
+	httpACLTest(t, nil, func(s *TestAgent) {
+		require := require.New(t)
+
+		// Create the auth method
+		method := mock.ACLAuthMethod()
+		req, err := http.NewRequest("PUT", "/v1/acl/auth-method", encodeReq(method))
+		require.NoError(err)
+		respW := httptest.NewRecorder()
+		setToken(req, s.RootToken)
+		obj, err := s.Server.ACLAuthMethodSpecificRequest(respW, req)
+		require.NoError(err)
+		require.NotEmpty(respW.HeaderMap.Get("X-Nomad-Index"))
+		created := obj.(*structs.ACLAuthMethod)
+		require.Equal(method.Name, created.Name)
+
+		// List the auth methods without a token
+		req, err = http.NewRequest("GET", "/v1/acl/auth-methods", nil)
+		require.NoError(err)
+		respW = httptest.NewRecorder()
+		obj, err = s.Server.ACLAuthMethodsRequest(respW, req)
+		require.NoError(err)
+		require.NotEmpty(respW.HeaderMap.Get("X-Nomad-Index"))
+		require.Len(obj.([]*structs.ACLAuthMethodListStub), 1)
+
+		// Read the auth method
+		req, err = http.NewRequest("GET", "/v1/acl/auth-method/"+method.Name, nil)
+		require.NoError(err)
+		respW = httptest.NewRecorder()
+		setToken(req, s.RootToken)
+		obj, err = s.Server.ACLAuthMethodSpecificRequest(respW, req)
+		require.NoError(err)
+		require.Equal(created, obj.(*structs.ACLAuthMethod))
+
+		// Updating with a mismatched name should fail
+		req, err = http.NewRequest("PUT", "/v1/acl/auth-method/other", encodeReq(method))
+		require.NoError(err)
+		respW = httptest.NewRecorder()
+		setToken(req, s.RootToken)
+		_, err = s.Server.ACLAuthMethodSpecificRequest(respW, req)
+		require.Error(err)
+		require.Contains(err.Error(), "does not match")
+
+		// Create a binding rule
+		rule := mock.ACLBindingRule(method.Name)
+		rule.ID = ""
+		req, err = http.NewRequest("PUT", "/v1/acl/binding-rule", encodeReq(rule))
+		require.NoError(err)
+		respW = httptest.NewRecorder()
+		setToken(req, s.RootToken)
+		obj, err = s.Server.ACLBindingRuleSpecificRequest(respW, req)
+		require.NoError(err)
+		createdRule := obj.(*structs.ACLBindingRule)
+		require.NotEmpty(createdRule.ID)
+
+		// List the binding rules
+		req, err = http.NewRequest("GET", "/v1/acl/binding-rules", nil)
+		require.NoError(err)
+		respW = httptest.NewRecorder()
+		setToken(req, s.RootToken)
+		obj, err = s.Server.ACLBindingRulesRequest(respW, req)
+		require.NoError(err)
+		require.Len(obj.([]*structs.ACLBindingRuleListStub), 1)
+
+		// Read the binding rule
+		req, err = http.NewRequest("GET", "/v1/acl/binding-rule/"+createdRule.ID, nil)
+		require.NoError(err)
+		respW = httptest.NewRecorder()
+		setToken(req, s.RootToken)
+		obj, err = s.Server.ACLBindingRuleSpecificRequest(respW, req)
+		require.NoError(err)
+		require.Equal(createdRule, obj.(*structs.ACLBindingRule))
+
+		// Delete the binding rule
+		req, err = http.NewRequest("DELETE", "/v1/acl/binding-rule/"+createdRule.ID, nil)
+		require.NoError(err)
+		respW = httptest.NewRecorder()
+		setToken(req, s.RootToken)
+		obj, err = s.Server.ACLBindingRuleSpecificRequest(respW, req)
+		require.NoError(err)
+		require.Nil(obj)
+
+		req, err = http.NewRequest("GET", "/v1/acl/binding-rule/"+createdRule.ID, nil)
+		require.NoError(err)
+		respW = httptest.NewRecorder()
+		setToken(req, s.RootToken)
+		_, err = s.Server.ACLBindingRuleSpecificRequest(respW, req)
+		require.Error(err)
+		require.Contains(err.Error(), "not found")
+
+		// Delete the auth method
+		req, err = http.NewRequest("DELETE", "/v1/acl/auth-method/"+method.Name, nil)
+		require.NoError(err)
+		respW = httptest.NewRecorder()
+		setToken(req, s.RootToken)
+		obj, err = s.Server.ACLAuthMethodSpecificRequest(respW, req)
+		require.NoError(err)
+		require.Nil(obj)
+
+		out, err := s.Agent.server.State().ACLAuthMethodByName(nil, method.Name)
+		require.NoError(err)
+		require.Nil(out)
+
+		// Reading it again should be a 404
+		req, err = http.NewRequest("GET", "/v1/acl/auth-method/"+method.Name, nil)
+		require.NoError(err)
+		respW = httptest.NewRecorder()
+		setToken(req, s.RootToken)
+		_, err = s.Server.ACLAuthMethodSpecificRequest(respW, req)
+		require.Error(err)
+		require.Contains(err.Error(), "not found")
+	})
+}
+
+func TestHTTP_ACLLogin(t *testing.T) {
+	t.Parallel()
+	httpACLTest(t, nil, func(s *TestAgent) {
+		require := require.New(t)
+
+		issuer := auth.NewTestIssuer(t)
+		defer issuer.Close()
+
+		// Create an auth method trusting the issuer and a binding rule
+		method := mock.ACLAuthMethod()
+		method.Config.JWKSURL = issuer.JWKSURL()
+		rule := mock.ACLBindingRule(method.Name)
+		state := s.Agent.server.State()
+		require.NoError(state.UpsertACLAuthMethods(1000, []*structs.ACLAuthMethod{method}))
+		require.NoError(state.UpsertACLBindingRules(1001, []*structs.ACLBindingRule{rule}))
+
+		// Log in
+		login := &structs.ACLLoginRequest{
+			AuthMethodName: method.Name,
+			LoginToken: issuer.SignJWT(map[string]interface{}{
+				"aud":    "nomad",
+				"groups": []string{"engineering"},
+			}),
+		}
+		req, err := http.NewRequest("POST", "/v1/acl/login", encodeReq(login))
+		require.NoError(err)
+		respW := httptest.NewRecorder()
+		obj, err := s.Server.ACLLoginRequest(respW, req)
+		require.NoError(err)
+		require.NotEmpty(respW.HeaderMap.Get("X-Nomad-Index"))
+		token := obj.(*structs.ACLToken)
+		require.Equal([]string{rule.BindName}, token.Policies)
+		require.True(token.HasExpirationTime())
+
+		// Tokens of other issuers are rejected
+		other := auth.NewTestIssuer(t)
+		defer other.Close()
+		login.LoginToken = other.SignJWT(map[string]interface{}{
+			"aud":    "nomad",
+			"groups": []string{"engineering"},
+		})
+		req, err = http.NewRequest("POST", "/v1/acl/login", encodeReq(login))
+		require.NoError(err)
+		respW = httptest.NewRecorder()
+		_, err = s.Server.ACLLoginRequest(respW, req)
+		require.Error(err)
+	})
+}
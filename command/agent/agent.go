@@ -316,6 +316,26 @@ func convertServerConfig(agentConfig *Config) (*nomad.Config, error) {
 		conf.MaxHeartbeatsPerSecond = maxHPS
 	}
 
+	conf.EvalFairShare = agentConfig.Server.EvalFairShare
+	for ns, weight := range agentConfig.Server.EvalNamespaceWeights {
+		if weight < 1 {
+			return nil, fmt.Errorf("eval_namespace_weights of namespace %q must be at least 1", ns)
+		}
+	}
+	conf.EvalNamespaceWeights = agentConfig.Server.EvalNamespaceWeights
+
+	if size := agentConfig.Server.EventBufferSize; size < 0 {
+		return nil, fmt.Errorf("event_buffer_size must be positive: %v", size)
+	} else if size > 0 {
+		conf.EventBufferSize = size
+	}
+
+	if max := agentConfig.Server.MaxInflightPlans; max < 0 {
+		return nil, fmt.Errorf("max_inflight_plans must be positive: %v", max)
+	} else if max > 0 {
+		conf.MaxInflightPlans = max
+	}
+
 	if *agentConfig.Consul.AutoAdvertise && agentConfig.Consul.ServerServiceName == "" {
 		return nil, fmt.Errorf("server_service_name must be set when auto_advertise is enabled")
 	}
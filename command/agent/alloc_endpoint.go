@@ -103,11 +103,23 @@ func (s *HTTPServer) allocGet(allocID string, resp http.ResponseWriter, req *htt
 		alloc = alloc.Copy()
 		alloc.Job.Payload = decoded
 	}
+	alloc = scrubAllocIdentities(alloc)
 	alloc.SetEventDisplayMessages()
 
 	return alloc, nil
 }
 
+// scrubAllocIdentities returns the allocation without the workload identities
+// of its tasks, which are only handed to the clients running them.
+func scrubAllocIdentities(alloc *structs.Allocation) *structs.Allocation {
+	if alloc.SignedIdentities == nil {
+		return alloc
+	}
+	alloc = alloc.CopySkipJob()
+	alloc.SignedIdentities = nil
+	return alloc
+}
+
 func (s *HTTPServer) allocStop(allocID string, resp http.ResponseWriter, req *http.Request) (interface{}, error) {
 	if !(req.Method == "POST" || req.Method == "PUT") {
 		return nil, CodedError(405, ErrInvalidMethod)
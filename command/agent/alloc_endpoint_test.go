@@ -205,6 +205,40 @@ func TestHTTP_AllocQuery(t *testing.T) {
 	})
 }
 
+func TestHTTP_AllocQuery_SignedIdentities(t *testing.T) {
+	t.Parallel()
+	httpTest(t, nil, func(s *TestAgent) {
+		require := require.New(t)
+
+		// Directly manipulate the state
+		state := s.Agent.server.State()
+		alloc := mock.Alloc()
+		alloc.SignedIdentities = map[string]string{"web": "header.payload.signature"}
+		require.NoError(state.UpsertJobSummary(999, mock.JobSummary(alloc.JobID)))
+		require.NoError(state.UpsertAllocs(1000, []*structs.Allocation{alloc}))
+
+		// The workload identities are not exposed
+		req, err := http.NewRequest("GET", "/v1/allocation/"+alloc.ID, nil)
+		require.NoError(err)
+		obj, err := s.Server.AllocSpecificRequest(httptest.NewRecorder(), req)
+		require.NoError(err)
+		require.Nil(obj.(*structs.Allocation).SignedIdentities)
+
+		req, err = http.NewRequest("GET", "/v1/node/"+alloc.NodeID+"/allocations", nil)
+		require.NoError(err)
+		obj, err = s.Server.NodeSpecificRequest(httptest.NewRecorder(), req)
+		require.NoError(err)
+		allocs := obj.([]*structs.Allocation)
+		require.Len(allocs, 1)
+		require.Nil(allocs[0].SignedIdentities)
+
+		// The allocation in state is left untouched
+		out, err := state.AllocByID(nil, alloc.ID)
+		require.NoError(err)
+		require.Len(out.SignedIdentities, 1)
+	})
+}
+
 func TestHTTP_AllocQuery_Payload(t *testing.T) {
 	t.Parallel()
 	httpTest(t, nil, func(s *TestAgent) {
@@ -398,6 +398,23 @@ type ServerConfig struct {
 	// to meet the target rate.
 	MaxHeartbeatsPerSecond float64 `hcl:"max_heartbeats_per_second"`
 
+	// EvalFairShare enables dequeuing ready evaluations round-robin across
+	// namespaces so that a burst of evaluations in one namespace does not
+	// starve the other namespaces.
+	EvalFairShare bool `hcl:"eval_fair_share"`
+
+	// EvalNamespaceWeights is the number of evaluations dequeued from a
+	// namespace on each of its turns when EvalFairShare is enabled.
+	EvalNamespaceWeights map[string]int `hcl:"eval_namespace_weights"`
+
+	// EventBufferSize is the number of Raft indexes whose events are buffered
+	// for the event stream.
+	EventBufferSize int `hcl:"event_buffer_size"`
+
+	// MaxInflightPlans is the maximum number of plans touching disjoint sets
+	// of nodes the leader applies through Raft concurrently.
+	MaxInflightPlans int `hcl:"max_inflight_plans"`
+
 	// StartJoin is a list of addresses to attempt to join when the
 	// agent starts. If Serf is unable to communicate with any of these
 	// addresses, then the agent will error and exit.
@@ -1309,6 +1326,24 @@ func (a *ServerConfig) Merge(b *ServerConfig) *ServerConfig {
 	if b.MaxHeartbeatsPerSecond != 0.0 {
 		result.MaxHeartbeatsPerSecond = b.MaxHeartbeatsPerSecond
 	}
+	if b.EvalFairShare {
+		result.EvalFairShare = true
+	}
+	if b.EvalNamespaceWeights != nil {
+		result.EvalNamespaceWeights = make(map[string]int, len(a.EvalNamespaceWeights)+len(b.EvalNamespaceWeights))
+		for ns, weight := range a.EvalNamespaceWeights {
+			result.EvalNamespaceWeights[ns] = weight
+		}
+		for ns, weight := range b.EvalNamespaceWeights {
+			result.EvalNamespaceWeights[ns] = weight
+		}
+	}
+	if b.EventBufferSize != 0 {
+		result.EventBufferSize = b.EventBufferSize
+	}
+	if b.MaxInflightPlans != 0 {
+		result.MaxInflightPlans = b.MaxInflightPlans
+	}
 	if b.RetryMaxAttempts != 0 {
 		result.RetryMaxAttempts = b.RetryMaxAttempts
 	}
@@ -106,6 +106,10 @@ var basicConfig = &Config{
 		MinHeartbeatTTL:        33 * time.Second,
 		MinHeartbeatTTLHCL:     "33s",
 		MaxHeartbeatsPerSecond: 11.0,
+		EvalFairShare:          true,
+		EvalNamespaceWeights:   map[string]int{"batch": 3},
+		EventBufferSize:        200,
+		MaxInflightPlans:       4,
 		RetryJoin:              []string{"1.1.1.1", "2.2.2.2"},
 		StartJoin:              []string{"1.1.1.1", "2.2.2.2"},
 		RetryInterval:          15 * time.Second,
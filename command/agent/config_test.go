@@ -288,6 +288,10 @@ func TestConfig_Merge(t *testing.T) {
 			HeartbeatGrace:         2 * time.Minute,
 			MinHeartbeatTTL:        2 * time.Minute,
 			MaxHeartbeatsPerSecond: 200.0,
+			EvalFairShare:          true,
+			EvalNamespaceWeights:   map[string]int{"batch": 3},
+			EventBufferSize:        200,
+			MaxInflightPlans:       4,
 			RejoinAfterLeave:       true,
 			StartJoin:              []string{"1.1.1.1"},
 			RetryJoin:              []string{"1.1.1.1"},
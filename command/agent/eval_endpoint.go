@@ -4,18 +4,28 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/hashicorp/nomad/api"
 	"github.com/hashicorp/nomad/nomad/structs"
 )
 
 func (s *HTTPServer) EvalsRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
-	if req.Method != "GET" {
+	switch req.Method {
+	case "GET":
+		return s.evalsList(resp, req)
+	case "DELETE":
+		return s.evalsDelete(resp, req)
+	default:
 		return nil, CodedError(405, ErrInvalidMethod)
 	}
+}
 
+func (s *HTTPServer) evalsList(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
 	args := structs.EvalListRequest{}
 	if s.parse(resp, req, &args.Region, &args.QueryOptions) {
 		return nil, nil
 	}
+	args.FilterJobID = req.URL.Query().Get("job")
+	args.FilterEvalStatus = req.URL.Query().Get("status")
 
 	var out structs.EvalListResponse
 	if err := s.agent.RPC("Eval.List", &args, &out); err != nil {
@@ -29,6 +39,49 @@ func (s *HTTPServer) EvalsRequest(resp http.ResponseWriter, req *http.Request) (
 	return out.Evaluations, nil
 }
 
+func (s *HTTPServer) evalsDelete(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	var body api.EvalDeleteRequest
+	if err := decodeBody(req, &body); err != nil {
+		return nil, CodedError(400, err.Error())
+	}
+
+	args := structs.EvalDeleteRequest{
+		Evals: body.EvalIDs,
+	}
+	s.parseWriteRequest(req, &args.WriteRequest)
+
+	var out structs.GenericResponse
+	if err := s.agent.RPC("Eval.Delete", &args, &out); err != nil {
+		return nil, err
+	}
+
+	setIndex(resp, out.Index)
+	return nil, nil
+}
+
+func (s *HTTPServer) EvalsBlockedRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	if req.Method != "GET" {
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+
+	args := structs.EvalListRequest{}
+	if s.parse(resp, req, &args.Region, &args.QueryOptions) {
+		return nil, nil
+	}
+	args.FilterJobID = req.URL.Query().Get("job")
+
+	var out structs.EvalBlockedResponse
+	if err := s.agent.RPC("Eval.Blocked", &args, &out); err != nil {
+		return nil, err
+	}
+
+	setMeta(resp, &out.QueryMeta)
+	if out.BlockedEvals == nil {
+		out.BlockedEvals = make([]*structs.BlockedEval, 0)
+	}
+	return out.BlockedEvals, nil
+}
+
 func (s *HTTPServer) EvalSpecificRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
 	path := strings.TrimPrefix(req.URL.Path, "/v1/evaluation/")
 	switch {
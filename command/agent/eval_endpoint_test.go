@@ -3,8 +3,10 @@ package agent
 import (
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
+	"github.com/hashicorp/nomad/api"
 	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/nomad/structs"
 )
@@ -54,6 +56,106 @@ func TestHTTP_EvalList(t *testing.T) {
 	})
 }
 
+func TestHTTP_EvalList_Filter(t *testing.T) {
+	t.Parallel()
+	httpTest(t, nil, func(s *TestAgent) {
+		// Directly manipulate the state
+		state := s.Agent.server.State()
+		eval1 := mock.Eval()
+		eval2 := mock.Eval()
+		eval2.Status = structs.EvalStatusComplete
+		err := state.UpsertEvals(1000,
+			[]*structs.Evaluation{eval1, eval2})
+		if err != nil {
+			t.Fatalf("err: %v", err)
+		}
+
+		// Make the HTTP request
+		req, err := http.NewRequest("GET", "/v1/evaluations?status=complete&job="+eval2.JobID, nil)
+		if err != nil {
+			t.Fatalf("err: %v", err)
+		}
+		respW := httptest.NewRecorder()
+
+		// Make the request
+		obj, err := s.Server.EvalsRequest(respW, req)
+		if err != nil {
+			t.Fatalf("err: %v", err)
+		}
+
+		// Check the eval
+		e := obj.([]*structs.Evaluation)
+		if len(e) != 1 || e[0].ID != eval2.ID {
+			t.Fatalf("bad: %#v", e)
+		}
+	})
+}
+
+func TestHTTP_EvalsDelete(t *testing.T) {
+	t.Parallel()
+	httpTest(t, nil, func(s *TestAgent) {
+		// Directly manipulate the state
+		state := s.Agent.server.State()
+		eval := mock.Eval()
+		err := state.UpsertEvals(1000, []*structs.Evaluation{eval})
+		if err != nil {
+			t.Fatalf("err: %v", err)
+		}
+
+		// Make the HTTP request
+		body := encodeReq(&api.EvalDeleteRequest{EvalIDs: []string{eval.ID}})
+		req, err := http.NewRequest("DELETE", "/v1/evaluations", body)
+		if err != nil {
+			t.Fatalf("err: %v", err)
+		}
+		respW := httptest.NewRecorder()
+
+		// Deleting evals requires the eval broker to be paused
+		_, err = s.Server.EvalsRequest(respW, req)
+		if err == nil || !strings.Contains(err.Error(), "eval broker must be paused") {
+			t.Fatalf("expected error, got: %v", err)
+		}
+	})
+}
+
+func TestHTTP_EvalsBlocked(t *testing.T) {
+	t.Parallel()
+	httpTest(t, nil, func(s *TestAgent) {
+		// Make the HTTP request
+		req, err := http.NewRequest("GET", "/v1/evaluations/blocked", nil)
+		if err != nil {
+			t.Fatalf("err: %v", err)
+		}
+		respW := httptest.NewRecorder()
+
+		// Make the request
+		obj, err := s.Server.EvalsBlockedRequest(respW, req)
+		if err != nil {
+			t.Fatalf("err: %v", err)
+		}
+
+		// Check for the index
+		if respW.HeaderMap.Get("X-Nomad-Index") == "" {
+			t.Fatalf("missing index")
+		}
+
+		// Check that an empty list is returned
+		e := obj.([]*structs.BlockedEval)
+		if e == nil || len(e) != 0 {
+			t.Fatalf("bad: %#v", e)
+		}
+
+		// Only GET is allowed
+		req, err = http.NewRequest("PUT", "/v1/evaluations/blocked", nil)
+		if err != nil {
+			t.Fatalf("err: %v", err)
+		}
+		if _, err := s.Server.EvalsBlockedRequest(httptest.NewRecorder(), req); err == nil {
+			t.Fatalf("expected error")
+		}
+	})
+}
+
 func TestHTTP_EvalPrefixList(t *testing.T) {
 	t.Parallel()
 	httpTest(t, nil, func(s *TestAgent) {
@@ -0,0 +1,140 @@
+package agent
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/docker/docker/pkg/ioutils"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/ugorji/go/codec"
+)
+
+// EventStream streams the events published by the servers as newline delimited
+// JSON.
+func (s *HTTPServer) EventStream(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	if req.Method != "GET" {
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+
+	query := req.URL.Query()
+	args := structs.EventStreamRequest{
+		Topics: parseEventTopics(query["topic"]),
+	}
+	if indexStr := query.Get("index"); indexStr != "" {
+		index, err := strconv.ParseUint(indexStr, 10, 64)
+		if err != nil {
+			return nil, CodedError(400, fmt.Sprintf("Invalid index: %v", err))
+		}
+		args.Index = index
+	}
+	s.parse(resp, req, &args.QueryOptions.Region, &args.QueryOptions)
+
+	// Stream from the local server, or from a server of the client
+	var handler structs.StreamingRpcHandler
+	var handlerErr error
+	if srv := s.agent.Server(); srv != nil {
+		handler, handlerErr = srv.StreamingRpcHandler("Event.Stream")
+	} else {
+		handler, handlerErr = s.agent.Client().RemoteStreamingRpcHandler("Event.Stream")
+	}
+	if handlerErr != nil {
+		return nil, CodedError(500, handlerErr.Error())
+	}
+
+	httpPipe, handlerPipe := net.Pipe()
+	decoder := codec.NewDecoder(httpPipe, structs.MsgpackHandle)
+	encoder := codec.NewEncoder(httpPipe, structs.MsgpackHandle)
+
+	ctx, cancel := context.WithCancel(req.Context())
+	go func() {
+		<-ctx.Done()
+		httpPipe.Close()
+	}()
+
+	// Create an output that gets flushed on every write
+	resp.Header().Set("Content-Type", "application/x-ndjson")
+	output := ioutils.NewWriteFlusher(resp)
+
+	// create an error channel to handle errors
+	errCh := make(chan HTTPCodedError, 2)
+
+	// stream response
+	go func() {
+		defer cancel()
+
+		// Send the request
+		if err := encoder.Encode(args); err != nil {
+			errCh <- CodedError(500, err.Error())
+			return
+		}
+
+		for {
+			select {
+			case <-ctx.Done():
+				errCh <- nil
+				return
+			default:
+			}
+
+			var res cstructs.StreamErrWrapper
+			if err := decoder.Decode(&res); err != nil {
+				errCh <- CodedError(500, err.Error())
+				return
+			}
+			decoder.Reset(httpPipe)
+
+			if err := res.Error; err != nil {
+				code := 500
+				if err.Code != nil {
+					code = int(*err.Code)
+				}
+				errCh <- CodedError(code, err.Error())
+				return
+			}
+
+			// Write each message on its own line
+			payload := append(res.Payload, '\n')
+			if _, err := io.Copy(output, bytes.NewReader(payload)); err != nil {
+				errCh <- CodedError(500, err.Error())
+				return
+			}
+		}
+	}()
+
+	handler(handlerPipe)
+	cancel()
+	codedErr := <-errCh
+
+	if codedErr != nil &&
+		(codedErr == io.EOF ||
+			strings.Contains(codedErr.Error(), "closed") ||
+			strings.Contains(codedErr.Error(), "EOF")) {
+		codedErr = nil
+	}
+	return nil, codedErr
+}
+
+// parseEventTopics parses the topic query parameters of the form
+// "<topic>:<key>" or "<topic>", which subscribes to every key of the topic.
+func parseEventTopics(params []string) map[structs.Topic][]string {
+	if len(params) == 0 {
+		return nil
+	}
+
+	topics := make(map[structs.Topic][]string, len(params))
+	for _, param := range params {
+		topic, key := param, "*"
+		if i := strings.Index(param, ":"); i != -1 {
+			topic, key = param[:i], param[i+1:]
+		}
+		topics[structs.Topic(topic)] = append(topics[structs.Topic(topic)], key)
+	}
+	return topics
+}
@@ -0,0 +1,76 @@
+package agent
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTP_EventStream(t *testing.T) {
+	t.Parallel()
+
+	httpTest(t, nil, func(s *TestAgent) {
+		// invalid index
+		{
+			req, err := http.NewRequest("GET", "/v1/event/stream?index=foo", nil)
+			require.Nil(t, err)
+			resp := newClosableRecorder()
+
+			_, err = s.Server.EventStream(resp, req)
+			require.Error(t, err)
+			require.Equal(t, 400, err.(HTTPCodedError).Code())
+		}
+
+		// Register a job
+		job := mock.Job()
+		args := structs.JobRegisterRequest{
+			Job: job,
+			WriteRequest: structs.WriteRequest{
+				Region:    "global",
+				Namespace: structs.DefaultNamespace,
+			},
+		}
+		var regResp structs.JobRegisterResponse
+		require.Nil(t, s.Agent.RPC("Job.Register", &args, &regResp))
+
+		// Stream the events of the job from the start of the buffer
+		req, err := http.NewRequest("GET", "/v1/event/stream?index=1&topic=Job:"+job.ID, nil)
+		require.Nil(t, err)
+		resp := newClosableRecorder()
+		defer resp.Close()
+
+		go func() {
+			_, err := s.Server.EventStream(resp, req)
+			require.NoError(t, err)
+		}()
+
+		want := fmt.Sprintf(`"Key":"%s"`, job.ID)
+		testutil.WaitForResult(func() (bool, error) {
+			got := resp.Body.String()
+			if strings.Contains(got, want) && strings.Contains(got, `"Type":"JobRegistered"`) {
+				return true, nil
+			}
+			return false, fmt.Errorf("missing expected event, got: %v, want: %v", got, want)
+		}, func(err error) {
+			require.Fail(t, err.Error())
+		})
+		require.Equal(t, "application/x-ndjson", resp.Header().Get("Content-Type"))
+	})
+}
+
+func TestHTTP_EventStream_ParseTopics(t *testing.T) {
+	t.Parallel()
+
+	require.Nil(t, parseEventTopics(nil))
+	require.Equal(t, map[structs.Topic][]string{
+		structs.TopicJob:        {"web", "api"},
+		structs.TopicAllocation: {"*"},
+		structs.TopicAll:        {"abc"},
+	}, parseEventTopics([]string{"Job:web", "Job:api", "Allocation", "*:abc"}))
+}
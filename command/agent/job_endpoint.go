@@ -81,6 +81,9 @@ func (s *HTTPServer) JobSpecificRequest(resp http.ResponseWriter, req *http.Requ
 	case strings.HasSuffix(path, "/stable"):
 		jobName := strings.TrimSuffix(path, "/stable")
 		return s.jobStable(resp, req, jobName)
+	case strings.HasSuffix(path, "/scale"):
+		jobName := strings.TrimSuffix(path, "/scale")
+		return s.jobScale(resp, req, jobName)
 	default:
 		return s.jobCRUD(resp, req, path)
 	}
@@ -157,6 +160,7 @@ func (s *HTTPServer) jobPlan(resp http.ResponseWriter, req *http.Request,
 		Job:            sJob,
 		Diff:           args.Diff,
 		PolicyOverride: args.PolicyOverride,
+		Explain:        args.Explain,
 		WriteRequest: structs.WriteRequest{
 			Region: sJob.Region,
 		},
@@ -545,6 +549,72 @@ func (s *HTTPServer) jobStable(resp http.ResponseWriter, req *http.Request,
 	return out, nil
 }
 
+func (s *HTTPServer) jobScale(resp http.ResponseWriter, req *http.Request,
+	jobName string) (interface{}, error) {
+	switch req.Method {
+	case "GET":
+		return s.jobScaleStatus(resp, req, jobName)
+	case "PUT", "POST":
+		return s.jobScaleAction(resp, req, jobName)
+	default:
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+}
+
+func (s *HTTPServer) jobScaleStatus(resp http.ResponseWriter, req *http.Request,
+	jobName string) (interface{}, error) {
+	args := structs.JobScaleStatusRequest{
+		JobID: jobName,
+	}
+	if s.parse(resp, req, &args.Region, &args.QueryOptions) {
+		return nil, nil
+	}
+
+	var out structs.JobScaleStatusResponse
+	if err := s.agent.RPC("Job.ScaleStatus", &args, &out); err != nil {
+		return nil, err
+	}
+
+	setMeta(resp, &out.QueryMeta)
+	if out.JobScaleStatus == nil {
+		return nil, CodedError(404, "job not found")
+	}
+	return out.JobScaleStatus, nil
+}
+
+func (s *HTTPServer) jobScaleAction(resp http.ResponseWriter, req *http.Request,
+	jobName string) (interface{}, error) {
+	var args api.ScalingRequest
+	if err := decodeBody(req, &args); err != nil {
+		return nil, CodedError(400, err.Error())
+	}
+
+	targetJob := args.Target[structs.ScalingTargetJob]
+	if targetJob != "" && targetJob != jobName {
+		return nil, CodedError(400, "job ID in payload did not match URL")
+	}
+
+	scaleReq := structs.JobScaleRequest{
+		JobID:          jobName,
+		Target:         args.Target,
+		Count:          args.Count,
+		PolicyOverride: args.PolicyOverride,
+		Message:        args.Message,
+		Error:          args.Error,
+		Meta:           args.Meta,
+	}
+	// parseWriteRequest overrides Namespace, Region and AuthToken
+	// based on values from the original http request
+	s.parseWriteRequest(req, &scaleReq.WriteRequest)
+
+	var out structs.JobRegisterResponse
+	if err := s.agent.RPC("Job.Scale", &scaleReq, &out); err != nil {
+		return nil, err
+	}
+	setIndex(resp, out.Index)
+	return out, nil
+}
+
 func (s *HTTPServer) jobSummaryRequest(resp http.ResponseWriter, req *http.Request, name string) (interface{}, error) {
 	args := structs.JobSummaryRequest{
 		JobID: name,
@@ -659,6 +729,9 @@ func ApiJobToStructJob(job *api.Job) *structs.Job {
 		}
 	}
 
+	j.DatacenterPreferences = ApiDatacenterPreferencesToStructs(job.DatacenterPreferences)
+	j.SchedulerAlgorithm = structs.SchedulerAlgorithm(job.SchedulerAlgorithm)
+
 	if job.Periodic != nil {
 		j.Periodic = &structs.PeriodicConfig{
 			Enabled:         *job.Periodic.Enabled,
@@ -701,6 +774,14 @@ func ApiTgToStructsTG(taskGroup *api.TaskGroup, tg *structs.TaskGroup) {
 	tg.Networks = ApiNetworkResourceToStructs(taskGroup.Networks)
 	tg.Services = ApiServicesToStructs(taskGroup.Services)
 
+	if taskGroup.MaxClientDisconnect != nil {
+		tg.MaxClientDisconnect = helper.TimeToPtr(*taskGroup.MaxClientDisconnect)
+	}
+
+	if taskGroup.Scaling != nil {
+		tg.Scaling = ApiScalingPolicyToStructs(tg.Count, taskGroup.Scaling)
+	}
+
 	tg.RestartPolicy = &structs.RestartPolicy{
 		Attempts: *taskGroup.RestartPolicy.Attempts,
 		Interval: *taskGroup.RestartPolicy.Interval,
@@ -741,6 +822,8 @@ func ApiTgToStructsTG(taskGroup *api.TaskGroup, tg *structs.TaskGroup) {
 		}
 	}
 
+	tg.DatacenterPreferences = ApiDatacenterPreferencesToStructs(taskGroup.DatacenterPreferences)
+
 	if l := len(taskGroup.Volumes); l != 0 {
 		tg.Volumes = make(map[string]*structs.VolumeRequest, l)
 		for k, v := range taskGroup.Volumes {
@@ -917,6 +1000,13 @@ func ApiTaskToStructsTask(apiTask *api.Task, structsTask *structs.Task) {
 			File: apiTask.DispatchPayload.File,
 		}
 	}
+
+	if apiTask.Lifecycle != nil {
+		structsTask.Lifecycle = &structs.TaskLifecycleConfig{
+			Hook:    apiTask.Lifecycle.Hook,
+			Sidecar: apiTask.Lifecycle.Sidecar,
+		}
+	}
 }
 
 func ApiResourcesToStructs(in *api.Resources) *structs.Resources {
@@ -925,10 +1015,21 @@ func ApiResourcesToStructs(in *api.Resources) *structs.Resources {
 	}
 
 	out := &structs.Resources{
-		CPU:      *in.CPU,
 		MemoryMB: *in.MemoryMB,
 	}
 
+	if in.CPU != nil {
+		out.CPU = *in.CPU
+	}
+
+	if in.Cores != nil {
+		out.Cores = *in.Cores
+	}
+
+	if in.MemoryMaxMB != nil {
+		out.MemoryMaxMB = *in.MemoryMaxMB
+	}
+
 	// COMPAT(0.10): Only being used to issue warnings
 	if in.IOPS != nil {
 		out.IOPS = *in.IOPS
@@ -1168,7 +1269,10 @@ func ApiAffinityToStructs(a1 *api.Affinity) *structs.Affinity {
 func ApiSpreadToStructs(a1 *api.Spread) *structs.Spread {
 	ret := &structs.Spread{}
 	ret.Attribute = a1.Attribute
-	ret.Weight = *a1.Weight
+	// Nested spreads inherit the weight of the outermost spread
+	if a1.Weight != nil {
+		ret.Weight = *a1.Weight
+	}
 	if a1.SpreadTarget != nil {
 		ret.SpreadTarget = make([]*structs.SpreadTarget, len(a1.SpreadTarget))
 		for i, st := range a1.SpreadTarget {
@@ -1178,5 +1282,44 @@ func ApiSpreadToStructs(a1 *api.Spread) *structs.Spread {
 			}
 		}
 	}
+	if a1.Nested != nil {
+		ret.Nested = ApiSpreadToStructs(a1.Nested)
+	}
 	return ret
 }
+
+func ApiDatacenterPreferencesToStructs(in []*api.DatacenterPreference) []*structs.DatacenterPreference {
+	if len(in) == 0 {
+		return nil
+	}
+
+	out := make([]*structs.DatacenterPreference, len(in))
+	for i, p := range in {
+		out[i] = &structs.DatacenterPreference{
+			Datacenter: p.Datacenter,
+		}
+		if p.Weight != nil {
+			out[i].Weight = *p.Weight
+		}
+	}
+	return out
+}
+
+// ApiScalingPolicyToStructs converts the scaling policy of a task group with
+// the given count.
+func ApiScalingPolicyToStructs(count int, ap *api.ScalingPolicy) *structs.ScalingPolicy {
+	p := &structs.ScalingPolicy{
+		Policy:  ap.Policy,
+		Max:     ap.Max,
+		Enabled: true,
+	}
+	if ap.Enabled != nil {
+		p.Enabled = *ap.Enabled
+	}
+	if ap.Min != nil {
+		p.Min = *ap.Min
+	} else {
+		p.Min = int64(count)
+	}
+	return p
+}
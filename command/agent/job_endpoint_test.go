@@ -1370,6 +1370,66 @@ func TestHTTP_JobStable(t *testing.T) {
 	})
 }
 
+func TestHTTP_JobScale(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	httpTest(t, nil, func(s *TestAgent) {
+		// Create the job
+		job := mock.Job()
+		regReq := structs.JobRegisterRequest{
+			Job: job,
+			WriteRequest: structs.WriteRequest{
+				Region:    "global",
+				Namespace: structs.DefaultNamespace,
+			},
+		}
+		var regResp structs.JobRegisterResponse
+		require.NoError(s.Agent.RPC("Job.Register", &regReq, &regResp))
+
+		// Scale the task group
+		newCount := job.TaskGroups[0].Count + 1
+		scaleReq := &api.ScalingRequest{
+			Count:   helper.Int64ToPtr(int64(newCount)),
+			Message: "testing",
+			Target: map[string]string{
+				"Job":   job.ID,
+				"Group": job.TaskGroups[0].Name,
+			},
+		}
+		buf := encodeReq(scaleReq)
+		req, err := http.NewRequest("POST", "/v1/job/"+job.ID+"/scale", buf)
+		require.NoError(err)
+		respW := httptest.NewRecorder()
+
+		obj, err := s.Server.JobSpecificRequest(respW, req)
+		require.NoError(err)
+		resp := obj.(structs.JobRegisterResponse)
+		require.NotEmpty(resp.EvalID)
+		require.NotEmpty(respW.HeaderMap.Get("X-Nomad-Index"))
+
+		// Check the scaling status
+		req, err = http.NewRequest("GET", "/v1/job/"+job.ID+"/scale", nil)
+		require.NoError(err)
+		respW = httptest.NewRecorder()
+
+		obj, err = s.Server.JobSpecificRequest(respW, req)
+		require.NoError(err)
+		status := obj.(*structs.JobScaleStatus)
+		tgStatus := status.TaskGroups[job.TaskGroups[0].Name]
+		require.Equal(newCount, tgStatus.Desired)
+		require.Len(tgStatus.Events, 1)
+		require.Equal("testing", tgStatus.Events[0].Message)
+
+		// A mismatched job ID is rejected
+		scaleReq.Target["Job"] = "foo"
+		req, err = http.NewRequest("POST", "/v1/job/"+job.ID+"/scale", encodeReq(scaleReq))
+		require.NoError(err)
+		_, err = s.Server.JobSpecificRequest(httptest.NewRecorder(), req)
+		require.Error(err)
+		require.Contains(err.Error(), "did not match")
+	})
+}
+
 func TestJobs_ApiJobToStructsJob(t *testing.T) {
 	apiJob := &api.Job{
 		Stop:        helper.BoolToPtr(true),
@@ -1419,6 +1479,12 @@ func TestJobs_ApiJobToStructsJob(t *testing.T) {
 				},
 			},
 		},
+		DatacenterPreferences: []*api.DatacenterPreference{
+			{
+				Datacenter: "dc1",
+				Weight:     helper.Int8ToPtr(100),
+			},
+		},
 		Periodic: &api.PeriodicConfig{
 			Enabled:         helper.BoolToPtr(true),
 			Spec:            helper.StringToPtr("spec"),
@@ -1486,6 +1552,12 @@ func TestJobs_ApiJobToStructsJob(t *testing.T) {
 						},
 					},
 				},
+				DatacenterPreferences: []*api.DatacenterPreference{
+					{
+						Datacenter: "dc1",
+						Weight:     helper.Int8ToPtr(50),
+					},
+				},
 				EphemeralDisk: &api.EphemeralDisk{
 					SizeMB:  helper.IntToPtr(100),
 					Sticky:  helper.BoolToPtr(true),
@@ -1710,6 +1782,10 @@ func TestJobs_ApiJobToStructsJob(t *testing.T) {
 						DispatchPayload: &api.DispatchPayloadConfig{
 							File: "fileA",
 						},
+						Lifecycle: &api.TaskLifecycle{
+							Hook:    "prestart",
+							Sidecar: true,
+						},
 					},
 				},
 			},
@@ -1761,6 +1837,12 @@ func TestJobs_ApiJobToStructsJob(t *testing.T) {
 				},
 			},
 		},
+		DatacenterPreferences: []*structs.DatacenterPreference{
+			{
+				Datacenter: "dc1",
+				Weight:     100,
+			},
+		},
 		Update: structs.UpdateStrategy{
 			Stagger:     1 * time.Second,
 			MaxParallel: 5,
@@ -1818,6 +1900,12 @@ func TestJobs_ApiJobToStructsJob(t *testing.T) {
 						},
 					},
 				},
+				DatacenterPreferences: []*structs.DatacenterPreference{
+					{
+						Datacenter: "dc1",
+						Weight:     50,
+					},
+				},
 				ReschedulePolicy: &structs.ReschedulePolicy{
 					Interval:      12 * time.Hour,
 					Attempts:      5,
@@ -2055,6 +2143,10 @@ func TestJobs_ApiJobToStructsJob(t *testing.T) {
 						DispatchPayload: &structs.DispatchPayloadConfig{
 							File: "fileA",
 						},
+						Lifecycle: &structs.TaskLifecycleConfig{
+							Hook:    "prestart",
+							Sidecar: true,
+						},
 					},
 				},
 			},
@@ -94,8 +94,10 @@ func (s *HTTPServer) nodeAllocations(resp http.ResponseWriter, req *http.Request
 	if out.Allocs == nil {
 		out.Allocs = make([]*structs.Allocation, 0)
 	}
-	for _, alloc := range out.Allocs {
+	for i, alloc := range out.Allocs {
+		alloc = scrubAllocIdentities(alloc)
 		alloc.SetEventDisplayMessages()
+		out.Allocs[i] = alloc
 	}
 	return out.Allocs, nil
 }
@@ -2,7 +2,10 @@ package agent
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -11,7 +14,10 @@ import (
 
 	"github.com/hashicorp/consul/testutil/retry"
 	"github.com/hashicorp/nomad/api"
+	snap "github.com/hashicorp/nomad/helper/snapshot"
+	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -283,7 +289,8 @@ func TestOperator_SchedulerSetConfiguration(t *testing.T) {
 	t.Parallel()
 	httpTest(t, nil, func(s *TestAgent) {
 		require := require.New(t)
-		body := bytes.NewBuffer([]byte(`{"PreemptionConfig": {
+		body := bytes.NewBuffer([]byte(`{"SchedulerAlgorithm": "spread",
+                     "PreemptionConfig": {
                      "SystemSchedulerEnabled": true,
                      "ServiceSchedulerEnabled": true
         }}`))
@@ -307,6 +314,20 @@ func TestOperator_SchedulerSetConfiguration(t *testing.T) {
 		require.Nil(err)
 		require.True(reply.SchedulerConfig.PreemptionConfig.SystemSchedulerEnabled)
 		require.True(reply.SchedulerConfig.PreemptionConfig.ServiceSchedulerEnabled)
+		require.Equal(structs.SchedulerAlgorithmSpread, reply.SchedulerConfig.SchedulerAlgorithm)
+	})
+}
+
+func TestOperator_SchedulerSetConfiguration_InvalidAlgorithm(t *testing.T) {
+	t.Parallel()
+	httpTest(t, nil, func(s *TestAgent) {
+		require := require.New(t)
+		body := bytes.NewBuffer([]byte(`{"SchedulerAlgorithm": "foo"}`))
+		req, _ := http.NewRequest("PUT", "/v1/operator/scheduler/configuration", body)
+		resp := httptest.NewRecorder()
+		_, err := s.Server.OperatorSchedulerConfiguration(resp, req)
+		require.Error(err)
+		require.Contains(err.Error(), "invalid scheduler algorithm")
 	})
 }
 
@@ -382,3 +403,82 @@ func TestOperator_SchedulerCASConfiguration(t *testing.T) {
 		require.False(reply.SchedulerConfig.PreemptionConfig.BatchSchedulerEnabled)
 	})
 }
+
+func TestOperator_SnapshotRequests(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	var snapshot bytes.Buffer
+	var index string
+	httpTest(t, nil, func(s *TestAgent) {
+		// Register a job so the snapshot holds some state.
+		job := mock.Job()
+		jobReq := structs.JobRegisterRequest{
+			Job: job,
+			WriteRequest: structs.WriteRequest{
+				Region:    "global",
+				Namespace: structs.DefaultNamespace,
+			},
+		}
+		var jobResp structs.JobRegisterResponse
+		require.NoError(s.Agent.RPC("Job.Register", &jobReq, &jobResp))
+
+		// Save the snapshot.
+		req, err := http.NewRequest("GET", "/v1/operator/snapshot", nil)
+		require.NoError(err)
+		resp := httptest.NewRecorder()
+		_, err = s.Server.SnapshotRequest(resp, req)
+		require.NoError(err)
+		require.Equal(200, resp.Code)
+
+		digest := resp.Header().Get("Digest")
+		require.Contains(digest, "sha-256=")
+		index = resp.Header().Get("X-Nomad-Index")
+		require.NotEmpty(index)
+
+		// Validate the checksum and that the archive holds the job.
+		sum := sha256.Sum256(resp.Body.Bytes())
+		require.Equal(digest, "sha-256="+base64.StdEncoding.EncodeToString(sum[:]))
+
+		_, err = io.Copy(&snapshot, resp.Body)
+		require.NoError(err)
+		_, err = snap.Verify(bytes.NewReader(snapshot.Bytes()))
+		require.NoError(err)
+
+		// Restoring with an invalid method fails.
+		req, err = http.NewRequest("DELETE", "/v1/operator/snapshot", nil)
+		require.NoError(err)
+		_, err = s.Server.SnapshotRequest(httptest.NewRecorder(), req)
+		require.Error(err)
+		require.Contains(err.Error(), ErrInvalidMethod)
+	})
+
+	// Restore the snapshot into a fresh agent and check that the job is there.
+	httpTest(t, nil, func(s *TestAgent) {
+		req, err := http.NewRequest("PUT", "/v1/operator/snapshot", &snapshot)
+		require.NoError(err)
+		resp := httptest.NewRecorder()
+		_, err = s.Server.SnapshotRequest(resp, req)
+		require.NoError(err)
+		require.Equal(200, resp.Code)
+		require.NotEmpty(resp.Header().Get("X-Nomad-Index"))
+
+		testutil.WaitForResult(func() (bool, error) {
+			req, err := http.NewRequest("GET", "/v1/jobs", nil)
+			if err != nil {
+				return false, err
+			}
+			obj, err := s.Server.JobsRequest(httptest.NewRecorder(), req)
+			if err != nil {
+				return false, err
+			}
+			jobs := obj.([]*structs.JobListStub)
+			if len(jobs) != 1 {
+				return false, fmt.Errorf("expected 1 job, found %d", len(jobs))
+			}
+			return true, nil
+		}, func(err error) {
+			t.Fatalf("err: %v", err)
+		})
+	})
+}
@@ -0,0 +1,90 @@
+package agent
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTP_ScalingPoliciesList(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	httpTest(t, nil, func(s *TestAgent) {
+		// Directly manipulate the state
+		state := s.Agent.server.State()
+		for i := 0; i < 3; i++ {
+			job := mock.Job()
+			job.TaskGroups[0].Scaling = &structs.ScalingPolicy{
+				ID:      uuid.Generate(),
+				Min:     1,
+				Max:     20,
+				Enabled: true,
+			}
+			require.NoError(state.UpsertJob(uint64(1000+i), job))
+		}
+
+		// Make the HTTP request
+		req, err := http.NewRequest("GET", "/v1/scaling/policies", nil)
+		require.NoError(err)
+		respW := httptest.NewRecorder()
+
+		// Make the request
+		obj, err := s.Server.ScalingPoliciesRequest(respW, req)
+		require.NoError(err)
+
+		// Check for the index
+		require.NotEmpty(respW.HeaderMap.Get("X-Nomad-Index"), "missing index")
+		require.NotEmpty(respW.HeaderMap.Get("X-Nomad-KnownLeader"), "missing known leader")
+		require.NotEmpty(respW.HeaderMap.Get("X-Nomad-LastContact"), "missing last contact")
+
+		// Check the list
+		l := obj.([]*structs.ScalingPolicyListStub)
+		require.Len(l, 3)
+	})
+}
+
+func TestHTTP_ScalingPolicyGet(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	httpTest(t, nil, func(s *TestAgent) {
+		// Directly manipulate the state
+		state := s.Agent.server.State()
+		job := mock.Job()
+		job.TaskGroups[0].Scaling = &structs.ScalingPolicy{
+			ID:      uuid.Generate(),
+			Min:     1,
+			Max:     20,
+			Enabled: true,
+		}
+		require.NoError(state.UpsertJob(1000, job))
+
+		// Make the HTTP request
+		req, err := http.NewRequest("GET", "/v1/scaling/policy/"+job.TaskGroups[0].Scaling.ID, nil)
+		require.NoError(err)
+		respW := httptest.NewRecorder()
+
+		// Make the request
+		obj, err := s.Server.ScalingPolicySpecificRequest(respW, req)
+		require.NoError(err)
+
+		// Check for the index
+		require.NotEmpty(respW.HeaderMap.Get("X-Nomad-Index"), "missing index")
+
+		// Check the policy
+		policy := obj.(*structs.ScalingPolicy)
+		require.Equal(job.TaskGroups[0].Scaling.ID, policy.ID)
+		require.Equal(job.ID, policy.TargetJob())
+
+		// Expect a 404 for an unknown policy
+		req, err = http.NewRequest("GET", "/v1/scaling/policy/"+uuid.Generate(), nil)
+		require.NoError(err)
+		_, err = s.Server.ScalingPolicySpecificRequest(httptest.NewRecorder(), req)
+		require.Error(err)
+		require.Contains(err.Error(), "not found")
+	})
+}
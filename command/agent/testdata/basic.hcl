@@ -114,6 +114,9 @@ server {
   heartbeat_grace           = "30s"
   min_heartbeat_ttl         = "33s"
   max_heartbeats_per_second = 11.0
+  eval_fair_share           = true
+  event_buffer_size         = 200
+  max_inflight_plans        = 4
   retry_join                = ["1.1.1.1", "2.2.2.2"]
   start_join                = ["1.1.1.1", "2.2.2.2"]
   retry_max                 = 3
@@ -129,6 +132,10 @@ server {
     retry_max      = 3
     retry_interval = "15s"
   }
+
+  eval_namespace_weights {
+    batch = 3
+  }
 }
 
 acl {
@@ -496,6 +496,8 @@ func buildDisplayMessage(event *api.TaskEvent) string {
 		desc = event.DriverMessage
 	case api.TaskLeaderDead:
 		desc = "Leader Task in Group dead"
+	case api.TaskMainDead:
+		desc = "Main tasks in the group died"
 	default:
 		desc = event.Message
 	}
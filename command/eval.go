@@ -23,6 +23,14 @@ Usage: nomad eval <subcommand> [options] [args]
 
       $ nomad eval status <eval-id>
 
+  List the evaluations blocked waiting for cluster capacity:
+
+      $ nomad eval list -blocked
+
+  Delete the pending evaluations of a job:
+
+      $ nomad eval delete -job example -status pending
+
   Please see the individual subcommand help for detailed usage information.
 `
 
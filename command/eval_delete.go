@@ -0,0 +1,180 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/api/contexts"
+	"github.com/posener/complete"
+)
+
+// evalDeleteBatchSize is the maximum number of evaluations deleted by a single
+// request, to bound the size of the Raft log entries.
+const evalDeleteBatchSize = 1000
+
+type EvalDeleteCommand struct {
+	Meta
+}
+
+func (c *EvalDeleteCommand) Help() string {
+	helpText := `
+Usage: nomad eval delete [options] [<evaluation>...]
+
+  Delete is used to delete evaluations, such as evaluations stuck pending. The
+  evaluations to delete are either given by their full IDs, or selected by the
+  -job and -status filters.
+
+  The eval broker must be paused before deleting evaluations, so that no
+  scheduler processes them while they are deleted:
+
+      $ nomad operator scheduler set-config -pause-eval-broker=true
+
+  Evaluations with allocations that are not terminal can not be deleted. When
+  ACLs are enabled, this command requires a management token.
+
+General Options:
+
+  ` + generalOptionsUsage() + `
+
+Delete Options:
+
+  -job
+    Delete the evaluations of the job with the given ID.
+
+  -status
+    Delete the evaluations with the given status, such as "pending".
+
+  -yes
+    Automatic yes to prompts.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *EvalDeleteCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-job":    complete.PredictAnything,
+			"-status": complete.PredictSet("blocked", "pending", "complete", "failed", "canceled"),
+			"-yes":    complete.PredictNothing,
+		})
+}
+
+func (c *EvalDeleteCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictFunc(func(a complete.Args) []string {
+		client, err := c.Meta.Client()
+		if err != nil {
+			return nil
+		}
+
+		resp, _, err := client.Search().PrefixSearch(a.Last, contexts.Evals, nil)
+		if err != nil {
+			return []string{}
+		}
+		return resp.Matches[contexts.Evals]
+	})
+}
+
+func (c *EvalDeleteCommand) Synopsis() string {
+	return "Delete evaluations"
+}
+
+func (c *EvalDeleteCommand) Name() string { return "eval delete" }
+
+func (c *EvalDeleteCommand) Run(args []string) int {
+	var autoYes bool
+	var job, status string
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.StringVar(&job, "job", "", "")
+	flags.StringVar(&status, "status", "", "")
+	flags.BoolVar(&autoYes, "yes", false, "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we got either evaluation IDs or filters
+	evalIDs := flags.Args()
+	filtered := job != "" || status != ""
+	if len(evalIDs) == 0 && !filtered {
+		c.Ui.Error("This command takes either evaluation IDs or the -job or -status flags")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+	if len(evalIDs) != 0 && filtered {
+		c.Ui.Error("Evaluation IDs can not be used with the -job or -status flags")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	// Get the HTTP client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	if filtered {
+		evals, _, err := client.Evaluations().List(&api.QueryOptions{
+			Params: evalListParams(job, status),
+		})
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error retrieving evaluations: %s", err))
+			return 1
+		}
+		if len(evals) == 0 {
+			c.Ui.Output("No evaluations found")
+			return 0
+		}
+
+		for _, eval := range evals {
+			evalIDs = append(evalIDs, eval.ID)
+		}
+
+		// Confirm the delete as the evaluations were not given explicitly
+		if !autoYes {
+			question := fmt.Sprintf("Are you sure you want to delete %d evaluations? [y/N]", len(evalIDs))
+			answer, err := c.Ui.Ask(question)
+			if err != nil {
+				c.Ui.Error(fmt.Sprintf("Failed to parse answer: %v", err))
+				return 1
+			}
+
+			if answer == "" || strings.ToLower(answer)[0] == 'n' {
+				// No case
+				c.Ui.Output("Cancelling eval delete")
+				return 0
+			} else if strings.ToLower(answer)[0] == 'y' && len(answer) > 1 {
+				// Non exact match yes
+				c.Ui.Output("For confirmation, an exact ‘y’ is required.")
+				return 0
+			} else if answer != "y" {
+				c.Ui.Output("No confirmation detected. For confirmation, an exact 'y' is required.")
+				return 1
+			}
+		}
+	}
+
+	deleted := 0
+	for len(evalIDs) != 0 {
+		batch := evalIDs
+		if len(batch) > evalDeleteBatchSize {
+			batch = batch[:evalDeleteBatchSize]
+		}
+		evalIDs = evalIDs[len(batch):]
+
+		if _, err := client.Evaluations().Delete(batch, nil); err != nil {
+			c.Ui.Error(fmt.Sprintf("Error deleting evaluations: %s", err))
+			if deleted != 0 {
+				c.Ui.Error(fmt.Sprintf("Deleted %d evaluations before the error", deleted))
+			}
+			return 1
+		}
+		deleted += len(batch)
+	}
+
+	c.Ui.Output(fmt.Sprintf("Successfully deleted %d evaluations", deleted))
+	return 0
+}
@@ -0,0 +1,63 @@
+package command
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvalDeleteCommand_Implements(t *testing.T) {
+	t.Parallel()
+	var _ cli.Command = &EvalDeleteCommand{}
+}
+
+func TestEvalDeleteCommand_Fails(t *testing.T) {
+	t.Parallel()
+	ui := new(cli.MockUi)
+	cmd := &EvalDeleteCommand{Meta: Meta{Ui: ui}}
+
+	// Fails without evals or filters
+	if code := cmd.Run(nil); code != 1 {
+		t.Fatalf("expected exit code 1, got: %d", code)
+	}
+	if out := ui.ErrorWriter.String(); !strings.Contains(out, commandErrorText(cmd)) {
+		t.Fatalf("expected help output, got: %s", out)
+	}
+	ui.ErrorWriter.Reset()
+
+	// Fails with both evals and filters
+	if code := cmd.Run([]string{"-job=example", "12345678-abcd-efab-cdef-123456789abc"}); code != 1 {
+		t.Fatalf("expected exit code 1, got: %d", code)
+	}
+	if out := ui.ErrorWriter.String(); !strings.Contains(out, "can not be used with") {
+		t.Fatalf("expected usage error, got: %s", out)
+	}
+	ui.ErrorWriter.Reset()
+
+	if code := cmd.Run([]string{"-address=nope", "-status=pending"}); code != 1 {
+		t.Fatalf("expected exit code 1, got: %d", code)
+	}
+	if out := ui.ErrorWriter.String(); !strings.Contains(out, "Error retrieving evaluations") {
+		t.Fatalf("expected failed query error, got: %s", out)
+	}
+	ui.ErrorWriter.Reset()
+}
+
+func TestEvalDeleteCommand_BrokerEnabled(t *testing.T) {
+	t.Parallel()
+	srv, _, url := testServer(t, true, nil)
+	defer srv.Shutdown()
+
+	ui := new(cli.MockUi)
+	cmd := &EvalDeleteCommand{Meta: Meta{Ui: ui}}
+
+	// Nothing matches the filters on a new cluster
+	require.Equal(t, 0, cmd.Run([]string{"-address=" + url, "-status=pending"}))
+	require.Contains(t, ui.OutputWriter.String(), "No evaluations found")
+
+	// The eval broker must be paused to delete evals
+	require.Equal(t, 1, cmd.Run([]string{"-address=" + url, "12345678-abcd-efab-cdef-123456789abc"}))
+	require.Contains(t, ui.ErrorWriter.String(), "eval broker must be paused")
+}
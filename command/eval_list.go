@@ -0,0 +1,249 @@
+package command
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/posener/complete"
+)
+
+type EvalListCommand struct {
+	Meta
+}
+
+func (c *EvalListCommand) Help() string {
+	helpText := `
+Usage: nomad eval list [options]
+
+  List is used to list the set of evaluations tracked by Nomad.
+
+  When the -blocked flag is given, the evaluations blocked waiting for cluster
+  capacity are listed instead, along with the resources that were exhausted and
+  how long they have been waiting.
+
+General Options:
+
+  ` + generalOptionsUsage() + `
+
+List Options:
+
+  -blocked
+    List the evaluations blocked waiting for cluster capacity.
+
+  -job
+    Only list the evaluations of the job with the given ID.
+
+  -status
+    Only list the evaluations with the given status, such as "pending".
+
+  -json
+    Output the evaluations in a JSON format.
+
+  -t
+    Format and display the evaluations using a Go template.
+
+  -verbose
+    Display full information.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *EvalListCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-blocked": complete.PredictNothing,
+			"-job":     complete.PredictAnything,
+			"-status":  complete.PredictSet("blocked", "pending", "complete", "failed", "canceled"),
+			"-json":    complete.PredictNothing,
+			"-t":       complete.PredictAnything,
+			"-verbose": complete.PredictNothing,
+		})
+}
+
+func (c *EvalListCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *EvalListCommand) Synopsis() string {
+	return "List evaluations"
+}
+
+func (c *EvalListCommand) Name() string { return "eval list" }
+
+func (c *EvalListCommand) Run(args []string) int {
+	var blocked, json, verbose bool
+	var tmpl, job, status string
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.BoolVar(&blocked, "blocked", false, "")
+	flags.StringVar(&job, "job", "", "")
+	flags.StringVar(&status, "status", "", "")
+	flags.BoolVar(&verbose, "verbose", false, "")
+	flags.BoolVar(&json, "json", false, "")
+	flags.StringVar(&tmpl, "t", "", "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we got no arguments
+	args = flags.Args()
+	if l := len(args); l != 0 {
+		c.Ui.Error("This command takes no arguments")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	// Blocked evaluations all have the blocked status
+	if blocked && status != "" {
+		c.Ui.Error("The -status flag can not be used with -blocked")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	// Truncate the id unless full length is requested
+	length := shortId
+	if verbose {
+		length = fullId
+	}
+
+	// Get the HTTP client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	q := &api.QueryOptions{
+		Params: evalListParams(job, status),
+	}
+
+	var evals interface{}
+	if blocked {
+		evals, _, err = client.Evaluations().Blocked(q)
+	} else {
+		evals, _, err = client.Evaluations().List(q)
+	}
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error retrieving evaluations: %s", err))
+		return 1
+	}
+
+	if json || len(tmpl) > 0 {
+		out, err := Format(json, tmpl, evals)
+		if err != nil {
+			c.Ui.Error(err.Error())
+			return 1
+		}
+
+		c.Ui.Output(out)
+		return 0
+	}
+
+	switch evals := evals.(type) {
+	case []*api.BlockedEvaluation:
+		c.Ui.Output(formatBlockedEvals(evals, length, time.Now()))
+	case []*api.Evaluation:
+		c.Ui.Output(formatEvals(evals, length))
+	}
+	return 0
+}
+
+// evalListParams returns the query parameters filtering the evaluations listed
+// by job and status.
+func evalListParams(job, status string) map[string]string {
+	params := make(map[string]string)
+	if job != "" {
+		params["job"] = job
+	}
+	if status != "" {
+		params["status"] = status
+	}
+	return params
+}
+
+func formatEvals(evals []*api.Evaluation, uuidLength int) string {
+	if len(evals) == 0 {
+		return "No evaluations found"
+	}
+
+	rows := make([]string, len(evals)+1)
+	rows[0] = "ID|Priority|Triggered By|Job ID|Status|Placement Failures"
+	for i, eval := range evals {
+		failures, _ := evalFailureStatus(eval)
+		rows[i+1] = fmt.Sprintf("%s|%d|%s|%s|%s|%s",
+			limit(eval.ID, uuidLength),
+			eval.Priority,
+			eval.TriggeredBy,
+			eval.JobID,
+			eval.Status,
+			failures)
+	}
+	return formatList(rows)
+}
+
+func formatBlockedEvals(evals []*api.BlockedEvaluation, uuidLength int, now time.Time) string {
+	if len(evals) == 0 {
+		return "No blocked evaluations found"
+	}
+
+	rows := make([]string, len(evals)+1)
+	rows[0] = "ID|Job ID|Priority|Blocked For|Exhausted|Class Eligibility"
+	for i, eval := range evals {
+		rows[i+1] = fmt.Sprintf("%s|%s|%d|%s|%s|%s",
+			limit(eval.ID, uuidLength),
+			eval.JobID,
+			eval.Priority,
+			formatTimeDifference(time.Unix(0, eval.CreateTime), now, time.Second),
+			blockedEvalExhausted(eval),
+			blockedEvalClassEligibility(eval))
+	}
+	return formatList(rows)
+}
+
+// blockedEvalExhausted returns the resources that were exhausted when placing
+// the allocations of the blocked evaluation.
+func blockedEvalExhausted(eval *api.BlockedEvaluation) string {
+	if eval.QuotaLimitReached != "" {
+		return fmt.Sprintf("quota %q", eval.QuotaLimitReached)
+	}
+
+	set := make(map[string]struct{})
+	for _, metric := range eval.FailedTGAllocs {
+		for dim := range metric.DimensionExhausted {
+			set[dim] = struct{}{}
+		}
+	}
+	if len(set) == 0 {
+		return "no eligible nodes"
+	}
+
+	dims := make([]string, 0, len(set))
+	for dim := range set {
+		dims = append(dims, dim)
+	}
+	sort.Strings(dims)
+	return strings.Join(dims, ", ")
+}
+
+// blockedEvalClassEligibility summarizes the node classes that were eligible to
+// place the allocations of the blocked evaluation.
+func blockedEvalClassEligibility(eval *api.BlockedEvaluation) string {
+	if eval.EscapedComputedClass {
+		return "escaped"
+	}
+
+	var eligible, ineligible int
+	for _, ok := range eval.ClassEligibility {
+		if ok {
+			eligible++
+		} else {
+			ineligible++
+		}
+	}
+	return fmt.Sprintf("%d eligible, %d ineligible", eligible, ineligible)
+}
@@ -0,0 +1,122 @@
+package command
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvalListCommand_Implements(t *testing.T) {
+	t.Parallel()
+	var _ cli.Command = &EvalListCommand{}
+}
+
+func TestEvalListCommand_Fails(t *testing.T) {
+	t.Parallel()
+	ui := new(cli.MockUi)
+	cmd := &EvalListCommand{Meta: Meta{Ui: ui}}
+
+	// Fails on misuse
+	if code := cmd.Run([]string{"some", "bad", "args"}); code != 1 {
+		t.Fatalf("expected exit code 1, got: %d", code)
+	}
+	if out := ui.ErrorWriter.String(); !strings.Contains(out, commandErrorText(cmd)) {
+		t.Fatalf("expected help output, got: %s", out)
+	}
+	ui.ErrorWriter.Reset()
+
+	if code := cmd.Run([]string{"-address=nope", "-blocked"}); code != 1 {
+		t.Fatalf("expected exit code 1, got: %d", code)
+	}
+	if out := ui.ErrorWriter.String(); !strings.Contains(out, "Error retrieving evaluations") {
+		t.Fatalf("expected failed query error, got: %s", out)
+	}
+	ui.ErrorWriter.Reset()
+
+	// Fails with a status filter on blocked evals
+	if code := cmd.Run([]string{"-blocked", "-status=pending"}); code != 1 {
+		t.Fatalf("expected exit code 1, got: %d", code)
+	}
+	if out := ui.ErrorWriter.String(); !strings.Contains(out, "can not be used with -blocked") {
+		t.Fatalf("expected usage error, got: %s", out)
+	}
+	ui.ErrorWriter.Reset()
+}
+
+func TestEvalListCommand_Run(t *testing.T) {
+	t.Parallel()
+	srv, _, url := testServer(t, true, nil)
+	defer srv.Shutdown()
+
+	ui := new(cli.MockUi)
+	cmd := &EvalListCommand{Meta: Meta{Ui: ui}}
+
+	// Nothing is blocked on a new cluster
+	if code := cmd.Run([]string{"-address=" + url, "-blocked"}); code != 0 {
+		t.Fatalf("expected exit 0, got: %d", code)
+	}
+	if out := ui.OutputWriter.String(); !strings.Contains(out, "No blocked evaluations found") {
+		t.Fatalf("expected no blocked evals, got: %s", out)
+	}
+	ui.OutputWriter.Reset()
+
+	// Nothing matches the filters on a new cluster
+	if code := cmd.Run([]string{"-address=" + url, "-job=example", "-status=pending"}); code != 0 {
+		t.Fatalf("expected exit 0, got: %d", code)
+	}
+	if out := ui.OutputWriter.String(); !strings.Contains(out, "No evaluations found") {
+		t.Fatalf("expected no evals, got: %s", out)
+	}
+}
+
+func TestEvalListCommand_FormatBlockedEvals(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	now := time.Now()
+	evals := []*api.BlockedEvaluation{
+		{
+			ID:       "aaaaaaaa-3350-4b4b-d185-0e1992ed43e9",
+			JobID:    "web",
+			Priority: 50,
+			FailedTGAllocs: map[string]*api.AllocationMetric{
+				"cache": {DimensionExhausted: map[string]int{"memory": 1}},
+				"web":   {DimensionExhausted: map[string]int{"cpu": 2, "memory": 1}},
+			},
+			ClassEligibility: map[string]bool{"v1:1": true, "v1:2": false},
+			CreateTime:       now.Add(-90 * time.Second).UnixNano(),
+		},
+		{
+			ID:                   "bbbbbbbb-3350-4b4b-d185-0e1992ed43e9",
+			JobID:                "batch",
+			Priority:             20,
+			EscapedComputedClass: true,
+			CreateTime:           now.Add(-time.Hour).UnixNano(),
+		},
+		{
+			ID:                "cccccccc-3350-4b4b-d185-0e1992ed43e9",
+			JobID:             "api",
+			Priority:          50,
+			QuotaLimitReached: "default",
+			CreateTime:        now.UnixNano(),
+		},
+	}
+
+	out := formatBlockedEvals(evals, shortId, now)
+	lines := strings.Split(out, "\n")
+	require.Len(lines, 4)
+	require.Contains(lines[1], "aaaaaaaa")
+	require.Contains(lines[1], "1m30s")
+	require.Contains(lines[1], "cpu, memory")
+	require.Contains(lines[1], "1 eligible, 1 ineligible")
+	require.Contains(lines[2], "1h0m0s")
+	require.Contains(lines[2], "no eligible nodes")
+	require.Contains(lines[2], "escaped")
+	require.Contains(lines[3], `quota "default"`)
+
+	require.Equal("No blocked evaluations found", formatBlockedEvals(nil, shortId, now))
+}
@@ -71,6 +71,15 @@ Plan Options:
     Determines whether the diff between the remote job and planned job is shown.
     Defaults to true.
 
+  -explain
+    Display a per node breakdown of the placement decisions, showing why each
+    evaluated node was filtered or exhausted and how the remaining nodes were
+    scored.
+
+  -node <id>
+    Limit the placement explanation to the node with the given ID prefix.
+    Implies -explain.
+
   -policy-override
     Sets the flag to force override any soft mandatory Sentinel policies.
 
@@ -88,6 +97,8 @@ func (c *JobPlanCommand) AutocompleteFlags() complete.Flags {
 	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
 		complete.Flags{
 			"-diff":            complete.PredictNothing,
+			"-explain":         complete.PredictNothing,
+			"-node":            complete.PredictAnything,
 			"-policy-override": complete.PredictNothing,
 			"-verbose":         complete.PredictNothing,
 		})
@@ -99,11 +110,14 @@ func (c *JobPlanCommand) AutocompleteArgs() complete.Predictor {
 
 func (c *JobPlanCommand) Name() string { return "job plan" }
 func (c *JobPlanCommand) Run(args []string) int {
-	var diff, policyOverride, verbose bool
+	var diff, explain, policyOverride, verbose bool
+	var nodeID string
 
 	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
 	flags.Usage = func() { c.Ui.Output(c.Help()) }
 	flags.BoolVar(&diff, "diff", true, "")
+	flags.BoolVar(&explain, "explain", false, "")
+	flags.StringVar(&nodeID, "node", "", "")
 	flags.BoolVar(&policyOverride, "policy-override", false, "")
 	flags.BoolVar(&verbose, "verbose", false, "")
 
@@ -152,6 +166,9 @@ func (c *JobPlanCommand) Run(args []string) int {
 	if policyOverride {
 		opts.PolicyOverride = true
 	}
+	if explain || nodeID != "" {
+		opts.Explain = true
+	}
 
 	// Submit the job
 	resp, _, err := client.Jobs().PlanOpts(job, opts, nil)
@@ -182,6 +199,13 @@ func (c *JobPlanCommand) Run(args []string) int {
 		c.addPreemptions(resp)
 	}
 
+	// Print the placement explanation if requested
+	if opts.Explain {
+		c.Ui.Output(c.Colorize().Color("[bold]Placement Explanation:[reset]"))
+		c.Ui.Output(formatPlacementExplanation(resp.Explanations, nodeID, verbose))
+		c.Ui.Output("")
+	}
+
 	// Print the job index info
 	c.Ui.Output(c.Colorize().Color(formatJobModifyIndex(resp.JobModifyIndex, path)))
 	return getExitCode(resp)
@@ -242,6 +266,98 @@ func (c *JobPlanCommand) addPreemptions(resp *api.JobPlanResponse) {
 
 }
 
+// formatPlacementExplanation produces a table per task group explaining why
+// each evaluated node was filtered, exhausted or how it was scored. If nodeID
+// is set only the nodes matching the ID prefix are shown.
+func formatPlacementExplanation(explanations map[string]*api.AllocationMetric, nodeID string, verbose bool) string {
+	if len(explanations) == 0 {
+		return "No placements were computed"
+	}
+
+	// Set the ID length
+	length := shortId
+	if verbose {
+		length = fullId
+	}
+
+	var out []string
+	for _, tg := range sortedTaskGroupFromMetrics(explanations) {
+		var nodes []*api.NodeExplanation
+		for id, e := range explanations[tg].NodeExplanations {
+			if strings.HasPrefix(id, nodeID) {
+				nodes = append(nodes, e)
+			}
+		}
+
+		if len(nodes) == 0 {
+			if nodeID != "" {
+				out = append(out, fmt.Sprintf("Task Group %q: node %q was not evaluated", tg, nodeID))
+			} else {
+				out = append(out, fmt.Sprintf("Task Group %q: no nodes were evaluated", tg))
+			}
+			continue
+		}
+		sortNodeExplanations(nodes)
+
+		rows := make([]string, len(nodes)+1)
+		rows[0] = "Node ID|Node Name|Result|Details|Final Score"
+		for i, e := range nodes {
+			result, details, score := "ranked", formatNodeScores(e.Scores), fmt.Sprintf("%.3g", e.NormScore)
+			if e.Filtered != "" {
+				result, details, score = "filtered", e.Filtered, "-"
+			} else if e.Exhausted != "" {
+				result, details, score = "exhausted", e.Exhausted, "-"
+			}
+			rows[i+1] = fmt.Sprintf("%s|%s|%s|%s|%s",
+				limit(e.NodeID, length), e.NodeName, result, details, score)
+		}
+		out = append(out, fmt.Sprintf("Task Group %q:\n%s", tg, formatList(rows)))
+	}
+
+	return strings.Join(out, "\n\n")
+}
+
+// sortNodeExplanations sorts ranked nodes by descending final score followed
+// by exhausted and then filtered nodes.
+func sortNodeExplanations(nodes []*api.NodeExplanation) {
+	rank := func(e *api.NodeExplanation) int {
+		switch {
+		case e.Filtered != "":
+			return 2
+		case e.Exhausted != "":
+			return 1
+		default:
+			return 0
+		}
+	}
+
+	sort.Slice(nodes, func(i, j int) bool {
+		ri, rj := rank(nodes[i]), rank(nodes[j])
+		if ri != rj {
+			return ri < rj
+		}
+		if ri == 0 && nodes[i].NormScore != nodes[j].NormScore {
+			return nodes[i].NormScore > nodes[j].NormScore
+		}
+		return nodes[i].NodeID < nodes[j].NodeID
+	})
+}
+
+// formatNodeScores returns the scores of a node sorted by scorer name.
+func formatNodeScores(scores map[string]float64) string {
+	names := make([]string, 0, len(scores))
+	for name := range scores {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	parts := make([]string, len(names))
+	for i, name := range names {
+		parts[i] = fmt.Sprintf("%s=%.3g", name, scores[name])
+	}
+	return strings.Join(parts, ", ")
+}
+
 type namespaceIdPair struct {
 	id        string
 	namespace string
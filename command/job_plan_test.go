@@ -255,3 +255,51 @@ func TestPlanCommad_Preemptions(t *testing.T) {
 	require.Contains(out, "batch")
 	require.Contains(out, "service")
 }
+
+func TestPlanCommand_FormatPlacementExplanation(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	explanations := map[string]*api.AllocationMetric{
+		"web": {
+			NodeExplanations: map[string]*api.NodeExplanation{
+				"aaaaaaaa-1111": {
+					NodeID:   "aaaaaaaa-1111",
+					NodeName: "filtered-node",
+					Filtered: "${attr.kernel.name} = linux",
+				},
+				"bbbbbbbb-2222": {
+					NodeID:    "bbbbbbbb-2222",
+					NodeName:  "ranked-node",
+					Scores:    map[string]float64{"binpack": 0.5, "job-anti-affinity": 0},
+					NormScore: 0.25,
+				},
+				"cccccccc-3333": {
+					NodeID:    "cccccccc-3333",
+					NodeName:  "exhausted-node",
+					Exhausted: "memory",
+				},
+			},
+		},
+	}
+
+	// All nodes are shown with ranked nodes first
+	out := formatPlacementExplanation(explanations, "", false)
+	require.Contains(out, `Task Group "web"`)
+	require.Contains(out, "binpack=0.5, job-anti-affinity=0")
+	require.Contains(out, "${attr.kernel.name} = linux")
+	ranked := strings.Index(out, "ranked-node")
+	exhausted := strings.Index(out, "exhausted-node")
+	filtered := strings.Index(out, "filtered-node")
+	require.True(ranked < exhausted && exhausted < filtered, out)
+
+	// Only the matching node is shown
+	out = formatPlacementExplanation(explanations, "cccc", false)
+	require.Contains(out, "exhausted-node")
+	require.NotContains(out, "ranked-node")
+	require.NotContains(out, "filtered-node")
+
+	// Unknown nodes are reported
+	out = formatPlacementExplanation(explanations, "dddd", false)
+	require.Contains(out, `node "dddd" was not evaluated`)
+}
@@ -0,0 +1,270 @@
+package command
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/posener/complete"
+	"github.com/skratchdot/open-golang/open"
+)
+
+const (
+	// defaultOIDCCallbackAddr is the address the login command listens on for
+	// the redirect of the OIDC provider
+	defaultOIDCCallbackAddr = "localhost:4649"
+
+	// oidcCallbackTimeout is how long the login command waits for the user to
+	// authenticate at the OIDC provider
+	oidcCallbackTimeout = 5 * time.Minute
+)
+
+type LoginCommand struct {
+	Meta
+}
+
+func (c *LoginCommand) Help() string {
+	helpText := `
+Usage: nomad login -method=<name> [options]
+
+  Login exchanges an identity of an external identity provider for a
+  short-lived ACL token, using the given ACL auth method.
+
+  For JWT auth methods, the JWT issued by the identity provider is given with
+  the -login-token flag. For OIDC auth methods, the command opens the login
+  page of the OIDC provider in a browser and waits for the provider to
+  redirect back to it.
+
+General Options:
+
+  ` + generalOptionsUsage() + `
+
+Login Options:
+
+  -method=<name>
+    The name of the ACL auth method to log in with.
+
+  -login-token=<jwt>
+    The JWT to log in with when using a JWT auth method.
+
+  -oidc-callback-addr=<addr>
+    The address to listen on for the redirect of the OIDC provider when using
+    an OIDC auth method. The redirect URI "http://<addr>/oidc/callback" must be
+    allowed by the auth method. Defaults to "localhost:4649".
+
+  -json
+    Output the ACL token in a JSON format.
+
+  -t
+    Format and display the ACL token using a Go template.
+`
+
+	return strings.TrimSpace(helpText)
+}
+
+func (c *LoginCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-method":             complete.PredictAnything,
+			"-login-token":        complete.PredictAnything,
+			"-oidc-callback-addr": complete.PredictAnything,
+			"-json":               complete.PredictNothing,
+			"-t":                  complete.PredictAnything,
+		})
+}
+
+func (c *LoginCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *LoginCommand) Synopsis() string {
+	return "Login to Nomad using an ACL auth method"
+}
+
+func (c *LoginCommand) Name() string { return "login" }
+
+func (c *LoginCommand) Run(args []string) int {
+	var methodName, loginToken, callbackAddr, tmpl string
+	var json bool
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.StringVar(&methodName, "method", "", "")
+	flags.StringVar(&loginToken, "login-token", "", "")
+	flags.StringVar(&callbackAddr, "oidc-callback-addr", defaultOIDCCallbackAddr, "")
+	flags.BoolVar(&json, "json", false, "")
+	flags.StringVar(&tmpl, "t", "", "")
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we have no arguments
+	args = flags.Args()
+	if l := len(args); l != 0 {
+		c.Ui.Error("This command takes no arguments")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	if methodName == "" {
+		c.Ui.Error("The -method flag is required")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	// Get the HTTP client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	// Look up the type of the auth method. Listing the auth methods doesn't
+	// require a token.
+	methods, _, err := client.ACLAuthMethods().List(nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error listing ACL auth methods: %s", err))
+		return 1
+	}
+	var method *api.ACLAuthMethodListStub
+	for _, m := range methods {
+		if m.Name == methodName {
+			method = m
+			break
+		}
+	}
+	if method == nil {
+		c.Ui.Error(fmt.Sprintf("ACL auth method %q not found", methodName))
+		return 1
+	}
+
+	var token *api.ACLToken
+	switch method.Type {
+	case api.ACLAuthMethodTypeJWT:
+		if loginToken == "" {
+			c.Ui.Error("The -login-token flag is required for JWT auth methods")
+			return 1
+		}
+		token, _, err = client.ACLAuthMethods().Login(&api.ACLLoginRequest{
+			AuthMethodName: methodName,
+			LoginToken:     loginToken,
+		}, nil)
+	case api.ACLAuthMethodTypeOIDC:
+		token, err = c.loginOIDC(client, methodName, callbackAddr)
+	default:
+		c.Ui.Error(fmt.Sprintf("Unsupported ACL auth method type %q", method.Type))
+		return 1
+	}
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error logging in: %s", err))
+		return 1
+	}
+
+	if json || len(tmpl) > 0 {
+		out, err := Format(json, tmpl, token)
+		if err != nil {
+			c.Ui.Error(err.Error())
+			return 1
+		}
+
+		c.Ui.Output(out)
+		return 0
+	}
+
+	c.Ui.Output(formatKVACLToken(token))
+	return 0
+}
+
+// oidcCallback is the result of the redirect of the OIDC provider
+type oidcCallback struct {
+	code string
+	err  error
+}
+
+// loginOIDC logs in with an OIDC auth method. It sends the user to the login
+// page of the OIDC provider and waits for the provider to redirect back to a
+// local HTTP server with the authorization code, which it exchanges for an
+// ACL token.
+func (c *LoginCommand) loginOIDC(client *api.Client, methodName, callbackAddr string) (*api.ACLToken, error) {
+	ln, err := net.Listen("tcp", callbackAddr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen for the OIDC callback: %v", err)
+	}
+	defer ln.Close()
+
+	redirectURI := fmt.Sprintf("http://%s/oidc/callback", callbackAddr)
+	state, nonce := uuid.Generate(), uuid.Generate()
+
+	authURL, _, err := client.ACLAuthMethods().GetAuthURL(&api.ACLOIDCAuthURLRequest{
+		AuthMethodName: methodName,
+		RedirectURI:    redirectURI,
+		State:          state,
+		Nonce:          nonce,
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	callbackCh := make(chan *oidcCallback, 1)
+	srv := &http.Server{
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/oidc/callback" {
+				http.NotFound(w, r)
+				return
+			}
+
+			q := r.URL.Query()
+			result := &oidcCallback{code: q.Get("code")}
+			switch {
+			case q.Get("error") != "":
+				result.err = fmt.Errorf("OIDC provider returned an error: %s %s",
+					q.Get("error"), q.Get("error_description"))
+			case q.Get("state") != state:
+				result.err = fmt.Errorf("OIDC callback has an invalid state")
+			case result.code == "":
+				result.err = fmt.Errorf("OIDC callback is missing the authorization code")
+			}
+
+			if result.err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprintf(w, "Login failed: %v\n", result.err)
+			} else {
+				fmt.Fprintln(w, "Login complete. You may close this window and return to the terminal.")
+			}
+
+			select {
+			case callbackCh <- result:
+			default:
+			}
+		}),
+	}
+	go srv.Serve(ln)
+	defer srv.Close()
+
+	c.Ui.Output(fmt.Sprintf("Complete the login via your OIDC provider. Launching browser to:\n\n    %s\n", authURL))
+	if err := open.Start(authURL); err != nil {
+		c.Ui.Warn(fmt.Sprintf("Failed to launch a browser, open the URL above manually: %v", err))
+	}
+
+	var result *oidcCallback
+	select {
+	case result = <-callbackCh:
+	case <-time.After(oidcCallbackTimeout):
+		return nil, fmt.Errorf("timed out waiting for the OIDC callback")
+	}
+	if result.err != nil {
+		return nil, result.err
+	}
+
+	token, _, err := client.ACLAuthMethods().CompleteAuth(&api.ACLOIDCCompleteAuthRequest{
+		AuthMethodName: methodName,
+		RedirectURI:    redirectURI,
+		Code:           result.code,
+		Nonce:          nonce,
+	}, nil)
+	return token, err
+}
@@ -0,0 +1,77 @@
+package command
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/command/agent"
+	"github.com/hashicorp/nomad/lib/auth"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoginCommand_Implements(t *testing.T) {
+	t.Parallel()
+	var _ cli.Command = &LoginCommand{}
+}
+
+func TestLoginCommand_JWT(t *testing.T) {
+	require := require.New(t)
+	t.Parallel()
+	config := func(c *agent.Config) {
+		c.ACL.Enabled = true
+	}
+
+	srv, _, url := testServer(t, true, config)
+	state := srv.Agent.Server().State()
+	defer srv.Shutdown()
+
+	issuer := auth.NewTestIssuer(t)
+	defer issuer.Close()
+
+	// Create an auth method trusting the issuer and a binding rule
+	method := mock.ACLAuthMethod()
+	method.Config.JWKSURL = issuer.JWKSURL()
+	rule := mock.ACLBindingRule(method.Name)
+	require.NoError(state.UpsertACLAuthMethods(1000, []*structs.ACLAuthMethod{method}))
+	require.NoError(state.UpsertACLBindingRules(1001, []*structs.ACLBindingRule{rule}))
+
+	ui := new(cli.MockUi)
+	cmd := &LoginCommand{Meta: Meta{Ui: ui, flagAddress: url}}
+
+	// The method is required
+	code := cmd.Run([]string{"-address=" + url})
+	require.Equal(1, code)
+	require.Contains(ui.ErrorWriter.String(), "-method")
+	ui.ErrorWriter.Reset()
+
+	// Unknown methods are rejected
+	code = cmd.Run([]string{"-address=" + url, "-method=unknown", "-login-token=foo"})
+	require.Equal(1, code)
+	require.Contains(ui.ErrorWriter.String(), "not found")
+	ui.ErrorWriter.Reset()
+
+	// JWTs of other issuers are rejected
+	other := auth.NewTestIssuer(t)
+	defer other.Close()
+	jwt := other.SignJWT(map[string]interface{}{
+		"aud":    "nomad",
+		"groups": []string{"engineering"},
+	})
+	code = cmd.Run([]string{"-address=" + url, "-method=" + method.Name, "-login-token=" + jwt})
+	require.Equal(1, code)
+	require.Contains(ui.ErrorWriter.String(), "Error logging in")
+	ui.ErrorWriter.Reset()
+
+	// Log in with a valid JWT
+	jwt = issuer.SignJWT(map[string]interface{}{
+		"aud":    "nomad",
+		"groups": []string{"engineering"},
+	})
+	code = cmd.Run([]string{"-address=" + url, "-method=" + method.Name, "-login-token=" + jwt})
+	require.Equal(0, code, ui.ErrorWriter.String())
+	out := ui.OutputWriter.String()
+	require.Contains(out, "Secret ID")
+	require.Contains(out, rule.BindName)
+}
@@ -16,7 +16,8 @@ Usage: nomad operator raft <subcommand> [options]
 
   This command groups subcommands for interacting with Nomad's Raft subsystem.
   The command can be used to verify Raft peers or in rare cases to recover
-  quorum by removing invalid peers.
+  quorum by removing invalid peers, and to inspect the Raft data of a stopped
+  server.
 
   List Raft peers:
 
@@ -26,6 +27,14 @@ Usage: nomad operator raft <subcommand> [options]
 
       $ nomad operator raft remove-peer -peer-address "IP:Port"
 
+  Display the Raft logs of a stopped server:
+
+      $ nomad operator raft logs /var/lib/nomad
+
+  Display the state of a stopped server:
+
+      $ nomad operator raft state /var/lib/nomad
+
   Please see the individual subcommand help for detailed usage information.
 `
 	return strings.TrimSpace(helpText)
@@ -0,0 +1,133 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/nomad/helper/raftutil"
+	"github.com/hashicorp/nomad/nomad"
+	"github.com/hashicorp/raft"
+	"github.com/posener/complete"
+)
+
+// raftLogTypes maps the Raft log types to their names.
+var raftLogTypes = map[raft.LogType]string{
+	raft.LogCommand:              "LogCommand",
+	raft.LogNoop:                 "LogNoop",
+	raft.LogAddPeerDeprecated:    "LogAddPeerDeprecated",
+	raft.LogRemovePeerDeprecated: "LogRemovePeerDeprecated",
+	raft.LogBarrier:              "LogBarrier",
+	raft.LogConfiguration:        "LogConfiguration",
+}
+
+// raftLogEntry is the decoded form of a Raft log that is displayed.
+type raftLogEntry struct {
+	Index       uint64
+	Term        uint64
+	Type        string
+	MessageType string      `json:",omitempty"`
+	Body        interface{} `json:",omitempty"`
+	Error       string      `json:",omitempty"`
+}
+
+type OperatorRaftLogsCommand struct {
+	Meta
+}
+
+func (c *OperatorRaftLogsCommand) Help() string {
+	helpText := `
+Usage: nomad operator raft logs [options] <path>
+
+  Displays the Raft logs of a Nomad server stored on disk as JSON. The requests
+  of the logs applied by the server are decoded.
+
+  The path may be the data directory of the server, its "server/raft"
+  directory or the "raft.db" file itself. The Raft store is opened read-only,
+  but it can't be opened while the server is running.
+
+  This command is intended for debugging a server that fails to start and
+  doesn't connect to a Nomad cluster.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *OperatorRaftLogsCommand) AutocompleteFlags() complete.Flags {
+	return complete.Flags{}
+}
+
+func (c *OperatorRaftLogsCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictFiles("*")
+}
+
+func (c *OperatorRaftLogsCommand) Synopsis() string {
+	return "Display the Raft logs stored on disk"
+}
+
+func (c *OperatorRaftLogsCommand) Name() string { return "operator raft logs" }
+
+func (c *OperatorRaftLogsCommand) Run(args []string) int {
+	flags := c.Meta.FlagSet(c.Name(), FlagSetNone)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	if err := flags.Parse(args); err != nil {
+		c.Ui.Error(fmt.Sprintf("Failed to parse args: %v", err))
+		return 1
+	}
+
+	// Check that we got exactly one argument
+	args = flags.Args()
+	if len(args) != 1 {
+		c.Ui.Error("This command takes one argument: <path>")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	path, err := raftutil.FindRaftFile(args[0])
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error finding Raft store: %s", err))
+		return 1
+	}
+
+	// Logs which fail to decode are still displayed, along with the error, as
+	// they may be the reason a server fails to start.
+	entries := []*raftLogEntry{}
+	err = raftutil.ReadLogs(path, func(log *raft.Log) error {
+		entry := &raftLogEntry{
+			Index: log.Index,
+			Term:  log.Term,
+			Type:  raftLogTypes[log.Type],
+		}
+		if entry.Type == "" {
+			entry.Type = fmt.Sprintf("LogType(%d)", log.Type)
+		}
+
+		if log.Type == raft.LogCommand {
+			name, req, err := nomad.DecodeLog(log.Data)
+			entry.MessageType = name
+			entry.Body = req
+			if err != nil {
+				entry.Error = err.Error()
+			}
+		}
+
+		entries = append(entries, entry)
+		return nil
+	})
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error reading Raft logs: %s", err))
+		return 1
+	}
+
+	f, err := DataFormat("json", "")
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error getting formatter: %s", err))
+		return 1
+	}
+
+	out, err := f.TransformData(entries)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error formatting the data: %s", err))
+		return 1
+	}
+	c.Ui.Output(out)
+	return 0
+}
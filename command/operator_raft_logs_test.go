@@ -0,0 +1,49 @@
+package command
+
+import (
+	"os"
+	"testing"
+
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOperator_Raft_Logs_Implements(t *testing.T) {
+	t.Parallel()
+	var _ cli.Command = &OperatorRaftLogsCommand{}
+}
+
+func TestOperator_Raft_Logs(t *testing.T) {
+	t.Parallel()
+	job := mock.Job()
+	dir := testRaftDataDir(t, job)
+	defer os.RemoveAll(dir)
+
+	ui := new(cli.MockUi)
+	cmd := &OperatorRaftLogsCommand{Meta: Meta{Ui: ui}}
+	code := cmd.Run([]string{dir})
+	require.Zero(t, code, ui.ErrorWriter.String())
+
+	out := ui.OutputWriter.String()
+	require.Contains(t, out, `"Type": "LogConfiguration"`)
+	require.Contains(t, out, `"MessageType": "JobRegisterRequestType"`)
+	require.Contains(t, out, job.ID)
+}
+
+func TestOperator_Raft_Logs_Fails(t *testing.T) {
+	t.Parallel()
+	ui := new(cli.MockUi)
+	cmd := &OperatorRaftLogsCommand{Meta: Meta{Ui: ui}}
+
+	// Fails on misuse
+	code := cmd.Run(nil)
+	require.Equal(t, 1, code)
+	require.Contains(t, ui.ErrorWriter.String(), commandErrorText(cmd))
+	ui.ErrorWriter.Reset()
+
+	// Fails on a missing Raft store
+	code = cmd.Run([]string{"/nonexistent/nomad"})
+	require.Equal(t, 1, code)
+	require.Contains(t, ui.ErrorWriter.String(), "Error finding Raft store")
+}
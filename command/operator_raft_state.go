@@ -0,0 +1,122 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	hclog "github.com/hashicorp/go-hclog"
+	memdb "github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/helper/raftutil"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/posener/complete"
+)
+
+type OperatorRaftStateCommand struct {
+	Meta
+}
+
+func (c *OperatorRaftStateCommand) Help() string {
+	helpText := `
+Usage: nomad operator raft state [options] <path>
+
+  Displays the state of a Nomad server rebuilt from its Raft data on disk. The
+  latest snapshot of the server is restored and its Raft logs are replayed, and
+  the resulting jobs, nodes and allocations are displayed as JSON.
+
+  The path may be the data directory of the server, its "server/raft"
+  directory or the "raft.db" file itself. The Raft store is opened read-only,
+  but it can't be opened while the server is running.
+
+  This command is intended for debugging a server that fails to start and
+  doesn't connect to a Nomad cluster.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *OperatorRaftStateCommand) AutocompleteFlags() complete.Flags {
+	return complete.Flags{}
+}
+
+func (c *OperatorRaftStateCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictFiles("*")
+}
+
+func (c *OperatorRaftStateCommand) Synopsis() string {
+	return "Display the server state rebuilt from Raft data on disk"
+}
+
+func (c *OperatorRaftStateCommand) Name() string { return "operator raft state" }
+
+func (c *OperatorRaftStateCommand) Run(args []string) int {
+	flags := c.Meta.FlagSet(c.Name(), FlagSetNone)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	if err := flags.Parse(args); err != nil {
+		c.Ui.Error(fmt.Sprintf("Failed to parse args: %v", err))
+		return 1
+	}
+
+	// Check that we got exactly one argument
+	args = flags.Args()
+	if len(args) != 1 {
+		c.Ui.Error("This command takes one argument: <path>")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	state, index, err := raftutil.ReplayState(args[0], hclog.NewNullLogger())
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error reading Raft state: %s", err))
+		return 1
+	}
+
+	out := struct {
+		Index  uint64
+		Jobs   []*structs.Job
+		Nodes  []*structs.Node
+		Allocs []*structs.Allocation
+	}{
+		Index: index,
+	}
+
+	ws := memdb.NewWatchSet()
+	jobs, err := state.Jobs(ws)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error listing jobs: %s", err))
+		return 1
+	}
+	for raw := jobs.Next(); raw != nil; raw = jobs.Next() {
+		out.Jobs = append(out.Jobs, raw.(*structs.Job))
+	}
+
+	nodes, err := state.Nodes(ws)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error listing nodes: %s", err))
+		return 1
+	}
+	for raw := nodes.Next(); raw != nil; raw = nodes.Next() {
+		out.Nodes = append(out.Nodes, raw.(*structs.Node))
+	}
+
+	allocs, err := state.Allocs(ws)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error listing allocations: %s", err))
+		return 1
+	}
+	for raw := allocs.Next(); raw != nil; raw = allocs.Next() {
+		out.Allocs = append(out.Allocs, raw.(*structs.Allocation))
+	}
+
+	f, err := DataFormat("json", "")
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error getting formatter: %s", err))
+		return 1
+	}
+
+	formatted, err := f.TransformData(out)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error formatting the data: %s", err))
+		return 1
+	}
+	c.Ui.Output(formatted)
+	return 0
+}
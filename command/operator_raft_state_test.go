@@ -0,0 +1,80 @@
+package command
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/raft"
+	raftboltdb "github.com/hashicorp/raft-boltdb"
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+)
+
+// testRaftDataDir returns a server data directory whose Raft store holds a
+// log registering the given job.
+func testRaftDataDir(t *testing.T, job *structs.Job) string {
+	dir, err := ioutil.TempDir("", "nomad-raft")
+	require.NoError(t, err)
+
+	raftDir := filepath.Join(dir, "server", "raft")
+	require.NoError(t, os.MkdirAll(raftDir, 0700))
+
+	store, err := raftboltdb.NewBoltStore(filepath.Join(raftDir, "raft.db"))
+	require.NoError(t, err)
+	defer store.Close()
+
+	buf, err := structs.Encode(structs.JobRegisterRequestType, &structs.JobRegisterRequest{Job: job})
+	require.NoError(t, err)
+	require.NoError(t, store.StoreLogs([]*raft.Log{
+		{Index: 1, Term: 1, Type: raft.LogConfiguration},
+		{Index: 2, Term: 1, Type: raft.LogCommand, Data: buf},
+	}))
+
+	return dir
+}
+
+func TestOperator_Raft_State_Implements(t *testing.T) {
+	t.Parallel()
+	var _ cli.Command = &OperatorRaftStateCommand{}
+}
+
+func TestOperator_Raft_State(t *testing.T) {
+	t.Parallel()
+	job := mock.Job()
+	dir := testRaftDataDir(t, job)
+	defer os.RemoveAll(dir)
+
+	ui := new(cli.MockUi)
+	cmd := &OperatorRaftStateCommand{Meta: Meta{Ui: ui}}
+	code := cmd.Run([]string{dir})
+	require.Zero(t, code, ui.ErrorWriter.String())
+
+	out := ui.OutputWriter.String()
+	require.Contains(t, out, `"Index": 2`)
+	require.Contains(t, out, job.ID)
+}
+
+func TestOperator_Raft_State_Fails(t *testing.T) {
+	t.Parallel()
+	ui := new(cli.MockUi)
+	cmd := &OperatorRaftStateCommand{Meta: Meta{Ui: ui}}
+
+	// Fails on misuse
+	code := cmd.Run(nil)
+	require.Equal(t, 1, code)
+	require.Contains(t, ui.ErrorWriter.String(), commandErrorText(cmd))
+	ui.ErrorWriter.Reset()
+
+	// Fails on a directory without Raft data
+	dir, err := ioutil.TempDir("", "nomad-raft")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	code = cmd.Run([]string{dir})
+	require.Equal(t, 1, code)
+	require.Contains(t, ui.ErrorWriter.String(), "Error reading Raft state")
+}
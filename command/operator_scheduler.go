@@ -0,0 +1,47 @@
+package command
+
+import (
+	"strings"
+
+	"github.com/mitchellh/cli"
+)
+
+type OperatorSchedulerCommand struct {
+	Meta
+}
+
+func (c *OperatorSchedulerCommand) Name() string { return "operator scheduler" }
+
+func (c *OperatorSchedulerCommand) Run(args []string) int {
+	return cli.RunResultHelp
+}
+
+func (c *OperatorSchedulerCommand) Synopsis() string {
+	return "Provides tools for modifying the scheduler configuration"
+}
+
+func (c *OperatorSchedulerCommand) Help() string {
+	helpText := `
+Usage: nomad operator scheduler <subcommand> [options]
+
+  This command groups subcommands for interacting with the cluster wide
+  configuration of Nomad's schedulers. The command can be used to view or
+  modify the scheduling algorithm and preemption settings, and to rebalance
+  allocations across nodes.
+
+  Get the current scheduler configuration:
+
+      $ nomad operator scheduler get-config
+
+  Set a new scheduler configuration, spreading allocations across nodes:
+
+      $ nomad operator scheduler set-config -scheduler-algorithm=spread
+
+  Display the migrations that would reduce cluster fragmentation:
+
+      $ nomad operator scheduler rebalance -dry-run
+
+  Please see the individual subcommand help for detailed usage information.
+  `
+	return strings.TrimSpace(helpText)
+}
@@ -0,0 +1,32 @@
+package command
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOperatorSchedulerGetConfig_Implements(t *testing.T) {
+	t.Parallel()
+	var _ cli.Command = &OperatorSchedulerGetConfig{}
+}
+
+func TestOperatorSchedulerGetConfig_Run(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	s, _, addr := testServer(t, false, nil)
+	defer s.Shutdown()
+
+	ui := new(cli.MockUi)
+	c := &OperatorSchedulerGetConfig{Meta: Meta{Ui: ui}}
+
+	code := c.Run([]string{"-address=" + addr})
+	require.Zero(code, ui.ErrorWriter.String())
+
+	output := strings.TrimSpace(ui.OutputWriter.String())
+	require.Contains(output, "Scheduler Algorithm")
+	require.Contains(output, "binpack")
+	require.Contains(output, "Preemption System Scheduler")
+}
@@ -0,0 +1,145 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/posener/complete"
+)
+
+type OperatorSchedulerRebalance struct {
+	Meta
+}
+
+func (c *OperatorSchedulerRebalance) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-dry-run":        complete.PredictNothing,
+			"-max-migrations": complete.PredictAnything,
+			"-verbose":        complete.PredictNothing,
+		})
+}
+
+func (c *OperatorSchedulerRebalance) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *OperatorSchedulerRebalance) Name() string { return "operator scheduler rebalance" }
+
+func (c *OperatorSchedulerRebalance) Run(args []string) int {
+	var dryRun, verbose bool
+	var maxMigrations int
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.BoolVar(&dryRun, "dry-run", false, "")
+	flags.IntVar(&maxMigrations, "max-migrations", 0, "")
+	flags.BoolVar(&verbose, "verbose", false, "")
+
+	if err := flags.Parse(args); err != nil {
+		c.Ui.Error(fmt.Sprintf("Failed to parse args: %v", err))
+		return 1
+	}
+
+	// Check that we got no arguments
+	if len(flags.Args()) != 0 {
+		c.Ui.Error("This command takes no arguments")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	if maxMigrations < 0 {
+		c.Ui.Error("-max-migrations cannot be negative")
+		return 1
+	}
+
+	// Truncate the id unless full length is requested
+	length := shortId
+	if verbose {
+		length = fullId
+	}
+
+	// Set up a client.
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	req := &api.SchedulerRebalanceRequest{
+		DryRun:        dryRun,
+		MaxMigrations: maxMigrations,
+	}
+	resp, _, err := client.Operator().SchedulerRebalance(req, nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error rebalancing cluster: %s", err))
+		return 1
+	}
+
+	if len(resp.Migrations) == 0 {
+		c.Ui.Output("No migrations would reduce cluster fragmentation")
+		return 0
+	}
+
+	rows := make([]string, len(resp.Migrations)+1)
+	rows[0] = "Alloc ID|Namespace|Job ID|Task Group|Source Node|Target Node|Score Improvement"
+	for i, m := range resp.Migrations {
+		rows[i+1] = fmt.Sprintf("%s|%s|%s|%s|%s|%s|%.3g",
+			limit(m.AllocID, length),
+			m.Namespace,
+			m.JobID,
+			m.TaskGroup,
+			limit(m.SourceNodeID, length),
+			limit(m.TargetNodeID, length),
+			m.ScoreImprovement)
+	}
+
+	if dryRun {
+		c.Ui.Output(c.Colorize().Color("[bold]Proposed Migrations[reset]"))
+		c.Ui.Output(formatList(rows))
+		return 0
+	}
+
+	c.Ui.Output(c.Colorize().Color("[bold]Migrations[reset]"))
+	c.Ui.Output(formatList(rows))
+	c.Ui.Output("")
+	for _, evalID := range resp.EvalIDs {
+		c.Ui.Output(fmt.Sprintf("Created evaluation %q", limit(evalID, length)))
+	}
+	return 0
+}
+
+func (c *OperatorSchedulerRebalance) Synopsis() string {
+	return "Migrate allocations to reduce cluster fragmentation"
+}
+
+func (c *OperatorSchedulerRebalance) Help() string {
+	helpText := `
+Usage: nomad operator scheduler rebalance [options]
+
+  Computes a plan of allocation migrations that reduces the fragmentation of
+  the cluster by moving allocations of service jobs away from the least
+  utilized nodes onto nodes where they bin pack better. Unless -dry-run is
+  set, the allocations are marked for migration and the schedulers move them,
+  never migrating more allocations of a task group at once than its migrate
+  stanza's max_parallel. The target nodes are a proposal and the schedulers
+  may place the allocations elsewhere.
+
+General Options:
+
+  ` + generalOptionsUsage() + `
+
+Rebalance Options:
+
+  -dry-run
+    Display the proposed migrations without applying them.
+
+  -max-migrations=<num>
+    The maximum number of allocations to migrate. Defaults to 10.
+
+  -verbose
+    Display full information.
+`
+	return strings.TrimSpace(helpText)
+}
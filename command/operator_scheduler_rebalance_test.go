@@ -0,0 +1,36 @@
+package command
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOperatorSchedulerRebalance_Implements(t *testing.T) {
+	t.Parallel()
+	var _ cli.Command = &OperatorSchedulerRebalance{}
+}
+
+func TestOperatorSchedulerRebalance_Run(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	s, _, addr := testServer(t, false, nil)
+	defer s.Shutdown()
+
+	ui := new(cli.MockUi)
+	c := &OperatorSchedulerRebalance{Meta: Meta{Ui: ui}}
+
+	// Fails on arguments and negative limits
+	require.Equal(1, c.Run([]string{"-address=" + addr, "foo"}))
+	require.Equal(1, c.Run([]string{"-address=" + addr, "-max-migrations=-1"}))
+	ui.ErrorWriter.Reset()
+
+	// An empty cluster has nothing to rebalance
+	code := c.Run([]string{"-address=" + addr, "-dry-run"})
+	require.Zero(code, ui.ErrorWriter.String())
+
+	output := strings.TrimSpace(ui.OutputWriter.String())
+	require.Contains(output, "No migrations would reduce cluster fragmentation")
+}
@@ -0,0 +1,143 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/consul/command/flags"
+	"github.com/hashicorp/nomad/api"
+	"github.com/posener/complete"
+)
+
+type OperatorSchedulerSetConfig struct {
+	Meta
+}
+
+func (c *OperatorSchedulerSetConfig) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-scheduler-algorithm":       complete.PredictSet("binpack", "spread"),
+			"-preempt-system-scheduler":  complete.PredictSet("true", "false"),
+			"-preempt-service-scheduler": complete.PredictSet("true", "false"),
+			"-preempt-batch-scheduler":   complete.PredictSet("true", "false"),
+			"-memory-oversubscription":   complete.PredictSet("true", "false"),
+			"-pause-eval-broker":         complete.PredictSet("true", "false"),
+		})
+}
+
+func (c *OperatorSchedulerSetConfig) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *OperatorSchedulerSetConfig) Name() string { return "operator scheduler set-config" }
+
+func (c *OperatorSchedulerSetConfig) Run(args []string) int {
+	var schedulerAlgorithm flags.StringValue
+	var preemptSystem flags.BoolValue
+	var preemptService flags.BoolValue
+	var preemptBatch flags.BoolValue
+	var memoryOversubscription flags.BoolValue
+	var pauseEvalBroker flags.BoolValue
+
+	f := c.Meta.FlagSet("scheduler", FlagSetClient)
+	f.Usage = func() { c.Ui.Output(c.Help()) }
+
+	f.Var(&schedulerAlgorithm, "scheduler-algorithm", "")
+	f.Var(&preemptSystem, "preempt-system-scheduler", "")
+	f.Var(&preemptService, "preempt-service-scheduler", "")
+	f.Var(&preemptBatch, "preempt-batch-scheduler", "")
+	f.Var(&memoryOversubscription, "memory-oversubscription", "")
+	f.Var(&pauseEvalBroker, "pause-eval-broker", "")
+
+	if err := f.Parse(args); err != nil {
+		c.Ui.Error(fmt.Sprintf("Failed to parse args: %v", err))
+		return 1
+	}
+
+	// Set up a client.
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	// Fetch the current configuration.
+	operator := client.Operator()
+	resp, _, err := operator.SchedulerGetConfiguration(nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error querying for scheduler configuration: %s", err))
+		return 1
+	}
+	conf := resp.SchedulerConfig
+
+	// Update the config values based on the set flags.
+	algorithm := string(conf.SchedulerAlgorithm)
+	schedulerAlgorithm.Merge(&algorithm)
+	switch algorithm {
+	case "", string(api.SchedulerAlgorithmBinpack), string(api.SchedulerAlgorithmSpread):
+	default:
+		c.Ui.Error(fmt.Sprintf("Invalid scheduler algorithm %q, must be one of [binpack|spread]", algorithm))
+		return 1
+	}
+	conf.SchedulerAlgorithm = api.SchedulerAlgorithm(algorithm)
+
+	preemptSystem.Merge(&conf.PreemptionConfig.SystemSchedulerEnabled)
+	preemptService.Merge(&conf.PreemptionConfig.ServiceSchedulerEnabled)
+	preemptBatch.Merge(&conf.PreemptionConfig.BatchSchedulerEnabled)
+	memoryOversubscription.Merge(&conf.MemoryOversubscriptionEnabled)
+	pauseEvalBroker.Merge(&conf.PauseEvalBroker)
+
+	// Check-and-set the new configuration.
+	result, _, err := operator.SchedulerCASConfiguration(conf, nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error setting scheduler configuration: %s", err))
+		return 1
+	}
+	if result.Updated {
+		c.Ui.Output("Scheduler configuration updated!")
+		return 0
+	}
+	c.Ui.Output("Scheduler configuration could not be atomically updated, please try again")
+	return 1
+}
+
+func (c *OperatorSchedulerSetConfig) Synopsis() string {
+	return "Modify the current scheduler configuration"
+}
+
+func (c *OperatorSchedulerSetConfig) Help() string {
+	helpText := `
+Usage: nomad operator scheduler set-config [options]
+
+  Modifies the current scheduler configuration.
+
+General Options:
+
+  ` + generalOptionsUsage() + `
+
+Set Config Options:
+
+  -scheduler-algorithm=[binpack|spread]
+     Specifies whether scheduler binpacks or spreads allocations on
+     available nodes. Binpacking places allocations on the most utilized
+     nodes while spreading places them on the least utilized nodes.
+
+  -preempt-system-scheduler=[true|false]
+     Specifies whether system jobs can preempt lower priority allocations.
+
+  -preempt-service-scheduler=[true|false]
+     Specifies whether service jobs can preempt lower priority allocations.
+
+  -preempt-batch-scheduler=[true|false]
+     Specifies whether batch jobs can preempt lower priority allocations.
+
+  -memory-oversubscription=[true|false]
+     Specifies whether tasks may set a memory_max limit above the memory
+     reserved for them.
+
+  -pause-eval-broker=[true|false]
+     Specifies whether the leader stops processing evaluations. The eval
+     broker must be paused to delete evaluations with "nomad eval delete".
+`
+	return strings.TrimSpace(helpText)
+}
@@ -0,0 +1,51 @@
+package command
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOperatorSchedulerSetConfig_Implements(t *testing.T) {
+	t.Parallel()
+	var _ cli.Command = &OperatorSchedulerSetConfig{}
+}
+
+func TestOperatorSchedulerSetConfig_Run(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	s, _, addr := testServer(t, false, nil)
+	defer s.Shutdown()
+
+	ui := new(cli.MockUi)
+	c := &OperatorSchedulerSetConfig{Meta: Meta{Ui: ui}}
+	args := []string{
+		"-address=" + addr,
+		"-scheduler-algorithm=spread",
+		"-preempt-batch-scheduler=true",
+	}
+
+	code := c.Run(args)
+	require.Zero(code, ui.ErrorWriter.String())
+	output := strings.TrimSpace(ui.OutputWriter.String())
+	require.Contains(output, "Scheduler configuration updated")
+
+	client, err := c.Client()
+	require.NoError(err)
+
+	resp, _, err := client.Operator().SchedulerGetConfiguration(nil)
+	require.NoError(err)
+	require.Equal(api.SchedulerAlgorithmSpread, resp.SchedulerConfig.SchedulerAlgorithm)
+	require.True(resp.SchedulerConfig.PreemptionConfig.SystemSchedulerEnabled)
+	require.True(resp.SchedulerConfig.PreemptionConfig.BatchSchedulerEnabled)
+
+	// Invalid algorithms are rejected
+	ui = new(cli.MockUi)
+	c = &OperatorSchedulerSetConfig{Meta: Meta{Ui: ui}}
+	code = c.Run([]string{"-address=" + addr, "-scheduler-algorithm=foo"})
+	require.Equal(1, code)
+	require.Contains(ui.ErrorWriter.String(), "Invalid scheduler algorithm")
+}
@@ -0,0 +1,391 @@
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	hclog "github.com/hashicorp/go-hclog"
+	memdb "github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/command/agent"
+	flaghelper "github.com/hashicorp/nomad/helper/flag-helpers"
+	"github.com/hashicorp/nomad/nomad"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/scheduler"
+	"github.com/posener/complete"
+)
+
+type OperatorSchedulerSimulate struct {
+	Meta
+	JobGetter
+}
+
+func (c *OperatorSchedulerSimulate) AutocompleteFlags() complete.Flags {
+	return complete.Flags{
+		"-add-nodes":  complete.PredictAnything,
+		"-node-class": complete.PredictAnything,
+		"-job":        complete.PredictOr(complete.PredictFiles("*.nomad"), complete.PredictFiles("*.hcl")),
+		"-verbose":    complete.PredictNothing,
+	}
+}
+
+func (c *OperatorSchedulerSimulate) AutocompleteArgs() complete.Predictor {
+	return complete.PredictFiles("*")
+}
+
+func (c *OperatorSchedulerSimulate) Name() string { return "operator scheduler simulate" }
+
+func (c *OperatorSchedulerSimulate) Run(args []string) int {
+	var addNodes int
+	var nodeClass string
+	var jobPaths []string
+	var verbose bool
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetNone)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.IntVar(&addNodes, "add-nodes", 0, "")
+	flags.StringVar(&nodeClass, "node-class", "", "")
+	flags.Var((*flaghelper.StringFlag)(&jobPaths), "job", "")
+	flags.BoolVar(&verbose, "verbose", false, "")
+
+	if err := flags.Parse(args); err != nil {
+		c.Ui.Error(fmt.Sprintf("Failed to parse args: %v", err))
+		return 1
+	}
+
+	// Check that we got exactly one argument
+	args = flags.Args()
+	if len(args) != 1 {
+		c.Ui.Error("This command takes one argument: <snapshot>")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	if addNodes < 0 {
+		c.Ui.Error("-add-nodes cannot be negative")
+		return 1
+	}
+	if nodeClass != "" && addNodes == 0 {
+		c.Ui.Error("-node-class requires -add-nodes")
+		return 1
+	}
+	if addNodes == 0 && len(jobPaths) == 0 {
+		c.Ui.Error("At least one of -add-nodes or -job must be given")
+		return 1
+	}
+
+	// Truncate the id unless full length is requested
+	length := shortId
+	if verbose {
+		length = fullId
+	}
+
+	// Parse the jobs before doing any work
+	jobs := make([]*structs.Job, 0, len(jobPaths))
+	for _, path := range jobPaths {
+		apiJob, err := c.JobGetter.ApiJob(path)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error getting job struct: %s", err))
+			return 1
+		}
+		jobs = append(jobs, agent.ApiJobToStructJob(apiJob))
+	}
+
+	state, err := restoreSimulationState(args[0])
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error restoring snapshot: %s", err))
+		return 1
+	}
+
+	sim, err := scheduler.NewSimulation(state, hclog.NewNullLogger())
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error creating simulation: %s", err))
+		return 1
+	}
+
+	if addNodes > 0 {
+		template, err := simulationTemplateNode(state, nodeClass)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error finding node to copy: %s", err))
+			return 1
+		}
+
+		nodes, err := sim.AddNodes(template, addNodes)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error adding nodes: %s", err))
+			return 1
+		}
+		c.Ui.Output(fmt.Sprintf("Added %d nodes like node %q (class %q)",
+			len(nodes), limit(template.ID, length), template.NodeClass))
+
+		// The added nodes may unblock jobs waiting for capacity and receive
+		// the allocations of system jobs
+		ids, err := simulationNodeUpdateJobs(state, sim)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error finding jobs to evaluate: %s", err))
+			return 1
+		}
+		for _, id := range ids {
+			result, err := sim.EvaluateJob(id.Namespace, id.ID, structs.EvalTriggerNodeUpdate)
+			if err != nil {
+				c.Ui.Error(fmt.Sprintf("Error evaluating job %q: %s", id.ID, err))
+				return 1
+			}
+			c.outputResult(result, length)
+		}
+	}
+
+	for _, job := range jobs {
+		result, err := sim.RegisterJob(job)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error registering job %q: %s", job.ID, err))
+			return 1
+		}
+		c.outputResult(result, length)
+	}
+
+	return 0
+}
+
+// outputResult prints the placements and placement failures of a simulated
+// evaluation.
+func (c *OperatorSchedulerSimulate) outputResult(result *scheduler.SimulationResult, length int) {
+	eval := result.Eval
+
+	c.Ui.Output("")
+	c.Ui.Output(c.Colorize().Color(fmt.Sprintf("[bold]Job %q (%s)[reset]", result.Job.ID, eval.TriggeredBy)))
+
+	// Summarize the changes of each task group
+	type tgSummary struct {
+		placed, stopped, preempted, failed, queued int
+	}
+	summaries := make(map[string]*tgSummary, len(result.Job.TaskGroups))
+	for _, tg := range result.Job.TaskGroups {
+		summaries[tg.Name] = &tgSummary{}
+	}
+	summary := func(tg string) *tgSummary {
+		s, ok := summaries[tg]
+		if !ok {
+			s = &tgSummary{}
+			summaries[tg] = s
+		}
+		return s
+	}
+
+	// Count the placements per task group and node
+	placements := make(map[string]map[string]int)
+	for _, alloc := range result.Placed {
+		summary(alloc.TaskGroup).placed++
+		if placements[alloc.TaskGroup] == nil {
+			placements[alloc.TaskGroup] = make(map[string]int)
+		}
+		placements[alloc.TaskGroup][alloc.NodeID]++
+	}
+	for _, alloc := range result.Stopped {
+		summary(alloc.TaskGroup).stopped++
+	}
+	for _, alloc := range result.Preempted {
+		summary(alloc.TaskGroup).preempted++
+	}
+	for tg, metrics := range eval.FailedTGAllocs {
+		summary(tg).failed = metrics.CoalescedFailures + 1
+	}
+	for tg, queued := range eval.QueuedAllocations {
+		summary(tg).queued = queued
+	}
+
+	tgs := make([]string, 0, len(summaries))
+	for tg := range summaries {
+		tgs = append(tgs, tg)
+	}
+	sort.Strings(tgs)
+
+	rows := make([]string, len(tgs)+1)
+	rows[0] = "Task Group|Placed|Stopped|Preempted|Failed|Queued"
+	for i, tg := range tgs {
+		s := summaries[tg]
+		rows[i+1] = fmt.Sprintf("%s|%d|%d|%d|%d|%d",
+			tg, s.placed, s.stopped, s.preempted, s.failed, s.queued)
+	}
+	c.Ui.Output(formatList(rows))
+
+	if len(result.Placed) > 0 {
+		var rows []string
+		rows = append(rows, "Task Group|Node ID|Placed")
+		for _, tg := range tgs {
+			nodes := make([]string, 0, len(placements[tg]))
+			for nodeID := range placements[tg] {
+				nodes = append(nodes, nodeID)
+			}
+			sort.Strings(nodes)
+			for _, nodeID := range nodes {
+				rows = append(rows, fmt.Sprintf("%s|%s|%d",
+					tg, limit(nodeID, length), placements[tg][nodeID]))
+			}
+		}
+
+		c.Ui.Output("")
+		c.Ui.Output(c.Colorize().Color("[bold]Placements[reset]"))
+		c.Ui.Output(formatList(rows))
+	}
+
+	if len(eval.FailedTGAllocs) > 0 {
+		c.Ui.Output("")
+		c.Ui.Output(c.Colorize().Color("[bold]Placement Failures[reset]"))
+		for _, tg := range tgs {
+			metrics, ok := eval.FailedTGAllocs[tg]
+			if !ok {
+				continue
+			}
+
+			noun := "allocation"
+			if metrics.CoalescedFailures > 0 {
+				noun += "s"
+			}
+			c.Ui.Output(fmt.Sprintf("Task Group %q (failed to place %d %s):", tg, metrics.CoalescedFailures+1, noun))
+
+			apiMetrics, err := apiAllocMetric(metrics)
+			if err != nil {
+				c.Ui.Error(fmt.Sprintf("Error converting metrics: %s", err))
+				continue
+			}
+			c.Ui.Output(formatAllocMetrics(apiMetrics, false, "  "))
+		}
+	}
+}
+
+// restoreSimulationState restores the state store from a snapshot of the
+// server state.
+func restoreSimulationState(path string) (*state.StateStore, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	fsm, err := nomad.NewFSM(&nomad.FSMConfig{
+		Logger: hclog.NewNullLogger(),
+	})
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	// Restore closes the file
+	if err := fsm.Restore(f); err != nil {
+		return nil, err
+	}
+	return fsm.State(), nil
+}
+
+// simulationTemplateNode returns a ready node of the node class to copy when
+// adding nodes, or any ready node if the node class is empty.
+func simulationTemplateNode(state *state.StateStore, nodeClass string) (*structs.Node, error) {
+	iter, err := state.Nodes(memdb.NewWatchSet())
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		raw := iter.Next()
+		if raw == nil {
+			break
+		}
+
+		node := raw.(*structs.Node)
+		if node.Status != structs.NodeStatusReady {
+			continue
+		}
+		if nodeClass == "" || node.NodeClass == nodeClass {
+			return node, nil
+		}
+	}
+
+	if nodeClass == "" {
+		return nil, fmt.Errorf("snapshot has no ready node")
+	}
+	return nil, fmt.Errorf("snapshot has no ready node of class %q", nodeClass)
+}
+
+// simulationNodeUpdateJobs returns the jobs to evaluate after adding nodes:
+// the jobs with blocked evaluations and the system jobs.
+func simulationNodeUpdateJobs(state *state.StateStore, sim *scheduler.Simulation) ([]structs.NamespacedID, error) {
+	ids, err := sim.BlockedJobs()
+	if err != nil {
+		return nil, err
+	}
+
+	iter, err := state.JobsByScheduler(memdb.NewWatchSet(), structs.JobTypeSystem)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		raw := iter.Next()
+		if raw == nil {
+			break
+		}
+
+		job := raw.(*structs.Job)
+		if job.Stopped() {
+			continue
+		}
+		ids = append(ids, structs.NamespacedID{Namespace: job.Namespace, ID: job.ID})
+	}
+	return ids, nil
+}
+
+// apiAllocMetric converts the allocation metrics to their API representation.
+func apiAllocMetric(metrics *structs.AllocMetric) (*api.AllocationMetric, error) {
+	buf, err := json.Marshal(metrics)
+	if err != nil {
+		return nil, err
+	}
+
+	var out api.AllocationMetric
+	if err := json.Unmarshal(buf, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *OperatorSchedulerSimulate) Synopsis() string {
+	return "Simulate scheduling decisions against a snapshot"
+}
+
+func (c *OperatorSchedulerSimulate) Help() string {
+	helpText := `
+Usage: nomad operator scheduler simulate [options] <snapshot>
+
+  Restores a snapshot of the server state, applies hypothetical changes to
+  the cluster and runs the schedulers to show where allocations would be
+  placed and why placements would fail. The cluster is never contacted or
+  modified.
+
+  The snapshot is the "state.bin" file of a Raft snapshot in a server's data
+  directory, under "server/raft/snapshots/<id>/".
+
+  When nodes are added, the jobs with blocked evaluations and the system jobs
+  are evaluated first. The jobs given with -job are then registered and
+  evaluated in order, each accounting for the placements of the previous
+  ones.
+
+Simulate Options:
+
+  -add-nodes=<count>
+    Add the given number of ready nodes to the cluster, copied from a ready
+    node of the snapshot.
+
+  -node-class=<class>
+    Copy the added nodes from a ready node of the given node class.
+
+  -job=<path>
+    Register the job in the jobspec file. Can be specified multiple times.
+
+  -verbose
+    Display full information.
+`
+	return strings.TrimSpace(helpText)
+}
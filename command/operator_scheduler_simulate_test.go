@@ -0,0 +1,116 @@
+package command
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/nomad"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOperatorSchedulerSimulate_Implements(t *testing.T) {
+	t.Parallel()
+	var _ cli.Command = &OperatorSchedulerSimulate{}
+}
+
+// snapshotSink writes a snapshot to a buffer
+type snapshotSink struct {
+	*bytes.Buffer
+}
+
+func (s *snapshotSink) ID() string    { return "test" }
+func (s *snapshotSink) Cancel() error { return nil }
+func (s *snapshotSink) Close() error  { return nil }
+
+func TestOperatorSchedulerSimulate_Run(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	dir, err := ioutil.TempDir("", "nomad-simulate")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+
+	// Write a snapshot of a cluster with a single node
+	fsm, err := nomad.NewFSM(&nomad.FSMConfig{Logger: testlog.HCLogger(t)})
+	require.NoError(err)
+	node := mock.Node()
+	node.NodeClass = "large"
+	require.NoError(node.ComputeClass())
+	require.NoError(fsm.State().UpsertNode(1000, node))
+
+	snap, err := fsm.Snapshot()
+	require.NoError(err)
+	sink := &snapshotSink{Buffer: new(bytes.Buffer)}
+	require.NoError(snap.Persist(sink))
+
+	snapPath := filepath.Join(dir, "state.bin")
+	require.NoError(ioutil.WriteFile(snapPath, sink.Bytes(), 0600))
+
+	jobPath := filepath.Join(dir, "example.nomad")
+	require.NoError(ioutil.WriteFile(jobPath, []byte(`
+job "example" {
+  datacenters = ["dc1"]
+
+  group "web" {
+    count = 10
+
+    task "web" {
+      driver = "exec"
+
+      config {
+        command = "/bin/date"
+      }
+
+      resources {
+        cpu    = 500
+        memory = 256
+      }
+    }
+  }
+}
+`), 0600))
+
+	ui := new(cli.MockUi)
+	c := &OperatorSchedulerSimulate{Meta: Meta{Ui: ui}}
+
+	// Fails on missing arguments, missing changes and unknown node classes
+	require.Equal(1, c.Run([]string{}))
+	require.Equal(1, c.Run([]string{snapPath}))
+	require.Equal(1, c.Run([]string{"-add-nodes=-1", snapPath}))
+	require.Equal(1, c.Run([]string{"-node-class=large", "-job=" + jobPath, snapPath}))
+	require.Equal(1, c.Run([]string{"-add-nodes=1", "-node-class=small", snapPath}))
+	require.Contains(ui.ErrorWriter.String(), `no ready node of class "small"`)
+	ui.ErrorWriter.Reset()
+
+	// Only 7 of the allocations fit on the node
+	code := c.Run([]string{"-job=" + jobPath, snapPath})
+	require.Zero(code, ui.ErrorWriter.String())
+	output := ui.OutputWriter.String()
+	require.Contains(output, `Job "example" (job-register)`)
+	require.Contains(output, `Task Group "web" (failed to place 3 allocations)`)
+	require.Contains(output, `Dimension "cpu" exhausted on 1 nodes`)
+	ui.OutputWriter.Reset()
+
+	// Adding a node makes room for all of them
+	code = c.Run([]string{"-add-nodes=1", "-node-class=large", "-job=" + jobPath, snapPath})
+	require.Zero(code, ui.ErrorWriter.String())
+	output = ui.OutputWriter.String()
+	require.Contains(output, "Added 1 nodes")
+	require.NotContains(output, "Placement Failures")
+
+	lines := strings.Split(output, "\n")
+	var summary string
+	for i, line := range lines {
+		if strings.HasPrefix(line, "Task Group") && strings.Contains(line, "Queued") {
+			summary = lines[i+1]
+		}
+	}
+	require.Equal([]string{"web", "10", "0", "0", "0", "0"}, strings.Fields(summary))
+}
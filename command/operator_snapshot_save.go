@@ -0,0 +1,147 @@
+package command
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/helper/snapshot"
+	"github.com/posener/complete"
+)
+
+type OperatorSnapshotSaveCommand struct {
+	Meta
+}
+
+func (c *OperatorSnapshotSaveCommand) Help() string {
+	helpText := `
+Usage: nomad operator snapshot save [options] <file>
+
+  Retrieves an atomic, point-in-time snapshot of the state of the Nomad servers
+  which includes jobs, nodes, allocations, periodic jobs, and ACLs.
+
+  If ACLs are enabled, a management token must be supplied in order to perform
+  snapshot operations.
+
+  To create a snapshot from the leader server and save it to "backup.snap":
+
+      $ nomad operator snapshot save backup.snap
+
+  To create a potentially stale snapshot from any available server (useful if
+  no leader is available):
+
+      $ nomad operator snapshot save -stale backup.snap
+
+General Options:
+
+  ` + generalOptionsUsage() + `
+
+Snapshot Save Options:
+
+  -stale=[true|false]
+    The -stale argument defaults to "false" which means the leader provides the
+    result. If the cluster is in an outage state without a leader, you may need
+    to set -stale to "true" to get the snapshot from a non-leader server.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *OperatorSnapshotSaveCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-stale": complete.PredictAnything,
+		})
+}
+
+func (c *OperatorSnapshotSaveCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictFiles("*")
+}
+
+func (c *OperatorSnapshotSaveCommand) Synopsis() string {
+	return "Saves a snapshot of the state of the Nomad servers"
+}
+
+func (c *OperatorSnapshotSaveCommand) Name() string { return "operator snapshot save" }
+
+func (c *OperatorSnapshotSaveCommand) Run(args []string) int {
+	var stale bool
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.BoolVar(&stale, "stale", false, "")
+	if err := flags.Parse(args); err != nil {
+		c.Ui.Error(fmt.Sprintf("Failed to parse args: %v", err))
+		return 1
+	}
+
+	// Check that we got exactly one argument
+	args = flags.Args()
+	if len(args) != 1 {
+		c.Ui.Error("This command takes one argument: <file>")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+	path := args[0]
+
+	// Set up a client.
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	// Take the snapshot.
+	snap, err := client.Operator().Snapshot(&api.QueryOptions{
+		AllowStale: stale,
+	})
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error querying snapshot: %s", err))
+		return 1
+	}
+	defer snap.Close()
+
+	// Save the snapshot to a temporary file in the destination directory so
+	// that a partial or corrupt snapshot never replaces an existing file. The
+	// checksum of the snapshot is validated as it is read.
+	tmpFile, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".tmp")
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error creating snapshot file: %s", err))
+		return 1
+	}
+	defer os.Remove(tmpFile.Name())
+
+	if _, err := io.Copy(tmpFile, snap); err != nil {
+		tmpFile.Close()
+		c.Ui.Error(fmt.Sprintf("Error writing snapshot file: %s", err))
+		return 1
+	}
+	if err := tmpFile.Close(); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error closing snapshot file: %s", err))
+		return 1
+	}
+
+	// Verify the snapshot archive.
+	f, err := os.Open(tmpFile.Name())
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error opening snapshot file for verify: %s", err))
+		return 1
+	}
+	meta, err := snapshot.Verify(f)
+	f.Close()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error verifying snapshot file: %s", err))
+		return 1
+	}
+
+	if err := os.Rename(tmpFile.Name(), path); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error saving snapshot file: %s", err))
+		return 1
+	}
+
+	c.Ui.Output(fmt.Sprintf("State file written to %v at index %d", path, meta.Index))
+	return 0
+}
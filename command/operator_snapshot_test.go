@@ -0,0 +1,97 @@
+package command
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOperator_Snapshot_Implements(t *testing.T) {
+	t.Parallel()
+	var _ cli.Command = &OperatorSnapshotCommand{}
+	var _ cli.Command = &OperatorSnapshotSaveCommand{}
+	var _ cli.Command = &OperatorSnapshotRestoreCommand{}
+	var _ cli.Command = &OperatorSnapshotInspectCommand{}
+}
+
+func TestOperator_Snapshot_SaveInspectRestore(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	srv, _, addr := testServer(t, false, nil)
+	defer srv.Shutdown()
+
+	dir, err := ioutil.TempDir("", "nomad-snapshot")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "backup.snap")
+
+	// Save a snapshot.
+	ui := new(cli.MockUi)
+	save := &OperatorSnapshotSaveCommand{Meta: Meta{Ui: ui}}
+	code := save.Run([]string{"-address=" + addr, path})
+	require.Zero(code, ui.ErrorWriter.String())
+	require.Contains(ui.OutputWriter.String(), "State file written to "+path)
+
+	// Only the snapshot is left in the directory.
+	files, err := ioutil.ReadDir(dir)
+	require.NoError(err)
+	require.Len(files, 1)
+
+	// Inspect the snapshot.
+	ui = new(cli.MockUi)
+	inspect := &OperatorSnapshotInspectCommand{Meta: Meta{Ui: ui}}
+	code = inspect.Run([]string{path})
+	require.Zero(code, ui.ErrorWriter.String())
+	output := ui.OutputWriter.String()
+	for _, key := range []string{"ID", "Size", "Index", "Term", "Version"} {
+		require.Contains(output, key)
+	}
+
+	// Restore the snapshot.
+	ui = new(cli.MockUi)
+	restore := &OperatorSnapshotRestoreCommand{Meta: Meta{Ui: ui}}
+	code = restore.Run([]string{"-address=" + addr, path})
+	require.Zero(code, ui.ErrorWriter.String())
+	require.Contains(ui.OutputWriter.String(), "Snapshot Restored")
+}
+
+func TestOperator_Snapshot_Fails(t *testing.T) {
+	t.Parallel()
+	dir, err := ioutil.TempDir("", "nomad-snapshot")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	// Write a file which isn't a snapshot.
+	path := filepath.Join(dir, "bad.snap")
+	require.NoError(t, ioutil.WriteFile(path, []byte("not a snapshot"), 0600))
+
+	save := func(ui cli.Ui) cli.Command { return &OperatorSnapshotSaveCommand{Meta: Meta{Ui: ui}} }
+	restore := func(ui cli.Ui) cli.Command { return &OperatorSnapshotRestoreCommand{Meta: Meta{Ui: ui}} }
+	inspect := func(ui cli.Ui) cli.Command { return &OperatorSnapshotInspectCommand{Meta: Meta{Ui: ui}} }
+
+	cases := []struct {
+		Name    string
+		Command func(cli.Ui) cli.Command
+		Args    []string
+		Error   string
+	}{
+		{"save no args", save, nil, "This command takes one argument"},
+		{"restore no args", restore, nil, "This command takes one argument"},
+		{"inspect no args", inspect, nil, "This command takes one argument"},
+		{"inspect missing file", inspect, []string{filepath.Join(dir, "nope")}, "Error opening snapshot file"},
+		{"inspect bad file", inspect, []string{path}, "Error verifying snapshot"},
+		{"restore missing file", restore, []string{filepath.Join(dir, "nope")}, "Error opening snapshot file"},
+		{"save bad address", save, []string{"-address=nope", filepath.Join(dir, "new.snap")}, "Error querying snapshot"},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			ui := new(cli.MockUi)
+			require.Equal(t, 1, c.Command(ui).Run(c.Args))
+			require.Contains(t, ui.ErrorWriter.String(), c.Error)
+		})
+	}
+}
@@ -231,6 +231,7 @@ func (d *Driver) RecoverTask(handle *drivers.TaskHandle) error {
 
 	d.tasks.Set(handle.Config.ID, h)
 	go h.run()
+	go h.syncSharedCpuset()
 
 	return nil
 }
@@ -376,6 +377,7 @@ CREATE:
 
 	d.tasks.Set(cfg.ID, h)
 	go h.run()
+	go h.syncSharedCpuset()
 
 	return handle, net, nil
 }
@@ -696,9 +698,13 @@ func (d *Driver) createContainerConfig(task *drivers.TaskConfig, driverConfig *T
 		config.WorkingDir = driverConfig.WorkDir
 	}
 
+	memory, memoryReservation := memoryLimits(task.Resources)
+
 	hostConfig := &docker.HostConfig{
-		Memory:    task.Resources.LinuxResources.MemoryLimitBytes,
-		CPUShares: task.Resources.LinuxResources.CPUShares,
+		Memory:            memory,
+		MemoryReservation: memoryReservation,
+		CPUShares:         task.Resources.LinuxResources.CPUShares,
+		CPUSetCPUs:        task.Resources.LinuxResources.CpusetCPUs,
 
 		// Binds are used to mount a host volume into the container. We mount a
 		// local directory for storage and a shared alloc directory that can be
@@ -739,7 +745,7 @@ func (d *Driver) createContainerConfig(task *drivers.TaskConfig, driverConfig *T
 		hostConfig.MemorySwap = 0
 		hostConfig.MemorySwappiness = -1
 	} else {
-		hostConfig.MemorySwap = task.Resources.LinuxResources.MemoryLimitBytes // MemorySwap is memory + swap.
+		hostConfig.MemorySwap = memory // MemorySwap is memory + swap.
 	}
 
 	loggingDriver := driverConfig.Logging.Type
@@ -1038,6 +1044,23 @@ func (d *Driver) createContainerConfig(task *drivers.TaskConfig, driverConfig *T
 	}, nil
 }
 
+// memoryLimits returns the hard memory limit and the soft memory reservation
+// in bytes for the task. A reservation is only set when the task may use
+// more memory than it reserved.
+func memoryLimits(resources *drivers.Resources) (memory, reservation int64) {
+	memory = resources.LinuxResources.MemoryLimitBytes
+	if resources.NomadResources == nil {
+		return memory, 0
+	}
+
+	maxMemory := resources.NomadResources.Memory.MemoryMaxMB * 1024 * 1024
+	if maxMemory <= memory {
+		return memory, 0
+	}
+
+	return maxMemory, memory
+}
+
 // detectIP of Docker container. Returns the first IP found as well as true if
 // the IP should be advertised (bridge network IPs return false). Returns an
 // empty string and false if no IP could be found.
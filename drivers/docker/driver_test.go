@@ -1028,6 +1028,55 @@ func TestDockerDriver_CreateContainerConfig(t *testing.T) {
 	require.Equal(t, containerName, c.Name)
 }
 
+func TestDockerDriver_CreateContainerConfig_MemoryMax(t *testing.T) {
+	t.Parallel()
+
+	task, cfg, ports := dockerTask(t)
+	defer freeport.Return(ports)
+	require.NoError(t, task.EncodeConcreteDriverConfig(cfg))
+
+	dh := dockerDriverHarness(t, nil)
+	driver := dh.Impl().(*Driver)
+
+	// Without a memory max the reservation is the hard limit
+	c, err := driver.createContainerConfig(task, cfg, "org/repo:0.1")
+	require.NoError(t, err)
+	require.EqualValues(t, 256*1024*1024, c.HostConfig.Memory)
+	require.EqualValues(t, 0, c.HostConfig.MemoryReservation)
+
+	// With a memory max the reservation becomes a soft limit
+	task.Resources.NomadResources.Memory.MemoryMaxMB = 1024
+	c, err = driver.createContainerConfig(task, cfg, "org/repo:0.1")
+	require.NoError(t, err)
+	require.EqualValues(t, 1024*1024*1024, c.HostConfig.Memory)
+	require.EqualValues(t, 256*1024*1024, c.HostConfig.MemoryReservation)
+	if runtime.GOOS != "windows" {
+		require.EqualValues(t, 1024*1024*1024, c.HostConfig.MemorySwap)
+	}
+}
+
+func TestDockerDriver_CreateContainerConfig_Cpuset(t *testing.T) {
+	t.Parallel()
+
+	task, cfg, ports := dockerTask(t)
+	defer freeport.Return(ports)
+	require.NoError(t, task.EncodeConcreteDriverConfig(cfg))
+
+	dh := dockerDriverHarness(t, nil)
+	driver := dh.Impl().(*Driver)
+
+	// Tasks without reserved cores are not pinned
+	c, err := driver.createContainerConfig(task, cfg, "org/repo:0.1")
+	require.NoError(t, err)
+	require.Empty(t, c.HostConfig.CPUSetCPUs)
+
+	// Tasks with reserved cores are pinned to them
+	task.Resources.LinuxResources.CpusetCPUs = "1,3"
+	c, err = driver.createContainerConfig(task, cfg, "org/repo:0.1")
+	require.NoError(t, err)
+	require.Equal(t, "1,3", c.HostConfig.CPUSetCPUs)
+}
+
 func TestDockerDriver_CreateContainerConfig_User(t *testing.T) {
 	t.Parallel()
 
@@ -12,12 +12,17 @@ import (
 	docker "github.com/fsouza/go-dockerclient"
 	hclog "github.com/hashicorp/go-hclog"
 	plugin "github.com/hashicorp/go-plugin"
+	"github.com/hashicorp/nomad/client/lib/cgutil"
 	"github.com/hashicorp/nomad/drivers/docker/docklog"
 	"github.com/hashicorp/nomad/plugins/drivers"
 	pstructs "github.com/hashicorp/nomad/plugins/shared/structs"
 	"golang.org/x/net/context"
 )
 
+// cpusetSyncInterval is how often the cpuset of the containers that don't
+// reserve cores is synced with the cores of the node that aren't reserved
+const cpusetSyncInterval = 5 * time.Second
+
 type taskHandle struct {
 	client                *docker.Client
 	waitClient            *docker.Client
@@ -170,6 +175,45 @@ func (h *taskHandle) Kill(killTimeout time.Duration, signal os.Signal) error {
 	return nil
 }
 
+// syncSharedCpuset keeps the cpuset of a container that doesn't reserve cores
+// to the cores of the node that aren't reserved, as allocations reserving
+// cores are started and stopped.
+func (h *taskHandle) syncSharedCpuset() {
+	res := h.task.Resources
+	if res == nil || res.LinuxResources == nil ||
+		(res.NomadResources != nil && len(res.NomadResources.Cpu.ReservedCores) > 0) {
+		return
+	}
+
+	cpuset := res.LinuxResources.CpusetCPUs
+	ticker := time.NewTicker(cpusetSyncInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-h.doneCh:
+			return
+		case <-ticker.C:
+		}
+
+		shared, err := cgutil.SharedCpusetCpus()
+		if err != nil {
+			h.logger.Debug("failed to read the shared cpuset", "error", err)
+			continue
+		}
+		if shared == "" || shared == cpuset {
+			continue
+		}
+
+		if err := h.client.UpdateContainer(h.containerID, docker.UpdateContainerOptions{
+			CpusetCpus: shared,
+		}); err != nil {
+			h.logger.Warn("failed to update the cpuset of the container", "cpuset", shared, "error", err)
+			continue
+		}
+		cpuset = shared
+	}
+}
+
 func (h *taskHandle) shutdownLogger() {
 	if err := h.dlogger.Stop(); err != nil {
 		h.logger.Error("failed to stop docker logger process during StopTask",
@@ -19,6 +19,7 @@ import (
 	hclog "github.com/hashicorp/go-hclog"
 	multierror "github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/nomad/client/allocdir"
+	"github.com/hashicorp/nomad/client/lib/cgutil"
 	"github.com/hashicorp/nomad/client/stats"
 	cstructs "github.com/hashicorp/nomad/client/structs"
 	shelpers "github.com/hashicorp/nomad/helper/stats"
@@ -697,9 +698,24 @@ func configureCgroups(cfg *lconfigs.Config, command *ExecCommand) error {
 		return nil
 	}
 
+	// Tasks that don't reserve cores are placed in the shared cgroup, whose
+	// cpuset the client keeps to the cores that aren't reserved
+	reservedCores := command.Resources.NomadResources.Cpu.ReservedCores
+	if len(reservedCores) == 0 {
+		cfg.Cgroups.Path = filepath.Join("/", cgutil.SharedCpusetCgroup, id)
+	}
+
 	if mb := command.Resources.NomadResources.Memory.MemoryMB; mb > 0 {
 		// Total amount of memory allowed to consume
 		cfg.Cgroups.Resources.Memory = mb * 1024 * 1024
+
+		// If the task may use more memory than it reserved, the reservation
+		// becomes a soft limit and the maximum the hard limit
+		if maxMB := command.Resources.NomadResources.Memory.MemoryMaxMB; maxMB > mb {
+			cfg.Cgroups.Resources.MemoryReservation = mb * 1024 * 1024
+			cfg.Cgroups.Resources.Memory = maxMB * 1024 * 1024
+		}
+
 		// Disable swap to avoid issues on the machine
 		var memSwappiness uint64
 		cfg.Cgroups.Resources.MemorySwappiness = &memSwappiness
@@ -713,6 +729,12 @@ func configureCgroups(cfg *lconfigs.Config, command *ExecCommand) error {
 	// Set the relative CPU shares for this cgroup.
 	cfg.Cgroups.Resources.CpuShares = uint64(cpuShares)
 
+	// Pin the task to its reserved cores. Other tasks inherit the cpuset of
+	// the shared cgroup so that it follows the reservations of the node.
+	if len(reservedCores) > 0 {
+		cfg.Cgroups.Resources.CpusetCpus = cgutil.FormatCpuset(reservedCores)
+	}
+
 	return nil
 }
 
@@ -0,0 +1,155 @@
+package raftutil
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"hash/crc64"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	hclog "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/nomad"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/raft"
+)
+
+// fsm is the Nomad FSM the Raft data is replayed into.
+type fsm interface {
+	raft.FSM
+	State() *state.StateStore
+}
+
+// snapshotMeta is the metadata of a snapshot of a Raft file snapshot store.
+type snapshotMeta struct {
+	raft.SnapshotMeta
+	CRC []byte
+}
+
+// ReplayState rebuilds the state of a server from its Raft data. Given the
+// path of a raft.db file or of a directory holding it, the latest snapshot of
+// the server is restored and the logs following it are replayed through the
+// FSM. It returns the rebuilt state store and the index of the last log
+// applied.
+func ReplayState(p string, logger hclog.Logger) (*state.StateStore, uint64, error) {
+	dbPath, err := FindRaftFile(p)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	f, err := newFSM(logger)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Restore the latest snapshot, since the logs it holds may have been
+	// compacted away.
+	snapDir := filepath.Join(filepath.Dir(dbPath), "snapshots")
+	lastIndex, err := restoreSnapshot(f, snapDir)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	err = ReadLogs(dbPath, func(log *raft.Log) error {
+		if log.Index <= lastIndex || log.Type != raft.LogCommand {
+			return nil
+		}
+		if err := applyLog(f, log); err != nil {
+			return err
+		}
+		lastIndex = log.Index
+		return nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return f.State(), lastIndex, nil
+}
+
+// newFSM returns a Nomad FSM whose eval broker, blocked evals and periodic
+// dispatcher are never enabled, so that applying logs only updates its state.
+func newFSM(logger hclog.Logger) (fsm, error) {
+	broker, err := nomad.NewEvalBroker(time.Second, time.Second, time.Second, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	return nomad.NewFSM(&nomad.FSMConfig{
+		EvalBroker: broker,
+		Periodic:   nomad.NewPeriodicDispatch(logger, nil),
+		Blocked:    nomad.NewBlockedEvals(broker, logger),
+		Logger:     logger,
+	})
+}
+
+// applyLog applies a log to the FSM. Errors returned by the FSM are ignored,
+// as a server would, but a log the FSM fails to decode is reported.
+func applyLog(f fsm, log *raft.Log) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("failed to apply log %d: %v", log.Index, r)
+		}
+	}()
+
+	f.Apply(log)
+	return nil
+}
+
+// restoreSnapshot restores the latest snapshot of the given Raft file snapshot
+// directory into the FSM and returns its index. No snapshot is restored if the
+// directory doesn't exist.
+func restoreSnapshot(f fsm, dir string) (uint64, error) {
+	entries, err := ioutil.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return 0, nil
+	} else if err != nil {
+		return 0, fmt.Errorf("failed to list snapshots: %v", err)
+	}
+
+	var latest *snapshotMeta
+	var latestDir string
+	for _, entry := range entries {
+		if !entry.IsDir() || strings.HasSuffix(entry.Name(), ".tmp") {
+			continue
+		}
+
+		buf, err := ioutil.ReadFile(filepath.Join(dir, entry.Name(), "meta.json"))
+		if err != nil {
+			return 0, fmt.Errorf("failed to read snapshot %q: %v", entry.Name(), err)
+		}
+		var meta snapshotMeta
+		if err := json.Unmarshal(buf, &meta); err != nil {
+			return 0, fmt.Errorf("failed to decode snapshot %q: %v", entry.Name(), err)
+		}
+
+		if latest == nil || meta.Term > latest.Term ||
+			(meta.Term == latest.Term && meta.Index > latest.Index) {
+			latest = &meta
+			latestDir = filepath.Join(dir, entry.Name())
+		}
+	}
+	if latest == nil {
+		return 0, nil
+	}
+
+	state, err := ioutil.ReadFile(filepath.Join(latestDir, "state.bin"))
+	if err != nil {
+		return 0, fmt.Errorf("failed to read snapshot %q: %v", latest.ID, err)
+	}
+
+	crc := crc64.New(crc64.MakeTable(crc64.ECMA))
+	crc.Write(state)
+	if !bytes.Equal(crc.Sum(nil), latest.CRC) {
+		return 0, fmt.Errorf("snapshot %q is corrupt: CRC mismatch", latest.ID)
+	}
+
+	if err := f.Restore(ioutil.NopCloser(bytes.NewReader(state))); err != nil {
+		return 0, fmt.Errorf("failed to restore snapshot %q: %v", latest.ID, err)
+	}
+
+	return latest.Index, nil
+}
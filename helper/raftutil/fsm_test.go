@@ -0,0 +1,185 @@
+package raftutil
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/raft"
+	raftboltdb "github.com/hashicorp/raft-boltdb"
+	"github.com/stretchr/testify/require"
+)
+
+// testLogs returns Raft logs registering a node, a job and an allocation of
+// the job on the node.
+func testLogs(t *testing.T) ([]*raft.Log, *structs.Node, *structs.Job, *structs.Allocation) {
+	node := mock.Node()
+	alloc := mock.Alloc()
+	alloc.NodeID = node.ID
+	job := alloc.Job
+
+	reqs := []struct {
+		msgType structs.MessageType
+		req     interface{}
+	}{
+		{structs.NodeRegisterRequestType, &structs.NodeRegisterRequest{Node: node}},
+		{structs.JobRegisterRequestType, &structs.JobRegisterRequest{Job: job}},
+		{structs.AllocUpdateRequestType, &structs.AllocUpdateRequest{Alloc: []*structs.Allocation{alloc}}},
+	}
+
+	var logs []*raft.Log
+	for i, r := range reqs {
+		buf, err := structs.Encode(r.msgType, r.req)
+		require.NoError(t, err)
+		logs = append(logs, &raft.Log{
+			Index: uint64(i + 1),
+			Term:  1,
+			Type:  raft.LogCommand,
+			Data:  buf,
+		})
+	}
+	return logs, node, job, alloc
+}
+
+// testRaftDir returns a data directory holding a raft.db file with the given
+// logs.
+func testRaftDir(t *testing.T, logs []*raft.Log) string {
+	dir, err := ioutil.TempDir("", "nomad-raftutil")
+	require.NoError(t, err)
+
+	raftDir := filepath.Join(dir, "server", "raft")
+	require.NoError(t, os.MkdirAll(raftDir, 0700))
+
+	store, err := raftboltdb.NewBoltStore(filepath.Join(raftDir, "raft.db"))
+	require.NoError(t, err)
+	defer store.Close()
+	require.NoError(t, store.StoreLogs(logs))
+
+	return dir
+}
+
+func TestFindRaftFile(t *testing.T) {
+	t.Parallel()
+	dir := testRaftDir(t, nil)
+	defer os.RemoveAll(dir)
+
+	expected := filepath.Join(dir, "server", "raft", "raft.db")
+	for _, p := range []string{
+		dir,
+		filepath.Join(dir, "server"),
+		filepath.Join(dir, "server", "raft"),
+		expected,
+	} {
+		found, err := FindRaftFile(p)
+		require.NoError(t, err)
+		require.Equal(t, expected, found)
+	}
+
+	_, err := FindRaftFile(filepath.Join(dir, "nope"))
+	require.Error(t, err)
+}
+
+func TestReadLogs(t *testing.T) {
+	t.Parallel()
+	logs, _, _, _ := testLogs(t)
+	dir := testRaftDir(t, logs)
+	defer os.RemoveAll(dir)
+
+	p, err := FindRaftFile(dir)
+	require.NoError(t, err)
+
+	var read []*raft.Log
+	require.NoError(t, ReadLogs(p, func(log *raft.Log) error {
+		read = append(read, log)
+		return nil
+	}))
+	require.Len(t, read, len(logs))
+	for i, log := range read {
+		require.Equal(t, logs[i].Index, log.Index)
+		require.Equal(t, logs[i].Data, log.Data)
+	}
+}
+
+func TestReplayState(t *testing.T) {
+	t.Parallel()
+	logs, node, job, alloc := testLogs(t)
+	dir := testRaftDir(t, logs)
+	defer os.RemoveAll(dir)
+
+	state, index, err := ReplayState(dir, testlog.HCLogger(t))
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), index)
+
+	out, err := state.NodeByID(nil, node.ID)
+	require.NoError(t, err)
+	require.NotNil(t, out)
+
+	outJob, err := state.JobByID(nil, job.Namespace, job.ID)
+	require.NoError(t, err)
+	require.NotNil(t, outJob)
+
+	outAlloc, err := state.AllocByID(nil, alloc.ID)
+	require.NoError(t, err)
+	require.NotNil(t, outAlloc)
+}
+
+func TestReplayState_Snapshot(t *testing.T) {
+	t.Parallel()
+	logger := testlog.HCLogger(t)
+	logs, node, job, alloc := testLogs(t)
+
+	// Only the last log is left in the store, the others having been
+	// compacted into a snapshot.
+	dir := testRaftDir(t, logs[2:])
+	defer os.RemoveAll(dir)
+
+	f, err := newFSM(logger)
+	require.NoError(t, err)
+	for _, log := range logs[:2] {
+		require.NoError(t, applyLog(f, log))
+	}
+	snap, err := f.Snapshot()
+	require.NoError(t, err)
+
+	snaps, err := raft.NewFileSnapshotStore(filepath.Join(dir, "server", "raft"), 1, ioutil.Discard)
+	require.NoError(t, err)
+	sink, err := snaps.Create(1, 2, 1, raft.Configuration{}, 1, nil)
+	require.NoError(t, err)
+	require.NoError(t, snap.Persist(sink))
+	require.NoError(t, sink.Close())
+
+	state, index, err := ReplayState(dir, logger)
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), index)
+
+	out, err := state.NodeByID(nil, node.ID)
+	require.NoError(t, err)
+	require.NotNil(t, out)
+
+	outJob, err := state.JobByID(nil, job.Namespace, job.ID)
+	require.NoError(t, err)
+	require.NotNil(t, outJob)
+
+	outAlloc, err := state.AllocByID(nil, alloc.ID)
+	require.NoError(t, err)
+	require.NotNil(t, outAlloc)
+}
+
+func TestReplayState_BadLog(t *testing.T) {
+	t.Parallel()
+	dir := testRaftDir(t, []*raft.Log{{
+		Index: 1,
+		Term:  1,
+		Type:  raft.LogCommand,
+		Data:  []byte{uint8(structs.JobRegisterRequestType), 0xff},
+	}})
+	defer os.RemoveAll(dir)
+
+	_, _, err := ReplayState(dir, testlog.HCLogger(t))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to apply log 1")
+}
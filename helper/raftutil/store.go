@@ -0,0 +1,79 @@
+// Package raftutil provides tooling to inspect the Raft data of a Nomad server
+// while the server is stopped.
+package raftutil
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/boltdb/bolt"
+	"github.com/hashicorp/go-msgpack/codec"
+	"github.com/hashicorp/raft"
+)
+
+var (
+	// logsBucket is the BoltDB bucket of the Raft logs, as created by
+	// raft-boltdb.
+	logsBucket = []byte("logs")
+)
+
+// FindRaftFile returns the path of the raft.db file of a server given either
+// the file itself or the data directory, server directory or Raft directory
+// holding it.
+func FindRaftFile(p string) (string, error) {
+	candidates := []string{
+		p,
+		filepath.Join(p, "raft.db"),
+		filepath.Join(p, "raft", "raft.db"),
+		filepath.Join(p, "server", "raft", "raft.db"),
+	}
+
+	for _, c := range candidates {
+		if fi, err := os.Stat(c); err == nil && !fi.IsDir() {
+			return c, nil
+		}
+	}
+
+	return "", fmt.Errorf("failed to find raft.db in %q", p)
+}
+
+// ReadLogs opens the raft.db file at the given path read-only and calls fn on
+// each of its logs in index order. It stops at the first error returned by fn.
+func ReadLogs(p string, fn func(*raft.Log) error) error {
+	// The file is opened read-only so that inspecting it never modifies it,
+	// and with a timeout since a running server holds a lock on it.
+	db, err := bolt.Open(p, 0600, &bolt.Options{
+		ReadOnly: true,
+		Timeout:  time.Second,
+	})
+	if err == bolt.ErrTimeout {
+		return fmt.Errorf("timed out opening raft store %q, is the server running?", p)
+	} else if err != nil {
+		return fmt.Errorf("failed to open raft store %q: %v", p, err)
+	}
+	defer db.Close()
+
+	return db.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(logsBucket)
+		if bucket == nil {
+			return fmt.Errorf("raft store %q has no logs", p)
+		}
+
+		return bucket.ForEach(func(k, v []byte) error {
+			var log raft.Log
+			if err := decodeMsgPack(v, &log); err != nil {
+				return fmt.Errorf("failed to decode log %x: %v", k, err)
+			}
+			return fn(&log)
+		})
+	})
+}
+
+// decodeMsgPack decodes a value encoded by raft-boltdb.
+func decodeMsgPack(buf []byte, out interface{}) error {
+	dec := codec.NewDecoder(bytes.NewReader(buf), &codec.MsgpackHandle{})
+	return dec.Decode(out)
+}
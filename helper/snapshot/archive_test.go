@@ -0,0 +1,196 @@
+package snapshot
+
+import (
+	"archive/tar"
+	"bytes"
+	"crypto/rand"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/raft"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArchive(t *testing.T) {
+	// Create some fake snapshot data.
+	metadata := raft.SnapshotMeta{
+		Index: 2005,
+		Term:  2011,
+		Configuration: raft.Configuration{
+			Servers: []raft.Server{
+				{
+					Suffrage: raft.Voter,
+					ID:       raft.ServerID("hello"),
+					Address:  raft.ServerAddress("127.0.0.1:8300"),
+				},
+			},
+		},
+		Size: 1024,
+	}
+	var snap bytes.Buffer
+	var expected bytes.Buffer
+	both := io.MultiWriter(&snap, &expected)
+	if _, err := io.Copy(both, io.LimitReader(rand.Reader, 1024)); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	// Write out the snapshot.
+	var archive bytes.Buffer
+	if err := write(&archive, &metadata, &snap); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	// Read the snapshot back.
+	var newMeta raft.SnapshotMeta
+	var newSnap bytes.Buffer
+	if err := read(&archive, &newMeta, &newSnap); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	// Check the contents.
+	if !reflect.DeepEqual(newMeta, metadata) {
+		t.Fatalf("bad: %#v", newMeta)
+	}
+	var buf bytes.Buffer
+	if _, err := io.Copy(&buf, &newSnap); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if !bytes.Equal(buf.Bytes(), expected.Bytes()) {
+		t.Fatalf("snapshot contents didn't match")
+	}
+}
+
+// testTar returns a tar archive holding the given files in order.
+func testTar(t *testing.T, files ...[2]string) *bytes.Buffer {
+	var buf bytes.Buffer
+	archive := tar.NewWriter(&buf)
+	for _, file := range files {
+		require.NoError(t, archive.WriteHeader(&tar.Header{
+			Name: file[0],
+			Mode: 0600,
+			Size: int64(len(file[1])),
+		}))
+		_, err := archive.Write([]byte(file[1]))
+		require.NoError(t, err)
+	}
+	require.NoError(t, archive.Close())
+	return &buf
+}
+
+// testSums returns a SHA256SUMS file for the given files.
+func testSums(t *testing.T, files ...[2]string) string {
+	hl := newHashList()
+	for _, file := range files {
+		_, err := hl.Add(file[0]).Write([]byte(file[1]))
+		require.NoError(t, err)
+	}
+	var buf bytes.Buffer
+	require.NoError(t, hl.Encode(&buf))
+	return buf.String()
+}
+
+func TestArchive_BadData(t *testing.T) {
+	meta := [2]string{"meta.json", `{"Index":1,"Term":1}`}
+	state := [2]string{"state.bin", "state"}
+	sums := [2]string{"SHA256SUMS", testSums(t, meta, state)}
+
+	cases := []struct {
+		Name    string
+		Archive *bytes.Buffer
+		Error   string
+	}{
+		{
+			"empty",
+			testTar(t),
+			"failed checking integrity of snapshot",
+		},
+		{
+			"extra",
+			testTar(t, meta, state, sums, [2]string{"nope", "nope"}),
+			`unexpected file "nope"`,
+		},
+		{
+			"missing meta",
+			testTar(t, state, sums),
+			`hash check failed for "meta.json"`,
+		},
+		{
+			"missing state",
+			testTar(t, meta, sums),
+			`hash check failed for "state.bin"`,
+		},
+		{
+			"missing sha",
+			testTar(t, meta, state),
+			"file missing",
+		},
+		{
+			"corrupt meta",
+			testTar(t, [2]string{"meta.json", `{"Index":2,"Term":1}`}, state, sums),
+			`hash check failed for "meta.json"`,
+		},
+		{
+			"corrupt state",
+			testTar(t, meta, [2]string{"state.bin", "corrupt"}, sums),
+			`hash check failed for "state.bin"`,
+		},
+		{
+			"corrupt sha",
+			testTar(t, meta, state, [2]string{"SHA256SUMS", testSums(t, meta, state, [2]string{"nope", ""})}),
+			`list missing hash for "nope"`,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			var metadata raft.SnapshotMeta
+			err := read(c.Archive, &metadata, ioutil.Discard)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), c.Error)
+		})
+	}
+}
+
+func TestArchive_hashList(t *testing.T) {
+	hl := newHashList()
+	for i := 0; i < 16; i++ {
+		h := hl.Add(fmt.Sprintf("file-%d", i))
+		if _, err := io.CopyN(h, rand.Reader, 32); err != nil {
+			t.Fatalf("err: %v", err)
+		}
+	}
+
+	// Do a normal round trip.
+	var buf bytes.Buffer
+	if err := hl.Encode(&buf); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if err := hl.DecodeAndVerify(&buf); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	// Have a local hash that isn't in the file.
+	buf.Reset()
+	if err := hl.Encode(&buf); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	hl.Add("nope")
+	err := hl.DecodeAndVerify(&buf)
+	if err == nil || !strings.Contains(err.Error(), "file missing for \"nope\"") {
+		t.Fatalf("err: %v", err)
+	}
+
+	// Have a hash in the file that we haven't seen locally.
+	buf.Reset()
+	if err := hl.Encode(&buf); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	delete(hl.hashes, "nope")
+	err = hl.DecodeAndVerify(&buf)
+	if err == nil || !strings.Contains(err.Error(), "list missing hash for \"nope\"") {
+		t.Fatalf("err: %v", err)
+	}
+}
@@ -0,0 +1,201 @@
+package snapshot
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/raft"
+	"github.com/stretchr/testify/require"
+)
+
+// mockFSM is a simple FSM for testing that records the data of the applied
+// logs.
+type mockFSM struct {
+	l    sync.Mutex
+	logs [][]byte
+}
+
+type mockSnapshot struct {
+	logs [][]byte
+}
+
+func (m *mockFSM) Apply(log *raft.Log) interface{} {
+	m.l.Lock()
+	defer m.l.Unlock()
+	m.logs = append(m.logs, log.Data)
+	return len(m.logs)
+}
+
+func (m *mockFSM) Snapshot() (raft.FSMSnapshot, error) {
+	m.l.Lock()
+	defer m.l.Unlock()
+	return &mockSnapshot{m.logs[:]}, nil
+}
+
+func (m *mockFSM) Restore(in io.ReadCloser) error {
+	m.l.Lock()
+	defer m.l.Unlock()
+	defer in.Close()
+
+	m.logs = nil
+	return json.NewDecoder(in).Decode(&m.logs)
+}
+
+func (m *mockFSM) Logs() [][]byte {
+	m.l.Lock()
+	defer m.l.Unlock()
+	return m.logs
+}
+
+func (m *mockSnapshot) Persist(sink raft.SnapshotSink) error {
+	if err := json.NewEncoder(sink).Encode(m.logs); err != nil {
+		sink.Cancel()
+		return err
+	}
+	return sink.Close()
+}
+
+func (m *mockSnapshot) Release() {}
+
+// testRaft returns a single node Raft cluster with an in-memory store and the
+// FSM it applies logs to.
+func testRaft(t *testing.T) (*raft.Raft, *mockFSM) {
+	config := raft.DefaultConfig()
+	config.LocalID = raft.ServerID("server")
+	config.HeartbeatTimeout = 50 * time.Millisecond
+	config.ElectionTimeout = 50 * time.Millisecond
+	config.LeaderLeaseTimeout = 50 * time.Millisecond
+	config.CommitTimeout = 5 * time.Millisecond
+	config.Logger = testlog.Logger(t)
+
+	fsm := &mockFSM{}
+	store := raft.NewInmemStore()
+	snaps := raft.NewInmemSnapshotStore()
+	addr, trans := raft.NewInmemTransport("")
+
+	configuration := raft.Configuration{
+		Servers: []raft.Server{{
+			ID:      config.LocalID,
+			Address: addr,
+		}},
+	}
+	require.NoError(t, raft.BootstrapCluster(config, store, store, snaps, trans, configuration))
+
+	r, err := raft.NewRaft(config, fsm, store, store, snaps, trans)
+	require.NoError(t, err)
+
+	select {
+	case <-r.LeaderCh():
+	case <-time.After(5 * time.Second):
+		t.Fatalf("timeout waiting for leader")
+	}
+	return r, fsm
+}
+
+func testApply(t *testing.T, r *raft.Raft, count int) [][]byte {
+	var logs [][]byte
+	for i := 0; i < count; i++ {
+		data := []byte(fmt.Sprintf("log-%d", i))
+		require.NoError(t, r.Apply(data, time.Second).Error())
+		logs = append(logs, data)
+	}
+	return logs
+}
+
+func TestSnapshot(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	logger := testlog.HCLogger(t)
+
+	// Apply some logs and take a snapshot.
+	before, fsm := testRaft(t)
+	defer before.Shutdown()
+	expected := testApply(t, before, 64)
+
+	snap, err := New(logger, before)
+	require.NoError(err)
+	defer snap.Close()
+
+	// Make sure the index and checksum were set.
+	require.NotZero(snap.Index())
+	require.Contains(snap.Checksum(), "sha-256=")
+
+	// Read the snapshot out so we can use it more than once.
+	var archive bytes.Buffer
+	_, err = io.Copy(&archive, snap)
+	require.NoError(err)
+
+	// Verify the snapshot.
+	meta, err := Verify(bytes.NewReader(archive.Bytes()))
+	require.NoError(err)
+	require.Equal(snap.Index(), meta.Index)
+
+	// Restore the snapshot on a fresh cluster.
+	after, afterFSM := testRaft(t)
+	defer after.Shutdown()
+	testApply(t, after, 4)
+	require.NoError(Restore(logger, bytes.NewReader(archive.Bytes()), after))
+
+	require.Equal(fsm.Logs(), afterFSM.Logs())
+	require.Equal(expected, afterFSM.Logs())
+}
+
+func TestSnapshot_Nil(t *testing.T) {
+	t.Parallel()
+	var snap *Snapshot
+
+	require.Zero(t, snap.Index())
+	require.Empty(t, snap.Checksum())
+
+	n, err := snap.Read(make([]byte, 16))
+	require.Zero(t, n)
+	require.Equal(t, io.EOF, err)
+
+	require.NoError(t, snap.Close())
+}
+
+func TestSnapshot_BadVerify(t *testing.T) {
+	t.Parallel()
+
+	// Garbage is not a compressed archive.
+	_, err := Verify(bytes.NewReader([]byte("nope")))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to decompress snapshot")
+}
+
+func TestSnapshot_BadRestore(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	logger := testlog.HCLogger(t)
+
+	// Take a snapshot.
+	before, _ := testRaft(t)
+	defer before.Shutdown()
+	testApply(t, before, 16)
+
+	snap, err := New(logger, before)
+	require.NoError(err)
+	defer snap.Close()
+
+	var archive bytes.Buffer
+	_, err = io.Copy(&archive, snap)
+	require.NoError(err)
+
+	// Corrupt the compressed archive.
+	data := archive.Bytes()
+	data[len(data)/2] ^= 0xff
+
+	// Restoring the corrupted snapshot fails and leaves the FSM unchanged.
+	after, afterFSM := testRaft(t)
+	defer after.Shutdown()
+	expected := testApply(t, after, 4)
+
+	require.Error(Restore(logger, bytes.NewReader(data), after))
+	require.Equal(expected, afterFSM.Logs())
+}
@@ -22,6 +22,7 @@ var (
 	cpuMhzPerCore float64
 	cpuModelName  string
 	cpuNumCores   int
+	cpuCoreIDs    []uint16
 	cpuTotalTicks float64
 
 	initErr error
@@ -49,6 +50,18 @@ func Init() error {
 			break
 		}
 
+		// Use the IDs of the logical CPUs if they are reported individually,
+		// otherwise assume they are numbered contiguously from zero
+		if len(cpuInfo) == cpuNumCores {
+			for _, cpu := range cpuInfo {
+				cpuCoreIDs = append(cpuCoreIDs, uint16(cpu.CPU))
+			}
+		} else {
+			for i := 0; i < cpuNumCores; i++ {
+				cpuCoreIDs = append(cpuCoreIDs, uint16(i))
+			}
+		}
+
 		// Floor all of the values such that small difference don't cause the
 		// node to fall into a unique computed node class
 		cpuMhzPerCore = math.Floor(cpuMhzPerCore)
@@ -65,6 +78,11 @@ func CPUNumCores() int {
 	return cpuNumCores
 }
 
+// CPUCoreIDs returns the IDs of the CPU cores available
+func CPUCoreIDs() []uint16 {
+	return cpuCoreIDs
+}
+
 // CPUMHzPerCore returns the MHz per CPU core
 func CPUMHzPerCore() float64 {
 	return cpuMhzPerCore
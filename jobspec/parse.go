@@ -306,6 +306,7 @@ func parseSpread(result *[]*api.Spread, list *ast.ObjectList) error {
 			"attribute",
 			"weight",
 			"target",
+			"spread",
 		}
 		if err := helper.CheckHCLKeys(o.Val, valid); err != nil {
 			return err
@@ -324,6 +325,7 @@ func parseSpread(result *[]*api.Spread, list *ast.ObjectList) error {
 			return err
 		}
 		delete(m, "target")
+		delete(m, "spread")
 		// Build spread
 		var s api.Spread
 		if err := mapstructure.WeakDecode(m, &s); err != nil {
@@ -337,12 +339,53 @@ func parseSpread(result *[]*api.Spread, list *ast.ObjectList) error {
 			}
 		}
 
+		// Parse the nested spread
+		if o := listVal.Filter("spread"); len(o.Items) > 0 {
+			if len(o.Items) > 1 {
+				return fmt.Errorf("only one nested 'spread' block allowed per spread")
+			}
+
+			var nested []*api.Spread
+			if err := parseSpread(&nested, o); err != nil {
+				return multierror.Prefix(err, "spread ->")
+			}
+			s.Nested = nested[0]
+		}
+
 		*result = append(*result, &s)
 	}
 
 	return nil
 }
 
+func parseDatacenterPreferences(result *[]*api.DatacenterPreference, list *ast.ObjectList) error {
+	for _, o := range list.Elem().Items {
+		// Check for invalid keys
+		valid := []string{
+			"datacenter",
+			"weight",
+		}
+		if err := helper.CheckHCLKeys(o.Val, valid); err != nil {
+			return err
+		}
+
+		var m map[string]interface{}
+		if err := hcl.DecodeObject(&m, o.Val); err != nil {
+			return err
+		}
+
+		// Build the datacenter preference
+		var p api.DatacenterPreference
+		if err := mapstructure.WeakDecode(m, &p); err != nil {
+			return err
+		}
+
+		*result = append(*result, &p)
+	}
+
+	return nil
+}
+
 func parseSpreadTarget(result *[]*api.SpreadTarget, list *ast.ObjectList) error {
 	seen := make(map[string]struct{})
 	for _, item := range list.Items {
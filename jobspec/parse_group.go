@@ -51,9 +51,12 @@ func parseGroups(result *api.Job, list *ast.ObjectList) error {
 			"vault",
 			"migrate",
 			"spread",
+			"datacenter_preference",
 			"network",
 			"service",
 			"volume",
+			"max_client_disconnect",
+			"scaling",
 		}
 		if err := helper.CheckHCLKeys(listVal, valid); err != nil {
 			return multierror.Prefix(err, fmt.Sprintf("'%s' ->", n))
@@ -73,14 +76,24 @@ func parseGroups(result *api.Job, list *ast.ObjectList) error {
 		delete(m, "vault")
 		delete(m, "migrate")
 		delete(m, "spread")
+		delete(m, "datacenter_preference")
 		delete(m, "network")
 		delete(m, "service")
 		delete(m, "volume")
+		delete(m, "scaling")
 
 		// Build the group with the basic decode
 		var g api.TaskGroup
 		g.Name = helper.StringToPtr(n)
-		if err := mapstructure.WeakDecode(m, &g); err != nil {
+		dec, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+			DecodeHook:       mapstructure.StringToTimeDurationHookFunc(),
+			WeaklyTypedInput: true,
+			Result:           &g,
+		})
+		if err != nil {
+			return err
+		}
+		if err := dec.Decode(m); err != nil {
 			return err
 		}
 
@@ -112,6 +125,13 @@ func parseGroups(result *api.Job, list *ast.ObjectList) error {
 			}
 		}
 
+		// Parse datacenter preferences
+		if o := listVal.Filter("datacenter_preference"); len(o.Items) > 0 {
+			if err := parseDatacenterPreferences(&g.DatacenterPreferences, o); err != nil {
+				return multierror.Prefix(err, fmt.Sprintf("'%s', datacenter_preference ->", n))
+			}
+		}
+
 		// Parse network
 		if o := listVal.Filter("network"); len(o.Items) > 0 {
 			networks, err := ParseNetwork(o)
@@ -163,6 +183,13 @@ func parseGroups(result *api.Job, list *ast.ObjectList) error {
 			}
 		}
 
+		// Parse scaling policy
+		if o := listVal.Filter("scaling"); len(o.Items) > 0 {
+			if err := parseScalingPolicy(&g.Scaling, o); err != nil {
+				return multierror.Prefix(err, fmt.Sprintf("'%s', scaling ->", n))
+			}
+		}
+
 		// Parse any volume declarations
 		if o := listVal.Filter("volume"); len(o.Items) > 0 {
 			if err := parseVolumes(&g.Volumes, o); err != nil {
@@ -284,6 +311,70 @@ func parseRestartPolicy(final **api.RestartPolicy, list *ast.ObjectList) error {
 	return nil
 }
 
+func parseScalingPolicy(out **api.ScalingPolicy, list *ast.ObjectList) error {
+	list = list.Elem()
+	if len(list.Items) > 1 {
+		return fmt.Errorf("only one 'scaling' block allowed")
+	}
+
+	// Get our resource object
+	o := list.Items[0]
+
+	// We need this later
+	var listVal *ast.ObjectList
+	if ot, ok := o.Val.(*ast.ObjectType); ok {
+		listVal = ot.List
+	} else {
+		return fmt.Errorf("should be an object")
+	}
+
+	valid := []string{
+		"min",
+		"max",
+		"policy",
+		"enabled",
+	}
+	if err := helper.CheckHCLKeys(o.Val, valid); err != nil {
+		return err
+	}
+
+	var m map[string]interface{}
+	if err := hcl.DecodeObject(&m, o.Val); err != nil {
+		return err
+	}
+	delete(m, "policy")
+
+	var result api.ScalingPolicy
+	dec, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		WeaklyTypedInput: true,
+		Result:           &result,
+	})
+	if err != nil {
+		return err
+	}
+	if err := dec.Decode(m); err != nil {
+		return err
+	}
+
+	// If we have policy, then parse that
+	if o := listVal.Filter("policy"); len(o.Items) > 0 {
+		if len(o.Elem().Items) > 1 {
+			return fmt.Errorf("only one 'policy' block allowed per 'scaling' block")
+		}
+		p := o.Elem().Items[0]
+		var m map[string]interface{}
+		if err := hcl.DecodeObject(&m, p.Val); err != nil {
+			return err
+		}
+		if err := mapstructure.WeakDecode(m, &result.Policy); err != nil {
+			return err
+		}
+	}
+
+	*out = &result
+	return nil
+}
+
 func parseVolumes(out *map[string]*api.VolumeRequest, list *ast.ObjectList) error {
 	volumes := make(map[string]*api.VolumeRequest, len(list.Items))
 
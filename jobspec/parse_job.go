@@ -38,6 +38,7 @@ func parseJob(result *api.Job, list *ast.ObjectList) error {
 	delete(m, "update")
 	delete(m, "vault")
 	delete(m, "spread")
+	delete(m, "datacenter_preference")
 
 	// Set the ID and name to the object key
 	result.ID = helper.StringToPtr(obj.Keys[0].Token.Value().(string))
@@ -62,6 +63,7 @@ func parseJob(result *api.Job, list *ast.ObjectList) error {
 		"constraint",
 		"affinity",
 		"spread",
+		"datacenter_preference",
 		"datacenters",
 		"group",
 		"id",
@@ -74,6 +76,7 @@ func parseJob(result *api.Job, list *ast.ObjectList) error {
 		"priority",
 		"region",
 		"reschedule",
+		"scheduler_algorithm",
 		"task",
 		"type",
 		"update",
@@ -119,6 +122,13 @@ func parseJob(result *api.Job, list *ast.ObjectList) error {
 		}
 	}
 
+	// Parse datacenter preferences
+	if o := listVal.Filter("datacenter_preference"); len(o.Items) > 0 {
+		if err := parseDatacenterPreferences(&result.DatacenterPreferences, o); err != nil {
+			return multierror.Prefix(err, "datacenter_preference ->")
+		}
+	}
+
 	// If we have a parameterized definition, then parse that
 	if o := listVal.Filter("parameterized"); len(o.Items) > 0 {
 		if err := parseParameterizedJob(&result.ParameterizedJob, o); err != nil {
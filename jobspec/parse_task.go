@@ -73,6 +73,7 @@ func parseTask(item *ast.ObjectItem) (*api.Task, error) {
 		"kill_signal",
 		"kind",
 		"volume_mount",
+		"lifecycle",
 	}
 	if err := helper.CheckHCLKeys(listVal, valid); err != nil {
 		return nil, err
@@ -95,6 +96,7 @@ func parseTask(item *ast.ObjectItem) (*api.Task, error) {
 	delete(m, "template")
 	delete(m, "vault")
 	delete(m, "volume_mount")
+	delete(m, "lifecycle")
 
 	// Build the task
 	var t api.Task
@@ -275,6 +277,34 @@ func parseTask(item *ast.ObjectItem) (*api.Task, error) {
 		}
 	}
 
+	// If we have a lifecycle block parse that
+	if o := listVal.Filter("lifecycle"); len(o.Items) > 0 {
+		if len(o.Items) > 1 {
+			return nil, fmt.Errorf("only one lifecycle block is allowed in a task. Number of lifecycle blocks found: %d", len(o.Items))
+		}
+
+		var m map[string]interface{}
+		lifecycleBlock := o.Items[0]
+
+		// Check for invalid keys
+		valid := []string{
+			"hook",
+			"sidecar",
+		}
+		if err := helper.CheckHCLKeys(lifecycleBlock.Val, valid); err != nil {
+			return nil, multierror.Prefix(err, "lifecycle ->")
+		}
+
+		if err := hcl.DecodeObject(&m, lifecycleBlock.Val); err != nil {
+			return nil, err
+		}
+
+		t.Lifecycle = &api.TaskLifecycle{}
+		if err := mapstructure.WeakDecode(m, t.Lifecycle); err != nil {
+			return nil, err
+		}
+	}
+
 	return &t, nil
 }
 
@@ -417,9 +447,11 @@ func parseResources(result *api.Resources, list *ast.ObjectList) error {
 	// Check for invalid keys
 	valid := []string{
 		"cpu",
+		"cores",
 		"iops", // COMPAT(0.10): Remove after one release to allow it to be removed from jobspecs
 		"disk",
 		"memory",
+		"memory_max",
 		"network",
 		"device",
 	}
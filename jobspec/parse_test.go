@@ -107,8 +107,9 @@ func TestParse(t *testing.T) {
 					},
 
 					{
-						Name:  helper.StringToPtr("binsl"),
-						Count: helper.IntToPtr(5),
+						Name:                helper.StringToPtr("binsl"),
+						Count:               helper.IntToPtr(5),
+						MaxClientDisconnect: helper.TimeToPtr(2 * time.Hour),
 						Constraints: []*api.Constraint{
 							{
 								LTarget: "kernel.os",
@@ -242,8 +243,9 @@ func TestParse(t *testing.T) {
 									"LOREM": "ipsum",
 								},
 								Resources: &api.Resources{
-									CPU:      helper.IntToPtr(500),
-									MemoryMB: helper.IntToPtr(128),
+									CPU:         helper.IntToPtr(500),
+									MemoryMB:    helper.IntToPtr(128),
+									MemoryMaxMB: helper.IntToPtr(256),
 									Networks: []*api.NetworkResource{
 										{
 											MBits:         helper.IntToPtr(100),
@@ -705,6 +707,47 @@ func TestParse(t *testing.T) {
 			},
 			false,
 		},
+		{
+			"task-lifecycle.hcl",
+			&api.Job{
+				ID:   helper.StringToPtr("foo"),
+				Name: helper.StringToPtr("foo"),
+				TaskGroups: []*api.TaskGroup{
+					{
+						Name: helper.StringToPtr("bar"),
+						Tasks: []*api.Task{
+							{
+								Name:   "init",
+								Driver: "docker",
+								Lifecycle: &api.TaskLifecycle{
+									Hook: "prestart",
+								},
+							},
+							{
+								Name:   "proxy",
+								Driver: "docker",
+								Lifecycle: &api.TaskLifecycle{
+									Hook:    "prestart",
+									Sidecar: true,
+								},
+							},
+							{
+								Name:   "main",
+								Driver: "docker",
+							},
+							{
+								Name:   "cleanup",
+								Driver: "docker",
+								Lifecycle: &api.TaskLifecycle{
+									Hook: "poststop",
+								},
+							},
+						},
+					},
+				},
+			},
+			false,
+		},
 		{
 			"service-check-driver-address.hcl",
 			&api.Job{
@@ -1038,6 +1081,171 @@ func TestParse(t *testing.T) {
 			},
 			false,
 		},
+		{
+			"spread-nested.hcl",
+			&api.Job{
+				ID:   helper.StringToPtr("foo"),
+				Name: helper.StringToPtr("foo"),
+				Spreads: []*api.Spread{
+					{
+						Attribute: "${node.datacenter}",
+						Weight:    helper.Int8ToPtr(100),
+						SpreadTarget: []*api.SpreadTarget{
+							{
+								Value:   "dc1",
+								Percent: 60,
+							},
+							{
+								Value:   "dc2",
+								Percent: 40,
+							},
+						},
+						Nested: &api.Spread{
+							Attribute: "${meta.rack}",
+							Nested: &api.Spread{
+								Attribute: "${node.unique.name}",
+							},
+						},
+					},
+				},
+			},
+			false,
+		},
+		{
+			"datacenter-preference.hcl",
+			&api.Job{
+				ID:          helper.StringToPtr("foo"),
+				Name:        helper.StringToPtr("foo"),
+				Datacenters: []string{"dc1", "dc2", "dc3"},
+				DatacenterPreferences: []*api.DatacenterPreference{
+					{
+						Datacenter: "dc1",
+						Weight:     helper.Int8ToPtr(100),
+					},
+					{
+						Datacenter: "dc2",
+					},
+				},
+				TaskGroups: []*api.TaskGroup{
+					{
+						Name: helper.StringToPtr("bar"),
+						DatacenterPreferences: []*api.DatacenterPreference{
+							{
+								Datacenter: "dc3",
+								Weight:     helper.Int8ToPtr(80),
+							},
+						},
+						Tasks: []*api.Task{
+							{
+								Name:   "bar",
+								Driver: "docker",
+							},
+						},
+					},
+				},
+			},
+			false,
+		},
+		{
+			"scheduler-algorithm.hcl",
+			&api.Job{
+				ID:                 helper.StringToPtr("foo"),
+				Name:               helper.StringToPtr("foo"),
+				SchedulerAlgorithm: api.SchedulerAlgorithmSpread,
+				TaskGroups: []*api.TaskGroup{
+					{
+						Name: helper.StringToPtr("bar"),
+						Tasks: []*api.Task{
+							{
+								Name:   "bar",
+								Driver: "docker",
+							},
+						},
+					},
+				},
+			},
+			false,
+		},
+		{
+			"resources-cores.hcl",
+			&api.Job{
+				ID:   helper.StringToPtr("foo"),
+				Name: helper.StringToPtr("foo"),
+				TaskGroups: []*api.TaskGroup{
+					{
+						Name: helper.StringToPtr("bar"),
+						Tasks: []*api.Task{
+							{
+								Name:   "bar",
+								Driver: "docker",
+								Resources: &api.Resources{
+									Cores:    helper.IntToPtr(4),
+									MemoryMB: helper.IntToPtr(128),
+								},
+							},
+						},
+					},
+				},
+			},
+			false,
+		},
+		{
+			"affinity-job.hcl",
+			&api.Job{
+				ID:   helper.StringToPtr("foo"),
+				Name: helper.StringToPtr("foo"),
+				TaskGroups: []*api.TaskGroup{
+					{
+						Name: helper.StringToPtr("web"),
+						Affinities: []*api.Affinity{
+							{
+								LTarget: "${job.id}",
+								RTarget: "cache",
+								Operand: "=",
+								Weight:  helper.Int8ToPtr(100),
+							},
+							{
+								LTarget: "${job.meta.tier}",
+								RTarget: "batch",
+								Operand: "!=",
+								Weight:  helper.Int8ToPtr(-50),
+							},
+						},
+						Tasks: []*api.Task{
+							{
+								Name:   "web",
+								Driver: "docker",
+							},
+						},
+					},
+				},
+			},
+			false,
+		},
+		{
+			"tg-scaling-policy.hcl",
+			&api.Job{
+				ID:   helper.StringToPtr("elastic"),
+				Name: helper.StringToPtr("elastic"),
+				TaskGroups: []*api.TaskGroup{
+					{
+						Name: helper.StringToPtr("group"),
+						Scaling: &api.ScalingPolicy{
+							Min: helper.Int64ToPtr(5),
+							Max: 100,
+							Policy: map[string]interface{}{
+								"foo": "bar",
+								"b":   true,
+								"val": 5,
+								"f":   .1,
+							},
+							Enabled: helper.BoolToPtr(false),
+						},
+					},
+				},
+			},
+			false,
+		},
 	}
 
 	for _, tc := range cases {
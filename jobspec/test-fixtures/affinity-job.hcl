@@ -0,0 +1,20 @@
+job "foo" {
+  group "web" {
+    affinity {
+      attribute = "${job.id}"
+      value     = "cache"
+      weight    = 100
+    }
+
+    affinity {
+      attribute = "${job.meta.tier}"
+      operator  = "!="
+      value     = "batch"
+      weight    = -50
+    }
+
+    task "web" {
+      driver = "docker"
+    }
+  }
+}
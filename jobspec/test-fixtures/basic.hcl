@@ -67,7 +67,8 @@ job "binstore-storagelocker" {
   }
 
   group "binsl" {
-    count = 5
+    count                 = 5
+    max_client_disconnect = "2h"
 
     volume "foo" {
       type = "host"
@@ -191,8 +192,9 @@ job "binstore-storagelocker" {
       }
 
       resources {
-        cpu    = 500
-        memory = 128
+        cpu        = 500
+        memory     = 128
+        memory_max = 256
 
         network {
           mbits = "100"
@@ -0,0 +1,23 @@
+job "foo" {
+  datacenters = ["dc1", "dc2", "dc3"]
+
+  datacenter_preference {
+    datacenter = "dc1"
+    weight     = 100
+  }
+
+  datacenter_preference {
+    datacenter = "dc2"
+  }
+
+  group "bar" {
+    datacenter_preference {
+      datacenter = "dc3"
+      weight     = 80
+    }
+
+    task "bar" {
+      driver = "docker"
+    }
+  }
+}
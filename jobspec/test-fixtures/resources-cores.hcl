@@ -0,0 +1,10 @@
+job "foo" {
+  task "bar" {
+    driver = "docker"
+
+    resources {
+      cores  = 4
+      memory = 128
+    }
+  }
+}
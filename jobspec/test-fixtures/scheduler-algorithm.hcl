@@ -0,0 +1,9 @@
+job "foo" {
+  scheduler_algorithm = "spread"
+
+  group "bar" {
+    task "bar" {
+      driver = "docker"
+    }
+  }
+}
@@ -0,0 +1,22 @@
+job "foo" {
+  spread {
+    attribute = "${node.datacenter}"
+    weight    = 100
+
+    target "dc1" {
+      percent = 60
+    }
+
+    target "dc2" {
+      percent = 40
+    }
+
+    spread {
+      attribute = "${meta.rack}"
+
+      spread {
+        attribute = "${node.unique.name}"
+      }
+    }
+  }
+}
@@ -0,0 +1,32 @@
+job "foo" {
+  group "bar" {
+    task "init" {
+      driver = "docker"
+
+      lifecycle {
+        hook = "prestart"
+      }
+    }
+
+    task "proxy" {
+      driver = "docker"
+
+      lifecycle {
+        hook    = "prestart"
+        sidecar = true
+      }
+    }
+
+    task "main" {
+      driver = "docker"
+    }
+
+    task "cleanup" {
+      driver = "docker"
+
+      lifecycle {
+        hook = "poststop"
+      }
+    }
+  }
+}
@@ -0,0 +1,110 @@
+package auth
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ClaimData is the data binding rule selectors are evaluated against. It is
+// built from the claims of an identity by the claim mappings of an auth
+// method.
+type ClaimData struct {
+	// Value holds the claims mapped by the claim mappings
+	Value map[string]string
+
+	// List holds the claims mapped by the list claim mappings
+	List map[string][]string
+}
+
+// MapClaims maps the claims of an identity to claim data. Mappings are keyed
+// by the name of the claim and valued by the name the claim is available
+// under in the claim data. Claim names starting with "/" are JSON pointers
+// into nested claims. Claims missing from the identity are ignored.
+func MapClaims(claims map[string]interface{}, mappings, listMappings map[string]string) (*ClaimData, error) {
+	data := &ClaimData{
+		Value: make(map[string]string, len(mappings)),
+		List:  make(map[string][]string, len(listMappings)),
+	}
+
+	for claim, name := range mappings {
+		raw, ok := lookupClaim(claims, claim)
+		if !ok {
+			continue
+		}
+		value, ok := stringifyClaim(raw)
+		if !ok {
+			return nil, fmt.Errorf("claim %q is not a string, number or bool", claim)
+		}
+		data.Value[name] = value
+	}
+
+	for claim, name := range listMappings {
+		raw, ok := lookupClaim(claims, claim)
+		if !ok {
+			continue
+		}
+
+		var list []string
+		switch v := raw.(type) {
+		case []interface{}:
+			list = make([]string, 0, len(v))
+			for _, elem := range v {
+				value, ok := stringifyClaim(elem)
+				if !ok {
+					return nil, fmt.Errorf("claim %q has an element that is not a string, number or bool", claim)
+				}
+				list = append(list, value)
+			}
+		default:
+			value, ok := stringifyClaim(v)
+			if !ok {
+				return nil, fmt.Errorf("claim %q is not a list", claim)
+			}
+			list = []string{value}
+		}
+		data.List[name] = list
+	}
+
+	return data, nil
+}
+
+// lookupClaim returns the value of a top level claim, or of a nested claim if
+// the name is a JSON pointer
+func lookupClaim(claims map[string]interface{}, name string) (interface{}, bool) {
+	if !strings.HasPrefix(name, "/") {
+		v, ok := claims[name]
+		return v, ok
+	}
+
+	var current interface{} = claims
+	for _, segment := range strings.Split(name[1:], "/") {
+		segment = strings.Replace(segment, "~1", "/", -1)
+		segment = strings.Replace(segment, "~0", "~", -1)
+
+		m, ok := current.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if current, ok = m[segment]; !ok {
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// stringifyClaim returns the string form of a scalar claim
+func stringifyClaim(v interface{}) (string, bool) {
+	switch c := v.(type) {
+	case string:
+		return c, true
+	case json.Number:
+		return c.String(), true
+	case float64:
+		return strconv.FormatFloat(c, 'f', -1, 64), true
+	case bool:
+		return strconv.FormatBool(c), true
+	}
+	return "", false
+}
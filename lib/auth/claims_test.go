@@ -0,0 +1,68 @@
+package auth
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMapClaims(t *testing.T) {
+	t.Parallel()
+
+	claims := map[string]interface{}{
+		"sub":      "jane",
+		"admin":    true,
+		"uid":      json.Number("1000"),
+		"groups":   []interface{}{"engineering", "on-call"},
+		"team":     "platform",
+		"unmapped": "ignored",
+		"profile": map[string]interface{}{
+			"org/unit": "infra",
+		},
+	}
+
+	data, err := MapClaims(claims,
+		map[string]string{
+			"sub":                "user",
+			"admin":              "admin",
+			"uid":                "uid",
+			"/profile/org~1unit": "unit",
+			"missing":            "missing",
+		},
+		map[string]string{
+			"groups": "groups",
+			"team":   "teams",
+		},
+	)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		"user":  "jane",
+		"admin": "true",
+		"uid":   "1000",
+		"unit":  "infra",
+	}, data.Value)
+	require.Equal(t, map[string][]string{
+		"groups": {"engineering", "on-call"},
+		"teams":  {"platform"},
+	}, data.List)
+}
+
+func TestMapClaims_Invalid(t *testing.T) {
+	t.Parallel()
+
+	claims := map[string]interface{}{
+		"groups":  []interface{}{"engineering"},
+		"nested":  []interface{}{[]interface{}{"engineering"}},
+		"profile": map[string]interface{}{"unit": "infra"},
+	}
+
+	_, err := MapClaims(claims, map[string]string{"groups": "groups"}, nil)
+	require.Error(t, err)
+
+	_, err = MapClaims(claims, map[string]string{"profile": "profile"}, nil)
+	require.Error(t, err)
+
+	_, err = MapClaims(claims, nil, map[string]string{"nested": "nested"})
+	require.Error(t, err)
+}
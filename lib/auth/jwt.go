@@ -0,0 +1,423 @@
+// Package auth validates identities issued by external identity providers,
+// so that they can be exchanged for Nomad ACL tokens. It verifies JWTs against
+// the JSON Web Key Set of their issuer, implements the parts of the OpenID
+// Connect authorization code flow the servers take part in, and maps the
+// claims of an identity to the data binding rule selectors are evaluated
+// against.
+package auth
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	// maxResponseSize is the maximum size in bytes of the documents read from
+	// an identity provider
+	maxResponseSize = 1024 * 1024
+)
+
+var (
+	// ErrInvalidJWT is returned when a JWT is malformed
+	ErrInvalidJWT = errors.New("invalid JWT")
+
+	// DefaultSigningAlgs are the signing algorithms accepted when none are
+	// configured
+	DefaultSigningAlgs = []string{"RS256"}
+
+	// signingAlgs maps the supported JWS signing algorithms to their hash
+	signingAlgs = map[string]crypto.Hash{
+		"RS256": crypto.SHA256,
+		"RS384": crypto.SHA384,
+		"RS512": crypto.SHA512,
+		"ES256": crypto.SHA256,
+		"ES384": crypto.SHA384,
+		"ES512": crypto.SHA512,
+	}
+)
+
+// ValidSigningAlg returns whether the JWS signing algorithm is supported.
+func ValidSigningAlg(alg string) bool {
+	_, ok := signingAlgs[alg]
+	return ok
+}
+
+// KeySet is a set of public keys JWTs are verified against, usually the JSON
+// Web Key Set published by an identity provider.
+type KeySet struct {
+	keys []*publicKey
+}
+
+// publicKey is a public key of a key set
+type publicKey struct {
+	keyID string
+	key   crypto.PublicKey
+}
+
+// jsonWebKey is a key of a JSON Web Key Set, as defined by RFC 7517
+type jsonWebKey struct {
+	KeyType string `json:"kty"`
+	KeyID   string `json:"kid"`
+	Use     string `json:"use"`
+
+	// RSA public key parameters
+	N string `json:"n"`
+	E string `json:"e"`
+
+	// EC public key parameters
+	Curve string `json:"crv"`
+	X     string `json:"x"`
+	Y     string `json:"y"`
+}
+
+// FetchKeySet fetches and parses the JSON Web Key Set served at the URL.
+func FetchKeySet(ctx context.Context, client *http.Client, url string) (*KeySet, error) {
+	body, err := get(ctx, client, url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch JWKS: %v", err)
+	}
+	return ParseKeySet(body)
+}
+
+// ParseKeySet parses a JSON Web Key Set. Keys of unsupported types and
+// encryption keys are ignored.
+func ParseKeySet(data []byte) (*KeySet, error) {
+	var doc struct {
+		Keys []*jsonWebKey `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to decode JWKS: %v", err)
+	}
+
+	ks := &KeySet{}
+	for _, jwk := range doc.Keys {
+		if jwk.Use == "enc" {
+			continue
+		}
+		key, err := jwk.publicKey()
+		if err != nil {
+			return nil, fmt.Errorf("invalid key %q in JWKS: %v", jwk.KeyID, err)
+		}
+		if key == nil {
+			continue
+		}
+		ks.keys = append(ks.keys, &publicKey{keyID: jwk.KeyID, key: key})
+	}
+	if len(ks.keys) == 0 {
+		return nil, fmt.Errorf("JWKS has no usable signing keys")
+	}
+	return ks, nil
+}
+
+// publicKey returns the public key of the JWK, or nil if its type isn't
+// supported
+func (k *jsonWebKey) publicKey() (crypto.PublicKey, error) {
+	switch k.KeyType {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+		if !e.IsInt64() || e.Int64() > 1<<31-1 {
+			return nil, fmt.Errorf("invalid RSA exponent")
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Curve {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", k.Curve)
+		}
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		if !curve.IsOnCurve(x, y) {
+			return nil, fmt.Errorf("point not on curve %q", k.Curve)
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	}
+
+	return nil, nil
+}
+
+// Expected holds the values the claims of a JWT are validated against. Empty
+// fields are not validated.
+type Expected struct {
+	// Issuers are the accepted values of the iss claim
+	Issuers []string
+
+	// Audiences are the accepted values of the aud claim. The JWT must be
+	// issued for at least one of them.
+	Audiences []string
+
+	// SigningAlgs are the accepted signing algorithms. DefaultSigningAlgs
+	// is used if empty.
+	SigningAlgs []string
+
+	// Nonce is the expected value of the nonce claim
+	Nonce string
+
+	// ClockSkewLeeway is the leeway applied when validating the exp, nbf and
+	// iat claims
+	ClockSkewLeeway time.Duration
+}
+
+// Verify checks the signature of the JWT against the keys of the set and its
+// registered claims against the expected values, and returns its claims.
+func (ks *KeySet) Verify(token string, expected *Expected) (map[string]interface{}, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, ErrInvalidJWT
+	}
+
+	var header struct {
+		Algorithm string `json:"alg"`
+		KeyID     string `json:"kid"`
+	}
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, err
+	}
+	if !allowedAlg(header.Algorithm, expected.SigningAlgs) {
+		return nil, fmt.Errorf("JWT signing algorithm %q not allowed", header.Algorithm)
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, ErrInvalidJWT
+	}
+	signed := []byte(parts[0] + "." + parts[1])
+
+	verified := false
+	for _, key := range ks.keys {
+		if header.KeyID != "" && key.keyID != "" && header.KeyID != key.keyID {
+			continue
+		}
+		if verifySignature(key.key, header.Algorithm, signed, sig) {
+			verified = true
+			break
+		}
+	}
+	if !verified {
+		return nil, fmt.Errorf("failed to verify JWT signature")
+	}
+
+	var claims map[string]interface{}
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, err
+	}
+	if err := validateClaims(claims, expected, time.Now()); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+// allowedAlg returns whether the signing algorithm is supported and allowed
+func allowedAlg(alg string, allowed []string) bool {
+	if !ValidSigningAlg(alg) {
+		return false
+	}
+	if len(allowed) == 0 {
+		allowed = DefaultSigningAlgs
+	}
+	for _, a := range allowed {
+		if a == alg {
+			return true
+		}
+	}
+	return false
+}
+
+// verifySignature returns whether sig is a valid signature of signed made with
+// the private key of key using the signing algorithm
+func verifySignature(key crypto.PublicKey, alg string, signed, sig []byte) bool {
+	hash := signingAlgs[alg]
+	h := hash.New()
+	h.Write(signed)
+	digest := h.Sum(nil)
+
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+		if !strings.HasPrefix(alg, "RS") {
+			return false
+		}
+		return rsa.VerifyPKCS1v15(k, hash, digest, sig) == nil
+
+	case *ecdsa.PublicKey:
+		if !strings.HasPrefix(alg, "ES") {
+			return false
+		}
+		size := (k.Curve.Params().BitSize + 7) / 8
+		if len(sig) != 2*size {
+			return false
+		}
+		r := new(big.Int).SetBytes(sig[:size])
+		s := new(big.Int).SetBytes(sig[size:])
+		return ecdsa.Verify(k, digest, r, s)
+	}
+
+	return false
+}
+
+// validateClaims validates the registered claims of a JWT
+func validateClaims(claims map[string]interface{}, expected *Expected, now time.Time) error {
+	leeway := expected.ClockSkewLeeway
+
+	if exp, ok, err := timeClaim(claims, "exp"); err != nil {
+		return err
+	} else if ok && now.Add(-leeway).After(exp) {
+		return fmt.Errorf("JWT is expired")
+	}
+	if nbf, ok, err := timeClaim(claims, "nbf"); err != nil {
+		return err
+	} else if ok && now.Add(leeway).Before(nbf) {
+		return fmt.Errorf("JWT is not valid yet")
+	}
+	if iat, ok, err := timeClaim(claims, "iat"); err != nil {
+		return err
+	} else if ok && now.Add(leeway).Before(iat) {
+		return fmt.Errorf("JWT is issued in the future")
+	}
+
+	if len(expected.Issuers) != 0 {
+		iss, _ := claims["iss"].(string)
+		if !contains(expected.Issuers, iss) {
+			return fmt.Errorf("JWT issuer %q not allowed", iss)
+		}
+	}
+
+	if len(expected.Audiences) != 0 {
+		var auds []string
+		switch aud := claims["aud"].(type) {
+		case string:
+			auds = []string{aud}
+		case []interface{}:
+			for _, a := range aud {
+				if s, ok := a.(string); ok {
+					auds = append(auds, s)
+				}
+			}
+		}
+		found := false
+		for _, aud := range auds {
+			if contains(expected.Audiences, aud) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("JWT audience not allowed")
+		}
+	}
+
+	if expected.Nonce != "" {
+		if nonce, _ := claims["nonce"].(string); nonce != expected.Nonce {
+			return fmt.Errorf("JWT nonce doesn't match")
+		}
+	}
+
+	return nil
+}
+
+// timeClaim returns the value of a NumericDate claim
+func timeClaim(claims map[string]interface{}, name string) (time.Time, bool, error) {
+	raw, ok := claims[name]
+	if !ok {
+		return time.Time{}, false, nil
+	}
+	n, ok := raw.(json.Number)
+	if !ok {
+		return time.Time{}, false, fmt.Errorf("JWT claim %q is not a number", name)
+	}
+	f, err := n.Float64()
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("JWT claim %q is not a number", name)
+	}
+	return time.Unix(int64(f), 0), true, nil
+}
+
+// decodeSegment decodes a base64 encoded JSON segment of a JWT. Numbers are
+// decoded as json.Number so that integer claims keep their precision.
+func decodeSegment(segment string, out interface{}) error {
+	raw, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return ErrInvalidJWT
+	}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	if err := dec.Decode(out); err != nil {
+		return ErrInvalidJWT
+	}
+	return nil
+}
+
+// decodeBigInt decodes a base64url encoded unsigned big-endian integer
+func decodeBigInt(s string) (*big.Int, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil || len(raw) == 0 {
+		return nil, fmt.Errorf("invalid key parameter")
+	}
+	return new(big.Int).SetBytes(raw), nil
+}
+
+// get fetches the document served at the URL
+func get(ctx context.Context, client *http.Client, url string) ([]byte, error) {
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected response code %d from %s", resp.StatusCode, url)
+	}
+	return body, nil
+}
+
+func contains(list []string, s string) bool {
+	for _, l := range list {
+		if l == s {
+			return true
+		}
+	}
+	return false
+}
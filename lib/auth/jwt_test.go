@@ -0,0 +1,158 @@
+package auth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeySet_Verify(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	issuer := NewTestIssuer(t)
+	defer issuer.Close()
+
+	ks, err := FetchKeySet(context.Background(), http.DefaultClient, issuer.JWKSURL())
+	require.NoError(err)
+
+	expected := &Expected{
+		Issuers:   []string{issuer.URL()},
+		Audiences: []string{"nomad", "other"},
+	}
+
+	// A valid token
+	claims, err := ks.Verify(issuer.SignJWT(map[string]interface{}{
+		"sub": "jane",
+		"aud": []interface{}{"nomad"},
+	}), expected)
+	require.NoError(err)
+	require.Equal("jane", claims["sub"])
+
+	// A single audience
+	_, err = ks.Verify(issuer.SignJWT(map[string]interface{}{"aud": "other"}), expected)
+	require.NoError(err)
+
+	// No expected values
+	_, err = ks.Verify(issuer.SignJWT(nil), &Expected{})
+	require.NoError(err)
+
+	now := time.Now()
+	cases := []struct {
+		name   string
+		claims map[string]interface{}
+		err    string
+	}{
+		{"issuer", map[string]interface{}{"aud": "nomad", "iss": "https://evil.example.com"}, "issuer"},
+		{"audience", map[string]interface{}{"aud": "vault"}, "audience"},
+		{"missing audience", map[string]interface{}{}, "audience"},
+		{"expired", map[string]interface{}{"aud": "nomad", "exp": now.Add(-time.Minute).Unix()}, "expired"},
+		{"not before", map[string]interface{}{"aud": "nomad", "nbf": now.Add(time.Minute).Unix()}, "not valid yet"},
+		{"issued at", map[string]interface{}{"aud": "nomad", "iat": now.Add(time.Minute).Unix()}, "future"},
+		{"exp type", map[string]interface{}{"aud": "nomad", "exp": "tomorrow"}, "not a number"},
+	}
+	for _, c := range cases {
+		_, err := ks.Verify(issuer.SignJWT(c.claims), expected)
+		require.Error(err, c.name)
+		require.Contains(err.Error(), c.err, c.name)
+	}
+
+	// Expired tokens are accepted within the leeway
+	token := issuer.SignJWT(map[string]interface{}{"exp": now.Add(-time.Minute).Unix()})
+	_, err = ks.Verify(token, &Expected{ClockSkewLeeway: 5 * time.Minute})
+	require.NoError(err)
+
+	// The nonce must match
+	token = issuer.SignJWT(map[string]interface{}{"nonce": "abc"})
+	_, err = ks.Verify(token, &Expected{Nonce: "abc"})
+	require.NoError(err)
+	_, err = ks.Verify(token, &Expected{Nonce: "def"})
+	require.Error(err)
+
+	// The signing algorithm must be allowed
+	_, err = ks.Verify(token, &Expected{SigningAlgs: []string{"ES256"}})
+	require.Error(err)
+	require.Contains(err.Error(), "not allowed")
+
+	// Tokens signed by another issuer are rejected
+	other := NewTestIssuer(t)
+	defer other.Close()
+	_, err = ks.Verify(other.SignJWT(nil), &Expected{})
+	require.Error(err)
+	require.Contains(err.Error(), "signature")
+
+	// Tampered tokens are rejected
+	payload := base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"admin"}`))
+	parts := strings.Split(token, ".")
+	_, err = ks.Verify(parts[0]+"."+payload+"."+parts[2], &Expected{})
+	require.Error(err)
+
+	// Malformed tokens are rejected
+	_, err = ks.Verify("not-a-jwt", &Expected{})
+	require.Equal(ErrInvalidJWT, err)
+}
+
+func TestKeySet_Verify_ES256(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(err)
+
+	jwks := fmt.Sprintf(`{"keys":[{"kty":"RSA","use":"enc","kid":"enc","n":"AQAB","e":"AQAB"},`+
+		`{"kty":"EC","kid":"ec","crv":"P-256","x":%q,"y":%q}]}`,
+		base64.RawURLEncoding.EncodeToString(key.X.Bytes()),
+		base64.RawURLEncoding.EncodeToString(key.Y.Bytes()))
+	ks, err := ParseKeySet([]byte(jwks))
+	require.NoError(err)
+	require.Len(ks.keys, 1)
+
+	header, _ := json.Marshal(map[string]string{"alg": "ES256", "kid": "ec"})
+	payload, _ := json.Marshal(map[string]interface{}{"sub": "jane"})
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." +
+		base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(signed))
+	r, s, err := ecdsa.Sign(rand.Reader, key, digest[:])
+	require.NoError(err)
+	sig := make([]byte, 64)
+	rb, sb := r.Bytes(), s.Bytes()
+	copy(sig[32-len(rb):32], rb)
+	copy(sig[64-len(sb):], sb)
+	token := signed + "." + base64.RawURLEncoding.EncodeToString(sig)
+
+	claims, err := ks.Verify(token, &Expected{SigningAlgs: []string{"ES256"}})
+	require.NoError(err)
+	require.Equal("jane", claims["sub"])
+
+	// RS256 is the only algorithm allowed by default
+	_, err = ks.Verify(token, &Expected{})
+	require.Error(err)
+}
+
+func TestParseKeySet_Invalid(t *testing.T) {
+	t.Parallel()
+
+	cases := []string{
+		`not json`,
+		`{"keys":[]}`,
+		`{"keys":[{"kty":"oct","k":"c2VjcmV0"}]}`,
+		`{"keys":[{"kty":"RSA","n":"","e":"AQAB"}]}`,
+		`{"keys":[{"kty":"EC","crv":"P-256","x":"AQAB","y":"AQAB"}]}`,
+		`{"keys":[{"kty":"EC","crv":"secp256k1","x":"AQAB","y":"AQAB"}]}`,
+	}
+	for _, c := range cases {
+		_, err := ParseKeySet([]byte(c))
+		require.Error(t, err, c)
+	}
+}
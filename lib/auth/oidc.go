@@ -0,0 +1,136 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	// oidcDiscoveryPath is the path of the discovery document of an OpenID
+	// provider, relative to its issuer URL
+	oidcDiscoveryPath = "/.well-known/openid-configuration"
+
+	// oidcScope is the scope requesting an ID token
+	oidcScope = "openid"
+)
+
+// ProviderConfig is the configuration of an OpenID provider, as served in
+// its discovery document.
+type ProviderConfig struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// Discover fetches the configuration of the OpenID provider with the issuer
+// URL.
+func Discover(ctx context.Context, client *http.Client, issuer string) (*ProviderConfig, error) {
+	issuer = strings.TrimSuffix(issuer, "/")
+	body, err := get(ctx, client, issuer+oidcDiscoveryPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch OIDC discovery document: %v", err)
+	}
+
+	var config ProviderConfig
+	if err := json.Unmarshal(body, &config); err != nil {
+		return nil, fmt.Errorf("failed to decode OIDC discovery document: %v", err)
+	}
+	if strings.TrimSuffix(config.Issuer, "/") != issuer {
+		return nil, fmt.Errorf("OIDC discovery document issuer %q doesn't match %q", config.Issuer, issuer)
+	}
+	if config.JWKSURI == "" {
+		return nil, fmt.Errorf("OIDC discovery document is missing jwks_uri")
+	}
+	return &config, nil
+}
+
+// AuthURL returns the URL of the authorization endpoint of the provider to
+// send the user to in order to authenticate with the authorization code
+// flow. The provider redirects the user to the redirect URI with the
+// authorization code and the state once authenticated.
+func (p *ProviderConfig) AuthURL(clientID, redirectURI, state, nonce string, scopes []string) (string, error) {
+	if p.AuthorizationEndpoint == "" {
+		return "", fmt.Errorf("OIDC provider has no authorization endpoint")
+	}
+
+	scope := []string{oidcScope}
+	for _, s := range scopes {
+		if s != oidcScope {
+			scope = append(scope, s)
+		}
+	}
+
+	params := url.Values{}
+	params.Set("response_type", "code")
+	params.Set("client_id", clientID)
+	params.Set("redirect_uri", redirectURI)
+	params.Set("scope", strings.Join(scope, " "))
+	params.Set("state", state)
+	params.Set("nonce", nonce)
+
+	sep := "?"
+	if strings.Contains(p.AuthorizationEndpoint, "?") {
+		sep = "&"
+	}
+	return p.AuthorizationEndpoint + sep + params.Encode(), nil
+}
+
+// Exchange exchanges an authorization code for the ID token of the user who
+// authenticated with the provider. The ID token is returned unverified.
+func (p *ProviderConfig) Exchange(ctx context.Context, client *http.Client,
+	clientID, clientSecret, redirectURI, code string) (string, error) {
+
+	if p.TokenEndpoint == "" {
+		return "", fmt.Errorf("OIDC provider has no token endpoint")
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", redirectURI)
+
+	req, err := http.NewRequest("POST", p.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(url.QueryEscape(clientID), url.QueryEscape(clientSecret))
+
+	resp, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return "", fmt.Errorf("failed to exchange authorization code: %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return "", fmt.Errorf("failed to exchange authorization code: %v", err)
+	}
+
+	var tokens struct {
+		IDToken          string `json:"id_token"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	if err := json.Unmarshal(body, &tokens); err != nil && resp.StatusCode == http.StatusOK {
+		return "", fmt.Errorf("failed to decode token response: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		if tokens.Error != "" {
+			return "", fmt.Errorf("failed to exchange authorization code: %s %s", tokens.Error, tokens.ErrorDescription)
+		}
+		return "", fmt.Errorf("failed to exchange authorization code: unexpected response code %d", resp.StatusCode)
+	}
+	if tokens.IDToken == "" {
+		return "", fmt.Errorf("token response is missing id_token")
+	}
+	return tokens.IDToken, nil
+}
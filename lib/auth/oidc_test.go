@@ -0,0 +1,88 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiscover(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	issuer := NewTestIssuer(t)
+	defer issuer.Close()
+
+	provider, err := Discover(context.Background(), http.DefaultClient, issuer.URL()+"/")
+	require.NoError(err)
+	require.Equal(issuer.URL(), provider.Issuer)
+	require.Equal(issuer.JWKSURL(), provider.JWKSURI)
+
+	// The issuer of the discovery document must match
+	_, err = Discover(context.Background(), http.DefaultClient, issuer.URL()+"/tenant")
+	require.Error(err)
+}
+
+func TestProviderConfig_AuthURL(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	provider := &ProviderConfig{AuthorizationEndpoint: "https://idp.example.com/authorize?tenant=1"}
+	raw, err := provider.AuthURL("nomad", "http://localhost:4649/oidc/callback", "state", "nonce",
+		[]string{"openid", "groups"})
+	require.NoError(err)
+
+	u, err := url.Parse(raw)
+	require.NoError(err)
+	require.Equal("idp.example.com", u.Host)
+	q := u.Query()
+	require.Equal("1", q.Get("tenant"))
+	require.Equal("code", q.Get("response_type"))
+	require.Equal("nomad", q.Get("client_id"))
+	require.Equal("http://localhost:4649/oidc/callback", q.Get("redirect_uri"))
+	require.Equal("openid groups", q.Get("scope"))
+	require.Equal("state", q.Get("state"))
+	require.Equal("nonce", q.Get("nonce"))
+}
+
+func TestProviderConfig_Exchange(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	issuer := NewTestIssuer(t)
+	defer issuer.Close()
+
+	ctx := context.Background()
+	provider, err := Discover(ctx, http.DefaultClient, issuer.URL())
+	require.NoError(err)
+	ks, err := FetchKeySet(ctx, http.DefaultClient, provider.JWKSURI)
+	require.NoError(err)
+
+	code := issuer.IssueCode(map[string]interface{}{"sub": "jane", "nonce": "n"})
+
+	// Invalid client credentials are rejected
+	_, err = provider.Exchange(ctx, http.DefaultClient, TestIssuerClientID, "wrong", "http://localhost", code)
+	require.Error(err)
+	require.Contains(err.Error(), "invalid_client")
+
+	idToken, err := provider.Exchange(ctx, http.DefaultClient,
+		TestIssuerClientID, TestIssuerClientSecret, "http://localhost", code)
+	require.NoError(err)
+
+	claims, err := ks.Verify(idToken, &Expected{
+		Issuers:   []string{provider.Issuer},
+		Audiences: []string{TestIssuerClientID},
+		Nonce:     "n",
+	})
+	require.NoError(err)
+	require.Equal("jane", claims["sub"])
+
+	// Codes can only be exchanged once
+	_, err = provider.Exchange(ctx, http.DefaultClient,
+		TestIssuerClientID, TestIssuerClientSecret, "http://localhost", code)
+	require.Error(err)
+	require.Contains(err.Error(), "invalid_grant")
+}
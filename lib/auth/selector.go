@@ -0,0 +1,235 @@
+package auth
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	selectorOpEqual    = "=="
+	selectorOpNotEqual = "!="
+	selectorOpIn       = "in"
+	selectorOpNotIn    = "not in"
+)
+
+// Selector is a parsed binding rule selector. A selector is a conjunction of
+// clauses evaluated against claim data, joined by "and":
+//
+//	value.<name> == "<string>"
+//	value.<name> != "<string>"
+//	"<string>" in list.<name>
+//	"<string>" not in list.<name>
+//
+// The empty selector matches any claim data.
+type Selector struct {
+	clauses []*selectorClause
+}
+
+// selectorClause is a single comparison of a selector
+type selectorClause struct {
+	op    string
+	name  string
+	value string
+}
+
+// ParseSelector parses a binding rule selector.
+func ParseSelector(selector string) (*Selector, error) {
+	tokens, err := tokenizeSelector(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Selector{}
+	for len(tokens) != 0 {
+		if len(s.clauses) != 0 {
+			if tokens[0].text != "and" || tokens[0].quoted {
+				return nil, fmt.Errorf("expected \"and\", got %s", tokens[0])
+			}
+			tokens = tokens[1:]
+		}
+
+		clause, rest, err := parseSelectorClause(tokens)
+		if err != nil {
+			return nil, err
+		}
+		s.clauses = append(s.clauses, clause)
+		tokens = rest
+	}
+	return s, nil
+}
+
+// parseSelectorClause parses the clause at the start of tokens and returns the
+// remaining tokens
+func parseSelectorClause(tokens []*selectorToken) (*selectorClause, []*selectorToken, error) {
+	next := func() (*selectorToken, error) {
+		if len(tokens) == 0 {
+			return nil, fmt.Errorf("unexpected end of selector")
+		}
+		t := tokens[0]
+		tokens = tokens[1:]
+		return t, nil
+	}
+
+	first, err := next()
+	if err != nil {
+		return nil, nil, err
+	}
+	clause := &selectorClause{}
+
+	if first.quoted {
+		// "<string>" [not] in list.<name>
+		clause.value = first.text
+		op, err := next()
+		if err != nil {
+			return nil, nil, err
+		}
+		switch {
+		case op.is("in"):
+			clause.op = selectorOpIn
+		case op.is("not"):
+			in, err := next()
+			if err != nil {
+				return nil, nil, err
+			}
+			if !in.is("in") {
+				return nil, nil, fmt.Errorf("expected \"in\", got %s", in)
+			}
+			clause.op = selectorOpNotIn
+		default:
+			return nil, nil, fmt.Errorf("expected \"in\" or \"not in\", got %s", op)
+		}
+
+		field, err := next()
+		if err != nil {
+			return nil, nil, err
+		}
+		if field.quoted || !strings.HasPrefix(field.text, "list.") || field.text == "list." {
+			return nil, nil, fmt.Errorf("expected list.<name>, got %s", field)
+		}
+		clause.name = strings.TrimPrefix(field.text, "list.")
+		return clause, tokens, nil
+	}
+
+	// value.<name> ==|!= "<string>"
+	if !strings.HasPrefix(first.text, "value.") || first.text == "value." {
+		return nil, nil, fmt.Errorf("expected value.<name> or a quoted string, got %s", first)
+	}
+	clause.name = strings.TrimPrefix(first.text, "value.")
+
+	op, err := next()
+	if err != nil {
+		return nil, nil, err
+	}
+	switch {
+	case op.is(selectorOpEqual):
+		clause.op = selectorOpEqual
+	case op.is(selectorOpNotEqual):
+		clause.op = selectorOpNotEqual
+	default:
+		return nil, nil, fmt.Errorf("expected \"==\" or \"!=\", got %s", op)
+	}
+
+	value, err := next()
+	if err != nil {
+		return nil, nil, err
+	}
+	if !value.quoted {
+		return nil, nil, fmt.Errorf("expected a quoted string, got %s", value)
+	}
+	clause.value = value.text
+	return clause, tokens, nil
+}
+
+// Match returns whether the claim data matches the selector.
+func (s *Selector) Match(data *ClaimData) bool {
+	if data == nil {
+		data = &ClaimData{}
+	}
+
+	for _, c := range s.clauses {
+		switch c.op {
+		case selectorOpEqual, selectorOpNotEqual:
+			value, ok := data.Value[c.name]
+			if (c.op == selectorOpEqual) != (ok && value == c.value) {
+				return false
+			}
+		case selectorOpIn, selectorOpNotIn:
+			if (c.op == selectorOpIn) != contains(data.List[c.name], c.value) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// selectorToken is a token of a selector
+type selectorToken struct {
+	text   string
+	quoted bool
+}
+
+func (t *selectorToken) is(text string) bool {
+	return !t.quoted && t.text == text
+}
+
+func (t *selectorToken) String() string {
+	if t.quoted {
+		return strconv.Quote(t.text)
+	}
+	return fmt.Sprintf("%q", t.text)
+}
+
+// tokenizeSelector splits a selector into quoted strings, operators and words
+func tokenizeSelector(selector string) ([]*selectorToken, error) {
+	var tokens []*selectorToken
+	for i := 0; i < len(selector); {
+		c := selector[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+
+		case c == '"':
+			// Find the closing quote, skipping escaped characters
+			end := i + 1
+			for ; end < len(selector) && selector[end] != '"'; end++ {
+				if selector[end] == '\\' {
+					end++
+				}
+			}
+			if end >= len(selector) {
+				return nil, fmt.Errorf("unterminated string at offset %d", i)
+			}
+			text, err := strconv.Unquote(selector[i : end+1])
+			if err != nil {
+				return nil, fmt.Errorf("invalid string at offset %d: %v", i, err)
+			}
+			tokens = append(tokens, &selectorToken{text: text, quoted: true})
+			i = end + 1
+
+		case c == '=' || c == '!':
+			if i+1 >= len(selector) || selector[i+1] != '=' {
+				return nil, fmt.Errorf("invalid operator at offset %d", i)
+			}
+			tokens = append(tokens, &selectorToken{text: selector[i : i+2]})
+			i += 2
+
+		case isSelectorWordChar(c):
+			end := i
+			for end < len(selector) && isSelectorWordChar(selector[end]) {
+				end++
+			}
+			tokens = append(tokens, &selectorToken{text: selector[i:end]})
+			i = end
+
+		default:
+			return nil, fmt.Errorf("unexpected character %q at offset %d", c, i)
+		}
+	}
+	return tokens, nil
+}
+
+func isSelectorWordChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' ||
+		c == '_' || c == '-' || c == '.'
+}
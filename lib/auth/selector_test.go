@@ -0,0 +1,85 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelector_Match(t *testing.T) {
+	t.Parallel()
+
+	data := &ClaimData{
+		Value: map[string]string{
+			"team":  "platform",
+			"email": "jane@example.com",
+		},
+		List: map[string][]string{
+			"groups": {"engineering", "on-call"},
+		},
+	}
+
+	cases := []struct {
+		selector string
+		match    bool
+	}{
+		{``, true},
+		{`value.team == "platform"`, true},
+		{`value.team == "web"`, false},
+		{`value.team != "web"`, true},
+		{`value.missing == "web"`, false},
+		{`value.missing != "web"`, true},
+		{`"on-call" in list.groups`, true},
+		{`"admins" in list.groups`, false},
+		{`"admins" not in list.groups`, true},
+		{`"admins" in list.missing`, false},
+		{`value.team == "platform" and "engineering" in list.groups`, true},
+		{`value.team == "platform" and "admins" in list.groups`, false},
+		{`value.email == "jane@example.com"`, true},
+		{`value.team=="platform"`, true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.selector, func(t *testing.T) {
+			s, err := ParseSelector(c.selector)
+			require.NoError(t, err)
+			require.Equal(t, c.match, s.Match(data))
+		})
+	}
+}
+
+func TestSelector_Match_NilData(t *testing.T) {
+	t.Parallel()
+
+	s, err := ParseSelector(`value.team != "web" and "admins" not in list.groups`)
+	require.NoError(t, err)
+	require.True(t, s.Match(nil))
+}
+
+func TestParseSelector_Invalid(t *testing.T) {
+	t.Parallel()
+
+	cases := []string{
+		`value.team`,
+		`value.team ==`,
+		`value.team == platform`,
+		`value.team = "platform"`,
+		`team == "platform"`,
+		`value. == "platform"`,
+		`"admins" in groups`,
+		`"admins" not list.groups`,
+		`"admins" in`,
+		`"admins`,
+		`value.team == "platform" or value.team == "web"`,
+		`value.team == "platform" value.team == "web"`,
+		`value.team == "platform" and`,
+		`(value.team == "platform")`,
+	}
+
+	for _, c := range cases {
+		t.Run(c, func(t *testing.T) {
+			_, err := ParseSelector(c)
+			require.Error(t, err)
+		})
+	}
+}
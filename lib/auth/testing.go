@@ -0,0 +1,170 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	testing "github.com/mitchellh/go-testing-interface"
+)
+
+const (
+	// TestIssuerClientID and TestIssuerClientSecret are the OIDC client
+	// credentials accepted by the test issuer
+	TestIssuerClientID     = "nomad"
+	TestIssuerClientSecret = "nomad-secret"
+
+	testIssuerKeyID = "test-key"
+)
+
+// TestIssuer is a stand-in identity provider for tests. It signs JWTs with an
+// RSA key and serves an OpenID discovery document, its JWKS and a token
+// endpoint exchanging the authorization codes it issued for ID tokens.
+type TestIssuer struct {
+	t      testing.T
+	key    *rsa.PrivateKey
+	server *httptest.Server
+
+	l     sync.Mutex
+	codes map[string]map[string]interface{}
+}
+
+// NewTestIssuer starts a test issuer. Close must be called once done.
+func NewTestIssuer(t testing.T) *TestIssuer {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	i := &TestIssuer{
+		t:     t,
+		key:   key,
+		codes: make(map[string]map[string]interface{}),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(oidcDiscoveryPath, i.handleDiscovery)
+	mux.HandleFunc("/jwks", i.handleJWKS)
+	mux.HandleFunc("/authorize", func(w http.ResponseWriter, r *http.Request) {})
+	mux.HandleFunc("/token", i.handleToken)
+	i.server = httptest.NewServer(mux)
+	return i
+}
+
+// URL returns the issuer URL, which is also its OIDC discovery URL.
+func (i *TestIssuer) URL() string {
+	return i.server.URL
+}
+
+// JWKSURL returns the URL of the JWKS of the issuer.
+func (i *TestIssuer) JWKSURL() string {
+	return i.server.URL + "/jwks"
+}
+
+// Close stops the test issuer.
+func (i *TestIssuer) Close() {
+	i.server.Close()
+}
+
+// SignJWT returns a JWT with the claims signed by the issuer. The iss, iat
+// and exp claims are set if missing.
+func (i *TestIssuer) SignJWT(claims map[string]interface{}) string {
+	now := time.Now()
+	payload := map[string]interface{}{
+		"iss": i.URL(),
+		"iat": now.Unix(),
+		"exp": now.Add(time.Hour).Unix(),
+	}
+	for k, v := range claims {
+		payload[k] = v
+	}
+
+	header := map[string]string{"alg": "RS256", "typ": "JWT", "kid": testIssuerKeyID}
+	signed := encodeTestSegment(i.t, header) + "." + encodeTestSegment(i.t, payload)
+
+	digest := sha256.Sum256([]byte(signed))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, i.key, crypto.SHA256, digest[:])
+	if err != nil {
+		i.t.Fatalf("failed to sign JWT: %v", err)
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(sig)
+}
+
+// IssueCode returns an authorization code the token endpoint of the issuer
+// exchanges for an ID token with the claims, issued for the test client.
+func (i *TestIssuer) IssueCode(claims map[string]interface{}) string {
+	i.l.Lock()
+	defer i.l.Unlock()
+	code := fmt.Sprintf("code-%d", len(i.codes))
+	i.codes[code] = claims
+	return code
+}
+
+func (i *TestIssuer) handleDiscovery(w http.ResponseWriter, r *http.Request) {
+	json.NewEncoder(w).Encode(&ProviderConfig{
+		Issuer:                i.URL(),
+		AuthorizationEndpoint: i.URL() + "/authorize",
+		TokenEndpoint:         i.URL() + "/token",
+		JWKSURI:               i.JWKSURL(),
+	})
+}
+
+func (i *TestIssuer) handleJWKS(w http.ResponseWriter, r *http.Request) {
+	pub := i.key.PublicKey
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"keys": []*jsonWebKey{{
+			KeyType: "RSA",
+			KeyID:   testIssuerKeyID,
+			Use:     "sig",
+			N:       base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+			E:       base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+		}},
+	})
+}
+
+func (i *TestIssuer) handleToken(w http.ResponseWriter, r *http.Request) {
+	id, secret, _ := r.BasicAuth()
+	if id != TestIssuerClientID || secret != TestIssuerClientSecret {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(map[string]string{"error": "invalid_client"})
+		return
+	}
+
+	code := r.PostFormValue("code")
+	i.l.Lock()
+	claims, ok := i.codes[code]
+	delete(i.codes, code)
+	i.l.Unlock()
+	if !ok {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	idClaims := map[string]interface{}{"aud": TestIssuerClientID}
+	for k, v := range claims {
+		idClaims[k] = v
+	}
+	json.NewEncoder(w).Encode(map[string]string{
+		"access_token": code,
+		"token_type":   "Bearer",
+		"id_token":     i.SignJWT(idClaims),
+	})
+}
+
+func encodeTestSegment(t testing.T, v interface{}) string {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("failed to encode JWT segment: %v", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
@@ -43,6 +43,11 @@ func resolveTokenFromSnapshotCache(snap *state.StateSnapshot, cache *lru.TwoQueu
 	var token *structs.ACLToken
 	var err error
 
+	// Handle workload identities signed for the tasks of allocations
+	if structs.IsWorkloadIdentity(secretID) {
+		return resolveWorkloadIdentity(snap, secretID)
+	}
+
 	// Handle anonymous requests
 	if secretID == "" {
 		token = structs.AnonymousACLToken
@@ -54,6 +59,9 @@ func resolveTokenFromSnapshotCache(snap *state.StateSnapshot, cache *lru.TwoQueu
 		if token == nil {
 			return nil, structs.ErrTokenNotFound
 		}
+		if token.IsExpired(time.Now().UTC()) {
+			return nil, structs.ErrTokenExpired
+		}
 	}
 
 	// Check if this is a management token
@@ -61,9 +69,13 @@ func resolveTokenFromSnapshotCache(snap *state.StateSnapshot, cache *lru.TwoQueu
 		return acl.ManagementACL, nil
 	}
 
-	// Get all associated policies
-	policies := make([]*structs.ACLPolicy, 0, len(token.Policies))
-	for _, policyName := range token.Policies {
+	// Get all associated policies, including those of the roles
+	policyNames, err := tokenPolicyNames(snap, token)
+	if err != nil {
+		return nil, err
+	}
+	policies := make([]*structs.ACLPolicy, 0, len(policyNames))
+	for _, policyName := range policyNames {
 		policy, err := snap.ACLPolicyByName(nil, policyName)
 		if err != nil {
 			return nil, err
@@ -84,3 +96,70 @@ func resolveTokenFromSnapshotCache(snap *state.StateSnapshot, cache *lru.TwoQueu
 	}
 	return aclObj, nil
 }
+
+// tokenPolicyNames returns the names of the policies granted to a token,
+// either directly or through its roles. Roles that don't exist are ignored,
+// since they don't grant any more privilege.
+func tokenPolicyNames(snap *state.StateSnapshot, token *structs.ACLToken) ([]string, error) {
+	if len(token.Roles) == 0 {
+		return token.Policies, nil
+	}
+
+	seen := make(map[string]struct{}, len(token.Policies))
+	names := make([]string, 0, len(token.Policies))
+	add := func(name string) {
+		if _, ok := seen[name]; !ok {
+			seen[name] = struct{}{}
+			names = append(names, name)
+		}
+	}
+
+	for _, policyName := range token.Policies {
+		add(policyName)
+	}
+	for _, link := range token.Roles {
+		role, err := snap.ACLRoleByID(nil, link.ID)
+		if err != nil {
+			return nil, err
+		}
+		if role == nil {
+			continue
+		}
+		for _, policy := range role.Policies {
+			add(policy.Name)
+		}
+	}
+	return names, nil
+}
+
+// resolveWorkloadIdentity is used to resolve an ACL object from the workload
+// identity of a task. The identity is valid as long as it was signed by the
+// servers and its allocation isn't terminal, and only allows reading the job
+// of the allocation.
+func resolveWorkloadIdentity(snap *state.StateSnapshot, token string) (*acl.ACL, error) {
+	key, err := snap.WorkloadIdentityKey(nil)
+	if err != nil {
+		return nil, err
+	}
+	if key == nil {
+		return nil, structs.ErrTokenNotFound
+	}
+
+	claims, err := structs.VerifyWorkloadIdentity(key, token)
+	if err != nil {
+		return nil, structs.ErrTokenNotFound
+	}
+
+	alloc, err := snap.AllocByID(nil, claims.AllocationID)
+	if err != nil {
+		return nil, err
+	}
+	if alloc == nil || alloc.Namespace != claims.Namespace || alloc.JobID != claims.JobID {
+		return nil, structs.ErrTokenNotFound
+	}
+	if alloc.TerminalStatus() {
+		return nil, structs.ErrTokenExpired
+	}
+
+	return acl.NewWorkloadIdentityACL(claims.Namespace, claims.JobID)
+}
@@ -1,18 +1,23 @@
 package nomad
 
 import (
+	"context"
 	"fmt"
 	"io/ioutil"
+	"net/http"
 	"os"
 	"path/filepath"
 	"strings"
 	"time"
 
 	metrics "github.com/armon/go-metrics"
+	cleanhttp "github.com/hashicorp/go-cleanhttp"
 	log "github.com/hashicorp/go-hclog"
 	memdb "github.com/hashicorp/go-memdb"
 	policy "github.com/hashicorp/nomad/acl"
+	"github.com/hashicorp/nomad/helper"
 	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/lib/auth"
 	"github.com/hashicorp/nomad/nomad/state"
 	"github.com/hashicorp/nomad/nomad/structs"
 )
@@ -26,6 +31,10 @@ const (
 	// aclBootstrapReset is the file name to create in the data dir. It's only contents
 	// should be the reset index
 	aclBootstrapReset = "acl-bootstrap-reset"
+
+	// authMethodRequestTimeout is the timeout of the requests made to the
+	// identity providers of auth methods
+	authMethodRequestTimeout = 30 * time.Second
 )
 
 // ACL endpoint is used for manipulating ACL tokens and policies
@@ -145,10 +154,11 @@ func (a *ACL) ListPolicies(args *structs.ACLPolicyListRequest, reply *structs.AC
 			return structs.ErrTokenNotFound
 		}
 
-		policies = make(map[string]struct{}, len(token.Policies))
-		for _, p := range token.Policies {
-			policies[p] = struct{}{}
+		policyNames, err := a.policyNames(token)
+		if err != nil {
+			return err
 		}
+		policies = helper.SliceStringToSet(policyNames)
 	}
 
 	// Setup the blocking query
@@ -229,8 +239,13 @@ func (a *ACL) GetPolicy(args *structs.ACLPolicySpecificRequest, reply *structs.S
 			return structs.ErrTokenNotFound
 		}
 
+		policyNames, err := a.policyNames(token)
+		if err != nil {
+			return err
+		}
+
 		found := false
-		for _, p := range token.Policies {
+		for _, p := range policyNames {
 			if p == args.Name {
 				found = true
 				break
@@ -286,7 +301,24 @@ func (a *ACL) requestACLToken(secretID string) (*structs.ACLToken, error) {
 		return nil, err
 	}
 
-	return snap.ACLTokenBySecretID(nil, secretID)
+	token, err := snap.ACLTokenBySecretID(nil, secretID)
+	if err != nil {
+		return nil, err
+	}
+	if token.IsExpired(time.Now().UTC()) {
+		return nil, structs.ErrTokenExpired
+	}
+	return token, nil
+}
+
+// policyNames returns the names of the policies granted to a token, either
+// directly or through its roles.
+func (a *ACL) policyNames(token *structs.ACLToken) ([]string, error) {
+	snap, err := a.srv.fsm.State().Snapshot()
+	if err != nil {
+		return nil, err
+	}
+	return tokenPolicyNames(snap, token)
 }
 
 // GetPolicies is used to get a set of policies
@@ -310,8 +342,14 @@ func (a *ACL) GetPolicies(args *structs.ACLPolicySetRequest, reply *structs.ACLP
 	if token == nil {
 		return structs.ErrTokenNotFound
 	}
-	if token.Type != structs.ACLManagementToken && !token.PolicySubset(args.Names) {
-		return structs.ErrPermissionDenied
+	if token.Type != structs.ACLManagementToken {
+		policyNames, err := a.policyNames(token)
+		if err != nil {
+			return err
+		}
+		if subset, _ := helper.SliceStringIsSubset(policyNames, args.Names); !subset {
+			return structs.ErrPermissionDenied
+		}
 	}
 
 	// Setup the blocking query
@@ -509,6 +547,16 @@ func (a *ACL) UpsertTokens(args *structs.ACLTokenUpsertRequest, reply *structs.A
 			token.SecretID = uuid.Generate()
 			token.CreateTime = time.Now().UTC()
 
+			// Compute the expiration time from the TTL, unless it is given
+			if token.HasExpirationTime() {
+				if !token.ExpirationTime.After(token.CreateTime) {
+					return structs.NewErrRPCCodedf(400, "token %d invalid: expiration time must be in the future", idx)
+				}
+			} else if token.ExpirationTTL != 0 {
+				expirationTime := token.CreateTime.Add(token.ExpirationTTL)
+				token.ExpirationTime = &expirationTime
+			}
+
 		} else {
 			// Verify the token exists
 			out, err := state.ACLTokenByAccessorID(nil, token.AccessorID)
@@ -523,7 +571,25 @@ func (a *ACL) UpsertTokens(args *structs.ACLTokenUpsertRequest, reply *structs.A
 			if token.Global != out.Global {
 				return structs.NewErrRPCCodedf(400, "cannot toggle global mode of %s", token.AccessorID)
 			}
+
+			// Cannot change the expiration, which is kept if not given
+			changed := token.ExpirationTTL != 0 && token.ExpirationTTL != out.ExpirationTTL
+			if token.HasExpirationTime() {
+				changed = changed || !out.HasExpirationTime() || !token.ExpirationTime.Equal(*out.ExpirationTime)
+			}
+			if changed {
+				return structs.NewErrRPCCodedf(400, "cannot change expiration of %s", token.AccessorID)
+			}
+			token.ExpirationTime = out.ExpirationTime
+			token.ExpirationTTL = out.ExpirationTTL
+		}
+
+		// Resolve the linked roles
+		roles, err := resolveTokenRoleLinks(state, token.Roles)
+		if err != nil {
+			return structs.NewErrRPCCodedf(400, "token %d invalid: %v", idx, err)
 		}
+		token.Roles = roles
 
 		// Compute the token hash
 		token.SetHash()
@@ -819,11 +885,14 @@ func (a *ACL) ResolveToken(args *structs.ResolveACLTokenRequest, reply *structs.
 		return err
 	}
 
-	// Look for the token
+	// Look for the token, treating expired tokens as if they were deleted
 	out, err := state.ACLTokenBySecretID(nil, args.SecretID)
 	if err != nil {
 		return err
 	}
+	if out.IsExpired(time.Now().UTC()) {
+		out = nil
+	}
 
 	// Setup the output
 	reply.Token = out
@@ -839,3 +908,1325 @@ func (a *ACL) ResolveToken(args *structs.ResolveACLTokenRequest, reply *structs.
 	}
 	return nil
 }
+
+// UpsertRoles is used to create or update a set of roles
+func (a *ACL) UpsertRoles(args *structs.ACLRoleUpsertRequest, reply *structs.ACLRoleUpsertResponse) error {
+	// Ensure ACLs are enabled, and always flow modification requests to the authoritative region
+	if !a.srv.config.ACLEnabled {
+		return aclDisabled
+	}
+	args.Region = a.srv.config.AuthoritativeRegion
+
+	if done, err := a.srv.forward("ACL.UpsertRoles", args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "acl", "upsert_roles"}, time.Now())
+
+	// Check management level permissions
+	if acl, err := a.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if acl == nil || !acl.IsManagement() {
+		return structs.ErrPermissionDenied
+	}
+
+	// Validate non-zero set of roles
+	if len(args.Roles) == 0 {
+		return structs.NewErrRPCCoded(400, "must specify as least one role")
+	}
+
+	// Snapshot the state
+	state, err := a.srv.State().Snapshot()
+	if err != nil {
+		return err
+	}
+
+	// Validate each role, compute hash
+	names := make(map[string]struct{}, len(args.Roles))
+	for idx, role := range args.Roles {
+		if err := role.Validate(); err != nil {
+			return structs.NewErrRPCCodedf(400, "role %d invalid: %v", idx, err)
+		}
+		if _, ok := names[role.Name]; ok {
+			return structs.NewErrRPCCodedf(400, "role %d invalid: duplicate name %q", idx, role.Name)
+		}
+		names[role.Name] = struct{}{}
+
+		// Generate an ID if new, otherwise verify the role exists
+		if role.ID == "" {
+			role.ID = uuid.Generate()
+		} else {
+			out, err := state.ACLRoleByID(nil, role.ID)
+			if err != nil {
+				return structs.NewErrRPCCodedf(400, "role lookup failed: %v", err)
+			}
+			if out == nil {
+				return structs.NewErrRPCCodedf(404, "cannot find role %s", role.ID)
+			}
+		}
+
+		// Ensure the name is not used by another role
+		existing, err := state.ACLRoleByName(nil, role.Name)
+		if err != nil {
+			return structs.NewErrRPCCodedf(400, "role lookup failed: %v", err)
+		}
+		if existing != nil && existing.ID != role.ID {
+			return structs.NewErrRPCCodedf(400, "role %d invalid: role with name %q already exists", idx, role.Name)
+		}
+
+		// Ensure the linked policies exist, ignoring duplicates
+		seen := make(map[string]struct{}, len(role.Policies))
+		policies := make([]*structs.ACLRolePolicyLink, 0, len(role.Policies))
+		for _, link := range role.Policies {
+			if _, ok := seen[link.Name]; ok {
+				continue
+			}
+			seen[link.Name] = struct{}{}
+
+			policy, err := state.ACLPolicyByName(nil, link.Name)
+			if err != nil {
+				return structs.NewErrRPCCodedf(400, "policy lookup failed: %v", err)
+			}
+			if policy == nil {
+				return structs.NewErrRPCCodedf(400, "role %d invalid: cannot find policy %q", idx, link.Name)
+			}
+			policies = append(policies, link)
+		}
+		role.Policies = policies
+
+		role.SetHash()
+	}
+
+	// Update via Raft
+	_, index, err := a.srv.raftApply(structs.ACLRoleUpsertRequestType, args)
+	if err != nil {
+		return err
+	}
+
+	// Populate the response. We do a lookup against the state to
+	// pickup the proper create / modify indexes.
+	state, err = a.srv.State().Snapshot()
+	if err != nil {
+		return err
+	}
+	for _, role := range args.Roles {
+		out, err := state.ACLRoleByID(nil, role.ID)
+		if err != nil {
+			return structs.NewErrRPCCodedf(400, "role lookup failed: %v", err)
+		}
+		reply.Roles = append(reply.Roles, out)
+	}
+
+	// Update the index
+	reply.Index = index
+	return nil
+}
+
+// DeleteRoles is used to delete roles
+func (a *ACL) DeleteRoles(args *structs.ACLRoleDeleteRequest, reply *structs.GenericResponse) error {
+	// Ensure ACLs are enabled, and always flow modification requests to the authoritative region
+	if !a.srv.config.ACLEnabled {
+		return aclDisabled
+	}
+	args.Region = a.srv.config.AuthoritativeRegion
+
+	if done, err := a.srv.forward("ACL.DeleteRoles", args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "acl", "delete_roles"}, time.Now())
+
+	// Check management level permissions
+	if acl, err := a.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if acl == nil || !acl.IsManagement() {
+		return structs.ErrPermissionDenied
+	}
+
+	// Validate non-zero set of roles
+	if len(args.RoleIDs) == 0 {
+		return structs.NewErrRPCCoded(400, "must specify as least one role")
+	}
+
+	// Snapshot the state
+	state, err := a.srv.State().Snapshot()
+	if err != nil {
+		return err
+	}
+
+	// Ensure the roles exist
+	var nonexistentRoles []string
+	for _, roleID := range args.RoleIDs {
+		role, err := state.ACLRoleByID(nil, roleID)
+		if err != nil {
+			return structs.NewErrRPCCodedf(400, "role lookup failed: %v", err)
+		}
+		if role == nil {
+			nonexistentRoles = append(nonexistentRoles, roleID)
+		}
+	}
+	if len(nonexistentRoles) != 0 {
+		return structs.NewErrRPCCodedf(400, "Cannot delete nonexistent roles: %v", strings.Join(nonexistentRoles, ", "))
+	}
+
+	// Update via Raft
+	_, index, err := a.srv.raftApply(structs.ACLRoleDeleteRequestType, args)
+	if err != nil {
+		return err
+	}
+
+	// Update the index
+	reply.Index = index
+	return nil
+}
+
+// ListRoles is used to list the roles
+func (a *ACL) ListRoles(args *structs.ACLRoleListRequest, reply *structs.ACLRoleListResponse) error {
+	if !a.srv.config.ACLEnabled {
+		return aclDisabled
+	}
+
+	if done, err := a.srv.forward("ACL.ListRoles", args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "acl", "list_roles"}, time.Now())
+
+	// Resolve the token
+	acl, err := a.srv.ResolveToken(args.AuthToken)
+	if err != nil {
+		return err
+	} else if acl == nil {
+		return structs.ErrPermissionDenied
+	}
+
+	// If it is not a management token determine the roles that may be listed
+	mgt := acl.IsManagement()
+	var roles map[string]struct{}
+	if !mgt {
+		token, err := a.requestACLToken(args.AuthToken)
+		if err != nil {
+			return err
+		}
+		if token == nil {
+			return structs.ErrTokenNotFound
+		}
+
+		roles = make(map[string]struct{}, len(token.Roles))
+		for _, r := range token.Roles {
+			roles[r.ID] = struct{}{}
+		}
+	}
+
+	// Setup the blocking query
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, state *state.StateStore) error {
+			// Iterate over all the roles
+			var err error
+			var iter memdb.ResultIterator
+			if prefix := args.QueryOptions.Prefix; prefix != "" {
+				iter, err = state.ACLRoleByIDPrefix(ws, prefix)
+			} else {
+				iter, err = state.ACLRoles(ws)
+			}
+			if err != nil {
+				return err
+			}
+
+			// Convert all the roles to a list stub
+			reply.Roles = nil
+			for raw := iter.Next(); raw != nil; raw = iter.Next() {
+				role := raw.(*structs.ACLRole)
+				if _, ok := roles[role.ID]; ok || mgt {
+					reply.Roles = append(reply.Roles, role.Stub())
+				}
+			}
+
+			// Use the last index that affected the role table
+			index, err := state.Index("acl_role")
+			if err != nil {
+				return err
+			}
+
+			// Ensure we never set the index to zero, otherwise a blocking query cannot be used.
+			// We floor the index at one, since realistically the first write must have a higher index.
+			if index == 0 {
+				index = 1
+			}
+			reply.Index = index
+			return nil
+		}}
+	return a.srv.blockingRPC(&opts)
+}
+
+// GetRole is used to get a specific role by ID
+func (a *ACL) GetRole(args *structs.ACLRoleSpecificRequest, reply *structs.SingleACLRoleResponse) error {
+	if !a.srv.config.ACLEnabled {
+		return aclDisabled
+	}
+
+	if done, err := a.srv.forward("ACL.GetRole", args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "acl", "get_role"}, time.Now())
+
+	return a.getRole(&args.QueryOptions, &reply.QueryMeta, reply, func(ws memdb.WatchSet, state *state.StateStore) (*structs.ACLRole, error) {
+		return state.ACLRoleByID(ws, args.RoleID)
+	})
+}
+
+// GetRoleByName is used to get a specific role by name
+func (a *ACL) GetRoleByName(args *structs.ACLRoleByNameRequest, reply *structs.SingleACLRoleResponse) error {
+	if !a.srv.config.ACLEnabled {
+		return aclDisabled
+	}
+
+	if done, err := a.srv.forward("ACL.GetRoleByName", args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "acl", "get_role_by_name"}, time.Now())
+
+	return a.getRole(&args.QueryOptions, &reply.QueryMeta, reply, func(ws memdb.WatchSet, state *state.StateStore) (*structs.ACLRole, error) {
+		return state.ACLRoleByName(ws, args.RoleName)
+	})
+}
+
+// getRole runs the blocking query of a role lookup. Management tokens may
+// get any role, other tokens only their own roles.
+func (a *ACL) getRole(queryOpts *structs.QueryOptions, queryMeta *structs.QueryMeta,
+	reply *structs.SingleACLRoleResponse, lookup func(memdb.WatchSet, *state.StateStore) (*structs.ACLRole, error)) error {
+
+	// Resolve the token
+	acl, err := a.srv.ResolveToken(queryOpts.AuthToken)
+	if err != nil {
+		return err
+	} else if acl == nil {
+		return structs.ErrPermissionDenied
+	}
+
+	var token *structs.ACLToken
+	if !acl.IsManagement() {
+		token, err = a.requestACLToken(queryOpts.AuthToken)
+		if err != nil {
+			return err
+		}
+		if token == nil {
+			return structs.ErrTokenNotFound
+		}
+	}
+
+	// Setup the blocking query
+	opts := blockingOptions{
+		queryOpts: queryOpts,
+		queryMeta: queryMeta,
+		run: func(ws memdb.WatchSet, state *state.StateStore) error {
+			// Look for the role
+			out, err := lookup(ws, state)
+			if err != nil {
+				return err
+			}
+
+			// Check the token may get the role
+			if out != nil && token != nil && !token.RoleSubset([]string{out.ID}) {
+				return structs.ErrPermissionDenied
+			}
+
+			// Setup the output
+			reply.Role = out
+			if out != nil {
+				reply.Index = out.ModifyIndex
+			} else {
+				// Use the last index that affected the role table
+				index, err := state.Index("acl_role")
+				if err != nil {
+					return err
+				}
+				reply.Index = index
+			}
+			return nil
+		}}
+	return a.srv.blockingRPC(&opts)
+}
+
+// GetRolesByID is used to get a set of roles by ID
+func (a *ACL) GetRolesByID(args *structs.ACLRoleSetRequest, reply *structs.ACLRoleSetResponse) error {
+	if !a.srv.config.ACLEnabled {
+		return aclDisabled
+	}
+	if done, err := a.srv.forward("ACL.GetRolesByID", args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "acl", "get_roles_by_id"}, time.Now())
+
+	// For client typed tokens, allow them to query any roles associated with that token.
+	// This is used by clients which are resolving the roles of the tokens to enforce.
+	token, err := a.requestACLToken(args.AuthToken)
+	if err != nil {
+		return err
+	}
+
+	if token == nil {
+		return structs.ErrTokenNotFound
+	}
+	if !token.RoleSubset(args.RoleIDs) {
+		return structs.ErrPermissionDenied
+	}
+
+	// Setup the blocking query
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, state *state.StateStore) error {
+			// Setup the output
+			reply.Roles = make(map[string]*structs.ACLRole, len(args.RoleIDs))
+
+			// Look for the roles
+			for _, roleID := range args.RoleIDs {
+				out, err := state.ACLRoleByID(ws, roleID)
+				if err != nil {
+					return err
+				}
+				if out != nil {
+					reply.Roles[roleID] = out
+				}
+			}
+
+			// Use the last index that affected the role table
+			index, err := state.Index("acl_role")
+			if err != nil {
+				return err
+			}
+			reply.Index = index
+			return nil
+		}}
+	return a.srv.blockingRPC(&opts)
+}
+
+// resolveTokenRoleLinks resolves the role links of a token by ID or by name,
+// filling in both fields of each link and removing duplicates. It fails if a
+// role doesn't exist.
+func resolveTokenRoleLinks(snap *state.StateSnapshot, links []*structs.ACLTokenRoleLink) ([]*structs.ACLTokenRoleLink, error) {
+	if len(links) == 0 {
+		return nil, nil
+	}
+
+	seen := make(map[string]struct{}, len(links))
+	resolved := make([]*structs.ACLTokenRoleLink, 0, len(links))
+	for _, link := range links {
+		if link == nil {
+			continue
+		}
+
+		var role *structs.ACLRole
+		var err error
+		switch {
+		case link.ID != "":
+			role, err = snap.ACLRoleByID(nil, link.ID)
+		case link.Name != "":
+			role, err = snap.ACLRoleByName(nil, link.Name)
+		default:
+			return nil, fmt.Errorf("role link missing ID or name")
+		}
+		if err != nil {
+			return nil, err
+		}
+		if role == nil {
+			if link.ID != "" {
+				return nil, fmt.Errorf("cannot find role %s", link.ID)
+			}
+			return nil, fmt.Errorf("cannot find role %q", link.Name)
+		}
+
+		if _, ok := seen[role.ID]; ok {
+			continue
+		}
+		seen[role.ID] = struct{}{}
+		resolved = append(resolved, &structs.ACLTokenRoleLink{ID: role.ID, Name: role.Name})
+	}
+	return resolved, nil
+}
+
+// UpsertAuthMethods is used to create or update a set of auth methods
+func (a *ACL) UpsertAuthMethods(args *structs.ACLAuthMethodUpsertRequest, reply *structs.ACLAuthMethodUpsertResponse) error {
+	// Ensure ACLs are enabled, and always flow modification requests to the authoritative region
+	if !a.srv.config.ACLEnabled {
+		return aclDisabled
+	}
+	args.Region = a.srv.config.AuthoritativeRegion
+
+	if done, err := a.srv.forward("ACL.UpsertAuthMethods", args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "acl", "upsert_auth_methods"}, time.Now())
+
+	// Check management level permissions
+	if acl, err := a.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if acl == nil || !acl.IsManagement() {
+		return structs.ErrPermissionDenied
+	}
+
+	// Validate non-zero set of auth methods
+	if len(args.AuthMethods) == 0 {
+		return structs.NewErrRPCCoded(400, "must specify as least one auth method")
+	}
+
+	// Validate each auth method, compute hash
+	names := make(map[string]struct{}, len(args.AuthMethods))
+	for idx, method := range args.AuthMethods {
+		if err := method.Validate(); err != nil {
+			return structs.NewErrRPCCodedf(400, "auth method %d invalid: %v", idx, err)
+		}
+		if _, ok := names[method.Name]; ok {
+			return structs.NewErrRPCCodedf(400, "auth method %d invalid: duplicate name %q", idx, method.Name)
+		}
+		names[method.Name] = struct{}{}
+		method.SetHash()
+	}
+
+	// Update via Raft
+	_, index, err := a.srv.raftApply(structs.ACLAuthMethodUpsertRequestType, args)
+	if err != nil {
+		return err
+	}
+
+	// Populate the response. We do a lookup against the state to
+	// pickup the proper create / modify indexes.
+	state, err := a.srv.State().Snapshot()
+	if err != nil {
+		return err
+	}
+	for _, method := range args.AuthMethods {
+		out, err := state.ACLAuthMethodByName(nil, method.Name)
+		if err != nil {
+			return structs.NewErrRPCCodedf(400, "auth method lookup failed: %v", err)
+		}
+		reply.AuthMethods = append(reply.AuthMethods, out)
+	}
+
+	// Update the index
+	reply.Index = index
+	return nil
+}
+
+// DeleteAuthMethods is used to delete auth methods, along with their binding
+// rules
+func (a *ACL) DeleteAuthMethods(args *structs.ACLAuthMethodDeleteRequest, reply *structs.GenericResponse) error {
+	// Ensure ACLs are enabled, and always flow modification requests to the authoritative region
+	if !a.srv.config.ACLEnabled {
+		return aclDisabled
+	}
+	args.Region = a.srv.config.AuthoritativeRegion
+
+	if done, err := a.srv.forward("ACL.DeleteAuthMethods", args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "acl", "delete_auth_methods"}, time.Now())
+
+	// Check management level permissions
+	if acl, err := a.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if acl == nil || !acl.IsManagement() {
+		return structs.ErrPermissionDenied
+	}
+
+	// Validate non-zero set of auth methods
+	if len(args.MethodNames) == 0 {
+		return structs.NewErrRPCCoded(400, "must specify as least one auth method")
+	}
+
+	// Snapshot the state
+	state, err := a.srv.State().Snapshot()
+	if err != nil {
+		return err
+	}
+
+	// Ensure the auth methods exist
+	var nonexistentMethods []string
+	for _, name := range args.MethodNames {
+		method, err := state.ACLAuthMethodByName(nil, name)
+		if err != nil {
+			return structs.NewErrRPCCodedf(400, "auth method lookup failed: %v", err)
+		}
+		if method == nil {
+			nonexistentMethods = append(nonexistentMethods, name)
+		}
+	}
+	if len(nonexistentMethods) != 0 {
+		return structs.NewErrRPCCodedf(400, "Cannot delete nonexistent auth methods: %v", strings.Join(nonexistentMethods, ", "))
+	}
+
+	// Update via Raft
+	_, index, err := a.srv.raftApply(structs.ACLAuthMethodDeleteRequestType, args)
+	if err != nil {
+		return err
+	}
+
+	// Update the index
+	reply.Index = index
+	return nil
+}
+
+// ListAuthMethods is used to list the auth methods. No token is required, as
+// users need to know the auth methods before they can log in.
+func (a *ACL) ListAuthMethods(args *structs.ACLAuthMethodListRequest, reply *structs.ACLAuthMethodListResponse) error {
+	if !a.srv.config.ACLEnabled {
+		return aclDisabled
+	}
+
+	if done, err := a.srv.forward("ACL.ListAuthMethods", args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "acl", "list_auth_methods"}, time.Now())
+
+	// Setup the blocking query
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, state *state.StateStore) error {
+			// Iterate over all the auth methods
+			iter, err := state.ACLAuthMethods(ws)
+			if err != nil {
+				return err
+			}
+
+			// Convert all the auth methods to a list stub
+			reply.AuthMethods = nil
+			for raw := iter.Next(); raw != nil; raw = iter.Next() {
+				method := raw.(*structs.ACLAuthMethod)
+				reply.AuthMethods = append(reply.AuthMethods, method.Stub())
+			}
+
+			// Use the last index that affected the auth method table
+			index, err := state.Index("acl_auth_method")
+			if err != nil {
+				return err
+			}
+
+			// Ensure we never set the index to zero, otherwise a blocking query cannot be used.
+			// We floor the index at one, since realistically the first write must have a higher index.
+			if index == 0 {
+				index = 1
+			}
+			reply.Index = index
+			return nil
+		}}
+	return a.srv.blockingRPC(&opts)
+}
+
+// GetAuthMethod is used to get a specific auth method
+func (a *ACL) GetAuthMethod(args *structs.ACLAuthMethodSpecificRequest, reply *structs.SingleACLAuthMethodResponse) error {
+	if !a.srv.config.ACLEnabled {
+		return aclDisabled
+	}
+
+	if done, err := a.srv.forward("ACL.GetAuthMethod", args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "acl", "get_auth_method"}, time.Now())
+
+	// Check management level permissions, as auth methods hold client secrets
+	if acl, err := a.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if acl == nil || !acl.IsManagement() {
+		return structs.ErrPermissionDenied
+	}
+
+	// Setup the blocking query
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, state *state.StateStore) error {
+			// Look for the auth method
+			out, err := state.ACLAuthMethodByName(ws, args.MethodName)
+			if err != nil {
+				return err
+			}
+
+			// Setup the output
+			reply.AuthMethod = out
+			if out != nil {
+				reply.Index = out.ModifyIndex
+			} else {
+				// Use the last index that affected the auth method table
+				index, err := state.Index("acl_auth_method")
+				if err != nil {
+					return err
+				}
+				reply.Index = index
+			}
+			return nil
+		}}
+	return a.srv.blockingRPC(&opts)
+}
+
+// GetAuthMethods is used to get a set of auth methods by name
+func (a *ACL) GetAuthMethods(args *structs.ACLAuthMethodSetRequest, reply *structs.ACLAuthMethodSetResponse) error {
+	if !a.srv.config.ACLEnabled {
+		return aclDisabled
+	}
+	if done, err := a.srv.forward("ACL.GetAuthMethods", args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "acl", "get_auth_methods"}, time.Now())
+
+	// Check management level permissions
+	if acl, err := a.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if acl == nil || !acl.IsManagement() {
+		return structs.ErrPermissionDenied
+	}
+
+	// Setup the blocking query
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, state *state.StateStore) error {
+			// Setup the output
+			reply.AuthMethods = make(map[string]*structs.ACLAuthMethod, len(args.MethodNames))
+
+			// Look for the auth methods
+			for _, name := range args.MethodNames {
+				out, err := state.ACLAuthMethodByName(ws, name)
+				if err != nil {
+					return err
+				}
+				if out != nil {
+					reply.AuthMethods[name] = out
+				}
+			}
+
+			// Use the last index that affected the auth method table
+			index, err := state.Index("acl_auth_method")
+			if err != nil {
+				return err
+			}
+			reply.Index = index
+			return nil
+		}}
+	return a.srv.blockingRPC(&opts)
+}
+
+// UpsertBindingRules is used to create or update a set of binding rules
+func (a *ACL) UpsertBindingRules(args *structs.ACLBindingRuleUpsertRequest, reply *structs.ACLBindingRuleUpsertResponse) error {
+	// Ensure ACLs are enabled, and always flow modification requests to the authoritative region
+	if !a.srv.config.ACLEnabled {
+		return aclDisabled
+	}
+	args.Region = a.srv.config.AuthoritativeRegion
+
+	if done, err := a.srv.forward("ACL.UpsertBindingRules", args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "acl", "upsert_binding_rules"}, time.Now())
+
+	// Check management level permissions
+	if acl, err := a.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if acl == nil || !acl.IsManagement() {
+		return structs.ErrPermissionDenied
+	}
+
+	// Validate non-zero set of binding rules
+	if len(args.BindingRules) == 0 {
+		return structs.NewErrRPCCoded(400, "must specify as least one binding rule")
+	}
+
+	// Snapshot the state
+	state, err := a.srv.State().Snapshot()
+	if err != nil {
+		return err
+	}
+
+	// Validate each binding rule, compute hash
+	for idx, rule := range args.BindingRules {
+		if err := rule.Validate(); err != nil {
+			return structs.NewErrRPCCodedf(400, "binding rule %d invalid: %v", idx, err)
+		}
+
+		// Generate an ID if new, otherwise verify the binding rule exists
+		if rule.ID == "" {
+			rule.ID = uuid.Generate()
+		} else {
+			out, err := state.ACLBindingRuleByID(nil, rule.ID)
+			if err != nil {
+				return structs.NewErrRPCCodedf(400, "binding rule lookup failed: %v", err)
+			}
+			if out == nil {
+				return structs.NewErrRPCCodedf(404, "cannot find binding rule %s", rule.ID)
+			}
+		}
+
+		// Ensure the auth method exists
+		method, err := state.ACLAuthMethodByName(nil, rule.AuthMethod)
+		if err != nil {
+			return structs.NewErrRPCCodedf(400, "auth method lookup failed: %v", err)
+		}
+		if method == nil {
+			return structs.NewErrRPCCodedf(400, "binding rule %d invalid: cannot find auth method %q", idx, rule.AuthMethod)
+		}
+
+		rule.SetHash()
+	}
+
+	// Update via Raft
+	_, index, err := a.srv.raftApply(structs.ACLBindingRuleUpsertRequestType, args)
+	if err != nil {
+		return err
+	}
+
+	// Populate the response. We do a lookup against the state to
+	// pickup the proper create / modify indexes.
+	state, err = a.srv.State().Snapshot()
+	if err != nil {
+		return err
+	}
+	for _, rule := range args.BindingRules {
+		out, err := state.ACLBindingRuleByID(nil, rule.ID)
+		if err != nil {
+			return structs.NewErrRPCCodedf(400, "binding rule lookup failed: %v", err)
+		}
+		reply.BindingRules = append(reply.BindingRules, out)
+	}
+
+	// Update the index
+	reply.Index = index
+	return nil
+}
+
+// DeleteBindingRules is used to delete binding rules
+func (a *ACL) DeleteBindingRules(args *structs.ACLBindingRuleDeleteRequest, reply *structs.GenericResponse) error {
+	// Ensure ACLs are enabled, and always flow modification requests to the authoritative region
+	if !a.srv.config.ACLEnabled {
+		return aclDisabled
+	}
+	args.Region = a.srv.config.AuthoritativeRegion
+
+	if done, err := a.srv.forward("ACL.DeleteBindingRules", args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "acl", "delete_binding_rules"}, time.Now())
+
+	// Check management level permissions
+	if acl, err := a.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if acl == nil || !acl.IsManagement() {
+		return structs.ErrPermissionDenied
+	}
+
+	// Validate non-zero set of binding rules
+	if len(args.BindingRuleIDs) == 0 {
+		return structs.NewErrRPCCoded(400, "must specify as least one binding rule")
+	}
+
+	// Snapshot the state
+	state, err := a.srv.State().Snapshot()
+	if err != nil {
+		return err
+	}
+
+	// Ensure the binding rules exist
+	var nonexistentRules []string
+	for _, ruleID := range args.BindingRuleIDs {
+		rule, err := state.ACLBindingRuleByID(nil, ruleID)
+		if err != nil {
+			return structs.NewErrRPCCodedf(400, "binding rule lookup failed: %v", err)
+		}
+		if rule == nil {
+			nonexistentRules = append(nonexistentRules, ruleID)
+		}
+	}
+	if len(nonexistentRules) != 0 {
+		return structs.NewErrRPCCodedf(400, "Cannot delete nonexistent binding rules: %v", strings.Join(nonexistentRules, ", "))
+	}
+
+	// Update via Raft
+	_, index, err := a.srv.raftApply(structs.ACLBindingRuleDeleteRequestType, args)
+	if err != nil {
+		return err
+	}
+
+	// Update the index
+	reply.Index = index
+	return nil
+}
+
+// ListBindingRules is used to list the binding rules
+func (a *ACL) ListBindingRules(args *structs.ACLBindingRuleListRequest, reply *structs.ACLBindingRuleListResponse) error {
+	if !a.srv.config.ACLEnabled {
+		return aclDisabled
+	}
+
+	if done, err := a.srv.forward("ACL.ListBindingRules", args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "acl", "list_binding_rules"}, time.Now())
+
+	// Check management level permissions
+	if acl, err := a.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if acl == nil || !acl.IsManagement() {
+		return structs.ErrPermissionDenied
+	}
+
+	// Setup the blocking query
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, state *state.StateStore) error {
+			// Iterate over all the binding rules
+			iter, err := state.ACLBindingRules(ws)
+			if err != nil {
+				return err
+			}
+
+			// Convert all the binding rules to a list stub
+			reply.BindingRules = nil
+			for raw := iter.Next(); raw != nil; raw = iter.Next() {
+				rule := raw.(*structs.ACLBindingRule)
+				reply.BindingRules = append(reply.BindingRules, rule.Stub())
+			}
+
+			// Use the last index that affected the binding rule table
+			index, err := state.Index("acl_binding_rule")
+			if err != nil {
+				return err
+			}
+
+			// Ensure we never set the index to zero, otherwise a blocking query cannot be used.
+			// We floor the index at one, since realistically the first write must have a higher index.
+			if index == 0 {
+				index = 1
+			}
+			reply.Index = index
+			return nil
+		}}
+	return a.srv.blockingRPC(&opts)
+}
+
+// GetBindingRule is used to get a specific binding rule
+func (a *ACL) GetBindingRule(args *structs.ACLBindingRuleSpecificRequest, reply *structs.SingleACLBindingRuleResponse) error {
+	if !a.srv.config.ACLEnabled {
+		return aclDisabled
+	}
+
+	if done, err := a.srv.forward("ACL.GetBindingRule", args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "acl", "get_binding_rule"}, time.Now())
+
+	// Check management level permissions
+	if acl, err := a.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if acl == nil || !acl.IsManagement() {
+		return structs.ErrPermissionDenied
+	}
+
+	// Setup the blocking query
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, state *state.StateStore) error {
+			// Look for the binding rule
+			out, err := state.ACLBindingRuleByID(ws, args.BindingRuleID)
+			if err != nil {
+				return err
+			}
+
+			// Setup the output
+			reply.BindingRule = out
+			if out != nil {
+				reply.Index = out.ModifyIndex
+			} else {
+				// Use the last index that affected the binding rule table
+				index, err := state.Index("acl_binding_rule")
+				if err != nil {
+					return err
+				}
+				reply.Index = index
+			}
+			return nil
+		}}
+	return a.srv.blockingRPC(&opts)
+}
+
+// GetBindingRules is used to get a set of binding rules by ID
+func (a *ACL) GetBindingRules(args *structs.ACLBindingRuleSetRequest, reply *structs.ACLBindingRuleSetResponse) error {
+	if !a.srv.config.ACLEnabled {
+		return aclDisabled
+	}
+	if done, err := a.srv.forward("ACL.GetBindingRules", args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "acl", "get_binding_rules"}, time.Now())
+
+	// Check management level permissions
+	if acl, err := a.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if acl == nil || !acl.IsManagement() {
+		return structs.ErrPermissionDenied
+	}
+
+	// Setup the blocking query
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, state *state.StateStore) error {
+			// Setup the output
+			reply.BindingRules = make(map[string]*structs.ACLBindingRule, len(args.BindingRuleIDs))
+
+			// Look for the binding rules
+			for _, ruleID := range args.BindingRuleIDs {
+				out, err := state.ACLBindingRuleByID(ws, ruleID)
+				if err != nil {
+					return err
+				}
+				if out != nil {
+					reply.BindingRules[ruleID] = out
+				}
+			}
+
+			// Use the last index that affected the binding rule table
+			index, err := state.Index("acl_binding_rule")
+			if err != nil {
+				return err
+			}
+			reply.Index = index
+			return nil
+		}}
+	return a.srv.blockingRPC(&opts)
+}
+
+// Login is used to exchange the JWT of an identity for a short-lived ACL
+// token, using a JWT auth method. The JWT is verified against the JWKS of the
+// auth method, and the policies and roles of the token are set by the binding
+// rules matching its claims.
+func (a *ACL) Login(args *structs.ACLLoginRequest, reply *structs.ACLLoginResponse) error {
+	if !a.srv.config.ACLEnabled {
+		return aclDisabled
+	}
+
+	if done, err := a.srv.forward("ACL.Login", args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "acl", "login"}, time.Now())
+
+	if args.LoginToken == "" {
+		return structs.NewErrRPCCoded(400, "missing login token")
+	}
+
+	method, err := a.loginAuthMethod(args.AuthMethodName, structs.ACLAuthMethodTypeJWT)
+	if err != nil {
+		return err
+	}
+
+	// Global tokens are minted by the authoritative region
+	if method.TokenLocality == structs.ACLAuthMethodTokenLocalityGlobal {
+		args.Region = a.srv.config.AuthoritativeRegion
+		if done, err := a.srv.forward("ACL.Login", args, args, reply); done {
+			return err
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), authMethodRequestTimeout)
+	defer cancel()
+
+	provider, err := authMethodProvider(ctx, method)
+	if err != nil {
+		return err
+	}
+	claims, err := verifyAuthMethodJWT(ctx, method, provider, args.LoginToken, "")
+	if err != nil {
+		return err
+	}
+	return a.mintAuthMethodToken(method, claims, reply)
+}
+
+// OIDCAuthURL is used to start the login of a user with an OIDC auth method.
+// It returns the URL of the OIDC provider the user authenticates at, which
+// redirects the user to the redirect URI with an authorization code.
+func (a *ACL) OIDCAuthURL(args *structs.ACLOIDCAuthURLRequest, reply *structs.ACLOIDCAuthURLResponse) error {
+	if !a.srv.config.ACLEnabled {
+		return aclDisabled
+	}
+
+	if done, err := a.srv.forward("ACL.OIDCAuthURL", args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "acl", "oidc_auth_url"}, time.Now())
+
+	if args.RedirectURI == "" || args.State == "" || args.Nonce == "" {
+		return structs.NewErrRPCCoded(400, "missing redirect URI, state or nonce")
+	}
+
+	method, err := a.loginAuthMethod(args.AuthMethodName, structs.ACLAuthMethodTypeOIDC)
+	if err != nil {
+		return err
+	}
+	if err := checkAuthMethodRedirectURI(method, args.RedirectURI); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), authMethodRequestTimeout)
+	defer cancel()
+
+	provider, err := authMethodProvider(ctx, method)
+	if err != nil {
+		return err
+	}
+	authURL, err := provider.AuthURL(method.Config.OIDCClientID, args.RedirectURI,
+		args.State, args.Nonce, method.Config.OIDCScopes)
+	if err != nil {
+		return err
+	}
+
+	reply.AuthURL = authURL
+	reply.Index = method.ModifyIndex
+	return nil
+}
+
+// OIDCCompleteAuth is used to complete the login of a user with an OIDC auth
+// method. It exchanges the authorization code the OIDC provider redirected
+// the user with for their ID token, and the ID token for a short-lived ACL
+// token.
+func (a *ACL) OIDCCompleteAuth(args *structs.ACLOIDCCompleteAuthRequest, reply *structs.ACLLoginResponse) error {
+	if !a.srv.config.ACLEnabled {
+		return aclDisabled
+	}
+
+	if done, err := a.srv.forward("ACL.OIDCCompleteAuth", args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "acl", "oidc_complete_auth"}, time.Now())
+
+	if args.RedirectURI == "" || args.Code == "" || args.Nonce == "" {
+		return structs.NewErrRPCCoded(400, "missing redirect URI, code or nonce")
+	}
+
+	method, err := a.loginAuthMethod(args.AuthMethodName, structs.ACLAuthMethodTypeOIDC)
+	if err != nil {
+		return err
+	}
+	if err := checkAuthMethodRedirectURI(method, args.RedirectURI); err != nil {
+		return err
+	}
+
+	// Global tokens are minted by the authoritative region
+	if method.TokenLocality == structs.ACLAuthMethodTokenLocalityGlobal {
+		args.Region = a.srv.config.AuthoritativeRegion
+		if done, err := a.srv.forward("ACL.OIDCCompleteAuth", args, args, reply); done {
+			return err
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), authMethodRequestTimeout)
+	defer cancel()
+
+	provider, err := authMethodProvider(ctx, method)
+	if err != nil {
+		return err
+	}
+	idToken, err := provider.Exchange(ctx, authMethodHTTPClient(), method.Config.OIDCClientID,
+		method.Config.OIDCClientSecret, args.RedirectURI, args.Code)
+	if err != nil {
+		return structs.NewErrRPCCodedf(403, "%v", err)
+	}
+	claims, err := verifyAuthMethodJWT(ctx, method, provider, idToken, args.Nonce)
+	if err != nil {
+		return err
+	}
+	return a.mintAuthMethodToken(method, claims, reply)
+}
+
+// loginAuthMethod returns the auth method of the given type users log in with
+func (a *ACL) loginAuthMethod(name, methodType string) (*structs.ACLAuthMethod, error) {
+	state, err := a.srv.State().Snapshot()
+	if err != nil {
+		return nil, err
+	}
+	method, err := state.ACLAuthMethodByName(nil, name)
+	if err != nil {
+		return nil, structs.NewErrRPCCodedf(400, "auth method lookup failed: %v", err)
+	}
+	if method == nil {
+		return nil, structs.NewErrRPCCodedf(404, "cannot find auth method %q", name)
+	}
+	if method.Type != methodType {
+		return nil, structs.NewErrRPCCodedf(400, "auth method %q is of type %s, not %s", name, method.Type, methodType)
+	}
+	return method, nil
+}
+
+// mintAuthMethodToken creates the ACL token of an identity that logged in
+// with an auth method. The token is bound to the policies and roles of the
+// binding rules of the auth method matching the claims of the identity, and
+// expires after the max token TTL of the auth method.
+func (a *ACL) mintAuthMethodToken(method *structs.ACLAuthMethod, claims map[string]interface{},
+	reply *structs.ACLLoginResponse) error {
+
+	data, err := auth.MapClaims(claims, method.Config.ClaimMappings, method.Config.ListClaimMappings)
+	if err != nil {
+		return structs.NewErrRPCCodedf(403, "failed to map claims: %v", err)
+	}
+
+	// Snapshot the state
+	state, err := a.srv.State().Snapshot()
+	if err != nil {
+		return err
+	}
+
+	// Evaluate the binding rules of the auth method
+	iter, err := state.ACLBindingRulesByAuthMethod(nil, method.Name)
+	if err != nil {
+		return err
+	}
+	var policies []string
+	var roles []*structs.ACLTokenRoleLink
+	seen := make(map[string]struct{})
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		rule := raw.(*structs.ACLBindingRule)
+		selector, err := auth.ParseSelector(rule.Selector)
+		if err != nil {
+			a.logger.Warn("skipping binding rule with invalid selector", "binding_rule", rule.ID, "error", err)
+			continue
+		}
+		if !selector.Match(data) {
+			continue
+		}
+
+		key := rule.BindType + "/" + rule.BindName
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+
+		switch rule.BindType {
+		case structs.ACLBindingRuleBindTypePolicy:
+			policies = append(policies, rule.BindName)
+		case structs.ACLBindingRuleBindTypeRole:
+			role, err := state.ACLRoleByName(nil, rule.BindName)
+			if err != nil {
+				return err
+			}
+			if role == nil {
+				a.logger.Warn("skipping binding rule bound to missing role", "binding_rule", rule.ID, "role", rule.BindName)
+				continue
+			}
+			roles = append(roles, &structs.ACLTokenRoleLink{ID: role.ID, Name: role.Name})
+		}
+	}
+	if len(policies) == 0 && len(roles) == 0 {
+		return structs.NewErrRPCCoded(403, "no binding rules of the auth method match the identity")
+	}
+
+	now := time.Now().UTC()
+	expirationTime := now.Add(method.MaxTokenTTL)
+	token := &structs.ACLToken{
+		AccessorID:     uuid.Generate(),
+		SecretID:       uuid.Generate(),
+		Name:           fmt.Sprintf("auth method %s", method.Name),
+		Type:           structs.ACLClientToken,
+		Policies:       policies,
+		Roles:          roles,
+		Global:         method.TokenLocality == structs.ACLAuthMethodTokenLocalityGlobal,
+		CreateTime:     now,
+		ExpirationTime: &expirationTime,
+		ExpirationTTL:  method.MaxTokenTTL,
+	}
+	if err := token.Validate(); err != nil {
+		return structs.NewErrRPCCodedf(500, "minted invalid token: %v", err)
+	}
+	token.SetHash()
+
+	// Update via Raft
+	req := &structs.ACLTokenUpsertRequest{Tokens: []*structs.ACLToken{token}}
+	_, index, err := a.srv.raftApply(structs.ACLTokenUpsertRequestType, req)
+	if err != nil {
+		return err
+	}
+
+	// Populate the response. We do a lookup against the state to
+	// pickup the proper create / modify indexes.
+	state, err = a.srv.State().Snapshot()
+	if err != nil {
+		return err
+	}
+	out, err := state.ACLTokenByAccessorID(nil, token.AccessorID)
+	if err != nil {
+		return structs.NewErrRPCCodedf(400, "token lookup failed: %v", err)
+	}
+	reply.Token = out
+	reply.Index = index
+	return nil
+}
+
+// checkAuthMethodRedirectURI returns an error if the OIDC provider isn't
+// allowed to redirect users to the redirect URI
+func checkAuthMethodRedirectURI(method *structs.ACLAuthMethod, redirectURI string) error {
+	for _, allowed := range method.Config.AllowedRedirectURIs {
+		if allowed == redirectURI {
+			return nil
+		}
+	}
+	return structs.NewErrRPCCodedf(400, "redirect URI %q not allowed by auth method %q", redirectURI, method.Name)
+}
+
+// authMethodProvider discovers the OIDC provider of an auth method. It returns
+// nil if the auth method has no OIDC discovery URL.
+func authMethodProvider(ctx context.Context, method *structs.ACLAuthMethod) (*auth.ProviderConfig, error) {
+	if method.Config.OIDCDiscoveryURL == "" {
+		return nil, nil
+	}
+	provider, err := auth.Discover(ctx, authMethodHTTPClient(), method.Config.OIDCDiscoveryURL)
+	if err != nil {
+		return nil, structs.NewErrRPCCodedf(500, "auth method %q: %v", method.Name, err)
+	}
+	return provider, nil
+}
+
+// verifyAuthMethodJWT verifies a JWT against the JWKS of an auth method and
+// returns its claims. The JWKS and the expected issuer default to the ones of
+// the OIDC provider of the auth method, and OIDC ID tokens must be issued for
+// the OIDC client of the auth method.
+func verifyAuthMethodJWT(ctx context.Context, method *structs.ACLAuthMethod, provider *auth.ProviderConfig,
+	token, nonce string) (map[string]interface{}, error) {
+
+	config := method.Config
+	expected := &auth.Expected{
+		Issuers:         config.BoundIssuer,
+		Audiences:       config.BoundAudiences,
+		SigningAlgs:     config.SigningAlgs,
+		Nonce:           nonce,
+		ClockSkewLeeway: config.ClockSkewLeeway,
+	}
+	if method.Type == structs.ACLAuthMethodTypeOIDC {
+		expected.Audiences = append([]string{config.OIDCClientID}, expected.Audiences...)
+	}
+
+	jwksURL := config.JWKSURL
+	if provider != nil {
+		if jwksURL == "" {
+			jwksURL = provider.JWKSURI
+		}
+		if len(expected.Issuers) == 0 {
+			expected.Issuers = []string{provider.Issuer}
+		}
+	}
+
+	keySet, err := auth.FetchKeySet(ctx, authMethodHTTPClient(), jwksURL)
+	if err != nil {
+		return nil, structs.NewErrRPCCodedf(500, "auth method %q: %v", method.Name, err)
+	}
+	claims, err := keySet.Verify(token, expected)
+	if err != nil {
+		return nil, structs.NewErrRPCCodedf(403, "failed to verify login token: %v", err)
+	}
+	return claims, nil
+}
+
+// authMethodHTTPClient returns the HTTP client used to reach the identity
+// providers of auth methods
+func authMethodHTTPClient() *http.Client {
+	client := cleanhttp.DefaultClient()
+	client.Timeout = authMethodRequestTimeout
+	return client
+}
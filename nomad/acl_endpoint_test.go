@@ -3,6 +3,7 @@ package nomad
 import (
 	"fmt"
 	"io/ioutil"
+	"net/url"
 	"os"
 	"path/filepath"
 	"strings"
@@ -11,6 +12,7 @@ import (
 
 	msgpackrpc "github.com/hashicorp/net-rpc-msgpackrpc"
 	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/lib/auth"
 	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/testutil"
@@ -1218,6 +1220,78 @@ func TestACLEndpoint_UpsertTokens_Invalid(t *testing.T) {
 	}
 }
 
+func TestACLEndpoint_UpsertTokens_Expiration(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	s1, root, cleanupS1 := TestACLServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	// Create a token with a TTL
+	p1 := mock.ACLToken()
+	p1.AccessorID = "" // Blank to create
+	p1.ExpirationTTL = time.Hour
+
+	req := &structs.ACLTokenUpsertRequest{
+		Tokens: []*structs.ACLToken{p1},
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			AuthToken: root.SecretID,
+		},
+	}
+	var resp structs.ACLTokenUpsertResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "ACL.UpsertTokens", req, &resp))
+
+	// The expiration time should be computed from the TTL
+	created := resp.Tokens[0]
+	require.NotNil(created.ExpirationTime)
+	require.Equal(created.CreateTime.Add(time.Hour), *created.ExpirationTime)
+	require.Equal(time.Hour, created.ExpirationTTL)
+
+	// Updating the token without an expiration should keep it
+	update := new(structs.ACLToken)
+	*update = *created
+	update.Name = "updated"
+	update.ExpirationTime = nil
+	update.ExpirationTTL = 0
+	req.Tokens = []*structs.ACLToken{update}
+	require.NoError(msgpackrpc.CallWithCodec(codec, "ACL.UpsertTokens", req, &resp))
+	out, err := s1.fsm.State().ACLTokenByAccessorID(nil, created.AccessorID)
+	require.NoError(err)
+	require.Equal("updated", out.Name)
+	require.True(created.ExpirationTime.Equal(*out.ExpirationTime))
+
+	// Changing the expiration should fail
+	update = new(structs.ACLToken)
+	*update = *created
+	update.ExpirationTTL = 2 * time.Hour
+	req.Tokens = []*structs.ACLToken{update}
+	err = msgpackrpc.CallWithCodec(codec, "ACL.UpsertTokens", req, &resp)
+	require.Error(err)
+	require.Contains(err.Error(), "cannot change expiration")
+
+	// Creating a token which has already expired should fail
+	past := time.Now().UTC().Add(-time.Hour)
+	p2 := mock.ACLToken()
+	p2.AccessorID = ""
+	p2.ExpirationTime = &past
+	req.Tokens = []*structs.ACLToken{p2}
+	err = msgpackrpc.CallWithCodec(codec, "ACL.UpsertTokens", req, &resp)
+	require.Error(err)
+	require.Contains(err.Error(), "expiration time must be in the future")
+
+	// Creating a token with a negative TTL should fail
+	p3 := mock.ACLToken()
+	p3.AccessorID = ""
+	p3.ExpirationTTL = -time.Hour
+	req.Tokens = []*structs.ACLToken{p3}
+	err = msgpackrpc.CallWithCodec(codec, "ACL.UpsertTokens", req, &resp)
+	require.Error(err)
+	require.Contains(err.Error(), "cannot be negative")
+}
+
 func TestACLEndpoint_ResolveToken(t *testing.T) {
 	t.Parallel()
 	s1, _, cleanupS1 := TestACLServer(t, nil)
@@ -1249,3 +1323,916 @@ func TestACLEndpoint_ResolveToken(t *testing.T) {
 	assert.Equal(t, uint64(1000), resp.Index)
 	assert.Nil(t, resp.Token)
 }
+
+func TestACLEndpoint_ResolveToken_Expired(t *testing.T) {
+	t.Parallel()
+	s1, _, cleanupS1 := TestACLServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	// Create an expired token
+	past := time.Now().UTC().Add(-time.Hour)
+	token := mock.ACLToken()
+	token.ExpirationTime = &past
+	s1.fsm.State().UpsertACLTokens(1000, []*structs.ACLToken{token})
+
+	// Lookup the token, which should not be found
+	get := &structs.ResolveACLTokenRequest{
+		SecretID:     token.SecretID,
+		QueryOptions: structs.QueryOptions{Region: "global"},
+	}
+	var resp structs.ResolveACLTokenResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "ACL.ResolveToken", get, &resp))
+	require.Equal(t, uint64(1000), resp.Index)
+	require.Nil(t, resp.Token)
+}
+
+func TestACLEndpoint_UpsertRoles(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	s1, root, cleanupS1 := TestACLServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	// Create the policies of the role
+	p1 := mock.ACLPolicy()
+	p2 := mock.ACLPolicy()
+	require.NoError(s1.fsm.State().UpsertACLPolicies(1000, []*structs.ACLPolicy{p1, p2}))
+
+	// Create the role
+	role := mock.ACLRole()
+	role.ID = "" // Blank to create
+	role.Policies = []*structs.ACLRolePolicyLink{{Name: p1.Name}, {Name: p1.Name}}
+	req := &structs.ACLRoleUpsertRequest{
+		Roles: []*structs.ACLRole{role},
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			AuthToken: root.SecretID,
+		},
+	}
+	var resp structs.ACLRoleUpsertResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "ACL.UpsertRoles", req, &resp))
+	require.NotEqual(uint64(0), resp.Index)
+
+	// The role should be created with an ID and deduplicated policies
+	created := resp.Roles[0]
+	require.NotEmpty(created.ID)
+	require.Equal(role.Name, created.Name)
+	require.Equal([]*structs.ACLRolePolicyLink{{Name: p1.Name}}, created.Policies)
+	out, err := s1.fsm.State().ACLRoleByID(nil, created.ID)
+	require.NoError(err)
+	require.Equal(created, out)
+
+	// Update the role
+	update := mock.ACLRole()
+	update.ID = created.ID
+	update.Name = created.Name
+	update.Policies = []*structs.ACLRolePolicyLink{{Name: p2.Name}}
+	req.Roles = []*structs.ACLRole{update}
+	require.NoError(msgpackrpc.CallWithCodec(codec, "ACL.UpsertRoles", req, &resp))
+	out, err = s1.fsm.State().ACLRoleByID(nil, created.ID)
+	require.NoError(err)
+	require.Equal([]string{p2.Name}, out.PolicyNames())
+	require.Equal(created.CreateIndex, out.CreateIndex)
+
+	// Linking a policy which doesn't exist should fail
+	invalid := mock.ACLRole()
+	invalid.ID = ""
+	req.Roles = []*structs.ACLRole{invalid}
+	err = msgpackrpc.CallWithCodec(codec, "ACL.UpsertRoles", req, &resp)
+	require.Error(err)
+	require.Contains(err.Error(), "cannot find policy")
+
+	// Reusing the name of another role should fail
+	dup := mock.ACLRole()
+	dup.ID = ""
+	dup.Name = created.Name
+	dup.Policies = []*structs.ACLRolePolicyLink{{Name: p1.Name}}
+	req.Roles = []*structs.ACLRole{dup}
+	err = msgpackrpc.CallWithCodec(codec, "ACL.UpsertRoles", req, &resp)
+	require.Error(err)
+	require.Contains(err.Error(), "already exists")
+
+	// Updating a role which doesn't exist should fail
+	missing := mock.ACLRole()
+	missing.Policies = []*structs.ACLRolePolicyLink{{Name: p1.Name}}
+	req.Roles = []*structs.ACLRole{missing}
+	err = msgpackrpc.CallWithCodec(codec, "ACL.UpsertRoles", req, &resp)
+	require.Error(err)
+	require.Contains(err.Error(), "cannot find role")
+
+	// Client tokens cannot upsert roles
+	token := mock.ACLToken()
+	require.NoError(s1.fsm.State().UpsertACLTokens(1001, []*structs.ACLToken{token}))
+	req.AuthToken = token.SecretID
+	req.Roles = []*structs.ACLRole{dup}
+	err = msgpackrpc.CallWithCodec(codec, "ACL.UpsertRoles", req, &resp)
+	require.EqualError(err, structs.ErrPermissionDenied.Error())
+}
+
+func TestACLEndpoint_DeleteRoles(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	s1, root, cleanupS1 := TestACLServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	// Create the role
+	role := mock.ACLRole()
+	require.NoError(s1.fsm.State().UpsertACLRoles(1000, []*structs.ACLRole{role}))
+
+	// Delete it
+	req := &structs.ACLRoleDeleteRequest{
+		RoleIDs: []string{role.ID},
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			AuthToken: root.SecretID,
+		},
+	}
+	var resp structs.GenericResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "ACL.DeleteRoles", req, &resp))
+	require.NotEqual(uint64(0), resp.Index)
+
+	out, err := s1.fsm.State().ACLRoleByID(nil, role.ID)
+	require.NoError(err)
+	require.Nil(out)
+
+	// Deleting it again should fail
+	err = msgpackrpc.CallWithCodec(codec, "ACL.DeleteRoles", req, &resp)
+	require.Error(err)
+	require.Contains(err.Error(), "nonexistent roles")
+}
+
+func TestACLEndpoint_ListRoles(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	s1, root, cleanupS1 := TestACLServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	// Create the roles and a token linked to one of them
+	r1 := mock.ACLRole()
+	r2 := mock.ACLRole()
+	require.NoError(s1.fsm.State().UpsertACLRoles(1000, []*structs.ACLRole{r1, r2}))
+	token := mock.ACLToken()
+	token.Roles = []*structs.ACLTokenRoleLink{{ID: r1.ID, Name: r1.Name}}
+	require.NoError(s1.fsm.State().UpsertACLTokens(1001, []*structs.ACLToken{token}))
+
+	// Management tokens list all the roles
+	req := &structs.ACLRoleListRequest{
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			AuthToken: root.SecretID,
+		},
+	}
+	var resp structs.ACLRoleListResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "ACL.ListRoles", req, &resp))
+	require.Equal(uint64(1000), resp.Index)
+	require.Len(resp.Roles, 2)
+
+	// Client tokens only list their roles
+	req.AuthToken = token.SecretID
+	require.NoError(msgpackrpc.CallWithCodec(codec, "ACL.ListRoles", req, &resp))
+	require.Len(resp.Roles, 1)
+	require.Equal(r1.ID, resp.Roles[0].ID)
+
+	// Lookup by prefix
+	req.AuthToken = root.SecretID
+	req.Prefix = r2.ID[:8]
+	require.NoError(msgpackrpc.CallWithCodec(codec, "ACL.ListRoles", req, &resp))
+	require.Len(resp.Roles, 1)
+	require.Equal(r2.ID, resp.Roles[0].ID)
+}
+
+func TestACLEndpoint_GetRole(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	s1, root, cleanupS1 := TestACLServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	// Create the roles and a token linked to one of them
+	r1 := mock.ACLRole()
+	r2 := mock.ACLRole()
+	require.NoError(s1.fsm.State().UpsertACLRoles(1000, []*structs.ACLRole{r1, r2}))
+	token := mock.ACLToken()
+	token.Roles = []*structs.ACLTokenRoleLink{{ID: r1.ID, Name: r1.Name}}
+	require.NoError(s1.fsm.State().UpsertACLTokens(1001, []*structs.ACLToken{token}))
+
+	// Lookup the role by ID
+	req := &structs.ACLRoleSpecificRequest{
+		RoleID: r1.ID,
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			AuthToken: root.SecretID,
+		},
+	}
+	var resp structs.SingleACLRoleResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "ACL.GetRole", req, &resp))
+	require.Equal(uint64(1000), resp.Index)
+	require.Equal(r1, resp.Role)
+
+	// Lookup the role by name
+	byName := &structs.ACLRoleByNameRequest{
+		RoleName:     r2.Name,
+		QueryOptions: req.QueryOptions,
+	}
+	require.NoError(msgpackrpc.CallWithCodec(codec, "ACL.GetRoleByName", byName, &resp))
+	require.Equal(r2, resp.Role)
+
+	// Lookup a role which doesn't exist
+	req.RoleID = uuid.Generate()
+	require.NoError(msgpackrpc.CallWithCodec(codec, "ACL.GetRole", req, &resp))
+	require.Nil(resp.Role)
+
+	// Client tokens may get their roles only
+	req.AuthToken = token.SecretID
+	req.RoleID = r1.ID
+	require.NoError(msgpackrpc.CallWithCodec(codec, "ACL.GetRole", req, &resp))
+	require.Equal(r1, resp.Role)
+
+	req.RoleID = r2.ID
+	err := msgpackrpc.CallWithCodec(codec, "ACL.GetRole", req, &resp)
+	require.EqualError(err, structs.ErrPermissionDenied.Error())
+}
+
+func TestACLEndpoint_GetRolesByID(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	s1, _, cleanupS1 := TestACLServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	// Create the roles and a token linked to one of them
+	r1 := mock.ACLRole()
+	r2 := mock.ACLRole()
+	require.NoError(s1.fsm.State().UpsertACLRoles(1000, []*structs.ACLRole{r1, r2}))
+	token := mock.ACLToken()
+	token.Roles = []*structs.ACLTokenRoleLink{{ID: r1.ID, Name: r1.Name}}
+	require.NoError(s1.fsm.State().UpsertACLTokens(1001, []*structs.ACLToken{token}))
+
+	// The token may fetch its roles
+	req := &structs.ACLRoleSetRequest{
+		RoleIDs: []string{r1.ID},
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			AuthToken: token.SecretID,
+		},
+	}
+	var resp structs.ACLRoleSetResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "ACL.GetRolesByID", req, &resp))
+	require.Equal(uint64(1000), resp.Index)
+	require.Equal(map[string]*structs.ACLRole{r1.ID: r1}, resp.Roles)
+
+	// But not the other roles
+	req.RoleIDs = []string{r1.ID, r2.ID}
+	err := msgpackrpc.CallWithCodec(codec, "ACL.GetRolesByID", req, &resp)
+	require.EqualError(err, structs.ErrPermissionDenied.Error())
+}
+
+func TestACLEndpoint_UpsertTokens_Roles(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	s1, root, cleanupS1 := TestACLServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	// Create the role
+	role := mock.ACLRole()
+	require.NoError(s1.fsm.State().UpsertACLRoles(1000, []*structs.ACLRole{role}))
+
+	// Create a token linked to the role by name, twice
+	token := mock.ACLToken()
+	token.AccessorID = "" // Blank to create
+	token.Policies = nil
+	token.Roles = []*structs.ACLTokenRoleLink{{Name: role.Name}, {ID: role.ID}}
+	req := &structs.ACLTokenUpsertRequest{
+		Tokens: []*structs.ACLToken{token},
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			AuthToken: root.SecretID,
+		},
+	}
+	var resp structs.ACLTokenUpsertResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "ACL.UpsertTokens", req, &resp))
+	require.Equal([]*structs.ACLTokenRoleLink{{ID: role.ID, Name: role.Name}}, resp.Tokens[0].Roles)
+
+	// Linking a role which doesn't exist should fail
+	token = mock.ACLToken()
+	token.AccessorID = ""
+	token.Roles = []*structs.ACLTokenRoleLink{{Name: "unknown"}}
+	req.Tokens = []*structs.ACLToken{token}
+	err := msgpackrpc.CallWithCodec(codec, "ACL.UpsertTokens", req, &resp)
+	require.Error(err)
+	require.Contains(err.Error(), "cannot find role")
+}
+
+func TestACLEndpoint_GetPolicies_Roles(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	s1, _, cleanupS1 := TestACLServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	// Create a token granted a policy through a role
+	policy := mock.ACLPolicy()
+	other := mock.ACLPolicy()
+	require.NoError(s1.fsm.State().UpsertACLPolicies(1000, []*structs.ACLPolicy{policy, other}))
+	role := mock.ACLRole()
+	role.Policies = []*structs.ACLRolePolicyLink{{Name: policy.Name}}
+	require.NoError(s1.fsm.State().UpsertACLRoles(1001, []*structs.ACLRole{role}))
+	token := mock.ACLToken()
+	token.Policies = nil
+	token.Roles = []*structs.ACLTokenRoleLink{{ID: role.ID, Name: role.Name}}
+	require.NoError(s1.fsm.State().UpsertACLTokens(1002, []*structs.ACLToken{token}))
+
+	// The token may fetch the policy of its role
+	req := &structs.ACLPolicySetRequest{
+		Names: []string{policy.Name},
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			AuthToken: token.SecretID,
+		},
+	}
+	var resp structs.ACLPolicySetResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "ACL.GetPolicies", req, &resp))
+	require.Contains(resp.Policies, policy.Name)
+
+	// But not the other policies
+	req.Names = []string{other.Name}
+	err := msgpackrpc.CallWithCodec(codec, "ACL.GetPolicies", req, &resp)
+	require.EqualError(err, structs.ErrPermissionDenied.Error())
+}
+
+func TestACLEndpoint_UpsertAuthMethods(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	s1, root, cleanupS1 := TestACLServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	// Create the auth method
+	method := mock.ACLAuthMethod()
+	req := &structs.ACLAuthMethodUpsertRequest{
+		AuthMethods: []*structs.ACLAuthMethod{method},
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			AuthToken: root.SecretID,
+		},
+	}
+	var resp structs.ACLAuthMethodUpsertResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "ACL.UpsertAuthMethods", req, &resp))
+	require.NotEqual(uint64(0), resp.Index)
+	require.Len(resp.AuthMethods, 1)
+	out, err := s1.fsm.State().ACLAuthMethodByName(nil, method.Name)
+	require.NoError(err)
+	require.Equal(resp.AuthMethods[0], out)
+
+	// Invalid auth methods should fail
+	invalid := mock.ACLAuthMethod()
+	invalid.MaxTokenTTL = 0
+	req.AuthMethods = []*structs.ACLAuthMethod{invalid}
+	err = msgpackrpc.CallWithCodec(codec, "ACL.UpsertAuthMethods", req, &resp)
+	require.Error(err)
+	require.Contains(err.Error(), "max token TTL")
+
+	// Duplicate names should fail
+	req.AuthMethods = []*structs.ACLAuthMethod{method, method}
+	err = msgpackrpc.CallWithCodec(codec, "ACL.UpsertAuthMethods", req, &resp)
+	require.Error(err)
+	require.Contains(err.Error(), "duplicate name")
+
+	// Non-management tokens should be denied
+	token := mock.ACLToken()
+	require.NoError(s1.fsm.State().UpsertACLTokens(1000, []*structs.ACLToken{token}))
+	req.AuthMethods = []*structs.ACLAuthMethod{mock.ACLAuthMethod()}
+	req.AuthToken = token.SecretID
+	err = msgpackrpc.CallWithCodec(codec, "ACL.UpsertAuthMethods", req, &resp)
+	require.EqualError(err, structs.ErrPermissionDenied.Error())
+}
+
+func TestACLEndpoint_DeleteAuthMethods(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	s1, root, cleanupS1 := TestACLServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	// Create the auth method and a binding rule
+	method := mock.ACLAuthMethod()
+	require.NoError(s1.fsm.State().UpsertACLAuthMethods(1000, []*structs.ACLAuthMethod{method}))
+	rule := mock.ACLBindingRule(method.Name)
+	require.NoError(s1.fsm.State().UpsertACLBindingRules(1001, []*structs.ACLBindingRule{rule}))
+
+	// Delete the auth method
+	req := &structs.ACLAuthMethodDeleteRequest{
+		MethodNames: []string{method.Name},
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			AuthToken: root.SecretID,
+		},
+	}
+	var resp structs.GenericResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "ACL.DeleteAuthMethods", req, &resp))
+	require.NotEqual(uint64(0), resp.Index)
+
+	// The auth method and its binding rule should be deleted
+	out, err := s1.fsm.State().ACLAuthMethodByName(nil, method.Name)
+	require.NoError(err)
+	require.Nil(out)
+	outRule, err := s1.fsm.State().ACLBindingRuleByID(nil, rule.ID)
+	require.NoError(err)
+	require.Nil(outRule)
+
+	// Deleting an auth method which doesn't exist should fail
+	err = msgpackrpc.CallWithCodec(codec, "ACL.DeleteAuthMethods", req, &resp)
+	require.Error(err)
+	require.Contains(err.Error(), "nonexistent")
+}
+
+func TestACLEndpoint_ListAuthMethods(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	s1, root, cleanupS1 := TestACLServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	m1 := mock.ACLAuthMethod()
+	m2 := mock.ACLAuthMethod()
+	require.NoError(s1.fsm.State().UpsertACLAuthMethods(1000, []*structs.ACLAuthMethod{m1, m2}))
+
+	// Management tokens list all the auth methods
+	req := &structs.ACLAuthMethodListRequest{
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			AuthToken: root.SecretID,
+		},
+	}
+	var resp structs.ACLAuthMethodListResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "ACL.ListAuthMethods", req, &resp))
+	require.EqualValues(1000, resp.Index)
+	require.Len(resp.AuthMethods, 2)
+
+	// Listing auth methods requires no token, so that users can log in
+	req.AuthToken = ""
+	var resp2 structs.ACLAuthMethodListResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "ACL.ListAuthMethods", req, &resp2))
+	require.Len(resp2.AuthMethods, 2)
+	require.Equal(structs.ACLAuthMethodTypeJWT, resp2.AuthMethods[0].Type)
+}
+
+func TestACLEndpoint_GetAuthMethod(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	s1, root, cleanupS1 := TestACLServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	method := mock.ACLAuthMethod()
+	require.NoError(s1.fsm.State().UpsertACLAuthMethods(1000, []*structs.ACLAuthMethod{method}))
+
+	// Lookup the auth method
+	req := &structs.ACLAuthMethodSpecificRequest{
+		MethodName: method.Name,
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			AuthToken: root.SecretID,
+		},
+	}
+	var resp structs.SingleACLAuthMethodResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "ACL.GetAuthMethod", req, &resp))
+	require.EqualValues(1000, resp.Index)
+	require.Equal(method, resp.AuthMethod)
+
+	// Lookup a missing auth method
+	req.MethodName = "missing"
+	require.NoError(msgpackrpc.CallWithCodec(codec, "ACL.GetAuthMethod", req, &resp))
+	require.Nil(resp.AuthMethod)
+
+	// Auth methods hold client secrets, so anonymous lookups are denied
+	req.MethodName = method.Name
+	req.AuthToken = ""
+	err := msgpackrpc.CallWithCodec(codec, "ACL.GetAuthMethod", req, &resp)
+	require.EqualError(err, structs.ErrPermissionDenied.Error())
+
+	// Lookup a set of auth methods
+	setReq := &structs.ACLAuthMethodSetRequest{
+		MethodNames: []string{method.Name, "missing"},
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			AuthToken: root.SecretID,
+		},
+	}
+	var setResp structs.ACLAuthMethodSetResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "ACL.GetAuthMethods", setReq, &setResp))
+	require.Len(setResp.AuthMethods, 1)
+	require.Equal(method, setResp.AuthMethods[method.Name])
+}
+
+func TestACLEndpoint_UpsertBindingRules(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	s1, root, cleanupS1 := TestACLServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	method := mock.ACLAuthMethod()
+	require.NoError(s1.fsm.State().UpsertACLAuthMethods(1000, []*structs.ACLAuthMethod{method}))
+
+	// Create the binding rule
+	rule := mock.ACLBindingRule(method.Name)
+	rule.ID = "" // Blank to create
+	req := &structs.ACLBindingRuleUpsertRequest{
+		BindingRules: []*structs.ACLBindingRule{rule},
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			AuthToken: root.SecretID,
+		},
+	}
+	var resp structs.ACLBindingRuleUpsertResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "ACL.UpsertBindingRules", req, &resp))
+	require.NotEqual(uint64(0), resp.Index)
+
+	created := resp.BindingRules[0]
+	require.NotEmpty(created.ID)
+	out, err := s1.fsm.State().ACLBindingRuleByID(nil, created.ID)
+	require.NoError(err)
+	require.Equal(created, out)
+
+	// Update the binding rule
+	update := mock.ACLBindingRule(method.Name)
+	update.ID = created.ID
+	update.BindName = "ops"
+	req.BindingRules = []*structs.ACLBindingRule{update}
+	require.NoError(msgpackrpc.CallWithCodec(codec, "ACL.UpsertBindingRules", req, &resp))
+	out, err = s1.fsm.State().ACLBindingRuleByID(nil, created.ID)
+	require.NoError(err)
+	require.Equal("ops", out.BindName)
+	require.Equal(created.CreateIndex, out.CreateIndex)
+
+	// Binding rules of an auth method which doesn't exist should fail
+	invalid := mock.ACLBindingRule("missing")
+	invalid.ID = ""
+	req.BindingRules = []*structs.ACLBindingRule{invalid}
+	err = msgpackrpc.CallWithCodec(codec, "ACL.UpsertBindingRules", req, &resp)
+	require.Error(err)
+	require.Contains(err.Error(), "cannot find auth method")
+
+	// Invalid selectors should fail
+	invalid = mock.ACLBindingRule(method.Name)
+	invalid.ID = ""
+	invalid.Selector = "value.team = platform"
+	req.BindingRules = []*structs.ACLBindingRule{invalid}
+	err = msgpackrpc.CallWithCodec(codec, "ACL.UpsertBindingRules", req, &resp)
+	require.Error(err)
+	require.Contains(err.Error(), "invalid selector")
+
+	// Updating a binding rule which doesn't exist should fail
+	req.BindingRules = []*structs.ACLBindingRule{mock.ACLBindingRule(method.Name)}
+	err = msgpackrpc.CallWithCodec(codec, "ACL.UpsertBindingRules", req, &resp)
+	require.Error(err)
+	require.Contains(err.Error(), "cannot find binding rule")
+}
+
+func TestACLEndpoint_DeleteBindingRules(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	s1, root, cleanupS1 := TestACLServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	method := mock.ACLAuthMethod()
+	require.NoError(s1.fsm.State().UpsertACLAuthMethods(1000, []*structs.ACLAuthMethod{method}))
+	rule := mock.ACLBindingRule(method.Name)
+	require.NoError(s1.fsm.State().UpsertACLBindingRules(1001, []*structs.ACLBindingRule{rule}))
+
+	// Delete the binding rule
+	req := &structs.ACLBindingRuleDeleteRequest{
+		BindingRuleIDs: []string{rule.ID},
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			AuthToken: root.SecretID,
+		},
+	}
+	var resp structs.GenericResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "ACL.DeleteBindingRules", req, &resp))
+	require.NotEqual(uint64(0), resp.Index)
+
+	out, err := s1.fsm.State().ACLBindingRuleByID(nil, rule.ID)
+	require.NoError(err)
+	require.Nil(out)
+
+	// Deleting a binding rule which doesn't exist should fail
+	err = msgpackrpc.CallWithCodec(codec, "ACL.DeleteBindingRules", req, &resp)
+	require.Error(err)
+	require.Contains(err.Error(), "nonexistent")
+}
+
+func TestACLEndpoint_GetBindingRules(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	s1, root, cleanupS1 := TestACLServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	method := mock.ACLAuthMethod()
+	require.NoError(s1.fsm.State().UpsertACLAuthMethods(1000, []*structs.ACLAuthMethod{method}))
+	r1 := mock.ACLBindingRule(method.Name)
+	r2 := mock.ACLBindingRule(method.Name)
+	require.NoError(s1.fsm.State().UpsertACLBindingRules(1001, []*structs.ACLBindingRule{r1, r2}))
+
+	// List the binding rules
+	listReq := &structs.ACLBindingRuleListRequest{
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			AuthToken: root.SecretID,
+		},
+	}
+	var listResp structs.ACLBindingRuleListResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "ACL.ListBindingRules", listReq, &listResp))
+	require.EqualValues(1001, listResp.Index)
+	require.Len(listResp.BindingRules, 2)
+
+	// Lookup a binding rule
+	req := &structs.ACLBindingRuleSpecificRequest{
+		BindingRuleID: r1.ID,
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			AuthToken: root.SecretID,
+		},
+	}
+	var resp structs.SingleACLBindingRuleResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "ACL.GetBindingRule", req, &resp))
+	require.Equal(r1, resp.BindingRule)
+
+	// Lookup a set of binding rules
+	setReq := &structs.ACLBindingRuleSetRequest{
+		BindingRuleIDs: []string{r1.ID, r2.ID},
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			AuthToken: root.SecretID,
+		},
+	}
+	var setResp structs.ACLBindingRuleSetResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "ACL.GetBindingRules", setReq, &setResp))
+	require.Len(setResp.BindingRules, 2)
+
+	// Non-management tokens should be denied
+	token := mock.ACLToken()
+	require.NoError(s1.fsm.State().UpsertACLTokens(1002, []*structs.ACLToken{token}))
+	listReq.AuthToken = token.SecretID
+	err := msgpackrpc.CallWithCodec(codec, "ACL.ListBindingRules", listReq, &listResp)
+	require.EqualError(err, structs.ErrPermissionDenied.Error())
+}
+
+func TestACLEndpoint_Login(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	s1, _, cleanupS1 := TestACLServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	issuer := auth.NewTestIssuer(t)
+	defer issuer.Close()
+
+	// Create a JWT auth method trusting the issuer, binding engineers to a
+	// policy and the platform team to a role
+	method := mock.ACLAuthMethod()
+	method.Config.JWKSURL = issuer.JWKSURL()
+	method.Config.BoundIssuer = []string{issuer.URL()}
+	method.Config.ClaimMappings = map[string]string{"team": "team"}
+	require.NoError(s1.fsm.State().UpsertACLAuthMethods(1000, []*structs.ACLAuthMethod{method}))
+
+	policy := mock.ACLPolicy()
+	require.NoError(s1.fsm.State().UpsertACLPolicies(1001, []*structs.ACLPolicy{policy}))
+	role := mock.ACLRole()
+	role.Policies = []*structs.ACLRolePolicyLink{{Name: policy.Name}}
+	require.NoError(s1.fsm.State().UpsertACLRoles(1002, []*structs.ACLRole{role}))
+
+	r1 := mock.ACLBindingRule(method.Name)
+	r1.BindName = policy.Name
+	r2 := mock.ACLBindingRule(method.Name)
+	r2.Selector = `value.team == "platform"`
+	r2.BindType = structs.ACLBindingRuleBindTypeRole
+	r2.BindName = role.Name
+	r3 := mock.ACLBindingRule(method.Name)
+	r3.Selector = `value.team == "platform"`
+	r3.BindType = structs.ACLBindingRuleBindTypeRole
+	r3.BindName = "missing"
+	require.NoError(s1.fsm.State().UpsertACLBindingRules(1003, []*structs.ACLBindingRule{r1, r2, r3}))
+
+	// Log in with a JWT matching both binding rules
+	req := &structs.ACLLoginRequest{
+		AuthMethodName: method.Name,
+		LoginToken: issuer.SignJWT(map[string]interface{}{
+			"aud":    "nomad",
+			"team":   "platform",
+			"groups": []string{"engineering"},
+		}),
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	var resp structs.ACLLoginResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "ACL.Login", req, &resp))
+	require.NotEqual(uint64(0), resp.Index)
+
+	token := resp.Token
+	require.NotNil(token)
+	require.Equal(structs.ACLClientToken, token.Type)
+	require.False(token.Global)
+	require.Equal([]string{policy.Name}, token.Policies)
+	require.Equal([]*structs.ACLTokenRoleLink{{ID: role.ID, Name: role.Name}}, token.Roles)
+	require.True(token.HasExpirationTime())
+	require.Equal(token.CreateTime.Add(method.MaxTokenTTL), *token.ExpirationTime)
+
+	// The minted token is usable
+	acl, err := s1.ResolveToken(token.SecretID)
+	require.NoError(err)
+	require.NotNil(acl)
+	require.False(acl.IsManagement())
+
+	// Identities matching no binding rule can't log in
+	req.LoginToken = issuer.SignJWT(map[string]interface{}{"aud": "nomad", "team": "web"})
+	err = msgpackrpc.CallWithCodec(codec, "ACL.Login", req, &resp)
+	require.Error(err)
+	require.Contains(err.Error(), "no binding rules")
+
+	// JWTs for another audience are rejected
+	req.LoginToken = issuer.SignJWT(map[string]interface{}{"aud": "vault", "team": "platform"})
+	err = msgpackrpc.CallWithCodec(codec, "ACL.Login", req, &resp)
+	require.Error(err)
+	require.Contains(err.Error(), "audience")
+
+	// JWTs signed by another issuer are rejected
+	other := auth.NewTestIssuer(t)
+	defer other.Close()
+	req.LoginToken = other.SignJWT(map[string]interface{}{"iss": issuer.URL(), "aud": "nomad", "team": "platform"})
+	err = msgpackrpc.CallWithCodec(codec, "ACL.Login", req, &resp)
+	require.Error(err)
+	require.Contains(err.Error(), "signature")
+
+	// Logging in with a missing auth method fails
+	req.AuthMethodName = "missing"
+	err = msgpackrpc.CallWithCodec(codec, "ACL.Login", req, &resp)
+	require.Error(err)
+	require.Contains(err.Error(), "cannot find auth method")
+}
+
+func TestACLEndpoint_Login_Discovery(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	s1, _, cleanupS1 := TestACLServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	issuer := auth.NewTestIssuer(t)
+	defer issuer.Close()
+
+	// The JWKS and issuer of the auth method are discovered
+	method := mock.ACLAuthMethod()
+	method.TokenLocality = structs.ACLAuthMethodTokenLocalityGlobal
+	method.Config.JWKSURL = ""
+	method.Config.OIDCDiscoveryURL = issuer.URL()
+	require.NoError(s1.fsm.State().UpsertACLAuthMethods(1000, []*structs.ACLAuthMethod{method}))
+	rule := mock.ACLBindingRule(method.Name)
+	rule.Selector = ""
+	require.NoError(s1.fsm.State().UpsertACLBindingRules(1001, []*structs.ACLBindingRule{rule}))
+
+	req := &structs.ACLLoginRequest{
+		AuthMethodName: method.Name,
+		LoginToken:     issuer.SignJWT(map[string]interface{}{"aud": "nomad"}),
+		WriteRequest:   structs.WriteRequest{Region: "global"},
+	}
+	var resp structs.ACLLoginResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "ACL.Login", req, &resp))
+	require.True(resp.Token.Global)
+	require.Equal([]string{rule.BindName}, resp.Token.Policies)
+
+	// JWTs of another issuer are rejected
+	req.LoginToken = issuer.SignJWT(map[string]interface{}{"aud": "nomad", "iss": "https://evil.example.com"})
+	err := msgpackrpc.CallWithCodec(codec, "ACL.Login", req, &resp)
+	require.Error(err)
+	require.Contains(err.Error(), "issuer")
+}
+
+func TestACLEndpoint_OIDCAuth(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	s1, _, cleanupS1 := TestACLServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	issuer := auth.NewTestIssuer(t)
+	defer issuer.Close()
+
+	redirectURI := "http://localhost:4649/oidc/callback"
+	method := mock.ACLAuthMethod()
+	method.Type = structs.ACLAuthMethodTypeOIDC
+	method.Config = &structs.ACLAuthMethodConfig{
+		OIDCDiscoveryURL:    issuer.URL(),
+		OIDCClientID:        auth.TestIssuerClientID,
+		OIDCClientSecret:    auth.TestIssuerClientSecret,
+		OIDCScopes:          []string{"groups"},
+		AllowedRedirectURIs: []string{redirectURI},
+		ListClaimMappings:   map[string]string{"groups": "groups"},
+	}
+	require.NoError(s1.fsm.State().UpsertACLAuthMethods(1000, []*structs.ACLAuthMethod{method}))
+	rule := mock.ACLBindingRule(method.Name)
+	require.NoError(s1.fsm.State().UpsertACLBindingRules(1001, []*structs.ACLBindingRule{rule}))
+
+	// Get the URL of the provider
+	urlReq := &structs.ACLOIDCAuthURLRequest{
+		AuthMethodName: method.Name,
+		RedirectURI:    redirectURI,
+		State:          "st",
+		Nonce:          "n",
+		QueryOptions:   structs.QueryOptions{Region: "global"},
+	}
+	var urlResp structs.ACLOIDCAuthURLResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "ACL.OIDCAuthURL", urlReq, &urlResp))
+	u, err := url.Parse(urlResp.AuthURL)
+	require.NoError(err)
+	require.Equal(issuer.URL()+"/authorize", u.Scheme+"://"+u.Host+u.Path)
+	require.Equal(auth.TestIssuerClientID, u.Query().Get("client_id"))
+	require.Equal(redirectURI, u.Query().Get("redirect_uri"))
+	require.Equal("st", u.Query().Get("state"))
+	require.Equal("n", u.Query().Get("nonce"))
+	require.Equal("openid groups", u.Query().Get("scope"))
+
+	// Redirect URIs must be allowed
+	urlReq.RedirectURI = "http://evil.example.com/callback"
+	err = msgpackrpc.CallWithCodec(codec, "ACL.OIDCAuthURL", urlReq, &urlResp)
+	require.Error(err)
+	require.Contains(err.Error(), "not allowed")
+
+	// JWT logins are not supported by OIDC auth methods
+	loginReq := &structs.ACLLoginRequest{
+		AuthMethodName: method.Name,
+		LoginToken:     issuer.SignJWT(nil),
+		WriteRequest:   structs.WriteRequest{Region: "global"},
+	}
+	var resp structs.ACLLoginResponse
+	err = msgpackrpc.CallWithCodec(codec, "ACL.Login", loginReq, &resp)
+	require.Error(err)
+	require.Contains(err.Error(), "not JWT")
+
+	// Complete the login with the authorization code of the provider
+	claims := map[string]interface{}{"nonce": "n", "groups": []string{"engineering"}}
+	req := &structs.ACLOIDCCompleteAuthRequest{
+		AuthMethodName: method.Name,
+		RedirectURI:    redirectURI,
+		Code:           issuer.IssueCode(claims),
+		Nonce:          "n",
+		WriteRequest:   structs.WriteRequest{Region: "global"},
+	}
+	require.NoError(msgpackrpc.CallWithCodec(codec, "ACL.OIDCCompleteAuth", req, &resp))
+	require.Equal([]string{rule.BindName}, resp.Token.Policies)
+	require.True(resp.Token.HasExpirationTime())
+
+	// The nonce of the ID token must match
+	req.Code = issuer.IssueCode(claims)
+	req.Nonce = "other"
+	err = msgpackrpc.CallWithCodec(codec, "ACL.OIDCCompleteAuth", req, &resp)
+	require.Error(err)
+	require.Contains(err.Error(), "nonce")
+
+	// Codes can only be used once
+	req.Nonce = "n"
+	err = msgpackrpc.CallWithCodec(codec, "ACL.OIDCCompleteAuth", req, &resp)
+	require.Error(err)
+	require.Contains(err.Error(), "invalid_grant")
+}
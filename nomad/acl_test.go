@@ -2,6 +2,7 @@ package nomad
 
 import (
 	"testing"
+	"time"
 
 	lru "github.com/hashicorp/golang-lru"
 	"github.com/hashicorp/nomad/acl"
@@ -11,6 +12,7 @@ import (
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/testutil"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestResolveACLToken(t *testing.T) {
@@ -107,3 +109,137 @@ func TestResolveACLToken_LeaderToken(t *testing.T) {
 		assert.True(token.IsManagement())
 	}
 }
+
+func TestResolveACLToken_Expired(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	// Create mock state store and cache
+	state := state.TestStateStore(t)
+	cache, err := lru.New2Q(16)
+	require.NoError(err)
+
+	// Create an expired and an unexpired token
+	past := time.Now().UTC().Add(-time.Hour)
+	future := time.Now().UTC().Add(time.Hour)
+	expired := mock.ACLToken()
+	expired.Type = structs.ACLManagementToken
+	expired.Policies = nil
+	expired.ExpirationTime = &past
+	valid := mock.ACLToken()
+	valid.Type = structs.ACLManagementToken
+	valid.Policies = nil
+	valid.ExpirationTime = &future
+	require.NoError(state.UpsertACLTokens(100, []*structs.ACLToken{expired, valid}))
+
+	snap, err := state.Snapshot()
+	require.NoError(err)
+
+	// Resolving the expired token should fail
+	aclObj, err := resolveTokenFromSnapshotCache(snap, cache, expired.SecretID)
+	require.Equal(structs.ErrTokenExpired, err)
+	require.Nil(aclObj)
+
+	// Resolving the unexpired token should succeed
+	aclObj, err = resolveTokenFromSnapshotCache(snap, cache, valid.SecretID)
+	require.NoError(err)
+	require.True(aclObj.IsManagement())
+}
+
+func TestResolveACLToken_Roles(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	// Create mock state store and cache
+	state := state.TestStateStore(t)
+	cache, err := lru.New2Q(16)
+	require.NoError(err)
+
+	// Create a token granted a policy directly and another through a role
+	policy := mock.ACLPolicy()
+	policy.Rules = `node { policy = "read" }`
+	policy2 := mock.ACLPolicy()
+	policy2.Rules = `agent { policy = "read" }`
+	require.NoError(state.UpsertACLPolicies(100, []*structs.ACLPolicy{policy, policy2}))
+	role := mock.ACLRole()
+	role.Policies = []*structs.ACLRolePolicyLink{{Name: policy2.Name}}
+	require.NoError(state.UpsertACLRoles(110, []*structs.ACLRole{role}))
+	token := mock.ACLToken()
+	token.Policies = []string{policy.Name}
+	token.Roles = []*structs.ACLTokenRoleLink{{ID: role.ID, Name: role.Name}}
+	require.NoError(state.UpsertACLTokens(120, []*structs.ACLToken{token}))
+
+	snap, err := state.Snapshot()
+	require.NoError(err)
+
+	// Both policies should be granted
+	aclObj, err := resolveTokenFromSnapshotCache(snap, cache, token.SecretID)
+	require.NoError(err)
+	require.True(aclObj.AllowNodeRead())
+	require.True(aclObj.AllowAgentRead())
+
+	// Deleting the role should revoke its policies
+	require.NoError(state.DeleteACLRoles(130, []string{role.ID}))
+	snap, err = state.Snapshot()
+	require.NoError(err)
+	aclObj, err = resolveTokenFromSnapshotCache(snap, cache, token.SecretID)
+	require.NoError(err)
+	require.True(aclObj.AllowNodeRead())
+	require.False(aclObj.AllowAgentRead())
+}
+
+func TestResolveACLToken_WorkloadIdentity(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	// Create mock state store and cache
+	state := state.TestStateStore(t)
+	cache, err := lru.New2Q(16)
+	require.NoError(err)
+
+	alloc := mock.Alloc()
+	require.NoError(state.UpsertAllocs(100, []*structs.Allocation{alloc}))
+	claims := structs.NewWorkloadIdentityClaims(alloc, "web", time.Now())
+
+	// Identities can't be resolved before the key exists
+	key, err := structs.NewWorkloadIdentityKey()
+	require.NoError(err)
+	token, err := structs.SignWorkloadIdentity(key, claims)
+	require.NoError(err)
+
+	snap, err := state.Snapshot()
+	require.NoError(err)
+	_, err = resolveTokenFromSnapshotCache(snap, cache, token)
+	require.Equal(structs.ErrTokenNotFound, err)
+
+	require.NoError(state.UpsertWorkloadIdentityKey(110, key))
+	snap, err = state.Snapshot()
+	require.NoError(err)
+
+	// The identity may read the job of its allocation only
+	aclObj, err := resolveTokenFromSnapshotCache(snap, cache, token)
+	require.NoError(err)
+	require.True(aclObj.AllowJobRead(alloc.Namespace, alloc.JobID))
+	require.False(aclObj.AllowJobRead(alloc.Namespace, "other"))
+	require.False(aclObj.AllowJobRead("other", alloc.JobID))
+	require.False(aclObj.AllowNsOp(alloc.Namespace, acl.NamespaceCapabilityReadJob))
+	require.False(aclObj.AllowNsOp(alloc.Namespace, acl.NamespaceCapabilitySubmitJob))
+	require.False(aclObj.AllowNodeRead())
+
+	// An identity signed with another key is rejected
+	other, err := structs.NewWorkloadIdentityKey()
+	require.NoError(err)
+	forged, err := structs.SignWorkloadIdentity(other, claims)
+	require.NoError(err)
+	_, err = resolveTokenFromSnapshotCache(snap, cache, forged)
+	require.Equal(structs.ErrTokenNotFound, err)
+
+	// The identity expires with its allocation
+	stopped := alloc.Copy()
+	stopped.DesiredStatus = structs.AllocDesiredStatusStop
+	require.NoError(state.UpsertAllocs(120, []*structs.Allocation{stopped}))
+	snap, err = state.Snapshot()
+	require.NoError(err)
+	_, err = resolveTokenFromSnapshotCache(snap, cache, token)
+	require.Equal(structs.ErrTokenExpired, err)
+}
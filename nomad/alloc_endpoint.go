@@ -88,6 +88,7 @@ func (a *Alloc) GetAlloc(args *structs.AllocSpecificRequest,
 
 	// Check namespace read-job permissions before performing blocking query.
 	allowNsOp := acl.NamespaceValidator(acl.NamespaceCapabilityReadJob)
+	var nodeID string
 	aclObj, err := a.srv.ResolveToken(args.AuthToken)
 	if err != nil {
 		// If ResolveToken had an unexpected error return that
@@ -109,6 +110,7 @@ func (a *Alloc) GetAlloc(args *structs.AllocSpecificRequest,
 		if node == nil {
 			return structs.ErrTokenNotFound
 		}
+		nodeID = node.ID
 	}
 
 	// Setup the blocking query
@@ -130,6 +132,7 @@ func (a *Alloc) GetAlloc(args *structs.AllocSpecificRequest,
 					return structs.NewErrUnknownAllocation(args.AllocID)
 				}
 
+				reply.Alloc = scrubAllocIdentities(out, nodeID)
 				reply.Index = out.ModifyIndex
 			} else {
 				// Use the last index that affected the allocs table
@@ -155,6 +158,19 @@ func (a *Alloc) GetAllocs(args *structs.AllocsGetRequest,
 	}
 	defer metrics.MeasureSince([]string{"nomad", "alloc", "get_allocs"}, time.Now())
 
+	// Only the node running the allocations receives the workload identities
+	// of their tasks
+	var nodeID string
+	if args.AuthToken != "" {
+		node, err := a.srv.fsm.State().NodeBySecretID(nil, args.AuthToken)
+		if err != nil {
+			return err
+		}
+		if node != nil {
+			nodeID = node.ID
+		}
+	}
+
 	allocs := make([]*structs.Allocation, len(args.AllocIDs))
 
 	// Setup the blocking query. We wait for at least one of the requested
@@ -179,7 +195,7 @@ func (a *Alloc) GetAllocs(args *structs.AllocsGetRequest,
 				}
 
 				// Store the pointer
-				allocs[i] = out
+				allocs[i] = scrubAllocIdentities(out, nodeID)
 
 				// Check if we have passed the minimum index
 				if out.ModifyIndex > args.QueryOptions.MinQueryIndex {
@@ -300,3 +316,14 @@ func (a *Alloc) UpdateDesiredTransition(args *structs.AllocUpdateDesiredTransiti
 	reply.Index = index
 	return nil
 }
+
+// scrubAllocIdentities returns the allocation without the workload identities
+// of its tasks, unless it runs on the node with the given ID.
+func scrubAllocIdentities(alloc *structs.Allocation, nodeID string) *structs.Allocation {
+	if alloc.SignedIdentities == nil || (nodeID != "" && alloc.NodeID == nodeID) {
+		return alloc
+	}
+	alloc = alloc.CopySkipJob()
+	alloc.SignedIdentities = nil
+	return alloc
+}
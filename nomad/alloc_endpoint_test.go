@@ -473,6 +473,79 @@ func TestAllocEndpoint_GetAllocs(t *testing.T) {
 	}
 }
 
+func TestAllocEndpoint_SignedIdentities(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	s1, root, cleanupS1 := TestACLServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	state := s1.fsm.State()
+
+	// Create an allocation on a node and one on another node
+	node := mock.Node()
+	require.Nil(state.UpsertNode(997, node))
+
+	alloc := mock.Alloc()
+	alloc.NodeID = node.ID
+	alloc.SignedIdentities = map[string]string{"web": "identity"}
+	alloc2 := mock.Alloc()
+	alloc2.SignedIdentities = map[string]string{"web": "identity2"}
+	require.Nil(state.UpsertJobSummary(998, mock.JobSummary(alloc.JobID)))
+	require.Nil(state.UpsertJobSummary(999, mock.JobSummary(alloc2.JobID)))
+	require.Nil(state.UpsertAllocs(1000, []*structs.Allocation{alloc, alloc2}))
+
+	// The node only receives the identities of its own allocations
+	get := &structs.AllocsGetRequest{
+		AllocIDs: []string{alloc.ID, alloc2.ID},
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			AuthToken: node.SecretID,
+		},
+	}
+	var resp structs.AllocsGetResponse
+	require.Nil(msgpackrpc.CallWithCodec(codec, "Alloc.GetAllocs", get, &resp))
+	require.Len(resp.Allocs, 2)
+	require.Equal(alloc.SignedIdentities, resp.Allocs[0].SignedIdentities)
+	require.Nil(resp.Allocs[1].SignedIdentities)
+
+	getAlloc := &structs.AllocSpecificRequest{
+		AllocID: alloc.ID,
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			AuthToken: node.SecretID,
+		},
+	}
+	var allocResp structs.SingleAllocResponse
+	require.Nil(msgpackrpc.CallWithCodec(codec, "Alloc.GetAlloc", getAlloc, &allocResp))
+	require.Equal(alloc.SignedIdentities, allocResp.Alloc.SignedIdentities)
+
+	getAlloc.AllocID = alloc2.ID
+	var allocResp2 structs.SingleAllocResponse
+	require.Nil(msgpackrpc.CallWithCodec(codec, "Alloc.GetAlloc", getAlloc, &allocResp2))
+	require.Nil(allocResp2.Alloc.SignedIdentities)
+
+	// ACL tokens never receive the identities
+	get.AuthToken = root.SecretID
+	var resp2 structs.AllocsGetResponse
+	require.Nil(msgpackrpc.CallWithCodec(codec, "Alloc.GetAllocs", get, &resp2))
+	require.Len(resp2.Allocs, 2)
+	require.Nil(resp2.Allocs[0].SignedIdentities)
+	require.Nil(resp2.Allocs[1].SignedIdentities)
+
+	getAlloc.AllocID = alloc.ID
+	getAlloc.AuthToken = root.SecretID
+	var allocResp3 structs.SingleAllocResponse
+	require.Nil(msgpackrpc.CallWithCodec(codec, "Alloc.GetAlloc", getAlloc, &allocResp3))
+	require.Nil(allocResp3.Alloc.SignedIdentities)
+
+	// The identities are kept in the state store
+	out, err := state.AllocByID(nil, alloc.ID)
+	require.Nil(err)
+	require.Equal(alloc.SignedIdentities, out.SignedIdentities)
+}
+
 func TestAllocEndpoint_GetAllocs_Blocking(t *testing.T) {
 	t.Parallel()
 
@@ -695,6 +695,22 @@ func (b *BlockedEvals) Flush() {
 	b.system = newSystemEvals()
 }
 
+// Evals returns the evaluations being tracked, whether they are captured by
+// computed node classes or have escaped them.
+func (b *BlockedEvals) Evals() []*structs.Evaluation {
+	b.l.RLock()
+	defer b.l.RUnlock()
+
+	evals := make([]*structs.Evaluation, 0, len(b.captured)+len(b.escaped))
+	for _, wrapped := range b.captured {
+		evals = append(evals, wrapped.eval)
+	}
+	for _, wrapped := range b.escaped {
+		evals = append(evals, wrapped.eval)
+	}
+	return evals
+}
+
 // Stats is used to query the state of the blocked eval tracker.
 func (b *BlockedEvals) Stats() *BlockedStats {
 	// Allocate a new stats struct
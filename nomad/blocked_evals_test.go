@@ -671,3 +671,26 @@ func TestBlockedEvals_SystemDisableFlush(t *testing.T) {
 	require.Empty(t, blocked.system.byJob)
 	require.Empty(t, blocked.system.byNode)
 }
+
+func TestBlockedEvals_Evals(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	blocked, _ := testBlockedEvals(t)
+
+	// Create a captured and an escaped eval and add them to the blocked tracker
+	captured := mock.Eval()
+	captured.Status = structs.EvalStatusBlocked
+	escaped := mock.Eval()
+	escaped.Status = structs.EvalStatusBlocked
+	escaped.EscapedComputedClass = true
+	blocked.Block(captured)
+	blocked.Block(escaped)
+
+	evals := blocked.Evals()
+	require.Len(evals, 2)
+	require.ElementsMatch([]*structs.Evaluation{captured, escaped}, evals)
+
+	// Flushing the tracker should stop tracking them
+	blocked.Flush()
+	require.Empty(blocked.Evals())
+}
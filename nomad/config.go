@@ -184,6 +184,10 @@ type Config struct {
 	// for GC. This gives users some time to view terminal deployments.
 	DeploymentGCThreshold time.Duration
 
+	// ACLTokenExpirationGCInterval is how often we dispatch a job to GC
+	// expired ACL tokens.
+	ACLTokenExpirationGCInterval time.Duration
+
 	// EvalNackTimeout controls how long we allow a sub-scheduler to
 	// work on an evaluation before we consider it failed and Nack it.
 	// This allows that evaluation to be handed to another sub-scheduler
@@ -220,6 +224,25 @@ type Config struct {
 	// additional delay is selected from this range randomly.
 	EvalFailedFollowupDelayRange time.Duration
 
+	// EvalFairShare enables dequeuing ready evaluations round-robin across
+	// namespaces so that a burst of evaluations in one namespace does not
+	// starve the other namespaces.
+	EvalFairShare bool
+
+	// EvalNamespaceWeights is the number of evaluations dequeued from a
+	// namespace on each of its turns when EvalFairShare is enabled.
+	// Namespaces without a weight have a weight of one.
+	EvalNamespaceWeights map[string]int
+
+	// MaxInflightPlans is the maximum number of plans touching disjoint
+	// sets of nodes the leader applies through Raft concurrently. A value
+	// of one applies plans one at a time.
+	MaxInflightPlans int
+
+	// EventBufferSize is the number of Raft indexes whose events are
+	// buffered for the subscribers of the event stream.
+	EventBufferSize int
+
 	// MinHeartbeatTTL is the minimum time between heartbeats.
 	// This is used as a floor to prevent excessive updates.
 	MinHeartbeatTTL time.Duration
@@ -350,12 +373,14 @@ func DefaultConfig() *Config {
 		NodeGCThreshold:                  24 * time.Hour,
 		DeploymentGCInterval:             5 * time.Minute,
 		DeploymentGCThreshold:            1 * time.Hour,
+		ACLTokenExpirationGCInterval:     5 * time.Minute,
 		EvalNackTimeout:                  60 * time.Second,
 		EvalDeliveryLimit:                3,
 		EvalNackInitialReenqueueDelay:    1 * time.Second,
 		EvalNackSubsequentReenqueueDelay: 20 * time.Second,
 		EvalFailedFollowupBaselineDelay:  1 * time.Minute,
 		EvalFailedFollowupDelayRange:     5 * time.Minute,
+		MaxInflightPlans:                 8,
 		MinHeartbeatTTL:                  10 * time.Second,
 		MaxHeartbeatsPerSecond:           50.0,
 		HeartbeatGrace:                   10 * time.Second,
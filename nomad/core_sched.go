@@ -50,6 +50,10 @@ func (c *CoreScheduler) Process(eval *structs.Evaluation) error {
 		return c.jobGC(eval)
 	case structs.CoreJobDeploymentGC:
 		return c.deploymentGC(eval)
+	case structs.CoreJobLocalTokenExpiredGC:
+		return c.expiredACLTokenGC(eval, false)
+	case structs.CoreJobGlobalTokenExpiredGC:
+		return c.expiredACLTokenGC(eval, true)
 	case structs.CoreJobForceGC:
 		return c.forceGC(eval)
 	default:
@@ -68,6 +72,12 @@ func (c *CoreScheduler) forceGC(eval *structs.Evaluation) error {
 	if err := c.deploymentGC(eval); err != nil {
 		return err
 	}
+	if err := c.expiredACLTokenGC(eval, false); err != nil {
+		return err
+	}
+	if err := c.expiredACLTokenGC(eval, true); err != nil {
+		return err
+	}
 
 	// Node GC must occur after the others to ensure the allocations are
 	// cleared.
@@ -703,3 +713,54 @@ func allocGCEligible(a *structs.Allocation, job *structs.Job, gcTime time.Time,
 
 	return timeDiff > interval.Nanoseconds()
 }
+
+// expiredACLTokenGC is used to garbage collect the expired local or global
+// ACL tokens. Global tokens are only collected in the authoritative region.
+func (c *CoreScheduler) expiredACLTokenGC(eval *structs.Evaluation, global bool) error {
+	if !c.srv.config.ACLEnabled {
+		return nil
+	}
+	if global && c.srv.config.Region != c.srv.config.AuthoritativeRegion {
+		return nil
+	}
+
+	ws := memdb.NewWatchSet()
+	iter, err := c.snap.ACLTokensByExpires(ws, global)
+	if err != nil {
+		return err
+	}
+
+	// The tokens are ordered by expiration time, so stop at the first one
+	// which hasn't expired.
+	now := time.Now().UTC()
+	var gcTokens []string
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		token := raw.(*structs.ACLToken)
+		if !token.IsExpired(now) {
+			break
+		}
+		gcTokens = append(gcTokens, token.AccessorID)
+	}
+
+	// Fast-path the nothing case
+	if len(gcTokens) == 0 {
+		return nil
+	}
+	c.logger.Debug("expired ACL token GC found eligible tokens", "tokens", len(gcTokens), "global", global)
+
+	for _, ids := range partitionAll(maxIdsPerReap, gcTokens) {
+		req := structs.ACLTokenDeleteRequest{
+			AccessorIDs: ids,
+			WriteRequest: structs.WriteRequest{
+				Region:    c.srv.config.Region,
+				AuthToken: eval.LeaderACL,
+			},
+		}
+		var resp structs.GenericResponse
+		if err := c.srv.RPC("ACL.DeleteTokens", &req, &resp); err != nil {
+			c.logger.Error("expired ACL token reap failed", "error", err)
+			return err
+		}
+	}
+	return nil
+}
@@ -1836,6 +1836,54 @@ func TestCoreScheduler_DeploymentGC_Force(t *testing.T) {
 	}
 }
 
+func TestCoreScheduler_ExpiredACLTokenGC(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	s1, _, cleanupS1 := TestACLServer(t, nil)
+	defer cleanupS1()
+	testutil.WaitForLeader(t, s1.RPC)
+
+	// Insert expired and unexpired local and global tokens
+	past := time.Now().UTC().Add(-time.Hour)
+	future := time.Now().UTC().Add(time.Hour)
+	expiredLocal, expiredGlobal := mock.ACLToken(), mock.ACLToken()
+	expiredLocal.ExpirationTime = &past
+	expiredGlobal.ExpirationTime = &past
+	expiredGlobal.Global = true
+	validLocal, validGlobal := mock.ACLToken(), mock.ACLToken()
+	validLocal.ExpirationTime = &future
+	validGlobal.ExpirationTime = &future
+	validGlobal.Global = true
+	noExpiry := mock.ACLToken()
+
+	state := s1.fsm.State()
+	tokens := []*structs.ACLToken{expiredLocal, expiredGlobal, validLocal, validGlobal, noExpiry}
+	require.NoError(state.UpsertACLTokens(1000, tokens))
+
+	for _, job := range []string{structs.CoreJobLocalTokenExpiredGC, structs.CoreJobGlobalTokenExpiredGC} {
+		// Create a core scheduler
+		snap, err := state.Snapshot()
+		require.NoError(err)
+		core := NewCoreScheduler(s1, snap)
+
+		// Attempt the GC
+		gc := s1.coreJobEval(job, 2000)
+		require.NoError(core.Process(gc))
+	}
+
+	// Only the expired tokens should be gone
+	for _, token := range tokens {
+		out, err := state.ACLTokenByAccessorID(nil, token.AccessorID)
+		require.NoError(err)
+		if token.IsExpired(time.Now().UTC()) {
+			require.Nil(out, "expired token %q", token.AccessorID)
+		} else {
+			require.NotNil(out, "unexpired token %q", token.AccessorID)
+		}
+	}
+}
+
 func TestCoreScheduler_PartitionEvalReap(t *testing.T) {
 	t.Parallel()
 
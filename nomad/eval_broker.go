@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"math/rand"
+	"sort"
 	"sync"
 	"time"
 
@@ -65,6 +66,24 @@ type EvalBroker struct {
 	// ready tracks the ready jobs by scheduler in a priority queue
 	ready map[string]PendingEvaluations
 
+	// fairShare enables dequeuing ready evaluations round-robin across
+	// namespaces instead of strictly by priority, so that a burst of
+	// evaluations in one namespace can not starve the other namespaces.
+	// namespaceWeights is the number of evaluations dequeued from a
+	// namespace on each of its turns.
+	fairShare        bool
+	namespaceWeights map[string]int
+
+	// readyByNamespace tracks the ready jobs by scheduler and namespace in a
+	// priority queue when fair share is enabled. It is used instead of ready.
+	readyByNamespace map[string]map[string]PendingEvaluations
+
+	// fairNamespace is the namespace whose turn it is to dequeue and
+	// fairCredits the number of evaluations it may still dequeue before the
+	// next namespace's turn.
+	fairNamespace string
+	fairCredits   int
+
 	// unack is a map of evalID to an un-acknowledged evaluation
 	unack map[string]*unackEval
 
@@ -136,6 +155,7 @@ func NewEvalBroker(timeout, initialNackDelay, subsequentNackDelay time.Duration,
 		jobEvals:             make(map[structs.NamespacedID]string),
 		blocked:              make(map[structs.NamespacedID]PendingEvaluations),
 		ready:                make(map[string]PendingEvaluations),
+		readyByNamespace:     make(map[string]map[string]PendingEvaluations),
 		unack:                make(map[string]*unackEval),
 		waiting:              make(map[string]chan struct{}),
 		requeue:              make(map[string]*structs.Evaluation),
@@ -146,10 +166,22 @@ func NewEvalBroker(timeout, initialNackDelay, subsequentNackDelay time.Duration,
 		delayedEvalsUpdateCh: make(chan struct{}, 1),
 	}
 	b.stats.ByScheduler = make(map[string]*SchedulerStats)
+	b.stats.ByNamespace = make(map[string]*NamespaceStats)
 
 	return b, nil
 }
 
+// SetFairShare is used to control if ready evaluations are dequeued
+// round-robin across namespaces. Each namespace dequeues as many evaluations
+// as its weight on each of its turns, and namespaces without a weight have a
+// weight of one. It must be called before the broker is enabled.
+func (b *EvalBroker) SetFairShare(enabled bool, weights map[string]int) {
+	b.l.Lock()
+	defer b.l.Unlock()
+	b.fairShare = enabled
+	b.namespaceWeights = weights
+}
+
 // Enabled is used to check if the broker is enabled.
 func (b *EvalBroker) Enabled() bool {
 	b.l.RLock()
@@ -296,18 +328,29 @@ func (b *EvalBroker) enqueueLocked(eval *structs.Evaluation, queue string) {
 		return
 	}
 
-	// Find the pending by scheduler class
-	pending, ok := b.ready[queue]
-	if !ok {
-		pending = make([]*structs.Evaluation, 0, 16)
-		if _, ok := b.waiting[queue]; !ok {
-			b.waiting[queue] = make(chan struct{}, 1)
-		}
+	if _, ok := b.waiting[queue]; !ok {
+		b.waiting[queue] = make(chan struct{}, 1)
 	}
 
-	// Push onto the heap
-	heap.Push(&pending, eval)
-	b.ready[queue] = pending
+	// Find the pending by scheduler class, and namespace when fair share is
+	// enabled, and push onto the heap
+	if b.fairShare {
+		byNamespace, ok := b.readyByNamespace[queue]
+		if !ok {
+			byNamespace = make(map[string]PendingEvaluations)
+			b.readyByNamespace[queue] = byNamespace
+		}
+		pending := byNamespace[eval.Namespace]
+		heap.Push(&pending, eval)
+		byNamespace[eval.Namespace] = pending
+	} else {
+		pending, ok := b.ready[queue]
+		if !ok {
+			pending = make([]*structs.Evaluation, 0, 16)
+		}
+		heap.Push(&pending, eval)
+		b.ready[queue] = pending
+	}
 
 	// Update the stats
 	b.stats.TotalReady += 1
@@ -317,6 +360,7 @@ func (b *EvalBroker) enqueueLocked(eval *structs.Evaluation, queue string) {
 		b.stats.ByScheduler[queue] = bySched
 	}
 	bySched.Ready += 1
+	b.namespaceStats(eval.Namespace).Ready += 1
 
 	// Unblock any blocked dequeues
 	select {
@@ -372,6 +416,10 @@ func (b *EvalBroker) scanForSchedulers(schedulers []string) (*structs.Evaluation
 		return nil, "", fmt.Errorf("eval broker disabled")
 	}
 
+	if b.fairShare {
+		return b.scanForSchedulersFair(schedulers)
+	}
+
 	// Scan for eligible work
 	var eligibleSched []string
 	var eligiblePriority int
@@ -419,6 +467,87 @@ func (b *EvalBroker) scanForSchedulers(schedulers []string) (*structs.Evaluation
 	}
 }
 
+// scanForSchedulersFair scans for work on any of the schedulers when fair
+// share is enabled. The namespaces with work take turns, and the highest
+// priority work of the namespace whose turn it is is dequeued first. This may
+// return nothing if there is no work waiting.
+func (b *EvalBroker) scanForSchedulersFair(schedulers []string) (*structs.Evaluation, string, error) {
+	// Find the namespaces with eligible work
+	eligibleNamespaces := make(map[string]struct{})
+	for _, sched := range schedulers {
+		for ns, pending := range b.readyByNamespace[sched] {
+			if len(pending) != 0 {
+				eligibleNamespaces[ns] = struct{}{}
+			}
+		}
+	}
+	if len(eligibleNamespaces) == 0 {
+		return nil, "", nil
+	}
+	namespace := b.nextFairNamespace(eligibleNamespaces)
+
+	// Scan for eligible work in the namespace
+	var eligibleSched []string
+	var eligiblePriority int
+	for _, sched := range schedulers {
+		ready := b.readyByNamespace[sched][namespace].Peek()
+		if ready == nil {
+			continue
+		}
+
+		// Add to eligible if equal or greater priority
+		if len(eligibleSched) == 0 || ready.Priority > eligiblePriority {
+			eligibleSched = []string{sched}
+			eligiblePriority = ready.Priority
+		} else if eligiblePriority == ready.Priority {
+			eligibleSched = append(eligibleSched, sched)
+		}
+	}
+
+	// Pick a random scheduler amongst those with the highest priority work so
+	// that we fairly distribute work.
+	sched := eligibleSched[rand.Intn(len(eligibleSched))]
+	return b.dequeueForNamespace(sched, namespace)
+}
+
+// nextFairNamespace returns the namespace to dequeue from amongst the
+// namespaces with eligible work. The current namespace keeps its turn until
+// it has used its weight, after which the turn passes to the next namespace
+// in lexical order. This assumes locks are held.
+func (b *EvalBroker) nextFairNamespace(eligible map[string]struct{}) string {
+	if _, ok := eligible[b.fairNamespace]; ok && b.fairCredits > 0 {
+		b.fairCredits -= 1
+		return b.fairNamespace
+	}
+
+	namespaces := make([]string, 0, len(eligible))
+	for ns := range eligible {
+		namespaces = append(namespaces, ns)
+	}
+	sort.Strings(namespaces)
+
+	// Find the namespace after the current one, wrapping around
+	i := sort.SearchStrings(namespaces, b.fairNamespace)
+	if i < len(namespaces) && namespaces[i] == b.fairNamespace {
+		i++
+	}
+	if i == len(namespaces) {
+		i = 0
+	}
+
+	b.fairNamespace = namespaces[i]
+	b.fairCredits = b.namespaceWeight(b.fairNamespace) - 1
+	return b.fairNamespace
+}
+
+// namespaceWeight returns the fair share weight of the namespace.
+func (b *EvalBroker) namespaceWeight(namespace string) int {
+	if weight := b.namespaceWeights[namespace]; weight > 0 {
+		return weight
+	}
+	return 1
+}
+
 // dequeueForSched is used to dequeue the next work item for a given scheduler.
 // This assumes locks are held and that this scheduler has work
 func (b *EvalBroker) dequeueForSched(sched string) (*structs.Evaluation, string, error) {
@@ -426,8 +555,27 @@ func (b *EvalBroker) dequeueForSched(sched string) (*structs.Evaluation, string,
 	pending := b.ready[sched]
 	raw := heap.Pop(&pending)
 	b.ready[sched] = pending
-	eval := raw.(*structs.Evaluation)
+	return b.deliverLocked(sched, raw.(*structs.Evaluation))
+}
 
+// dequeueForNamespace is used to dequeue the next work item for a given
+// scheduler and namespace when fair share is enabled. This assumes locks are
+// held and that this scheduler has work in the namespace
+func (b *EvalBroker) dequeueForNamespace(sched, namespace string) (*structs.Evaluation, string, error) {
+	byNamespace := b.readyByNamespace[sched]
+	pending := byNamespace[namespace]
+	raw := heap.Pop(&pending)
+	if len(pending) != 0 {
+		byNamespace[namespace] = pending
+	} else {
+		delete(byNamespace, namespace)
+	}
+	return b.deliverLocked(sched, raw.(*structs.Evaluation))
+}
+
+// deliverLocked tracks a dequeued evaluation as unacknowledged and returns it
+// along with its token. This assumes locks are held
+func (b *EvalBroker) deliverLocked(sched string, eval *structs.Evaluation) (*structs.Evaluation, string, error) {
 	// Generate a UUID for the token
 	token := uuid.Generate()
 
@@ -452,6 +600,9 @@ func (b *EvalBroker) dequeueForSched(sched string) (*structs.Evaluation, string,
 	bySched := b.stats.ByScheduler[sched]
 	bySched.Ready -= 1
 	bySched.Unacked += 1
+	byNamespace := b.namespaceStats(eval.Namespace)
+	byNamespace.Ready -= 1
+	byNamespace.Unacked += 1
 
 	return eval, token, nil
 }
@@ -559,6 +710,7 @@ func (b *EvalBroker) Ack(evalID, token string) error {
 	}
 	bySched := b.stats.ByScheduler[queue]
 	bySched.Unacked -= 1
+	b.namespaceStats(unack.Eval.Namespace).Unacked -= 1
 
 	// Cleanup
 	delete(b.unack, evalID)
@@ -619,6 +771,7 @@ func (b *EvalBroker) Nack(evalID, token string) error {
 	b.stats.TotalUnacked -= 1
 	bySched := b.stats.ByScheduler[unack.Eval.Type]
 	bySched.Unacked -= 1
+	b.namespaceStats(unack.Eval.Namespace).Unacked -= 1
 
 	// Check if we've hit the delivery limit, and re-enqueue
 	// in the failedQueue
@@ -720,10 +873,14 @@ func (b *EvalBroker) flush() {
 	b.stats.TotalBlocked = 0
 	b.stats.TotalWaiting = 0
 	b.stats.ByScheduler = make(map[string]*SchedulerStats)
+	b.stats.ByNamespace = make(map[string]*NamespaceStats)
 	b.evals = make(map[string]int)
 	b.jobEvals = make(map[structs.NamespacedID]string)
 	b.blocked = make(map[structs.NamespacedID]PendingEvaluations)
 	b.ready = make(map[string]PendingEvaluations)
+	b.readyByNamespace = make(map[string]map[string]PendingEvaluations)
+	b.fairNamespace = ""
+	b.fairCredits = 0
 	b.unack = make(map[string]*unackEval)
 	b.timeWait = make(map[string]*time.Timer)
 	b.delayHeap = delayheap.NewDelayHeap()
@@ -804,6 +961,7 @@ func (b *EvalBroker) Stats() *BrokerStats {
 	// Allocate a new stats struct
 	stats := new(BrokerStats)
 	stats.ByScheduler = make(map[string]*SchedulerStats)
+	stats.ByNamespace = make(map[string]*NamespaceStats)
 
 	b.l.RLock()
 	defer b.l.RUnlock()
@@ -818,9 +976,25 @@ func (b *EvalBroker) Stats() *BrokerStats {
 		*subStatCopy = *subStat
 		stats.ByScheduler[sched] = subStatCopy
 	}
+	for ns, subStat := range b.stats.ByNamespace {
+		subStatCopy := new(NamespaceStats)
+		*subStatCopy = *subStat
+		stats.ByNamespace[ns] = subStatCopy
+	}
 	return stats
 }
 
+// namespaceStats returns the stats of the namespace, creating them if
+// needed. This assumes locks are held
+func (b *EvalBroker) namespaceStats(namespace string) *NamespaceStats {
+	byNamespace, ok := b.stats.ByNamespace[namespace]
+	if !ok {
+		byNamespace = &NamespaceStats{}
+		b.stats.ByNamespace[namespace] = byNamespace
+	}
+	return byNamespace
+}
+
 // EmitStats is used to export metrics about the broker while enabled
 func (b *EvalBroker) EmitStats(period time.Duration, stopCh <-chan struct{}) {
 	for {
@@ -835,6 +1009,11 @@ func (b *EvalBroker) EmitStats(period time.Duration, stopCh <-chan struct{}) {
 				metrics.SetGauge([]string{"nomad", "broker", sched, "ready"}, float32(schedStats.Ready))
 				metrics.SetGauge([]string{"nomad", "broker", sched, "unacked"}, float32(schedStats.Unacked))
 			}
+			for ns, nsStats := range stats.ByNamespace {
+				labels := []metrics.Label{{Name: "namespace", Value: ns}}
+				metrics.SetGaugeWithLabels([]string{"nomad", "broker", "namespace", "ready"}, float32(nsStats.Ready), labels)
+				metrics.SetGaugeWithLabels([]string{"nomad", "broker", "namespace", "unacked"}, float32(nsStats.Unacked), labels)
+			}
 
 		case <-stopCh:
 			return
@@ -849,6 +1028,7 @@ type BrokerStats struct {
 	TotalBlocked int
 	TotalWaiting int
 	ByScheduler  map[string]*SchedulerStats
+	ByNamespace  map[string]*NamespaceStats
 }
 
 // SchedulerStats returns the stats per scheduler
@@ -857,6 +1037,12 @@ type SchedulerStats struct {
 	Unacked int
 }
 
+// NamespaceStats returns the stats per namespace
+type NamespaceStats struct {
+	Ready   int
+	Unacked int
+}
+
 // Len is for the sorting interface
 func (p PendingEvaluations) Len() int {
 	return len(p)
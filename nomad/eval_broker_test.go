@@ -47,6 +47,7 @@ func testBrokerFromConfig(t *testing.T, c *Config) *EvalBroker {
 	if err != nil {
 		t.Fatalf("err: %v", err)
 	}
+	b.SetFairShare(c.EvalFairShare, c.EvalNamespaceWeights)
 
 	return b
 }
@@ -869,6 +870,91 @@ func TestEvalBroker_Dequeue_Fairness(t *testing.T) {
 	}
 }
 
+func TestEvalBroker_Dequeue_NamespaceFairShare(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	config := testBrokerConfig()
+	config.EvalFairShare = true
+	b := testBrokerFromConfig(t, config)
+	b.SetEnabled(true)
+
+	// Enqueue a burst of high priority evals in one namespace and a few low
+	// priority evals in another
+	for i := 0; i < 10; i++ {
+		eval := mock.Eval()
+		eval.Namespace = "burst"
+		eval.Priority = 90
+		b.Enqueue(eval)
+	}
+	for i := 0; i < 2; i++ {
+		eval := mock.Eval()
+		eval.Namespace = "other"
+		eval.Priority = 10
+		eval.Type = structs.JobTypeBatch
+		b.Enqueue(eval)
+	}
+
+	stats := b.Stats()
+	require.Equal(10, stats.ByNamespace["burst"].Ready)
+	require.Equal(2, stats.ByNamespace["other"].Ready)
+
+	// The namespaces take turns until the other namespace is drained
+	expected := []string{"burst", "other", "burst", "other", "burst", "burst"}
+	var tokens []string
+	var evals []*structs.Evaluation
+	for i, ns := range expected {
+		out, token, err := b.Dequeue(defaultSched, time.Second)
+		require.NoError(err)
+		require.NotNil(out)
+		require.Equal(ns, out.Namespace, "dequeue %d", i)
+		evals = append(evals, out)
+		tokens = append(tokens, token)
+	}
+
+	stats = b.Stats()
+	require.Equal(6, stats.ByNamespace["burst"].Ready)
+	require.Equal(4, stats.ByNamespace["burst"].Unacked)
+	require.Equal(0, stats.ByNamespace["other"].Ready)
+	require.Equal(2, stats.ByNamespace["other"].Unacked)
+
+	for i, eval := range evals {
+		require.NoError(b.Ack(eval.ID, tokens[i]))
+	}
+
+	stats = b.Stats()
+	require.Equal(0, stats.ByNamespace["burst"].Unacked)
+	require.Equal(0, stats.ByNamespace["other"].Unacked)
+}
+
+func TestEvalBroker_Dequeue_NamespaceFairShare_Weights(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	config := testBrokerConfig()
+	config.EvalFairShare = true
+	config.EvalNamespaceWeights = map[string]int{"heavy": 3}
+	b := testBrokerFromConfig(t, config)
+	b.SetEnabled(true)
+
+	for _, ns := range []string{"heavy", "light"} {
+		for i := 0; i < 6; i++ {
+			eval := mock.Eval()
+			eval.Namespace = ns
+			b.Enqueue(eval)
+		}
+	}
+
+	// The heavy namespace dequeues three evals on each of its turns
+	expected := []string{"heavy", "heavy", "heavy", "light", "heavy", "heavy", "heavy", "light", "light"}
+	for i, ns := range expected {
+		out, _, err := b.Dequeue(defaultSched, time.Second)
+		require.NoError(err)
+		require.NotNil(out)
+		require.Equal(ns, out.Namespace, "dequeue %d", i)
+	}
+}
+
 // Ensure we get unblocked
 func TestEvalBroker_Dequeue_Blocked(t *testing.T) {
 	t.Parallel()
@@ -2,6 +2,8 @@ package nomad
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	metrics "github.com/armon/go-metrics"
@@ -330,6 +332,74 @@ func (e *Eval) Reap(args *structs.EvalDeleteRequest,
 	return nil
 }
 
+// Delete is used by operators to delete evaluations, such as evaluations that
+// are stuck pending. The eval broker must be paused so that no scheduler is
+// processing the evaluations being deleted.
+func (e *Eval) Delete(args *structs.EvalDeleteRequest,
+	reply *structs.GenericResponse) error {
+	if done, err := e.srv.forward("Eval.Delete", args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "eval", "delete"}, time.Now())
+
+	// This action requires a management token
+	if aclObj, err := e.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if aclObj != nil && !aclObj.IsManagement() {
+		return structs.ErrPermissionDenied
+	}
+
+	if len(args.Evals) == 0 {
+		return structs.NewErrRPCCoded(400, "missing evaluations to delete")
+	}
+	if len(args.Allocs) != 0 {
+		return structs.NewErrRPCCoded(400, "allocations can not be deleted")
+	}
+	if e.srv.evalBroker.Enabled() {
+		return structs.NewErrRPCCoded(400, "eval broker must be paused to delete evaluations")
+	}
+
+	// Only delete evaluations without running allocations, as they would
+	// otherwise reference an evaluation that no longer exists
+	snap, err := e.srv.fsm.State().Snapshot()
+	if err != nil {
+		return err
+	}
+	for _, evalID := range args.Evals {
+		eval, err := snap.EvalByID(nil, evalID)
+		if err != nil {
+			return err
+		}
+		if eval == nil {
+			return structs.NewErrRPCCodedf(404, "evaluation %q not found", evalID)
+		}
+
+		allocs, err := snap.AllocsByEval(nil, evalID)
+		if err != nil {
+			return err
+		}
+		for _, alloc := range allocs {
+			if !alloc.TerminalStatus() {
+				return structs.NewErrRPCCodedf(400,
+					"evaluation %q has non-terminal allocation %q", evalID, alloc.ID)
+			}
+		}
+	}
+
+	// Update via Raft
+	resp, index, err := e.srv.raftApply(structs.EvalDeleteRequestType, args)
+	if err != nil {
+		return err
+	}
+	if err, ok := resp.(error); ok && err != nil {
+		return err
+	}
+
+	// Update the index
+	reply.Index = index
+	return nil
+}
+
 // List is used to get a list of the evaluations in the system
 func (e *Eval) List(args *structs.EvalListRequest,
 	reply *structs.EvalListResponse) error {
@@ -369,6 +439,9 @@ func (e *Eval) List(args *structs.EvalListRequest,
 					break
 				}
 				eval := raw.(*structs.Evaluation)
+				if args.ShouldBeFiltered(eval) {
+					continue
+				}
 				evals = append(evals, eval)
 			}
 			reply.Evaluations = evals
@@ -387,6 +460,69 @@ func (e *Eval) List(args *structs.EvalListRequest,
 	return e.srv.blockingRPC(&opts)
 }
 
+// Blocked is used to list the evaluations blocked waiting for cluster capacity
+func (e *Eval) Blocked(args *structs.EvalListRequest,
+	reply *structs.EvalBlockedResponse) error {
+	// The blocked evaluations are only tracked by the leader
+	args.AllowStale = false
+	if done, err := e.srv.forward("Eval.Blocked", args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "eval", "blocked"}, time.Now())
+
+	// Check for read-job permissions
+	if aclObj, err := e.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if aclObj != nil && !aclObj.AllowNsOp(args.RequestNamespace(), acl.NamespaceCapabilityReadJob) {
+		return structs.ErrPermissionDenied
+	}
+
+	snap, err := e.srv.fsm.State().Snapshot()
+	if err != nil {
+		return err
+	}
+
+	blocked := []*structs.BlockedEval{}
+	for _, eval := range e.srv.blockedEvals.Evals() {
+		if eval.Namespace != args.RequestNamespace() ||
+			!strings.HasPrefix(eval.ID, args.Prefix) ||
+			args.ShouldBeFiltered(eval) {
+			continue
+		}
+
+		// The placement failures are stored on the evaluation that created
+		// the blocked evaluation until the blocked evaluation is processed
+		var previous *structs.Evaluation
+		if len(eval.FailedTGAllocs) == 0 && eval.PreviousEval != "" {
+			previous, err = snap.EvalByID(nil, eval.PreviousEval)
+			if err != nil {
+				return err
+			}
+		}
+		blocked = append(blocked, structs.NewBlockedEval(eval, previous))
+	}
+
+	// List the evaluations that have been waiting the longest first
+	sort.Slice(blocked, func(i, j int) bool {
+		if blocked[i].CreateIndex != blocked[j].CreateIndex {
+			return blocked[i].CreateIndex < blocked[j].CreateIndex
+		}
+		return blocked[i].ID < blocked[j].ID
+	})
+	reply.BlockedEvals = blocked
+
+	// Use the last index that affected the evals table
+	index, err := snap.Index("evals")
+	if err != nil {
+		return err
+	}
+	reply.Index = index
+
+	// Set the query response
+	e.srv.setQueryMeta(&reply.QueryMeta)
+	return nil
+}
+
 // Allocations is used to list the allocations for an evaluation
 func (e *Eval) Allocations(args *structs.EvalSpecificRequest,
 	reply *structs.EvalAllocationsResponse) error {
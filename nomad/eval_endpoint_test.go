@@ -784,6 +784,277 @@ func TestEvalEndpoint_List_ACL(t *testing.T) {
 	}
 }
 
+func TestEvalEndpoint_Blocked(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	s1, cleanupS1 := TestServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	// Create an eval that failed to place its allocations and the blocked eval
+	// it created
+	prev := mock.Eval()
+	prev.FailedTGAllocs = map[string]*structs.AllocMetric{
+		"web": {
+			NodesEvaluated:     2,
+			DimensionExhausted: map[string]int{"memory": 2},
+		},
+	}
+	eval := mock.Eval()
+	eval.ID = "aaaaaaaa-3350-4b4b-d185-0e1992ed43e9"
+	eval.Status = structs.EvalStatusBlocked
+	eval.PreviousEval = prev.ID
+	eval.ClassEligibility = map[string]bool{"v1:123": true}
+	require.Nil(s1.fsm.State().UpsertEvals(1000, []*structs.Evaluation{prev, eval}))
+	s1.blockedEvals.Block(eval)
+
+	// Create a blocked eval in another namespace
+	other := mock.Eval()
+	other.Namespace = "other"
+	other.Status = structs.EvalStatusBlocked
+	s1.blockedEvals.Block(other)
+
+	get := &structs.EvalListRequest{
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			Namespace: structs.DefaultNamespace,
+		},
+	}
+	var resp structs.EvalBlockedResponse
+	require.Nil(msgpackrpc.CallWithCodec(codec, "Eval.Blocked", get, &resp))
+	require.EqualValues(1000, resp.Index)
+	require.Len(resp.BlockedEvals, 1)
+
+	blocked := resp.BlockedEvals[0]
+	require.Equal(eval.ID, blocked.ID)
+	require.Equal(prev.ID, blocked.PreviousEval)
+	require.Equal(eval.ClassEligibility, blocked.ClassEligibility)
+	require.Contains(blocked.FailedTGAllocs, "web")
+	require.Equal(2, blocked.FailedTGAllocs["web"].DimensionExhausted["memory"])
+
+	// Lookup the blocked evals by prefix
+	get.Prefix = "bbbb"
+	var resp2 structs.EvalBlockedResponse
+	require.Nil(msgpackrpc.CallWithCodec(codec, "Eval.Blocked", get, &resp2))
+	require.Empty(resp2.BlockedEvals)
+}
+
+func TestEvalEndpoint_Blocked_ACL(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	s1, root, cleanupS1 := TestACLServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	eval := mock.Eval()
+	eval.Status = structs.EvalStatusBlocked
+	state := s1.fsm.State()
+	require.Nil(state.UpsertEvals(1000, []*structs.Evaluation{eval}))
+	s1.blockedEvals.Block(eval)
+
+	// Create ACL tokens
+	validToken := mock.CreatePolicyAndToken(t, state, 1003, "test-valid",
+		mock.NamespacePolicy(structs.DefaultNamespace, "", []string{acl.NamespaceCapabilityReadJob}))
+	invalidToken := mock.CreatePolicyAndToken(t, state, 1001, "test-invalid",
+		mock.NamespacePolicy(structs.DefaultNamespace, "", []string{acl.NamespaceCapabilityListJobs}))
+
+	get := &structs.EvalListRequest{
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			Namespace: structs.DefaultNamespace,
+		},
+	}
+
+	// Try without a token and expect permission denied
+	{
+		var resp structs.EvalBlockedResponse
+		err := msgpackrpc.CallWithCodec(codec, "Eval.Blocked", get, &resp)
+		require.NotNil(err)
+		require.Contains(err.Error(), structs.ErrPermissionDenied.Error())
+	}
+
+	// Try with an invalid token and expect permission denied
+	{
+		get.AuthToken = invalidToken.SecretID
+		var resp structs.EvalBlockedResponse
+		err := msgpackrpc.CallWithCodec(codec, "Eval.Blocked", get, &resp)
+		require.NotNil(err)
+		require.Contains(err.Error(), structs.ErrPermissionDenied.Error())
+	}
+
+	// List blocked evals with a valid token
+	{
+		get.AuthToken = validToken.SecretID
+		var resp structs.EvalBlockedResponse
+		require.Nil(msgpackrpc.CallWithCodec(codec, "Eval.Blocked", get, &resp))
+		require.Len(resp.BlockedEvals, 1)
+	}
+
+	// List blocked evals with a root token
+	{
+		get.AuthToken = root.SecretID
+		var resp structs.EvalBlockedResponse
+		require.Nil(msgpackrpc.CallWithCodec(codec, "Eval.Blocked", get, &resp))
+		require.Len(resp.BlockedEvals, 1)
+	}
+}
+
+func TestEvalEndpoint_List_Filter(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	s1, cleanupS1 := TestServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	eval1 := mock.Eval()
+	eval2 := mock.Eval()
+	eval2.Status = structs.EvalStatusComplete
+	eval3 := mock.Eval()
+	eval3.JobID = eval1.JobID
+	require.Nil(s1.fsm.State().UpsertEvals(1000, []*structs.Evaluation{eval1, eval2, eval3}))
+
+	cases := []struct {
+		job, status string
+		expected    []*structs.Evaluation
+	}{
+		{"", "", []*structs.Evaluation{eval1, eval2, eval3}},
+		{eval1.JobID, "", []*structs.Evaluation{eval1, eval3}},
+		{"", structs.EvalStatusComplete, []*structs.Evaluation{eval2}},
+		{eval1.JobID, structs.EvalStatusComplete, nil},
+	}
+	for _, c := range cases {
+		get := &structs.EvalListRequest{
+			FilterJobID:      c.job,
+			FilterEvalStatus: c.status,
+			QueryOptions: structs.QueryOptions{
+				Region:    "global",
+				Namespace: structs.DefaultNamespace,
+			},
+		}
+		var resp structs.EvalListResponse
+		require.Nil(msgpackrpc.CallWithCodec(codec, "Eval.List", get, &resp))
+		require.ElementsMatch(c.expected, resp.Evaluations, "job %q status %q", c.job, c.status)
+	}
+}
+
+func TestEvalEndpoint_Delete(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	state := s1.fsm.State()
+
+	// Create a pending eval and an eval with a running allocation
+	eval1 := mock.Eval()
+	eval2 := mock.Eval()
+	alloc := mock.Alloc()
+	alloc.EvalID = eval2.ID
+	require.Nil(state.UpsertEvals(1000, []*structs.Evaluation{eval1, eval2}))
+	require.Nil(state.UpsertJobSummary(1001, mock.JobSummary(alloc.JobID)))
+	require.Nil(state.UpsertAllocs(1002, []*structs.Allocation{alloc}))
+
+	req := &structs.EvalDeleteRequest{
+		Evals:        []string{eval1.ID},
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+
+	// Deleting evals requires the eval broker to be paused
+	var resp structs.GenericResponse
+	err := msgpackrpc.CallWithCodec(codec, "Eval.Delete", req, &resp)
+	require.Error(err)
+	require.Contains(err.Error(), "eval broker must be paused")
+	s1.evalBroker.SetEnabled(false)
+
+	// Evals with non-terminal allocations can not be deleted
+	req.Evals = []string{eval1.ID, eval2.ID}
+	err = msgpackrpc.CallWithCodec(codec, "Eval.Delete", req, &resp)
+	require.Error(err)
+	require.Contains(err.Error(), "non-terminal allocation")
+
+	// Unknown evals can not be deleted
+	req.Evals = []string{uuid.Generate()}
+	err = msgpackrpc.CallWithCodec(codec, "Eval.Delete", req, &resp)
+	require.Error(err)
+	require.Contains(err.Error(), "not found")
+
+	// Delete the pending eval
+	req.Evals = []string{eval1.ID}
+	require.Nil(msgpackrpc.CallWithCodec(codec, "Eval.Delete", req, &resp))
+	require.NotZero(resp.Index)
+
+	out, err := state.EvalByID(nil, eval1.ID)
+	require.Nil(err)
+	require.Nil(out)
+	out, err = state.EvalByID(nil, eval2.ID)
+	require.Nil(err)
+	require.NotNil(out)
+}
+
+func TestEvalEndpoint_Delete_ACL(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	s1, root, cleanupS1 := TestACLServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	state := s1.fsm.State()
+	s1.evalBroker.SetEnabled(false)
+
+	eval := mock.Eval()
+	require.Nil(state.UpsertEvals(1000, []*structs.Evaluation{eval}))
+
+	// Create an ACL token with all the namespace capabilities
+	invalidToken := mock.CreatePolicyAndToken(t, state, 1001, "test-invalid",
+		mock.NamespacePolicy(structs.DefaultNamespace, "write", nil))
+
+	req := &structs.EvalDeleteRequest{
+		Evals:        []string{eval.ID},
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+
+	// Try without a token and expect permission denied
+	{
+		var resp structs.GenericResponse
+		err := msgpackrpc.CallWithCodec(codec, "Eval.Delete", req, &resp)
+		require.NotNil(err)
+		require.Contains(err.Error(), structs.ErrPermissionDenied.Error())
+	}
+
+	// Try with a non-management token and expect permission denied
+	{
+		req.AuthToken = invalidToken.SecretID
+		var resp structs.GenericResponse
+		err := msgpackrpc.CallWithCodec(codec, "Eval.Delete", req, &resp)
+		require.NotNil(err)
+		require.Contains(err.Error(), structs.ErrPermissionDenied.Error())
+	}
+
+	// Delete the eval with a root token
+	{
+		req.AuthToken = root.SecretID
+		var resp structs.GenericResponse
+		require.Nil(msgpackrpc.CallWithCodec(codec, "Eval.Delete", req, &resp))
+
+		out, err := state.EvalByID(nil, eval.ID)
+		require.Nil(err)
+		require.Nil(out)
+	}
+}
+
 func TestEvalEndpoint_List_Blocking(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,191 @@
+package nomad
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"time"
+
+	log "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/acl"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/nomad/stream"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/ugorji/go/codec"
+)
+
+const (
+	// eventStreamHeartbeat is the interval at which an empty message is sent
+	// to the subscribers of the event stream when no events are published, so
+	// that idle connections are kept open
+	eventStreamHeartbeat = 10 * time.Second
+
+	// eventStreamTokenCheck is the interval at which the token of an event
+	// stream is resolved again, so that the stream follows the changes of the
+	// policies of the token and ends once it is deleted or expires
+	eventStreamTokenCheck = 30 * time.Second
+)
+
+// Event endpoint is used to stream the events published by the FSM.
+type Event struct {
+	srv    *Server
+	logger log.Logger
+
+	// tokenCheck overrides eventStreamTokenCheck in tests
+	tokenCheck time.Duration
+}
+
+func (e *Event) register() {
+	e.srv.streamingRpcs.Register("Event.Stream", e.stream)
+}
+
+// stream streams the events matching the request as JSON encoded
+// structs.Events messages. The events of objects the token of the request can
+// not read are filtered out.
+func (e *Event) stream(conn io.ReadWriteCloser) {
+	defer conn.Close()
+
+	// Decode args
+	var args structs.EventStreamRequest
+	decoder := codec.NewDecoder(conn, structs.MsgpackHandle)
+	encoder := codec.NewEncoder(conn, structs.MsgpackHandle)
+
+	if err := decoder.Decode(&args); err != nil {
+		handleStreamResultError(err, helper.Int64ToPtr(500), encoder)
+		return
+	}
+
+	// Forward the request to a server of the requested region
+	if args.RequestRegion() != e.srv.Region() {
+		e.forwardRegion(conn, encoder, &args)
+		return
+	}
+
+	aclObj, err := e.srv.ResolveToken(args.AuthToken)
+	if err != nil {
+		handleStreamResultError(err, nil, encoder)
+		return
+	}
+
+	// The filter reads aclObj when the events are read, so that it follows
+	// the token being resolved again
+	var filter func(*structs.Event) bool
+	if aclObj != nil {
+		filter = func(event *structs.Event) bool {
+			return eventAllowed(aclObj, event)
+		}
+	}
+
+	sub, err := e.srv.eventBroker.Subscribe(&stream.SubscribeRequest{
+		Topics:    args.Topics,
+		Namespace: args.RequestNamespace(),
+		Index:     args.Index,
+		Filter:    filter,
+	})
+	if err != nil {
+		// The events after the index of the request were dropped
+		handleStreamResultError(err, helper.Int64ToPtr(400), encoder)
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// goroutine to detect remote side closing
+	go func() {
+		if _, err := conn.Read(nil); err != nil {
+			// One end of the pipe explicitly closed, exit
+			cancel()
+			return
+		}
+		select {
+		case <-ctx.Done():
+			return
+		}
+	}()
+
+	tokenCheck := eventStreamTokenCheck
+	if e.tokenCheck != 0 {
+		tokenCheck = e.tokenCheck
+	}
+	heartbeat := eventStreamHeartbeat
+	if tokenCheck < heartbeat {
+		heartbeat = tokenCheck
+	}
+	nextTokenCheck := time.Now().Add(tokenCheck)
+
+	var buf bytes.Buffer
+	jsonEncoder := codec.NewEncoder(&buf, structs.JsonHandle)
+	for {
+		nextCtx, nextCancel := context.WithTimeout(ctx, heartbeat)
+		events, err := sub.Next(nextCtx)
+		nextCancel()
+
+		switch {
+		case err == nil:
+		case err == context.DeadlineExceeded && ctx.Err() == nil:
+			// Send a heartbeat
+			events = &structs.Events{}
+		case ctx.Err() != nil:
+			// The subscriber has gone away
+			return
+		default:
+			handleStreamResultError(err, nil, encoder)
+			return
+		}
+
+		// Resolve the token again before sending the events once the check
+		// interval has elapsed
+		if aclObj != nil && !time.Now().Before(nextTokenCheck) {
+			aclObj, err = e.srv.ResolveToken(args.AuthToken)
+			if err == structs.ErrTokenNotFound || err == structs.ErrTokenExpired {
+				handleStreamResultError(structs.ErrPermissionDenied, helper.Int64ToPtr(403), encoder)
+				return
+			} else if err != nil {
+				handleStreamResultError(err, nil, encoder)
+				return
+			}
+			nextTokenCheck = time.Now().Add(tokenCheck)
+		}
+
+		buf.Reset()
+		if err := jsonEncoder.Encode(events); err != nil {
+			handleStreamResultError(err, helper.Int64ToPtr(500), encoder)
+			return
+		}
+		jsonEncoder.Reset(&buf)
+
+		resp := cstructs.StreamErrWrapper{
+			Payload: buf.Bytes(),
+		}
+		if err := encoder.Encode(resp); err != nil {
+			return
+		}
+		encoder.Reset(conn)
+	}
+}
+
+// forwardRegion bridges the event stream request to a server of the region of
+// the request.
+func (e *Event) forwardRegion(conn io.ReadWriteCloser, encoder *codec.Encoder, args *structs.EventStreamRequest) {
+	server, err := e.srv.findRegionServer(args.RequestRegion())
+	if err != nil {
+		handleStreamResultError(err, nil, encoder)
+		return
+	}
+
+	if err := e.srv.forwardStreamingRpc(server, "Event.Stream", args, conn); err != nil {
+		handleStreamResultError(err, nil, encoder)
+	}
+}
+
+// eventAllowed returns whether the ACL can read the object of the event.
+func eventAllowed(aclObj *acl.ACL, event *structs.Event) bool {
+	switch event.Topic {
+	case structs.TopicNode:
+		return aclObj.AllowNodeRead()
+	default:
+		return aclObj.AllowNsOp(event.Namespace, acl.NamespaceCapabilityReadJob)
+	}
+}
@@ -0,0 +1,246 @@
+package nomad
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	msgpackrpc "github.com/hashicorp/net-rpc-msgpackrpc"
+	"github.com/hashicorp/nomad/acl"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/stretchr/testify/require"
+	"github.com/ugorji/go/codec"
+)
+
+// testEventStream starts an event stream on the server and returns the
+// channels of the received events and errors.
+func testEventStream(t *testing.T, s *Server, req *structs.EventStreamRequest) (<-chan *structs.Events, <-chan error, func()) {
+	handler, err := s.StreamingRpcHandler("Event.Stream")
+	require.Nil(t, err)
+
+	// create pipe
+	p1, p2 := net.Pipe()
+
+	errCh := make(chan error, 1)
+	eventsCh := make(chan *structs.Events, 10)
+
+	go handler(p2)
+
+	// Start decoder
+	go func() {
+		decoder := codec.NewDecoder(p1, structs.MsgpackHandle)
+		for {
+			var msg cstructs.StreamErrWrapper
+			if err := decoder.Decode(&msg); err != nil {
+				if err == io.EOF || strings.Contains(err.Error(), "closed") {
+					return
+				}
+				errCh <- fmt.Errorf("error decoding: %v", err)
+				return
+			}
+			if msg.Error != nil {
+				errCh <- msg.Error
+				return
+			}
+
+			var events structs.Events
+			if err := codec.NewDecoderBytes(msg.Payload, structs.JsonHandle).Decode(&events); err != nil {
+				errCh <- fmt.Errorf("error decoding events: %v", err)
+				return
+			}
+			eventsCh <- &events
+		}
+	}()
+
+	// send request
+	encoder := codec.NewEncoder(p1, structs.MsgpackHandle)
+	require.Nil(t, encoder.Encode(req))
+
+	return eventsCh, errCh, func() {
+		p1.Close()
+		p2.Close()
+	}
+}
+
+func TestEventEndpoint_Stream(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	s, cleanupS := TestServer(t, nil)
+	defer cleanupS()
+	codec := rpcClient(t, s)
+	testutil.WaitForLeader(t, s.RPC)
+
+	// Register a job
+	job := mock.Job()
+	regReq := &structs.JobRegisterRequest{
+		Job: job,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+	var regResp structs.JobRegisterResponse
+	require.Nil(msgpackrpc.CallWithCodec(codec, "Job.Register", regReq, &regResp))
+
+	// Resume the stream before the job was registered
+	req := &structs.EventStreamRequest{
+		Topics: map[structs.Topic][]string{
+			structs.TopicJob: {job.ID},
+		},
+		Index: 1,
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+	eventsCh, errCh, cleanup := testEventStream(t, s, req)
+	defer cleanup()
+
+	select {
+	case err := <-errCh:
+		t.Fatal(err)
+	case events := <-eventsCh:
+		require.Equal(regResp.JobModifyIndex, events.Index)
+		require.Len(events.Events, 1)
+		event := events.Events[0]
+		require.Equal(structs.TopicJob, event.Topic)
+		require.Equal(structs.TypeJobRegistered, event.Type)
+		require.Equal(job.ID, event.Key)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout waiting for events")
+	}
+}
+
+func TestEventEndpoint_Stream_ACL(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	s, _, cleanupS := TestACLServer(t, nil)
+	defer cleanupS()
+	testutil.WaitForLeader(t, s.RPC)
+	state := s.fsm.State()
+
+	// Create a token that can read jobs but not nodes
+	policy := mock.NamespacePolicy(structs.DefaultNamespace, "", []string{acl.NamespaceCapabilityReadJob})
+	token := mock.CreatePolicyAndToken(t, state, 1001, "job-read", policy)
+
+	// Apply a node and a job through Raft so that their events are published
+	node := mock.Node()
+	_, _, err := s.raftApply(structs.NodeRegisterRequestType, &structs.NodeRegisterRequest{Node: node})
+	require.Nil(err)
+
+	job := mock.Job()
+	_, index, err := s.raftApply(structs.JobRegisterRequestType, &structs.JobRegisterRequest{
+		Job: job,
+		WriteRequest: structs.WriteRequest{
+			Namespace: job.Namespace,
+		},
+	})
+	require.Nil(err)
+
+	req := &structs.EventStreamRequest{
+		Topics: map[structs.Topic][]string{
+			structs.TopicNode: {"*"},
+			structs.TopicJob:  {"*"},
+		},
+		Index: 1,
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			Namespace: job.Namespace,
+			AuthToken: token.SecretID,
+		},
+	}
+	eventsCh, errCh, cleanup := testEventStream(t, s, req)
+	defer cleanup()
+
+	// Only the job event is streamed
+	select {
+	case err := <-errCh:
+		t.Fatal(err)
+	case events := <-eventsCh:
+		require.Equal(index, events.Index)
+		require.Len(events.Events, 1)
+		require.Equal(structs.TopicJob, events.Events[0].Topic)
+		require.Equal(job.ID, events.Events[0].Key)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout waiting for events")
+	}
+
+	// An invalid token is rejected
+	req.AuthToken = uuid.Generate()
+	_, errCh, cleanupInvalid := testEventStream(t, s, req)
+	defer cleanupInvalid()
+
+	select {
+	case err := <-errCh:
+		require.Contains(err.Error(), structs.ErrTokenNotFound.Error())
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout waiting for error")
+	}
+}
+
+func TestEventEndpoint_Stream_ACL_TokenDeleted(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	s, _, cleanupS := TestACLServer(t, nil)
+	defer cleanupS()
+	testutil.WaitForLeader(t, s.RPC)
+	state := s.fsm.State()
+
+	// Resolve the token of the streams again frequently
+	s.staticEndpoints.Event.tokenCheck = 50 * time.Millisecond
+
+	policy := mock.NamespacePolicy(structs.DefaultNamespace, "", []string{acl.NamespaceCapabilityReadJob})
+	token := mock.CreatePolicyAndToken(t, state, 1001, "job-read", policy)
+
+	job := mock.Job()
+	_, index, err := s.raftApply(structs.JobRegisterRequestType, &structs.JobRegisterRequest{
+		Job: job,
+		WriteRequest: structs.WriteRequest{
+			Namespace: job.Namespace,
+		},
+	})
+	require.Nil(err)
+
+	req := &structs.EventStreamRequest{
+		Topics: map[structs.Topic][]string{
+			structs.TopicJob: {"*"},
+		},
+		Index: 1,
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			Namespace: job.Namespace,
+			AuthToken: token.SecretID,
+		},
+	}
+	eventsCh, errCh, cleanup := testEventStream(t, s, req)
+	defer cleanup()
+
+	select {
+	case err := <-errCh:
+		t.Fatal(err)
+	case events := <-eventsCh:
+		require.Equal(index, events.Index)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout waiting for events")
+	}
+
+	// Deleting the token ends the stream
+	require.Nil(state.DeleteACLTokens(index+1, []string{token.AccessorID}))
+
+	select {
+	case err := <-errCh:
+		require.Contains(err.Error(), structs.ErrPermissionDenied.Error())
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout waiting for error")
+	}
+}
@@ -12,6 +12,7 @@ import (
 	memdb "github.com/hashicorp/go-memdb"
 	"github.com/hashicorp/nomad/helper/uuid"
 	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/stream"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/scheduler"
 	"github.com/hashicorp/raft"
@@ -45,6 +46,12 @@ const (
 	ACLPolicySnapshot
 	ACLTokenSnapshot
 	SchedulerConfigSnapshot
+	ScalingPolicySnapshot
+	ScalingEventsSnapshot
+	ACLRoleSnapshot
+	WorkloadIdentityKeySnapshot
+	ACLAuthMethodSnapshot
+	ACLBindingRuleSnapshot
 )
 
 // LogApplier is the definition of a function that can apply a Raft log
@@ -68,6 +75,7 @@ type nomadFSM struct {
 	evalBroker         *EvalBroker
 	blockedEvals       *BlockedEvals
 	periodicDispatcher *PeriodicDispatch
+	eventBroker        *stream.EventBroker
 	logger             log.Logger
 	state              *state.StateStore
 	timetable          *TimeTable
@@ -113,6 +121,10 @@ type FSMConfig struct {
 	// be added to.
 	Blocked *BlockedEvals
 
+	// EventBroker is the event broker the events of the applied logs are
+	// published to. No events are published when it is nil.
+	EventBroker *stream.EventBroker
+
 	// Logger is the logger used by the FSM
 	Logger log.Logger
 
@@ -136,6 +148,7 @@ func NewFSM(config *FSMConfig) (*nomadFSM, error) {
 		evalBroker:          config.EvalBroker,
 		periodicDispatcher:  config.Periodic,
 		blockedEvals:        config.Blocked,
+		eventBroker:         config.EventBroker,
 		logger:              config.Logger.Named("fsm"),
 		config:              config,
 		state:               state,
@@ -170,7 +183,7 @@ func (n *nomadFSM) TimeTable() *TimeTable {
 	return n.timetable
 }
 
-func (n *nomadFSM) Apply(log *raft.Log) interface{} {
+func (n *nomadFSM) Apply(log *raft.Log) (resp interface{}) {
 	buf := log.Data
 	msgType := structs.MessageType(buf[0])
 
@@ -186,6 +199,13 @@ func (n *nomadFSM) Apply(log *raft.Log) interface{} {
 		ignoreUnknown = true
 	}
 
+	// Publish the events of the log once it has been successfully applied
+	defer func() {
+		if _, failed := resp.(error); !failed {
+			n.publishEvents(msgType, buf[1:], log.Index)
+		}
+	}()
+
 	switch msgType {
 	case structs.NodeRegisterRequestType:
 		return n.applyUpsertNode(buf[1:], log.Index)
@@ -251,6 +271,22 @@ func (n *nomadFSM) Apply(log *raft.Log) interface{} {
 		return n.applySchedulerConfigUpdate(buf[1:], log.Index)
 	case structs.NodeBatchDeregisterRequestType:
 		return n.applyDeregisterNodeBatch(buf[1:], log.Index)
+	case structs.ScalingEventRegisterRequestType:
+		return n.applyUpsertScalingEvent(buf[1:], log.Index)
+	case structs.ACLRoleUpsertRequestType:
+		return n.applyACLRoleUpsert(buf[1:], log.Index)
+	case structs.ACLRoleDeleteRequestType:
+		return n.applyACLRoleDelete(buf[1:], log.Index)
+	case structs.WorkloadIdentityKeyRequestType:
+		return n.applyWorkloadIdentityKey(buf[1:], log.Index)
+	case structs.ACLAuthMethodUpsertRequestType:
+		return n.applyACLAuthMethodUpsert(buf[1:], log.Index)
+	case structs.ACLAuthMethodDeleteRequestType:
+		return n.applyACLAuthMethodDelete(buf[1:], log.Index)
+	case structs.ACLBindingRuleUpsertRequestType:
+		return n.applyACLBindingRuleUpsert(buf[1:], log.Index)
+	case structs.ACLBindingRuleDeleteRequestType:
+		return n.applyACLBindingRuleDelete(buf[1:], log.Index)
 	}
 
 	// Check enterprise only message types.
@@ -977,6 +1013,111 @@ func (n *nomadFSM) applyACLPolicyDelete(buf []byte, index uint64) interface{} {
 	return nil
 }
 
+// applyACLRoleUpsert is used to upsert a set of roles
+func (n *nomadFSM) applyACLRoleUpsert(buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_acl_role_upsert"}, time.Now())
+	var req structs.ACLRoleUpsertRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.UpsertACLRoles(index, req.Roles); err != nil {
+		n.logger.Error("UpsertACLRoles failed", "error", err)
+		return err
+	}
+	return nil
+}
+
+// applyACLRoleDelete is used to delete a set of roles
+func (n *nomadFSM) applyACLRoleDelete(buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_acl_role_delete"}, time.Now())
+	var req structs.ACLRoleDeleteRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.DeleteACLRoles(index, req.RoleIDs); err != nil {
+		n.logger.Error("DeleteACLRoles failed", "error", err)
+		return err
+	}
+	return nil
+}
+
+// applyACLAuthMethodUpsert is used to upsert a set of auth methods
+func (n *nomadFSM) applyACLAuthMethodUpsert(buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_acl_auth_method_upsert"}, time.Now())
+	var req structs.ACLAuthMethodUpsertRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.UpsertACLAuthMethods(index, req.AuthMethods); err != nil {
+		n.logger.Error("UpsertACLAuthMethods failed", "error", err)
+		return err
+	}
+	return nil
+}
+
+// applyACLAuthMethodDelete is used to delete a set of auth methods
+func (n *nomadFSM) applyACLAuthMethodDelete(buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_acl_auth_method_delete"}, time.Now())
+	var req structs.ACLAuthMethodDeleteRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.DeleteACLAuthMethods(index, req.MethodNames); err != nil {
+		n.logger.Error("DeleteACLAuthMethods failed", "error", err)
+		return err
+	}
+	return nil
+}
+
+// applyACLBindingRuleUpsert is used to upsert a set of binding rules
+func (n *nomadFSM) applyACLBindingRuleUpsert(buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_acl_binding_rule_upsert"}, time.Now())
+	var req structs.ACLBindingRuleUpsertRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.UpsertACLBindingRules(index, req.BindingRules); err != nil {
+		n.logger.Error("UpsertACLBindingRules failed", "error", err)
+		return err
+	}
+	return nil
+}
+
+// applyACLBindingRuleDelete is used to delete a set of binding rules
+func (n *nomadFSM) applyACLBindingRuleDelete(buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_acl_binding_rule_delete"}, time.Now())
+	var req structs.ACLBindingRuleDeleteRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.DeleteACLBindingRules(index, req.BindingRuleIDs); err != nil {
+		n.logger.Error("DeleteACLBindingRules failed", "error", err)
+		return err
+	}
+	return nil
+}
+
+// applyWorkloadIdentityKey is used to set the workload identity key
+func (n *nomadFSM) applyWorkloadIdentityKey(buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_workload_identity_key"}, time.Now())
+	var req structs.WorkloadIdentityKeyRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.UpsertWorkloadIdentityKey(index, req.Key); err != nil {
+		n.logger.Error("UpsertWorkloadIdentityKey failed", "error", err)
+		return err
+	}
+	return nil
+}
+
 // applyACLTokenUpsert is used to upsert a set of policies
 func (n *nomadFSM) applyACLTokenUpsert(buf []byte, index uint64) interface{} {
 	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_acl_token_upsert"}, time.Now())
@@ -1056,6 +1197,22 @@ func (n *nomadFSM) applySchedulerConfigUpdate(buf []byte, index uint64) interfac
 	return n.state.SchedulerSetConfig(index, &req.Config)
 }
 
+// applyUpsertScalingEvent records a scaling event against a task group
+func (n *nomadFSM) applyUpsertScalingEvent(buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "upsert_scaling_event"}, time.Now())
+	var req structs.ScalingEventRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.UpsertScalingEvent(index, &req); err != nil {
+		n.logger.Error("UpsertScalingEvent failed", "error", err)
+		return err
+	}
+
+	return nil
+}
+
 func (n *nomadFSM) Snapshot() (raft.FSMSnapshot, error) {
 	// Create a new snapshot
 	snap, err := n.state.Snapshot()
@@ -1246,6 +1403,42 @@ func (n *nomadFSM) Restore(old io.ReadCloser) error {
 				return err
 			}
 
+		case ACLRoleSnapshot:
+			role := new(structs.ACLRole)
+			if err := dec.Decode(role); err != nil {
+				return err
+			}
+			if err := restore.ACLRoleRestore(role); err != nil {
+				return err
+			}
+
+		case WorkloadIdentityKeySnapshot:
+			key := new(structs.WorkloadIdentityKey)
+			if err := dec.Decode(key); err != nil {
+				return err
+			}
+			if err := restore.WorkloadIdentityKeyRestore(key); err != nil {
+				return err
+			}
+
+		case ACLAuthMethodSnapshot:
+			method := new(structs.ACLAuthMethod)
+			if err := dec.Decode(method); err != nil {
+				return err
+			}
+			if err := restore.ACLAuthMethodRestore(method); err != nil {
+				return err
+			}
+
+		case ACLBindingRuleSnapshot:
+			rule := new(structs.ACLBindingRule)
+			if err := dec.Decode(rule); err != nil {
+				return err
+			}
+			if err := restore.ACLBindingRuleRestore(rule); err != nil {
+				return err
+			}
+
 		case SchedulerConfigSnapshot:
 			schedConfig := new(structs.SchedulerConfiguration)
 			if err := dec.Decode(schedConfig); err != nil {
@@ -1255,6 +1448,24 @@ func (n *nomadFSM) Restore(old io.ReadCloser) error {
 				return err
 			}
 
+		case ScalingPolicySnapshot:
+			policy := new(structs.ScalingPolicy)
+			if err := dec.Decode(policy); err != nil {
+				return err
+			}
+			if err := restore.ScalingPolicyRestore(policy); err != nil {
+				return err
+			}
+
+		case ScalingEventsSnapshot:
+			jobEvents := new(structs.JobScalingEvents)
+			if err := dec.Decode(jobEvents); err != nil {
+				return err
+			}
+			if err := restore.ScalingEventsRestore(jobEvents); err != nil {
+				return err
+			}
+
 		default:
 			// Check if this is an enterprise only object being restored
 			restorer, ok := n.enterpriseRestorers[snapType]
@@ -1519,6 +1730,10 @@ func (s *nomadSnapshot) Persist(sink raft.SnapshotSink) error {
 		sink.Cancel()
 		return err
 	}
+	if err := s.persistACLRoles(sink, encoder); err != nil {
+		sink.Cancel()
+		return err
+	}
 	if err := s.persistEnterpriseTables(sink, encoder); err != nil {
 		sink.Cancel()
 		return err
@@ -1527,6 +1742,26 @@ func (s *nomadSnapshot) Persist(sink raft.SnapshotSink) error {
 		sink.Cancel()
 		return err
 	}
+	if err := s.persistScalingPolicies(sink, encoder); err != nil {
+		sink.Cancel()
+		return err
+	}
+	if err := s.persistScalingEvents(sink, encoder); err != nil {
+		sink.Cancel()
+		return err
+	}
+	if err := s.persistWorkloadIdentityKey(sink, encoder); err != nil {
+		sink.Cancel()
+		return err
+	}
+	if err := s.persistACLAuthMethods(sink, encoder); err != nil {
+		sink.Cancel()
+		return err
+	}
+	if err := s.persistACLBindingRules(sink, encoder); err != nil {
+		sink.Cancel()
+		return err
+	}
 	return nil
 }
 
@@ -1859,6 +2094,90 @@ func (s *nomadSnapshot) persistACLTokens(sink raft.SnapshotSink,
 	return nil
 }
 
+func (s *nomadSnapshot) persistACLRoles(sink raft.SnapshotSink,
+	encoder *codec.Encoder) error {
+	// Get all the roles
+	ws := memdb.NewWatchSet()
+	roles, err := s.snap.ACLRoles(ws)
+	if err != nil {
+		return err
+	}
+
+	for {
+		// Get the next item
+		raw := roles.Next()
+		if raw == nil {
+			break
+		}
+
+		// Prepare the request struct
+		role := raw.(*structs.ACLRole)
+
+		// Write out a role registration
+		sink.Write([]byte{byte(ACLRoleSnapshot)})
+		if err := encoder.Encode(role); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *nomadSnapshot) persistACLAuthMethods(sink raft.SnapshotSink,
+	encoder *codec.Encoder) error {
+	// Get all the auth methods
+	ws := memdb.NewWatchSet()
+	methods, err := s.snap.ACLAuthMethods(ws)
+	if err != nil {
+		return err
+	}
+
+	for {
+		// Get the next item
+		raw := methods.Next()
+		if raw == nil {
+			break
+		}
+
+		// Prepare the request struct
+		method := raw.(*structs.ACLAuthMethod)
+
+		// Write out an auth method registration
+		sink.Write([]byte{byte(ACLAuthMethodSnapshot)})
+		if err := encoder.Encode(method); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *nomadSnapshot) persistACLBindingRules(sink raft.SnapshotSink,
+	encoder *codec.Encoder) error {
+	// Get all the binding rules
+	ws := memdb.NewWatchSet()
+	rules, err := s.snap.ACLBindingRules(ws)
+	if err != nil {
+		return err
+	}
+
+	for {
+		// Get the next item
+		raw := rules.Next()
+		if raw == nil {
+			break
+		}
+
+		// Prepare the request struct
+		rule := raw.(*structs.ACLBindingRule)
+
+		// Write out a binding rule registration
+		sink.Write([]byte{byte(ACLBindingRuleSnapshot)})
+		if err := encoder.Encode(rule); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (s *nomadSnapshot) persistSchedulerConfig(sink raft.SnapshotSink,
 	encoder *codec.Encoder) error {
 	// Get scheduler config
@@ -1874,6 +2193,81 @@ func (s *nomadSnapshot) persistSchedulerConfig(sink raft.SnapshotSink,
 	return nil
 }
 
+func (s *nomadSnapshot) persistWorkloadIdentityKey(sink raft.SnapshotSink,
+	encoder *codec.Encoder) error {
+	// Get the workload identity key
+	key, err := s.snap.WorkloadIdentityKey(nil)
+	if err != nil {
+		return err
+	}
+	if key == nil {
+		return nil
+	}
+
+	// Write out the key
+	sink.Write([]byte{byte(WorkloadIdentityKeySnapshot)})
+	if err := encoder.Encode(key); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (s *nomadSnapshot) persistScalingPolicies(sink raft.SnapshotSink,
+	encoder *codec.Encoder) error {
+	// Get all the scaling policies
+	ws := memdb.NewWatchSet()
+	policies, err := s.snap.ScalingPolicies(ws)
+	if err != nil {
+		return err
+	}
+
+	for {
+		// Get the next item
+		raw := policies.Next()
+		if raw == nil {
+			break
+		}
+
+		// Prepare the request struct
+		policy := raw.(*structs.ScalingPolicy)
+
+		// Write out a scaling policy
+		sink.Write([]byte{byte(ScalingPolicySnapshot)})
+		if err := encoder.Encode(policy); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *nomadSnapshot) persistScalingEvents(sink raft.SnapshotSink,
+	encoder *codec.Encoder) error {
+	// Get all the scaling events
+	ws := memdb.NewWatchSet()
+	iter, err := s.snap.ScalingEvents(ws)
+	if err != nil {
+		return err
+	}
+
+	for {
+		// Get the next item
+		raw := iter.Next()
+		if raw == nil {
+			break
+		}
+
+		// Prepare the request struct
+		jobEvents := raw.(*structs.JobScalingEvents)
+
+		// Write out the scaling events of a job
+		sink.Write([]byte{byte(ScalingEventsSnapshot)})
+		if err := encoder.Encode(jobEvents); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Release is a no-op, as we just need to GC the pointer
 // to the state store snapshot. There is nothing to explicitly
 // cleanup.
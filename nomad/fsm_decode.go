@@ -0,0 +1,86 @@
+package nomad
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// logRequest describes the request a Raft log of a given message type is
+// decoded into by the FSM.
+type logRequest struct {
+	// name is the name of the message type
+	name string
+
+	// request returns a new instance of the request of the message type
+	request func() interface{}
+}
+
+// logRequests maps the message types the FSM applies to the requests their
+// logs are decoded into. It must be kept in sync with nomadFSM.Apply, which
+// TestFSM_DecodeLog checks.
+var logRequests = map[structs.MessageType]logRequest{
+	structs.NodeRegisterRequestType:                 {"NodeRegisterRequestType", func() interface{} { return &structs.NodeRegisterRequest{} }},
+	structs.NodeDeregisterRequestType:               {"NodeDeregisterRequestType", func() interface{} { return &structs.NodeDeregisterRequest{} }},
+	structs.NodeUpdateStatusRequestType:             {"NodeUpdateStatusRequestType", func() interface{} { return &structs.NodeUpdateStatusRequest{} }},
+	structs.NodeUpdateDrainRequestType:              {"NodeUpdateDrainRequestType", func() interface{} { return &structs.NodeUpdateDrainRequest{} }},
+	structs.JobRegisterRequestType:                  {"JobRegisterRequestType", func() interface{} { return &structs.JobRegisterRequest{} }},
+	structs.JobDeregisterRequestType:                {"JobDeregisterRequestType", func() interface{} { return &structs.JobDeregisterRequest{} }},
+	structs.EvalUpdateRequestType:                   {"EvalUpdateRequestType", func() interface{} { return &structs.EvalUpdateRequest{} }},
+	structs.EvalDeleteRequestType:                   {"EvalDeleteRequestType", func() interface{} { return &structs.EvalDeleteRequest{} }},
+	structs.AllocUpdateRequestType:                  {"AllocUpdateRequestType", func() interface{} { return &structs.AllocUpdateRequest{} }},
+	structs.AllocClientUpdateRequestType:            {"AllocClientUpdateRequestType", func() interface{} { return &structs.AllocUpdateRequest{} }},
+	structs.ReconcileJobSummariesRequestType:        {"ReconcileJobSummariesRequestType", func() interface{} { return &structs.GenericRequest{} }},
+	structs.VaultAccessorRegisterRequestType:        {"VaultAccessorRegisterRequestType", func() interface{} { return &structs.VaultAccessorsRequest{} }},
+	structs.VaultAccessorDeregisterRequestType:      {"VaultAccessorDeregisterRequestType", func() interface{} { return &structs.VaultAccessorsRequest{} }},
+	structs.ApplyPlanResultsRequestType:             {"ApplyPlanResultsRequestType", func() interface{} { return &structs.ApplyPlanResultsRequest{} }},
+	structs.DeploymentStatusUpdateRequestType:       {"DeploymentStatusUpdateRequestType", func() interface{} { return &structs.DeploymentStatusUpdateRequest{} }},
+	structs.DeploymentPromoteRequestType:            {"DeploymentPromoteRequestType", func() interface{} { return &structs.ApplyDeploymentPromoteRequest{} }},
+	structs.DeploymentAllocHealthRequestType:        {"DeploymentAllocHealthRequestType", func() interface{} { return &structs.ApplyDeploymentAllocHealthRequest{} }},
+	structs.DeploymentDeleteRequestType:             {"DeploymentDeleteRequestType", func() interface{} { return &structs.DeploymentDeleteRequest{} }},
+	structs.JobStabilityRequestType:                 {"JobStabilityRequestType", func() interface{} { return &structs.JobStabilityRequest{} }},
+	structs.ACLPolicyUpsertRequestType:              {"ACLPolicyUpsertRequestType", func() interface{} { return &structs.ACLPolicyUpsertRequest{} }},
+	structs.ACLPolicyDeleteRequestType:              {"ACLPolicyDeleteRequestType", func() interface{} { return &structs.ACLPolicyDeleteRequest{} }},
+	structs.ACLTokenUpsertRequestType:               {"ACLTokenUpsertRequestType", func() interface{} { return &structs.ACLTokenUpsertRequest{} }},
+	structs.ACLTokenDeleteRequestType:               {"ACLTokenDeleteRequestType", func() interface{} { return &structs.ACLTokenDeleteRequest{} }},
+	structs.ACLTokenBootstrapRequestType:            {"ACLTokenBootstrapRequestType", func() interface{} { return &structs.ACLTokenBootstrapRequest{} }},
+	structs.AutopilotRequestType:                    {"AutopilotRequestType", func() interface{} { return &structs.AutopilotSetConfigRequest{} }},
+	structs.UpsertNodeEventsType:                    {"UpsertNodeEventsType", func() interface{} { return &structs.EmitNodeEventsRequest{} }},
+	structs.JobBatchDeregisterRequestType:           {"JobBatchDeregisterRequestType", func() interface{} { return &structs.JobBatchDeregisterRequest{} }},
+	structs.AllocUpdateDesiredTransitionRequestType: {"AllocUpdateDesiredTransitionRequestType", func() interface{} { return &structs.AllocUpdateDesiredTransitionRequest{} }},
+	structs.NodeUpdateEligibilityRequestType:        {"NodeUpdateEligibilityRequestType", func() interface{} { return &structs.NodeUpdateEligibilityRequest{} }},
+	structs.BatchNodeUpdateDrainRequestType:         {"BatchNodeUpdateDrainRequestType", func() interface{} { return &structs.BatchNodeUpdateDrainRequest{} }},
+	structs.SchedulerConfigRequestType:              {"SchedulerConfigRequestType", func() interface{} { return &structs.SchedulerSetConfigRequest{} }},
+	structs.NodeBatchDeregisterRequestType:          {"NodeBatchDeregisterRequestType", func() interface{} { return &structs.NodeBatchDeregisterRequest{} }},
+	structs.ScalingEventRegisterRequestType:         {"ScalingEventRegisterRequestType", func() interface{} { return &structs.ScalingEventRequest{} }},
+	structs.ACLRoleUpsertRequestType:                {"ACLRoleUpsertRequestType", func() interface{} { return &structs.ACLRoleUpsertRequest{} }},
+	structs.ACLRoleDeleteRequestType:                {"ACLRoleDeleteRequestType", func() interface{} { return &structs.ACLRoleDeleteRequest{} }},
+	structs.WorkloadIdentityKeyRequestType:          {"WorkloadIdentityKeyRequestType", func() interface{} { return &structs.WorkloadIdentityKeyRequest{} }},
+	structs.ACLAuthMethodUpsertRequestType:          {"ACLAuthMethodUpsertRequestType", func() interface{} { return &structs.ACLAuthMethodUpsertRequest{} }},
+	structs.ACLAuthMethodDeleteRequestType:          {"ACLAuthMethodDeleteRequestType", func() interface{} { return &structs.ACLAuthMethodDeleteRequest{} }},
+	structs.ACLBindingRuleUpsertRequestType:         {"ACLBindingRuleUpsertRequestType", func() interface{} { return &structs.ACLBindingRuleUpsertRequest{} }},
+	structs.ACLBindingRuleDeleteRequestType:         {"ACLBindingRuleDeleteRequestType", func() interface{} { return &structs.ACLBindingRuleDeleteRequest{} }},
+}
+
+// DecodeLog decodes the data of a Raft command log into the name of its
+// message type and the request the FSM applies. It allows tooling to inspect
+// the logs of a Raft store outside of a running server.
+func DecodeLog(buf []byte) (string, interface{}, error) {
+	if len(buf) == 0 {
+		return "", nil, fmt.Errorf("empty log")
+	}
+
+	msgType := structs.MessageType(buf[0])
+	msgType &= ^structs.IgnoreUnknownTypeFlag
+
+	lr, ok := logRequests[msgType]
+	if !ok {
+		return "", nil, fmt.Errorf("unknown message type %d", msgType)
+	}
+
+	req := lr.request()
+	if err := structs.Decode(buf[1:], req); err != nil {
+		return lr.name, nil, fmt.Errorf("failed to decode %s: %v", lr.name, err)
+	}
+	return lr.name, req, nil
+}
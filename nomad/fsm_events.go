@@ -0,0 +1,350 @@
+package nomad
+
+import (
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// publishEvents publishes the events of a Raft log once it has been applied.
+// The objects of the events are read back from the state store so that they
+// reflect the changes made by the log.
+func (n *nomadFSM) publishEvents(msgType structs.MessageType, buf []byte, index uint64) {
+	if n.eventBroker == nil {
+		return
+	}
+
+	events, err := n.eventsFromLog(msgType, buf, index)
+	if err != nil {
+		n.logger.Error("failed to generate events", "msg_type", msgType, "index", index, "error", err)
+		return
+	}
+	if len(events) == 0 {
+		return
+	}
+
+	n.eventBroker.Publish(&structs.Events{
+		Index:  index,
+		Events: events,
+	})
+}
+
+// eventsFromLog returns the events of an applied Raft log.
+func (n *nomadFSM) eventsFromLog(msgType structs.MessageType, buf []byte, index uint64) ([]structs.Event, error) {
+	e := &eventBuilder{
+		store: n.state,
+		index: index,
+	}
+
+	switch msgType {
+	case structs.NodeRegisterRequestType:
+		var req structs.NodeRegisterRequest
+		if err := structs.Decode(buf, &req); err != nil {
+			return nil, err
+		}
+		e.node(structs.TypeNodeRegistration, req.Node.ID)
+
+	case structs.NodeDeregisterRequestType:
+		var req structs.NodeDeregisterRequest
+		if err := structs.Decode(buf, &req); err != nil {
+			return nil, err
+		}
+		e.node(structs.TypeNodeDeregistration, req.NodeID)
+
+	case structs.NodeBatchDeregisterRequestType:
+		var req structs.NodeBatchDeregisterRequest
+		if err := structs.Decode(buf, &req); err != nil {
+			return nil, err
+		}
+		for _, nodeID := range req.NodeIDs {
+			e.node(structs.TypeNodeDeregistration, nodeID)
+		}
+
+	case structs.NodeUpdateStatusRequestType:
+		var req structs.NodeUpdateStatusRequest
+		if err := structs.Decode(buf, &req); err != nil {
+			return nil, err
+		}
+		e.node(structs.TypeNodeStatusUpdate, req.NodeID)
+
+	case structs.NodeUpdateDrainRequestType:
+		var req structs.NodeUpdateDrainRequest
+		if err := structs.Decode(buf, &req); err != nil {
+			return nil, err
+		}
+		e.node(structs.TypeNodeDrain, req.NodeID)
+
+	case structs.BatchNodeUpdateDrainRequestType:
+		var req structs.BatchNodeUpdateDrainRequest
+		if err := structs.Decode(buf, &req); err != nil {
+			return nil, err
+		}
+		for nodeID := range req.Updates {
+			e.node(structs.TypeNodeDrain, nodeID)
+		}
+
+	case structs.NodeUpdateEligibilityRequestType:
+		var req structs.NodeUpdateEligibilityRequest
+		if err := structs.Decode(buf, &req); err != nil {
+			return nil, err
+		}
+		e.node(structs.TypeNodeEligibilityUpdate, req.NodeID)
+
+	case structs.UpsertNodeEventsType:
+		var req structs.EmitNodeEventsRequest
+		if err := structs.Decode(buf, &req); err != nil {
+			return nil, err
+		}
+		for nodeID := range req.NodeEvents {
+			e.node(structs.TypeNodeEvent, nodeID)
+		}
+
+	case structs.JobRegisterRequestType:
+		var req structs.JobRegisterRequest
+		if err := structs.Decode(buf, &req); err != nil {
+			return nil, err
+		}
+		e.job(structs.TypeJobRegistered, req.Job.Namespace, req.Job.ID)
+
+	case structs.JobDeregisterRequestType:
+		var req structs.JobDeregisterRequest
+		if err := structs.Decode(buf, &req); err != nil {
+			return nil, err
+		}
+		e.job(structs.TypeJobDeregistered, req.RequestNamespace(), req.JobID)
+
+	case structs.JobBatchDeregisterRequestType:
+		var req structs.JobBatchDeregisterRequest
+		if err := structs.Decode(buf, &req); err != nil {
+			return nil, err
+		}
+		for jobID := range req.Jobs {
+			e.job(structs.TypeJobDeregistered, jobID.Namespace, jobID.ID)
+		}
+		e.evals(req.Evals)
+
+	case structs.EvalUpdateRequestType:
+		var req structs.EvalUpdateRequest
+		if err := structs.Decode(buf, &req); err != nil {
+			return nil, err
+		}
+		e.evals(req.Evals)
+
+	case structs.AllocUpdateRequestType, structs.AllocClientUpdateRequestType:
+		var req structs.AllocUpdateRequest
+		if err := structs.Decode(buf, &req); err != nil {
+			return nil, err
+		}
+		e.allocUpdates(&req)
+
+	case structs.AllocUpdateDesiredTransitionRequestType:
+		var req structs.AllocUpdateDesiredTransitionRequest
+		if err := structs.Decode(buf, &req); err != nil {
+			return nil, err
+		}
+		for allocID := range req.Allocs {
+			e.alloc(allocID)
+		}
+		e.evals(req.Evals)
+
+	case structs.ApplyPlanResultsRequestType:
+		var req structs.ApplyPlanResultsRequest
+		if err := structs.Decode(buf, &req); err != nil {
+			return nil, err
+		}
+		e.allocUpdates(&req.AllocUpdateRequest)
+		for _, alloc := range req.NodePreemptions {
+			e.alloc(alloc.ID)
+		}
+		for _, diff := range req.AllocsPreempted {
+			e.alloc(diff.ID)
+		}
+		if req.Deployment != nil {
+			e.deployment(structs.TypeDeploymentStatusUpdate, req.Deployment.ID)
+		}
+		for _, update := range req.DeploymentUpdates {
+			e.deployment(structs.TypeDeploymentStatusUpdate, update.DeploymentID)
+		}
+		e.evals(req.PreemptionEvals)
+
+	case structs.DeploymentStatusUpdateRequestType:
+		var req structs.DeploymentStatusUpdateRequest
+		if err := structs.Decode(buf, &req); err != nil {
+			return nil, err
+		}
+		if req.DeploymentUpdate != nil {
+			e.deployment(structs.TypeDeploymentStatusUpdate, req.DeploymentUpdate.DeploymentID)
+		}
+		if req.Job != nil {
+			e.job(structs.TypeJobRegistered, req.Job.Namespace, req.Job.ID)
+		}
+		if req.Eval != nil {
+			e.evals([]*structs.Evaluation{req.Eval})
+		}
+
+	case structs.DeploymentPromoteRequestType:
+		var req structs.ApplyDeploymentPromoteRequest
+		if err := structs.Decode(buf, &req); err != nil {
+			return nil, err
+		}
+		e.deployment(structs.TypeDeploymentPromotion, req.DeploymentID)
+		if req.Eval != nil {
+			e.evals([]*structs.Evaluation{req.Eval})
+		}
+
+	case structs.DeploymentAllocHealthRequestType:
+		var req structs.ApplyDeploymentAllocHealthRequest
+		if err := structs.Decode(buf, &req); err != nil {
+			return nil, err
+		}
+		e.deployment(structs.TypeDeploymentAllocHealth, req.DeploymentID)
+		for _, allocID := range req.HealthyAllocationIDs {
+			e.alloc(allocID)
+		}
+		for _, allocID := range req.UnhealthyAllocationIDs {
+			e.alloc(allocID)
+		}
+		if req.Job != nil {
+			e.job(structs.TypeJobRegistered, req.Job.Namespace, req.Job.ID)
+		}
+		if req.Eval != nil {
+			e.evals([]*structs.Evaluation{req.Eval})
+		}
+	}
+
+	return e.events, e.err
+}
+
+// eventBuilder accumulates the events of a Raft log, looking up their objects
+// in the state store. The first error looking up an object is retained.
+type eventBuilder struct {
+	store  *state.StateStore
+	index  uint64
+	events []structs.Event
+	err    error
+}
+
+func (e *eventBuilder) add(event structs.Event, err error) {
+	if e.err != nil {
+		return
+	}
+	if err != nil {
+		e.err = err
+		return
+	}
+	event.Index = e.index
+	e.events = append(e.events, event)
+}
+
+func (e *eventBuilder) node(eventType, nodeID string) {
+	node, err := e.store.NodeByID(nil, nodeID)
+	if node == nil {
+		// The node was deregistered
+		node = &structs.Node{ID: nodeID}
+	} else {
+		node = node.Copy()
+		node.SecretID = ""
+	}
+
+	e.add(structs.Event{
+		Topic:   structs.TopicNode,
+		Type:    eventType,
+		Key:     nodeID,
+		Payload: &structs.NodeStreamEvent{Node: node},
+	}, err)
+}
+
+func (e *eventBuilder) job(eventType, namespace, jobID string) {
+	job, err := e.store.JobByID(nil, namespace, jobID)
+	if job == nil {
+		// The job was purged
+		job = &structs.Job{ID: jobID, Namespace: namespace}
+	}
+
+	e.add(structs.Event{
+		Topic:     structs.TopicJob,
+		Type:      eventType,
+		Key:       jobID,
+		Namespace: namespace,
+		Payload:   &structs.JobEvent{Job: job},
+	}, err)
+}
+
+func (e *eventBuilder) evals(evals []*structs.Evaluation) {
+	for _, update := range evals {
+		eval, err := e.store.EvalByID(nil, update.ID)
+		if eval == nil {
+			eval = update
+		}
+
+		e.add(structs.Event{
+			Topic:      structs.TopicEvaluation,
+			Type:       structs.TypeEvalUpdated,
+			Key:        eval.ID,
+			Namespace:  eval.Namespace,
+			FilterKeys: filterKeys(eval.JobID, eval.DeploymentID),
+			Payload:    &structs.EvaluationEvent{Evaluation: eval},
+		}, err)
+	}
+}
+
+func (e *eventBuilder) allocUpdates(req *structs.AllocUpdateRequest) {
+	for _, alloc := range req.Alloc {
+		e.alloc(alloc.ID)
+	}
+	for _, diff := range req.AllocsStopped {
+		e.alloc(diff.ID)
+	}
+	for _, alloc := range req.AllocsUpdated {
+		e.alloc(alloc.ID)
+	}
+	e.evals(req.Evals)
+}
+
+func (e *eventBuilder) alloc(allocID string) {
+	alloc, err := e.store.AllocByID(nil, allocID)
+	if alloc == nil {
+		// The allocation was garbage collected
+		return
+	}
+	alloc = alloc.CopySkipJob()
+	alloc.Job = nil
+	alloc.SignedIdentities = nil
+
+	e.add(structs.Event{
+		Topic:      structs.TopicAllocation,
+		Type:       structs.TypeAllocationUpdated,
+		Key:        alloc.ID,
+		Namespace:  alloc.Namespace,
+		FilterKeys: filterKeys(alloc.JobID, alloc.DeploymentID),
+		Payload:    &structs.AllocationEvent{Allocation: alloc},
+	}, err)
+}
+
+func (e *eventBuilder) deployment(eventType, deploymentID string) {
+	deployment, err := e.store.DeploymentByID(nil, deploymentID)
+	if deployment == nil {
+		// The deployment was garbage collected
+		return
+	}
+
+	e.add(structs.Event{
+		Topic:      structs.TopicDeployment,
+		Type:       eventType,
+		Key:        deployment.ID,
+		Namespace:  deployment.Namespace,
+		FilterKeys: filterKeys(deployment.JobID),
+		Payload:    &structs.DeploymentEvent{Deployment: deployment},
+	}, err)
+}
+
+// filterKeys returns the IDs of the objects related to an event, skipping the
+// empty ones.
+func filterKeys(ids ...string) []string {
+	var keys []string
+	for _, id := range ids {
+		if id != "" {
+			keys = append(keys, id)
+		}
+	}
+	return keys
+}
@@ -2,7 +2,11 @@ package nomad
 
 import (
 	"bytes"
+	"context"
 	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
 	"reflect"
 	"strings"
 	"testing"
@@ -15,6 +19,7 @@ import (
 	"github.com/hashicorp/nomad/helper/uuid"
 	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/stream"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/testutil"
 	"github.com/hashicorp/raft"
@@ -2215,6 +2220,147 @@ func TestFSM_DeleteACLPolicies(t *testing.T) {
 	assert.Nil(t, out)
 }
 
+func TestFSM_UpsertACLRoles(t *testing.T) {
+	t.Parallel()
+	fsm := testFSM(t)
+
+	role := mock.ACLRole()
+	req := structs.ACLRoleUpsertRequest{
+		Roles: []*structs.ACLRole{role},
+	}
+	buf, err := structs.Encode(structs.ACLRoleUpsertRequestType, req)
+	require.NoError(t, err)
+	require.Nil(t, fsm.Apply(makeLog(buf)))
+
+	// Verify we are registered
+	out, err := fsm.State().ACLRoleByID(nil, role.ID)
+	require.NoError(t, err)
+	require.NotNil(t, out)
+}
+
+func TestFSM_DeleteACLRoles(t *testing.T) {
+	t.Parallel()
+	fsm := testFSM(t)
+
+	role := mock.ACLRole()
+	require.NoError(t, fsm.State().UpsertACLRoles(1000, []*structs.ACLRole{role}))
+
+	req := structs.ACLRoleDeleteRequest{
+		RoleIDs: []string{role.ID},
+	}
+	buf, err := structs.Encode(structs.ACLRoleDeleteRequestType, req)
+	require.NoError(t, err)
+	require.Nil(t, fsm.Apply(makeLog(buf)))
+
+	// Verify we are NOT registered
+	out, err := fsm.State().ACLRoleByID(nil, role.ID)
+	require.NoError(t, err)
+	require.Nil(t, out)
+}
+
+func TestFSM_UpsertACLAuthMethods(t *testing.T) {
+	t.Parallel()
+	fsm := testFSM(t)
+
+	method := mock.ACLAuthMethod()
+	req := structs.ACLAuthMethodUpsertRequest{
+		AuthMethods: []*structs.ACLAuthMethod{method},
+	}
+	buf, err := structs.Encode(structs.ACLAuthMethodUpsertRequestType, req)
+	require.NoError(t, err)
+	require.Nil(t, fsm.Apply(makeLog(buf)))
+
+	// Verify we are registered
+	out, err := fsm.State().ACLAuthMethodByName(nil, method.Name)
+	require.NoError(t, err)
+	require.NotNil(t, out)
+}
+
+func TestFSM_DeleteACLAuthMethods(t *testing.T) {
+	t.Parallel()
+	fsm := testFSM(t)
+
+	method := mock.ACLAuthMethod()
+	require.NoError(t, fsm.State().UpsertACLAuthMethods(1000, []*structs.ACLAuthMethod{method}))
+
+	req := structs.ACLAuthMethodDeleteRequest{
+		MethodNames: []string{method.Name},
+	}
+	buf, err := structs.Encode(structs.ACLAuthMethodDeleteRequestType, req)
+	require.NoError(t, err)
+	require.Nil(t, fsm.Apply(makeLog(buf)))
+
+	// Verify we are NOT registered
+	out, err := fsm.State().ACLAuthMethodByName(nil, method.Name)
+	require.NoError(t, err)
+	require.Nil(t, out)
+}
+
+func TestFSM_UpsertACLBindingRules(t *testing.T) {
+	t.Parallel()
+	fsm := testFSM(t)
+
+	method := mock.ACLAuthMethod()
+	require.NoError(t, fsm.State().UpsertACLAuthMethods(1000, []*structs.ACLAuthMethod{method}))
+
+	rule := mock.ACLBindingRule(method.Name)
+	req := structs.ACLBindingRuleUpsertRequest{
+		BindingRules: []*structs.ACLBindingRule{rule},
+	}
+	buf, err := structs.Encode(structs.ACLBindingRuleUpsertRequestType, req)
+	require.NoError(t, err)
+	require.Nil(t, fsm.Apply(makeLog(buf)))
+
+	// Verify we are registered
+	out, err := fsm.State().ACLBindingRuleByID(nil, rule.ID)
+	require.NoError(t, err)
+	require.NotNil(t, out)
+}
+
+func TestFSM_DeleteACLBindingRules(t *testing.T) {
+	t.Parallel()
+	fsm := testFSM(t)
+
+	method := mock.ACLAuthMethod()
+	require.NoError(t, fsm.State().UpsertACLAuthMethods(1000, []*structs.ACLAuthMethod{method}))
+	rule := mock.ACLBindingRule(method.Name)
+	require.NoError(t, fsm.State().UpsertACLBindingRules(1001, []*structs.ACLBindingRule{rule}))
+
+	req := structs.ACLBindingRuleDeleteRequest{
+		BindingRuleIDs: []string{rule.ID},
+	}
+	buf, err := structs.Encode(structs.ACLBindingRuleDeleteRequestType, req)
+	require.NoError(t, err)
+	require.Nil(t, fsm.Apply(makeLog(buf)))
+
+	// Verify we are NOT registered
+	out, err := fsm.State().ACLBindingRuleByID(nil, rule.ID)
+	require.NoError(t, err)
+	require.Nil(t, out)
+}
+
+func TestFSM_WorkloadIdentityKey(t *testing.T) {
+	t.Parallel()
+	fsm := testFSM(t)
+
+	key, err := structs.NewWorkloadIdentityKey()
+	require.NoError(t, err)
+
+	req := structs.WorkloadIdentityKeyRequest{
+		Key: key,
+	}
+	buf, err := structs.Encode(structs.WorkloadIdentityKeyRequestType, req)
+	require.NoError(t, err)
+	require.Nil(t, fsm.Apply(makeLog(buf)))
+
+	// Verify the key is set
+	out, err := fsm.State().WorkloadIdentityKey(nil)
+	require.NoError(t, err)
+	require.NotNil(t, out)
+	require.Equal(t, key.KeyID, out.KeyID)
+	require.Equal(t, key.Key, out.Key)
+}
+
 func TestFSM_BootstrapACLTokens(t *testing.T) {
 	t.Parallel()
 	fsm := testFSM(t)
@@ -2678,6 +2824,65 @@ func TestFSM_SnapshotRestore_ACLTokens(t *testing.T) {
 	assert.Equal(t, tk2, out2)
 }
 
+func TestFSM_SnapshotRestore_ACLRoles(t *testing.T) {
+	t.Parallel()
+	// Add some state
+	fsm := testFSM(t)
+	state := fsm.State()
+	r1 := mock.ACLRole()
+	r2 := mock.ACLRole()
+	state.UpsertACLRoles(1000, []*structs.ACLRole{r1, r2})
+
+	// Verify the contents
+	fsm2 := testSnapshotRestore(t, fsm)
+	state2 := fsm2.State()
+	out1, _ := state2.ACLRoleByID(nil, r1.ID)
+	out2, _ := state2.ACLRoleByID(nil, r2.ID)
+	require.Equal(t, r1, out1)
+	require.Equal(t, r2, out2)
+}
+
+func TestFSM_SnapshotRestore_ACLAuthMethods(t *testing.T) {
+	t.Parallel()
+	// Add some state
+	fsm := testFSM(t)
+	state := fsm.State()
+	m1 := mock.ACLAuthMethod()
+	m2 := mock.ACLAuthMethod()
+	state.UpsertACLAuthMethods(1000, []*structs.ACLAuthMethod{m1, m2})
+	r1 := mock.ACLBindingRule(m1.Name)
+	r2 := mock.ACLBindingRule(m2.Name)
+	state.UpsertACLBindingRules(1001, []*structs.ACLBindingRule{r1, r2})
+
+	// Verify the contents
+	fsm2 := testSnapshotRestore(t, fsm)
+	state2 := fsm2.State()
+	out1, _ := state2.ACLAuthMethodByName(nil, m1.Name)
+	out2, _ := state2.ACLAuthMethodByName(nil, m2.Name)
+	require.Equal(t, m1, out1)
+	require.Equal(t, m2, out2)
+	out3, _ := state2.ACLBindingRuleByID(nil, r1.ID)
+	out4, _ := state2.ACLBindingRuleByID(nil, r2.ID)
+	require.Equal(t, r1, out3)
+	require.Equal(t, r2, out4)
+}
+
+func TestFSM_SnapshotRestore_WorkloadIdentityKey(t *testing.T) {
+	t.Parallel()
+	// Add some state
+	fsm := testFSM(t)
+	state := fsm.State()
+	key, err := structs.NewWorkloadIdentityKey()
+	require.NoError(t, err)
+	require.NoError(t, state.UpsertWorkloadIdentityKey(1000, key))
+
+	// Verify the contents
+	fsm2 := testSnapshotRestore(t, fsm)
+	out, err := fsm2.State().WorkloadIdentityKey(nil)
+	require.NoError(t, err)
+	require.Equal(t, key, out)
+}
+
 func TestFSM_SnapshotRestore_SchedulerConfiguration(t *testing.T) {
 	t.Parallel()
 	// Add some state
@@ -2701,6 +2906,47 @@ func TestFSM_SnapshotRestore_SchedulerConfiguration(t *testing.T) {
 
 }
 
+func TestFSM_SnapshotRestore_ScalingPoliciesAndEvents(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	// Add some state
+	fsm := testFSM(t)
+	state := fsm.State()
+
+	job := mock.Job()
+	job.TaskGroups[0].Scaling = &structs.ScalingPolicy{
+		ID:      uuid.Generate(),
+		Min:     1,
+		Max:     20,
+		Enabled: true,
+		Policy:  map[string]interface{}{"a": "b"},
+	}
+	require.NoError(state.UpsertJob(1000, job))
+	require.NoError(state.UpsertScalingEvent(1001, &structs.ScalingEventRequest{
+		Namespace:    job.Namespace,
+		JobID:        job.ID,
+		TaskGroup:    job.TaskGroups[0].Name,
+		ScalingEvent: &structs.ScalingEvent{Message: "hello"},
+	}))
+
+	// Verify the contents
+	fsm2 := testSnapshotRestore(t, fsm)
+	state2 := fsm2.State()
+
+	policy, err := state.ScalingPolicyByID(nil, job.TaskGroups[0].Scaling.ID)
+	require.NoError(err)
+	out, err := state2.ScalingPolicyByID(nil, job.TaskGroups[0].Scaling.ID)
+	require.NoError(err)
+	require.Equal(policy, out)
+
+	events, modifyIndex, err := state2.ScalingEventsByJob(nil, job.Namespace, job.ID)
+	require.NoError(err)
+	require.EqualValues(1001, modifyIndex)
+	require.Len(events[job.TaskGroups[0].Name], 1)
+	require.Equal("hello", events[job.TaskGroups[0].Name][0].Message)
+}
+
 func TestFSM_ReconcileSummaries(t *testing.T) {
 	t.Parallel()
 	// Add some state
@@ -2972,3 +3218,156 @@ func TestFSM_SchedulerConfig(t *testing.T) {
 	require.True(config.PreemptionConfig.SystemSchedulerEnabled)
 	require.True(config.PreemptionConfig.BatchSchedulerEnabled)
 }
+
+func TestFSM_PublishEvents(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	fsm := testFSM(t)
+	fsm.eventBroker = stream.NewEventBroker(0)
+	sub, err := fsm.eventBroker.Subscribe(&stream.SubscribeRequest{Namespace: "*"})
+	require.NoError(err)
+
+	next := func() *structs.Events {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		events, err := sub.Next(ctx)
+		require.NoError(err)
+		return events
+	}
+
+	// Register a node
+	node := mock.Node()
+	buf, err := structs.Encode(structs.NodeRegisterRequestType, structs.NodeRegisterRequest{Node: node})
+	require.NoError(err)
+	require.Nil(fsm.Apply(makeLog(buf)))
+
+	events := next()
+	require.EqualValues(1, events.Index)
+	require.Len(events.Events, 1)
+	event := events.Events[0]
+	require.Equal(structs.TopicNode, event.Topic)
+	require.Equal(structs.TypeNodeRegistration, event.Type)
+	require.Equal(node.ID, event.Key)
+	payload := event.Payload.(*structs.NodeStreamEvent)
+	require.Equal(node.ID, payload.Node.ID)
+	require.Empty(payload.Node.SecretID)
+
+	// Register a job
+	job := mock.Job()
+	buf, err = structs.Encode(structs.JobRegisterRequestType, structs.JobRegisterRequest{
+		Job: job,
+		WriteRequest: structs.WriteRequest{
+			Namespace: job.Namespace,
+		},
+	})
+	require.NoError(err)
+	log := makeLog(buf)
+	log.Index = 2
+	require.Nil(fsm.Apply(log))
+
+	events = next()
+	require.EqualValues(2, events.Index)
+	require.Len(events.Events, 1)
+	event = events.Events[0]
+	require.Equal(structs.TopicJob, event.Topic)
+	require.Equal(structs.TypeJobRegistered, event.Type)
+	require.Equal(job.ID, event.Key)
+	require.Equal(job.Namespace, event.Namespace)
+	require.EqualValues(2, event.Payload.(*structs.JobEvent).Job.ModifyIndex)
+
+	// Allocations are published with the job ID as filter key
+	alloc := mock.Alloc()
+	alloc.JobID = job.ID
+	alloc.Job = job
+	alloc.NodeID = node.ID
+	buf, err = structs.Encode(structs.AllocUpdateRequestType, structs.AllocUpdateRequest{
+		Alloc: []*structs.Allocation{alloc},
+	})
+	require.NoError(err)
+	log = makeLog(buf)
+	log.Index = 3
+	require.Nil(fsm.Apply(log))
+
+	events = next()
+	require.Len(events.Events, 1)
+	event = events.Events[0]
+	require.Equal(structs.TopicAllocation, event.Topic)
+	require.Equal(structs.TypeAllocationUpdated, event.Type)
+	require.Equal(alloc.ID, event.Key)
+	require.Equal([]string{job.ID}, event.FilterKeys)
+	require.Nil(event.Payload.(*structs.AllocationEvent).Allocation.Job)
+}
+
+// fsmAppliedMessageTypes returns the names of the message types handled by
+// nomadFSM.Apply, parsed from the cases of its switch statement.
+func fsmAppliedMessageTypes(t *testing.T) []string {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "fsm.go", nil, 0)
+	require.NoError(t, err)
+
+	var names []string
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Name.Name != "Apply" || fn.Recv == nil {
+			continue
+		}
+		ast.Inspect(fn.Body, func(node ast.Node) bool {
+			clause, ok := node.(*ast.CaseClause)
+			if !ok {
+				return true
+			}
+			for _, expr := range clause.List {
+				if sel, ok := expr.(*ast.SelectorExpr); ok {
+					names = append(names, sel.Sel.Name)
+				}
+			}
+			return false
+		})
+	}
+	return names
+}
+
+func TestFSM_DecodeLog(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	// Every message type applied by the FSM must be decodable.
+	applied := fsmAppliedMessageTypes(t)
+	require.NotEmpty(applied)
+	decoded := make(map[string]bool, len(logRequests))
+	for _, lr := range logRequests {
+		decoded[lr.name] = true
+	}
+	for _, name := range applied {
+		require.True(decoded[name], "message type %s applied by the FSM is missing from logRequests", name)
+	}
+	require.Len(logRequests, len(applied))
+
+	job := mock.Job()
+	req := structs.JobRegisterRequest{
+		Job: job,
+		WriteRequest: structs.WriteRequest{
+			Namespace: job.Namespace,
+		},
+	}
+	buf, err := structs.Encode(structs.JobRegisterRequestType, req)
+	require.NoError(err)
+
+	name, out, err := DecodeLog(buf)
+	require.NoError(err)
+	require.Equal("JobRegisterRequestType", name)
+	require.Equal(job.ID, out.(*structs.JobRegisterRequest).Job.ID)
+
+	// Message types flagged to be ignored when unknown are decoded too.
+	buf[0] |= uint8(structs.IgnoreUnknownTypeFlag)
+	name, _, err = DecodeLog(buf)
+	require.NoError(err)
+	require.Equal("JobRegisterRequestType", name)
+
+	// Unknown message types and empty logs fail.
+	_, _, err = DecodeLog([]byte{uint8(structs.IgnoreUnknownTypeFlag) - 1})
+	require.Error(err)
+	_, _, err = DecodeLog(nil)
+	require.Error(err)
+}
@@ -65,6 +65,7 @@ func NewJobEndpoints(s *Server) *Job {
 		validators: []jobValidator{
 			jobConnectHook{},
 			jobValidate{},
+			&memoryOversubscriptionValidator{srv: s},
 		},
 	}
 }
@@ -228,6 +229,14 @@ func (j *Job) Register(args *structs.JobRegisterRequest, reply *structs.JobRegis
 	// Clear the Vault token
 	args.Job.VaultToken = ""
 
+	// Assign the scaling policies an ID. The state store keeps the ID of an
+	// existing policy for the same task group.
+	for _, tg := range args.Job.TaskGroups {
+		if tg.Scaling != nil {
+			tg.Scaling.ID = uuid.Generate()
+		}
+	}
+
 	// Check if the job has changed at all
 	if existingJob == nil || existingJob.SpecChanged(args.Job) {
 		// Set the submit time
@@ -313,7 +322,7 @@ func (j *Job) Summary(args *structs.JobSummaryRequest,
 	// Check for read-job permissions
 	if aclObj, err := j.srv.ResolveToken(args.AuthToken); err != nil {
 		return err
-	} else if aclObj != nil && !aclObj.AllowNsOp(args.RequestNamespace(), acl.NamespaceCapabilityReadJob) {
+	} else if aclObj != nil && !aclObj.AllowJobRead(args.RequestNamespace(), args.JobID) {
 		return structs.ErrPermissionDenied
 	}
 
@@ -767,6 +776,258 @@ func (j *Job) BatchDeregister(args *structs.JobBatchDeregisterRequest, reply *st
 	return nil
 }
 
+// Scale is used to modify the count of a task group of a job and record the
+// scaling event
+func (j *Job) Scale(args *structs.JobScaleRequest, reply *structs.JobRegisterResponse) error {
+	if done, err := j.srv.forward("Job.Scale", args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "job", "scale"}, time.Now())
+
+	// Check for scale-job or submit-job permissions
+	if aclObj, err := j.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if aclObj != nil &&
+		!aclObj.AllowNsOp(args.RequestNamespace(), acl.NamespaceCapabilityScaleJob) &&
+		!aclObj.AllowNsOp(args.RequestNamespace(), acl.NamespaceCapabilitySubmitJob) {
+		return structs.ErrPermissionDenied
+	}
+
+	// Validate the arguments
+	if args.JobID == "" {
+		return fmt.Errorf("missing job ID for scaling")
+	}
+	groupName := args.Target[structs.ScalingTargetGroup]
+	if groupName == "" {
+		return structs.NewErrRPCCoded(400, "missing task group name for scaling action")
+	}
+	if args.Count != nil {
+		if *args.Count < 0 {
+			return structs.NewErrRPCCoded(400, "scaling action count can't be negative")
+		}
+		if args.Error {
+			return structs.NewErrRPCCoded(400, "scaling action should not contain count if error is true")
+		}
+	}
+
+	// Lookup the job
+	snap, err := j.srv.fsm.State().Snapshot()
+	if err != nil {
+		return err
+	}
+	ws := memdb.NewWatchSet()
+	job, err := snap.JobByID(ws, args.RequestNamespace(), args.JobID)
+	if err != nil {
+		return err
+	}
+	if job == nil {
+		return structs.NewErrRPCCodedf(404, "job %q not found", args.JobID)
+	}
+	if job.IsPeriodic() || job.IsParameterized() {
+		return structs.NewErrRPCCoded(400, "can't scale periodic or parameterized job")
+	}
+
+	tg := job.LookupTaskGroup(groupName)
+	if tg == nil {
+		return structs.NewErrRPCCodedf(400, "task group %q specified for scaling does not exist in job", groupName)
+	}
+
+	now := time.Now().UTC().UnixNano()
+	reply.JobModifyIndex = job.JobModifyIndex
+
+	var eval *structs.Evaluation
+	if args.Count != nil {
+		if job.Stopped() {
+			return structs.NewErrRPCCodedf(400, "job %q is stopped", args.JobID)
+		}
+
+		// Enforce the bounds of the task group's scaling policy
+		if tg.Scaling != nil && !args.PolicyOverride {
+			if *args.Count < tg.Scaling.Min {
+				return structs.NewErrRPCCodedf(400, "group count was less than scaling policy minimum: %d < %d",
+					*args.Count, tg.Scaling.Min)
+			}
+			if *args.Count > tg.Scaling.Max {
+				return structs.NewErrRPCCodedf(400, "group count was greater than scaling policy maximum: %d > %d",
+					*args.Count, tg.Scaling.Max)
+			}
+		}
+
+		// Register a new version of the job if the count changed
+		if int64(tg.Count) != *args.Count {
+			scaled := job.Copy()
+			scaled.LookupTaskGroup(groupName).Count = int(*args.Count)
+			scaled.SetSubmitTime()
+
+			regReq := &structs.JobRegisterRequest{
+				Job:          scaled,
+				WriteRequest: args.WriteRequest,
+			}
+			fsmErr, index, err := j.srv.raftApply(structs.JobRegisterRequestType, regReq)
+			if err, ok := fsmErr.(error); ok && err != nil {
+				j.logger.Error("job scaling failed", "error", err, "fsm", true)
+				return err
+			}
+			if err != nil {
+				j.logger.Error("job scaling failed", "error", err, "raft", true)
+				return err
+			}
+			reply.JobModifyIndex = index
+		}
+
+		// Create a new evaluation
+		eval = &structs.Evaluation{
+			ID:             uuid.Generate(),
+			Namespace:      args.RequestNamespace(),
+			Priority:       job.Priority,
+			Type:           job.Type,
+			TriggeredBy:    structs.EvalTriggerScaling,
+			JobID:          job.ID,
+			JobModifyIndex: reply.JobModifyIndex,
+			Status:         structs.EvalStatusPending,
+			CreateTime:     now,
+			ModifyTime:     now,
+		}
+		update := &structs.EvalUpdateRequest{
+			Evals:        []*structs.Evaluation{eval},
+			WriteRequest: structs.WriteRequest{Region: args.Region},
+		}
+
+		_, evalIndex, err := j.srv.raftApply(structs.EvalUpdateRequestType, update)
+		if err != nil {
+			j.logger.Error("eval create failed", "error", err, "method", "scale")
+			return err
+		}
+
+		reply.EvalID = eval.ID
+		reply.EvalCreateIndex = evalIndex
+	}
+
+	// Record the scaling event
+	event := &structs.ScalingEvent{
+		Time:    now,
+		Count:   args.Count,
+		Message: args.Message,
+		Error:   args.Error,
+		Meta:    args.Meta,
+	}
+	if eval != nil {
+		event.EvalID = &eval.ID
+	}
+	eventReq := &structs.ScalingEventRequest{
+		Namespace:    job.Namespace,
+		JobID:        job.ID,
+		TaskGroup:    groupName,
+		ScalingEvent: event,
+		WriteRequest: structs.WriteRequest{Region: args.Region},
+	}
+	fsmErr, index, err := j.srv.raftApply(structs.ScalingEventRegisterRequestType, eventReq)
+	if err, ok := fsmErr.(error); ok && err != nil {
+		j.logger.Error("scaling event create failed", "error", err, "fsm", true)
+		return err
+	}
+	if err != nil {
+		j.logger.Error("scaling event create failed", "error", err, "raft", true)
+		return err
+	}
+
+	reply.Index = index
+	return nil
+}
+
+// ScaleStatus is used to retrieve the scaling status of the task groups of
+// a job
+func (j *Job) ScaleStatus(args *structs.JobScaleStatusRequest,
+	reply *structs.JobScaleStatusResponse) error {
+	if done, err := j.srv.forward("Job.ScaleStatus", args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "job", "scale_status"}, time.Now())
+
+	// Check for read-job permissions
+	if aclObj, err := j.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if aclObj != nil && !aclObj.AllowJobRead(args.RequestNamespace(), args.JobID) {
+		return structs.ErrPermissionDenied
+	}
+
+	// Setup the blocking query
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, state *state.StateStore) error {
+			// Use the last index that affected any of the tables used
+			var index uint64
+			for _, table := range []string{"jobs", "allocs", "deployment", "scaling_event"} {
+				tableIndex, err := state.Index(table)
+				if err != nil {
+					return err
+				}
+				if tableIndex > index {
+					index = tableIndex
+				}
+			}
+			reply.Index = index
+			j.srv.setQueryMeta(&reply.QueryMeta)
+
+			job, err := state.JobByID(ws, args.RequestNamespace(), args.JobID)
+			if err != nil {
+				return err
+			}
+			if job == nil {
+				reply.JobScaleStatus = nil
+				return nil
+			}
+
+			allocs, err := state.AllocsByJob(ws, args.RequestNamespace(), args.JobID, false)
+			if err != nil {
+				return err
+			}
+			deployment, err := state.LatestDeploymentByJobID(ws, args.RequestNamespace(), args.JobID)
+			if err != nil {
+				return err
+			}
+			events, _, err := state.ScalingEventsByJob(ws, args.RequestNamespace(), args.JobID)
+			if err != nil {
+				return err
+			}
+
+			status := &structs.JobScaleStatus{
+				JobID:          job.ID,
+				JobCreateIndex: job.CreateIndex,
+				JobModifyIndex: job.ModifyIndex,
+				JobStopped:     job.Stop,
+				TaskGroups:     make(map[string]*structs.TaskGroupScaleStatus, len(job.TaskGroups)),
+			}
+			for _, tg := range job.TaskGroups {
+				tgStatus := &structs.TaskGroupScaleStatus{
+					Desired: tg.Count,
+					Events:  events[tg.Name],
+				}
+				if deployment != nil {
+					if ds, ok := deployment.TaskGroups[tg.Name]; ok {
+						tgStatus.Healthy = ds.HealthyAllocs
+						tgStatus.Unhealthy = ds.UnhealthyAllocs
+					}
+				}
+				status.TaskGroups[tg.Name] = tgStatus
+			}
+			for _, alloc := range allocs {
+				tgStatus, ok := status.TaskGroups[alloc.TaskGroup]
+				if !ok || alloc.TerminalStatus() {
+					continue
+				}
+				tgStatus.Placed++
+				if alloc.ClientStatus == structs.AllocClientStatusRunning {
+					tgStatus.Running++
+				}
+			}
+			reply.JobScaleStatus = status
+			return nil
+		}}
+	return j.srv.blockingRPC(&opts)
+}
+
 // GetJob is used to request information about a specific job
 func (j *Job) GetJob(args *structs.JobSpecificRequest,
 	reply *structs.SingleJobResponse) error {
@@ -778,7 +1039,7 @@ func (j *Job) GetJob(args *structs.JobSpecificRequest,
 	// Check for read-job permissions
 	if aclObj, err := j.srv.ResolveToken(args.AuthToken); err != nil {
 		return err
-	} else if aclObj != nil && !aclObj.AllowNsOp(args.RequestNamespace(), acl.NamespaceCapabilityReadJob) {
+	} else if aclObj != nil && !aclObj.AllowJobRead(args.RequestNamespace(), args.JobID) {
 		return structs.ErrPermissionDenied
 	}
 
@@ -824,7 +1085,7 @@ func (j *Job) GetJobVersions(args *structs.JobVersionsRequest,
 	// Check for read-job permissions
 	if aclObj, err := j.srv.ResolveToken(args.AuthToken); err != nil {
 		return err
-	} else if aclObj != nil && !aclObj.AllowNsOp(args.RequestNamespace(), acl.NamespaceCapabilityReadJob) {
+	} else if aclObj != nil && !aclObj.AllowJobRead(args.RequestNamespace(), args.JobID) {
 		return structs.ErrPermissionDenied
 	}
 
@@ -947,7 +1208,7 @@ func (j *Job) Allocations(args *structs.JobSpecificRequest,
 	// Check for read-job permissions
 	if aclObj, err := j.srv.ResolveToken(args.AuthToken); err != nil {
 		return err
-	} else if aclObj != nil && !aclObj.AllowNsOp(args.RequestNamespace(), acl.NamespaceCapabilityReadJob) {
+	} else if aclObj != nil && !aclObj.AllowJobRead(args.RequestNamespace(), args.JobID) {
 		return structs.ErrPermissionDenied
 	}
 
@@ -1002,7 +1263,7 @@ func (j *Job) Evaluations(args *structs.JobSpecificRequest,
 	// Check for read-job permissions
 	if aclObj, err := j.srv.ResolveToken(args.AuthToken); err != nil {
 		return err
-	} else if aclObj != nil && !aclObj.AllowNsOp(args.RequestNamespace(), acl.NamespaceCapabilityReadJob) {
+	} else if aclObj != nil && !aclObj.AllowJobRead(args.RequestNamespace(), args.JobID) {
 		return structs.ErrPermissionDenied
 	}
 
@@ -1044,7 +1305,7 @@ func (j *Job) Deployments(args *structs.JobSpecificRequest,
 	// Check for read-job permissions
 	if aclObj, err := j.srv.ResolveToken(args.AuthToken); err != nil {
 		return err
-	} else if aclObj != nil && !aclObj.AllowNsOp(args.RequestNamespace(), acl.NamespaceCapabilityReadJob) {
+	} else if aclObj != nil && !aclObj.AllowJobRead(args.RequestNamespace(), args.JobID) {
 		return structs.ErrPermissionDenied
 	}
 
@@ -1086,7 +1347,7 @@ func (j *Job) LatestDeployment(args *structs.JobSpecificRequest,
 	// Check for read-job permissions
 	if aclObj, err := j.srv.ResolveToken(args.AuthToken); err != nil {
 		return err
-	} else if aclObj != nil && !aclObj.AllowNsOp(args.RequestNamespace(), acl.NamespaceCapabilityReadJob) {
+	} else if aclObj != nil && !aclObj.AllowJobRead(args.RequestNamespace(), args.JobID) {
 		return structs.ErrPermissionDenied
 	}
 
@@ -1204,15 +1465,16 @@ func (j *Job) Plan(args *structs.JobPlanRequest, reply *structs.JobPlanResponse)
 	// Create an eval and mark it as requiring annotations and insert that as well
 	now := time.Now().UTC().UnixNano()
 	eval := &structs.Evaluation{
-		ID:             uuid.Generate(),
-		Namespace:      args.RequestNamespace(),
-		Priority:       args.Job.Priority,
-		Type:           args.Job.Type,
-		TriggeredBy:    structs.EvalTriggerJobRegister,
-		JobID:          args.Job.ID,
-		JobModifyIndex: updatedIndex,
-		Status:         structs.EvalStatusPending,
-		AnnotatePlan:   true,
+		ID:               uuid.Generate(),
+		Namespace:        args.RequestNamespace(),
+		Priority:         args.Job.Priority,
+		Type:             args.Job.Type,
+		TriggeredBy:      structs.EvalTriggerJobRegister,
+		JobID:            args.Job.ID,
+		JobModifyIndex:   updatedIndex,
+		Status:           structs.EvalStatusPending,
+		AnnotatePlan:     true,
+		ExplainPlacement: args.Explain,
 		// Timestamps are added for consistency but this eval is never persisted
 		CreateTime: now,
 		ModifyTime: now,
@@ -1267,6 +1529,10 @@ func (j *Job) Plan(args *structs.JobPlanRequest, reply *structs.JobPlanResponse)
 		}
 	}
 
+	if args.Explain {
+		reply.Explanations = planExplanations(planner.Plans[0], updatedEval.FailedTGAllocs)
+	}
+
 	reply.FailedTGAllocs = updatedEval.FailedTGAllocs
 	reply.JobModifyIndex = index
 	reply.Annotations = annotations
@@ -1275,6 +1541,51 @@ func (j *Job) Plan(args *structs.JobPlanRequest, reply *structs.JobPlanResponse)
 	return nil
 }
 
+// planExplanations returns the placement metrics per task group including
+// the explanation of every node evaluated. The metrics of a failed placement
+// take precedence, otherwise the metrics of the placed allocations are
+// merged so that system jobs, which evaluate one node per placement, cover
+// every node.
+func planExplanations(plan *structs.Plan, failed map[string]*structs.AllocMetric) map[string]*structs.AllocMetric {
+	explanations := make(map[string]*structs.AllocMetric)
+	for tg, metric := range failed {
+		explanations[tg] = metric.Copy()
+	}
+
+	// Sort the placements so the base metric of each task group is stable
+	var allocs []*structs.Allocation
+	for _, nodeAllocs := range plan.NodeAllocation {
+		allocs = append(allocs, nodeAllocs...)
+	}
+	sort.Slice(allocs, func(i, j int) bool {
+		return allocs[i].Name < allocs[j].Name
+	})
+
+	for _, alloc := range allocs {
+		if alloc.Metrics == nil {
+			continue
+		}
+
+		metric, ok := explanations[alloc.TaskGroup]
+		if !ok {
+			explanations[alloc.TaskGroup] = alloc.Metrics.Copy()
+			continue
+		}
+
+		for id, e := range alloc.Metrics.NodeExplanations {
+			if _, ok := metric.NodeExplanations[id]; ok {
+				continue
+			}
+			if metric.NodeExplanations == nil {
+				metric.NodeExplanations = make(map[string]*structs.NodeExplanation)
+			}
+			metric.NodeExplanations[id] = e.Copy()
+		}
+	}
+
+	return explanations
+}
+
 // validateJobUpdate ensures updates to a job are valid.
 func validateJobUpdate(old, new *structs.Job) error {
 	// Validate Dispatch not set on new Jobs
@@ -217,3 +217,34 @@ func (jobValidate) Validate(job *structs.Job) (warnings []error, err error) {
 
 	return warnings, validationErrors.ErrorOrNil()
 }
+
+// memoryOversubscriptionValidator warns when tasks set a memory_max limit
+// while memory oversubscription is disabled in the scheduler configuration.
+type memoryOversubscriptionValidator struct {
+	srv *Server
+}
+
+func (*memoryOversubscriptionValidator) Name() string {
+	return "memory_oversubscription"
+}
+
+func (v *memoryOversubscriptionValidator) Validate(job *structs.Job) (warnings []error, err error) {
+	_, c, err := v.srv.State().SchedulerConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	if c != nil && c.MemoryOversubscriptionEnabled {
+		return nil, nil
+	}
+
+	for _, tg := range job.TaskGroups {
+		for _, t := range tg.Tasks {
+			if t.Resources != nil && t.Resources.MemoryMaxMB != 0 {
+				warnings = append(warnings, fmt.Errorf("Memory oversubscription is not enabled; Task %q memory_max value will be ignored", tg.Name+"."+t.Name))
+			}
+		}
+	}
+
+	return warnings, nil
+}
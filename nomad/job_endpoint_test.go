@@ -728,6 +728,50 @@ func TestJobEndpoint_Register_Dispatched(t *testing.T) {
 	require.Contains(err.Error(), "job can't be submitted with 'Dispatched'")
 }
 
+func TestJobEndpoint_Register_MemoryMaxMB(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	// Create the register request with a task setting memory_max
+	job := mock.Job()
+	job.TaskGroups[0].Tasks[0].Resources.MemoryMaxMB = 2048
+	req := &structs.JobRegisterRequest{
+		Job: job,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+
+	// Memory oversubscription is disabled by default
+	var resp structs.JobRegisterResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Job.Register", req, &resp))
+	require.Contains(resp.Warnings, "memory_max value will be ignored")
+
+	// Enable memory oversubscription
+	configReq := &structs.SchedulerSetConfigRequest{
+		Config: structs.SchedulerConfiguration{
+			MemoryOversubscriptionEnabled: true,
+		},
+		WriteRequest: structs.WriteRequest{
+			Region: "global",
+		},
+	}
+	var configResp structs.SchedulerSetConfigurationResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Operator.SchedulerSetConfiguration", configReq, &configResp))
+
+	resp = structs.JobRegisterResponse{}
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Job.Register", req, &resp))
+	require.NotContains(resp.Warnings, "memory_max value will be ignored")
+}
+
 func TestJobEndpoint_Register_EnforceIndex(t *testing.T) {
 	t.Parallel()
 
@@ -1855,6 +1899,349 @@ func TestJobEndpoint_Stable_ACL(t *testing.T) {
 	require.Equal(true, out.Stable)
 }
 
+func TestJobEndpoint_Register_ScalingPolicy(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	// Register a job with a scaling policy
+	job := mock.Job()
+	job.TaskGroups[0].Scaling = &structs.ScalingPolicy{
+		Min:     1,
+		Max:     20,
+		Enabled: true,
+	}
+	req := &structs.JobRegisterRequest{
+		Job: job.Copy(),
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+	var resp structs.JobRegisterResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Job.Register", req, &resp))
+
+	state := s1.fsm.State()
+	policies, err := state.ScalingPoliciesByJob(nil, job.Namespace, job.ID)
+	require.NoError(err)
+	require.Len(policies, 1)
+	policy := policies[job.TaskGroups[0].Name]
+	require.NotEmpty(policy.ID)
+
+	// Registering the same job again doesn't change the job or the policy
+	req.Job = job.Copy()
+	var resp2 structs.JobRegisterResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Job.Register", req, &resp2))
+	require.Equal(resp.JobModifyIndex, resp2.JobModifyIndex)
+
+	out, err := state.ScalingPolicyByID(nil, policy.ID)
+	require.NoError(err)
+	require.NotNil(out)
+	require.Equal(policy.ModifyIndex, out.ModifyIndex)
+}
+
+func TestJobEndpoint_Scale(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	state := s1.fsm.State()
+
+	job := mock.Job()
+	job.TaskGroups[0].Scaling = &structs.ScalingPolicy{
+		ID:      uuid.Generate(),
+		Min:     1,
+		Max:     20,
+		Enabled: true,
+	}
+	groupName := job.TaskGroups[0].Name
+	require.NoError(state.UpsertJob(1000, job))
+
+	scale := &structs.JobScaleRequest{
+		JobID: job.ID,
+		Target: map[string]string{
+			structs.ScalingTargetGroup: groupName,
+		},
+		Count:   helper.Int64ToPtr(13),
+		Message: "because of the load",
+		Meta:    map[string]interface{}{"metric": 0.9},
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+	var resp structs.JobRegisterResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Job.Scale", scale, &resp))
+	require.NotEmpty(resp.EvalID)
+	require.NotZero(resp.Index)
+
+	// Check the job's count was updated
+	out, err := state.JobByID(nil, job.Namespace, job.ID)
+	require.NoError(err)
+	require.Equal(13, out.LookupTaskGroup(groupName).Count)
+	require.Equal(resp.JobModifyIndex, out.JobModifyIndex)
+
+	// Check the eval was created
+	eval, err := state.EvalByID(nil, resp.EvalID)
+	require.NoError(err)
+	require.NotNil(eval)
+	require.Equal(structs.EvalTriggerScaling, eval.TriggeredBy)
+
+	// Check the scaling event was recorded
+	events, _, err := state.ScalingEventsByJob(nil, job.Namespace, job.ID)
+	require.NoError(err)
+	require.Len(events[groupName], 1)
+	require.Equal("because of the load", events[groupName][0].Message)
+	require.EqualValues(13, *events[groupName][0].Count)
+	require.Equal(resp.EvalID, *events[groupName][0].EvalID)
+
+	// Scaling beyond the policy's bounds fails unless it is overridden
+	scale.Count = helper.Int64ToPtr(21)
+	err = msgpackrpc.CallWithCodec(codec, "Job.Scale", scale, &resp)
+	require.Error(err)
+	require.Contains(err.Error(), "greater than scaling policy maximum")
+
+	scale.PolicyOverride = true
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Job.Scale", scale, &resp))
+	out, err = state.JobByID(nil, job.Namespace, job.ID)
+	require.NoError(err)
+	require.Equal(21, out.LookupTaskGroup(groupName).Count)
+
+	// An error event is recorded without changing the count
+	errEvent := &structs.JobScaleRequest{
+		JobID: job.ID,
+		Target: map[string]string{
+			structs.ScalingTargetGroup: groupName,
+		},
+		Message: "metrics unavailable",
+		Error:   true,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+	var errResp structs.JobRegisterResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Job.Scale", errEvent, &errResp))
+	require.Empty(errResp.EvalID)
+
+	events, _, err = state.ScalingEventsByJob(nil, job.Namespace, job.ID)
+	require.NoError(err)
+	require.Len(events[groupName], 3)
+	require.True(events[groupName][0].Error)
+	require.Nil(events[groupName][0].Count)
+}
+
+func TestJobEndpoint_Scale_Invalid(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	state := s1.fsm.State()
+
+	job := mock.Job()
+	require.NoError(state.UpsertJob(1000, job))
+
+	cases := []struct {
+		name    string
+		jobID   string
+		group   string
+		count   *int64
+		isError bool
+		wantErr string
+	}{
+		{
+			name:    "missing group",
+			jobID:   job.ID,
+			count:   helper.Int64ToPtr(2),
+			wantErr: "missing task group name",
+		},
+		{
+			name:    "unknown group",
+			jobID:   job.ID,
+			group:   "foo",
+			count:   helper.Int64ToPtr(2),
+			wantErr: "does not exist",
+		},
+		{
+			name:    "negative count",
+			jobID:   job.ID,
+			group:   job.TaskGroups[0].Name,
+			count:   helper.Int64ToPtr(-1),
+			wantErr: "can't be negative",
+		},
+		{
+			name:    "count with error",
+			jobID:   job.ID,
+			group:   job.TaskGroups[0].Name,
+			count:   helper.Int64ToPtr(2),
+			isError: true,
+			wantErr: "should not contain count",
+		},
+		{
+			name:    "unknown job",
+			jobID:   "foo",
+			group:   job.TaskGroups[0].Name,
+			count:   helper.Int64ToPtr(2),
+			wantErr: "not found",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			scale := &structs.JobScaleRequest{
+				JobID: c.jobID,
+				Target: map[string]string{
+					structs.ScalingTargetGroup: c.group,
+				},
+				Count: c.count,
+				Error: c.isError,
+				WriteRequest: structs.WriteRequest{
+					Region:    "global",
+					Namespace: job.Namespace,
+				},
+			}
+			var resp structs.JobRegisterResponse
+			err := msgpackrpc.CallWithCodec(codec, "Job.Scale", scale, &resp)
+			require.Error(err)
+			require.Contains(err.Error(), c.wantErr)
+		})
+	}
+}
+
+func TestJobEndpoint_Scale_ACL(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	s1, root, cleanupS1 := TestACLServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	state := s1.fsm.State()
+
+	job := mock.Job()
+	require.NoError(state.UpsertJob(1000, job))
+
+	scale := &structs.JobScaleRequest{
+		JobID: job.ID,
+		Target: map[string]string{
+			structs.ScalingTargetGroup: job.TaskGroups[0].Name,
+		},
+		Count: helper.Int64ToPtr(5),
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+
+	// Expect failure without a token
+	var resp structs.JobRegisterResponse
+	err := msgpackrpc.CallWithCodec(codec, "Job.Scale", scale, &resp)
+	require.Error(err)
+	require.Contains(err.Error(), "Permission denied")
+
+	// Expect failure with a read-job token
+	invalidToken := mock.CreatePolicyAndToken(t, state, 1003, "test-invalid",
+		mock.NamespacePolicy(structs.DefaultNamespace, "", []string{acl.NamespaceCapabilityReadJob}))
+	scale.AuthToken = invalidToken.SecretID
+	err = msgpackrpc.CallWithCodec(codec, "Job.Scale", scale, &resp)
+	require.Error(err)
+	require.Contains(err.Error(), "Permission denied")
+
+	// Expect success with a scale-job token, a submit-job token and a
+	// management token
+	scaleToken := mock.CreatePolicyAndToken(t, state, 1005, "test-scale",
+		mock.NamespacePolicy(structs.DefaultNamespace, "", []string{acl.NamespaceCapabilityScaleJob}))
+	submitToken := mock.CreatePolicyAndToken(t, state, 1007, "test-submit",
+		mock.NamespacePolicy(structs.DefaultNamespace, "", []string{acl.NamespaceCapabilitySubmitJob}))
+	for _, token := range []string{scaleToken.SecretID, submitToken.SecretID, root.SecretID} {
+		scale.AuthToken = token
+		require.NoError(msgpackrpc.CallWithCodec(codec, "Job.Scale", scale, &resp))
+	}
+}
+
+func TestJobEndpoint_ScaleStatus(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	state := s1.fsm.State()
+
+	job := mock.Job()
+	groupName := job.TaskGroups[0].Name
+
+	// Expect no status for an unknown job
+	get := &structs.JobScaleStatusRequest{
+		JobID: job.ID,
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+	var resp structs.JobScaleStatusResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Job.ScaleStatus", get, &resp))
+	require.Nil(resp.JobScaleStatus)
+
+	require.NoError(state.UpsertJob(1000, job))
+
+	alloc := mock.Alloc()
+	alloc.Job = job
+	alloc.JobID = job.ID
+	alloc.TaskGroup = groupName
+	alloc.ClientStatus = structs.AllocClientStatusRunning
+	stopped := mock.Alloc()
+	stopped.Job = job
+	stopped.JobID = job.ID
+	stopped.TaskGroup = groupName
+	stopped.DesiredStatus = structs.AllocDesiredStatusStop
+	require.NoError(state.UpsertAllocs(1001, []*structs.Allocation{alloc, stopped}))
+
+	require.NoError(state.UpsertScalingEvent(1002, &structs.ScalingEventRequest{
+		Namespace:    job.Namespace,
+		JobID:        job.ID,
+		TaskGroup:    groupName,
+		ScalingEvent: &structs.ScalingEvent{Message: "hello"},
+	}))
+
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Job.ScaleStatus", get, &resp))
+	require.EqualValues(1002, resp.Index)
+
+	status := resp.JobScaleStatus
+	require.NotNil(status)
+	require.Equal(job.ID, status.JobID)
+	require.False(status.JobStopped)
+
+	tgStatus := status.TaskGroups[groupName]
+	require.NotNil(tgStatus)
+	require.Equal(job.TaskGroups[0].Count, tgStatus.Desired)
+	require.Equal(1, tgStatus.Placed)
+	require.Equal(1, tgStatus.Running)
+	require.Len(tgStatus.Events, 1)
+	require.Equal("hello", tgStatus.Events[0].Message)
+}
+
 func TestJobEndpoint_Evaluate(t *testing.T) {
 	t.Parallel()
 
@@ -2839,6 +3226,61 @@ func TestJobEndpoint_GetJob_ACL(t *testing.T) {
 	require.Equal(job.ID, validResp2.Job.ID)
 }
 
+func TestJobEndpoint_GetJob_WorkloadIdentity(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	s1, _, cleanupS1 := TestACLServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	state := s1.fsm.State()
+
+	// Create two jobs and an allocation of the first one
+	job := mock.Job()
+	other := mock.Job()
+	require.Nil(state.UpsertJob(1000, job))
+	require.Nil(state.UpsertJob(1001, other))
+
+	alloc := mock.Alloc()
+	alloc.Job = job
+	alloc.JobID = job.ID
+	require.Nil(state.UpsertAllocs(1002, []*structs.Allocation{alloc}))
+
+	// Sign a workload identity for the allocation with the key of the leader
+	var key *structs.WorkloadIdentityKey
+	testutil.WaitForResult(func() (bool, error) {
+		var err error
+		key, err = state.WorkloadIdentityKey(nil)
+		return key != nil, err
+	}, func(err error) {
+		t.Fatalf("workload identity key not created: %v", err)
+	})
+	token, err := structs.SignWorkloadIdentity(key,
+		structs.NewWorkloadIdentityClaims(alloc, alloc.Job.TaskGroups[0].Tasks[0].Name, time.Now()))
+	require.Nil(err)
+
+	// The identity may read the job of its allocation
+	get := &structs.JobSpecificRequest{
+		JobID: job.ID,
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			Namespace: job.Namespace,
+			AuthToken: token,
+		},
+	}
+	var resp structs.SingleJobResponse
+	require.Nil(msgpackrpc.CallWithCodec(codec, "Job.GetJob", get, &resp))
+	require.Equal(job.ID, resp.Job.ID)
+
+	// But not the other jobs of the namespace
+	get.JobID = other.ID
+	var otherResp structs.SingleJobResponse
+	err = msgpackrpc.CallWithCodec(codec, "Job.GetJob", get, &otherResp)
+	require.NotNil(err)
+	require.Contains(err.Error(), "Permission denied")
+}
+
 func TestJobEndpoint_GetJob_Blocking(t *testing.T) {
 	t.Parallel()
 
@@ -4473,6 +4915,65 @@ func TestJobEndpoint_Plan_NoDiff(t *testing.T) {
 	}
 }
 
+func TestJobEndpoint_Plan_Explain(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	// Create a node that is feasible and one that fails the job constraint
+	state := s1.fsm.State()
+	node1 := mock.Node()
+	node2 := mock.Node()
+	node2.Attributes["kernel.name"] = "windows"
+	node2.ComputeClass()
+	require.NoError(state.UpsertNode(1000, node1))
+	require.NoError(state.UpsertNode(1001, node2))
+
+	// Create a plan request
+	job := mock.Job()
+	job.TaskGroups[0].Count = 1
+	planReq := &structs.JobPlanRequest{
+		Job:     job,
+		Explain: true,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+
+	// Fetch the response
+	var planResp structs.JobPlanResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Job.Plan", planReq, &planResp))
+	require.Empty(planResp.FailedTGAllocs)
+
+	metric := planResp.Explanations[job.TaskGroups[0].Name]
+	require.NotNil(metric)
+	require.Len(metric.NodeExplanations, 2)
+
+	ranked := metric.NodeExplanations[node1.ID]
+	require.NotNil(ranked)
+	require.Empty(ranked.Filtered)
+	require.Contains(ranked.Scores, "binpack")
+	require.NotZero(ranked.NormScore)
+
+	filtered := metric.NodeExplanations[node2.ID]
+	require.NotNil(filtered)
+	require.Equal(node2.Name, filtered.NodeName)
+	require.Contains(filtered.Filtered, "kernel.name")
+
+	// Plans without explain don't return explanations
+	planReq.Explain = false
+	planResp = structs.JobPlanResponse{}
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Job.Plan", planReq, &planResp))
+	require.Nil(planResp.Explanations)
+}
+
 func TestJobEndpoint_ImplicitConstraints_Vault(t *testing.T) {
 	t.Parallel()
 
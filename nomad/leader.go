@@ -44,6 +44,8 @@ var minAutopilotVersion = version.Must(version.NewVersion("0.8.0"))
 
 var minSchedulerConfigVersion = version.Must(version.NewVersion("0.9.0"))
 
+var minWorkloadIdentityVersion = version.Must(version.NewVersion("0.10.3"))
+
 // Default configuration for scheduler with preemption enabled for system jobs
 var defaultSchedulerConfig = &structs.SchedulerConfiguration{
 	PreemptionConfig: structs.PreemptionConfig{
@@ -169,6 +171,28 @@ WAIT:
 			goto RECONCILE
 		case member := <-reconcileCh:
 			s.reconcileMember(member)
+		case errCh := <-s.reassertLeaderCh:
+			// Leadership may not have been established if the initial
+			// attempt failed and is waiting to be retried.
+			if !establishedLeader {
+				errCh <- fmt.Errorf("leadership has not been established")
+				continue
+			}
+
+			// Rebuild the leader state from the current state store
+			if err := s.revokeLeadership(); err != nil {
+				s.logger.Error("failed to revoke leadership", "error", err)
+			}
+			err := s.establishLeadership(stopCh)
+			errCh <- err
+
+			// Retry establishing leadership on the next reconciliation
+			if err != nil {
+				s.logger.Error("failed to re-establish leadership", "error", err)
+				establishedLeader = false
+				interval = time.After(5 * time.Second)
+				goto WAIT
+			}
 		}
 	}
 }
@@ -199,7 +223,12 @@ func (s *Server) establishLeadership(stopCh chan struct{}) error {
 	s.autopilot.Start()
 
 	// Initialize scheduler configuration
-	s.getOrCreateSchedulerConfig()
+	schedConfig := s.getOrCreateSchedulerConfig()
+
+	// Initialize the key the plan applier signs workload identities with
+	if s.config.ACLEnabled {
+		s.initializeWorkloadIdentityKey()
+	}
 
 	// Enable the plan queue, since we are now the leader
 	s.planQueue.SetEnabled(true)
@@ -207,11 +236,11 @@ func (s *Server) establishLeadership(stopCh chan struct{}) error {
 	// Start the plan evaluator
 	go s.planApply()
 
-	// Enable the eval broker, since we are now the leader
-	s.evalBroker.SetEnabled(true)
-
-	// Enable the blocked eval tracker, since we are now the leader
-	s.blockedEvals.SetEnabled(true)
+	// Enable the eval broker and the blocked eval tracker, since we are now
+	// the leader, unless operators have paused them
+	paused := schedConfig != nil && schedConfig.PauseEvalBroker
+	s.evalBroker.SetEnabled(!paused)
+	s.blockedEvals.SetEnabled(!paused)
 	s.blockedEvals.SetTimetable(s.fsm.TimeTable())
 
 	// Enable the deployment watcher, since we are now the leader
@@ -275,7 +304,10 @@ func (s *Server) establishLeadership(stopCh chan struct{}) error {
 	// and we are not the authoritative region.
 	if s.config.ACLEnabled && s.config.Region != s.config.AuthoritativeRegion {
 		go s.replicateACLPolicies(stopCh)
+		go s.replicateACLRoles(stopCh)
 		go s.replicateACLTokens(stopCh)
+		go s.replicateACLAuthMethods(stopCh)
+		go s.replicateACLBindingRules(stopCh)
 	}
 
 	// Setup any enterprise systems required.
@@ -316,6 +348,31 @@ func (s *Server) restoreEvals() error {
 	return nil
 }
 
+// handleEvalBrokerStateChange pauses or resumes the eval broker and the blocked
+// eval tracker of the leader when the scheduler configuration changes. The
+// evaluations are restored when they are resumed, as they may have been
+// created or deleted while paused.
+func (s *Server) handleEvalBrokerStateChange(schedConfig *structs.SchedulerConfiguration) error {
+	if !s.IsLeader() || schedConfig == nil {
+		return nil
+	}
+
+	enabled := s.evalBroker.Enabled()
+	switch {
+	case enabled && schedConfig.PauseEvalBroker:
+		s.logger.Info("pausing eval broker")
+		s.evalBroker.SetEnabled(false)
+		s.blockedEvals.SetEnabled(false)
+	case !enabled && !schedConfig.PauseEvalBroker:
+		s.logger.Info("resuming eval broker")
+		s.evalBroker.SetEnabled(true)
+		s.blockedEvals.SetEnabled(true)
+		s.blockedEvals.SetTimetable(s.fsm.TimeTable())
+		return s.restoreEvals()
+	}
+	return nil
+}
+
 // restoreRevokingAccessors is used to restore Vault accessors that should be
 // revoked.
 func (s *Server) restoreRevokingAccessors() error {
@@ -447,6 +504,8 @@ func (s *Server) schedulePeriodic(stopCh chan struct{}) {
 	defer jobGC.Stop()
 	deploymentGC := time.NewTicker(s.config.DeploymentGCInterval)
 	defer deploymentGC.Stop()
+	tokenExpiredGC := time.NewTicker(s.config.ACLTokenExpirationGCInterval)
+	defer tokenExpiredGC.Stop()
 
 	// getLatest grabs the latest index from the state store. It returns true if
 	// the index was retrieved successfully.
@@ -479,6 +538,18 @@ func (s *Server) schedulePeriodic(stopCh chan struct{}) {
 			if index, ok := getLatest(); ok {
 				s.evalBroker.Enqueue(s.coreJobEval(structs.CoreJobDeploymentGC, index))
 			}
+		case <-tokenExpiredGC.C:
+			if !s.config.ACLEnabled {
+				continue
+			}
+			if index, ok := getLatest(); ok {
+				s.evalBroker.Enqueue(s.coreJobEval(structs.CoreJobLocalTokenExpiredGC, index))
+
+				// Global tokens are only deleted in the authoritative region
+				if s.config.Region == s.config.AuthoritativeRegion {
+					s.evalBroker.Enqueue(s.coreJobEval(structs.CoreJobGlobalTokenExpiredGC, index))
+				}
+			}
 		case <-stopCh:
 			return
 		}
@@ -511,7 +582,14 @@ func (s *Server) reapFailedEvaluations(stopCh chan struct{}) {
 			// Scan for a failed evaluation
 			eval, token, err := s.evalBroker.Dequeue([]string{failedQueue}, time.Second)
 			if err != nil {
-				return
+				// The eval broker is disabled while it is paused, so wait for
+				// it to be resumed rather than stopping to reap.
+				select {
+				case <-stopCh:
+					return
+				case <-time.After(time.Second):
+				}
+				continue
 			}
 			if eval == nil {
 				continue
@@ -1133,6 +1211,417 @@ func diffACLPolicies(state *state.StateStore, minIndex uint64, remoteList []*str
 	return
 }
 
+// replicateACLRoles is used to replicate ACL roles from
+// the authoritative region to this region.
+func (s *Server) replicateACLRoles(stopCh chan struct{}) {
+	req := structs.ACLRoleListRequest{
+		QueryOptions: structs.QueryOptions{
+			Region:     s.config.AuthoritativeRegion,
+			AllowStale: true,
+		},
+	}
+	limiter := rate.NewLimiter(replicationRateLimit, int(replicationRateLimit))
+	s.logger.Debug("starting ACL role replication from authoritative region", "authoritative_region", req.Region)
+
+START:
+	for {
+		select {
+		case <-stopCh:
+			return
+		default:
+			// Rate limit how often we attempt replication
+			limiter.Wait(context.Background())
+
+			// Fetch the list of roles
+			var resp structs.ACLRoleListResponse
+			req.AuthToken = s.ReplicationToken()
+			err := s.forwardRegion(s.config.AuthoritativeRegion,
+				"ACL.ListRoles", &req, &resp)
+			if err != nil {
+				s.logger.Error("failed to fetch roles from authoritative region", "error", err)
+				goto ERR_WAIT
+			}
+
+			// Perform a two-way diff
+			delete, update := diffACLRoles(s.State(), req.MinQueryIndex, resp.Roles)
+
+			// Delete roles that should not exist
+			if len(delete) > 0 {
+				args := &structs.ACLRoleDeleteRequest{
+					RoleIDs: delete,
+				}
+				_, _, err := s.raftApply(structs.ACLRoleDeleteRequestType, args)
+				if err != nil {
+					s.logger.Error("failed to delete roles", "error", err)
+					goto ERR_WAIT
+				}
+			}
+
+			// Fetch any outdated roles
+			var fetched []*structs.ACLRole
+			if len(update) > 0 {
+				req := structs.ACLRoleSetRequest{
+					RoleIDs: update,
+					QueryOptions: structs.QueryOptions{
+						Region:        s.config.AuthoritativeRegion,
+						AuthToken:     s.ReplicationToken(),
+						AllowStale:    true,
+						MinQueryIndex: resp.Index - 1,
+					},
+				}
+				var reply structs.ACLRoleSetResponse
+				if err := s.forwardRegion(s.config.AuthoritativeRegion,
+					"ACL.GetRolesByID", &req, &reply); err != nil {
+					s.logger.Error("failed to fetch roles from authoritative region", "error", err)
+					goto ERR_WAIT
+				}
+				for _, role := range reply.Roles {
+					fetched = append(fetched, role)
+				}
+			}
+
+			// Update local roles
+			if len(fetched) > 0 {
+				args := &structs.ACLRoleUpsertRequest{
+					Roles: fetched,
+				}
+				_, _, err := s.raftApply(structs.ACLRoleUpsertRequestType, args)
+				if err != nil {
+					s.logger.Error("failed to update roles", "error", err)
+					goto ERR_WAIT
+				}
+			}
+
+			// Update the minimum query index, blocks until there
+			// is a change.
+			req.MinQueryIndex = resp.Index
+		}
+	}
+
+ERR_WAIT:
+	select {
+	case <-time.After(s.config.ReplicationBackoff):
+		goto START
+	case <-stopCh:
+		return
+	}
+}
+
+// diffACLRoles is used to perform a two-way diff between the local
+// roles and the remote roles to determine which roles need to
+// be deleted or updated.
+func diffACLRoles(state *state.StateStore, minIndex uint64, remoteList []*structs.ACLRoleListStub) (delete []string, update []string) {
+	// Construct a set of the local and remote roles
+	local := make(map[string][]byte)
+	remote := make(map[string]struct{})
+
+	// Add all the local roles
+	iter, err := state.ACLRoles(nil)
+	if err != nil {
+		panic("failed to iterate local roles")
+	}
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		role := raw.(*structs.ACLRole)
+		local[role.ID] = role.Hash
+	}
+
+	// Iterate over the remote roles
+	for _, rr := range remoteList {
+		remote[rr.ID] = struct{}{}
+
+		// Check if the role is missing locally
+		if localHash, ok := local[rr.ID]; !ok {
+			update = append(update, rr.ID)
+
+			// Check if role is newer remotely and there is a hash mis-match.
+		} else if rr.ModifyIndex > minIndex && !bytes.Equal(localHash, rr.Hash) {
+			update = append(update, rr.ID)
+		}
+	}
+
+	// Check if role should be deleted
+	for lr := range local {
+		if _, ok := remote[lr]; !ok {
+			delete = append(delete, lr)
+		}
+	}
+	return
+}
+
+// replicateACLAuthMethods is used to replicate ACL auth methods from
+// the authoritative region to this region.
+func (s *Server) replicateACLAuthMethods(stopCh chan struct{}) {
+	req := structs.ACLAuthMethodListRequest{
+		QueryOptions: structs.QueryOptions{
+			Region:     s.config.AuthoritativeRegion,
+			AllowStale: true,
+		},
+	}
+	limiter := rate.NewLimiter(replicationRateLimit, int(replicationRateLimit))
+	s.logger.Debug("starting ACL auth method replication from authoritative region", "authoritative_region", req.Region)
+
+START:
+	for {
+		select {
+		case <-stopCh:
+			return
+		default:
+			// Rate limit how often we attempt replication
+			limiter.Wait(context.Background())
+
+			// Fetch the list of auth methods
+			var resp structs.ACLAuthMethodListResponse
+			req.AuthToken = s.ReplicationToken()
+			err := s.forwardRegion(s.config.AuthoritativeRegion,
+				"ACL.ListAuthMethods", &req, &resp)
+			if err != nil {
+				s.logger.Error("failed to fetch auth methods from authoritative region", "error", err)
+				goto ERR_WAIT
+			}
+
+			// Perform a two-way diff
+			delete, update := diffACLAuthMethods(s.State(), req.MinQueryIndex, resp.AuthMethods)
+
+			// Delete auth methods that should not exist
+			if len(delete) > 0 {
+				args := &structs.ACLAuthMethodDeleteRequest{
+					MethodNames: delete,
+				}
+				_, _, err := s.raftApply(structs.ACLAuthMethodDeleteRequestType, args)
+				if err != nil {
+					s.logger.Error("failed to delete auth methods", "error", err)
+					goto ERR_WAIT
+				}
+			}
+
+			// Fetch any outdated auth methods
+			var fetched []*structs.ACLAuthMethod
+			if len(update) > 0 {
+				req := structs.ACLAuthMethodSetRequest{
+					MethodNames: update,
+					QueryOptions: structs.QueryOptions{
+						Region:        s.config.AuthoritativeRegion,
+						AuthToken:     s.ReplicationToken(),
+						AllowStale:    true,
+						MinQueryIndex: resp.Index - 1,
+					},
+				}
+				var reply structs.ACLAuthMethodSetResponse
+				if err := s.forwardRegion(s.config.AuthoritativeRegion,
+					"ACL.GetAuthMethods", &req, &reply); err != nil {
+					s.logger.Error("failed to fetch auth methods from authoritative region", "error", err)
+					goto ERR_WAIT
+				}
+				for _, method := range reply.AuthMethods {
+					fetched = append(fetched, method)
+				}
+			}
+
+			// Update local auth methods
+			if len(fetched) > 0 {
+				args := &structs.ACLAuthMethodUpsertRequest{
+					AuthMethods: fetched,
+				}
+				_, _, err := s.raftApply(structs.ACLAuthMethodUpsertRequestType, args)
+				if err != nil {
+					s.logger.Error("failed to update auth methods", "error", err)
+					goto ERR_WAIT
+				}
+			}
+
+			// Update the minimum query index, blocks until there
+			// is a change.
+			req.MinQueryIndex = resp.Index
+		}
+	}
+
+ERR_WAIT:
+	select {
+	case <-time.After(s.config.ReplicationBackoff):
+		goto START
+	case <-stopCh:
+		return
+	}
+}
+
+// diffACLAuthMethods is used to perform a two-way diff between the local
+// auth methods and the remote auth methods to determine which auth methods need to
+// be deleted or updated.
+func diffACLAuthMethods(state *state.StateStore, minIndex uint64, remoteList []*structs.ACLAuthMethodListStub) (delete []string, update []string) {
+	// Construct a set of the local and remote auth methods
+	local := make(map[string][]byte)
+	remote := make(map[string]struct{})
+
+	// Add all the local auth methods
+	iter, err := state.ACLAuthMethods(nil)
+	if err != nil {
+		panic("failed to iterate local auth methods")
+	}
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		method := raw.(*structs.ACLAuthMethod)
+		local[method.Name] = method.Hash
+	}
+
+	// Iterate over the remote auth methods
+	for _, r := range remoteList {
+		remote[r.Name] = struct{}{}
+
+		// Check if the auth method is missing locally
+		if localHash, ok := local[r.Name]; !ok {
+			update = append(update, r.Name)
+
+			// Check if auth method is newer remotely and there is a hash mis-match.
+		} else if r.ModifyIndex > minIndex && !bytes.Equal(localHash, r.Hash) {
+			update = append(update, r.Name)
+		}
+	}
+
+	// Check if auth method should be deleted
+	for l := range local {
+		if _, ok := remote[l]; !ok {
+			delete = append(delete, l)
+		}
+	}
+	return
+}
+
+// replicateACLBindingRules is used to replicate ACL binding rules from
+// the authoritative region to this region.
+func (s *Server) replicateACLBindingRules(stopCh chan struct{}) {
+	req := structs.ACLBindingRuleListRequest{
+		QueryOptions: structs.QueryOptions{
+			Region:     s.config.AuthoritativeRegion,
+			AllowStale: true,
+		},
+	}
+	limiter := rate.NewLimiter(replicationRateLimit, int(replicationRateLimit))
+	s.logger.Debug("starting ACL binding rule replication from authoritative region", "authoritative_region", req.Region)
+
+START:
+	for {
+		select {
+		case <-stopCh:
+			return
+		default:
+			// Rate limit how often we attempt replication
+			limiter.Wait(context.Background())
+
+			// Fetch the list of binding rules
+			var resp structs.ACLBindingRuleListResponse
+			req.AuthToken = s.ReplicationToken()
+			err := s.forwardRegion(s.config.AuthoritativeRegion,
+				"ACL.ListBindingRules", &req, &resp)
+			if err != nil {
+				s.logger.Error("failed to fetch binding rules from authoritative region", "error", err)
+				goto ERR_WAIT
+			}
+
+			// Perform a two-way diff
+			delete, update := diffACLBindingRules(s.State(), req.MinQueryIndex, resp.BindingRules)
+
+			// Delete binding rules that should not exist
+			if len(delete) > 0 {
+				args := &structs.ACLBindingRuleDeleteRequest{
+					BindingRuleIDs: delete,
+				}
+				_, _, err := s.raftApply(structs.ACLBindingRuleDeleteRequestType, args)
+				if err != nil {
+					s.logger.Error("failed to delete binding rules", "error", err)
+					goto ERR_WAIT
+				}
+			}
+
+			// Fetch any outdated binding rules
+			var fetched []*structs.ACLBindingRule
+			if len(update) > 0 {
+				req := structs.ACLBindingRuleSetRequest{
+					BindingRuleIDs: update,
+					QueryOptions: structs.QueryOptions{
+						Region:        s.config.AuthoritativeRegion,
+						AuthToken:     s.ReplicationToken(),
+						AllowStale:    true,
+						MinQueryIndex: resp.Index - 1,
+					},
+				}
+				var reply structs.ACLBindingRuleSetResponse
+				if err := s.forwardRegion(s.config.AuthoritativeRegion,
+					"ACL.GetBindingRules", &req, &reply); err != nil {
+					s.logger.Error("failed to fetch binding rules from authoritative region", "error", err)
+					goto ERR_WAIT
+				}
+				for _, rule := range reply.BindingRules {
+					fetched = append(fetched, rule)
+				}
+			}
+
+			// Update local binding rules
+			if len(fetched) > 0 {
+				args := &structs.ACLBindingRuleUpsertRequest{
+					BindingRules: fetched,
+				}
+				_, _, err := s.raftApply(structs.ACLBindingRuleUpsertRequestType, args)
+				if err != nil {
+					s.logger.Error("failed to update binding rules", "error", err)
+					goto ERR_WAIT
+				}
+			}
+
+			// Update the minimum query index, blocks until there
+			// is a change.
+			req.MinQueryIndex = resp.Index
+		}
+	}
+
+ERR_WAIT:
+	select {
+	case <-time.After(s.config.ReplicationBackoff):
+		goto START
+	case <-stopCh:
+		return
+	}
+}
+
+// diffACLBindingRules is used to perform a two-way diff between the local
+// binding rules and the remote binding rules to determine which binding rules need to
+// be deleted or updated.
+func diffACLBindingRules(state *state.StateStore, minIndex uint64, remoteList []*structs.ACLBindingRuleListStub) (delete []string, update []string) {
+	// Construct a set of the local and remote binding rules
+	local := make(map[string][]byte)
+	remote := make(map[string]struct{})
+
+	// Add all the local binding rules
+	iter, err := state.ACLBindingRules(nil)
+	if err != nil {
+		panic("failed to iterate local binding rules")
+	}
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		rule := raw.(*structs.ACLBindingRule)
+		local[rule.ID] = rule.Hash
+	}
+
+	// Iterate over the remote binding rules
+	for _, r := range remoteList {
+		remote[r.ID] = struct{}{}
+
+		// Check if the binding rule is missing locally
+		if localHash, ok := local[r.ID]; !ok {
+			update = append(update, r.ID)
+
+			// Check if binding rule is newer remotely and there is a hash mis-match.
+		} else if r.ModifyIndex > minIndex && !bytes.Equal(localHash, r.Hash) {
+			update = append(update, r.ID)
+		}
+	}
+
+	// Check if binding rule should be deleted
+	for l := range local {
+		if _, ok := remote[l]; !ok {
+			delete = append(delete, l)
+		}
+	}
+	return
+}
+
 // replicateACLTokens is used to replicate global ACL tokens from
 // the authoritative region to this region.
 func (s *Server) replicateACLTokens(stopCh chan struct{}) {
@@ -1302,6 +1791,33 @@ func (s *Server) getOrCreateAutopilotConfig() *structs.AutopilotConfig {
 	return config
 }
 
+// initializeWorkloadIdentityKey generates the key workload identities are
+// signed with if it doesn't already exist.
+func (s *Server) initializeWorkloadIdentityKey() {
+	key, err := s.fsm.State().WorkloadIdentityKey(nil)
+	if err != nil {
+		s.logger.Named("core").Error("failed to get workload identity key", "error", err)
+		return
+	}
+	if key != nil {
+		return
+	}
+	if !ServersMeetMinimumVersion(s.Members(), minWorkloadIdentityVersion, false) {
+		s.logger.Named("core").Warn("can't initialize workload identity key until all servers are above minimum version", "min_version", minWorkloadIdentityVersion)
+		return
+	}
+
+	key, err = structs.NewWorkloadIdentityKey()
+	if err != nil {
+		s.logger.Named("core").Error("failed to generate workload identity key", "error", err)
+		return
+	}
+	req := structs.WorkloadIdentityKeyRequest{Key: key}
+	if _, _, err := s.raftApply(structs.WorkloadIdentityKeyRequestType, req); err != nil {
+		s.logger.Named("core").Error("failed to initialize workload identity key", "error", err)
+	}
+}
+
 // getOrCreateSchedulerConfig is used to get the scheduler config. We create a default
 // config if it doesn't already exist for bootstrapping an empty cluster
 func (s *Server) getOrCreateSchedulerConfig() *structs.SchedulerConfiguration {
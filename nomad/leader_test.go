@@ -571,6 +571,51 @@ func TestLeader_ReapFailedEval(t *testing.T) {
 	})
 }
 
+func TestLeader_ReapFailedEval_PausedEvalBroker(t *testing.T) {
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0
+		c.EvalDeliveryLimit = 1
+	})
+	defer cleanupS1()
+	testutil.WaitForLeader(t, s1.RPC)
+
+	// Pause the eval broker for long enough that the reaper fails to dequeue
+	require.Nil(t, s1.handleEvalBrokerStateChange(&structs.SchedulerConfiguration{PauseEvalBroker: true}))
+	require.False(t, s1.evalBroker.Enabled())
+	time.Sleep(1500 * time.Millisecond)
+	require.Nil(t, s1.handleEvalBrokerStateChange(&structs.SchedulerConfiguration{}))
+	require.True(t, s1.evalBroker.Enabled())
+
+	// Reach the delivery limit of an eval
+	eval := mock.Eval()
+	s1.evalBroker.Enqueue(eval)
+	out, token, err := s1.evalBroker.Dequeue(defaultSched, time.Second)
+	require.Nil(t, err)
+	require.NotNil(t, out)
+	require.Nil(t, s1.evalBroker.Nack(out.ID, token))
+
+	// The reaper marks it as failed and creates a follow-up
+	state := s1.fsm.State()
+	testutil.WaitForResult(func() (bool, error) {
+		out, err := state.EvalByID(nil, eval.ID)
+		if err != nil {
+			return false, err
+		}
+		if out == nil {
+			return false, fmt.Errorf("expect original evaluation to exist")
+		}
+		if out.Status != structs.EvalStatusFailed {
+			return false, fmt.Errorf("got status %v; want %v", out.Status, structs.EvalStatusFailed)
+		}
+		if out.NextEval == "" {
+			return false, fmt.Errorf("got empty NextEval")
+		}
+		return true, nil
+	}, func(err error) {
+		t.Fatalf("err: %v", err)
+	})
+}
+
 func TestLeader_ReapDuplicateEval(t *testing.T) {
 	s1, cleanupS1 := TestServer(t, func(c *Config) {
 		c.NumSchedulers = 0
@@ -698,6 +743,212 @@ func TestLeader_DiffACLPolicies(t *testing.T) {
 	assert.Equal(t, []string{p3.Name, p4.Name}, update)
 }
 
+func TestLeader_ReplicateACLRoles(t *testing.T) {
+	t.Parallel()
+
+	s1, root, cleanupS1 := TestACLServer(t, func(c *Config) {
+		c.Region = "region1"
+		c.AuthoritativeRegion = "region1"
+		c.ACLEnabled = true
+	})
+	defer cleanupS1()
+	s2, _, cleanupS2 := TestACLServer(t, func(c *Config) {
+		c.Region = "region2"
+		c.AuthoritativeRegion = "region1"
+		c.ACLEnabled = true
+		c.ReplicationBackoff = 20 * time.Millisecond
+		c.ReplicationToken = root.SecretID
+	})
+	defer cleanupS2()
+	TestJoin(t, s1, s2)
+	testutil.WaitForLeader(t, s1.RPC)
+	testutil.WaitForLeader(t, s2.RPC)
+
+	// Write a role to the authoritative region
+	r1 := mock.ACLRole()
+	require.NoError(t, s1.State().UpsertACLRoles(100, []*structs.ACLRole{r1}))
+
+	// Wait for the role to replicate
+	testutil.WaitForResult(func() (bool, error) {
+		state := s2.State()
+		out, err := state.ACLRoleByID(nil, r1.ID)
+		return out != nil, err
+	}, func(err error) {
+		t.Fatalf("should replicate role")
+	})
+}
+
+func TestLeader_ReplicateACLAuthMethods(t *testing.T) {
+	t.Parallel()
+
+	s1, root, cleanupS1 := TestACLServer(t, func(c *Config) {
+		c.Region = "region1"
+		c.AuthoritativeRegion = "region1"
+		c.ACLEnabled = true
+	})
+	defer cleanupS1()
+	s2, _, cleanupS2 := TestACLServer(t, func(c *Config) {
+		c.Region = "region2"
+		c.AuthoritativeRegion = "region1"
+		c.ACLEnabled = true
+		c.ReplicationBackoff = 20 * time.Millisecond
+		c.ReplicationToken = root.SecretID
+	})
+	defer cleanupS2()
+	TestJoin(t, s1, s2)
+	testutil.WaitForLeader(t, s1.RPC)
+	testutil.WaitForLeader(t, s2.RPC)
+
+	// Write an auth method and a binding rule to the authoritative region
+	m1 := mock.ACLAuthMethod()
+	require.NoError(t, s1.State().UpsertACLAuthMethods(100, []*structs.ACLAuthMethod{m1}))
+	r1 := mock.ACLBindingRule(m1.Name)
+	require.NoError(t, s1.State().UpsertACLBindingRules(101, []*structs.ACLBindingRule{r1}))
+
+	// Wait for the auth method and binding rule to replicate
+	testutil.WaitForResult(func() (bool, error) {
+		state := s2.State()
+		out, err := state.ACLAuthMethodByName(nil, m1.Name)
+		if err != nil || out == nil {
+			return false, err
+		}
+		rule, err := state.ACLBindingRuleByID(nil, r1.ID)
+		return rule != nil, err
+	}, func(err error) {
+		t.Fatalf("should replicate auth method and binding rule")
+	})
+}
+
+func TestLeader_WorkloadIdentityKey(t *testing.T) {
+	t.Parallel()
+
+	s1, _, cleanupS1 := TestACLServer(t, nil)
+	defer cleanupS1()
+	testutil.WaitForLeader(t, s1.RPC)
+
+	// The leader generates the key once ACLs are enabled
+	testutil.WaitForResult(func() (bool, error) {
+		key, err := s1.State().WorkloadIdentityKey(nil)
+		return key != nil, err
+	}, func(err error) {
+		t.Fatalf("should initialize workload identity key")
+	})
+	key, err := s1.State().WorkloadIdentityKey(nil)
+	require.NoError(t, err)
+
+	// Regaining leadership keeps the existing key
+	s1.initializeWorkloadIdentityKey()
+	out, err := s1.State().WorkloadIdentityKey(nil)
+	require.NoError(t, err)
+	require.Equal(t, key, out)
+
+	// Servers without ACLs don't need a key
+	s2, cleanupS2 := TestServer(t, nil)
+	defer cleanupS2()
+	testutil.WaitForLeader(t, s2.RPC)
+	out, err = s2.State().WorkloadIdentityKey(nil)
+	require.NoError(t, err)
+	require.Nil(t, out)
+}
+
+func TestLeader_DiffACLRoles(t *testing.T) {
+	t.Parallel()
+
+	state := state.TestStateStore(t)
+
+	// Populate the local state
+	r1 := mock.ACLRole()
+	r2 := mock.ACLRole()
+	r3 := mock.ACLRole()
+	require.NoError(t, state.UpsertACLRoles(100, []*structs.ACLRole{r1, r2, r3}))
+
+	// Simulate a remote list
+	r2Stub := r2.Stub()
+	r2Stub.ModifyIndex = 50 // Ignored, same index
+	r3Stub := r3.Stub()
+	r3Stub.ModifyIndex = 100 // Updated, higher index
+	r3Stub.Hash = []byte{0, 1, 2, 3}
+	r4 := mock.ACLRole()
+	remoteList := []*structs.ACLRoleListStub{
+		r2Stub,
+		r3Stub,
+		r4.Stub(),
+	}
+	delete, update := diffACLRoles(state, 50, remoteList)
+
+	// R1 does not exist on the remote side, should delete
+	require.Equal(t, []string{r1.ID}, delete)
+
+	// R2 is un-modified - ignore. R3 modified, R4 new.
+	require.Equal(t, []string{r3.ID, r4.ID}, update)
+}
+
+func TestLeader_DiffACLAuthMethods(t *testing.T) {
+	t.Parallel()
+
+	state := state.TestStateStore(t)
+
+	// Populate the local state
+	m1 := mock.ACLAuthMethod()
+	m2 := mock.ACLAuthMethod()
+	m3 := mock.ACLAuthMethod()
+	require.NoError(t, state.UpsertACLAuthMethods(100, []*structs.ACLAuthMethod{m1, m2, m3}))
+
+	// Simulate a remote list
+	m2Stub := m2.Stub()
+	m2Stub.ModifyIndex = 50 // Ignored, same index
+	m3Stub := m3.Stub()
+	m3Stub.ModifyIndex = 100 // Updated, higher index
+	m3Stub.Hash = []byte{0, 1, 2, 3}
+	m4 := mock.ACLAuthMethod()
+	remoteList := []*structs.ACLAuthMethodListStub{
+		m2Stub,
+		m3Stub,
+		m4.Stub(),
+	}
+	delete, update := diffACLAuthMethods(state, 50, remoteList)
+
+	// M1 does not exist on the remote side, should delete
+	require.Equal(t, []string{m1.Name}, delete)
+
+	// M2 is un-modified - ignore. M3 modified, M4 new.
+	require.Equal(t, []string{m3.Name, m4.Name}, update)
+}
+
+func TestLeader_DiffACLBindingRules(t *testing.T) {
+	t.Parallel()
+
+	state := state.TestStateStore(t)
+
+	// Populate the local state
+	method := mock.ACLAuthMethod()
+	require.NoError(t, state.UpsertACLAuthMethods(99, []*structs.ACLAuthMethod{method}))
+	r1 := mock.ACLBindingRule(method.Name)
+	r2 := mock.ACLBindingRule(method.Name)
+	r3 := mock.ACLBindingRule(method.Name)
+	require.NoError(t, state.UpsertACLBindingRules(100, []*structs.ACLBindingRule{r1, r2, r3}))
+
+	// Simulate a remote list
+	r2Stub := r2.Stub()
+	r2Stub.ModifyIndex = 50 // Ignored, same index
+	r3Stub := r3.Stub()
+	r3Stub.ModifyIndex = 100 // Updated, higher index
+	r3Stub.Hash = []byte{0, 1, 2, 3}
+	r4 := mock.ACLBindingRule(method.Name)
+	remoteList := []*structs.ACLBindingRuleListStub{
+		r2Stub,
+		r3Stub,
+		r4.Stub(),
+	}
+	delete, update := diffACLBindingRules(state, 50, remoteList)
+
+	// R1 does not exist on the remote side, should delete
+	require.Equal(t, []string{r1.ID}, delete)
+
+	// R2 is un-modified - ignore. R3 modified, R4 new.
+	require.Equal(t, []string{r3.ID, r4.ID}, update)
+}
+
 func TestLeader_ReplicateACLTokens(t *testing.T) {
 	t.Parallel()
 
@@ -463,6 +463,151 @@ func BatchJob() *structs.Job {
 	return job
 }
 
+func LifecycleJob() *structs.Job {
+	job := &structs.Job{
+		Region:      "global",
+		ID:          fmt.Sprintf("mock-service-%s", uuid.Generate()),
+		Name:        "my-job",
+		Namespace:   structs.DefaultNamespace,
+		Type:        structs.JobTypeBatch,
+		Priority:    50,
+		AllAtOnce:   false,
+		Datacenters: []string{"dc1"},
+		TaskGroups: []*structs.TaskGroup{
+			{
+				Name:  "web",
+				Count: 1,
+				RestartPolicy: &structs.RestartPolicy{
+					Attempts: 0,
+					Interval: 10 * time.Minute,
+					Delay:    1 * time.Minute,
+					Mode:     structs.RestartPolicyModeFail,
+				},
+				Tasks: []*structs.Task{
+					{
+						Name:   "web",
+						Driver: "mock_driver",
+						Config: map[string]interface{}{
+							"run_for": "1s",
+						},
+						LogConfig: structs.DefaultLogConfig(),
+						Resources: &structs.Resources{
+							CPU:      1000,
+							MemoryMB: 256,
+						},
+					},
+					{
+						Name:   "side",
+						Driver: "mock_driver",
+						Config: map[string]interface{}{
+							"run_for": "1s",
+						},
+						Lifecycle: &structs.TaskLifecycleConfig{
+							Hook:    structs.TaskLifecycleHookPrestart,
+							Sidecar: true,
+						},
+						LogConfig: structs.DefaultLogConfig(),
+						Resources: &structs.Resources{
+							CPU:      1000,
+							MemoryMB: 256,
+						},
+					},
+					{
+						Name:   "init",
+						Driver: "mock_driver",
+						Config: map[string]interface{}{
+							"run_for": "1s",
+						},
+						Lifecycle: &structs.TaskLifecycleConfig{
+							Hook:    structs.TaskLifecycleHookPrestart,
+							Sidecar: false,
+						},
+						LogConfig: structs.DefaultLogConfig(),
+						Resources: &structs.Resources{
+							CPU:      1000,
+							MemoryMB: 256,
+						},
+					},
+				},
+			},
+		},
+		Meta: map[string]string{
+			"owner": "armon",
+		},
+		Status:         structs.JobStatusPending,
+		Version:        0,
+		CreateIndex:    42,
+		ModifyIndex:    99,
+		JobModifyIndex: 99,
+	}
+	job.Canonicalize()
+	return job
+}
+
+func LifecycleAlloc() *structs.Allocation {
+	alloc := &structs.Allocation{
+		ID:        uuid.Generate(),
+		EvalID:    uuid.Generate(),
+		NodeID:    "12345678-abcd-efab-cdef-123456789abc",
+		Namespace: structs.DefaultNamespace,
+		TaskGroup: "web",
+
+		// TODO Remove once clientv2 gets merged
+		Resources: &structs.Resources{
+			CPU:      500,
+			MemoryMB: 256,
+		},
+		TaskResources: map[string]*structs.Resources{
+			"web": {
+				CPU:      1000,
+				MemoryMB: 256,
+			},
+			"init": {
+				CPU:      1000,
+				MemoryMB: 256,
+			},
+			"side": {
+				CPU:      1000,
+				MemoryMB: 256,
+			},
+		},
+
+		AllocatedResources: &structs.AllocatedResources{
+			Tasks: map[string]*structs.AllocatedTaskResources{
+				"web": {
+					Cpu: structs.AllocatedCpuResources{
+						CpuShares: 1000,
+					},
+					Memory: structs.AllocatedMemoryResources{
+						MemoryMB: 256,
+					},
+				},
+				"init": {
+					Cpu: structs.AllocatedCpuResources{
+						CpuShares: 1000,
+					},
+					Memory: structs.AllocatedMemoryResources{
+						MemoryMB: 256,
+					},
+				},
+				"side": {
+					Cpu: structs.AllocatedCpuResources{
+						CpuShares: 1000,
+					},
+					Memory: structs.AllocatedMemoryResources{
+						MemoryMB: 256,
+					},
+				},
+			},
+		},
+		Job:           LifecycleJob(),
+		DesiredStatus: structs.AllocDesiredStatusRun,
+		ClientStatus:  structs.AllocClientStatusPending,
+	}
+	alloc.JobID = alloc.Job.ID
+	return alloc
+}
+
 func SystemJob() *structs.Job {
 	job := &structs.Job{
 		Region:      "global",
@@ -887,6 +1032,60 @@ func ACLToken() *structs.ACLToken {
 	return tk
 }
 
+func ACLRole() *structs.ACLRole {
+	role := &structs.ACLRole{
+		ID:          uuid.Generate(),
+		Name:        fmt.Sprintf("role-%s", uuid.Generate()),
+		Description: "Super cool role!",
+		Policies: []*structs.ACLRolePolicyLink{
+			{Name: "foo"},
+			{Name: "bar"},
+		},
+		CreateIndex: 10,
+		ModifyIndex: 20,
+	}
+	role.SetHash()
+	return role
+}
+
+func ACLAuthMethod() *structs.ACLAuthMethod {
+	method := &structs.ACLAuthMethod{
+		Name:          fmt.Sprintf("method-%s", uuid.Generate()[:8]),
+		Type:          structs.ACLAuthMethodTypeJWT,
+		TokenLocality: structs.ACLAuthMethodTokenLocalityLocal,
+		MaxTokenTTL:   time.Hour,
+		Config: &structs.ACLAuthMethodConfig{
+			JWKSURL:        "https://idp.example.com/jwks",
+			BoundAudiences: []string{"nomad"},
+			ClaimMappings: map[string]string{
+				"sub": "user",
+			},
+			ListClaimMappings: map[string]string{
+				"groups": "groups",
+			},
+		},
+		CreateIndex: 10,
+		ModifyIndex: 20,
+	}
+	method.SetHash()
+	return method
+}
+
+func ACLBindingRule(method string) *structs.ACLBindingRule {
+	rule := &structs.ACLBindingRule{
+		ID:          uuid.Generate(),
+		Description: "Super cool binding rule!",
+		AuthMethod:  method,
+		Selector:    `"engineering" in list.groups`,
+		BindType:    structs.ACLBindingRuleBindTypePolicy,
+		BindName:    "engineering",
+		CreateIndex: 10,
+		ModifyIndex: 20,
+	}
+	rule.SetHash()
+	return rule
+}
+
 func ACLManagementToken() *structs.ACLToken {
 	return &structs.ACLToken{
 		AccessorID:  uuid.Generate(),
@@ -289,10 +289,13 @@ func (n *Node) deregister(args *structs.NodeBatchDeregisterRequest,
 	reply *structs.NodeUpdateResponse,
 	raftApplyFn func() (interface{}, uint64, error),
 ) error {
-	// Check request permissions
-	if aclObj, err := n.srv.ResolveToken(args.AuthToken); err != nil {
+	// Check request permissions. Tokens may only be allowed to write the nodes
+	// matching the selectors of their node policies, which is checked once
+	// the nodes are found.
+	aclObj, err := n.srv.ResolveToken(args.AuthToken)
+	if err != nil {
 		return err
-	} else if aclObj != nil && !aclObj.AllowNodeWrite() {
+	} else if aclObj != nil && !aclObj.AllowAnyNodeWrite() {
 		return structs.ErrPermissionDenied
 	}
 
@@ -311,6 +314,9 @@ func (n *Node) deregister(args *structs.NodeBatchDeregisterRequest,
 		if node == nil {
 			return fmt.Errorf("node not found")
 		}
+		if aclObj != nil && !aclObj.AllowNodeWriteFor(node.NodeClass, node.Datacenter, node.Meta) {
+			return structs.ErrPermissionDenied
+		}
 	}
 
 	// Commit this update via Raft
@@ -495,10 +501,13 @@ func (n *Node) UpdateDrain(args *structs.NodeUpdateDrainRequest,
 	}
 	defer metrics.MeasureSince([]string{"nomad", "client", "update_drain"}, time.Now())
 
-	// Check node write permissions
-	if aclObj, err := n.srv.ResolveToken(args.AuthToken); err != nil {
+	// Check node write permissions. Tokens may only be allowed to write the
+	// nodes matching the selectors of their node policies, which is checked
+	// once the node is found.
+	aclObj, err := n.srv.ResolveToken(args.AuthToken)
+	if err != nil {
 		return err
-	} else if aclObj != nil && !aclObj.AllowNodeWrite() {
+	} else if aclObj != nil && !aclObj.AllowAnyNodeWrite() {
 		return structs.ErrPermissionDenied
 	}
 
@@ -522,6 +531,9 @@ func (n *Node) UpdateDrain(args *structs.NodeUpdateDrainRequest,
 	if node == nil {
 		return fmt.Errorf("node not found")
 	}
+	if aclObj != nil && !aclObj.AllowNodeWriteFor(node.NodeClass, node.Datacenter, node.Meta) {
+		return structs.ErrPermissionDenied
+	}
 
 	now := time.Now().UTC()
 
@@ -598,10 +610,13 @@ func (n *Node) UpdateEligibility(args *structs.NodeUpdateEligibilityRequest,
 	}
 	defer metrics.MeasureSince([]string{"nomad", "client", "update_eligibility"}, time.Now())
 
-	// Check node write permissions
-	if aclObj, err := n.srv.ResolveToken(args.AuthToken); err != nil {
+	// Check node write permissions. Tokens may only be allowed to write the
+	// nodes matching the selectors of their node policies, which is checked
+	// once the node is found.
+	aclObj, err := n.srv.ResolveToken(args.AuthToken)
+	if err != nil {
 		return err
-	} else if aclObj != nil && !aclObj.AllowNodeWrite() {
+	} else if aclObj != nil && !aclObj.AllowAnyNodeWrite() {
 		return structs.ErrPermissionDenied
 	}
 
@@ -632,6 +647,9 @@ func (n *Node) UpdateEligibility(args *structs.NodeUpdateEligibilityRequest,
 	if node == nil {
 		return fmt.Errorf("node not found")
 	}
+	if aclObj != nil && !aclObj.AllowNodeWriteFor(node.NodeClass, node.Datacenter, node.Meta) {
+		return structs.ErrPermissionDenied
+	}
 
 	if node.DrainStrategy != nil && args.Eligibility == structs.NodeSchedulingEligible {
 		return fmt.Errorf("can not set node's scheduling eligibility to eligible while it is draining")
@@ -693,10 +711,13 @@ func (n *Node) Evaluate(args *structs.NodeEvaluateRequest, reply *structs.NodeUp
 	}
 	defer metrics.MeasureSince([]string{"nomad", "client", "evaluate"}, time.Now())
 
-	// Check node write permissions
-	if aclObj, err := n.srv.ResolveToken(args.AuthToken); err != nil {
+	// Check node write permissions. Tokens may only be allowed to write the
+	// nodes matching the selectors of their node policies, which is checked
+	// once the node is found.
+	aclObj, err := n.srv.ResolveToken(args.AuthToken)
+	if err != nil {
 		return err
-	} else if aclObj != nil && !aclObj.AllowNodeWrite() {
+	} else if aclObj != nil && !aclObj.AllowAnyNodeWrite() {
 		return structs.ErrPermissionDenied
 	}
 
@@ -718,6 +739,9 @@ func (n *Node) Evaluate(args *structs.NodeEvaluateRequest, reply *structs.NodeUp
 	if node == nil {
 		return fmt.Errorf("node not found")
 	}
+	if aclObj != nil && !aclObj.AllowNodeWriteFor(node.NodeClass, node.Datacenter, node.Meta) {
+		return structs.ErrPermissionDenied
+	}
 
 	// Create the evaluation
 	evalIDs, evalIndex, err := n.createNodeEvals(args.NodeID, node.ModifyIndex)
@@ -748,8 +772,11 @@ func (n *Node) GetNode(args *structs.NodeSpecificRequest,
 	}
 	defer metrics.MeasureSince([]string{"nomad", "client", "get_node"}, time.Now())
 
-	// Check node read permissions
-	if aclObj, err := n.srv.ResolveToken(args.AuthToken); err != nil {
+	// Check node read permissions. Tokens may only be allowed to read the
+	// nodes matching the selectors of their node policies, which is checked
+	// once the node is found.
+	aclObj, err := n.srv.ResolveToken(args.AuthToken)
+	if err != nil {
 		// If ResolveToken had an unexpected error return that
 		if err != structs.ErrTokenNotFound {
 			return err
@@ -769,7 +796,7 @@ func (n *Node) GetNode(args *structs.NodeSpecificRequest,
 		if node == nil {
 			return structs.ErrTokenNotFound
 		}
-	} else if aclObj != nil && !aclObj.AllowNodeRead() {
+	} else if aclObj != nil && !aclObj.AllowAnyNodeRead() {
 		return structs.ErrPermissionDenied
 	}
 
@@ -789,6 +816,11 @@ func (n *Node) GetNode(args *structs.NodeSpecificRequest,
 				return err
 			}
 
+			if out != nil && aclObj != nil &&
+				!aclObj.AllowNodeReadFor(out.NodeClass, out.Datacenter, out.Meta) {
+				return structs.ErrPermissionDenied
+			}
+
 			// Setup the output
 			if out != nil {
 				// Clear the secret ID
@@ -820,12 +852,14 @@ func (n *Node) GetAllocs(args *structs.NodeSpecificRequest,
 	}
 	defer metrics.MeasureSince([]string{"nomad", "client", "get_allocs"}, time.Now())
 
-	// Check node read and namespace job read permissions
+	// Check node read and namespace job read permissions. Tokens may only be
+	// allowed to read the nodes matching the selectors of their node policies,
+	// which is checked once the node is found.
 	aclObj, err := n.srv.ResolveToken(args.AuthToken)
 	if err != nil {
 		return err
 	}
-	if aclObj != nil && !aclObj.AllowNodeRead() {
+	if aclObj != nil && !aclObj.AllowAnyNodeRead() {
 		return structs.ErrPermissionDenied
 	}
 
@@ -861,6 +895,16 @@ func (n *Node) GetAllocs(args *structs.NodeSpecificRequest,
 		queryMeta: &reply.QueryMeta,
 		run: func(ws memdb.WatchSet, state *state.StateStore) error {
 			// Look for the node
+			if aclObj != nil && !aclObj.AllowNodeRead() {
+				node, err := state.NodeByID(ws, args.NodeID)
+				if err != nil {
+					return err
+				}
+				if node == nil || !aclObj.AllowNodeReadFor(node.NodeClass, node.Datacenter, node.Meta) {
+					return structs.ErrPermissionDenied
+				}
+			}
+
 			allocs, err := state.AllocsByNode(ws, args.NodeID)
 			if err != nil {
 				return err
@@ -871,7 +915,7 @@ func (n *Node) GetAllocs(args *structs.NodeSpecificRequest,
 				reply.Allocs = make([]*structs.Allocation, 0, n)
 				for _, alloc := range allocs {
 					if readNS(alloc.Namespace) {
-						reply.Allocs = append(reply.Allocs, alloc)
+						reply.Allocs = append(reply.Allocs, scrubAllocIdentities(alloc, ""))
 					}
 
 					// Get the max of all allocs since
@@ -1214,10 +1258,12 @@ func (n *Node) List(args *structs.NodeListRequest,
 	}
 	defer metrics.MeasureSince([]string{"nomad", "client", "list"}, time.Now())
 
-	// Check node read permissions
-	if aclObj, err := n.srv.ResolveToken(args.AuthToken); err != nil {
+	// Check node read permissions. Tokens only allowed to read the nodes
+	// matching the selectors of their node policies only list those nodes.
+	aclObj, err := n.srv.ResolveToken(args.AuthToken)
+	if err != nil {
 		return err
-	} else if aclObj != nil && !aclObj.AllowNodeRead() {
+	} else if aclObj != nil && !aclObj.AllowAnyNodeRead() {
 		return structs.ErrPermissionDenied
 	}
 
@@ -1245,6 +1291,9 @@ func (n *Node) List(args *structs.NodeListRequest,
 					break
 				}
 				node := raw.(*structs.Node)
+				if aclObj != nil && !aclObj.AllowNodeReadFor(node.NodeClass, node.Datacenter, node.Meta) {
+					continue
+				}
 				nodes = append(nodes, node.Stub())
 			}
 			reply.Nodes = nodes
@@ -317,6 +317,82 @@ func TestClientEndpoint_Deregister_ACL(t *testing.T) {
 	}
 }
 
+func TestClientEndpoint_Deregister_ACL_NodeSelectors(t *testing.T) {
+	t.Parallel()
+
+	s1, _, cleanupS1 := TestACLServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	require := require.New(t)
+
+	// Create nodes in two datacenters
+	dc1 := mock.Node()
+	dc2 := mock.Node()
+	dc2.Datacenter = "dc2"
+	state := s1.fsm.State()
+	require.Nil(state.UpsertNode(1, dc1), "UpsertNode")
+	require.Nil(state.UpsertNode(2, dc2), "UpsertNode")
+
+	// Create a token allowed to write all the nodes but the ones of dc2
+	mock.CreatePolicy(t, state, 1001, "test-write", mock.NodePolicy(acl.PolicyWrite))
+	mock.CreatePolicy(t, state, 1002, "test-deny-dc2", `node { policy = "deny" datacenter = "dc2" }`)
+	token := mock.CreateToken(t, state, 1003, []string{"test-write", "test-deny-dc2"})
+
+	dereg := &structs.NodeBatchDeregisterRequest{
+		NodeIDs: []string{dc2.ID},
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			AuthToken: token.SecretID,
+		},
+	}
+
+	// Deregistering a node of dc2 is not allowed, alone or with a node of dc1
+	{
+		var resp structs.GenericResponse
+		err := msgpackrpc.CallWithCodec(codec, "Node.BatchDeregister", dereg, &resp)
+		require.NotNil(err, "RPC")
+		require.Equal(err.Error(), structs.ErrPermissionDenied.Error())
+	}
+	dereg.NodeIDs = []string{dc1.ID, dc2.ID}
+	{
+		var resp structs.GenericResponse
+		err := msgpackrpc.CallWithCodec(codec, "Node.BatchDeregister", dereg, &resp)
+		require.NotNil(err, "RPC")
+		require.Equal(err.Error(), structs.ErrPermissionDenied.Error())
+	}
+
+	// Neither is forcing the evaluation of a node of dc2
+	{
+		req := &structs.NodeEvaluateRequest{
+			NodeID:       dc2.ID,
+			WriteRequest: dereg.WriteRequest,
+		}
+		var resp structs.NodeUpdateResponse
+		err := msgpackrpc.CallWithCodec(codec, "Node.Evaluate", req, &resp)
+		require.NotNil(err, "RPC")
+		require.Equal(err.Error(), structs.ErrPermissionDenied.Error())
+	}
+
+	// Both nodes are still registered
+	out, err := state.NodeByID(nil, dc1.ID)
+	require.Nil(err)
+	require.NotNil(out)
+	out, err = state.NodeByID(nil, dc2.ID)
+	require.Nil(err)
+	require.NotNil(out)
+
+	// Deregistering a node of dc1 is allowed
+	dereg.NodeIDs = []string{dc1.ID}
+	{
+		var resp structs.GenericResponse
+		require.Nil(msgpackrpc.CallWithCodec(codec, "Node.BatchDeregister", dereg, &resp), "RPC")
+	}
+	out, err = state.NodeByID(nil, dc1.ID)
+	require.Nil(err)
+	require.Nil(out)
+}
+
 func TestClientEndpoint_Deregister_Vault(t *testing.T) {
 	t.Parallel()
 
@@ -1019,6 +1095,63 @@ func TestClientEndpoint_UpdateDrain_ACL(t *testing.T) {
 	}
 }
 
+func TestClientEndpoint_UpdateDrain_ACL_NodeSelectors(t *testing.T) {
+	t.Parallel()
+
+	s1, _, cleanupS1 := TestACLServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	require := require.New(t)
+
+	// Create the nodes of two teams
+	teamA := mock.Node()
+	teamA.Meta["team"] = "a"
+	teamB := mock.Node()
+	teamB.Meta["team"] = "b"
+	state := s1.fsm.State()
+	require.Nil(state.UpsertNode(1, teamA), "UpsertNode")
+	require.Nil(state.UpsertNode(2, teamB), "UpsertNode")
+
+	// Create a token allowed to write the nodes of team a only
+	policy := `
+node {
+	policy = "write"
+	meta {
+		team = "a"
+	}
+}`
+	token := mock.CreatePolicyAndToken(t, state, 1001, "test-team-a", policy)
+
+	dereg := &structs.NodeUpdateDrainRequest{
+		NodeID: teamA.ID,
+		DrainStrategy: &structs.DrainStrategy{
+			DrainSpec: structs.DrainSpec{
+				Deadline: 10 * time.Second,
+			},
+		},
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			AuthToken: token.SecretID,
+		},
+	}
+
+	// Draining a node of team a is allowed
+	{
+		var resp structs.NodeDrainUpdateResponse
+		require.Nil(msgpackrpc.CallWithCodec(codec, "Node.UpdateDrain", dereg, &resp), "RPC")
+	}
+
+	// Draining a node of team b is not
+	dereg.NodeID = teamB.ID
+	{
+		var resp structs.NodeDrainUpdateResponse
+		err := msgpackrpc.CallWithCodec(codec, "Node.UpdateDrain", dereg, &resp)
+		require.NotNil(err, "RPC")
+		require.Equal(err.Error(), structs.ErrPermissionDenied.Error())
+	}
+}
+
 // This test ensures that Nomad marks client state of allocations which are in
 // pending/running state to lost when a node is marked as down.
 func TestClientEndpoint_Drain_Down(t *testing.T) {
@@ -1266,6 +1399,57 @@ func TestClientEndpoint_UpdateEligibility_ACL(t *testing.T) {
 	}
 }
 
+func TestClientEndpoint_UpdateEligibility_ACL_NodeSelectors(t *testing.T) {
+	t.Parallel()
+
+	s1, _, cleanupS1 := TestACLServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	require := require.New(t)
+
+	// Create nodes of two classes
+	teamA := mock.Node()
+	teamA.NodeClass = "team-a-large"
+	teamB := mock.Node()
+	teamB.NodeClass = "team-b-large"
+	state := s1.fsm.State()
+	require.Nil(state.UpsertNode(1, teamA), "UpsertNode")
+	require.Nil(state.UpsertNode(2, teamB), "UpsertNode")
+
+	// Create a token allowed to write the nodes of the classes of team a
+	policy := `
+node {
+	policy = "write"
+	class = "team-a-*"
+}`
+	token := mock.CreatePolicyAndToken(t, state, 1001, "test-team-a", policy)
+
+	req := &structs.NodeUpdateEligibilityRequest{
+		NodeID:      teamA.ID,
+		Eligibility: structs.NodeSchedulingIneligible,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			AuthToken: token.SecretID,
+		},
+	}
+
+	// Updating the eligibility of a node of team a is allowed
+	{
+		var resp structs.NodeEligibilityUpdateResponse
+		require.Nil(msgpackrpc.CallWithCodec(codec, "Node.UpdateEligibility", req, &resp), "RPC")
+	}
+
+	// Updating the eligibility of a node of team b is not
+	req.NodeID = teamB.ID
+	{
+		var resp structs.NodeEligibilityUpdateResponse
+		err := msgpackrpc.CallWithCodec(codec, "Node.UpdateEligibility", req, &resp)
+		require.NotNil(err, "RPC")
+		require.Equal(err.Error(), structs.ErrPermissionDenied.Error())
+	}
+}
+
 func TestClientEndpoint_GetNode(t *testing.T) {
 	t.Parallel()
 
@@ -1399,6 +1583,58 @@ func TestClientEndpoint_GetNode_ACL(t *testing.T) {
 	}
 }
 
+func TestClientEndpoint_GetNode_ACL_NodeSelectors(t *testing.T) {
+	t.Parallel()
+
+	s1, _, cleanupS1 := TestACLServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	require := require.New(t)
+
+	// Create the nodes of two teams
+	teamA := mock.Node()
+	teamA.Meta["team"] = "a"
+	teamB := mock.Node()
+	teamB.Meta["team"] = "b"
+	state := s1.fsm.State()
+	require.Nil(state.UpsertNode(1, teamA), "UpsertNode")
+	require.Nil(state.UpsertNode(2, teamB), "UpsertNode")
+
+	// Create a token allowed to write the nodes of team a only
+	policy := `
+node {
+	policy = "write"
+	meta {
+		team = "a"
+	}
+}`
+	token := mock.CreatePolicyAndToken(t, state, 1001, "test-team-a", policy)
+
+	// Reading a node of team a is allowed
+	req := &structs.NodeSpecificRequest{
+		NodeID: teamA.ID,
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			AuthToken: token.SecretID,
+		},
+	}
+	{
+		var resp structs.SingleNodeResponse
+		require.Nil(msgpackrpc.CallWithCodec(codec, "Node.GetNode", req, &resp), "RPC")
+		require.Equal(teamA.ID, resp.Node.ID)
+	}
+
+	// Reading a node of team b is not
+	req.NodeID = teamB.ID
+	{
+		var resp structs.SingleNodeResponse
+		err := msgpackrpc.CallWithCodec(codec, "Node.GetNode", req, &resp)
+		require.NotNil(err, "RPC")
+		require.Equal(err.Error(), structs.ErrPermissionDenied.Error())
+	}
+}
+
 func TestClientEndpoint_GetNode_Blocking(t *testing.T) {
 	t.Parallel()
 
@@ -2666,6 +2902,55 @@ func TestClientEndpoint_ListNodes_ACL(t *testing.T) {
 	}
 }
 
+func TestClientEndpoint_ListNodes_ACL_NodeSelectors(t *testing.T) {
+	t.Parallel()
+
+	s1, _, cleanupS1 := TestACLServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	require := require.New(t)
+
+	// Create nodes in two datacenters
+	node1 := mock.Node()
+	node2 := mock.Node()
+	node2.Datacenter = "dc2"
+	state := s1.fsm.State()
+	require.Nil(state.UpsertNode(1, node1), "UpsertNode")
+	require.Nil(state.UpsertNode(2, node2), "UpsertNode")
+
+	// Create a token allowed to read the nodes of dc2 only
+	policy := `
+node {
+	policy = "read"
+	datacenter = "dc2"
+}`
+	token := mock.CreatePolicyAndToken(t, state, 1001, "test-dc2", policy)
+
+	// Only the nodes of dc2 are listed
+	req := &structs.NodeListRequest{
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			AuthToken: token.SecretID,
+		},
+	}
+	var resp structs.NodeListResponse
+	require.Nil(msgpackrpc.CallWithCodec(codec, "Node.List", req, &resp), "RPC")
+	require.Len(resp.Nodes, 1)
+	require.Equal(node2.ID, resp.Nodes[0].ID)
+
+	// Deny takes precedence for the nodes of dc2
+	mock.CreatePolicy(t, state, 1003, "test-deny-dc2", `
+node {
+	policy = "deny"
+	datacenter = "dc2"
+}`)
+	token = mock.CreateToken(t, state, 1004, []string{"test-dc2", "test-deny-dc2"})
+	req.AuthToken = token.SecretID
+	require.Nil(msgpackrpc.CallWithCodec(codec, "Node.List", req, &resp), "RPC")
+	require.Empty(resp.Nodes)
+}
+
 func TestClientEndpoint_ListNodes_Blocking(t *testing.T) {
 	t.Parallel()
 
@@ -1,15 +1,26 @@
 package nomad
 
 import (
+	"errors"
 	"fmt"
+	"io"
 	"net"
+	"sort"
+	"time"
 
+	metrics "github.com/armon/go-metrics"
 	log "github.com/hashicorp/go-hclog"
 
 	"github.com/hashicorp/consul/agent/consul/autopilot"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/helper/snapshot"
+	"github.com/hashicorp/nomad/helper/uuid"
 	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/scheduler"
 	"github.com/hashicorp/raft"
 	"github.com/hashicorp/serf/serf"
+	"github.com/ugorji/go/codec"
 )
 
 // Operator endpoint is used to perform low-level operator tasks for Nomad.
@@ -308,6 +319,11 @@ func (op *Operator) SchedulerSetConfiguration(args *structs.SchedulerSetConfigRe
 	if !ServersMeetMinimumVersion(op.srv.Members(), minSchedulerConfigVersion, false) {
 		return fmt.Errorf("All servers should be running version %v to update scheduler config", minSchedulerConfigVersion)
 	}
+
+	// Validate the configuration
+	if err := args.Config.Validate(); err != nil {
+		return structs.NewErrRPCCoded(400, err.Error())
+	}
 	// Apply the update
 	resp, index, err := op.srv.raftApply(structs.SchedulerConfigRequestType, args)
 	if err != nil {
@@ -323,7 +339,13 @@ func (op *Operator) SchedulerSetConfiguration(args *structs.SchedulerSetConfigRe
 		reply.Updated = respBool
 	}
 	reply.Index = index
-	return nil
+
+	// Pause or resume the eval broker to match the applied configuration
+	_, config, err := op.srv.fsm.State().SchedulerConfig()
+	if err != nil {
+		return err
+	}
+	return op.srv.handleEvalBrokerStateChange(config)
 }
 
 // SchedulerGetConfiguration is used to retrieve the current Scheduler configuration.
@@ -355,3 +377,319 @@ func (op *Operator) SchedulerGetConfiguration(args *structs.GenericRequest, repl
 
 	return nil
 }
+
+// SchedulerRebalance is used to compute a plan of allocation migrations that
+// reduces the fragmentation of the cluster. Unless it is a dry run, the
+// allocations are marked for migration and evaluations are created so the
+// schedulers move them.
+func (op *Operator) SchedulerRebalance(args *structs.SchedulerRebalanceRequest, reply *structs.SchedulerRebalanceResponse) error {
+	if done, err := op.srv.forward("Operator.SchedulerRebalance", args, args, reply); done {
+		return err
+	}
+
+	// This action requires operator write access.
+	rule, err := op.srv.ResolveToken(args.AuthToken)
+	if err != nil {
+		return err
+	} else if rule != nil && !rule.AllowOperatorWrite() {
+		return structs.ErrPermissionDenied
+	}
+
+	if args.MaxMigrations < 0 {
+		return structs.NewErrRPCCoded(400, "max migrations cannot be negative")
+	}
+	maxMigrations := args.MaxMigrations
+	if maxMigrations == 0 {
+		maxMigrations = structs.DefaultRebalanceMaxMigrations
+	}
+
+	snap, err := op.srv.fsm.State().Snapshot()
+	if err != nil {
+		return err
+	}
+
+	migrations, err := scheduler.ComputeRebalancePlan(snap, op.logger, maxMigrations)
+	if err != nil {
+		return err
+	}
+	reply.Migrations = migrations
+
+	if args.DryRun || len(migrations) == 0 {
+		return nil
+	}
+
+	// Mark the allocations for migration and create an evaluation per job
+	now := time.Now().UTC().UnixNano()
+	transitions := make(map[string]*structs.DesiredTransition, len(migrations))
+	evals := make(map[structs.NamespacedID]*structs.Evaluation)
+	for _, m := range migrations {
+		transitions[m.AllocID] = &structs.DesiredTransition{
+			Migrate: helper.BoolToPtr(true),
+		}
+
+		id := structs.NamespacedID{Namespace: m.Namespace, ID: m.JobID}
+		if _, ok := evals[id]; ok {
+			continue
+		}
+
+		job, err := snap.JobByID(nil, m.Namespace, m.JobID)
+		if err != nil {
+			return err
+		} else if job == nil {
+			return fmt.Errorf("job %q not found", m.JobID)
+		}
+
+		evals[id] = &structs.Evaluation{
+			ID:             uuid.Generate(),
+			Namespace:      job.Namespace,
+			Priority:       job.Priority,
+			Type:           job.Type,
+			TriggeredBy:    structs.EvalTriggerRebalance,
+			JobID:          job.ID,
+			JobModifyIndex: job.ModifyIndex,
+			Status:         structs.EvalStatusPending,
+			CreateTime:     now,
+			ModifyTime:     now,
+		}
+	}
+
+	req := &structs.AllocUpdateDesiredTransitionRequest{
+		Allocs: transitions,
+	}
+	for _, eval := range evals {
+		req.Evals = append(req.Evals, eval)
+		reply.EvalIDs = append(reply.EvalIDs, eval.ID)
+	}
+	sort.Strings(reply.EvalIDs)
+
+	_, index, err := op.srv.raftApply(structs.AllocUpdateDesiredTransitionRequestType, req)
+	if err != nil {
+		op.logger.Error("failed applying rebalance plan", "error", err)
+		return err
+	}
+	reply.Index = index
+	return nil
+}
+
+func (op *Operator) register() {
+	op.srv.streamingRpcs.Register("Operator.SnapshotSave", op.snapshotSave)
+	op.srv.streamingRpcs.Register("Operator.SnapshotRestore", op.snapshotRestore)
+}
+
+// forwardSnapshotRpc forwards a snapshot request to the leader of the region
+// of the request, returning whether the request was forwarded.
+func (op *Operator) forwardSnapshotRpc(method string, info structs.RPCInfo, args interface{}, conn io.ReadWriteCloser) (bool, error) {
+	var server *serverParts
+	if region := info.RequestRegion(); region != op.srv.Region() {
+		var err error
+		server, err = op.srv.findRegionServer(region)
+		if err != nil {
+			return true, err
+		}
+	} else if !info.IsRead() || !info.AllowStaleRead() {
+		isLeader, leader := op.srv.getLeader()
+		if isLeader {
+			return false, nil
+		}
+		if leader == nil {
+			return true, structs.ErrNoLeader
+		}
+		server = leader
+	} else {
+		return false, nil
+	}
+
+	return true, op.srv.forwardStreamingRpc(server, method, args, conn)
+}
+
+// snapshotAclCode returns the error code of the response of a snapshot request
+// when resolving its token fails.
+func snapshotAclCode(err error) int {
+	if err == structs.ErrTokenNotFound || err == structs.ErrTokenExpired || err == structs.ErrPermissionDenied {
+		return 403
+	}
+	return 500
+}
+
+// snapshotSave streams a snapshot of the state of the cluster. The response
+// header is followed by the snapshot archive.
+func (op *Operator) snapshotSave(conn io.ReadWriteCloser) {
+	defer conn.Close()
+	defer metrics.MeasureSince([]string{"nomad", "operator", "snapshot_save"}, time.Now())
+
+	var args structs.SnapshotSaveRequest
+	var reply structs.SnapshotSaveResponse
+	decoder := codec.NewDecoder(conn, structs.MsgpackHandle)
+	encoder := codec.NewEncoder(conn, structs.MsgpackHandle)
+
+	handleFailure := func(code int, err error) {
+		encoder.Encode(&structs.SnapshotSaveResponse{
+			ErrorCode: code,
+			ErrorMsg:  err.Error(),
+		})
+	}
+
+	if err := decoder.Decode(&args); err != nil {
+		handleFailure(500, err)
+		return
+	}
+
+	if done, err := op.forwardSnapshotRpc("Operator.SnapshotSave", &args, &args, conn); done {
+		if err != nil {
+			handleFailure(500, err)
+		}
+		return
+	}
+
+	// Check management permissions
+	if aclObj, err := op.srv.ResolveToken(args.AuthToken); err != nil {
+		handleFailure(snapshotAclCode(err), err)
+		return
+	} else if aclObj != nil && !aclObj.IsManagement() {
+		handleFailure(403, structs.ErrPermissionDenied)
+		return
+	}
+
+	// Take the snapshot
+	snap, err := snapshot.New(op.logger.Named("snapshot"), op.srv.raft)
+	if err != nil {
+		handleFailure(500, err)
+		return
+	}
+	defer snap.Close()
+
+	reply.SnapshotChecksum = snap.Checksum()
+	reply.Index = snap.Index()
+	op.srv.setQueryMeta(&reply.QueryMeta)
+
+	if err := encoder.Encode(&reply); err != nil {
+		op.logger.Error("failed to encode snapshot response", "error", err)
+		return
+	}
+	if _, err := io.Copy(conn, snap); err != nil {
+		op.logger.Error("failed to stream snapshot", "error", err)
+	}
+}
+
+// snapshotRestore restores the state of the cluster from the snapshot archive
+// following the request and re-establishes leadership so that the leader
+// reflects the restored state.
+func (op *Operator) snapshotRestore(conn io.ReadWriteCloser) {
+	defer conn.Close()
+	defer metrics.MeasureSince([]string{"nomad", "operator", "snapshot_restore"}, time.Now())
+
+	var args structs.SnapshotRestoreRequest
+	var reply structs.SnapshotRestoreResponse
+	decoder := codec.NewDecoder(conn, structs.MsgpackHandle)
+	encoder := codec.NewEncoder(conn, structs.MsgpackHandle)
+
+	handleFailure := func(code int, err error) {
+		encoder.Encode(&structs.SnapshotRestoreResponse{
+			ErrorCode: code,
+			ErrorMsg:  err.Error(),
+		})
+	}
+
+	if err := decoder.Decode(&args); err != nil {
+		handleFailure(500, err)
+		return
+	}
+
+	if done, err := op.forwardSnapshotRpc("Operator.SnapshotRestore", &args, &args, conn); done {
+		if err != nil {
+			handleFailure(500, err)
+		}
+		return
+	}
+
+	// Check management permissions
+	if aclObj, err := op.srv.ResolveToken(args.AuthToken); err != nil {
+		handleFailure(snapshotAclCode(err), err)
+		return
+	} else if aclObj != nil && !aclObj.IsManagement() {
+		handleFailure(403, structs.ErrPermissionDenied)
+		return
+	}
+
+	reader, errCh := decodeStreamOutput(decoder)
+	defer reader.Close()
+
+	if err := snapshot.Restore(op.logger.Named("snapshot"), reader, op.srv.raft); err != nil {
+		handleFailure(500, fmt.Errorf("failed to restore from snapshot: %v", err))
+		return
+	}
+	if err := <-errCh; err != nil {
+		handleFailure(400, fmt.Errorf("failed to read snapshot: %v", err))
+		return
+	}
+
+	// Reassert leadership so the leader state, such as the eval broker and
+	// the blocked evals, is rebuilt from the restored state.
+	timeoutCh := time.After(time.Minute)
+	leaderErrCh := make(chan error, 1)
+	select {
+	case op.srv.reassertLeaderCh <- leaderErrCh:
+	case <-timeoutCh:
+		handleFailure(500, fmt.Errorf("timed out waiting to re-run leader actions"))
+		return
+	}
+
+	select {
+	case err := <-leaderErrCh:
+		if err != nil {
+			handleFailure(500, err)
+			return
+		}
+	case <-timeoutCh:
+		handleFailure(500, fmt.Errorf("timed out waiting for re-run of leader actions"))
+		return
+	}
+
+	reply.Index, _ = op.srv.State().LatestIndex()
+	op.srv.setQueryMeta(&reply.QueryMeta)
+	encoder.Encode(&reply)
+}
+
+// decodeStreamOutput returns a reader of the payloads of the StreamErrWrapper
+// messages decoded from a stream, which the sender ends with an io.EOF error.
+// The error channel is closed once the stream has been fully read, or receives
+// the error that ended the stream early.
+func decodeStreamOutput(decoder *codec.Decoder) (io.ReadCloser, <-chan error) {
+	pr, pw := io.Pipe()
+	errCh := make(chan error, 1)
+
+	go func() {
+		defer close(errCh)
+
+		for {
+			var wrapper cstructs.StreamErrWrapper
+			if err := decoder.Decode(&wrapper); err != nil {
+				pw.CloseWithError(fmt.Errorf("failed to decode input: %v", err))
+				errCh <- err
+				return
+			}
+
+			if len(wrapper.Payload) != 0 {
+				if _, err := pw.Write(wrapper.Payload); err != nil {
+					pw.CloseWithError(err)
+					errCh <- err
+					return
+				}
+			}
+
+			if rpcErr := wrapper.Error; rpcErr != nil {
+				if rpcErr.Message == io.EOF.Error() {
+					pw.Close()
+					return
+				}
+
+				err := errors.New(rpcErr.Message)
+				pw.CloseWithError(err)
+				errCh <- err
+				return
+			}
+		}
+	}()
+
+	return pr, errCh
+}
@@ -1,20 +1,29 @@
 package nomad
 
 import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
 	"fmt"
+	"io"
+	"net"
 	"reflect"
 	"strings"
 	"testing"
 
 	msgpackrpc "github.com/hashicorp/net-rpc-msgpackrpc"
 	"github.com/hashicorp/nomad/acl"
+	cstructs "github.com/hashicorp/nomad/client/structs"
 	"github.com/hashicorp/nomad/helper/freeport"
+	"github.com/hashicorp/nomad/helper/snapshot"
+	"github.com/hashicorp/nomad/helper/uuid"
 	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/testutil"
 	"github.com/hashicorp/raft"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/ugorji/go/codec"
 )
 
 func TestOperator_RaftGetConfiguration(t *testing.T) {
@@ -424,6 +433,46 @@ func TestOperator_SchedulerSetConfiguration(t *testing.T) {
 	require.False(reply.SchedulerConfig.PreemptionConfig.SystemSchedulerEnabled)
 }
 
+func TestOperator_SchedulerSetConfiguration_PauseEvalBroker(t *testing.T) {
+	t.Parallel()
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.Build = "0.9.0+unittest"
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	require := require.New(t)
+	require.True(s1.evalBroker.Enabled())
+
+	// Create a pending eval to be restored when the broker is resumed
+	eval := mock.Eval()
+
+	// Pause the eval broker
+	arg := structs.SchedulerSetConfigRequest{
+		Config: structs.SchedulerConfiguration{
+			PauseEvalBroker: true,
+		},
+	}
+	arg.Region = s1.config.Region
+
+	var setResponse structs.SchedulerSetConfigurationResponse
+	require.Nil(msgpackrpc.CallWithCodec(codec, "Operator.SchedulerSetConfiguration", &arg, &setResponse))
+	require.False(s1.evalBroker.Enabled())
+	require.False(s1.blockedEvals.Enabled())
+
+	require.Nil(s1.fsm.State().UpsertEvals(setResponse.Index+1, []*structs.Evaluation{eval}))
+
+	// Resume the eval broker and check the pending eval was restored
+	arg.Config.PauseEvalBroker = false
+	require.Nil(msgpackrpc.CallWithCodec(codec, "Operator.SchedulerSetConfiguration", &arg, &setResponse))
+	require.True(s1.evalBroker.Enabled())
+	require.True(s1.blockedEvals.Enabled())
+	require.Equal(1, s1.evalBroker.Stats().TotalReady)
+}
+
 func TestOperator_SchedulerGetConfiguration_ACL(t *testing.T) {
 	t.Parallel()
 
@@ -521,3 +570,348 @@ func TestOperator_SchedulerSetConfiguration_ACL(t *testing.T) {
 	}
 
 }
+
+func TestOperator_SchedulerRebalance(t *testing.T) {
+	t.Parallel()
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	state := s1.fsm.State()
+
+	require := require.New(t)
+
+	// Create a job with an allocation alone on a node and a more utilized
+	// node it can move onto
+	node1, node2 := mock.Node(), mock.Node()
+	require.NoError(state.UpsertNode(1000, node1))
+	require.NoError(state.UpsertNode(1001, node2))
+
+	job1, job2 := mock.Job(), mock.Job()
+	require.NoError(state.UpsertJob(1002, job1))
+	require.NoError(state.UpsertJob(1003, job2))
+
+	alloc := mock.Alloc()
+	alloc.Job = job1
+	alloc.JobID = job1.ID
+	alloc.NodeID = node1.ID
+	alloc.ClientStatus = structs.AllocClientStatusRunning
+	var allocs []*structs.Allocation
+	allocs = append(allocs, alloc)
+	for i := 0; i < 3; i++ {
+		a := mock.Alloc()
+		a.Job = job2
+		a.JobID = job2.ID
+		a.NodeID = node2.ID
+		a.Name = fmt.Sprintf("my-job.web[%d]", i)
+		a.ClientStatus = structs.AllocClientStatusRunning
+		allocs = append(allocs, a)
+	}
+	require.NoError(state.UpsertAllocs(1004, allocs))
+
+	// A dry run proposes the migration without applying it
+	arg := structs.SchedulerRebalanceRequest{
+		DryRun: true,
+		WriteRequest: structs.WriteRequest{
+			Region: s1.config.Region,
+		},
+	}
+	var reply structs.SchedulerRebalanceResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Operator.SchedulerRebalance", &arg, &reply))
+	require.Len(reply.Migrations, 1)
+	require.Equal(alloc.ID, reply.Migrations[0].AllocID)
+	require.Equal(node2.ID, reply.Migrations[0].TargetNodeID)
+	require.Empty(reply.EvalIDs)
+
+	out, err := state.AllocByID(nil, alloc.ID)
+	require.NoError(err)
+	require.False(out.DesiredTransition.ShouldMigrate())
+
+	// Applying the plan marks the allocation for migration and creates an
+	// evaluation for its job
+	arg.DryRun = false
+	reply = structs.SchedulerRebalanceResponse{}
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Operator.SchedulerRebalance", &arg, &reply))
+	require.Len(reply.Migrations, 1)
+	require.Len(reply.EvalIDs, 1)
+	require.NotZero(reply.Index)
+
+	out, err = state.AllocByID(nil, alloc.ID)
+	require.NoError(err)
+	require.True(out.DesiredTransition.ShouldMigrate())
+
+	eval, err := state.EvalByID(nil, reply.EvalIDs[0])
+	require.NoError(err)
+	require.NotNil(eval)
+	require.Equal(job1.ID, eval.JobID)
+	require.Equal(structs.EvalTriggerRebalance, eval.TriggeredBy)
+}
+
+func TestOperator_SchedulerRebalance_ACL(t *testing.T) {
+	t.Parallel()
+
+	s1, root, cleanupS1 := TestACLServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	state := s1.fsm.State()
+
+	// Create ACL token
+	invalidToken := mock.CreatePolicyAndToken(t, state, 1001, "test-invalid", mock.NodePolicy(acl.PolicyWrite))
+
+	arg := structs.SchedulerRebalanceRequest{
+		DryRun: true,
+	}
+	arg.Region = s1.config.Region
+
+	require := require.New(t)
+	var reply structs.SchedulerRebalanceResponse
+
+	// Try with no token and expect permission denied
+	err := msgpackrpc.CallWithCodec(codec, "Operator.SchedulerRebalance", &arg, &reply)
+	require.EqualError(err, structs.ErrPermissionDenied.Error())
+
+	// Try with an invalid token and expect permission denied
+	arg.AuthToken = invalidToken.SecretID
+	err = msgpackrpc.CallWithCodec(codec, "Operator.SchedulerRebalance", &arg, &reply)
+	require.EqualError(err, structs.ErrPermissionDenied.Error())
+
+	// Try with root token, should succeed
+	arg.AuthToken = root.SecretID
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Operator.SchedulerRebalance", &arg, &reply))
+	require.Empty(reply.Migrations)
+}
+
+// testOperatorSnapshotSave takes a snapshot through the Operator.SnapshotSave
+// streaming RPC of the server and returns the response and the archive.
+func testOperatorSnapshotSave(t *testing.T, s *Server, req *structs.SnapshotSaveRequest) (*structs.SnapshotSaveResponse, []byte) {
+	handler, err := s.StreamingRpcHandler("Operator.SnapshotSave")
+	require.NoError(t, err)
+
+	p1, p2 := net.Pipe()
+	defer p1.Close()
+	defer p2.Close()
+	go handler(p2)
+
+	encoder := codec.NewEncoder(p1, structs.MsgpackHandle)
+	decoder := codec.NewDecoder(p1, structs.MsgpackHandle)
+	require.NoError(t, encoder.Encode(req))
+
+	var resp structs.SnapshotSaveResponse
+	require.NoError(t, decoder.Decode(&resp))
+
+	var buf bytes.Buffer
+	if resp.ErrorMsg == "" {
+		_, err := io.Copy(&buf, p1)
+		require.NoError(t, err)
+	}
+	return &resp, buf.Bytes()
+}
+
+// testOperatorSnapshotRestore restores a snapshot through the
+// Operator.SnapshotRestore streaming RPC of the server.
+func testOperatorSnapshotRestore(t *testing.T, s *Server, req *structs.SnapshotRestoreRequest, archive []byte) *structs.SnapshotRestoreResponse {
+	handler, err := s.StreamingRpcHandler("Operator.SnapshotRestore")
+	require.NoError(t, err)
+
+	p1, p2 := net.Pipe()
+	defer p1.Close()
+	defer p2.Close()
+	go handler(p2)
+
+	encoder := codec.NewEncoder(p1, structs.MsgpackHandle)
+	decoder := codec.NewDecoder(p1, structs.MsgpackHandle)
+	require.NoError(t, encoder.Encode(req))
+
+	// Stream the archive in chunks, ending with an EOF
+	go func() {
+		for len(archive) > 0 {
+			n := 1024
+			if n > len(archive) {
+				n = len(archive)
+			}
+			if err := encoder.Encode(&cstructs.StreamErrWrapper{Payload: archive[:n]}); err != nil {
+				return
+			}
+			archive = archive[n:]
+		}
+		encoder.Encode(&cstructs.StreamErrWrapper{
+			Error: cstructs.NewRpcError(io.EOF, nil),
+		})
+	}()
+
+	var resp structs.SnapshotRestoreResponse
+	require.NoError(t, decoder.Decode(&resp))
+	return &resp
+}
+
+func TestOperator_SnapshotSaveRestore(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	s1, cleanupS1 := TestServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	register := func(job *structs.Job) {
+		req := &structs.JobRegisterRequest{
+			Job: job,
+			WriteRequest: structs.WriteRequest{
+				Region:    "global",
+				Namespace: job.Namespace,
+			},
+		}
+		var resp structs.JobRegisterResponse
+		require.NoError(msgpackrpc.CallWithCodec(codec, "Job.Register", req, &resp))
+	}
+
+	// Register a job and take a snapshot
+	job1 := mock.Job()
+	register(job1)
+
+	saveResp, archive := testOperatorSnapshotSave(t, s1, &structs.SnapshotSaveRequest{
+		QueryOptions: structs.QueryOptions{Region: "global"},
+	})
+	require.Empty(saveResp.ErrorMsg)
+	require.NotZero(saveResp.Index)
+	require.True(saveResp.KnownLeader)
+
+	sum := sha256.Sum256(archive)
+	require.Equal("sha-256="+base64.StdEncoding.EncodeToString(sum[:]), saveResp.SnapshotChecksum)
+
+	meta, err := snapshot.Verify(bytes.NewReader(archive))
+	require.NoError(err)
+	require.Equal(saveResp.Index, meta.Index)
+
+	// Register another job and restore the snapshot
+	job2 := mock.Job()
+	register(job2)
+
+	restoreResp := testOperatorSnapshotRestore(t, s1, &structs.SnapshotRestoreRequest{
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}, archive)
+	require.Empty(restoreResp.ErrorMsg)
+
+	// Only the job of the snapshot exists
+	out, err := s1.fsm.State().JobByID(nil, job1.Namespace, job1.ID)
+	require.NoError(err)
+	require.NotNil(out)
+
+	out, err = s1.fsm.State().JobByID(nil, job2.Namespace, job2.ID)
+	require.NoError(err)
+	require.Nil(out)
+
+	// The leader is re-established and accepts writes
+	require.True(s1.IsLeader())
+	register(mock.Job())
+}
+
+func TestOperator_SnapshotRestore_Corrupt(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	s1, cleanupS1 := TestServer(t, nil)
+	defer cleanupS1()
+	testutil.WaitForLeader(t, s1.RPC)
+
+	saveResp, archive := testOperatorSnapshotSave(t, s1, &structs.SnapshotSaveRequest{
+		QueryOptions: structs.QueryOptions{Region: "global"},
+	})
+	require.Empty(saveResp.ErrorMsg)
+
+	// Corrupt the archive
+	archive[len(archive)/2] ^= 0xff
+
+	restoreResp := testOperatorSnapshotRestore(t, s1, &structs.SnapshotRestoreRequest{
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}, archive)
+	require.Equal(500, restoreResp.ErrorCode)
+	require.Contains(restoreResp.ErrorMsg, "failed to restore from snapshot")
+}
+
+func TestOperator_Snapshot_ACL(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	s1, root, cleanupS1 := TestACLServer(t, nil)
+	defer cleanupS1()
+	testutil.WaitForLeader(t, s1.RPC)
+	state := s1.fsm.State()
+
+	// Create a non-management token
+	invalidToken := mock.CreatePolicyAndToken(t, state, 1001, "test-invalid", mock.NodePolicy(acl.PolicyWrite))
+
+	cases := []struct {
+		Name  string
+		Token string
+		Code  int
+		Error string
+	}{
+		{"no token", "", 403, structs.ErrPermissionDenied.Error()},
+		{"invalid token", invalidToken.SecretID, 403, structs.ErrPermissionDenied.Error()},
+		{"unknown token", uuid.Generate(), 403, structs.ErrTokenNotFound.Error()},
+		{"management token", root.SecretID, 0, ""},
+	}
+
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			saveResp, archive := testOperatorSnapshotSave(t, s1, &structs.SnapshotSaveRequest{
+				QueryOptions: structs.QueryOptions{
+					Region:    "global",
+					AuthToken: c.Token,
+				},
+			})
+			require.Equal(c.Code, saveResp.ErrorCode)
+			require.Equal(c.Error, saveResp.ErrorMsg)
+
+			if c.Code != 0 {
+				restoreResp := testOperatorSnapshotRestore(t, s1, &structs.SnapshotRestoreRequest{
+					WriteRequest: structs.WriteRequest{
+						Region:    "global",
+						AuthToken: c.Token,
+					},
+				}, nil)
+				require.Equal(c.Code, restoreResp.ErrorCode)
+				require.Equal(c.Error, restoreResp.ErrorMsg)
+				return
+			}
+			require.NotEmpty(archive)
+		})
+	}
+}
+
+func TestOperator_SnapshotSave_Follower(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.BootstrapExpect = 2
+	})
+	defer cleanupS1()
+	s2, cleanupS2 := TestServer(t, func(c *Config) {
+		c.BootstrapExpect = 2
+	})
+	defer cleanupS2()
+	TestJoin(t, s1, s2)
+	testutil.WaitForLeader(t, s1.RPC)
+	testutil.WaitForLeader(t, s2.RPC)
+
+	follower := s1
+	if s1.IsLeader() {
+		follower = s2
+	}
+
+	// The request is forwarded to the leader
+	saveResp, archive := testOperatorSnapshotSave(t, follower, &structs.SnapshotSaveRequest{
+		QueryOptions: structs.QueryOptions{Region: "global"},
+	})
+	require.Empty(saveResp.ErrorMsg)
+	require.NotEmpty(archive)
+
+	_, err := snapshot.Verify(bytes.NewReader(archive))
+	require.NoError(err)
+}
@@ -25,6 +25,11 @@ type planner struct {
 	// planQueue is used to manage the submitted allocation
 	// plans that are waiting to be assessed by the leader
 	planQueue *PlanQueue
+
+	// raftApplyPlan dispatches the Raft transaction of a plan result. It is
+	// the server's raftApplyFuture, and is replaced by tests to control when
+	// plans are applied.
+	raftApplyPlan func(structs.MessageType, interface{}) (raft.ApplyFuture, error)
 }
 
 // newPlanner returns a new planner to be used for managing allocation plans.
@@ -36,12 +41,23 @@ func newPlanner(s *Server) (*planner, error) {
 	}
 
 	return &planner{
-		Server:    s,
-		log:       s.logger.Named("planner"),
-		planQueue: planQueue,
+		Server:        s,
+		log:           s.logger.Named("planner"),
+		planQueue:     planQueue,
+		raftApplyPlan: s.raftApplyFuture,
 	}, nil
 }
 
+// inflightPlan tracks a plan whose Raft application is outstanding.
+type inflightPlan struct {
+	// indexCh receives the plan's committed index or is closed if the plan
+	// failed to apply
+	indexCh chan uint64
+
+	// nodes is the set of nodes the plan result touches
+	nodes map[string]struct{}
+}
+
 // planApply is a long lived goroutine that reads plan allocations from
 // the plan queue, determines if they can be applied safely and applies
 // them via Raft.
@@ -62,6 +78,14 @@ func newPlanner(s *Server) (*planner, error) {
 // happy path, this lets us do productive work during the latency of
 // apply.
 //
+// Plans are only ever rejected because of the state of the nodes they
+// touch, so a plan whose nodes are disjoint from the nodes of the plans
+// being applied does not depend on whether those succeed. Such plans are
+// dispatched to Raft without waiting, up to MaxInflightPlans at a time,
+// which pipelines their application. A plan touching the nodes of a plan
+// being applied waits for all the plans being applied to complete first,
+// and is re-evaluated if its snapshot was missing their results.
+//
 // In the unhappy path (Raft transaction fails), effectively we only
 // wasted work during a time we would have been waiting anyways. However,
 // in anticipation of this case we cannot respond to the plan until
@@ -69,14 +93,19 @@ func newPlanner(s *Server) (*planner, error) {
 // but there are many of those and only a single plan verifier.
 //
 func (p *planner) planApply() {
-	// planIndexCh is used to track an outstanding application and receive
-	// its committed index while snap holds an optimistic state which
-	// includes that plan application.
-	var planIndexCh chan uint64
+	// inflight tracks the plans being applied, oldest first, and
+	// inflightNodes counts the plans being applied that touch each node.
+	var inflight []*inflightPlan
+	inflightNodes := make(map[string]int)
+
+	// snap holds an optimistic state which includes the plans being
+	// applied, unless snapComplete is false because it was taken while
+	// plans were being applied.
 	var snap *state.StateSnapshot
+	snapComplete := true
 
 	// prevPlanResultIndex is the index when the last PlanResult was
-	// committed. Since only the last plan is optimistically applied to the
+	// committed. Since plans are only optimistically applied to the
 	// snapshot, it's possible the current snapshot's and plan's indexes
 	// are less than the index the previous plan result was committed at.
 	// prevPlanResultIndex also guards against the previous plan committing
@@ -85,6 +114,11 @@ func (p *planner) planApply() {
 	// against an index older than the previous plan was committed at.
 	var prevPlanResultIndex uint64
 
+	maxInflight := p.config.MaxInflightPlans
+	if maxInflight < 1 {
+		maxInflight = 1
+	}
+
 	// Setup a worker pool with half the cores, with at least 1
 	poolSize := runtime.NumCPU() / 2
 	if poolSize == 0 {
@@ -93,6 +127,46 @@ func (p *planner) planApply() {
 	pool := NewEvaluatePool(poolSize, workerPoolBufferSize)
 	defer pool.Shutdown()
 
+	// reap stops tracking the oldest plan being applied once it completes,
+	// waiting for it if wait is set. It returns whether a plan was reaped.
+	reap := func(wait bool) bool {
+		if len(inflight) == 0 {
+			return false
+		}
+
+		var idx uint64
+		if wait {
+			idx = <-inflight[0].indexCh
+		} else {
+			select {
+			case idx = <-inflight[0].indexCh:
+			default:
+				return false
+			}
+		}
+
+		for nodeID := range inflight[0].nodes {
+			if inflightNodes[nodeID]--; inflightNodes[nodeID] == 0 {
+				delete(inflightNodes, nodeID)
+			}
+		}
+		inflight[0] = nil
+		inflight = inflight[1:]
+
+		// Plan completed. idx may be 0 if the plan failed to apply, in
+		// which case the snapshot includes a result that was never
+		// committed. A snapshot taken while plans were being applied is
+		// missing the result of the plan, which may be relied on now that
+		// its nodes are no longer tracked. Otherwise once no plans are being
+		// applied discard the snapshot to ensure future snapshots include
+		// the committed state.
+		prevPlanResultIndex = max(prevPlanResultIndex, idx)
+		if idx == 0 || !snapComplete || len(inflight) == 0 {
+			snap = nil
+		}
+		return true
+	}
+
 	for {
 		// Pull the next pending plan, exit if we are no longer leader
 		pending, err := p.planQueue.Dequeue(0)
@@ -100,16 +174,8 @@ func (p *planner) planApply() {
 			return
 		}
 
-		// If last plan has completed get a new snapshot
-		select {
-		case idx := <-planIndexCh:
-			// Previous plan committed. Discard snapshot and ensure
-			// future snapshots include this plan. idx may be 0 if
-			// plan failed to apply, so use max(prev, idx)
-			prevPlanResultIndex = max(prevPlanResultIndex, idx)
-			planIndexCh = nil
-			snap = nil
-		default:
+		// Stop tracking the plans that completed
+		for reap(false) {
 		}
 
 		if snap != nil {
@@ -123,17 +189,16 @@ func (p *planner) planApply() {
 		}
 
 		// Snapshot the state so that we have a consistent view of the world
-		// if no snapshot is available.
-		//  - planIndexCh will be nil if the previous plan result applied
-		//    during Dequeue
-		//  - snap will be nil if its index < max(prevIndex, curIndex)
-		if planIndexCh == nil || snap == nil {
+		// if no snapshot is available. The snapshot is missing the results
+		// of the plans still being applied.
+		if snap == nil {
 			snap, err = p.snapshotMinIndex(prevPlanResultIndex, pending.plan.SnapshotIndex)
 			if err != nil {
 				p.logger.Error("failed to snapshot state", "error", err)
 				pending.respond(nil, err)
 				continue
 			}
+			snapComplete = len(inflight) == 0
 		}
 
 		// Evaluate the plan
@@ -150,17 +215,55 @@ func (p *planner) planApply() {
 			continue
 		}
 
-		// Ensure any parallel apply is complete before starting the next one.
-		// This also limits how out of date our snapshot can be.
-		if planIndexCh != nil {
-			idx := <-planIndexCh
-			prevPlanResultIndex = max(prevPlanResultIndex, idx)
-			snap, err = p.snapshotMinIndex(prevPlanResultIndex, pending.plan.SnapshotIndex)
+		nodes := planResultNodes(result)
+		if nodesOverlap(inflightNodes, nodes) {
+			// Ensure the plans touching the same nodes are complete before
+			// starting this one. This also limits how out of date our
+			// snapshot can be.
+			for reap(true) {
+			}
+			fresh, err := p.snapshotMinIndex(prevPlanResultIndex, pending.plan.SnapshotIndex)
 			if err != nil {
 				p.logger.Error("failed to update snapshot state", "error", err)
 				pending.respond(nil, err)
 				continue
 			}
+
+			// The plan was evaluated without the results of the plans that
+			// were being applied, so evaluate it again.
+			if !snapComplete {
+				result, err = evaluatePlan(pool, fresh, pending.plan, p.logger)
+				if err != nil {
+					p.logger.Error("failed to evaluate plan", "error", err)
+					pending.respond(nil, err)
+					continue
+				}
+				if result.IsNoOp() {
+					pending.respond(result, nil)
+					continue
+				}
+				nodes = planResultNodes(result)
+			}
+
+			snap = fresh
+			snapComplete = true
+		} else {
+			// Limit the number of plans being applied concurrently
+			for len(inflight) >= maxInflight {
+				reap(true)
+			}
+			if snap == nil {
+				// A plan being applied failed while waiting and the snapshot
+				// has been discarded. The plan's nodes did not overlap so its
+				// evaluation still holds.
+				snap, err = p.snapshotMinIndex(prevPlanResultIndex, pending.plan.SnapshotIndex)
+				if err != nil {
+					p.logger.Error("failed to update snapshot state", "error", err)
+					pending.respond(nil, err)
+					continue
+				}
+				snapComplete = len(inflight) == 0
+			}
 		}
 
 		// Dispatch the Raft transaction for the plan
@@ -172,9 +275,42 @@ func (p *planner) planApply() {
 		}
 
 		// Respond to the plan in async; receive plan's committed index via chan
-		planIndexCh = make(chan uint64, 1)
-		go p.asyncPlanWait(planIndexCh, future, result, pending)
+		plan := &inflightPlan{
+			indexCh: make(chan uint64, 1),
+			nodes:   nodes,
+		}
+		inflight = append(inflight, plan)
+		for nodeID := range nodes {
+			inflightNodes[nodeID]++
+		}
+		go p.asyncPlanWait(plan.indexCh, future, result, pending)
+	}
+}
+
+// planResultNodes returns the set of nodes the plan result touches.
+func planResultNodes(result *structs.PlanResult) map[string]struct{} {
+	nodes := make(map[string]struct{}, len(result.NodeUpdate)+len(result.NodeAllocation))
+	for nodeID := range result.NodeUpdate {
+		nodes[nodeID] = struct{}{}
 	}
+	for nodeID := range result.NodeAllocation {
+		nodes[nodeID] = struct{}{}
+	}
+	for nodeID := range result.NodePreemptions {
+		nodes[nodeID] = struct{}{}
+	}
+	return nodes
+}
+
+// nodesOverlap returns whether any of the nodes is being touched by a plan
+// being applied.
+func nodesOverlap(inflightNodes map[string]int, nodes map[string]struct{}) bool {
+	for nodeID := range nodes {
+		if _, ok := inflightNodes[nodeID]; ok {
+			return true
+		}
+	}
+	return false
 }
 
 // snapshotMinIndex wraps SnapshotAfter with a 5s timeout and converts timeout
@@ -215,6 +351,13 @@ func (p *planner) applyPlan(plan *structs.Plan, result *structs.PlanResult, snap
 	preemptedJobIDs := make(map[structs.NamespacedID]struct{})
 	now := time.Now().UTC().UnixNano()
 
+	// Sign the workload identities of the tasks of new allocations
+	if p.config.ACLEnabled {
+		if err := p.signAllocIdentities(plan.Job, result.NodeAllocation); err != nil {
+			return nil, err
+		}
+	}
+
 	if ServersMeetMinimumVersion(p.Members(), MinVersionPlanNormalization, true) {
 		// Initialize the allocs request using the new optimized log entry format.
 		// Determine the minimum number of updates, could be more if there
@@ -302,7 +445,7 @@ func (p *planner) applyPlan(plan *structs.Plan, result *structs.PlanResult, snap
 	req.PreemptionEvals = evals
 
 	// Dispatch the Raft transaction
-	future, err := p.raftApplyFuture(structs.ApplyPlanResultsRequestType, &req)
+	future, err := p.raftApplyPlan(structs.ApplyPlanResultsRequestType, &req)
 	if err != nil {
 		return nil, err
 	}
@@ -317,6 +460,52 @@ func (p *planner) applyPlan(plan *structs.Plan, result *structs.PlanResult, snap
 	return future, nil
 }
 
+// signAllocIdentities signs a workload identity for each task of the
+// allocations that don't have one yet. Allocations are skipped until the
+// leader has generated the workload identity key.
+func (p *planner) signAllocIdentities(job *structs.Job, nodeAllocs map[string][]*structs.Allocation) error {
+	key, err := p.fsm.State().WorkloadIdentityKey(nil)
+	if err != nil {
+		return err
+	}
+	if key == nil {
+		return nil
+	}
+
+	now := time.Now().UTC()
+	for _, allocs := range nodeAllocs {
+		for _, alloc := range allocs {
+			if alloc.SignedIdentities != nil {
+				continue
+			}
+
+			allocJob := alloc.Job
+			if allocJob == nil {
+				allocJob = job
+			}
+			if allocJob == nil {
+				continue
+			}
+			tg := allocJob.LookupTaskGroup(alloc.TaskGroup)
+			if tg == nil {
+				continue
+			}
+
+			identities := make(map[string]string, len(tg.Tasks))
+			for _, task := range tg.Tasks {
+				claims := structs.NewWorkloadIdentityClaims(alloc, task.Name, now)
+				token, err := structs.SignWorkloadIdentity(key, claims)
+				if err != nil {
+					return fmt.Errorf("failed to sign workload identity of task %q: %v", task.Name, err)
+				}
+				identities[task.Name] = token
+			}
+			alloc.SignedIdentities = identities
+		}
+	}
+	return nil
+}
+
 // normalizePreemptedAlloc removes redundant fields from a preempted allocation and
 // returns AllocationDiff. Since a preempted allocation is always an existing allocation,
 // the struct returned by this method contains only the differential, which can be
@@ -631,6 +820,12 @@ func evaluateNodePlan(snap *state.StateSnapshot, plan *structs.Plan, nodeID stri
 		return true, "", nil
 	}
 
+	// If the plan only marks the allocations of a disconnected node as
+	// unknown, it always 'fits' since no resources change.
+	if isUnknownOnlyPlan(plan.NodeAllocation[nodeID]) {
+		return true, "", nil
+	}
+
 	// Get the node itself
 	ws := memdb.NewWatchSet()
 	node, err := snap.NodeByID(ws, nodeID)
@@ -687,3 +882,14 @@ func max(a, b uint64) uint64 {
 	}
 	return b
 }
+
+// isUnknownOnlyPlan returns whether all of the given allocations are being
+// marked as unknown because their node has disconnected.
+func isUnknownOnlyPlan(allocs []*structs.Allocation) bool {
+	for _, alloc := range allocs {
+		if alloc.ClientStatus != structs.AllocClientStatusUnknown {
+			return false
+		}
+	}
+	return true
+}
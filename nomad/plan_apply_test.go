@@ -1,7 +1,10 @@
 package nomad
 
 import (
+	"io/ioutil"
+	"os"
 	"reflect"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -389,6 +392,72 @@ func TestPlanApply_applyPlanWithNormalizedAllocs(t *testing.T) {
 	assert.Equal(index, evalOut.ModifyIndex)
 }
 
+func TestPlanApply_applyPlan_SignIdentities(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	s1, _, cleanupS1 := TestACLServer(t, nil)
+	defer cleanupS1()
+	testutil.WaitForLeader(t, s1.RPC)
+
+	// Wait for the leader to generate the workload identity key
+	var key *structs.WorkloadIdentityKey
+	testutil.WaitForResult(func() (bool, error) {
+		var err error
+		key, err = s1.State().WorkloadIdentityKey(nil)
+		return key != nil, err
+	}, func(err error) {
+		t.Fatalf("workload identity key not initialized: %v", err)
+	})
+
+	node := mock.Node()
+	testRegisterNode(t, s1, node)
+
+	// Create a new allocation and one that was already signed
+	alloc := mock.Alloc()
+	alloc.NodeID = node.ID
+	job := alloc.Job
+	alloc.Job = nil
+	signed := mock.Alloc()
+	signed.NodeID = node.ID
+	signed.Job = nil
+	signed.SignedIdentities = map[string]string{"web": "existing"}
+	require.NoError(s1.State().UpsertJobSummary(1000, mock.JobSummary(job.ID)))
+
+	plan := &structs.Plan{
+		Job: job,
+	}
+	planRes := &structs.PlanResult{
+		NodeAllocation: map[string][]*structs.Allocation{
+			node.ID: {alloc, signed},
+		},
+	}
+
+	snap, err := s1.State().Snapshot()
+	require.NoError(err)
+	future, err := s1.applyPlan(plan, planRes, snap)
+	require.NoError(err)
+	_, err = planWaitFuture(future)
+	require.NoError(err)
+
+	// The new allocation has a valid identity for each of its tasks
+	out, err := s1.State().AllocByID(nil, alloc.ID)
+	require.NoError(err)
+	require.Len(out.SignedIdentities, 1)
+	claims, err := structs.VerifyWorkloadIdentity(key, out.SignedIdentities["web"])
+	require.NoError(err)
+	require.Equal(alloc.ID, claims.AllocationID)
+	require.Equal(job.ID, claims.JobID)
+	require.Equal(alloc.Namespace, claims.Namespace)
+	require.Equal("web", claims.TaskGroup)
+	require.Equal("web", claims.Task)
+
+	// The existing identities are kept
+	out, err = s1.State().AllocByID(nil, signed.ID)
+	require.NoError(err)
+	require.Equal(map[string]string{"web": "existing"}, out.SignedIdentities)
+}
+
 func TestPlanApply_EvalPlan_Simple(t *testing.T) {
 	t.Parallel()
 	state := testStateStore(t)
@@ -958,6 +1027,40 @@ func TestPlanApply_EvalNodePlan_NodeFull_AllocEvict(t *testing.T) {
 	}
 }
 
+func TestPlanApply_EvalNodePlan_NodeDown_UnknownOnly(t *testing.T) {
+	t.Parallel()
+	alloc := mock.Alloc()
+	state := testStateStore(t)
+	node := mock.Node()
+	alloc.NodeID = node.ID
+	node.Status = structs.NodeStatusDown
+	state.UpsertNode(1000, node)
+	state.UpsertAllocs(1001, []*structs.Allocation{alloc})
+	snap, _ := state.Snapshot()
+
+	// Marking the allocations of a down node as unknown always fits
+	plan := &structs.Plan{
+		Job:            alloc.Job,
+		NodeAllocation: map[string][]*structs.Allocation{},
+	}
+	plan.AppendUnknownAlloc(alloc)
+
+	fit, reason, err := evaluateNodePlan(snap, plan, node.ID)
+	require.NoError(t, err)
+	require.True(t, fit)
+	require.Empty(t, reason)
+
+	// Placing new allocations on a down node does not fit
+	alloc2 := mock.Alloc()
+	alloc2.NodeID = node.ID
+	plan.AppendAlloc(alloc2)
+
+	fit, reason, err = evaluateNodePlan(snap, plan, node.ID)
+	require.NoError(t, err)
+	require.False(t, fit)
+	require.Equal(t, "node is not ready for placements", reason)
+}
+
 func TestPlanApply_EvalNodePlan_NodeDown_EvictOnly(t *testing.T) {
 	t.Parallel()
 	alloc := mock.Alloc()
@@ -992,3 +1095,383 @@ func TestPlanApply_EvalNodePlan_NodeDown_EvictOnly(t *testing.T) {
 		t.Fatalf("bad")
 	}
 }
+
+// testPlanApplyAlloc returns a small allocation of the job on the node
+// without networks so that many of them fit on a node.
+func testPlanApplyAlloc(job *structs.Job, nodeID string) *structs.Allocation {
+	alloc := mock.Alloc()
+	alloc.Job = job
+	alloc.JobID = job.ID
+	alloc.NodeID = nodeID
+	alloc.TaskResources["web"].Networks = nil
+	task := alloc.AllocatedResources.Tasks["web"]
+	task.Cpu.CpuShares = 10
+	task.Memory.MemoryMB = 10
+	task.Networks = nil
+	return alloc
+}
+
+func TestPlanApply_Pipelined_Disjoint(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0
+	})
+	defer cleanupS1()
+	testutil.WaitForLeader(t, s1.RPC)
+
+	job := mock.Job()
+	require.NoError(s1.State().UpsertJob(1000, job))
+
+	// Enqueue plans placing an allocation on distinct nodes before waiting
+	// on any of them
+	var futures []PlanFuture
+	var allocs []*structs.Allocation
+	for i := 0; i < 10; i++ {
+		node := mock.Node()
+		require.NoError(s1.State().UpsertNode(uint64(1001+i), node))
+
+		eval := mock.Eval()
+		eval.JobID = job.ID
+		require.NoError(s1.State().UpsertEvals(uint64(1101+i), []*structs.Evaluation{eval}))
+
+		alloc := testPlanApplyAlloc(job, node.ID)
+		allocs = append(allocs, alloc)
+		future, err := s1.planQueue.Enqueue(&structs.Plan{
+			EvalID:   eval.ID,
+			Priority: job.Priority,
+			Job:      job,
+			NodeAllocation: map[string][]*structs.Allocation{
+				node.ID: {alloc},
+			},
+		})
+		require.NoError(err)
+		futures = append(futures, future)
+	}
+
+	// All the plans are committed
+	for _, future := range futures {
+		result, err := future.Wait()
+		require.NoError(err)
+		require.Len(result.NodeAllocation, 1)
+		require.NotZero(result.AllocIndex)
+		require.Zero(result.RefreshIndex)
+	}
+
+	for _, alloc := range allocs {
+		out, err := s1.fsm.State().AllocByID(nil, alloc.ID)
+		require.NoError(err)
+		require.NotNil(out)
+	}
+}
+
+func TestPlanApply_Pipelined_Overlapping(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0
+	})
+	defer cleanupS1()
+	testutil.WaitForLeader(t, s1.RPC)
+
+	job := mock.Job()
+	require.NoError(s1.State().UpsertJob(1000, job))
+
+	node := mock.Node()
+	require.NoError(s1.State().UpsertNode(1001, node))
+
+	// Enqueue two plans each using most of the CPU of the same node before
+	// waiting on either of them
+	var futures []PlanFuture
+	for i := 0; i < 2; i++ {
+		eval := mock.Eval()
+		eval.JobID = job.ID
+		require.NoError(s1.State().UpsertEvals(uint64(1002+i), []*structs.Evaluation{eval}))
+
+		alloc := testPlanApplyAlloc(job, node.ID)
+		alloc.AllocatedResources.Tasks["web"].Cpu.CpuShares = node.NodeResources.Cpu.CpuShares * 3 / 4
+		future, err := s1.planQueue.Enqueue(&structs.Plan{
+			EvalID:   eval.ID,
+			Priority: job.Priority,
+			Job:      job,
+			NodeAllocation: map[string][]*structs.Allocation{
+				node.ID: {alloc},
+			},
+		})
+		require.NoError(err)
+		futures = append(futures, future)
+	}
+
+	// Only one of the plans may be committed
+	placed := 0
+	for _, future := range futures {
+		result, err := future.Wait()
+		require.NoError(err)
+		if len(result.NodeAllocation) != 0 {
+			placed++
+		} else {
+			require.NotZero(result.RefreshIndex)
+		}
+	}
+	require.Equal(1, placed)
+
+	allocs, err := s1.fsm.State().AllocsByNode(nil, node.ID)
+	require.NoError(err)
+	require.Len(allocs, 1)
+}
+
+// testHeldApplyFuture is a Raft apply future whose transaction is only
+// dispatched once it is released.
+type testHeldApplyFuture struct {
+	doneCh chan struct{}
+	index  uint64
+	resp   interface{}
+	err    error
+}
+
+func (f *testHeldApplyFuture) Error() error {
+	<-f.doneCh
+	return f.err
+}
+
+func (f *testHeldApplyFuture) Index() uint64         { return f.index }
+func (f *testHeldApplyFuture) Response() interface{} { return f.resp }
+
+// testHoldPlanApplies makes the planner hold the Raft transactions of the
+// next plans until the matching channel is closed. Later plans are applied
+// without delay. It returns a func returning the number of plans dispatched.
+func testHoldPlanApplies(s *Server, releaseChs ...chan struct{}) func() int {
+	apply := s.raftApplyFuture
+	var applied int32
+	s.planner.raftApplyPlan = func(t structs.MessageType, msg interface{}) (raft.ApplyFuture, error) {
+		n := int(atomic.AddInt32(&applied, 1))
+		if n > len(releaseChs) {
+			return apply(t, msg)
+		}
+
+		releaseCh := releaseChs[n-1]
+		f := &testHeldApplyFuture{doneCh: make(chan struct{})}
+		go func() {
+			defer close(f.doneCh)
+			<-releaseCh
+			future, err := apply(t, msg)
+			if err == nil {
+				err = future.Error()
+			}
+			if err != nil {
+				f.err = err
+				return
+			}
+			f.index, f.resp = future.Index(), future.Response()
+		}()
+		return f, nil
+	}
+	return func() int { return int(atomic.LoadInt32(&applied)) }
+}
+
+func TestPlanApply_Pipelined_ReapedPlanNodes(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0
+	})
+	defer cleanupS1()
+	testutil.WaitForLeader(t, s1.RPC)
+
+	job := mock.Job()
+	require.NoError(s1.State().UpsertJob(1000, job))
+
+	node1, node2 := mock.Node(), mock.Node()
+	require.NoError(s1.State().UpsertNode(1001, node1))
+	require.NoError(s1.State().UpsertNode(1002, node2))
+
+	// Hold the application of the first two plans
+	releaseA, releaseB := make(chan struct{}), make(chan struct{})
+	dispatched := testHoldPlanApplies(s1, releaseA, releaseB)
+	defer func() {
+		select {
+		case <-releaseB:
+		default:
+			close(releaseB)
+		}
+	}()
+
+	// Create the evals of the plans
+	var evals []*structs.Evaluation
+	for i := 0; i < 3; i++ {
+		eval := mock.Eval()
+		eval.JobID = job.ID
+		evals = append(evals, eval)
+	}
+	require.NoError(s1.State().UpsertEvals(1003, evals))
+
+	enqueue := func(nodeID string, snapshotIndex uint64) PlanFuture {
+		eval := evals[0]
+		evals = evals[1:]
+
+		alloc := testPlanApplyAlloc(job, nodeID)
+		alloc.AllocatedResources.Tasks["web"].Cpu.CpuShares = node1.NodeResources.Cpu.CpuShares * 3 / 4
+		future, err := s1.planQueue.Enqueue(&structs.Plan{
+			EvalID:        eval.ID,
+			Priority:      job.Priority,
+			Job:           job,
+			SnapshotIndex: snapshotIndex,
+			NodeAllocation: map[string][]*structs.Allocation{
+				nodeID: {alloc},
+			},
+		})
+		require.NoError(err)
+		return future
+	}
+
+	waitDispatched := func(n int) {
+		testutil.WaitForResult(func() (bool, error) {
+			return dispatched() == n, nil
+		}, func(err error) {
+			t.Fatalf("plans not dispatched: %v", err)
+		})
+	}
+
+	// Plan A is applied on node1
+	futureA := enqueue(node1.ID, 0)
+	waitDispatched(1)
+
+	// Plan B on node2 requires a newer snapshot, which is taken while plan A
+	// is being applied and is missing its result
+	otherJob := mock.Job()
+	require.NoError(s1.State().UpsertJob(5000, otherJob))
+	futureB := enqueue(node2.ID, 5000)
+
+	// Plan A completes while plan B is still being applied
+	waitDispatched(2)
+	close(releaseA)
+	resultA, err := futureA.Wait()
+	require.NoError(err)
+	require.Len(resultA.NodeAllocation, 1)
+
+	// Plan C also uses most of the CPU of node1, so it must be rejected
+	// even though plan A is no longer being applied
+	resultC, err := enqueue(node1.ID, 0).Wait()
+	require.NoError(err)
+	require.Empty(resultC.NodeAllocation)
+	require.NotZero(resultC.RefreshIndex)
+
+	close(releaseB)
+	resultB, err := futureB.Wait()
+	require.NoError(err)
+	require.Len(resultB.NodeAllocation, 1)
+
+	allocs, err := s1.fsm.State().AllocsByNode(nil, node1.ID)
+	require.NoError(err)
+	require.Len(allocs, 1)
+}
+
+func TestPlanApply_planResultNodes(t *testing.T) {
+	t.Parallel()
+
+	result := &structs.PlanResult{
+		NodeUpdate: map[string][]*structs.Allocation{
+			"a": {mock.Alloc()},
+		},
+		NodeAllocation: map[string][]*structs.Allocation{
+			"a": {mock.Alloc()},
+			"b": {mock.Alloc()},
+		},
+		NodePreemptions: map[string][]*structs.Allocation{
+			"c": {mock.Alloc()},
+		},
+	}
+
+	nodes := planResultNodes(result)
+	require.Equal(t, map[string]struct{}{"a": {}, "b": {}, "c": {}}, nodes)
+	require.True(t, nodesOverlap(map[string]int{"c": 1}, nodes))
+	require.False(t, nodesOverlap(map[string]int{"d": 1}, nodes))
+}
+
+// benchmarkPlanApply measures the throughput of the plan applier for plans
+// placing an allocation on one of many nodes, submitted faster than they
+// can be applied. The Raft log is stored on disk so that applying a plan
+// has a realistic latency.
+func benchmarkPlanApply(b *testing.B, maxInflightPlans int) {
+	dir, err := ioutil.TempDir("", "nomad")
+	if err != nil {
+		b.Fatalf("err: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	s1, cleanupS1 := TestServer(b, func(c *Config) {
+		c.NumSchedulers = 0
+		c.MaxInflightPlans = maxInflightPlans
+		c.DevMode = false
+		c.Bootstrap = true
+		c.DataDir = dir
+	})
+	defer cleanupS1()
+	testutil.WaitForLeader(b, s1.RPC)
+
+	job := mock.Job()
+	if err := s1.State().UpsertJob(1000, job); err != nil {
+		b.Fatalf("err: %v", err)
+	}
+
+	const numNodes = 256
+	nodes := make([]*structs.Node, numNodes)
+	for i := range nodes {
+		nodes[i] = mock.Node()
+		if err := s1.State().UpsertNode(uint64(1001+i), nodes[i]); err != nil {
+			b.Fatalf("err: %v", err)
+		}
+	}
+
+	evals := make([]*structs.Evaluation, b.N)
+	for i := range evals {
+		evals[i] = mock.Eval()
+		evals[i].JobID = job.ID
+	}
+	if err := s1.State().UpsertEvals(2000, evals); err != nil {
+		b.Fatalf("err: %v", err)
+	}
+
+	plans := make([]*structs.Plan, b.N)
+	for i := range plans {
+		node := nodes[i%numNodes]
+		plans[i] = &structs.Plan{
+			EvalID:   evals[i].ID,
+			Priority: job.Priority,
+			Job:      job,
+			NodeAllocation: map[string][]*structs.Allocation{
+				node.ID: {testPlanApplyAlloc(job, node.ID)},
+			},
+		}
+	}
+
+	b.ResetTimer()
+	futures := make([]PlanFuture, b.N)
+	for i, plan := range plans {
+		future, err := s1.planQueue.Enqueue(plan)
+		if err != nil {
+			b.Fatalf("err: %v", err)
+		}
+		futures[i] = future
+	}
+	for _, future := range futures {
+		result, err := future.Wait()
+		if err != nil {
+			b.Fatalf("err: %v", err)
+		}
+		if len(result.NodeAllocation) != 1 {
+			b.Fatalf("plan not applied: %#v", result)
+		}
+	}
+}
+
+func BenchmarkPlanApply_Serial(b *testing.B) {
+	benchmarkPlanApply(b, 1)
+}
+
+func BenchmarkPlanApply_Pipelined(b *testing.B) {
+	benchmarkPlanApply(b, 8)
+}
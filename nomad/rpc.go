@@ -528,6 +528,39 @@ func (r *rpcHandler) forwardRegion(region, method string, args interface{}, repl
 	return r.connPool.RPC(region, server.Addr, server.MajorVersion, method, args, reply)
 }
 
+// findRegionServer returns a random server of the given region, or an error if
+// no path to the region is known.
+func (r *rpcHandler) findRegionServer(region string) (*serverParts, error) {
+	r.peerLock.RLock()
+	defer r.peerLock.RUnlock()
+
+	servers := r.peers[region]
+	if len(servers) == 0 {
+		r.logger.Warn("no path found to region", "region", region)
+		return nil, structs.ErrNoRegionPath
+	}
+
+	return servers[rand.Intn(len(servers))], nil
+}
+
+// forwardStreamingRpc forwards a streaming RPC to the given server by sending
+// it the arguments of the request and bridging the connections until either
+// side closes.
+func (r *rpcHandler) forwardStreamingRpc(server *serverParts, method string, args interface{}, in io.ReadWriteCloser) error {
+	conn, err := r.streamingRpc(server, method)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if err := codec.NewEncoder(conn, structs.MsgpackHandle).Encode(args); err != nil {
+		return err
+	}
+
+	structs.Bridge(in, conn)
+	return nil
+}
+
 // streamingRpc creates a connection to the given server and conducts the
 // initial handshake, returning the connection or an error. It is the callers
 // responsibility to close the connection if there is no returned error.
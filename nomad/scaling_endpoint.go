@@ -0,0 +1,117 @@
+package nomad
+
+import (
+	"time"
+
+	metrics "github.com/armon/go-metrics"
+	log "github.com/hashicorp/go-hclog"
+	memdb "github.com/hashicorp/go-memdb"
+
+	"github.com/hashicorp/nomad/acl"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// Scaling endpoint is used for listing and retrieving scaling policies
+type Scaling struct {
+	srv    *Server
+	logger log.Logger
+}
+
+// ListPolicies is used to list the scaling policies of the jobs in the
+// request namespace
+func (a *Scaling) ListPolicies(args *structs.ScalingPolicyListRequest,
+	reply *structs.ScalingPolicyListResponse) error {
+	if done, err := a.srv.forward("Scaling.ListPolicies", args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "scaling", "list_policies"}, time.Now())
+
+	// Check for read-job permissions
+	if aclObj, err := a.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if aclObj != nil && !aclObj.AllowNsOp(args.RequestNamespace(), acl.NamespaceCapabilityReadJob) {
+		return structs.ErrPermissionDenied
+	}
+
+	// Setup the blocking query
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, state *state.StateStore) error {
+			// Iterate over all the scaling policies of the namespace
+			iter, err := state.ScalingPoliciesByNamespace(ws, args.RequestNamespace())
+			if err != nil {
+				return err
+			}
+
+			var policies []*structs.ScalingPolicyListStub
+			for {
+				raw := iter.Next()
+				if raw == nil {
+					break
+				}
+				policies = append(policies, raw.(*structs.ScalingPolicy).Stub())
+			}
+			reply.Policies = policies
+
+			// Use the last index that affected the scaling_policy table
+			index, err := state.Index("scaling_policy")
+			if err != nil {
+				return err
+			}
+			reply.Index = index
+
+			// Set the query response
+			a.srv.setQueryMeta(&reply.QueryMeta)
+			return nil
+		}}
+	return a.srv.blockingRPC(&opts)
+}
+
+// GetPolicy is used to get a specific scaling policy
+func (a *Scaling) GetPolicy(args *structs.ScalingPolicySpecificRequest,
+	reply *structs.SingleScalingPolicyResponse) error {
+	if done, err := a.srv.forward("Scaling.GetPolicy", args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "scaling", "get_policy"}, time.Now())
+
+	// Check for read-job permissions
+	aclObj, err := a.srv.ResolveToken(args.AuthToken)
+	if err != nil {
+		return err
+	} else if aclObj != nil && !aclObj.AllowNsOp(args.RequestNamespace(), acl.NamespaceCapabilityReadJob) {
+		return structs.ErrPermissionDenied
+	}
+
+	// Setup the blocking query
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, state *state.StateStore) error {
+			// Look for the policy
+			out, err := state.ScalingPolicyByID(ws, args.ID)
+			if err != nil {
+				return err
+			}
+
+			// Only return policies of jobs in the request namespace
+			if out != nil && out.TargetNamespace() != args.RequestNamespace() {
+				out = nil
+			}
+			reply.Policy = out
+
+			// Use the last index that affected the scaling_policy table
+			index, err := state.Index("scaling_policy")
+			if err != nil {
+				return err
+			}
+			reply.Index = index
+
+			// Set the query response
+			a.srv.setQueryMeta(&reply.QueryMeta)
+			return nil
+		}}
+	return a.srv.blockingRPC(&opts)
+}
@@ -0,0 +1,146 @@
+package nomad
+
+import (
+	"testing"
+
+	msgpackrpc "github.com/hashicorp/net-rpc-msgpackrpc"
+	"github.com/hashicorp/nomad/acl"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScalingEndpoint_GetPolicy(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	s1, cleanupS1 := TestServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	job := mock.Job()
+	job.TaskGroups[0].Scaling = &structs.ScalingPolicy{
+		ID:      uuid.Generate(),
+		Min:     1,
+		Max:     20,
+		Enabled: true,
+	}
+	require.NoError(s1.fsm.State().UpsertJob(1000, job))
+	policy, err := s1.fsm.State().ScalingPolicyByID(nil, job.TaskGroups[0].Scaling.ID)
+	require.NoError(err)
+
+	get := &structs.ScalingPolicySpecificRequest{
+		ID: job.TaskGroups[0].Scaling.ID,
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+	var resp structs.SingleScalingPolicyResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Scaling.GetPolicy", get, &resp))
+	require.EqualValues(1000, resp.Index)
+	require.Equal(policy, resp.Policy)
+
+	// Lookup a non-existing policy
+	get.ID = uuid.Generate()
+	resp = structs.SingleScalingPolicyResponse{}
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Scaling.GetPolicy", get, &resp))
+	require.EqualValues(1000, resp.Index)
+	require.Nil(resp.Policy)
+}
+
+func TestScalingEndpoint_GetPolicy_ACL(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	s1, root, cleanupS1 := TestACLServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	state := s1.fsm.State()
+
+	job := mock.Job()
+	job.TaskGroups[0].Scaling = &structs.ScalingPolicy{
+		ID:      uuid.Generate(),
+		Min:     1,
+		Max:     20,
+		Enabled: true,
+	}
+	require.NoError(state.UpsertJob(1000, job))
+	policy, err := state.ScalingPolicyByID(nil, job.TaskGroups[0].Scaling.ID)
+	require.NoError(err)
+
+	get := &structs.ScalingPolicySpecificRequest{
+		ID: job.TaskGroups[0].Scaling.ID,
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+
+	// Expect failure without a token
+	var resp structs.SingleScalingPolicyResponse
+	err = msgpackrpc.CallWithCodec(codec, "Scaling.GetPolicy", get, &resp)
+	require.Error(err)
+	require.Contains(err.Error(), "Permission denied")
+
+	// Expect failure with a list-jobs token
+	invalidToken := mock.CreatePolicyAndToken(t, state, 1003, "test-invalid",
+		mock.NamespacePolicy(structs.DefaultNamespace, "", []string{acl.NamespaceCapabilityListJobs}))
+	get.AuthToken = invalidToken.SecretID
+	err = msgpackrpc.CallWithCodec(codec, "Scaling.GetPolicy", get, &resp)
+	require.Error(err)
+	require.Contains(err.Error(), "Permission denied")
+
+	// Expect success with a read-job token and a management token
+	validToken := mock.CreatePolicyAndToken(t, state, 1005, "test-valid",
+		mock.NamespacePolicy(structs.DefaultNamespace, "", []string{acl.NamespaceCapabilityReadJob}))
+	for _, token := range []string{validToken.SecretID, root.SecretID} {
+		get.AuthToken = token
+		resp = structs.SingleScalingPolicyResponse{}
+		require.NoError(msgpackrpc.CallWithCodec(codec, "Scaling.GetPolicy", get, &resp))
+		require.Equal(policy, resp.Policy)
+	}
+}
+
+func TestScalingEndpoint_ListPolicies(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	s1, cleanupS1 := TestServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	list := &structs.ScalingPolicyListRequest{
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			Namespace: structs.DefaultNamespace,
+		},
+	}
+	var resp structs.ScalingPolicyListResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Scaling.ListPolicies", list, &resp))
+	require.Empty(resp.Policies)
+
+	job := mock.Job()
+	job.TaskGroups[0].Scaling = &structs.ScalingPolicy{
+		ID:      uuid.Generate(),
+		Min:     1,
+		Max:     20,
+		Enabled: true,
+	}
+	require.NoError(s1.fsm.State().UpsertJob(1000, job))
+
+	// A job without a scaling policy isn't listed
+	require.NoError(s1.fsm.State().UpsertJob(1001, mock.Job()))
+
+	resp = structs.ScalingPolicyListResponse{}
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Scaling.ListPolicies", list, &resp))
+	require.EqualValues(1000, resp.Index)
+	require.Len(resp.Policies, 1)
+	require.Equal(job.TaskGroups[0].Scaling.ID, resp.Policies[0].ID)
+	require.Equal(job.ID, resp.Policies[0].Target[structs.ScalingTargetJob])
+}
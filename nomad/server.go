@@ -30,6 +30,7 @@ import (
 	"github.com/hashicorp/nomad/nomad/deploymentwatcher"
 	"github.com/hashicorp/nomad/nomad/drainer"
 	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/stream"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/nomad/structs/config"
 	"github.com/hashicorp/nomad/scheduler"
@@ -167,6 +168,11 @@ type Server struct {
 	// join/leave from the region.
 	reconcileCh chan serf.Member
 
+	// reassertLeaderCh is used to signal that the leader loop must
+	// re-establish leadership, such as after the state has been restored
+	// from a snapshot. The result is sent on the given channel.
+	reassertLeaderCh chan chan error
+
 	// used to track when the server is ready to serve consistent reads, updated atomically
 	readyForConsistentReads int32
 
@@ -177,6 +183,10 @@ type Server struct {
 	// capacity changes.
 	blockedEvals *BlockedEvals
 
+	// eventBroker buffers the events published by the FSM and streams them
+	// to the subscribers of the event stream
+	eventBroker *stream.EventBroker
+
 	// deploymentWatcher is used to watch deployments and their allocations and
 	// make the required calls to continue to transition the deployment.
 	deploymentWatcher *deploymentwatcher.Watcher
@@ -245,6 +255,7 @@ type endpoints struct {
 	Search     *Search
 	Periodic   *Periodic
 	System     *System
+	Scaling    *Scaling
 	Operator   *Operator
 	ACL        *ACL
 	Enterprise *EnterpriseEndpoints
@@ -254,6 +265,9 @@ type endpoints struct {
 	FileSystem        *FileSystem
 	Agent             *Agent
 	ClientAllocations *ClientAllocations
+
+	// Event stream endpoint
+	Event *Event
 }
 
 // NewServer is used to construct a new Nomad server from the
@@ -273,6 +287,7 @@ func NewServer(config *Config, consulCatalog consul.CatalogAPI) (*Server, error)
 	if err != nil {
 		return nil, err
 	}
+	evalBroker.SetFairShare(config.EvalFairShare, config.EvalNamespaceWeights)
 
 	// Configure TLS
 	tlsConf, err := tlsutil.NewTLSConfiguration(config.TLSConfig, true, true)
@@ -295,22 +310,24 @@ func NewServer(config *Config, consulCatalog consul.CatalogAPI) (*Server, error)
 
 	// Create the server
 	s := &Server{
-		config:        config,
-		consulCatalog: consulCatalog,
-		connPool:      pool.NewPool(logger, serverRPCCache, serverMaxStreams, tlsWrap),
-		logger:        logger,
-		tlsWrap:       tlsWrap,
-		rpcServer:     rpc.NewServer(),
-		streamingRpcs: structs.NewStreamingRpcRegistry(),
-		nodeConns:     make(map[string][]*nodeConnState),
-		peers:         make(map[string][]*serverParts),
-		localPeers:    make(map[raft.ServerAddress]*serverParts),
-		reconcileCh:   make(chan serf.Member, 32),
-		eventCh:       make(chan serf.Event, 256),
-		evalBroker:    evalBroker,
-		blockedEvals:  NewBlockedEvals(evalBroker, logger),
-		rpcTLS:        incomingTLS,
-		aclCache:      aclCache,
+		config:           config,
+		consulCatalog:    consulCatalog,
+		connPool:         pool.NewPool(logger, serverRPCCache, serverMaxStreams, tlsWrap),
+		logger:           logger,
+		tlsWrap:          tlsWrap,
+		rpcServer:        rpc.NewServer(),
+		streamingRpcs:    structs.NewStreamingRpcRegistry(),
+		nodeConns:        make(map[string][]*nodeConnState),
+		peers:            make(map[string][]*serverParts),
+		localPeers:       make(map[raft.ServerAddress]*serverParts),
+		reconcileCh:      make(chan serf.Member, 32),
+		reassertLeaderCh: make(chan chan error),
+		eventCh:          make(chan serf.Event, 256),
+		evalBroker:       evalBroker,
+		blockedEvals:     NewBlockedEvals(evalBroker, logger),
+		eventBroker:      stream.NewEventBroker(config.EventBufferSize),
+		rpcTLS:           incomingTLS,
+		aclCache:         aclCache,
 	}
 
 	s.shutdownCtx, s.shutdownCancel = context.WithCancel(context.Background())
@@ -574,6 +591,9 @@ func (s *Server) Shutdown() error {
 		s.fsm.Close()
 	}
 
+	// Close the event stream subscriptions
+	s.eventBroker.Close()
+
 	// Stop Vault token renewal
 	if s.vault != nil {
 		s.vault.Stop()
@@ -1029,9 +1049,11 @@ func (s *Server) setupRpcServer(server *rpc.Server, ctx *RPCContext) {
 		s.staticEndpoints.Node = &Node{srv: s, logger: s.logger.Named("client")} // Add but don't register
 		s.staticEndpoints.Deployment = &Deployment{srv: s, logger: s.logger.Named("deployment")}
 		s.staticEndpoints.Operator = &Operator{srv: s, logger: s.logger.Named("operator")}
+		s.staticEndpoints.Operator.register()
 		s.staticEndpoints.Periodic = &Periodic{srv: s, logger: s.logger.Named("periodic")}
 		s.staticEndpoints.Plan = &Plan{srv: s, logger: s.logger.Named("plan")}
 		s.staticEndpoints.Region = &Region{srv: s, logger: s.logger.Named("region")}
+		s.staticEndpoints.Scaling = &Scaling{srv: s, logger: s.logger.Named("scaling")}
 		s.staticEndpoints.Status = &Status{srv: s, logger: s.logger.Named("status")}
 		s.staticEndpoints.System = &System{srv: s, logger: s.logger.Named("system")}
 		s.staticEndpoints.Search = &Search{srv: s, logger: s.logger.Named("search")}
@@ -1048,6 +1070,9 @@ func (s *Server) setupRpcServer(server *rpc.Server, ctx *RPCContext) {
 
 		s.staticEndpoints.Agent = &Agent{srv: s}
 		s.staticEndpoints.Agent.register()
+
+		s.staticEndpoints.Event = &Event{srv: s, logger: s.logger.Named("event")}
+		s.staticEndpoints.Event.register()
 	}
 
 	// Register the static handlers
@@ -1060,6 +1085,7 @@ func (s *Server) setupRpcServer(server *rpc.Server, ctx *RPCContext) {
 	server.Register(s.staticEndpoints.Periodic)
 	server.Register(s.staticEndpoints.Plan)
 	server.Register(s.staticEndpoints.Region)
+	server.Register(s.staticEndpoints.Scaling)
 	server.Register(s.staticEndpoints.Status)
 	server.Register(s.staticEndpoints.System)
 	server.Register(s.staticEndpoints.Search)
@@ -1088,11 +1114,12 @@ func (s *Server) setupRaft() error {
 
 	// Create the FSM
 	fsmConfig := &FSMConfig{
-		EvalBroker: s.evalBroker,
-		Periodic:   s.periodicDispatcher,
-		Blocked:    s.blockedEvals,
-		Logger:     s.logger,
-		Region:     s.Region(),
+		EvalBroker:  s.evalBroker,
+		Periodic:    s.periodicDispatcher,
+		Blocked:     s.blockedEvals,
+		EventBroker: s.eventBroker,
+		Logger:      s.logger,
+		Region:      s.Region(),
 	}
 	var err error
 	s.fsm, err = NewFSM(fsmConfig)
@@ -1127,7 +1154,10 @@ func (s *Server) setupRaft() error {
 		s.raftInmem = store
 		stable = store
 		log = store
-		snap = raft.NewDiscardSnapshotStore()
+
+		// Keep the latest snapshot in memory so that it can be saved
+		// by operators
+		snap = raft.NewInmemSnapshotStore()
 
 	} else {
 		// Create the base raft path
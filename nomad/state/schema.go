@@ -1,8 +1,10 @@
 package state
 
 import (
+	"encoding/binary"
 	"fmt"
 	"sync"
+	"time"
 
 	memdb "github.com/hashicorp/go-memdb"
 	"github.com/hashicorp/nomad/nomad/structs"
@@ -43,8 +45,14 @@ func init() {
 		vaultAccessorTableSchema,
 		aclPolicyTableSchema,
 		aclTokenTableSchema,
+		aclRoleTableSchema,
+		aclAuthMethodTableSchema,
+		aclBindingRuleTableSchema,
 		autopilotConfigTableSchema,
 		schedulerConfigTableSchema,
+		scalingPolicyTableSchema,
+		scalingEventTableSchema,
+		workloadIdentityKeyTableSchema,
 	}...)
 }
 
@@ -567,6 +575,79 @@ func aclPolicyTableSchema() *memdb.TableSchema {
 	}
 }
 
+// aclRoleTableSchema returns the MemDB schema for the roles table.
+// This table is used to store the roles which group the policies referenced
+// by tokens
+func aclRoleTableSchema() *memdb.TableSchema {
+	return &memdb.TableSchema{
+		Name: "acl_role",
+		Indexes: map[string]*memdb.IndexSchema{
+			"id": {
+				Name:         "id",
+				AllowMissing: false,
+				Unique:       true,
+				Indexer: &memdb.UUIDFieldIndex{
+					Field: "ID",
+				},
+			},
+			"name": {
+				Name:         "name",
+				AllowMissing: false,
+				Unique:       true,
+				Indexer: &memdb.StringFieldIndex{
+					Field: "Name",
+				},
+			},
+		},
+	}
+}
+
+// aclAuthMethodTableSchema returns the MemDB schema for the auth methods
+// table. This table is used to store the auth methods external identities are
+// exchanged for tokens with
+func aclAuthMethodTableSchema() *memdb.TableSchema {
+	return &memdb.TableSchema{
+		Name: "acl_auth_method",
+		Indexes: map[string]*memdb.IndexSchema{
+			"id": {
+				Name:         "id",
+				AllowMissing: false,
+				Unique:       true,
+				Indexer: &memdb.StringFieldIndex{
+					Field: "Name",
+				},
+			},
+		},
+	}
+}
+
+// aclBindingRuleTableSchema returns the MemDB schema for the binding rules
+// table. This table is used to store the rules binding the identities logging
+// in with an auth method to policies and roles
+func aclBindingRuleTableSchema() *memdb.TableSchema {
+	return &memdb.TableSchema{
+		Name: "acl_binding_rule",
+		Indexes: map[string]*memdb.IndexSchema{
+			"id": {
+				Name:         "id",
+				AllowMissing: false,
+				Unique:       true,
+				Indexer: &memdb.UUIDFieldIndex{
+					Field: "ID",
+				},
+			},
+			"auth_method": {
+				Name:         "auth_method",
+				AllowMissing: false,
+				Unique:       false,
+				Indexer: &memdb.StringFieldIndex{
+					Field: "AuthMethod",
+				},
+			},
+		},
+	}
+}
+
 // aclTokenTableSchema returns the MemDB schema for the tokens table.
 // This table is used to store the bearer tokens which are used to authenticate
 func aclTokenTableSchema() *memdb.TableSchema {
@@ -597,10 +678,75 @@ func aclTokenTableSchema() *memdb.TableSchema {
 					Field: "Global",
 				},
 			},
+			// Expires index is used to find the local or global tokens
+			// that have expired, ordered by their expiration time
+			"expires": {
+				Name:         "expires",
+				AllowMissing: true,
+				Unique:       false,
+				Indexer:      &aclTokenExpiresIndex{},
+			},
 		},
 	}
 }
 
+// aclTokenExpiresIndex indexes the ACL tokens that expire by the tuple of
+// their global flag and their expiration time, so that the tokens of either
+// kind are iterated in the order they expire. Lookups take the same tuple,
+// while prefix lookups may omit the expiration time.
+type aclTokenExpiresIndex struct{}
+
+func (a *aclTokenExpiresIndex) FromObject(obj interface{}) (bool, []byte, error) {
+	token, ok := obj.(*structs.ACLToken)
+	if !ok {
+		return false, nil, fmt.Errorf("object %#v is not an ACLToken", obj)
+	}
+
+	// Tokens which never expire are not indexed
+	if !token.HasExpirationTime() {
+		return false, nil, nil
+	}
+
+	return true, aclTokenExpiresKey(token.Global, *token.ExpirationTime), nil
+}
+
+func (a *aclTokenExpiresIndex) FromArgs(args ...interface{}) ([]byte, error) {
+	if len(args) != 2 {
+		return nil, fmt.Errorf("must provide global flag and expiration time")
+	}
+	global, ok := args[0].(bool)
+	if !ok {
+		return nil, fmt.Errorf("global flag must be a bool: %#v", args[0])
+	}
+	expires, ok := args[1].(time.Time)
+	if !ok {
+		return nil, fmt.Errorf("expiration time must be a time: %#v", args[1])
+	}
+	return aclTokenExpiresKey(global, expires), nil
+}
+
+func (a *aclTokenExpiresIndex) PrefixFromArgs(args ...interface{}) ([]byte, error) {
+	if len(args) != 1 {
+		return a.FromArgs(args...)
+	}
+	global, ok := args[0].(bool)
+	if !ok {
+		return nil, fmt.Errorf("global flag must be a bool: %#v", args[0])
+	}
+	return aclTokenExpiresKey(global, time.Time{})[:1], nil
+}
+
+// aclTokenExpiresKey returns the index key of a token given its global flag
+// and expiration time. The time is encoded big endian so keys sort by time.
+func aclTokenExpiresKey(global bool, expires time.Time) []byte {
+	key := make([]byte, 9)
+	if global {
+		key[0] = 1
+	}
+	binary.BigEndian.PutUint64(key[1:], uint64(expires.UnixNano()))
+	return key
+}
+
 // schedulerConfigTableSchema returns the MemDB schema for the scheduler config table.
 // This table is used to store configuration options for the scheduler
 func schedulerConfigTableSchema() *memdb.TableSchema {
@@ -619,3 +765,125 @@ func schedulerConfigTableSchema() *memdb.TableSchema {
 		},
 	}
 }
+
+// workloadIdentityKeyTableSchema returns the MemDB schema for the workload
+// identity key table. This table stores the single key the servers sign the
+// workload identities of tasks with.
+func workloadIdentityKeyTableSchema() *memdb.TableSchema {
+	return &memdb.TableSchema{
+		Name: "workload_identity_key",
+		Indexes: map[string]*memdb.IndexSchema{
+			"id": {
+				Name:         "id",
+				AllowMissing: true,
+				Unique:       true,
+				// This indexer ensures that this table is a singleton
+				Indexer: &memdb.ConditionalIndex{
+					Conditional: func(obj interface{}) (bool, error) { return true, nil },
+				},
+			},
+		},
+	}
+}
+
+// scalingPolicyTableSchema returns the MemDB schema for the scaling policy
+// table. This table is used to store the scaling policies of task groups.
+func scalingPolicyTableSchema() *memdb.TableSchema {
+	return &memdb.TableSchema{
+		Name: "scaling_policy",
+		Indexes: map[string]*memdb.IndexSchema{
+			// Primary index is used for simple direct lookup.
+			"id": {
+				Name:         "id",
+				AllowMissing: false,
+				Unique:       true,
+				Indexer: &memdb.StringFieldIndex{
+					Field: "ID",
+				},
+			},
+			// Target index is used for looking up the policy of a task group
+			// and listing the policies of a namespace or job using a prefix
+			"target": {
+				Name:         "target",
+				AllowMissing: false,
+				Unique:       true,
+				Indexer:      &scalingPolicyTargetIndex{},
+			},
+		},
+	}
+}
+
+// scalingPolicyTargetIndex indexes scaling policies by the tuple of the
+// namespace, job and group of their target. Lookups take the same tuple,
+// while prefix lookups may omit the trailing parts of it.
+type scalingPolicyTargetIndex struct{}
+
+func (s *scalingPolicyTargetIndex) FromObject(obj interface{}) (bool, []byte, error) {
+	policy, ok := obj.(*structs.ScalingPolicy)
+	if !ok {
+		return false, nil, fmt.Errorf("object %#v is not a ScalingPolicy", obj)
+	}
+
+	return true, scalingPolicyTargetKey(policy.TargetNamespace(), policy.TargetJob(), policy.TargetGroup()), nil
+}
+
+func (s *scalingPolicyTargetIndex) FromArgs(args ...interface{}) ([]byte, error) {
+	if len(args) != 3 {
+		return nil, fmt.Errorf("must provide namespace, job and group")
+	}
+	return s.PrefixFromArgs(args...)
+}
+
+func (s *scalingPolicyTargetIndex) PrefixFromArgs(args ...interface{}) ([]byte, error) {
+	parts := make([]string, len(args))
+	for i, arg := range args {
+		part, ok := arg.(string)
+		if !ok {
+			return nil, fmt.Errorf("argument must be a string: %#v", arg)
+		}
+		parts[i] = part
+	}
+	return scalingPolicyTargetKey(parts...), nil
+}
+
+// scalingPolicyTargetKey returns the index key of the parts of a target,
+// each terminated by a null byte so prefixes only match whole parts.
+func scalingPolicyTargetKey(parts ...string) []byte {
+	var key []byte
+	for _, part := range parts {
+		key = append(key, part...)
+		key = append(key, '\x00')
+	}
+	return key
+}
+
+// scalingEventTableSchema returns the MemDB schema for the scaling event
+// table. This table is used to store the scaling events of jobs.
+func scalingEventTableSchema() *memdb.TableSchema {
+	return &memdb.TableSchema{
+		Name: "scaling_event",
+		Indexes: map[string]*memdb.IndexSchema{
+			// Primary index is used for job management and simple direct
+			// lookup. ID is required to be unique within a namespace.
+			"id": {
+				Name:         "id",
+				AllowMissing: false,
+				Unique:       true,
+
+				// Use a compound index so the tuple of (Namespace, JobID) is
+				// uniquely identifying
+				Indexer: &memdb.CompoundIndex{
+					Indexes: []memdb.Indexer{
+						&memdb.StringFieldIndex{
+							Field: "Namespace",
+						},
+
+						&memdb.StringFieldIndex{
+							Field: "JobID",
+						},
+					},
+				},
+			},
+		},
+	}
+}
@@ -1055,6 +1055,10 @@ func (s *StateStore) upsertJobImpl(index uint64, job *structs.Job, keepVersion b
 		}
 	}
 
+	if err := s.updateJobScalingPolicies(index, job, txn); err != nil {
+		return fmt.Errorf("unable to update job scaling policies: %v", err)
+	}
+
 	if err := s.updateSummaryWithJob(index, job, txn); err != nil {
 		return fmt.Errorf("unable to create job summary: %v", err)
 	}
@@ -1165,6 +1169,14 @@ func (s *StateStore) DeleteJobTxn(index uint64, namespace, jobID string, txn Txn
 		return fmt.Errorf("index update failed: %v", err)
 	}
 
+	// Delete the job scaling policies and events
+	if err := s.deleteJobScalingPolicies(index, job, txn); err != nil {
+		return err
+	}
+	if err := s.deleteJobScalingEvents(index, job, txn); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -2087,9 +2099,10 @@ func (s *StateStore) upsertAllocsImpl(index uint64, allocs []*structs.Allocation
 			// Keep the clients task states
 			alloc.TaskStates = exist.TaskStates
 
-			// If the scheduler is marking this allocation as lost we do not
-			// want to reuse the status of the existing allocation.
-			if alloc.ClientStatus != structs.AllocClientStatusLost {
+			// If the scheduler is marking this allocation as lost or unknown
+			// we do not want to reuse the status of the existing allocation.
+			if alloc.ClientStatus != structs.AllocClientStatusLost &&
+				alloc.ClientStatus != structs.AllocClientStatusUnknown {
 				alloc.ClientStatus = exist.ClientStatus
 				alloc.ClientDescription = exist.ClientDescription
 			}
@@ -3102,6 +3115,8 @@ func (s *StateStore) ReconcileJobSummaries(index uint64) error {
 				tg.Failed += 1
 			case structs.AllocClientStatusLost:
 				tg.Lost += 1
+			case structs.AllocClientStatusUnknown:
+				tg.Unknown += 1
 			case structs.AllocClientStatusComplete:
 				tg.Complete += 1
 			case structs.AllocClientStatusRunning:
@@ -3540,6 +3555,8 @@ func (s *StateStore) updateSummaryWithAlloc(index uint64, alloc *structs.Allocat
 			tgSummary.Complete += 1
 		case structs.AllocClientStatusLost:
 			tgSummary.Lost += 1
+		case structs.AllocClientStatusUnknown:
+			tgSummary.Unknown += 1
 		}
 
 		// Decrementing the count of the bin of the last state
@@ -3556,6 +3573,10 @@ func (s *StateStore) updateSummaryWithAlloc(index uint64, alloc *structs.Allocat
 			if tgSummary.Lost > 0 {
 				tgSummary.Lost -= 1
 			}
+		case structs.AllocClientStatusUnknown:
+			if tgSummary.Unknown > 0 {
+				tgSummary.Unknown -= 1
+			}
 		case structs.AllocClientStatusFailed, structs.AllocClientStatusComplete:
 		default:
 			s.logger.Error("invalid old client status for allocatio",
@@ -3581,6 +3602,22 @@ func (s *StateStore) updateSummaryWithAlloc(index uint64, alloc *structs.Allocat
 	return nil
 }
 
+// ScalingPolicyRestore is used to restore a scaling policy
+func (r *StateRestore) ScalingPolicyRestore(policy *structs.ScalingPolicy) error {
+	if err := r.txn.Insert("scaling_policy", policy); err != nil {
+		return fmt.Errorf("inserting scaling policy failed: %v", err)
+	}
+	return nil
+}
+
+// ScalingEventsRestore is used to restore the scaling events of a job
+func (r *StateRestore) ScalingEventsRestore(jobEvents *structs.JobScalingEvents) error {
+	if err := r.txn.Insert("scaling_event", jobEvents); err != nil {
+		return fmt.Errorf("inserting scaling events failed: %v", err)
+	}
+	return nil
+}
+
 // addEphemeralDiskToTaskGroups adds missing EphemeralDisk objects to TaskGroups
 func (s *StateStore) addEphemeralDiskToTaskGroups(job *structs.Job) {
 	for _, tg := range job.TaskGroups {
@@ -3702,6 +3739,341 @@ func (s *StateStore) ACLPolicies(ws memdb.WatchSet) (memdb.ResultIterator, error
 	return iter, nil
 }
 
+// UpsertACLRoles is used to create or update a set of ACL roles
+func (s *StateStore) UpsertACLRoles(index uint64, roles []*structs.ACLRole) error {
+	txn := s.db.Txn(true)
+	defer txn.Abort()
+
+	for _, role := range roles {
+		// Ensure the role hash is non-nil. This should be done outside the state store
+		// for performance reasons, but we check here for defense in depth.
+		if len(role.Hash) == 0 {
+			role.SetHash()
+		}
+
+		// Check if the role already exists
+		existing, err := txn.First("acl_role", "id", role.ID)
+		if err != nil {
+			return fmt.Errorf("role lookup failed: %v", err)
+		}
+
+		// Ensure the name is not used by another role
+		named, err := txn.First("acl_role", "name", role.Name)
+		if err != nil {
+			return fmt.Errorf("role lookup failed: %v", err)
+		}
+		if named != nil && named.(*structs.ACLRole).ID != role.ID {
+			return fmt.Errorf("role with name %q already exists", role.Name)
+		}
+
+		// Update all the indexes
+		if existing != nil {
+			role.CreateIndex = existing.(*structs.ACLRole).CreateIndex
+			role.ModifyIndex = index
+		} else {
+			role.CreateIndex = index
+			role.ModifyIndex = index
+		}
+
+		// Update the role
+		if err := txn.Insert("acl_role", role); err != nil {
+			return fmt.Errorf("upserting role failed: %v", err)
+		}
+	}
+
+	// Update the indexes table
+	if err := txn.Insert("index", &IndexEntry{"acl_role", index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+
+	txn.Commit()
+	return nil
+}
+
+// DeleteACLRoles deletes the roles with the given IDs
+func (s *StateStore) DeleteACLRoles(index uint64, roleIDs []string) error {
+	txn := s.db.Txn(true)
+	defer txn.Abort()
+
+	// Delete the roles
+	for _, roleID := range roleIDs {
+		if _, err := txn.DeleteAll("acl_role", "id", roleID); err != nil {
+			return fmt.Errorf("deleting acl role failed: %v", err)
+		}
+	}
+	if err := txn.Insert("index", &IndexEntry{"acl_role", index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+	txn.Commit()
+	return nil
+}
+
+// ACLRoleByID is used to lookup a role by ID
+func (s *StateStore) ACLRoleByID(ws memdb.WatchSet, roleID string) (*structs.ACLRole, error) {
+	txn := s.db.Txn(false)
+
+	watchCh, existing, err := txn.FirstWatch("acl_role", "id", roleID)
+	if err != nil {
+		return nil, fmt.Errorf("acl role lookup failed: %v", err)
+	}
+	ws.Add(watchCh)
+
+	if existing != nil {
+		return existing.(*structs.ACLRole), nil
+	}
+	return nil, nil
+}
+
+// ACLRoleByName is used to lookup a role by name
+func (s *StateStore) ACLRoleByName(ws memdb.WatchSet, name string) (*structs.ACLRole, error) {
+	txn := s.db.Txn(false)
+
+	watchCh, existing, err := txn.FirstWatch("acl_role", "name", name)
+	if err != nil {
+		return nil, fmt.Errorf("acl role lookup failed: %v", err)
+	}
+	ws.Add(watchCh)
+
+	if existing != nil {
+		return existing.(*structs.ACLRole), nil
+	}
+	return nil, nil
+}
+
+// ACLRoleByIDPrefix is used to lookup roles by ID prefix
+func (s *StateStore) ACLRoleByIDPrefix(ws memdb.WatchSet, prefix string) (memdb.ResultIterator, error) {
+	txn := s.db.Txn(false)
+
+	iter, err := txn.Get("acl_role", "id_prefix", prefix)
+	if err != nil {
+		return nil, fmt.Errorf("acl role lookup failed: %v", err)
+	}
+	ws.Add(iter.WatchCh())
+
+	return iter, nil
+}
+
+// ACLRoles returns an iterator over all the acl roles
+func (s *StateStore) ACLRoles(ws memdb.WatchSet) (memdb.ResultIterator, error) {
+	txn := s.db.Txn(false)
+
+	// Walk the entire table
+	iter, err := txn.Get("acl_role", "id")
+	if err != nil {
+		return nil, err
+	}
+	ws.Add(iter.WatchCh())
+	return iter, nil
+}
+
+// UpsertACLAuthMethods is used to create or update a set of ACL auth methods
+func (s *StateStore) UpsertACLAuthMethods(index uint64, methods []*structs.ACLAuthMethod) error {
+	txn := s.db.Txn(true)
+	defer txn.Abort()
+
+	for _, method := range methods {
+		// Ensure the auth method hash is non-nil. This should be done outside the state store
+		// for performance reasons, but we check here for defense in depth.
+		if len(method.Hash) == 0 {
+			method.SetHash()
+		}
+
+		// Check if the auth method already exists
+		existing, err := txn.First("acl_auth_method", "id", method.Name)
+		if err != nil {
+			return fmt.Errorf("auth method lookup failed: %v", err)
+		}
+
+		// Update all the indexes
+		if existing != nil {
+			method.CreateIndex = existing.(*structs.ACLAuthMethod).CreateIndex
+			method.ModifyIndex = index
+		} else {
+			method.CreateIndex = index
+			method.ModifyIndex = index
+		}
+
+		// Update the auth method
+		if err := txn.Insert("acl_auth_method", method); err != nil {
+			return fmt.Errorf("upserting auth method failed: %v", err)
+		}
+	}
+
+	// Update the indexes table
+	if err := txn.Insert("index", &IndexEntry{"acl_auth_method", index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+
+	txn.Commit()
+	return nil
+}
+
+// DeleteACLAuthMethods deletes the auth methods with the given names, along
+// with their binding rules
+func (s *StateStore) DeleteACLAuthMethods(index uint64, names []string) error {
+	txn := s.db.Txn(true)
+	defer txn.Abort()
+
+	// Delete the auth methods and their binding rules
+	for _, name := range names {
+		if _, err := txn.DeleteAll("acl_auth_method", "id", name); err != nil {
+			return fmt.Errorf("deleting acl auth method failed: %v", err)
+		}
+		if _, err := txn.DeleteAll("acl_binding_rule", "auth_method", name); err != nil {
+			return fmt.Errorf("deleting acl binding rules failed: %v", err)
+		}
+	}
+	if err := txn.Insert("index", &IndexEntry{"acl_auth_method", index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+	if err := txn.Insert("index", &IndexEntry{"acl_binding_rule", index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+	txn.Commit()
+	return nil
+}
+
+// ACLAuthMethodByName is used to lookup an auth method by name
+func (s *StateStore) ACLAuthMethodByName(ws memdb.WatchSet, name string) (*structs.ACLAuthMethod, error) {
+	txn := s.db.Txn(false)
+
+	watchCh, existing, err := txn.FirstWatch("acl_auth_method", "id", name)
+	if err != nil {
+		return nil, fmt.Errorf("acl auth method lookup failed: %v", err)
+	}
+	ws.Add(watchCh)
+
+	if existing != nil {
+		return existing.(*structs.ACLAuthMethod), nil
+	}
+	return nil, nil
+}
+
+// ACLAuthMethods returns an iterator over all the acl auth methods
+func (s *StateStore) ACLAuthMethods(ws memdb.WatchSet) (memdb.ResultIterator, error) {
+	txn := s.db.Txn(false)
+
+	// Walk the entire table
+	iter, err := txn.Get("acl_auth_method", "id")
+	if err != nil {
+		return nil, err
+	}
+	ws.Add(iter.WatchCh())
+	return iter, nil
+}
+
+// UpsertACLBindingRules is used to create or update a set of ACL binding
+// rules. The auth methods of the binding rules must exist.
+func (s *StateStore) UpsertACLBindingRules(index uint64, rules []*structs.ACLBindingRule) error {
+	txn := s.db.Txn(true)
+	defer txn.Abort()
+
+	for _, rule := range rules {
+		// Ensure the binding rule hash is non-nil. This should be done outside the state store
+		// for performance reasons, but we check here for defense in depth.
+		if len(rule.Hash) == 0 {
+			rule.SetHash()
+		}
+
+		// Ensure the auth method exists
+		method, err := txn.First("acl_auth_method", "id", rule.AuthMethod)
+		if err != nil {
+			return fmt.Errorf("auth method lookup failed: %v", err)
+		}
+		if method == nil {
+			return fmt.Errorf("auth method %q not found", rule.AuthMethod)
+		}
+
+		// Check if the binding rule already exists
+		existing, err := txn.First("acl_binding_rule", "id", rule.ID)
+		if err != nil {
+			return fmt.Errorf("binding rule lookup failed: %v", err)
+		}
+
+		// Update all the indexes
+		if existing != nil {
+			rule.CreateIndex = existing.(*structs.ACLBindingRule).CreateIndex
+			rule.ModifyIndex = index
+		} else {
+			rule.CreateIndex = index
+			rule.ModifyIndex = index
+		}
+
+		// Update the binding rule
+		if err := txn.Insert("acl_binding_rule", rule); err != nil {
+			return fmt.Errorf("upserting binding rule failed: %v", err)
+		}
+	}
+
+	// Update the indexes table
+	if err := txn.Insert("index", &IndexEntry{"acl_binding_rule", index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+
+	txn.Commit()
+	return nil
+}
+
+// DeleteACLBindingRules deletes the binding rules with the given IDs
+func (s *StateStore) DeleteACLBindingRules(index uint64, ruleIDs []string) error {
+	txn := s.db.Txn(true)
+	defer txn.Abort()
+
+	// Delete the binding rules
+	for _, ruleID := range ruleIDs {
+		if _, err := txn.DeleteAll("acl_binding_rule", "id", ruleID); err != nil {
+			return fmt.Errorf("deleting acl binding rule failed: %v", err)
+		}
+	}
+	if err := txn.Insert("index", &IndexEntry{"acl_binding_rule", index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+	txn.Commit()
+	return nil
+}
+
+// ACLBindingRuleByID is used to lookup a binding rule by ID
+func (s *StateStore) ACLBindingRuleByID(ws memdb.WatchSet, ruleID string) (*structs.ACLBindingRule, error) {
+	txn := s.db.Txn(false)
+
+	watchCh, existing, err := txn.FirstWatch("acl_binding_rule", "id", ruleID)
+	if err != nil {
+		return nil, fmt.Errorf("acl binding rule lookup failed: %v", err)
+	}
+	ws.Add(watchCh)
+
+	if existing != nil {
+		return existing.(*structs.ACLBindingRule), nil
+	}
+	return nil, nil
+}
+
+// ACLBindingRulesByAuthMethod returns an iterator over the acl binding rules
+// of an auth method
+func (s *StateStore) ACLBindingRulesByAuthMethod(ws memdb.WatchSet, method string) (memdb.ResultIterator, error) {
+	txn := s.db.Txn(false)
+
+	iter, err := txn.Get("acl_binding_rule", "auth_method", method)
+	if err != nil {
+		return nil, fmt.Errorf("acl binding rule lookup failed: %v", err)
+	}
+	ws.Add(iter.WatchCh())
+	return iter, nil
+}
+
+// ACLBindingRules returns an iterator over all the acl binding rules
+func (s *StateStore) ACLBindingRules(ws memdb.WatchSet) (memdb.ResultIterator, error) {
+	txn := s.db.Txn(false)
+
+	// Walk the entire table
+	iter, err := txn.Get("acl_binding_rule", "id")
+	if err != nil {
+		return nil, err
+	}
+	ws.Add(iter.WatchCh())
+	return iter, nil
+}
+
 // UpsertACLTokens is used to create or update a set of ACL tokens
 func (s *StateStore) UpsertACLTokens(index uint64, tokens []*structs.ACLToken) error {
 	txn := s.db.Txn(true)
@@ -3845,6 +4217,20 @@ func (s *StateStore) ACLTokensByGlobal(ws memdb.WatchSet, globalVal bool) (memdb
 	return iter, nil
 }
 
+// ACLTokensByExpires returns an iterator over the local or global tokens that
+// expire, ordered by their expiration time. Callers looking for the expired
+// tokens can stop at the first token which has not expired.
+func (s *StateStore) ACLTokensByExpires(ws memdb.WatchSet, globalVal bool) (memdb.ResultIterator, error) {
+	txn := s.db.Txn(false)
+
+	iter, err := txn.Get("acl_token", "expires_prefix", globalVal)
+	if err != nil {
+		return nil, err
+	}
+	ws.Add(iter.WatchCh())
+	return iter, nil
+}
+
 // CanBootstrapACLToken checks if bootstrapping is possible and returns the reset index
 func (s *StateStore) CanBootstrapACLToken() (bool, uint64, error) {
 	txn := s.db.Txn(false)
@@ -3902,6 +4288,51 @@ func (s *StateStore) BootstrapACLTokens(index, resetIndex uint64, token *structs
 	return nil
 }
 
+// WorkloadIdentityKey returns the key workload identities are signed with, or
+// nil if it hasn't been generated yet.
+func (s *StateStore) WorkloadIdentityKey(ws memdb.WatchSet) (*structs.WorkloadIdentityKey, error) {
+	txn := s.db.Txn(false)
+
+	watchCh, existing, err := txn.FirstWatch("workload_identity_key", "id")
+	if err != nil {
+		return nil, fmt.Errorf("workload identity key lookup failed: %v", err)
+	}
+	ws.Add(watchCh)
+
+	if existing != nil {
+		return existing.(*structs.WorkloadIdentityKey), nil
+	}
+	return nil, nil
+}
+
+// UpsertWorkloadIdentityKey sets the key workload identities are signed with.
+// The first key set is kept, so that a key can't be replaced while tokens
+// signed with it are still in use.
+func (s *StateStore) UpsertWorkloadIdentityKey(index uint64, key *structs.WorkloadIdentityKey) error {
+	txn := s.db.Txn(true)
+	defer txn.Abort()
+
+	existing, err := txn.First("workload_identity_key", "id")
+	if err != nil {
+		return fmt.Errorf("workload identity key lookup failed: %v", err)
+	}
+	if existing != nil {
+		return nil
+	}
+
+	key.CreateIndex = index
+	key.ModifyIndex = index
+	if err := txn.Insert("workload_identity_key", key); err != nil {
+		return fmt.Errorf("upserting workload identity key failed: %v", err)
+	}
+	if err := txn.Insert("index", &IndexEntry{"workload_identity_key", index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+
+	txn.Commit()
+	return nil
+}
+
 // SchedulerConfig is used to get the current Scheduler configuration.
 func (s *StateStore) SchedulerConfig() (uint64, *structs.SchedulerConfiguration, error) {
 	tx := s.db.Txn(false)
@@ -3994,6 +4425,276 @@ func (s *StateStore) schedulerSetConfigTxn(idx uint64, tx *memdb.Txn, config *st
 	return nil
 }
 
+// updateJobScalingPolicies upserts the scaling policies of the task groups
+// of the job and deletes the policies of groups that no longer have one. A
+// policy keeps its ID while its task group has a policy.
+func (s *StateStore) updateJobScalingPolicies(index uint64, job *structs.Job, txn *memdb.Txn) error {
+	existing, err := s.scalingPoliciesByJob(txn, nil, job.Namespace, job.ID)
+	if err != nil {
+		return err
+	}
+
+	updated := false
+	groups := make(map[string]struct{}, len(job.TaskGroups))
+	for _, tg := range job.TaskGroups {
+		if tg.Scaling == nil {
+			continue
+		}
+		groups[tg.Name] = struct{}{}
+
+		policy := tg.Scaling
+		policy.Target = map[string]string{
+			structs.ScalingTargetNamespace: job.Namespace,
+			structs.ScalingTargetJob:       job.ID,
+			structs.ScalingTargetGroup:     tg.Name,
+		}
+
+		if prev, ok := existing[tg.Name]; ok {
+			policy.ID = prev.ID
+			policy.CreateIndex = prev.CreateIndex
+			policy.ModifyIndex = prev.ModifyIndex
+			if scalingPolicyEqual(prev, policy) {
+				continue
+			}
+		} else {
+			// The ID of new policies is generated before the job is
+			// committed to the log
+			if policy.ID == "" {
+				return fmt.Errorf("scaling policy for group %q is missing an ID", tg.Name)
+			}
+			policy.CreateIndex = index
+		}
+		policy.ModifyIndex = index
+
+		if err := txn.Insert("scaling_policy", policy.Copy()); err != nil {
+			return fmt.Errorf("scaling policy insert failed: %v", err)
+		}
+		updated = true
+	}
+
+	for group, prev := range existing {
+		if _, ok := groups[group]; ok {
+			continue
+		}
+		if err := txn.Delete("scaling_policy", prev); err != nil {
+			return fmt.Errorf("scaling policy delete failed: %v", err)
+		}
+		updated = true
+	}
+
+	if updated {
+		if err := txn.Insert("index", &IndexEntry{"scaling_policy", index}); err != nil {
+			return fmt.Errorf("index update failed: %v", err)
+		}
+	}
+	return nil
+}
+
+// scalingPolicyEqual returns whether the user configurable fields of the
+// scaling policies are equal.
+func scalingPolicyEqual(a, b *structs.ScalingPolicy) bool {
+	return a.Min == b.Min && a.Max == b.Max && a.Enabled == b.Enabled &&
+		reflect.DeepEqual(a.Policy, b.Policy)
+}
+
+// deleteJobScalingPolicies deletes the scaling policies of the given job.
+func (s *StateStore) deleteJobScalingPolicies(index uint64, job *structs.Job, txn *memdb.Txn) error {
+	deleted, err := txn.DeleteAll("scaling_policy", "target_prefix", job.Namespace, job.ID)
+	if err != nil {
+		return fmt.Errorf("deleting job scaling policies failed: %v", err)
+	}
+	if deleted > 0 {
+		if err := txn.Insert("index", &IndexEntry{"scaling_policy", index}); err != nil {
+			return fmt.Errorf("index update failed: %v", err)
+		}
+	}
+	return nil
+}
+
+// ScalingPolicies returns an iterator over all the scaling policies
+func (s *StateStore) ScalingPolicies(ws memdb.WatchSet) (memdb.ResultIterator, error) {
+	txn := s.db.Txn(false)
+
+	// Walk the entire scaling_policy table
+	iter, err := txn.Get("scaling_policy", "id")
+	if err != nil {
+		return nil, err
+	}
+
+	ws.Add(iter.WatchCh())
+	return iter, nil
+}
+
+// ScalingPoliciesByNamespace returns an iterator over the scaling policies
+// of the jobs of the given namespace
+func (s *StateStore) ScalingPoliciesByNamespace(ws memdb.WatchSet, namespace string) (memdb.ResultIterator, error) {
+	txn := s.db.Txn(false)
+
+	iter, err := txn.Get("scaling_policy", "target_prefix", namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	ws.Add(iter.WatchCh())
+	return iter, nil
+}
+
+// ScalingPoliciesByJob returns the scaling policies of the given job, keyed
+// by the name of their task group
+func (s *StateStore) ScalingPoliciesByJob(ws memdb.WatchSet, namespace, jobID string) (map[string]*structs.ScalingPolicy, error) {
+	txn := s.db.Txn(false)
+	return s.scalingPoliciesByJob(txn, &ws, namespace, jobID)
+}
+
+func (s *StateStore) scalingPoliciesByJob(txn *memdb.Txn, ws *memdb.WatchSet, namespace, jobID string) (map[string]*structs.ScalingPolicy, error) {
+	iter, err := txn.Get("scaling_policy", "target_prefix", namespace, jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	if ws != nil {
+		ws.Add(iter.WatchCh())
+	}
+
+	policies := make(map[string]*structs.ScalingPolicy)
+	for {
+		raw := iter.Next()
+		if raw == nil {
+			break
+		}
+
+		policy := raw.(*structs.ScalingPolicy)
+		policies[policy.TargetGroup()] = policy
+	}
+	return policies, nil
+}
+
+// ScalingPolicyByID is used to lookup a scaling policy by its ID
+func (s *StateStore) ScalingPolicyByID(ws memdb.WatchSet, id string) (*structs.ScalingPolicy, error) {
+	txn := s.db.Txn(false)
+
+	watchCh, existing, err := txn.FirstWatch("scaling_policy", "id", id)
+	if err != nil {
+		return nil, fmt.Errorf("scaling policy lookup failed: %v", err)
+	}
+
+	ws.Add(watchCh)
+
+	if existing != nil {
+		return existing.(*structs.ScalingPolicy), nil
+	}
+	return nil, nil
+}
+
+// ScalingPolicyByTarget is used to lookup the scaling policy of a task group
+func (s *StateStore) ScalingPolicyByTarget(ws memdb.WatchSet, namespace, jobID, group string) (*structs.ScalingPolicy, error) {
+	txn := s.db.Txn(false)
+
+	watchCh, existing, err := txn.FirstWatch("scaling_policy", "target", namespace, jobID, group)
+	if err != nil {
+		return nil, fmt.Errorf("scaling policy lookup failed: %v", err)
+	}
+
+	ws.Add(watchCh)
+
+	if existing != nil {
+		return existing.(*structs.ScalingPolicy), nil
+	}
+	return nil, nil
+}
+
+// UpsertScalingEvent is used to record a scaling event against a task
+// group. Only the most recent JobTrackedScalingEvents events of each task
+// group are kept.
+func (s *StateStore) UpsertScalingEvent(index uint64, req *structs.ScalingEventRequest) error {
+	txn := s.db.Txn(true)
+	defer txn.Abort()
+
+	existing, err := txn.First("scaling_event", "id", req.Namespace, req.JobID)
+	if err != nil {
+		return fmt.Errorf("scaling event lookup failed: %v", err)
+	}
+
+	var jobEvents *structs.JobScalingEvents
+	if existing != nil {
+		jobEvents = existing.(*structs.JobScalingEvents).Copy()
+	} else {
+		jobEvents = &structs.JobScalingEvents{
+			Namespace:     req.Namespace,
+			JobID:         req.JobID,
+			ScalingEvents: make(map[string][]*structs.ScalingEvent),
+		}
+	}
+
+	event := new(structs.ScalingEvent)
+	*event = *req.ScalingEvent
+	event.CreateIndex = index
+
+	events := append([]*structs.ScalingEvent{event}, jobEvents.ScalingEvents[req.TaskGroup]...)
+	if len(events) > structs.JobTrackedScalingEvents {
+		events = events[:structs.JobTrackedScalingEvents]
+	}
+	jobEvents.ScalingEvents[req.TaskGroup] = events
+	jobEvents.ModifyIndex = index
+
+	if err := txn.Insert("scaling_event", jobEvents); err != nil {
+		return fmt.Errorf("scaling event insert failed: %v", err)
+	}
+	if err := txn.Insert("index", &IndexEntry{"scaling_event", index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+
+	txn.Commit()
+	return nil
+}
+
+// ScalingEvents returns an iterator over the scaling events of all jobs
+func (s *StateStore) ScalingEvents(ws memdb.WatchSet) (memdb.ResultIterator, error) {
+	txn := s.db.Txn(false)
+
+	// Walk the entire scaling_event table
+	iter, err := txn.Get("scaling_event", "id")
+	if err != nil {
+		return nil, err
+	}
+
+	ws.Add(iter.WatchCh())
+	return iter, nil
+}
+
+// ScalingEventsByJob returns the scaling events of the task groups of the
+// given job, newest first, and the index they were last modified at
+func (s *StateStore) ScalingEventsByJob(ws memdb.WatchSet, namespace, jobID string) (map[string][]*structs.ScalingEvent, uint64, error) {
+	txn := s.db.Txn(false)
+
+	watchCh, existing, err := txn.FirstWatch("scaling_event", "id", namespace, jobID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("scaling event lookup failed: %v", err)
+	}
+
+	ws.Add(watchCh)
+
+	if existing != nil {
+		events := existing.(*structs.JobScalingEvents)
+		return events.ScalingEvents, events.ModifyIndex, nil
+	}
+	return nil, 0, nil
+}
+
+// deleteJobScalingEvents deletes the scaling events of the given job.
+func (s *StateStore) deleteJobScalingEvents(index uint64, job *structs.Job, txn *memdb.Txn) error {
+	deleted, err := txn.DeleteAll("scaling_event", "id", job.Namespace, job.ID)
+	if err != nil {
+		return fmt.Errorf("deleting job scaling events failed: %v", err)
+	}
+	if deleted > 0 {
+		if err := txn.Insert("index", &IndexEntry{"scaling_event", index}); err != nil {
+			return fmt.Errorf("index update failed: %v", err)
+		}
+	}
+	return nil
+}
+
 // StateSnapshot is used to provide a point-in-time snapshot
 type StateSnapshot struct {
 	StateStore
@@ -4182,6 +4883,38 @@ func (r *StateRestore) ACLPolicyRestore(policy *structs.ACLPolicy) error {
 	return nil
 }
 
+// ACLRoleRestore is used to restore an ACL role
+func (r *StateRestore) ACLRoleRestore(role *structs.ACLRole) error {
+	if err := r.txn.Insert("acl_role", role); err != nil {
+		return fmt.Errorf("inserting acl role failed: %v", err)
+	}
+	return nil
+}
+
+// ACLAuthMethodRestore is used to restore an ACL auth method
+func (r *StateRestore) ACLAuthMethodRestore(method *structs.ACLAuthMethod) error {
+	if err := r.txn.Insert("acl_auth_method", method); err != nil {
+		return fmt.Errorf("inserting acl auth method failed: %v", err)
+	}
+	return nil
+}
+
+// ACLBindingRuleRestore is used to restore an ACL binding rule
+func (r *StateRestore) ACLBindingRuleRestore(rule *structs.ACLBindingRule) error {
+	if err := r.txn.Insert("acl_binding_rule", rule); err != nil {
+		return fmt.Errorf("inserting acl binding rule failed: %v", err)
+	}
+	return nil
+}
+
+// WorkloadIdentityKeyRestore is used to restore the workload identity key
+func (r *StateRestore) WorkloadIdentityKeyRestore(key *structs.WorkloadIdentityKey) error {
+	if err := r.txn.Insert("workload_identity_key", key); err != nil {
+		return fmt.Errorf("inserting workload identity key failed: %v", err)
+	}
+	return nil
+}
+
 // ACLTokenRestore is used to restore an ACL token
 func (r *StateRestore) ACLTokenRestore(token *structs.ACLToken) error {
 	if err := r.txn.Insert("acl_token", token); err != nil {
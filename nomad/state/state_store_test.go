@@ -7306,6 +7306,367 @@ func TestStateStore_ACLTokensByGlobal(t *testing.T) {
 	}
 }
 
+func TestStateStore_ACLTokensByExpires(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	state := testStateStore(t)
+	now := time.Now().UTC()
+
+	// Tokens which never expire, expire later and expire sooner, both local
+	// and global
+	never := mock.ACLToken()
+	later := mock.ACLToken()
+	laterTime := now.Add(time.Hour)
+	later.ExpirationTime = &laterTime
+	sooner := mock.ACLToken()
+	soonerTime := now.Add(time.Minute)
+	sooner.ExpirationTime = &soonerTime
+	global := mock.ACLToken()
+	global.Global = true
+	global.ExpirationTime = &soonerTime
+
+	require.NoError(state.UpsertACLTokens(1000,
+		[]*structs.ACLToken{never, later, sooner, global}))
+
+	// Local tokens are returned in the order they expire
+	iter, err := state.ACLTokensByExpires(nil, false)
+	require.NoError(err)
+	var ids []string
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		ids = append(ids, raw.(*structs.ACLToken).AccessorID)
+	}
+	require.Equal([]string{sooner.AccessorID, later.AccessorID}, ids)
+
+	// Global tokens are returned separately
+	iter, err = state.ACLTokensByExpires(nil, true)
+	require.NoError(err)
+	ids = nil
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		ids = append(ids, raw.(*structs.ACLToken).AccessorID)
+	}
+	require.Equal([]string{global.AccessorID}, ids)
+}
+
+func TestStateStore_UpsertACLRoles(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	state := testStateStore(t)
+	role := mock.ACLRole()
+	role2 := mock.ACLRole()
+
+	ws := memdb.NewWatchSet()
+	_, err := state.ACLRoleByID(ws, role.ID)
+	require.NoError(err)
+
+	require.NoError(state.UpsertACLRoles(1000, []*structs.ACLRole{role, role2}))
+	require.True(watchFired(ws))
+
+	ws = memdb.NewWatchSet()
+	out, err := state.ACLRoleByID(ws, role.ID)
+	require.NoError(err)
+	require.Equal(role, out)
+
+	out, err = state.ACLRoleByName(ws, role2.Name)
+	require.NoError(err)
+	require.Equal(role2, out)
+
+	iter, err := state.ACLRoles(ws)
+	require.NoError(err)
+	count := 0
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		count++
+	}
+	require.Equal(2, count)
+
+	index, err := state.Index("acl_role")
+	require.NoError(err)
+	require.EqualValues(1000, index)
+
+	// Update a role, keeping its create index
+	update := mock.ACLRole()
+	update.ID = role.ID
+	update.Name = role.Name
+	require.NoError(state.UpsertACLRoles(1001, []*structs.ACLRole{update}))
+	out, err = state.ACLRoleByID(nil, role.ID)
+	require.NoError(err)
+	require.EqualValues(1000, out.CreateIndex)
+	require.EqualValues(1001, out.ModifyIndex)
+
+	// Names must be unique
+	dup := mock.ACLRole()
+	dup.Name = role.Name
+	err = state.UpsertACLRoles(1002, []*structs.ACLRole{dup})
+	require.Error(err)
+	require.Contains(err.Error(), "already exists")
+}
+
+func TestStateStore_DeleteACLRoles(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	state := testStateStore(t)
+	role := mock.ACLRole()
+	role2 := mock.ACLRole()
+	require.NoError(state.UpsertACLRoles(1000, []*structs.ACLRole{role, role2}))
+
+	ws := memdb.NewWatchSet()
+	_, err := state.ACLRoleByID(ws, role.ID)
+	require.NoError(err)
+
+	require.NoError(state.DeleteACLRoles(1001, []string{role.ID}))
+	require.True(watchFired(ws))
+
+	out, err := state.ACLRoleByID(nil, role.ID)
+	require.NoError(err)
+	require.Nil(out)
+	out, err = state.ACLRoleByName(nil, role.Name)
+	require.NoError(err)
+	require.Nil(out)
+	out, err = state.ACLRoleByID(nil, role2.ID)
+	require.NoError(err)
+	require.Equal(role2, out)
+
+	index, err := state.Index("acl_role")
+	require.NoError(err)
+	require.EqualValues(1001, index)
+}
+
+func TestStateStore_ACLRoleByIDPrefix(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	state := testStateStore(t)
+	role := mock.ACLRole()
+	role.ID = "aaaaaaaa-7bfb-395d-eb95-0685af2176b2"
+	role2 := mock.ACLRole()
+	role2.ID = "aaaabbbb-7bfb-395d-eb95-0685af2176b2"
+	role3 := mock.ACLRole()
+	role3.ID = "bbbbbbbb-7bfb-395d-eb95-0685af2176b2"
+	require.NoError(state.UpsertACLRoles(1000, []*structs.ACLRole{role, role2, role3}))
+
+	iter, err := state.ACLRoleByIDPrefix(nil, "aaaa")
+	require.NoError(err)
+	var out []string
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		out = append(out, raw.(*structs.ACLRole).ID)
+	}
+	require.Equal([]string{role.ID, role2.ID}, out)
+}
+
+func TestStateStore_RestoreACLRole(t *testing.T) {
+	t.Parallel()
+
+	state := testStateStore(t)
+	role := mock.ACLRole()
+
+	restore, err := state.Restore()
+	require.NoError(t, err)
+	require.NoError(t, restore.ACLRoleRestore(role))
+	restore.Commit()
+
+	out, err := state.ACLRoleByID(nil, role.ID)
+	require.NoError(t, err)
+	require.Equal(t, role, out)
+}
+
+func TestStateStore_UpsertACLAuthMethods(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	state := testStateStore(t)
+	method := mock.ACLAuthMethod()
+	method2 := mock.ACLAuthMethod()
+
+	ws := memdb.NewWatchSet()
+	_, err := state.ACLAuthMethodByName(ws, method.Name)
+	require.NoError(err)
+
+	require.NoError(state.UpsertACLAuthMethods(1000, []*structs.ACLAuthMethod{method, method2}))
+	require.True(watchFired(ws))
+
+	ws = memdb.NewWatchSet()
+	out, err := state.ACLAuthMethodByName(ws, method.Name)
+	require.NoError(err)
+	require.Equal(method, out)
+
+	iter, err := state.ACLAuthMethods(ws)
+	require.NoError(err)
+	count := 0
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		count++
+	}
+	require.Equal(2, count)
+
+	index, err := state.Index("acl_auth_method")
+	require.NoError(err)
+	require.EqualValues(1000, index)
+
+	// Update an auth method, keeping its create index
+	update := mock.ACLAuthMethod()
+	update.Name = method.Name
+	require.NoError(state.UpsertACLAuthMethods(1001, []*structs.ACLAuthMethod{update}))
+	out, err = state.ACLAuthMethodByName(nil, method.Name)
+	require.NoError(err)
+	require.EqualValues(1000, out.CreateIndex)
+	require.EqualValues(1001, out.ModifyIndex)
+}
+
+func TestStateStore_DeleteACLAuthMethods(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	state := testStateStore(t)
+	method := mock.ACLAuthMethod()
+	method2 := mock.ACLAuthMethod()
+	require.NoError(state.UpsertACLAuthMethods(1000, []*structs.ACLAuthMethod{method, method2}))
+
+	rule := mock.ACLBindingRule(method.Name)
+	rule2 := mock.ACLBindingRule(method2.Name)
+	require.NoError(state.UpsertACLBindingRules(1001, []*structs.ACLBindingRule{rule, rule2}))
+
+	ws := memdb.NewWatchSet()
+	_, err := state.ACLAuthMethodByName(ws, method.Name)
+	require.NoError(err)
+
+	require.NoError(state.DeleteACLAuthMethods(1002, []string{method.Name}))
+	require.True(watchFired(ws))
+
+	out, err := state.ACLAuthMethodByName(nil, method.Name)
+	require.NoError(err)
+	require.Nil(out)
+	out, err = state.ACLAuthMethodByName(nil, method2.Name)
+	require.NoError(err)
+	require.Equal(method2, out)
+
+	// The binding rules of the auth method are deleted with it
+	outRule, err := state.ACLBindingRuleByID(nil, rule.ID)
+	require.NoError(err)
+	require.Nil(outRule)
+	outRule, err = state.ACLBindingRuleByID(nil, rule2.ID)
+	require.NoError(err)
+	require.Equal(rule2, outRule)
+
+	for _, table := range []string{"acl_auth_method", "acl_binding_rule"} {
+		index, err := state.Index(table)
+		require.NoError(err)
+		require.EqualValues(1002, index)
+	}
+}
+
+func TestStateStore_UpsertACLBindingRules(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	state := testStateStore(t)
+	method := mock.ACLAuthMethod()
+	method2 := mock.ACLAuthMethod()
+	require.NoError(state.UpsertACLAuthMethods(1000, []*structs.ACLAuthMethod{method, method2}))
+
+	rule := mock.ACLBindingRule(method.Name)
+	rule2 := mock.ACLBindingRule(method2.Name)
+
+	ws := memdb.NewWatchSet()
+	_, err := state.ACLBindingRuleByID(ws, rule.ID)
+	require.NoError(err)
+
+	require.NoError(state.UpsertACLBindingRules(1001, []*structs.ACLBindingRule{rule, rule2}))
+	require.True(watchFired(ws))
+
+	ws = memdb.NewWatchSet()
+	out, err := state.ACLBindingRuleByID(ws, rule.ID)
+	require.NoError(err)
+	require.Equal(rule, out)
+
+	iter, err := state.ACLBindingRulesByAuthMethod(ws, method.Name)
+	require.NoError(err)
+	var ids []string
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		ids = append(ids, raw.(*structs.ACLBindingRule).ID)
+	}
+	require.Equal([]string{rule.ID}, ids)
+
+	iter, err = state.ACLBindingRules(ws)
+	require.NoError(err)
+	count := 0
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		count++
+	}
+	require.Equal(2, count)
+
+	index, err := state.Index("acl_binding_rule")
+	require.NoError(err)
+	require.EqualValues(1001, index)
+
+	// Update a binding rule, keeping its create index
+	update := mock.ACLBindingRule(method.Name)
+	update.ID = rule.ID
+	require.NoError(state.UpsertACLBindingRules(1002, []*structs.ACLBindingRule{update}))
+	out, err = state.ACLBindingRuleByID(nil, rule.ID)
+	require.NoError(err)
+	require.EqualValues(1001, out.CreateIndex)
+	require.EqualValues(1002, out.ModifyIndex)
+
+	// The auth method must exist
+	missing := mock.ACLBindingRule("missing")
+	err = state.UpsertACLBindingRules(1003, []*structs.ACLBindingRule{missing})
+	require.Error(err)
+	require.Contains(err.Error(), "not found")
+}
+
+func TestStateStore_DeleteACLBindingRules(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	state := testStateStore(t)
+	method := mock.ACLAuthMethod()
+	require.NoError(state.UpsertACLAuthMethods(1000, []*structs.ACLAuthMethod{method}))
+	rule := mock.ACLBindingRule(method.Name)
+	rule2 := mock.ACLBindingRule(method.Name)
+	require.NoError(state.UpsertACLBindingRules(1001, []*structs.ACLBindingRule{rule, rule2}))
+
+	ws := memdb.NewWatchSet()
+	_, err := state.ACLBindingRuleByID(ws, rule.ID)
+	require.NoError(err)
+
+	require.NoError(state.DeleteACLBindingRules(1002, []string{rule.ID}))
+	require.True(watchFired(ws))
+
+	out, err := state.ACLBindingRuleByID(nil, rule.ID)
+	require.NoError(err)
+	require.Nil(out)
+	out, err = state.ACLBindingRuleByID(nil, rule2.ID)
+	require.NoError(err)
+	require.Equal(rule2, out)
+
+	index, err := state.Index("acl_binding_rule")
+	require.NoError(err)
+	require.EqualValues(1002, index)
+}
+
+func TestStateStore_RestoreACLAuthMethod(t *testing.T) {
+	t.Parallel()
+
+	state := testStateStore(t)
+	method := mock.ACLAuthMethod()
+	rule := mock.ACLBindingRule(method.Name)
+
+	restore, err := state.Restore()
+	require.NoError(t, err)
+	require.NoError(t, restore.ACLAuthMethodRestore(method))
+	require.NoError(t, restore.ACLBindingRuleRestore(rule))
+	restore.Commit()
+
+	out, err := state.ACLAuthMethodByName(nil, method.Name)
+	require.NoError(t, err)
+	require.Equal(t, method, out)
+
+	outRule, err := state.ACLBindingRuleByID(nil, rule.ID)
+	require.NoError(t, err)
+	require.Equal(t, rule, outRule)
+}
+
 func TestStateStore_RestoreACLToken(t *testing.T) {
 	t.Parallel()
 
@@ -7331,6 +7692,62 @@ func TestStateStore_RestoreACLToken(t *testing.T) {
 	assert.Equal(t, token, out)
 }
 
+func TestStateStore_UpsertWorkloadIdentityKey(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	state := testStateStore(t)
+
+	out, err := state.WorkloadIdentityKey(nil)
+	require.NoError(err)
+	require.Nil(out)
+
+	key, err := structs.NewWorkloadIdentityKey()
+	require.NoError(err)
+
+	ws := memdb.NewWatchSet()
+	_, err = state.WorkloadIdentityKey(ws)
+	require.NoError(err)
+
+	require.NoError(state.UpsertWorkloadIdentityKey(1000, key))
+	require.True(watchFired(ws))
+
+	out, err = state.WorkloadIdentityKey(nil)
+	require.NoError(err)
+	require.Equal(key, out)
+	require.EqualValues(1000, out.CreateIndex)
+
+	index, err := state.Index("workload_identity_key")
+	require.NoError(err)
+	require.EqualValues(1000, index)
+
+	// The first key is kept
+	other, err := structs.NewWorkloadIdentityKey()
+	require.NoError(err)
+	require.NoError(state.UpsertWorkloadIdentityKey(1001, other))
+
+	out, err = state.WorkloadIdentityKey(nil)
+	require.NoError(err)
+	require.Equal(key.KeyID, out.KeyID)
+}
+
+func TestStateStore_RestoreWorkloadIdentityKey(t *testing.T) {
+	t.Parallel()
+
+	state := testStateStore(t)
+	key, err := structs.NewWorkloadIdentityKey()
+	require.NoError(t, err)
+
+	restore, err := state.Restore()
+	require.NoError(t, err)
+	require.NoError(t, restore.WorkloadIdentityKeyRestore(key))
+	restore.Commit()
+
+	out, err := state.WorkloadIdentityKey(nil)
+	require.NoError(t, err)
+	require.Equal(t, key, out)
+}
+
 func TestStateStore_SchedulerConfig(t *testing.T) {
 	t.Parallel()
 
@@ -7360,6 +7777,145 @@ func TestStateStore_SchedulerConfig(t *testing.T) {
 	require.Equal(schedConfig, out)
 }
 
+func TestStateStore_UpsertJob_ScalingPolicies(t *testing.T) {
+	t.Parallel()
+
+	require := require.New(t)
+	state := testStateStore(t)
+
+	job := mock.Job()
+	job.TaskGroups[0].Scaling = &structs.ScalingPolicy{
+		ID:      uuid.Generate(),
+		Min:     1,
+		Max:     20,
+		Enabled: true,
+	}
+	policyID := job.TaskGroups[0].Scaling.ID
+
+	ws := memdb.NewWatchSet()
+	_, err := state.ScalingPolicyByID(ws, policyID)
+	require.NoError(err)
+
+	require.NoError(state.UpsertJob(1000, job))
+	require.True(watchFired(ws))
+
+	policies, err := state.ScalingPoliciesByJob(nil, job.Namespace, job.ID)
+	require.NoError(err)
+	require.Len(policies, 1)
+	policy := policies[job.TaskGroups[0].Name]
+	require.NotNil(policy)
+	require.Equal(policyID, policy.ID)
+	require.Equal(job.Namespace, policy.TargetNamespace())
+	require.Equal(job.ID, policy.TargetJob())
+	require.Equal(job.TaskGroups[0].Name, policy.TargetGroup())
+	require.EqualValues(1000, policy.CreateIndex)
+	require.EqualValues(1000, policy.ModifyIndex)
+
+	// Registering the same policy with a new ID keeps the existing one
+	job2 := job.Copy()
+	job2.TaskGroups[0].Scaling.ID = uuid.Generate()
+	require.NoError(state.UpsertJob(1001, job2))
+
+	policy, err = state.ScalingPolicyByTarget(nil, job.Namespace, job.ID, job.TaskGroups[0].Name)
+	require.NoError(err)
+	require.Equal(policyID, policy.ID)
+	require.EqualValues(1000, policy.ModifyIndex)
+
+	// Changing the policy updates its modify index
+	job3 := job2.Copy()
+	job3.TaskGroups[0].Scaling.Max = 30
+	require.NoError(state.UpsertJob(1002, job3))
+
+	policy, err = state.ScalingPolicyByID(nil, policyID)
+	require.NoError(err)
+	require.NotNil(policy)
+	require.EqualValues(30, policy.Max)
+	require.EqualValues(1000, policy.CreateIndex)
+	require.EqualValues(1002, policy.ModifyIndex)
+
+	index, err := state.Index("scaling_policy")
+	require.NoError(err)
+	require.EqualValues(1002, index)
+
+	iter, err := state.ScalingPoliciesByNamespace(nil, job.Namespace)
+	require.NoError(err)
+	require.NotNil(iter.Next())
+	require.Nil(iter.Next())
+
+	// Removing the policy from the job deletes it
+	job4 := job3.Copy()
+	job4.TaskGroups[0].Scaling = nil
+	require.NoError(state.UpsertJob(1003, job4))
+
+	policy, err = state.ScalingPolicyByID(nil, policyID)
+	require.NoError(err)
+	require.Nil(policy)
+
+	// Deleting the job deletes its policies
+	require.NoError(state.UpsertJob(1004, job3.Copy()))
+	policies, err = state.ScalingPoliciesByJob(nil, job.Namespace, job.ID)
+	require.NoError(err)
+	require.Len(policies, 1)
+
+	require.NoError(state.DeleteJob(1005, job.Namespace, job.ID))
+	policies, err = state.ScalingPoliciesByJob(nil, job.Namespace, job.ID)
+	require.NoError(err)
+	require.Empty(policies)
+
+	index, err = state.Index("scaling_policy")
+	require.NoError(err)
+	require.EqualValues(1005, index)
+}
+
+func TestStateStore_UpsertScalingEvent(t *testing.T) {
+	t.Parallel()
+
+	require := require.New(t)
+	state := testStateStore(t)
+
+	job := mock.Job()
+	group := job.TaskGroups[0].Name
+	require.NoError(state.UpsertJob(1000, job))
+
+	ws := memdb.NewWatchSet()
+	events, _, err := state.ScalingEventsByJob(ws, job.Namespace, job.ID)
+	require.NoError(err)
+	require.Nil(events)
+
+	// Only the most recent events are kept, newest first
+	for i := 0; i < structs.JobTrackedScalingEvents+5; i++ {
+		req := &structs.ScalingEventRequest{
+			Namespace: job.Namespace,
+			JobID:     job.ID,
+			TaskGroup: group,
+			ScalingEvent: &structs.ScalingEvent{
+				Time:    time.Now().UnixNano(),
+				Message: fmt.Sprintf("event %d", i),
+			},
+		}
+		require.NoError(state.UpsertScalingEvent(uint64(1001+i), req))
+	}
+	require.True(watchFired(ws))
+
+	events, modifyIndex, err := state.ScalingEventsByJob(nil, job.Namespace, job.ID)
+	require.NoError(err)
+	require.EqualValues(1025, modifyIndex)
+	require.Len(events[group], structs.JobTrackedScalingEvents)
+	require.Equal("event 24", events[group][0].Message)
+	require.EqualValues(1025, events[group][0].CreateIndex)
+	require.Equal("event 5", events[group][structs.JobTrackedScalingEvents-1].Message)
+
+	index, err := state.Index("scaling_event")
+	require.NoError(err)
+	require.EqualValues(1025, index)
+
+	// Deleting the job deletes its events
+	require.NoError(state.DeleteJob(1026, job.Namespace, job.ID))
+	events, _, err = state.ScalingEventsByJob(nil, job.Namespace, job.ID)
+	require.NoError(err)
+	require.Nil(events)
+}
+
 func TestStateStore_Abandon(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,256 @@
+package stream
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+const (
+	// DefaultBufferSize is the default number of Raft indexes whose events
+	// are buffered
+	DefaultBufferSize = 100
+)
+
+var (
+	// ErrSubscriptionClosed is returned when the event broker is closed
+	ErrSubscriptionClosed = errors.New("subscription closed by server")
+
+	// ErrSubscriptionLagging is returned when a subscriber did not read the
+	// events before they were dropped from the buffer. The subscriber may
+	// resubscribe from the index of the last events it received.
+	ErrSubscriptionLagging = errors.New("subscription fell behind the event buffer")
+
+	// ErrIndexTooOld is returned when subscribing from an index whose
+	// following events were already dropped from the buffer
+	ErrIndexTooOld = errors.New("subscription index is older than the event buffer")
+)
+
+// EventBroker buffers the most recent events published by the FSM and streams
+// them to subscribers.
+type EventBroker struct {
+	l sync.Mutex
+
+	// buffer holds the most recent events, oldest first. It holds at most
+	// size entries.
+	buffer []*structs.Events
+	size   int
+
+	// seq is the sequence number of the last events published, the events
+	// at buffer[i] have the sequence number seq-len(buffer)+1+i
+	seq uint64
+
+	// droppedIndex is the Raft index of the most recent events dropped from
+	// the buffer
+	droppedIndex uint64
+
+	// notifyCh is closed and replaced when events are published to wake the
+	// waiting subscribers
+	notifyCh chan struct{}
+
+	closed bool
+}
+
+// NewEventBroker returns an event broker buffering the events of the given
+// number of Raft indexes.
+func NewEventBroker(size int) *EventBroker {
+	if size <= 0 {
+		size = DefaultBufferSize
+	}
+
+	return &EventBroker{
+		buffer:   make([]*structs.Events, 0, size),
+		size:     size,
+		notifyCh: make(chan struct{}),
+	}
+}
+
+// Publish adds the events to the buffer, dropping the oldest events when it is
+// full, and wakes the subscribers.
+func (b *EventBroker) Publish(events *structs.Events) {
+	if events == nil || len(events.Events) == 0 {
+		return
+	}
+
+	b.l.Lock()
+	defer b.l.Unlock()
+
+	if b.closed {
+		return
+	}
+
+	if len(b.buffer) == b.size {
+		b.droppedIndex = b.buffer[0].Index
+		b.buffer[0] = nil
+		b.buffer = b.buffer[1:]
+	}
+	b.buffer = append(b.buffer, events)
+	b.seq++
+
+	close(b.notifyCh)
+	b.notifyCh = make(chan struct{})
+}
+
+// Close stops the event broker, returning ErrSubscriptionClosed to the
+// subscribers.
+func (b *EventBroker) Close() {
+	b.l.Lock()
+	defer b.l.Unlock()
+
+	if b.closed {
+		return
+	}
+	b.closed = true
+	b.buffer = nil
+	close(b.notifyCh)
+}
+
+// SubscribeRequest describes the events a subscription receives.
+type SubscribeRequest struct {
+	// Topics maps the topics subscribed to to the keys of the events to
+	// receive, "*" matching every key. Every event is received when empty.
+	Topics map[structs.Topic][]string
+
+	// Namespace is the namespace of the events to receive, "*" matching
+	// every namespace. Events without a namespace are always received.
+	Namespace string
+
+	// Index is the Raft index after which events are received. Only new
+	// events are received when it is zero.
+	Index uint64
+
+	// Filter is an optional function returning whether an event may be
+	// received, such as to enforce ACLs
+	Filter func(*structs.Event) bool
+}
+
+// Subscription streams the events of an event broker matching a request.
+type Subscription struct {
+	broker *EventBroker
+	req    *SubscribeRequest
+
+	// next is the sequence number of the next events to read
+	next uint64
+}
+
+// Subscribe returns a subscription to the events matching the request. It
+// returns ErrIndexTooOld if events after the index of the request were already
+// dropped from the buffer.
+func (b *EventBroker) Subscribe(req *SubscribeRequest) (*Subscription, error) {
+	b.l.Lock()
+	defer b.l.Unlock()
+
+	if req.Index != 0 && req.Index < b.droppedIndex {
+		return nil, ErrIndexTooOld
+	}
+
+	sub := &Subscription{
+		broker: b,
+		req:    req,
+		next:   b.seq + 1,
+	}
+	if req.Index == 0 {
+		return sub, nil
+	}
+
+	oldest := b.seq - uint64(len(b.buffer)) + 1
+	for i, events := range b.buffer {
+		if events.Index > req.Index {
+			sub.next = oldest + uint64(i)
+			break
+		}
+	}
+	return sub, nil
+}
+
+// Next blocks until events matching the subscription are published and returns
+// them, or returns an error if the context is done or the subscription can not
+// continue.
+func (s *Subscription) Next(ctx context.Context) (*structs.Events, error) {
+	b := s.broker
+	for {
+		b.l.Lock()
+		if b.closed {
+			b.l.Unlock()
+			return nil, ErrSubscriptionClosed
+		}
+
+		oldest := b.seq - uint64(len(b.buffer)) + 1
+		if s.next < oldest {
+			b.l.Unlock()
+			return nil, ErrSubscriptionLagging
+		}
+
+		if s.next <= b.seq {
+			events := b.buffer[s.next-oldest]
+			b.l.Unlock()
+
+			s.next++
+			if matched := s.filter(events); matched != nil {
+				return matched, nil
+			}
+			continue
+		}
+
+		notifyCh := b.notifyCh
+		b.l.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-notifyCh:
+		}
+	}
+}
+
+// filter returns the events matching the subscription, or nil if none match.
+func (s *Subscription) filter(events *structs.Events) *structs.Events {
+	var matched []structs.Event
+	for i := range events.Events {
+		if s.matches(&events.Events[i]) {
+			matched = append(matched, events.Events[i])
+		}
+	}
+	if len(matched) == 0 {
+		return nil
+	}
+
+	return &structs.Events{
+		Index:  events.Index,
+		Events: matched,
+	}
+}
+
+// matches returns whether the event matches the subscription.
+func (s *Subscription) matches(event *structs.Event) bool {
+	req := s.req
+	if req.Namespace != "*" && event.Namespace != "" && event.Namespace != req.Namespace {
+		return false
+	}
+
+	if len(req.Topics) != 0 &&
+		!matchesKeys(req.Topics[event.Topic], event) &&
+		!matchesKeys(req.Topics[structs.TopicAll], event) {
+		return false
+	}
+
+	return req.Filter == nil || req.Filter(event)
+}
+
+// matchesKeys returns whether any of the keys matches the key or the filter
+// keys of the event.
+func matchesKeys(keys []string, event *structs.Event) bool {
+	for _, key := range keys {
+		if key == "*" || key == event.Key {
+			return true
+		}
+		for _, filterKey := range event.FilterKeys {
+			if key == filterKey {
+				return true
+			}
+		}
+	}
+	return false
+}
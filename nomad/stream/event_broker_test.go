@@ -0,0 +1,197 @@
+package stream
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/stretchr/testify/require"
+)
+
+func testEvents(index uint64, topic structs.Topic, key, namespace string, filterKeys ...string) *structs.Events {
+	return &structs.Events{
+		Index: index,
+		Events: []structs.Event{{
+			Topic:      topic,
+			Type:       "Test",
+			Key:        key,
+			Namespace:  namespace,
+			FilterKeys: filterKeys,
+			Index:      index,
+		}},
+	}
+}
+
+func nextEvents(t *testing.T, sub *Subscription) *structs.Events {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	events, err := sub.Next(ctx)
+	require.NoError(t, err)
+	return events
+}
+
+func requireNoEvents(t *testing.T, sub *Subscription) {
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	events, err := sub.Next(ctx)
+	require.Equal(t, context.DeadlineExceeded, err)
+	require.Nil(t, events)
+}
+
+func TestEventBroker_PublishSubscribe(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	b := NewEventBroker(0)
+	b.Publish(testEvents(1, structs.TopicJob, "old", "default"))
+
+	// Only new events are received without an index
+	sub, err := b.Subscribe(&SubscribeRequest{Namespace: "default"})
+	require.NoError(err)
+	requireNoEvents(t, sub)
+
+	doneCh := make(chan *structs.Events)
+	go func() {
+		doneCh <- nextEvents(t, sub)
+	}()
+
+	b.Publish(testEvents(2, structs.TopicJob, "new", "default"))
+	select {
+	case events := <-doneCh:
+		require.EqualValues(2, events.Index)
+		require.Len(events.Events, 1)
+		require.Equal("new", events.Events[0].Key)
+	case <-time.After(5 * time.Second):
+		t.Fatalf("timeout waiting for events")
+	}
+}
+
+func TestEventBroker_Subscribe_Index(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	b := NewEventBroker(3)
+	for i := uint64(1); i <= 5; i++ {
+		b.Publish(testEvents(i*10, structs.TopicJob, "job", "default"))
+	}
+
+	// Resume after a buffered index
+	sub, err := b.Subscribe(&SubscribeRequest{Namespace: "default", Index: 30})
+	require.NoError(err)
+	require.EqualValues(40, nextEvents(t, sub).Index)
+	require.EqualValues(50, nextEvents(t, sub).Index)
+	requireNoEvents(t, sub)
+
+	// Resume from the index of the most recent dropped events
+	sub, err = b.Subscribe(&SubscribeRequest{Namespace: "default", Index: 20})
+	require.NoError(err)
+	require.EqualValues(30, nextEvents(t, sub).Index)
+
+	// Resuming from an index whose following events were dropped fails
+	sub, err = b.Subscribe(&SubscribeRequest{Namespace: "default", Index: 5})
+	require.Equal(ErrIndexTooOld, err)
+	require.Nil(sub)
+
+	// Resume from the latest index
+	sub, err = b.Subscribe(&SubscribeRequest{Namespace: "default", Index: 50})
+	require.NoError(err)
+	requireNoEvents(t, sub)
+}
+
+func TestEventBroker_Subscribe_Filters(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	b := NewEventBroker(0)
+	req := &SubscribeRequest{
+		Topics: map[structs.Topic][]string{
+			structs.TopicJob:        {"web"},
+			structs.TopicAllocation: {"*"},
+		},
+		Namespace: "default",
+		Index:     1,
+		Filter: func(event *structs.Event) bool {
+			return event.Key != "denied"
+		},
+	}
+
+	b.Publish(testEvents(10, structs.TopicJob, "web", "default"))
+	b.Publish(testEvents(11, structs.TopicJob, "api", "default"))
+	b.Publish(testEvents(12, structs.TopicJob, "web", "other"))
+	b.Publish(testEvents(13, structs.TopicAllocation, "alloc", "default"))
+	b.Publish(testEvents(14, structs.TopicAllocation, "denied", "default"))
+	b.Publish(testEvents(15, structs.TopicNode, "node", ""))
+	b.Publish(testEvents(16, structs.TopicEvaluation, "eval", "default", "web"))
+
+	sub, err := b.Subscribe(req)
+	require.NoError(err)
+	require.EqualValues(10, nextEvents(t, sub).Index)
+	require.EqualValues(13, nextEvents(t, sub).Index)
+	requireNoEvents(t, sub)
+
+	// Events match the filter keys of the events and the wildcard topic
+	req.Topics = map[structs.Topic][]string{
+		structs.TopicEvaluation: {"web"},
+		structs.TopicAll:        {"node"},
+	}
+	sub, err = b.Subscribe(req)
+	require.NoError(err)
+	require.EqualValues(15, nextEvents(t, sub).Index)
+	require.EqualValues(16, nextEvents(t, sub).Index)
+	requireNoEvents(t, sub)
+
+	// Every namespace is matched by the wildcard namespace
+	req.Topics = map[structs.Topic][]string{structs.TopicJob: {"web"}}
+	req.Namespace = "*"
+	sub, err = b.Subscribe(req)
+	require.NoError(err)
+	require.EqualValues(10, nextEvents(t, sub).Index)
+	require.EqualValues(12, nextEvents(t, sub).Index)
+	requireNoEvents(t, sub)
+}
+
+func TestEventBroker_Subscribe_Lagging(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	b := NewEventBroker(2)
+	b.Publish(testEvents(1, structs.TopicJob, "job", "default"))
+	sub, err := b.Subscribe(&SubscribeRequest{Namespace: "default", Index: 0})
+	require.NoError(err)
+
+	for i := uint64(2); i <= 4; i++ {
+		b.Publish(testEvents(i, structs.TopicJob, "job", "default"))
+	}
+
+	_, err = sub.Next(context.Background())
+	require.Equal(ErrSubscriptionLagging, err)
+}
+
+func TestEventBroker_Close(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	b := NewEventBroker(0)
+	sub, err := b.Subscribe(&SubscribeRequest{Namespace: "default"})
+	require.NoError(err)
+
+	errCh := make(chan error)
+	go func() {
+		_, err := sub.Next(context.Background())
+		errCh <- err
+	}()
+
+	b.Close()
+	select {
+	case err := <-errCh:
+		require.Equal(ErrSubscriptionClosed, err)
+	case <-time.After(5 * time.Second):
+		t.Fatalf("timeout waiting for subscription to close")
+	}
+
+	// Publishing after closing is a no-op
+	b.Publish(testEvents(1, structs.TopicJob, "job", "default"))
+}
@@ -115,6 +115,17 @@ func (j *Job) Diff(other *Job, contextual bool) (*JobDiff, error) {
 		diff.Objects = append(diff.Objects, affinitiesDiff...)
 	}
 
+	// Datacenter preferences diff
+	prefDiff := primitiveObjectSetDiff(
+		interfaceSlice(j.DatacenterPreferences),
+		interfaceSlice(other.DatacenterPreferences),
+		[]string{"str"},
+		"DatacenterPreference",
+		contextual)
+	if prefDiff != nil {
+		diff.Objects = append(diff.Objects, prefDiff...)
+	}
+
 	// Task groups diff
 	tgs, err := taskGroupDiffs(j.TaskGroups, other.TaskGroups, contextual)
 	if err != nil {
@@ -249,6 +260,17 @@ func (tg *TaskGroup) Diff(other *TaskGroup, contextual bool) (*TaskGroupDiff, er
 		diff.Objects = append(diff.Objects, affinitiesDiff...)
 	}
 
+	// Datacenter preferences diff
+	prefDiff := primitiveObjectSetDiff(
+		interfaceSlice(tg.DatacenterPreferences),
+		interfaceSlice(other.DatacenterPreferences),
+		[]string{"str"},
+		"DatacenterPreference",
+		contextual)
+	if prefDiff != nil {
+		diff.Objects = append(diff.Objects, prefDiff...)
+	}
+
 	// Restart policy diff
 	rDiff := primitiveObjectDiff(tg.RestartPolicy, other.RestartPolicy, nil, "RestartPolicy", contextual)
 	if rDiff != nil {
@@ -451,6 +473,12 @@ func (t *Task) Diff(other *Task, contextual bool) (*TaskDiff, error) {
 		diff.Objects = append(diff.Objects, dDiff)
 	}
 
+	// Lifecycle diff
+	lcDiff := primitiveObjectDiff(t.Lifecycle, other.Lifecycle, nil, "Lifecycle", contextual)
+	if lcDiff != nil {
+		diff.Objects = append(diff.Objects, lcDiff)
+	}
+
 	// Artifacts diff
 	diffs := primitiveObjectSetDiff(
 		interfaceSlice(t.Artifacts),
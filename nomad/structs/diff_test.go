@@ -857,6 +857,74 @@ func TestJobDiff(t *testing.T) {
 				},
 			},
 		},
+		{
+			// Datacenter preferences edited
+			Old: &Job{
+				DatacenterPreferences: []*DatacenterPreference{
+					{
+						Datacenter: "dc1",
+						Weight:     100,
+					},
+					{
+						Datacenter: "dc2",
+						Weight:     50,
+					},
+				},
+			},
+			New: &Job{
+				DatacenterPreferences: []*DatacenterPreference{
+					{
+						Datacenter: "dc1",
+						Weight:     100,
+					},
+					{
+						Datacenter: "dc2",
+						Weight:     20,
+					},
+				},
+			},
+			Expected: &JobDiff{
+				Type: DiffTypeEdited,
+				Objects: []*ObjectDiff{
+					{
+						Type: DiffTypeAdded,
+						Name: "DatacenterPreference",
+						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeAdded,
+								Name: "Datacenter",
+								Old:  "",
+								New:  "dc2",
+							},
+							{
+								Type: DiffTypeAdded,
+								Name: "Weight",
+								Old:  "",
+								New:  "20",
+							},
+						},
+					},
+					{
+						Type: DiffTypeDeleted,
+						Name: "DatacenterPreference",
+						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeDeleted,
+								Name: "Datacenter",
+								Old:  "dc2",
+								New:  "",
+							},
+							{
+								Type: DiffTypeDeleted,
+								Name: "Weight",
+								Old:  "50",
+								New:  "",
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			// Task groups edited
 			Old: &Job{
@@ -3735,6 +3803,12 @@ func TestTaskDiff(t *testing.T) {
 								Old:  "100",
 								New:  "200",
 							},
+							{
+								Type: DiffTypeNone,
+								Name: "Cores",
+								Old:  "0",
+								New:  "0",
+							},
 							{
 								Type: DiffTypeEdited,
 								Name: "DiskMB",
@@ -3753,6 +3827,12 @@ func TestTaskDiff(t *testing.T) {
 								Old:  "100",
 								New:  "100",
 							},
+							{
+								Type: DiffTypeNone,
+								Name: "MemoryMaxMB",
+								Old:  "0",
+								New:  "0",
+							},
 						},
 					},
 				},
@@ -4088,6 +4168,12 @@ func TestTaskDiff(t *testing.T) {
 								Old:  "100",
 								New:  "100",
 							},
+							{
+								Type: DiffTypeNone,
+								Name: "Cores",
+								Old:  "0",
+								New:  "0",
+							},
 							{
 								Type: DiffTypeNone,
 								Name: "DiskMB",
@@ -4106,6 +4192,12 @@ func TestTaskDiff(t *testing.T) {
 								Old:  "100",
 								New:  "100",
 							},
+							{
+								Type: DiffTypeNone,
+								Name: "MemoryMaxMB",
+								Old:  "0",
+								New:  "0",
+							},
 						},
 						Objects: []*ObjectDiff{
 							{
@@ -12,6 +12,7 @@ const (
 	errNotReadyForConsistentReads = "Not ready to serve consistent reads"
 	errNoRegionPath               = "No path to region"
 	errTokenNotFound              = "ACL token not found"
+	errTokenExpired               = "ACL token expired"
 	errPermissionDenied           = "Permission denied"
 	errNoNodeConn                 = "No path to node"
 	errUnknownMethod              = "Unknown rpc method"
@@ -35,6 +36,7 @@ var (
 	ErrNotReadyForConsistentReads = errors.New(errNotReadyForConsistentReads)
 	ErrNoRegionPath               = errors.New(errNoRegionPath)
 	ErrTokenNotFound              = errors.New(errTokenNotFound)
+	ErrTokenExpired               = errors.New(errTokenExpired)
 	ErrPermissionDenied           = errors.New(errPermissionDenied)
 	ErrNoNodeConn                 = errors.New(errNoNodeConn)
 	ErrUnknownMethod              = errors.New(errUnknownMethod)
@@ -60,6 +62,12 @@ func IsErrTokenNotFound(err error) bool {
 	return err != nil && strings.Contains(err.Error(), errTokenNotFound)
 }
 
+// IsErrTokenExpired returns whether the error is due to the passed token
+// having expired.
+func IsErrTokenExpired(err error) bool {
+	return err != nil && strings.Contains(err.Error(), errTokenExpired)
+}
+
 // IsErrPermissionDenied returns whether the error is due to the operation not
 // being allowed due to lack of permissions.
 func IsErrPermissionDenied(err error) bool {
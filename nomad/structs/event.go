@@ -0,0 +1,104 @@
+package structs
+
+// Topic is the category of objects an Event is about.
+type Topic string
+
+const (
+	TopicDeployment Topic = "Deployment"
+	TopicEvaluation Topic = "Evaluation"
+	TopicAllocation Topic = "Allocation"
+	TopicJob        Topic = "Job"
+	TopicNode       Topic = "Node"
+
+	// TopicAll matches the events of every topic when subscribing
+	TopicAll Topic = "*"
+)
+
+const (
+	TypeNodeRegistration       = "NodeRegistration"
+	TypeNodeDeregistration     = "NodeDeregistration"
+	TypeNodeStatusUpdate       = "NodeStatusUpdate"
+	TypeNodeEligibilityUpdate  = "NodeEligibility"
+	TypeNodeDrain              = "NodeDrain"
+	TypeNodeEvent              = "NodeEvent"
+	TypeDeploymentStatusUpdate = "DeploymentStatusUpdate"
+	TypeDeploymentPromotion    = "DeploymentPromotion"
+	TypeDeploymentAllocHealth  = "DeploymentAllocHealth"
+	TypeAllocationUpdated      = "AllocationUpdated"
+	TypeEvalUpdated            = "EvaluationUpdated"
+	TypeJobRegistered          = "JobRegistered"
+	TypeJobDeregistered        = "JobDeregistered"
+)
+
+// Event is a change to the state store published to the event stream.
+type Event struct {
+	// Topic is the category of the object the event is about
+	Topic Topic
+
+	// Type is the kind of change, such as JobRegistered
+	Type string
+
+	// Key is the ID of the object the event is about
+	Key string
+
+	// Namespace is the namespace of the object, or empty for objects that
+	// are not namespaced such as nodes
+	Namespace string
+
+	// FilterKeys are the IDs of related objects that also match a
+	// subscription to the topic, such as the job ID of an allocation
+	FilterKeys []string
+
+	// Index is the Raft index of the change
+	Index uint64
+
+	// Payload is the object the event is about, such as a JobEvent
+	Payload interface{}
+}
+
+// Events are the events published by the application of a Raft log.
+type Events struct {
+	Index  uint64
+	Events []Event
+}
+
+// JobEvent is the payload of the events of TopicJob.
+type JobEvent struct {
+	Job *Job
+}
+
+// EvaluationEvent is the payload of the events of TopicEvaluation.
+type EvaluationEvent struct {
+	Evaluation *Evaluation
+}
+
+// AllocationEvent is the payload of the events of TopicAllocation. The job of
+// the allocation is omitted to keep events small.
+type AllocationEvent struct {
+	Allocation *Allocation
+}
+
+// DeploymentEvent is the payload of the events of TopicDeployment.
+type DeploymentEvent struct {
+	Deployment *Deployment
+}
+
+// NodeStreamEvent is the payload of the events of TopicNode. The secret ID of
+// the node is omitted.
+type NodeStreamEvent struct {
+	Node *Node
+}
+
+// EventStreamRequest is used to subscribe to the event stream.
+type EventStreamRequest struct {
+	// Topics maps the topics to subscribe to to the keys of the objects to
+	// receive the events of. The "*" key matches every object of the topic.
+	// All the events are streamed when no topic is given.
+	Topics map[Topic][]string
+
+	// Index is the Raft index after which events are streamed, to resume a
+	// previous subscription. Only new events are streamed when it is zero.
+	Index uint64
+
+	QueryOptions
+}
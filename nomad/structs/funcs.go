@@ -107,13 +107,28 @@ func AllocsFit(node *Node, allocs []*Allocation, netIdx *NetworkIndex, checkDevi
 	used.Add(node.ComparableReservedResources())
 
 	// For each alloc, add the resources
+	reservedCores := make(map[uint16]struct{})
+	coreOverlap := false
 	for _, alloc := range allocs {
 		// Do not consider the resource impact of terminal allocations
 		if alloc.TerminalStatus() {
 			continue
 		}
 
-		used.Add(alloc.ComparableResources())
+		cr := alloc.ComparableResources()
+		used.Add(cr)
+
+		// Reserved cores may not be shared between allocations
+		for _, core := range cr.Flattened.Cpu.ReservedCores {
+			if _, ok := reservedCores[core]; ok {
+				coreOverlap = true
+			}
+			reservedCores[core] = struct{}{}
+		}
+	}
+
+	if coreOverlap {
+		return false, "cores", used, nil
 	}
 
 	// Check that the node resources are a super set of those
@@ -148,10 +163,9 @@ func AllocsFit(node *Node, allocs []*Allocation, netIdx *NetworkIndex, checkDevi
 	return true, "", used, nil
 }
 
-// ScoreFit is used to score the fit based on the Google work published here:
-// http://www.columbia.edu/~cs2035/courses/ieor4405.S13/datacenter_scheduling.ppt
-// This is equivalent to their BestFit v3
-func ScoreFit(node *Node, util *ComparableResources) float64 {
+// computeFreePercentage returns the percentage of free CPU and memory
+// resources on the node given the utilization.
+func computeFreePercentage(node *Node, util *ComparableResources) (freePctCpu, freePctRam float64) {
 	// COMPAT(0.11): Remove in 0.11
 	reserved := node.ComparableReservedResources()
 	res := node.ComparableResources()
@@ -165,8 +179,18 @@ func ScoreFit(node *Node, util *ComparableResources) float64 {
 	}
 
 	// Compute the free percentage
-	freePctCpu := 1 - (float64(util.Flattened.Cpu.CpuShares) / nodeCpu)
-	freePctRam := 1 - (float64(util.Flattened.Memory.MemoryMB) / nodeMem)
+	freePctCpu = 1 - (float64(util.Flattened.Cpu.CpuShares) / nodeCpu)
+	freePctRam = 1 - (float64(util.Flattened.Memory.MemoryMB) / nodeMem)
+	return freePctCpu, freePctRam
+}
+
+// ScoreFitBinPack computes a fit score to achieve bin packing behavior.
+// Score is in [0, 18]
+//
+// It's the BestFit v3 on the Google work published here:
+// http://www.columbia.edu/~cs2035/courses/ieor4405.S13/datacenter_scheduling.ppt
+func ScoreFitBinPack(node *Node, util *ComparableResources) float64 {
+	freePctCpu, freePctRam := computeFreePercentage(node, util)
 
 	// Total will be "maximized" the smaller the value is.
 	// At 100% utilization, the total is 2, while at 0% util it is 20.
@@ -187,6 +211,31 @@ func ScoreFit(node *Node, util *ComparableResources) float64 {
 	return score
 }
 
+// ScoreFitSpread computes a fit score to achieve spread behavior.
+// Score is in [0, 18]
+//
+// This is equivalent to Worst Fit of
+// http://www.columbia.edu/~cs2035/courses/ieor4405.S13/datacenter_scheduling.ppt
+func ScoreFitSpread(node *Node, util *ComparableResources) float64 {
+	freePctCpu, freePctRam := computeFreePercentage(node, util)
+
+	// Total will be "maximized" the larger the value is.
+	// At 100% utilization, the total is 2, while at 0% util it is 20.
+	total := math.Pow(10, freePctCpu) + math.Pow(10, freePctRam)
+
+	// Shift so that the floor of 2 maps to a zero score. This means that
+	// an empty node returns 18 as the score.
+	score := total - 2
+
+	// Bound the score, just in case
+	if score > 18.0 {
+		score = 18.0
+	} else if score < 0 {
+		score = 0
+	}
+	return score
+}
+
 func CopySliceConstraints(s []*Constraint) []*Constraint {
 	l := len(s)
 	if l == 0 {
@@ -226,6 +275,19 @@ func CopySliceSpreads(s []*Spread) []*Spread {
 	return c
 }
 
+func CopySliceDatacenterPreferences(s []*DatacenterPreference) []*DatacenterPreference {
+	l := len(s)
+	if l == 0 {
+		return nil
+	}
+
+	c := make([]*DatacenterPreference, l)
+	for i, v := range s {
+		c[i] = v.Copy()
+	}
+	return c
+}
+
 func CopySliceSpreadTarget(s []*SpreadTarget) []*SpreadTarget {
 	l := len(s)
 	if l == 0 {
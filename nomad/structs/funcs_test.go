@@ -353,6 +353,58 @@ func TestAllocsFit(t *testing.T) {
 	require.EqualValues(3072, used.Flattened.Memory.MemoryMB)
 }
 
+func TestAllocsFit_Cores(t *testing.T) {
+	require := require.New(t)
+
+	n := &Node{
+		NodeResources: &NodeResources{
+			Cpu: NodeCpuResources{
+				CpuShares:          4000,
+				ReservableCpuCores: []uint16{0, 1, 2, 3},
+			},
+			Memory: NodeMemoryResources{
+				MemoryMB: 2048,
+			},
+		},
+	}
+
+	coreAlloc := func(cores ...uint16) *Allocation {
+		return &Allocation{
+			AllocatedResources: &AllocatedResources{
+				Tasks: map[string]*AllocatedTaskResources{
+					"web": {
+						Cpu: AllocatedCpuResources{
+							CpuShares:     int64(len(cores)) * 1000,
+							ReservedCores: cores,
+						},
+						Memory: AllocatedMemoryResources{
+							MemoryMB: 256,
+						},
+					},
+				},
+			},
+		}
+	}
+
+	// Allocations on distinct cores should fit
+	fit, _, used, err := AllocsFit(n, []*Allocation{coreAlloc(0, 1), coreAlloc(2)}, nil, false)
+	require.NoError(err)
+	require.True(fit)
+	require.Equal([]uint16{0, 1, 2}, used.Flattened.Cpu.ReservedCores)
+
+	// Allocations sharing a core should not fit
+	fit, dim, _, err := AllocsFit(n, []*Allocation{coreAlloc(0, 1), coreAlloc(1)}, nil, false)
+	require.NoError(err)
+	require.False(fit)
+	require.Equal("cores", dim)
+
+	// Allocations on cores the node doesn't have should not fit
+	fit, dim, _, err = AllocsFit(n, []*Allocation{coreAlloc(4)}, nil, false)
+	require.NoError(err)
+	require.False(fit)
+	require.Equal("cores", dim)
+}
+
 func TestAllocsFit_TerminalAlloc(t *testing.T) {
 	require := require.New(t)
 
@@ -528,7 +580,7 @@ func TestScoreFit_Old(t *testing.T) {
 			},
 		},
 	}
-	score := ScoreFit(node, util)
+	score := ScoreFitBinPack(node, util)
 	if score != 18.0 {
 		t.Fatalf("bad: %v", score)
 	}
@@ -544,7 +596,7 @@ func TestScoreFit_Old(t *testing.T) {
 			},
 		},
 	}
-	score = ScoreFit(node, util)
+	score = ScoreFitBinPack(node, util)
 	if score != 0.0 {
 		t.Fatalf("bad: %v", score)
 	}
@@ -560,7 +612,7 @@ func TestScoreFit_Old(t *testing.T) {
 			},
 		},
 	}
-	score = ScoreFit(node, util)
+	score = ScoreFitBinPack(node, util)
 	if score < 10.0 || score > 16.0 {
 		t.Fatalf("bad: %v", score)
 	}
@@ -596,7 +648,7 @@ func TestScoreFit(t *testing.T) {
 			},
 		},
 	}
-	score := ScoreFit(node, util)
+	score := ScoreFitBinPack(node, util)
 	if score != 18.0 {
 		t.Fatalf("bad: %v", score)
 	}
@@ -612,7 +664,7 @@ func TestScoreFit(t *testing.T) {
 			},
 		},
 	}
-	score = ScoreFit(node, util)
+	score = ScoreFitBinPack(node, util)
 	if score != 0.0 {
 		t.Fatalf("bad: %v", score)
 	}
@@ -628,12 +680,72 @@ func TestScoreFit(t *testing.T) {
 			},
 		},
 	}
-	score = ScoreFit(node, util)
+	score = ScoreFitBinPack(node, util)
 	if score < 10.0 || score > 16.0 {
 		t.Fatalf("bad: %v", score)
 	}
 }
 
+func TestScoreFitSpread(t *testing.T) {
+	node := &Node{}
+	node.NodeResources = &NodeResources{
+		Cpu: NodeCpuResources{
+			CpuShares: 4096,
+		},
+		Memory: NodeMemoryResources{
+			MemoryMB: 8192,
+		},
+	}
+	node.ReservedResources = &NodeReservedResources{
+		Cpu: NodeReservedCpuResources{
+			CpuShares: 2048,
+		},
+		Memory: NodeReservedMemoryResources{
+			MemoryMB: 4096,
+		},
+	}
+
+	// Test a perfect fit
+	util := &ComparableResources{
+		Flattened: AllocatedTaskResources{
+			Cpu: AllocatedCpuResources{
+				CpuShares: 2048,
+			},
+			Memory: AllocatedMemoryResources{
+				MemoryMB: 4096,
+			},
+		},
+	}
+	require.Equal(t, 0.0, ScoreFitSpread(node, util))
+
+	// Test an empty node
+	util = &ComparableResources{
+		Flattened: AllocatedTaskResources{
+			Cpu: AllocatedCpuResources{
+				CpuShares: 0,
+			},
+			Memory: AllocatedMemoryResources{
+				MemoryMB: 0,
+			},
+		},
+	}
+	require.Equal(t, 18.0, ScoreFitSpread(node, util))
+
+	// Test a mid-case scenario, which mirrors the binpack score
+	util = &ComparableResources{
+		Flattened: AllocatedTaskResources{
+			Cpu: AllocatedCpuResources{
+				CpuShares: 1024,
+			},
+			Memory: AllocatedMemoryResources{
+				MemoryMB: 2048,
+			},
+		},
+	}
+	score := ScoreFitSpread(node, util)
+	require.InDelta(t, 18.0-ScoreFitBinPack(node, util), score, 0.001)
+}
+
 func TestACLPolicyListHash(t *testing.T) {
 	h1 := ACLPolicyListHash(nil)
 	assert.NotEqual(t, "", h1)
@@ -1,6 +1,7 @@
 package structs
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/hashicorp/raft"
@@ -120,17 +121,120 @@ type AutopilotConfig struct {
 	ModifyIndex uint64
 }
 
+// SchedulerAlgorithm is an enum string that encapsulates the valid options for a
+// SchedulerConfiguration stanza's SchedulerAlgorithm. These modes will allow the
+// scheduler to be user-selectable.
+type SchedulerAlgorithm string
+
+const (
+	// SchedulerAlgorithmBinpack packs allocations tightly onto the most
+	// utilized nodes.
+	SchedulerAlgorithmBinpack SchedulerAlgorithm = "binpack"
+
+	// SchedulerAlgorithmSpread spreads allocations across the least
+	// utilized nodes.
+	SchedulerAlgorithmSpread SchedulerAlgorithm = "spread"
+)
+
 // SchedulerConfiguration is the config for controlling scheduler behavior
 type SchedulerConfiguration struct {
+	// SchedulerAlgorithm lets you select between available scheduling algorithms.
+	SchedulerAlgorithm SchedulerAlgorithm
+
 	// PreemptionConfig specifies whether to enable eviction of lower
 	// priority jobs to place higher priority jobs.
 	PreemptionConfig PreemptionConfig
 
+	// MemoryOversubscriptionEnabled specifies whether tasks may set a
+	// memory_max limit above the memory reserved for them.
+	MemoryOversubscriptionEnabled bool
+
+	// PauseEvalBroker stops the leader from processing evaluations. It is
+	// used by operators to delete evaluations while no scheduler can dequeue
+	// them.
+	PauseEvalBroker bool
+
 	// CreateIndex/ModifyIndex store the create/modify indexes of this configuration.
 	CreateIndex uint64
 	ModifyIndex uint64
 }
 
+// EffectiveSchedulerAlgorithm returns the scheduler algorithm to use,
+// defaulting to binpack when none is set.
+func (s *SchedulerConfiguration) EffectiveSchedulerAlgorithm() SchedulerAlgorithm {
+	if s == nil || s.SchedulerAlgorithm == "" {
+		return SchedulerAlgorithmBinpack
+	}
+
+	return s.SchedulerAlgorithm
+}
+
+// Validate returns an error if the scheduler configuration is invalid.
+func (s *SchedulerConfiguration) Validate() error {
+	if s == nil {
+		return nil
+	}
+
+	switch s.SchedulerAlgorithm {
+	case "", SchedulerAlgorithmBinpack, SchedulerAlgorithmSpread:
+	default:
+		return fmt.Errorf("invalid scheduler algorithm: %v", s.SchedulerAlgorithm)
+	}
+
+	return nil
+}
+
+// SchedulerRebalanceRequest is used by the Operator endpoint to compute a
+// plan of allocation migrations that reduces the fragmentation of the
+// cluster and optionally apply it.
+type SchedulerRebalanceRequest struct {
+	// DryRun computes the rebalance plan without applying it.
+	DryRun bool
+
+	// MaxMigrations is the maximum number of allocations the plan migrates.
+	// DefaultRebalanceMaxMigrations is used if it is zero.
+	MaxMigrations int
+
+	// WriteRequest holds the ACL token to go along with this request.
+	WriteRequest
+}
+
+// DefaultRebalanceMaxMigrations is the default maximum number of allocations
+// migrated by a rebalance plan.
+const DefaultRebalanceMaxMigrations = 10
+
+// RebalanceMigration is a proposed migration of an allocation that is part
+// of a rebalance plan.
+type RebalanceMigration struct {
+	AllocID   string
+	AllocName string
+	Namespace string
+	JobID     string
+	TaskGroup string
+
+	// SourceNodeID is the node the allocation currently runs on and
+	// TargetNodeID the node it scores best on. The scheduler may pick a
+	// different node when the migration is applied.
+	SourceNodeID string
+	TargetNodeID string
+
+	// ScoreImprovement is how much higher the allocation scores on the
+	// target node than on its current node.
+	ScoreImprovement float64
+}
+
+// SchedulerRebalanceResponse is the response to a SchedulerRebalanceRequest.
+type SchedulerRebalanceResponse struct {
+	// Migrations is the rebalance plan.
+	Migrations []*RebalanceMigration
+
+	// EvalIDs are the IDs of the evaluations created to carry out the
+	// migrations. It is empty for dry runs.
+	EvalIDs []string
+
+	WriteMeta
+}
+
 // SchedulerConfigurationResponse is the response object that wraps SchedulerConfiguration
 type SchedulerConfigurationResponse struct {
 	// SchedulerConfig contains scheduler config options
@@ -173,3 +277,40 @@ type SchedulerSetConfigRequest struct {
 	// WriteRequest holds the ACL token to go along with this request.
 	WriteRequest
 }
+
+// SnapshotSaveRequest is used by the Operator endpoint to take a snapshot of
+// the state of the cluster.
+type SnapshotSaveRequest struct {
+	QueryOptions
+}
+
+// SnapshotSaveResponse is the header of the response to a
+// SnapshotSaveRequest, which is followed by the snapshot archive.
+type SnapshotSaveResponse struct {
+	// SnapshotChecksum is the checksum of the snapshot archive, of the form
+	// "sha-256=<base64 encoded sum>"
+	SnapshotChecksum string
+
+	// ErrorCode and ErrorMsg are set when the snapshot could not be taken
+	ErrorCode int    `codec:",omitempty"`
+	ErrorMsg  string `codec:",omitempty"`
+
+	QueryMeta
+}
+
+// SnapshotRestoreRequest is used by the Operator endpoint to restore the state
+// of the cluster from a snapshot. The request is followed by the snapshot
+// archive, sent as a stream of StreamErrWrapper payloads terminated by an
+// io.EOF error.
+type SnapshotRestoreRequest struct {
+	WriteRequest
+}
+
+// SnapshotRestoreResponse is the response to a SnapshotRestoreRequest.
+type SnapshotRestoreResponse struct {
+	// ErrorCode and ErrorMsg are set when the snapshot could not be restored
+	ErrorCode int    `codec:",omitempty"`
+	ErrorMsg  string `codec:",omitempty"`
+
+	QueryMeta
+}
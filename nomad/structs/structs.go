@@ -32,6 +32,7 @@ import (
 	"github.com/hashicorp/nomad/helper/args"
 	"github.com/hashicorp/nomad/helper/constraints/semver"
 	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/lib/auth"
 	"github.com/hashicorp/nomad/lib/kheap"
 	psstructs "github.com/hashicorp/nomad/plugins/shared/structs"
 	"github.com/mitchellh/copystructure"
@@ -82,6 +83,14 @@ const (
 	BatchNodeUpdateDrainRequestType
 	SchedulerConfigRequestType
 	NodeBatchDeregisterRequestType
+	ScalingEventRegisterRequestType
+	ACLRoleUpsertRequestType
+	ACLRoleDeleteRequestType
+	WorkloadIdentityKeyRequestType
+	ACLAuthMethodUpsertRequestType
+	ACLAuthMethodDeleteRequestType
+	ACLBindingRuleUpsertRequestType
+	ACLBindingRuleDeleteRequestType
 )
 
 const (
@@ -117,6 +126,13 @@ const (
 	// maxTokenNameLength limits a ACL token name length
 	maxTokenNameLength = 256
 
+	// maxRoleDescriptionLength limits an ACL role description length
+	maxRoleDescriptionLength = 256
+
+	// maxBindingRuleDescriptionLength limits an ACL binding rule description
+	// length
+	maxBindingRuleDescriptionLength = 256
+
 	// ACLClientToken and ACLManagementToken are the only types of tokens
 	ACLClientToken     = "client"
 	ACLManagementToken = "management"
@@ -565,6 +581,8 @@ type JobPlanRequest struct {
 	Diff bool // Toggles an annotated diff
 	// PolicyOverride is set when the user is attempting to override any policies
 	PolicyOverride bool
+	// Explain toggles a per node breakdown of the placement decisions
+	Explain bool
 	WriteRequest
 }
 
@@ -625,6 +643,107 @@ type JobStabilityResponse struct {
 	WriteMeta
 }
 
+// JobScaleRequest is used for the Job.Scale endpoint to scale one of the
+// task groups of a job, or to record a scaling event against it
+type JobScaleRequest struct {
+	JobID string
+
+	// Target identifies the task group to scale, using the
+	// ScalingTargetGroup key
+	Target map[string]string
+
+	// Count is the new count of the task group. If it is nil, only the
+	// scaling event is recorded.
+	Count *int64
+
+	// Message, Error and Meta describe the scaling event
+	Message string
+	Error   bool
+	Meta    map[string]interface{}
+
+	// PolicyOverride is set when the count may exceed the bounds of the
+	// task group's scaling policy
+	PolicyOverride bool
+
+	WriteRequest
+}
+
+// JobScaleStatusRequest is used to get the scaling status of a job
+type JobScaleStatusRequest struct {
+	JobID string
+	QueryOptions
+}
+
+// JobScaleStatusResponse is used to return the scaling status of a job
+type JobScaleStatusResponse struct {
+	JobScaleStatus *JobScaleStatus
+	QueryMeta
+}
+
+// JobScaleStatus is the scaling status of the task groups of a job
+type JobScaleStatus struct {
+	JobID          string
+	JobCreateIndex uint64
+	JobModifyIndex uint64
+	JobStopped     bool
+	TaskGroups     map[string]*TaskGroupScaleStatus
+}
+
+// TaskGroupScaleStatus is the scaling status of a task group
+type TaskGroupScaleStatus struct {
+	// Desired is the count of the task group
+	Desired int
+
+	// Placed and Running are the number of allocations of the task group
+	// that are placed, and that are running
+	Placed  int
+	Running int
+
+	// Healthy and Unhealthy are the number of allocations of the task group
+	// that are healthy and unhealthy in the latest deployment
+	Healthy   int
+	Unhealthy int
+
+	// Events are the most recent scaling events of the task group, newest
+	// first
+	Events []*ScalingEvent
+}
+
+// ScalingEventRequest is used to record a scaling event against a task
+// group
+type ScalingEventRequest struct {
+	Namespace    string
+	JobID        string
+	TaskGroup    string
+	ScalingEvent *ScalingEvent
+
+	WriteRequest
+}
+
+// ScalingPolicyListRequest is used to list the scaling policies
+type ScalingPolicyListRequest struct {
+	QueryOptions
+}
+
+// ScalingPolicyListResponse is used for a list request
+type ScalingPolicyListResponse struct {
+	Policies []*ScalingPolicyListStub
+	QueryMeta
+}
+
+// ScalingPolicySpecificRequest is used when we just need to specify a
+// target scaling policy
+type ScalingPolicySpecificRequest struct {
+	ID string
+	QueryOptions
+}
+
+// SingleScalingPolicyResponse is used to return a single scaling policy
+type SingleScalingPolicyResponse struct {
+	Policy *ScalingPolicy
+	QueryMeta
+}
+
 // NodeListRequest is used to parameterize a list request
 type NodeListRequest struct {
 	QueryOptions
@@ -667,9 +786,26 @@ type EvalDequeueRequest struct {
 
 // EvalListRequest is used to list the evaluations
 type EvalListRequest struct {
+	// FilterJobID and FilterEvalStatus restrict the evaluations listed to
+	// those of the job and with the status, when set
+	FilterJobID      string
+	FilterEvalStatus string
+
 	QueryOptions
 }
 
+// ShouldBeFiltered returns whether the evaluation should be removed from the
+// results of the request
+func (req *EvalListRequest) ShouldBeFiltered(e *Evaluation) bool {
+	if req.FilterJobID != "" && req.FilterJobID != e.JobID {
+		return true
+	}
+	if req.FilterEvalStatus != "" && req.FilterEvalStatus != e.Status {
+		return true
+	}
+	return false
+}
+
 // PlanRequest is used to submit an allocation plan to the leader
 type PlanRequest struct {
 	Plan *Plan
@@ -1164,6 +1300,10 @@ type JobPlanResponse struct {
 	// FailedTGAllocs is the placement failures per task group.
 	FailedTGAllocs map[string]*AllocMetric
 
+	// Explanations is the per node breakdown of the placement decisions for
+	// each task group. It is only populated if Explain was requested.
+	Explanations map[string]*AllocMetric
+
 	// JobModifyIndex is the modification index of the job. The value can be
 	// used when running `nomad run` to ensure that the Job wasn’t modified
 	// since the last plan. If the job is being created, the value is zero.
@@ -1269,6 +1409,12 @@ type EvalListResponse struct {
 	QueryMeta
 }
 
+// EvalBlockedResponse is used to return the blocked evaluations
+type EvalBlockedResponse struct {
+	BlockedEvals []*BlockedEval
+	QueryMeta
+}
+
 // EvalAllocationsResponse is used to return the allocations for an evaluation
 type EvalAllocationsResponse struct {
 	Allocations []*AllocListStub
@@ -1809,12 +1955,14 @@ type NodeListStub struct {
 // Resources is used to define the resources available
 // on a client
 type Resources struct {
-	CPU      int
-	MemoryMB int
-	DiskMB   int
-	IOPS     int // COMPAT(0.10): Only being used to issue warnings
-	Networks Networks
-	Devices  ResourceDevices
+	CPU         int
+	Cores       int
+	MemoryMB    int
+	MemoryMaxMB int
+	DiskMB      int
+	IOPS        int // COMPAT(0.10): Only being used to issue warnings
+	Networks    Networks
+	Devices     ResourceDevices
 }
 
 const (
@@ -1866,6 +2014,18 @@ func (r *Resources) Validate() error {
 		}
 	}
 
+	// Ensure the memory limit isn't lower than the reservation
+	if r.MemoryMaxMB != 0 && r.MemoryMaxMB < r.MemoryMB {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("MemoryMaxMB value (%d) should be larger than MemoryMB value (%d)", r.MemoryMaxMB, r.MemoryMB))
+	}
+
+	// Ensure the task asks for either shared CPU or reserved cores
+	if r.Cores < 0 {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("Cores value (%d) must not be negative", r.Cores))
+	} else if r.Cores > 0 && r.CPU > 0 {
+		mErr.Errors = append(mErr.Errors, errors.New("Task can only ask for 'cpu' or 'cores' resource, not both."))
+	}
+
 	return mErr.ErrorOrNil()
 }
 
@@ -1875,9 +2035,15 @@ func (r *Resources) Merge(other *Resources) {
 	if other.CPU != 0 {
 		r.CPU = other.CPU
 	}
+	if other.Cores != 0 {
+		r.Cores = other.Cores
+	}
 	if other.MemoryMB != 0 {
 		r.MemoryMB = other.MemoryMB
 	}
+	if other.MemoryMaxMB != 0 {
+		r.MemoryMaxMB = other.MemoryMaxMB
+	}
 	if other.DiskMB != 0 {
 		r.DiskMB = other.DiskMB
 	}
@@ -1898,7 +2064,9 @@ func (r *Resources) Equals(o *Resources) bool {
 		return false
 	}
 	return r.CPU == o.CPU &&
+		r.Cores == o.Cores &&
 		r.MemoryMB == o.MemoryMB &&
+		r.MemoryMaxMB == o.MemoryMaxMB &&
 		r.DiskMB == o.DiskMB &&
 		r.IOPS == o.IOPS &&
 		r.Networks.Equals(&o.Networks) &&
@@ -1957,7 +2125,7 @@ func (r *Resources) Canonicalize() {
 func (r *Resources) MeetsMinResources() error {
 	var mErr multierror.Error
 	minResources := MinResources()
-	if r.CPU < minResources.CPU {
+	if r.CPU < minResources.CPU && r.Cores == 0 {
 		mErr.Errors = append(mErr.Errors, fmt.Errorf("minimum CPU value is %d; got %d", minResources.CPU, r.CPU))
 	}
 	if r.MemoryMB < minResources.MemoryMB {
@@ -2363,6 +2531,9 @@ func (n *NodeResources) Copy() *NodeResources {
 	newN := new(NodeResources)
 	*newN = *n
 
+	// Copy the reservable cores
+	newN.Cpu.ReservableCpuCores = copyCores(n.Cpu.ReservableCpuCores)
+
 	// Copy the networks
 	newN.Networks = n.Networks.Copy()
 
@@ -2388,7 +2559,8 @@ func (n *NodeResources) Comparable() *ComparableResources {
 	c := &ComparableResources{
 		Flattened: AllocatedTaskResources{
 			Cpu: AllocatedCpuResources{
-				CpuShares: n.Cpu.CpuShares,
+				CpuShares:     n.Cpu.CpuShares,
+				ReservedCores: n.Cpu.ReservableCpuCores,
 			},
 			Memory: AllocatedMemoryResources{
 				MemoryMB: n.Memory.MemoryMB,
@@ -2496,6 +2668,10 @@ type NodeCpuResources struct {
 	// CpuShares is the CPU shares available. This is calculated by number of
 	// cores multiplied by the core frequency.
 	CpuShares int64
+
+	// ReservableCpuCores is the set of CPU core IDs that tasks may reserve
+	// for their exclusive use.
+	ReservableCpuCores []uint16
 }
 
 func (n *NodeCpuResources) Merge(o *NodeCpuResources) {
@@ -2506,6 +2682,18 @@ func (n *NodeCpuResources) Merge(o *NodeCpuResources) {
 	if o.CpuShares != 0 {
 		n.CpuShares = o.CpuShares
 	}
+
+	if len(o.ReservableCpuCores) != 0 {
+		n.ReservableCpuCores = o.ReservableCpuCores
+	}
+}
+
+// SharesPerCore returns the CPU shares provided by a single core.
+func (n *NodeCpuResources) SharesPerCore() int64 {
+	if len(n.ReservableCpuCores) == 0 {
+		return 0
+	}
+	return n.CpuShares / int64(len(n.ReservableCpuCores))
 }
 
 func (n *NodeCpuResources) Equals(o *NodeCpuResources) bool {
@@ -2521,6 +2709,10 @@ func (n *NodeCpuResources) Equals(o *NodeCpuResources) bool {
 		return false
 	}
 
+	if !coresEqual(n.ReservableCpuCores, o.ReservableCpuCores) {
+		return false
+	}
+
 	return true
 }
 
@@ -2958,6 +3150,9 @@ func (a *AllocatedTaskResources) Copy() *AllocatedTaskResources {
 	newA := new(AllocatedTaskResources)
 	*newA = *a
 
+	// Copy the reserved cores
+	newA.Cpu.ReservedCores = copyCores(a.Cpu.ReservedCores)
+
 	// Copy the networks
 	newA.Networks = a.Networks.Copy()
 
@@ -3013,7 +3208,8 @@ func (a *AllocatedTaskResources) Comparable() *ComparableResources {
 	ret := &ComparableResources{
 		Flattened: AllocatedTaskResources{
 			Cpu: AllocatedCpuResources{
-				CpuShares: a.Cpu.CpuShares,
+				CpuShares:     a.Cpu.CpuShares,
+				ReservedCores: copyCores(a.Cpu.ReservedCores),
 			},
 			Memory: AllocatedMemoryResources{
 				MemoryMB: a.Memory.MemoryMB,
@@ -3083,6 +3279,10 @@ func (a *AllocatedSharedResources) Subtract(delta *AllocatedSharedResources) {
 // AllocatedCpuResources captures the allocated CPU resources.
 type AllocatedCpuResources struct {
 	CpuShares int64
+
+	// ReservedCores is the set of CPU core IDs reserved for the exclusive use
+	// of the task.
+	ReservedCores []uint16
 }
 
 func (a *AllocatedCpuResources) Add(delta *AllocatedCpuResources) {
@@ -3091,6 +3291,7 @@ func (a *AllocatedCpuResources) Add(delta *AllocatedCpuResources) {
 	}
 
 	a.CpuShares += delta.CpuShares
+	a.ReservedCores = unionCores(a.ReservedCores, delta.ReservedCores)
 }
 
 func (a *AllocatedCpuResources) Subtract(delta *AllocatedCpuResources) {
@@ -3099,11 +3300,77 @@ func (a *AllocatedCpuResources) Subtract(delta *AllocatedCpuResources) {
 	}
 
 	a.CpuShares -= delta.CpuShares
+	a.ReservedCores = differenceCores(a.ReservedCores, delta.ReservedCores)
+}
+
+// copyCores returns a copy of the set of core IDs.
+func copyCores(cores []uint16) []uint16 {
+	if cores == nil {
+		return nil
+	}
+	c := make([]uint16, len(cores))
+	copy(c, cores)
+	return c
+}
+
+// coresEqual returns whether both sets contain the same core IDs.
+func coresEqual(a, b []uint16) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	return len(differenceCores(a, b)) == 0
+}
+
+// unionCores returns the sorted set of core IDs in either a or b.
+func unionCores(a, b []uint16) []uint16 {
+	if len(a) == 0 && len(b) == 0 {
+		return nil
+	}
+
+	seen := make(map[uint16]struct{}, len(a)+len(b))
+	union := make([]uint16, 0, len(a)+len(b))
+	for _, cores := range [][]uint16{a, b} {
+		for _, core := range cores {
+			if _, ok := seen[core]; ok {
+				continue
+			}
+			seen[core] = struct{}{}
+			union = append(union, core)
+		}
+	}
+	sort.Slice(union, func(i, j int) bool { return union[i] < union[j] })
+	return union
+}
+
+// differenceCores returns the core IDs in a that are not in b.
+func differenceCores(a, b []uint16) []uint16 {
+	if len(a) == 0 {
+		return nil
+	}
+
+	remove := make(map[uint16]struct{}, len(b))
+	for _, core := range b {
+		remove[core] = struct{}{}
+	}
+
+	var diff []uint16
+	for _, core := range a {
+		if _, ok := remove[core]; !ok {
+			diff = append(diff, core)
+		}
+	}
+	return diff
 }
 
 // AllocatedMemoryResources captures the allocated memory resources.
 type AllocatedMemoryResources struct {
+	// MemoryMB is the amount of memory reserved for the task and used for
+	// scheduling
 	MemoryMB int64
+
+	// MemoryMaxMB is the maximum amount of memory the task may use. It is
+	// only set when memory oversubscription is enabled.
+	MemoryMaxMB int64
 }
 
 func (a *AllocatedMemoryResources) Add(delta *AllocatedMemoryResources) {
@@ -3112,6 +3379,7 @@ func (a *AllocatedMemoryResources) Add(delta *AllocatedMemoryResources) {
 	}
 
 	a.MemoryMB += delta.MemoryMB
+	a.MemoryMaxMB += delta.MemoryMaxMB
 }
 
 func (a *AllocatedMemoryResources) Subtract(delta *AllocatedMemoryResources) {
@@ -3120,6 +3388,7 @@ func (a *AllocatedMemoryResources) Subtract(delta *AllocatedMemoryResources) {
 	}
 
 	a.MemoryMB -= delta.MemoryMB
+	a.MemoryMaxMB -= delta.MemoryMaxMB
 }
 
 type AllocatedDevices []*AllocatedDeviceResource
@@ -3228,6 +3497,9 @@ func (c *ComparableResources) Superset(other *ComparableResources) (bool, string
 	if c.Flattened.Cpu.CpuShares < other.Flattened.Cpu.CpuShares {
 		return false, "cpu"
 	}
+	if len(differenceCores(other.Flattened.Cpu.ReservedCores, c.Flattened.Cpu.ReservedCores)) != 0 {
+		return false, "cores"
+	}
 	if c.Flattened.Memory.MemoryMB < other.Flattened.Memory.MemoryMB {
 		return false, "memory"
 	}
@@ -3336,6 +3608,16 @@ type Job struct {
 	// allocations across a desired attribute, such as datacenter
 	Spreads []*Spread
 
+	// DatacenterPreferences orders the datacenters of the job so that
+	// allocations are placed in a less preferred datacenter only when the
+	// more preferred ones have no capacity
+	DatacenterPreferences []*DatacenterPreference
+
+	// SchedulerAlgorithm overrides the scheduler algorithm of the scheduler
+	// configuration for the job. The cluster wide algorithm is used when
+	// empty.
+	SchedulerAlgorithm SchedulerAlgorithm
+
 	// TaskGroups are the collections of task groups that this job needs
 	// to run. Each task group is an atomic unit of scheduling and placement.
 	TaskGroups []*TaskGroup
@@ -3449,6 +3731,7 @@ func (j *Job) Copy() *Job {
 	nj.Datacenters = helper.CopySliceString(nj.Datacenters)
 	nj.Constraints = CopySliceConstraints(nj.Constraints)
 	nj.Affinities = CopySliceAffinities(nj.Affinities)
+	nj.DatacenterPreferences = CopySliceDatacenterPreferences(nj.DatacenterPreferences)
 
 	if j.TaskGroups != nil {
 		tgs := make([]*TaskGroup, len(nj.TaskGroups))
@@ -3492,6 +3775,11 @@ func (j *Job) Validate() error {
 	if j.Priority < JobMinPriority || j.Priority > JobMaxPriority {
 		mErr.Errors = append(mErr.Errors, fmt.Errorf("Job priority must be between [%d, %d]", JobMinPriority, JobMaxPriority))
 	}
+	switch j.SchedulerAlgorithm {
+	case "", SchedulerAlgorithmBinpack, SchedulerAlgorithmSpread:
+	default:
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("Invalid scheduler algorithm: %q", j.SchedulerAlgorithm))
+	}
 	if len(j.Datacenters) == 0 {
 		mErr.Errors = append(mErr.Errors, errors.New("Missing job datacenters"))
 	} else {
@@ -3536,6 +3824,14 @@ func (j *Job) Validate() error {
 		}
 	}
 
+	if j.Type == JobTypeSystem {
+		if j.DatacenterPreferences != nil {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("System jobs may not have a datacenter_preference stanza"))
+		}
+	} else if err := validateDatacenterPreferences(j.DatacenterPreferences, j.Datacenters); err != nil {
+		mErr.Errors = append(mErr.Errors, err)
+	}
+
 	// Check for duplicate task groups
 	taskGroups := make(map[string]int)
 	for idx, tg := range j.TaskGroups {
@@ -3812,6 +4108,19 @@ func (j *Job) SpecChanged(new *Job) bool {
 	c.JobModifyIndex = j.JobModifyIndex
 	c.SubmitTime = j.SubmitTime
 
+	// Scaling policies are assigned their identity and target when they are
+	// registered, so only compare their user supplied fields
+	for _, tg := range c.TaskGroups {
+		existing := j.LookupTaskGroup(tg.Name)
+		if tg.Scaling == nil || existing == nil || existing.Scaling == nil {
+			continue
+		}
+		tg.Scaling.ID = existing.Scaling.ID
+		tg.Scaling.Target = existing.Scaling.Target
+		tg.Scaling.CreateIndex = existing.Scaling.CreateIndex
+		tg.Scaling.ModifyIndex = existing.Scaling.ModifyIndex
+	}
+
 	// Deep equals the jobs
 	return !reflect.DeepEqual(j, c)
 }
@@ -3900,6 +4209,7 @@ type TaskGroupSummary struct {
 	Running  int
 	Starting int
 	Lost     int
+	Unknown  int
 }
 
 const (
@@ -4298,6 +4608,61 @@ func (d *DispatchPayloadConfig) Validate() error {
 	return nil
 }
 
+const (
+	// TaskLifecycleHookPrestart tasks are started before the main tasks of
+	// the group. Non-sidecar prestart tasks must complete successfully
+	// before the main tasks are started.
+	TaskLifecycleHookPrestart = "prestart"
+
+	// TaskLifecycleHookPoststart tasks are started once all the main tasks
+	// of the group are running.
+	TaskLifecycleHookPoststart = "poststart"
+
+	// TaskLifecycleHookPoststop tasks are started once all the main tasks
+	// of the group have stopped.
+	TaskLifecycleHookPoststop = "poststop"
+)
+
+// TaskLifecycleConfig describes when a task should be run relative to the
+// main tasks of its group.
+type TaskLifecycleConfig struct {
+	// Hook is the lifecycle phase the task is run in.
+	Hook string
+
+	// Sidecar marks the task as long lived. Sidecar tasks are kept running
+	// alongside the main tasks instead of running to completion.
+	Sidecar bool
+}
+
+func (d *TaskLifecycleConfig) Copy() *TaskLifecycleConfig {
+	if d == nil {
+		return nil
+	}
+	nd := new(TaskLifecycleConfig)
+	*nd = *d
+	return nd
+}
+
+func (d *TaskLifecycleConfig) Validate() error {
+	if d == nil {
+		return nil
+	}
+
+	switch d.Hook {
+	case TaskLifecycleHookPrestart, TaskLifecycleHookPoststart:
+	case TaskLifecycleHookPoststop:
+		if d.Sidecar {
+			return fmt.Errorf("poststop tasks can not be sidecars")
+		}
+	case "":
+		return fmt.Errorf("no lifecycle hook provided")
+	default:
+		return fmt.Errorf("invalid hook: %v", d.Hook)
+	}
+
+	return nil
+}
+
 var (
 	// These default restart policies needs to be in sync with
 	// Canonicalize in api/tasks.go
@@ -4727,6 +5092,10 @@ type TaskGroup struct {
 	// allocations across a desired attribute, such as datacenter
 	Spreads []*Spread
 
+	// DatacenterPreferences override the datacenter preferences of the job
+	// for the task group
+	DatacenterPreferences []*DatacenterPreference
+
 	// Networks are the network configuration for the task group. This can be
 	// overridden in the task.
 	Networks Networks
@@ -4736,6 +5105,14 @@ type TaskGroup struct {
 
 	// Volumes is a map of volumes that have been requested by the task group.
 	Volumes map[string]*VolumeRequest
+
+	// MaxClientDisconnect, if set, is the duration the allocations of the
+	// group are kept in the unknown state when their client stops
+	// heartbeating, instead of being marked as lost.
+	MaxClientDisconnect *time.Duration
+
+	// Scaling is the list of autoscaling policies for the TaskGroup
+	Scaling *ScalingPolicy
 }
 
 func (tg *TaskGroup) Copy() *TaskGroup {
@@ -4750,7 +5127,13 @@ func (tg *TaskGroup) Copy() *TaskGroup {
 	ntg.ReschedulePolicy = ntg.ReschedulePolicy.Copy()
 	ntg.Affinities = CopySliceAffinities(ntg.Affinities)
 	ntg.Spreads = CopySliceSpreads(ntg.Spreads)
+	ntg.DatacenterPreferences = CopySliceDatacenterPreferences(ntg.DatacenterPreferences)
 	ntg.Volumes = CopyMapVolumeRequest(ntg.Volumes)
+	ntg.Scaling = ntg.Scaling.Copy()
+
+	if tg.MaxClientDisconnect != nil {
+		ntg.MaxClientDisconnect = helper.TimeToPtr(*tg.MaxClientDisconnect)
+	}
 
 	// Copy the network objects
 	if tg.Networks != nil {
@@ -4864,6 +5247,14 @@ func (tg *TaskGroup) Validate(j *Job) error {
 		mErr.Errors = append(mErr.Errors, fmt.Errorf("Task Group %v should have a restart policy", tg.Name))
 	}
 
+	if tg.MaxClientDisconnect != nil {
+		if j.Type == JobTypeSystem {
+			mErr.Errors = append(mErr.Errors, errors.New("System jobs may not set max_client_disconnect"))
+		} else if *tg.MaxClientDisconnect < 0 {
+			mErr.Errors = append(mErr.Errors, errors.New("max_client_disconnect cannot be negative"))
+		}
+	}
+
 	if j.Type == JobTypeSystem {
 		if tg.Spreads != nil {
 			mErr.Errors = append(mErr.Errors, fmt.Errorf("System jobs may not have a spread stanza"))
@@ -4877,6 +5268,14 @@ func (tg *TaskGroup) Validate(j *Job) error {
 		}
 	}
 
+	if j.Type == JobTypeSystem {
+		if tg.DatacenterPreferences != nil {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("System jobs may not have a datacenter_preference stanza"))
+		}
+	} else if err := validateDatacenterPreferences(tg.DatacenterPreferences, j.Datacenters); err != nil {
+		mErr.Errors = append(mErr.Errors, err)
+	}
+
 	if j.Type == JobTypeSystem {
 		if tg.ReschedulePolicy != nil {
 			mErr.Errors = append(mErr.Errors, fmt.Errorf("System jobs should not have a reschedule policy"))
@@ -4899,6 +5298,13 @@ func (tg *TaskGroup) Validate(j *Job) error {
 		mErr.Errors = append(mErr.Errors, fmt.Errorf("Task Group %v should have an ephemeral disk object", tg.Name))
 	}
 
+	// Validate the scaling policy
+	if tg.Scaling != nil {
+		if err := tg.validateScalingPolicy(j); err != nil {
+			mErr.Errors = append(mErr.Errors, err)
+		}
+	}
+
 	// Validate the update strategy
 	if u := tg.Update; u != nil {
 		switch j.Type {
@@ -4946,6 +5352,11 @@ func (tg *TaskGroup) Validate(j *Job) error {
 		mErr.Errors = append(mErr.Errors, fmt.Errorf("Only one task may be marked as leader"))
 	}
 
+	// Check that lifecycle hooks have a main task to attach to
+	if len(tg.Tasks) > 0 && !tg.hasMainTask() {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("Task group must have at least one task without a lifecycle stanza"))
+	}
+
 	// Validate the Host Volumes
 	for name, decl := range tg.Volumes {
 		if decl.Type != VolumeTypeHost {
@@ -4994,6 +5405,17 @@ func (tg *TaskGroup) Validate(j *Job) error {
 	return mErr.ErrorOrNil()
 }
 
+// hasMainTask returns true if the task group has at least one task that is
+// not a lifecycle hook task.
+func (tg *TaskGroup) hasMainTask() bool {
+	for _, task := range tg.Tasks {
+		if task.Lifecycle == nil {
+			return true
+		}
+	}
+	return false
+}
+
 func (tg *TaskGroup) validateNetworks() error {
 	var mErr multierror.Error
 	portLabels := make(map[string]string)
@@ -5161,6 +5583,185 @@ func (tg *TaskGroup) GoString() string {
 	return fmt.Sprintf("*%#v", *tg)
 }
 
+// validateScalingPolicy ensures that the scaling policy has consistent
+// min and max, and that the task group count is within them.
+func (tg *TaskGroup) validateScalingPolicy(j *Job) error {
+	var mErr multierror.Error
+
+	switch {
+	case j.Type == JobTypeSystem:
+		return fmt.Errorf("System jobs may not have a scaling policy")
+	case j.IsPeriodic() || j.IsParameterized():
+		return fmt.Errorf("Periodic and parameterized jobs may not have a scaling policy")
+	}
+
+	if err := tg.Scaling.Validate(); err != nil {
+		mErr.Errors = append(mErr.Errors, err)
+	}
+
+	if int64(tg.Count) < tg.Scaling.Min {
+		mErr.Errors = append(mErr.Errors,
+			fmt.Errorf("Scaling policy invalid: task group count must not be less than minimum count in scaling policy"))
+	}
+	if tg.Scaling.Max < int64(tg.Count) {
+		mErr.Errors = append(mErr.Errors,
+			fmt.Errorf("Scaling policy invalid: task group count must not be greater than maximum count in scaling policy"))
+	}
+
+	return mErr.ErrorOrNil()
+}
+
+const (
+	// ScalingTargetNamespace, ScalingTargetJob and ScalingTargetGroup are
+	// the keys of the target of a scaling policy or request.
+	ScalingTargetNamespace = "Namespace"
+	ScalingTargetJob       = "Job"
+	ScalingTargetGroup     = "Group"
+
+	// JobTrackedScalingEvents is the number of scaling events that are
+	// tracked for each task group.
+	JobTrackedScalingEvents = 20
+)
+
+// ScalingPolicy specifies how a task group's count may be scaled by an
+// external autoscaler.
+type ScalingPolicy struct {
+	// ID is a generated UUID used for looking up the scaling policy
+	ID string
+
+	// Target contains information about the target of the scaling policy,
+	// like the namespace, job and group
+	Target map[string]string
+
+	// Policy is an opaque description of the scaling policy, passed to the
+	// autoscaler
+	Policy map[string]interface{}
+
+	// Min is the minimum allowable scaling count for this target
+	Min int64
+
+	// Max is the maximum allowable scaling count for this target
+	Max int64
+
+	// Enabled indicates whether this policy has been enabled/disabled
+	Enabled bool
+
+	CreateIndex uint64
+	ModifyIndex uint64
+}
+
+func (p *ScalingPolicy) Copy() *ScalingPolicy {
+	if p == nil {
+		return nil
+	}
+
+	np := new(ScalingPolicy)
+	*np = *p
+	np.Target = helper.CopyMapStringString(p.Target)
+
+	if p.Policy != nil {
+		policy, err := copystructure.Copy(p.Policy)
+		if err != nil {
+			panic(err.Error())
+		}
+		np.Policy = policy.(map[string]interface{})
+	}
+	return np
+}
+
+// Validate checks that the scaling policy bounds are consistent.
+func (p *ScalingPolicy) Validate() error {
+	var mErr multierror.Error
+
+	if p.Min < 0 {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("Scaling policy invalid: minimum count must not be negative"))
+	}
+	if p.Max < p.Min {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("Scaling policy invalid: maximum count must not be less than minimum count"))
+	}
+
+	return mErr.ErrorOrNil()
+}
+
+// TargetNamespace, TargetJob and TargetGroup return the parts of the target
+// of the scaling policy.
+func (p *ScalingPolicy) TargetNamespace() string { return p.Target[ScalingTargetNamespace] }
+func (p *ScalingPolicy) TargetJob() string       { return p.Target[ScalingTargetJob] }
+func (p *ScalingPolicy) TargetGroup() string     { return p.Target[ScalingTargetGroup] }
+
+// Stub returns a summary of the scaling policy used in list responses.
+func (p *ScalingPolicy) Stub() *ScalingPolicyListStub {
+	return &ScalingPolicyListStub{
+		ID:          p.ID,
+		Enabled:     p.Enabled,
+		Target:      helper.CopyMapStringString(p.Target),
+		CreateIndex: p.CreateIndex,
+		ModifyIndex: p.ModifyIndex,
+	}
+}
+
+// ScalingPolicyListStub is used to return a subset of scaling policy
+// information for the scaling policy list
+type ScalingPolicyListStub struct {
+	ID          string
+	Enabled     bool
+	Target      map[string]string
+	CreateIndex uint64
+	ModifyIndex uint64
+}
+
+// ScalingEvent describes a scaling event against a task group, whether it
+// changed its count or only reported a message.
+type ScalingEvent struct {
+	// Time is the time the event was recorded, in nanoseconds since the epoch
+	Time int64
+
+	// Count is the new count of the task group, if it was changed
+	Count *int64
+
+	// Message is the description of the event
+	Message string
+
+	// Error indicates that the event reports an error from the autoscaler
+	Error bool
+
+	// Meta is opaque metadata supplied by the autoscaler
+	Meta map[string]interface{}
+
+	// EvalID is the ID of the evaluation created if the count was changed
+	EvalID *string
+
+	CreateIndex uint64
+}
+
+// JobScalingEvents holds the most recent scaling events of each task group
+// of a job, newest first.
+type JobScalingEvents struct {
+	Namespace string
+	JobID     string
+
+	// ScalingEvents is a map of task group name to its scaling events
+	ScalingEvents map[string][]*ScalingEvent
+
+	ModifyIndex uint64
+}
+
+// Copy returns a copy of the job scaling events, sharing the events
+// themselves, which are never modified once recorded.
+func (j *JobScalingEvents) Copy() *JobScalingEvents {
+	if j == nil {
+		return nil
+	}
+
+	nj := new(JobScalingEvents)
+	*nj = *j
+	nj.ScalingEvents = make(map[string][]*ScalingEvent, len(j.ScalingEvents))
+	for group, events := range j.ScalingEvents {
+		nj.ScalingEvents[group] = append([]*ScalingEvent(nil), events...)
+	}
+	return nj
+}
+
 // CheckRestart describes if and when a task should be restarted based on
 // failing health checks.
 type CheckRestart struct {
@@ -5337,12 +5938,36 @@ type Task struct {
 	// Used internally to manage tasks according to their TaskKind. Initial use case
 	// is for Consul Connect
 	Kind TaskKind
+
+	// Lifecycle is used to run the task in a lifecycle phase other than
+	// alongside the main tasks of the group.
+	Lifecycle *TaskLifecycleConfig
 }
 
-func (t *Task) Copy() *Task {
-	if t == nil {
-		return nil
-	}
+// IsPrestart returns true if the task is run before the main tasks.
+func (t *Task) IsPrestart() bool {
+	return t != nil && t.Lifecycle != nil && t.Lifecycle.Hook == TaskLifecycleHookPrestart
+}
+
+// IsPoststart returns true if the task is run after the main tasks started.
+func (t *Task) IsPoststart() bool {
+	return t != nil && t.Lifecycle != nil && t.Lifecycle.Hook == TaskLifecycleHookPoststart
+}
+
+// IsPoststop returns true if the task is run after the main tasks stopped.
+func (t *Task) IsPoststop() bool {
+	return t != nil && t.Lifecycle != nil && t.Lifecycle.Hook == TaskLifecycleHookPoststop
+}
+
+// IsSidecar returns true if the task is a long lived lifecycle task.
+func (t *Task) IsSidecar() bool {
+	return t != nil && t.Lifecycle != nil && t.Lifecycle.Sidecar
+}
+
+func (t *Task) Copy() *Task {
+	if t == nil {
+		return nil
+	}
 	nt := new(Task)
 	*nt = *t
 	nt.Env = helper.CopyMapStringString(nt.Env)
@@ -5364,6 +5989,7 @@ func (t *Task) Copy() *Task {
 	nt.LogConfig = nt.LogConfig.Copy()
 	nt.Meta = helper.CopyMapStringString(nt.Meta)
 	nt.DispatchPayload = nt.DispatchPayload.Copy()
+	nt.Lifecycle = nt.Lifecycle.Copy()
 
 	if t.Artifacts != nil {
 		artifacts := make([]*TaskArtifact, 0, len(t.Artifacts))
@@ -5543,6 +6169,16 @@ func (t *Task) Validate(ephemeralDisk *EphemeralDisk, jobType string, tgServices
 		}
 	}
 
+	// Validate the lifecycle block if there
+	if t.Lifecycle != nil {
+		if err := t.Lifecycle.Validate(); err != nil {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("Lifecycle validation failed: %v", err))
+		}
+		if t.Leader && t.Lifecycle.Hook == TaskLifecycleHookPoststop {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("Poststop task must not have leader set"))
+		}
+	}
+
 	// Validation for TaskKind field which is used for Consul Connect integration
 	if t.Kind.IsConnectProxy() {
 		// This task is a Connect proxy so it should not have service stanzas
@@ -6056,6 +6692,9 @@ const (
 	// TaskLeaderDead indicates that the leader task within the has finished.
 	TaskLeaderDead = "Leader Task Dead"
 
+	// TaskMainDead indicates that the main tasks have dead
+	TaskMainDead = "Main Tasks Dead"
+
 	// TaskHookFailed indicates that one of the hooks for a task failed.
 	TaskHookFailed = "Task hook failed"
 
@@ -6271,6 +6910,8 @@ func (event *TaskEvent) PopulateEventDisplayMessage() {
 		desc = event.DriverMessage
 	case TaskLeaderDead:
 		desc = "Leader Task in Group dead"
+	case TaskMainDead:
+		desc = "Main tasks in the group died"
 	default:
 		desc = event.Message
 	}
@@ -6759,6 +7400,19 @@ type Affinity struct {
 	str     string // Memoized string
 }
 
+const (
+	// AffinityTargetJobID is the affinity LTarget that matches the ID of the
+	// jobs whose allocations are placed on a node.
+	AffinityTargetJobID = "${job.id}"
+
+	// AffinityTargetJobMetaPrefix is the affinity LTarget prefix that matches
+	// the meta of the jobs whose allocations are placed on a node.
+	AffinityTargetJobMetaPrefix = "${job.meta."
+
+	// affinityTargetJobPrefix is the prefix shared by all job affinity targets
+	affinityTargetJobPrefix = "${job."
+)
+
 // Equal checks if two affinities are equal
 func (a *Affinity) Equals(o *Affinity) bool {
 	return a == o ||
@@ -6789,12 +7443,32 @@ func (a *Affinity) String() string {
 	return a.str
 }
 
+// IsJobAffinity returns whether the affinity targets the jobs of the
+// allocations already placed on a node rather than the node itself.
+func (a *Affinity) IsJobAffinity() bool {
+	return strings.HasPrefix(a.LTarget, affinityTargetJobPrefix)
+}
+
 func (a *Affinity) Validate() error {
 	var mErr multierror.Error
 	if a.Operand == "" {
 		mErr.Errors = append(mErr.Errors, errors.New("Missing affinity operand"))
 	}
 
+	// Job affinities may only target the job ID or job meta, and are compared
+	// against a literal value
+	if a.IsJobAffinity() {
+		key := strings.TrimSuffix(strings.TrimPrefix(a.LTarget, AffinityTargetJobMetaPrefix), "}")
+		validMeta := strings.HasPrefix(a.LTarget, AffinityTargetJobMetaPrefix) &&
+			strings.HasSuffix(a.LTarget, "}") && key != ""
+		if a.LTarget != AffinityTargetJobID && !validMeta {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("Unsupported job affinity target %q", a.LTarget))
+		}
+		if strings.HasPrefix(a.RTarget, "${") {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("Job affinity RTarget must be a literal value"))
+		}
+	}
+
 	// Perform additional validation based on operand
 	switch a.Operand {
 	case ConstraintSetContainsAll, ConstraintSetContainsAny, ConstraintSetContains:
@@ -6850,6 +7524,12 @@ type Spread struct {
 	// SpreadTarget is used to describe desired percentages for each attribute value
 	SpreadTarget []*SpreadTarget
 
+	// Nested is an optional spread applied within each value of Attribute.
+	// It forms a hierarchy, such as racks within datacenters, whose targets
+	// are relative to the allocations of the enclosing value. The weight of
+	// a nested spread is ignored in favor of the outermost spread's weight.
+	Nested *Spread
+
 	// Memoized string representation
 	str string
 }
@@ -6887,6 +7567,7 @@ func (s *Spread) Copy() *Spread {
 	*ns = *s
 
 	ns.SpreadTarget = CopySliceSpreadTarget(s.SpreadTarget)
+	ns.Nested = s.Nested.Copy()
 	return ns
 }
 
@@ -6895,17 +7576,56 @@ func (s *Spread) String() string {
 		return s.str
 	}
 	s.str = fmt.Sprintf("%s %s %v", s.Attribute, s.SpreadTarget, s.Weight)
+	if s.Nested != nil {
+		s.str += fmt.Sprintf(" [%s]", s.Nested)
+	}
 	return s.str
 }
 
+// Levels returns the spread followed by its nested spreads, from the
+// outermost to the innermost attribute.
+func (s *Spread) Levels() []*Spread {
+	var levels []*Spread
+	for l := s; l != nil; l = l.Nested {
+		levels = append(levels, l)
+	}
+	return levels
+}
+
 func (s *Spread) Validate() error {
 	var mErr multierror.Error
-	if s.Attribute == "" {
-		mErr.Errors = append(mErr.Errors, errors.New("Missing spread attribute"))
-	}
 	if s.Weight <= 0 || s.Weight > 100 {
 		mErr.Errors = append(mErr.Errors, errors.New("Spread stanza must have a positive weight from 0 to 100"))
 	}
+
+	seen := make(map[string]struct{})
+	for i, level := range s.Levels() {
+		if err := level.validateTargets(); err != nil {
+			if i > 0 {
+				err = multierror.Prefix(err, fmt.Sprintf("Nested spread %q:", level.Attribute))
+			}
+			multierror.Append(&mErr, err)
+		}
+
+		// Make sure an attribute is used by a single level of the hierarchy
+		if level.Attribute == "" {
+			continue
+		}
+		if _, ok := seen[level.Attribute]; ok {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("Spread attribute %q used at multiple levels", level.Attribute))
+		}
+		seen[level.Attribute] = struct{}{}
+	}
+	return mErr.ErrorOrNil()
+}
+
+// validateTargets validates the attribute and targets of a single level of
+// the spread.
+func (s *Spread) validateTargets() error {
+	var mErr multierror.Error
+	if s.Attribute == "" {
+		mErr.Errors = append(mErr.Errors, errors.New("Missing spread attribute"))
+	}
 	seen := make(map[string]struct{})
 	sumPercent := uint32(0)
 
@@ -6958,6 +7678,80 @@ func (s *SpreadTarget) String() string {
 	return s.str
 }
 
+// DatacenterPreference is used to prefer placing allocations in a datacenter
+// over the other datacenters of the job.
+type DatacenterPreference struct {
+	// Datacenter is the preferred datacenter
+	Datacenter string
+
+	// Weight is the preference for the datacenter. Allocations are placed in
+	// the datacenters with the highest weight that have capacity, and
+	// datacenters without a preference have a weight of 0.
+	Weight int8
+
+	// Memoized string representation
+	str string
+}
+
+func (p *DatacenterPreference) Copy() *DatacenterPreference {
+	if p == nil {
+		return nil
+	}
+
+	np := new(DatacenterPreference)
+	*np = *p
+	return np
+}
+
+func (p *DatacenterPreference) String() string {
+	if p.str != "" {
+		return p.str
+	}
+	p.str = fmt.Sprintf("%q %v", p.Datacenter, p.Weight)
+	return p.str
+}
+
+func (p *DatacenterPreference) Equals(o *DatacenterPreference) bool {
+	return p == o || (p != nil && o != nil &&
+		p.Datacenter == o.Datacenter && p.Weight == o.Weight)
+}
+
+func (p *DatacenterPreference) Validate() error {
+	var mErr multierror.Error
+	if p.Datacenter == "" {
+		mErr.Errors = append(mErr.Errors, errors.New("Missing datacenter"))
+	}
+	if p.Weight <= 0 || p.Weight > 100 {
+		mErr.Errors = append(mErr.Errors, errors.New("Datacenter preference must have a positive weight from 1 to 100"))
+	}
+	return mErr.ErrorOrNil()
+}
+
+// validateDatacenterPreferences validates the preferences and that they
+// reference distinct datacenters of the job.
+func validateDatacenterPreferences(prefs []*DatacenterPreference, datacenters []string) error {
+	var mErr multierror.Error
+	dcs := helper.SliceStringToSet(datacenters)
+	seen := make(map[string]struct{}, len(prefs))
+	for idx, pref := range prefs {
+		if err := pref.Validate(); err != nil {
+			outer := fmt.Errorf("Datacenter preference %d validation failed: %s", idx+1, err)
+			mErr.Errors = append(mErr.Errors, outer)
+			continue
+		}
+
+		if _, ok := seen[pref.Datacenter]; ok {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("Datacenter preference for %q already defined", pref.Datacenter))
+		}
+		seen[pref.Datacenter] = struct{}{}
+
+		if _, ok := dcs[pref.Datacenter]; !ok {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("Datacenter preference for %q is not one of the job's datacenters", pref.Datacenter))
+		}
+	}
+	return mErr.ErrorOrNil()
+}
+
 // EphemeralDisk is an ephemeral disk object
 type EphemeralDisk struct {
 	// Sticky indicates whether the allocation is sticky to a node
@@ -7460,6 +8254,39 @@ const (
 	AllocClientStatusComplete = "complete"
 	AllocClientStatusFailed   = "failed"
 	AllocClientStatusLost     = "lost"
+	AllocClientStatusUnknown  = "unknown"
+)
+
+// AllocStateField is the field of an allocation whose transition is recorded
+// by an AllocState.
+type AllocStateField uint8
+
+const (
+	// AllocStateFieldClientStatus records a change of the client status made
+	// by the servers
+	AllocStateFieldClientStatus AllocStateField = iota
+)
+
+// AllocState records a transition of the state of an allocation.
+type AllocState struct {
+	Field AllocStateField
+	Value string
+	Time  time.Time
+}
+
+func (s *AllocState) Copy() *AllocState {
+	if s == nil {
+		return nil
+	}
+	ns := new(AllocState)
+	*ns = *s
+	return ns
+}
+
+const (
+	// AllocClientDescriptionUnknown is the client description of allocations
+	// whose client has disconnected.
+	AllocClientDescriptionUnknown = "alloc is unknown since its node is disconnected"
 )
 
 // Allocation is used to allocate the placement of a task group to a node.
@@ -7536,6 +8363,10 @@ type Allocation struct {
 	// TaskStates stores the state of each task,
 	TaskStates map[string]*TaskState
 
+	// AllocStates records the transitions of the state of the allocation that
+	// the client does not report, such as its client disconnecting
+	AllocStates []*AllocState
+
 	// PreviousAllocation is the allocation that this allocation is replacing
 	PreviousAllocation string
 
@@ -7565,6 +8396,10 @@ type Allocation struct {
 	// to stop running because it got preempted
 	PreemptedByAllocation string
 
+	// SignedIdentities maps the name of each task of the allocation to the
+	// workload identity token signed for it by the servers
+	SignedIdentities map[string]string
+
 	// Raft Indexes
 	CreateIndex uint64
 	ModifyIndex uint64
@@ -7639,11 +8474,43 @@ func (a *Allocation) copyImpl(job bool) *Allocation {
 		na.TaskStates = ts
 	}
 
+	if a.AllocStates != nil {
+		states := make([]*AllocState, len(a.AllocStates))
+		for i, s := range a.AllocStates {
+			states[i] = s.Copy()
+		}
+		na.AllocStates = states
+	}
+
 	na.RescheduleTracker = a.RescheduleTracker.Copy()
 	na.PreemptedAllocations = helper.CopySliceString(a.PreemptedAllocations)
+	na.SignedIdentities = helper.CopyMapStringString(a.SignedIdentities)
 	return na
 }
 
+// AppendState records a transition of the state of the allocation.
+func (a *Allocation) AppendState(field AllocStateField, value string) {
+	a.AllocStates = append(a.AllocStates, &AllocState{
+		Field: field,
+		Value: value,
+		Time:  time.Now().UTC(),
+	})
+}
+
+// NeedsToReconnect returns whether the allocation was marked as unknown
+// because its client disconnected and has not been reconciled since. The
+// client may have already reported a new status for it after reconnecting.
+func (a *Allocation) NeedsToReconnect() bool {
+	disconnected := false
+	for _, s := range a.AllocStates {
+		if s.Field != AllocStateFieldClientStatus {
+			continue
+		}
+		disconnected = s.Value == AllocClientStatusUnknown
+	}
+	return disconnected
+}
+
 // TerminalStatus returns if the desired or actual status is terminal and
 // will no longer transition.
 func (a *Allocation) TerminalStatus() bool {
@@ -8086,6 +8953,40 @@ type AllocMetric struct {
 	// This is to prevent creating many failed allocations for a
 	// single task group.
 	CoalescedFailures int
+
+	// NodeExplanations is the per node breakdown of why each evaluated node
+	// was filtered, exhausted or how it was scored. It is keyed by node ID
+	// and only populated when placements are explained during a job plan.
+	NodeExplanations map[string]*NodeExplanation
+}
+
+// NodeExplanation describes the outcome of evaluating a single node for a
+// placement.
+type NodeExplanation struct {
+	NodeID   string
+	NodeName string
+
+	// Filtered is the reason the node was filtered by a constraint or
+	// feasibility check.
+	Filtered string
+
+	// Exhausted is the resource dimension that was exhausted on the node.
+	Exhausted string
+
+	// Scores is the score per scorer and NormScore the final normalized
+	// score of a node that was ranked.
+	Scores    map[string]float64
+	NormScore float64
+}
+
+func (e *NodeExplanation) Copy() *NodeExplanation {
+	if e == nil {
+		return nil
+	}
+	ne := new(NodeExplanation)
+	*ne = *e
+	ne.Scores = helper.CopyMapStringFloat64(ne.Scores)
+	return ne
 }
 
 func (a *AllocMetric) Copy() *AllocMetric {
@@ -8102,15 +9003,49 @@ func (a *AllocMetric) Copy() *AllocMetric {
 	na.QuotaExhausted = helper.CopySliceString(na.QuotaExhausted)
 	na.Scores = helper.CopyMapStringFloat64(na.Scores)
 	na.ScoreMetaData = CopySliceNodeScoreMeta(na.ScoreMetaData)
+	if a.NodeExplanations != nil {
+		na.NodeExplanations = make(map[string]*NodeExplanation, len(a.NodeExplanations))
+		for id, e := range a.NodeExplanations {
+			na.NodeExplanations[id] = e.Copy()
+		}
+	}
 	return na
 }
 
+// ExplainNodes enables recording a NodeExplanation for every node that is
+// filtered, exhausted or scored.
+func (a *AllocMetric) ExplainNodes() {
+	if a.NodeExplanations == nil {
+		a.NodeExplanations = make(map[string]*NodeExplanation)
+	}
+}
+
+// explainNode returns the explanation for the given node or nil if nodes
+// are not being explained.
+func (a *AllocMetric) explainNode(node *Node) *NodeExplanation {
+	if a.NodeExplanations == nil || node == nil {
+		return nil
+	}
+	e, ok := a.NodeExplanations[node.ID]
+	if !ok {
+		e = &NodeExplanation{
+			NodeID:   node.ID,
+			NodeName: node.Name,
+		}
+		a.NodeExplanations[node.ID] = e
+	}
+	return e
+}
+
 func (a *AllocMetric) EvaluateNode() {
 	a.NodesEvaluated += 1
 }
 
 func (a *AllocMetric) FilterNode(node *Node, constraint string) {
 	a.NodesFiltered += 1
+	if e := a.explainNode(node); e != nil {
+		e.Filtered = constraint
+	}
 	if node != nil && node.NodeClass != "" {
 		if a.ClassFiltered == nil {
 			a.ClassFiltered = make(map[string]int)
@@ -8127,6 +9062,9 @@ func (a *AllocMetric) FilterNode(node *Node, constraint string) {
 
 func (a *AllocMetric) ExhaustedNode(node *Node, dimension string) {
 	a.NodesExhausted += 1
+	if e := a.explainNode(node); e != nil {
+		e.Exhausted = dimension
+	}
 	if node != nil && node.NodeClass != "" {
 		if a.ClassExhausted == nil {
 			a.ClassExhausted = make(map[string]int)
@@ -8151,6 +9089,17 @@ func (a *AllocMetric) ExhaustQuota(dimensions []string) {
 
 // ScoreNode is used to gather top K scoring nodes in a heap
 func (a *AllocMetric) ScoreNode(node *Node, name string, score float64) {
+	if e := a.explainNode(node); e != nil {
+		if name == NormScorerName {
+			e.NormScore = score
+		} else {
+			if e.Scores == nil {
+				e.Scores = make(map[string]float64)
+			}
+			e.Scores[name] = score
+		}
+	}
+
 	// Create nodeScoreMeta lazily if its the first time or if its a new node
 	if a.nodeScoreMeta == nil || a.nodeScoreMeta.NodeID != node.ID {
 		a.nodeScoreMeta = &NodeScoreMeta{
@@ -8301,20 +9250,23 @@ const (
 )
 
 const (
-	EvalTriggerJobRegister       = "job-register"
-	EvalTriggerJobDeregister     = "job-deregister"
-	EvalTriggerPeriodicJob       = "periodic-job"
-	EvalTriggerNodeDrain         = "node-drain"
-	EvalTriggerNodeUpdate        = "node-update"
-	EvalTriggerAllocStop         = "alloc-stop"
-	EvalTriggerScheduled         = "scheduled"
-	EvalTriggerRollingUpdate     = "rolling-update"
-	EvalTriggerDeploymentWatcher = "deployment-watcher"
-	EvalTriggerFailedFollowUp    = "failed-follow-up"
-	EvalTriggerMaxPlans          = "max-plan-attempts"
-	EvalTriggerRetryFailedAlloc  = "alloc-failure"
-	EvalTriggerQueuedAllocs      = "queued-allocs"
-	EvalTriggerPreemption        = "preemption"
+	EvalTriggerJobRegister          = "job-register"
+	EvalTriggerJobDeregister        = "job-deregister"
+	EvalTriggerPeriodicJob          = "periodic-job"
+	EvalTriggerNodeDrain            = "node-drain"
+	EvalTriggerNodeUpdate           = "node-update"
+	EvalTriggerAllocStop            = "alloc-stop"
+	EvalTriggerScheduled            = "scheduled"
+	EvalTriggerRollingUpdate        = "rolling-update"
+	EvalTriggerDeploymentWatcher    = "deployment-watcher"
+	EvalTriggerFailedFollowUp       = "failed-follow-up"
+	EvalTriggerMaxPlans             = "max-plan-attempts"
+	EvalTriggerRetryFailedAlloc     = "alloc-failure"
+	EvalTriggerQueuedAllocs         = "queued-allocs"
+	EvalTriggerPreemption           = "preemption"
+	EvalTriggerMaxDisconnectTimeout = "max-disconnect-timeout"
+	EvalTriggerRebalance            = "rebalance"
+	EvalTriggerScaling              = "job-scaling"
 )
 
 const (
@@ -8340,6 +9292,16 @@ const (
 	// check if they are terminal. If so, we delete these out of the system.
 	CoreJobDeploymentGC = "deployment-gc"
 
+	// CoreJobLocalTokenExpiredGC is used for the garbage collection of
+	// expired local ACL tokens. We periodically scan the local tokens of the
+	// region in expiration order and delete the expired ones.
+	CoreJobLocalTokenExpiredGC = "local-token-expired-gc"
+
+	// CoreJobGlobalTokenExpiredGC is used for the garbage collection of
+	// expired global ACL tokens. It only runs in the authoritative region,
+	// from which the deletions are replicated.
+	CoreJobGlobalTokenExpiredGC = "global-token-expired-gc"
+
 	// CoreJobForceGC is used to force garbage collection of all GCable objects.
 	CoreJobForceGC = "force-gc"
 )
@@ -8441,6 +9403,11 @@ type Evaluation struct {
 	// during the evaluation. This should not be set during normal operations.
 	AnnotatePlan bool
 
+	// ExplainPlacement triggers the scheduler to record why each evaluated
+	// node was filtered, exhausted or how it was scored. This should not be
+	// set during normal operations.
+	ExplainPlacement bool
+
 	// QueuedAllocations is the number of unplaced allocations at the time the
 	// evaluation was processed. The map is keyed by Task Group names.
 	QueuedAllocations map[string]int
@@ -8639,6 +9606,64 @@ func (e *Evaluation) UpdateModifyTime() {
 	}
 }
 
+// BlockedEval describes an evaluation tracked by the leader while it waits for
+// cluster capacity to place the allocations it failed to place.
+type BlockedEval struct {
+	ID           string
+	Namespace    string
+	JobID        string
+	Type         string
+	TriggeredBy  string
+	Priority     int
+	PreviousEval string
+
+	// FailedTGAllocs are the placement failures per task group that caused the
+	// evaluation to block, including the resource dimensions exhausted
+	FailedTGAllocs map[string]*AllocMetric
+
+	// ClassEligibility and EscapedComputedClass determine which node classes
+	// unblock the evaluation when their capacity changes. Escaped evaluations
+	// are unblocked by the capacity changes of any node.
+	ClassEligibility     map[string]bool
+	EscapedComputedClass bool
+
+	// QuotaLimitReached is the quota that was exhausted, if any
+	QuotaLimitReached string
+
+	CreateIndex uint64
+	ModifyIndex uint64
+	CreateTime  int64
+	ModifyTime  int64
+}
+
+// NewBlockedEval returns the description of the blocked evaluation, with the
+// placement failures of the evaluation that created it if it has none of its
+// own.
+func NewBlockedEval(eval, previous *Evaluation) *BlockedEval {
+	failed := eval.FailedTGAllocs
+	if len(failed) == 0 && previous != nil {
+		failed = previous.FailedTGAllocs
+	}
+
+	return &BlockedEval{
+		ID:                   eval.ID,
+		Namespace:            eval.Namespace,
+		JobID:                eval.JobID,
+		Type:                 eval.Type,
+		TriggeredBy:          eval.TriggeredBy,
+		Priority:             eval.Priority,
+		PreviousEval:         eval.PreviousEval,
+		FailedTGAllocs:       failed,
+		ClassEligibility:     eval.ClassEligibility,
+		EscapedComputedClass: eval.EscapedComputedClass,
+		QuotaLimitReached:    eval.QuotaLimitReached,
+		CreateIndex:          eval.CreateIndex,
+		ModifyIndex:          eval.ModifyIndex,
+		CreateTime:           eval.CreateTime,
+		ModifyTime:           eval.ModifyTime,
+	}
+}
+
 // Plan is used to submit a commit plan for task allocations. These
 // are submitted to the leader which verifies that resources have
 // not been overcommitted before admitting the plan.
@@ -8732,6 +9757,23 @@ func (p *Plan) AppendStoppedAlloc(alloc *Allocation, desiredDesc, clientStatus s
 	p.NodeUpdate[node] = append(existing, newAlloc)
 }
 
+// AppendUnknownAlloc is used to append an allocation whose client has
+// disconnected to the plan, marking its client status as unknown.
+func (p *Plan) AppendUnknownAlloc(alloc *Allocation) {
+	newAlloc := alloc.Copy()
+
+	// Normalize the job
+	newAlloc.Job = nil
+
+	newAlloc.ClientStatus = AllocClientStatusUnknown
+	newAlloc.ClientDescription = AllocClientDescriptionUnknown
+	newAlloc.AppendState(AllocStateFieldClientStatus, AllocClientStatusUnknown)
+
+	node := alloc.NodeID
+	existing := p.NodeAllocation[node]
+	p.NodeAllocation[node] = append(existing, newAlloc)
+}
+
 // AppendPreemptedAlloc is used to append an allocation that's being preempted to the plan.
 // To minimize the size of the plan, this only sets a minimal set of fields in the allocation
 func (p *Plan) AppendPreemptedAlloc(alloc *Allocation, preemptingAllocID string) {
@@ -9187,6 +10229,19 @@ type ACLToken struct {
 	CreateTime  time.Time // Time of creation
 	CreateIndex uint64
 	ModifyIndex uint64
+
+	// Roles are the ACL roles this token ties to. The token is granted the
+	// policies of its roles in addition to its own Policies.
+	Roles []*ACLTokenRoleLink
+
+	// ExpirationTime is the time after which the token can no longer be
+	// used, nil if the token never expires. It is either set when creating
+	// the token or computed from ExpirationTTL.
+	ExpirationTime *time.Time
+
+	// ExpirationTTL is the duration the token can be used for after its
+	// creation, zero if the token never expires.
+	ExpirationTTL time.Duration
 }
 
 var (
@@ -9202,15 +10257,17 @@ var (
 )
 
 type ACLTokenListStub struct {
-	AccessorID  string
-	Name        string
-	Type        string
-	Policies    []string
-	Global      bool
-	Hash        []byte
-	CreateTime  time.Time
-	CreateIndex uint64
-	ModifyIndex uint64
+	AccessorID     string
+	Name           string
+	Type           string
+	Policies       []string
+	Roles          []*ACLTokenRoleLink
+	Global         bool
+	Hash           []byte
+	CreateTime     time.Time
+	ExpirationTime *time.Time
+	CreateIndex    uint64
+	ModifyIndex    uint64
 }
 
 // SetHash is used to compute and set the hash of the ACL token
@@ -9227,6 +10284,9 @@ func (a *ACLToken) SetHash() []byte {
 	for _, policyName := range a.Policies {
 		hash.Write([]byte(policyName))
 	}
+	for _, role := range a.Roles {
+		hash.Write([]byte(role.ID))
+	}
 	if a.Global {
 		hash.Write([]byte("global"))
 	} else {
@@ -9243,16 +10303,31 @@ func (a *ACLToken) SetHash() []byte {
 
 func (a *ACLToken) Stub() *ACLTokenListStub {
 	return &ACLTokenListStub{
-		AccessorID:  a.AccessorID,
-		Name:        a.Name,
-		Type:        a.Type,
-		Policies:    a.Policies,
-		Global:      a.Global,
-		Hash:        a.Hash,
-		CreateTime:  a.CreateTime,
-		CreateIndex: a.CreateIndex,
-		ModifyIndex: a.ModifyIndex,
+		AccessorID:     a.AccessorID,
+		Name:           a.Name,
+		Type:           a.Type,
+		Policies:       a.Policies,
+		Roles:          a.Roles,
+		Global:         a.Global,
+		Hash:           a.Hash,
+		CreateTime:     a.CreateTime,
+		ExpirationTime: a.ExpirationTime,
+		CreateIndex:    a.CreateIndex,
+		ModifyIndex:    a.ModifyIndex,
+	}
+}
+
+// HasExpirationTime returns whether the token expires.
+func (a *ACLToken) HasExpirationTime() bool {
+	return a != nil && a.ExpirationTime != nil && !a.ExpirationTime.IsZero()
+}
+
+// IsExpired returns whether the token has expired at the given time.
+func (a *ACLToken) IsExpired(now time.Time) bool {
+	if !a.HasExpirationTime() {
+		return false
 	}
+	return a.ExpirationTime.Before(now)
 }
 
 // Validate is used to sanity check a token
@@ -9263,16 +10338,22 @@ func (a *ACLToken) Validate() error {
 	}
 	switch a.Type {
 	case ACLClientToken:
-		if len(a.Policies) == 0 {
-			mErr.Errors = append(mErr.Errors, fmt.Errorf("client token missing policies"))
+		if len(a.Policies) == 0 && len(a.Roles) == 0 {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("client token missing policies or roles"))
 		}
 	case ACLManagementToken:
 		if len(a.Policies) != 0 {
 			mErr.Errors = append(mErr.Errors, fmt.Errorf("management token cannot be associated with policies"))
 		}
+		if len(a.Roles) != 0 {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("management token cannot be associated with roles"))
+		}
 	default:
 		mErr.Errors = append(mErr.Errors, fmt.Errorf("token type must be client or management"))
 	}
+	if a.ExpirationTTL < 0 {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("token expiration TTL cannot be negative"))
+	}
 	return mErr.ErrorOrNil()
 }
 
@@ -9294,6 +10375,33 @@ func (a *ACLToken) PolicySubset(policies []string) bool {
 	return true
 }
 
+// RoleSubset checks if a given set of role IDs is a subset of the roles of
+// the token
+func (a *ACLToken) RoleSubset(roleIDs []string) bool {
+	// Hot-path the management tokens, superset of all roles.
+	if a.Type == ACLManagementToken {
+		return true
+	}
+	associatedRoles := make(map[string]struct{}, len(a.Roles))
+	for _, role := range a.Roles {
+		associatedRoles[role.ID] = struct{}{}
+	}
+	for _, roleID := range roleIDs {
+		if _, ok := associatedRoles[roleID]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// ACLTokenRoleLink links an ACL token to an ACL role. Either the ID or the
+// name of the role may be given when upserting the token, the other is
+// filled in from the role.
+type ACLTokenRoleLink struct {
+	ID   string
+	Name string
+}
+
 // ACLTokenListRequest is used to request a list of tokens
 type ACLTokenListRequest struct {
 	GlobalOnly bool
@@ -9366,3 +10474,598 @@ type ACLTokenUpsertResponse struct {
 	Tokens []*ACLToken
 	WriteMeta
 }
+
+// ACLRole groups a set of ACL policies under a name, so that tokens can be
+// linked to the role rather than to each of its policies.
+type ACLRole struct {
+	ID          string // Unique ID (UUID)
+	Name        string // Unique name
+	Description string // Human readable
+	Policies    []*ACLRolePolicyLink
+	Hash        []byte
+	CreateIndex uint64
+	ModifyIndex uint64
+}
+
+// ACLRolePolicyLink links an ACL role to an ACL policy by name.
+type ACLRolePolicyLink struct {
+	Name string
+}
+
+// SetHash is used to compute and set the hash of the ACL role
+func (r *ACLRole) SetHash() []byte {
+	// Initialize a 256bit Blake2 hash (32 bytes)
+	hash, err := blake2b.New256(nil)
+	if err != nil {
+		panic(err)
+	}
+
+	// Write all the user set fields
+	hash.Write([]byte(r.Name))
+	hash.Write([]byte(r.Description))
+	for _, policy := range r.Policies {
+		hash.Write([]byte(policy.Name))
+	}
+
+	// Finalize the hash
+	hashVal := hash.Sum(nil)
+
+	// Set and return the hash
+	r.Hash = hashVal
+	return hashVal
+}
+
+func (r *ACLRole) Stub() *ACLRoleListStub {
+	return &ACLRoleListStub{
+		ID:          r.ID,
+		Name:        r.Name,
+		Description: r.Description,
+		Policies:    r.Policies,
+		Hash:        r.Hash,
+		CreateIndex: r.CreateIndex,
+		ModifyIndex: r.ModifyIndex,
+	}
+}
+
+// Validate is used to sanity check a role
+func (r *ACLRole) Validate() error {
+	var mErr multierror.Error
+	if !validPolicyName.MatchString(r.Name) {
+		err := fmt.Errorf("invalid name '%s'", r.Name)
+		mErr.Errors = append(mErr.Errors, err)
+	}
+	if len(r.Description) > maxRoleDescriptionLength {
+		err := fmt.Errorf("description longer than %d", maxRoleDescriptionLength)
+		mErr.Errors = append(mErr.Errors, err)
+	}
+	if len(r.Policies) == 0 {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("role missing policies"))
+	}
+	for _, policy := range r.Policies {
+		if policy == nil || policy.Name == "" {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("role policy link missing name"))
+			break
+		}
+	}
+	return mErr.ErrorOrNil()
+}
+
+// PolicyNames returns the names of the policies of the role
+func (r *ACLRole) PolicyNames() []string {
+	names := make([]string, 0, len(r.Policies))
+	for _, policy := range r.Policies {
+		names = append(names, policy.Name)
+	}
+	return names
+}
+
+// ACLRoleListStub is used to for listing ACL roles
+type ACLRoleListStub struct {
+	ID          string
+	Name        string
+	Description string
+	Policies    []*ACLRolePolicyLink
+	Hash        []byte
+	CreateIndex uint64
+	ModifyIndex uint64
+}
+
+// ACLRoleListRequest is used to request a list of roles
+type ACLRoleListRequest struct {
+	QueryOptions
+}
+
+// ACLRoleSpecificRequest is used to query a specific role by ID
+type ACLRoleSpecificRequest struct {
+	RoleID string
+	QueryOptions
+}
+
+// ACLRoleByNameRequest is used to query a specific role by name
+type ACLRoleByNameRequest struct {
+	RoleName string
+	QueryOptions
+}
+
+// ACLRoleSetRequest is used to query a set of roles by ID
+type ACLRoleSetRequest struct {
+	RoleIDs []string
+	QueryOptions
+}
+
+// ACLRoleListResponse is used for a list request
+type ACLRoleListResponse struct {
+	Roles []*ACLRoleListStub
+	QueryMeta
+}
+
+// SingleACLRoleResponse is used to return a single role
+type SingleACLRoleResponse struct {
+	Role *ACLRole
+	QueryMeta
+}
+
+// ACLRoleSetResponse is used to return a set of roles
+type ACLRoleSetResponse struct {
+	Roles map[string]*ACLRole // Keyed by ID
+	QueryMeta
+}
+
+// ACLRoleDeleteRequest is used to delete a set of roles
+type ACLRoleDeleteRequest struct {
+	RoleIDs []string
+	WriteRequest
+}
+
+// ACLRoleUpsertRequest is used to upsert a set of roles
+type ACLRoleUpsertRequest struct {
+	Roles []*ACLRole
+	WriteRequest
+}
+
+// ACLRoleUpsertResponse is used to return from an ACLRoleUpsertRequest
+type ACLRoleUpsertResponse struct {
+	Roles []*ACLRole
+	WriteMeta
+}
+
+const (
+	// ACLAuthMethodTypeJWT is the type of auth methods logging in with JWTs
+	// issued by a trusted issuer
+	ACLAuthMethodTypeJWT = "JWT"
+
+	// ACLAuthMethodTypeOIDC is the type of auth methods logging in with the
+	// OpenID Connect authorization code flow of an OIDC provider
+	ACLAuthMethodTypeOIDC = "OIDC"
+
+	// ACLAuthMethodTokenLocalityLocal and ACLAuthMethodTokenLocalityGlobal
+	// set whether the tokens minted by an auth method are local to the
+	// region or global
+	ACLAuthMethodTokenLocalityLocal  = "local"
+	ACLAuthMethodTokenLocalityGlobal = "global"
+
+	// ACLBindingRuleBindTypePolicy binds the identities matching a binding
+	// rule to an ACL policy
+	ACLBindingRuleBindTypePolicy = "policy"
+
+	// ACLBindingRuleBindTypeRole binds the identities matching a binding rule
+	// to an ACL role
+	ACLBindingRuleBindTypeRole = "role"
+)
+
+// ACLAuthMethod is a method of exchanging an identity issued by an external
+// identity provider for an ACL token. The policies and roles of the minted
+// token are set by the binding rules of the auth method.
+type ACLAuthMethod struct {
+	Name          string // Unique name
+	Type          string // JWT or OIDC
+	TokenLocality string // local or global
+	MaxTokenTTL   time.Duration
+	Config        *ACLAuthMethodConfig
+	Hash          []byte
+	CreateIndex   uint64
+	ModifyIndex   uint64
+}
+
+// ACLAuthMethodConfig is the configuration of an ACL auth method
+type ACLAuthMethodConfig struct {
+	// OIDCDiscoveryURL is the issuer URL of the OIDC provider. The provider
+	// configuration and its JWKS are discovered from it.
+	OIDCDiscoveryURL string
+
+	// OIDCClientID and OIDCClientSecret are the credentials of the OIDC
+	// client registered with the provider
+	OIDCClientID     string
+	OIDCClientSecret string
+
+	// OIDCScopes are the scopes requested in addition to "openid"
+	OIDCScopes []string
+
+	// AllowedRedirectURIs are the URIs the OIDC provider is allowed to
+	// redirect users to once authenticated
+	AllowedRedirectURIs []string
+
+	// JWKSURL is the URL of the JWKS JWTs are verified against. It takes
+	// precedence over the JWKS of the OIDC provider.
+	JWKSURL string
+
+	// BoundIssuer and BoundAudiences are the accepted values of the iss and
+	// aud claims of JWTs
+	BoundIssuer    []string
+	BoundAudiences []string
+
+	// SigningAlgs are the accepted JWT signing algorithms
+	SigningAlgs []string
+
+	// ClockSkewLeeway is the leeway applied when validating the time claims
+	// of JWTs
+	ClockSkewLeeway time.Duration
+
+	// ClaimMappings and ListClaimMappings map claims to the names binding
+	// rule selectors refer to them by, as value.<name> and list.<name>
+	ClaimMappings     map[string]string
+	ListClaimMappings map[string]string
+}
+
+// SetHash is used to compute and set the hash of the ACL auth method
+func (a *ACLAuthMethod) SetHash() []byte {
+	// Initialize a 256bit Blake2 hash (32 bytes)
+	hash, err := blake2b.New256(nil)
+	if err != nil {
+		panic(err)
+	}
+
+	// Write all the user set fields
+	hash.Write([]byte(a.Name))
+	hash.Write([]byte(a.Type))
+	hash.Write([]byte(a.TokenLocality))
+	hash.Write([]byte(a.MaxTokenTTL.String()))
+	if c := a.Config; c != nil {
+		hash.Write([]byte(c.OIDCDiscoveryURL))
+		hash.Write([]byte(c.OIDCClientID))
+		hash.Write([]byte(c.OIDCClientSecret))
+		hash.Write([]byte(c.JWKSURL))
+		hash.Write([]byte(c.ClockSkewLeeway.String()))
+		for _, list := range [][]string{c.OIDCScopes, c.AllowedRedirectURIs,
+			c.BoundIssuer, c.BoundAudiences, c.SigningAlgs} {
+			for _, v := range list {
+				hash.Write([]byte(v))
+			}
+			hash.Write([]byte{0})
+		}
+		for _, m := range []map[string]string{c.ClaimMappings, c.ListClaimMappings} {
+			keys := make([]string, 0, len(m))
+			for k := range m {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			for _, k := range keys {
+				hash.Write([]byte(k))
+				hash.Write([]byte(m[k]))
+			}
+			hash.Write([]byte{0})
+		}
+	}
+
+	// Finalize the hash
+	hashVal := hash.Sum(nil)
+
+	// Set and return the hash
+	a.Hash = hashVal
+	return hashVal
+}
+
+func (a *ACLAuthMethod) Stub() *ACLAuthMethodListStub {
+	return &ACLAuthMethodListStub{
+		Name:        a.Name,
+		Type:        a.Type,
+		Hash:        a.Hash,
+		CreateIndex: a.CreateIndex,
+		ModifyIndex: a.ModifyIndex,
+	}
+}
+
+// Validate is used to sanity check an auth method
+func (a *ACLAuthMethod) Validate() error {
+	var mErr multierror.Error
+	if !validPolicyName.MatchString(a.Name) {
+		err := fmt.Errorf("invalid name '%s'", a.Name)
+		mErr.Errors = append(mErr.Errors, err)
+	}
+	switch a.Type {
+	case ACLAuthMethodTypeJWT, ACLAuthMethodTypeOIDC:
+	default:
+		err := fmt.Errorf("invalid type '%s'", a.Type)
+		mErr.Errors = append(mErr.Errors, err)
+	}
+	switch a.TokenLocality {
+	case ACLAuthMethodTokenLocalityLocal, ACLAuthMethodTokenLocalityGlobal:
+	default:
+		err := fmt.Errorf("invalid token locality '%s'", a.TokenLocality)
+		mErr.Errors = append(mErr.Errors, err)
+	}
+	if a.MaxTokenTTL <= 0 {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("max token TTL must be positive"))
+	}
+
+	c := a.Config
+	if c == nil {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("auth method missing config"))
+		return mErr.ErrorOrNil()
+	}
+	switch a.Type {
+	case ACLAuthMethodTypeJWT:
+		if c.JWKSURL == "" && c.OIDCDiscoveryURL == "" {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("JWT auth method requires a JWKS URL or an OIDC discovery URL"))
+		}
+	case ACLAuthMethodTypeOIDC:
+		if c.OIDCDiscoveryURL == "" {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("OIDC auth method requires an OIDC discovery URL"))
+		}
+		if c.OIDCClientID == "" || c.OIDCClientSecret == "" {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("OIDC auth method requires an OIDC client ID and secret"))
+		}
+		if len(c.AllowedRedirectURIs) == 0 {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("OIDC auth method requires allowed redirect URIs"))
+		}
+	}
+	for _, alg := range c.SigningAlgs {
+		if !auth.ValidSigningAlg(alg) {
+			err := fmt.Errorf("invalid signing algorithm '%s'", alg)
+			mErr.Errors = append(mErr.Errors, err)
+		}
+	}
+	if c.ClockSkewLeeway < 0 {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("clock skew leeway must not be negative"))
+	}
+	return mErr.ErrorOrNil()
+}
+
+// ACLAuthMethodListStub is used to for listing ACL auth methods. It holds
+// what is needed to log in, so listing auth methods requires no token.
+type ACLAuthMethodListStub struct {
+	Name        string
+	Type        string
+	Hash        []byte
+	CreateIndex uint64
+	ModifyIndex uint64
+}
+
+// ACLAuthMethodListRequest is used to request a list of auth methods
+type ACLAuthMethodListRequest struct {
+	QueryOptions
+}
+
+// ACLAuthMethodSpecificRequest is used to query a specific auth method
+type ACLAuthMethodSpecificRequest struct {
+	MethodName string
+	QueryOptions
+}
+
+// ACLAuthMethodSetRequest is used to query a set of auth methods by name
+type ACLAuthMethodSetRequest struct {
+	MethodNames []string
+	QueryOptions
+}
+
+// ACLAuthMethodListResponse is used for a list request
+type ACLAuthMethodListResponse struct {
+	AuthMethods []*ACLAuthMethodListStub
+	QueryMeta
+}
+
+// SingleACLAuthMethodResponse is used to return a single auth method
+type SingleACLAuthMethodResponse struct {
+	AuthMethod *ACLAuthMethod
+	QueryMeta
+}
+
+// ACLAuthMethodSetResponse is used to return a set of auth methods
+type ACLAuthMethodSetResponse struct {
+	AuthMethods map[string]*ACLAuthMethod // Keyed by name
+	QueryMeta
+}
+
+// ACLAuthMethodDeleteRequest is used to delete a set of auth methods. The
+// binding rules of the auth methods are deleted with them.
+type ACLAuthMethodDeleteRequest struct {
+	MethodNames []string
+	WriteRequest
+}
+
+// ACLAuthMethodUpsertRequest is used to upsert a set of auth methods
+type ACLAuthMethodUpsertRequest struct {
+	AuthMethods []*ACLAuthMethod
+	WriteRequest
+}
+
+// ACLAuthMethodUpsertResponse is used to return from an
+// ACLAuthMethodUpsertRequest
+type ACLAuthMethodUpsertResponse struct {
+	AuthMethods []*ACLAuthMethod
+	WriteMeta
+}
+
+// ACLBindingRule binds the identities logging in with an auth method whose
+// claims match its selector to an ACL policy or role.
+type ACLBindingRule struct {
+	ID          string // Unique ID (UUID)
+	Description string // Human readable
+	AuthMethod  string // Name of the auth method
+	Selector    string // Expression matched against the mapped claims
+	BindType    string // policy or role
+	BindName    string // Name of the policy or role
+	Hash        []byte
+	CreateIndex uint64
+	ModifyIndex uint64
+}
+
+// SetHash is used to compute and set the hash of the ACL binding rule
+func (b *ACLBindingRule) SetHash() []byte {
+	// Initialize a 256bit Blake2 hash (32 bytes)
+	hash, err := blake2b.New256(nil)
+	if err != nil {
+		panic(err)
+	}
+
+	// Write all the user set fields
+	hash.Write([]byte(b.Description))
+	hash.Write([]byte(b.AuthMethod))
+	hash.Write([]byte(b.Selector))
+	hash.Write([]byte(b.BindType))
+	hash.Write([]byte(b.BindName))
+
+	// Finalize the hash
+	hashVal := hash.Sum(nil)
+
+	// Set and return the hash
+	b.Hash = hashVal
+	return hashVal
+}
+
+func (b *ACLBindingRule) Stub() *ACLBindingRuleListStub {
+	return &ACLBindingRuleListStub{
+		ID:          b.ID,
+		Description: b.Description,
+		AuthMethod:  b.AuthMethod,
+		BindType:    b.BindType,
+		BindName:    b.BindName,
+		Hash:        b.Hash,
+		CreateIndex: b.CreateIndex,
+		ModifyIndex: b.ModifyIndex,
+	}
+}
+
+// Validate is used to sanity check a binding rule
+func (b *ACLBindingRule) Validate() error {
+	var mErr multierror.Error
+	if b.AuthMethod == "" {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("binding rule missing auth method"))
+	}
+	if len(b.Description) > maxBindingRuleDescriptionLength {
+		err := fmt.Errorf("description longer than %d", maxBindingRuleDescriptionLength)
+		mErr.Errors = append(mErr.Errors, err)
+	}
+	switch b.BindType {
+	case ACLBindingRuleBindTypePolicy, ACLBindingRuleBindTypeRole:
+	default:
+		err := fmt.Errorf("invalid bind type '%s'", b.BindType)
+		mErr.Errors = append(mErr.Errors, err)
+	}
+	if b.BindName == "" {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("binding rule missing bind name"))
+	}
+	if _, err := auth.ParseSelector(b.Selector); err != nil {
+		err = fmt.Errorf("invalid selector: %v", err)
+		mErr.Errors = append(mErr.Errors, err)
+	}
+	return mErr.ErrorOrNil()
+}
+
+// ACLBindingRuleListStub is used to for listing ACL binding rules
+type ACLBindingRuleListStub struct {
+	ID          string
+	Description string
+	AuthMethod  string
+	BindType    string
+	BindName    string
+	Hash        []byte
+	CreateIndex uint64
+	ModifyIndex uint64
+}
+
+// ACLBindingRuleListRequest is used to request a list of binding rules
+type ACLBindingRuleListRequest struct {
+	QueryOptions
+}
+
+// ACLBindingRuleSpecificRequest is used to query a specific binding rule
+type ACLBindingRuleSpecificRequest struct {
+	BindingRuleID string
+	QueryOptions
+}
+
+// ACLBindingRuleSetRequest is used to query a set of binding rules by ID
+type ACLBindingRuleSetRequest struct {
+	BindingRuleIDs []string
+	QueryOptions
+}
+
+// ACLBindingRuleListResponse is used for a list request
+type ACLBindingRuleListResponse struct {
+	BindingRules []*ACLBindingRuleListStub
+	QueryMeta
+}
+
+// SingleACLBindingRuleResponse is used to return a single binding rule
+type SingleACLBindingRuleResponse struct {
+	BindingRule *ACLBindingRule
+	QueryMeta
+}
+
+// ACLBindingRuleSetResponse is used to return a set of binding rules
+type ACLBindingRuleSetResponse struct {
+	BindingRules map[string]*ACLBindingRule // Keyed by ID
+	QueryMeta
+}
+
+// ACLBindingRuleDeleteRequest is used to delete a set of binding rules
+type ACLBindingRuleDeleteRequest struct {
+	BindingRuleIDs []string
+	WriteRequest
+}
+
+// ACLBindingRuleUpsertRequest is used to upsert a set of binding rules
+type ACLBindingRuleUpsertRequest struct {
+	BindingRules []*ACLBindingRule
+	WriteRequest
+}
+
+// ACLBindingRuleUpsertResponse is used to return from an
+// ACLBindingRuleUpsertRequest
+type ACLBindingRuleUpsertResponse struct {
+	BindingRules []*ACLBindingRule
+	WriteMeta
+}
+
+// ACLLoginRequest is used to exchange the JWT of an identity for an ACL
+// token with a JWT auth method
+type ACLLoginRequest struct {
+	AuthMethodName string
+	LoginToken     string
+	WriteRequest
+}
+
+// ACLLoginResponse returns the ACL token minted by a login
+type ACLLoginResponse struct {
+	Token *ACLToken
+	WriteMeta
+}
+
+// ACLOIDCAuthURLRequest is used to start the login of a user with an OIDC
+// auth method. The state and nonce are generated by the client and must be
+// presented again to complete the login.
+type ACLOIDCAuthURLRequest struct {
+	AuthMethodName string
+	RedirectURI    string
+	State          string
+	Nonce          string
+	QueryOptions
+}
+
+// ACLOIDCAuthURLResponse returns the URL of the OIDC provider the user
+// authenticates at
+type ACLOIDCAuthURLResponse struct {
+	AuthURL string
+	QueryMeta
+}
+
+// ACLOIDCCompleteAuthRequest is used to exchange the authorization code the
+// OIDC provider redirected the user with for an ACL token
+type ACLOIDCCompleteAuthRequest struct {
+	AuthMethodName string
+	RedirectURI    string
+	Code           string
+	Nonce          string
+	WriteRequest
+}
@@ -199,6 +199,24 @@ func TestJob_SpecChanged(t *testing.T) {
 	change := base.Copy()
 	change.Priority = 99
 
+	// Scaling policies differing only by their assigned identity
+	scaling := base.Copy()
+	scaling.TaskGroups[0].Scaling = &ScalingPolicy{
+		ID:          "foo",
+		Target:      map[string]string{ScalingTargetGroup: scaling.TaskGroups[0].Name},
+		Min:         1,
+		Max:         10,
+		Enabled:     true,
+		CreateIndex: 10,
+		ModifyIndex: 20,
+	}
+	scalingNewID := scaling.Copy()
+	scalingNewID.TaskGroups[0].Scaling.ID = "bar"
+	scalingNewID.TaskGroups[0].Scaling.Target = nil
+	scalingNewID.TaskGroups[0].Scaling.ModifyIndex = 0
+	scalingChange := scalingNewID.Copy()
+	scalingChange.TaskGroups[0].Scaling.Max = 20
+
 	cases := []struct {
 		Name     string
 		Original *Job
@@ -217,6 +235,18 @@ func TestJob_SpecChanged(t *testing.T) {
 			Original: base,
 			New:      change,
 		},
+		{
+			Name:     "Same scaling policy with new ID",
+			Changed:  false,
+			Original: scaling,
+			New:      scalingNewID,
+		},
+		{
+			Name:     "Different scaling policy",
+			Changed:  true,
+			Original: scaling,
+			New:      scalingChange,
+		},
 	}
 
 	for _, c := range cases {
@@ -750,6 +780,26 @@ func TestTaskGroup_Validate(t *testing.T) {
 		t.Errorf("expected %s but found: %v", expected, err)
 	}
 
+	negative := -1 * time.Second
+	tg = &TaskGroup{
+		MaxClientDisconnect: &negative,
+	}
+	err = tg.Validate(&Job{})
+	expected = `max_client_disconnect cannot be negative`
+	if !strings.Contains(err.Error(), expected) {
+		t.Errorf("expected %s but found: %v", expected, err)
+	}
+
+	hour := time.Hour
+	tg = &TaskGroup{
+		MaxClientDisconnect: &hour,
+	}
+	err = tg.Validate(&Job{Type: JobTypeSystem})
+	expected = `System jobs may not set max_client_disconnect`
+	if !strings.Contains(err.Error(), expected) {
+		t.Errorf("expected %s but found: %v", expected, err)
+	}
+
 	tg = &TaskGroup{
 		Tasks: []*Task{
 			{
@@ -963,6 +1013,98 @@ func TestTaskGroup_Validate(t *testing.T) {
 	expected = `Check check-a invalid: only script and gRPC checks should have tasks`
 	require.Contains(t, err.Error(), expected)
 
+	tg = &TaskGroup{
+		Tasks: []*Task{
+			{
+				Name: "task-a",
+				Lifecycle: &TaskLifecycleConfig{
+					Hook: TaskLifecycleHookPrestart,
+				},
+			},
+		},
+	}
+	err = tg.Validate(&Job{})
+	expected = `Task group must have at least one task without a lifecycle stanza`
+	require.Contains(t, err.Error(), expected)
+}
+
+func TestTaskGroup_Validate_ScalingPolicy(t *testing.T) {
+	cases := []struct {
+		name    string
+		job     func(*Job)
+		count   int
+		policy  *ScalingPolicy
+		wantErr string
+	}{
+		{
+			name:   "valid",
+			count:  5,
+			policy: &ScalingPolicy{Min: 1, Max: 10},
+		},
+		{
+			name:    "negative min",
+			count:   0,
+			policy:  &ScalingPolicy{Min: -1, Max: 10},
+			wantErr: "minimum count must not be negative",
+		},
+		{
+			name:    "max less than min",
+			count:   5,
+			policy:  &ScalingPolicy{Min: 5, Max: 4},
+			wantErr: "maximum count must not be less than minimum count",
+		},
+		{
+			name:    "count below min",
+			count:   1,
+			policy:  &ScalingPolicy{Min: 2, Max: 10},
+			wantErr: "count must not be less than minimum count",
+		},
+		{
+			name:    "count above max",
+			count:   11,
+			policy:  &ScalingPolicy{Min: 2, Max: 10},
+			wantErr: "count must not be greater than maximum count",
+		},
+		{
+			name:    "system job",
+			job:     func(j *Job) { j.Type = JobTypeSystem },
+			count:   1,
+			policy:  &ScalingPolicy{Min: 1, Max: 1},
+			wantErr: "System jobs may not have a scaling policy",
+		},
+		{
+			name: "periodic job",
+			job: func(j *Job) {
+				j.Periodic = &PeriodicConfig{Enabled: true}
+			},
+			count:   1,
+			policy:  &ScalingPolicy{Min: 1, Max: 1},
+			wantErr: "Periodic and parameterized jobs may not have a scaling policy",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			j := testJob()
+			j.Periodic = nil
+			if c.job != nil {
+				c.job(j)
+			}
+			tg := j.TaskGroups[0]
+			tg.Count = c.count
+			tg.Scaling = c.policy
+
+			err := tg.Validate(j)
+			if c.wantErr == "" {
+				if err != nil {
+					require.NotContains(t, err.Error(), "caling policy")
+				}
+				return
+			}
+			require.Error(t, err)
+			require.Contains(t, err.Error(), c.wantErr)
+		})
+	}
 }
 
 func TestTask_Validate(t *testing.T) {
@@ -1665,6 +1807,77 @@ func TestTask_Validate_LogConfig(t *testing.T) {
 	}
 }
 
+func TestTask_Validate_Lifecycle(t *testing.T) {
+	cases := []struct {
+		name      string
+		lifecycle *TaskLifecycleConfig
+		leader    bool
+		err       string
+	}{
+		{
+			name: "prestart sidecar",
+			lifecycle: &TaskLifecycleConfig{
+				Hook:    TaskLifecycleHookPrestart,
+				Sidecar: true,
+			},
+		},
+		{
+			name: "poststart",
+			lifecycle: &TaskLifecycleConfig{
+				Hook: TaskLifecycleHookPoststart,
+			},
+		},
+		{
+			name:      "missing hook",
+			lifecycle: &TaskLifecycleConfig{},
+			err:       "no lifecycle hook provided",
+		},
+		{
+			name: "invalid hook",
+			lifecycle: &TaskLifecycleConfig{
+				Hook: "foo",
+			},
+			err: "invalid hook: foo",
+		},
+		{
+			name: "poststop sidecar",
+			lifecycle: &TaskLifecycleConfig{
+				Hook:    TaskLifecycleHookPoststop,
+				Sidecar: true,
+			},
+			err: "poststop tasks can not be sidecars",
+		},
+		{
+			name: "poststop leader",
+			lifecycle: &TaskLifecycleConfig{
+				Hook: TaskLifecycleHookPoststop,
+			},
+			leader: true,
+			err:    "Poststop task must not have leader set",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			task := &Task{
+				Name:      "foo",
+				Driver:    "docker",
+				Resources: DefaultResources(),
+				LogConfig: DefaultLogConfig(),
+				Leader:    tc.leader,
+				Lifecycle: tc.lifecycle,
+			}
+			err := task.Validate(&EphemeralDisk{SizeMB: 300}, JobTypeService, nil)
+			if tc.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.err)
+			}
+		})
+	}
+}
+
 func TestTask_Validate_Template(t *testing.T) {
 
 	bad := &Template{}
@@ -1983,6 +2196,41 @@ func TestAffinity_Validate(t *testing.T) {
 			},
 			err: fmt.Errorf("Regular expression failed to compile"),
 		},
+		{
+			affinity: &Affinity{
+				Operand: "=",
+				LTarget: "${job.name}",
+				RTarget: "cache",
+				Weight:  50,
+			},
+			err: fmt.Errorf("Unsupported job affinity target \"${job.name}\""),
+		},
+		{
+			affinity: &Affinity{
+				Operand: "=",
+				LTarget: "${job.meta.}",
+				RTarget: "cache",
+				Weight:  50,
+			},
+			err: fmt.Errorf("Unsupported job affinity target \"${job.meta.}\""),
+		},
+		{
+			affinity: &Affinity{
+				Operand: "=",
+				LTarget: "${job.id}",
+				RTarget: "${node.class}",
+				Weight:  50,
+			},
+			err: fmt.Errorf("Job affinity RTarget must be a literal value"),
+		},
+		{
+			affinity: &Affinity{
+				Operand: "=",
+				LTarget: "${job.meta.tier}",
+				RTarget: "cache",
+				Weight:  -50,
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -2082,6 +2330,50 @@ func TestResource_Superset(t *testing.T) {
 	}
 }
 
+func TestResource_Validate_MemoryMaxMB(t *testing.T) {
+	r := DefaultResources()
+	r.MemoryMaxMB = r.MemoryMB - 1
+	err := r.Validate()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "MemoryMaxMB value")
+
+	r.MemoryMaxMB = r.MemoryMB * 2
+	require.NoError(t, r.Validate())
+}
+
+func TestResource_Validate_Cores(t *testing.T) {
+	r := DefaultResources()
+	r.Cores = 2
+	err := r.Validate()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Task can only ask for 'cpu' or 'cores' resource, not both.")
+
+	r.CPU = 0
+	require.NoError(t, r.Validate())
+	require.NoError(t, r.MeetsMinResources())
+}
+
+func TestAllocatedCpuResources_Add(t *testing.T) {
+	c := &AllocatedCpuResources{}
+	c.Add(&AllocatedCpuResources{CpuShares: 2000, ReservedCores: []uint16{2, 0}})
+	c.Add(&AllocatedCpuResources{CpuShares: 500})
+	c.Add(&AllocatedCpuResources{CpuShares: 1000, ReservedCores: []uint16{1}})
+	require.Equal(t, &AllocatedCpuResources{CpuShares: 3500, ReservedCores: []uint16{0, 1, 2}}, c)
+
+	c.Subtract(&AllocatedCpuResources{CpuShares: 2000, ReservedCores: []uint16{2, 0}})
+	require.Equal(t, &AllocatedCpuResources{CpuShares: 1500, ReservedCores: []uint16{1}}, c)
+}
+
+func TestAllocatedMemoryResources_Add(t *testing.T) {
+	m := &AllocatedMemoryResources{}
+	m.Add(&AllocatedMemoryResources{MemoryMB: 256, MemoryMaxMB: 1024})
+	m.Add(&AllocatedMemoryResources{MemoryMB: 128, MemoryMaxMB: 512})
+	require.Equal(t, &AllocatedMemoryResources{MemoryMB: 384, MemoryMaxMB: 1536}, m)
+
+	m.Subtract(&AllocatedMemoryResources{MemoryMB: 128, MemoryMaxMB: 512})
+	require.Equal(t, &AllocatedMemoryResources{MemoryMB: 256, MemoryMaxMB: 1024}, m)
+}
+
 func TestResource_Add(t *testing.T) {
 	r1 := &Resources{
 		CPU:      2000,
@@ -3410,6 +3702,22 @@ func TestAllocation_Terminated(t *testing.T) {
 	}
 }
 
+func TestAllocation_NeedsToReconnect(t *testing.T) {
+	alloc := MockAlloc()
+	require.False(t, alloc.NeedsToReconnect())
+
+	// Marked as unknown when its client disconnected
+	alloc.AppendState(AllocStateFieldClientStatus, AllocClientStatusUnknown)
+	require.True(t, alloc.NeedsToReconnect())
+
+	// The copy keeps its own record
+	copied := alloc.Copy()
+	copied.AppendState(AllocStateFieldClientStatus, AllocClientStatusRunning)
+	require.False(t, copied.NeedsToReconnect())
+	require.True(t, alloc.NeedsToReconnect())
+	require.Len(t, alloc.AllocStates, 1)
+}
+
 func TestAllocation_ShouldReschedule(t *testing.T) {
 	type testCase struct {
 		Desc               string
@@ -4316,6 +4624,175 @@ func TestACLTokenSetHash(t *testing.T) {
 	assert.NotEqual(t, out1, out2)
 }
 
+func TestACLTokenValidate_Roles(t *testing.T) {
+	require := require.New(t)
+
+	// Client tokens may be linked to roles only
+	tk := &ACLToken{
+		Type:  ACLClientToken,
+		Roles: []*ACLTokenRoleLink{{Name: "foo"}},
+	}
+	require.NoError(tk.Validate())
+
+	// Management tokens cannot be linked to roles
+	tk.Type = ACLManagementToken
+	err := tk.Validate()
+	require.Error(err)
+	require.Contains(err.Error(), "associated with roles")
+}
+
+func TestACLTokenRoleSubset(t *testing.T) {
+	tk := &ACLToken{
+		Type:  ACLClientToken,
+		Roles: []*ACLTokenRoleLink{{ID: "foo"}, {ID: "bar"}},
+	}
+
+	require.True(t, tk.RoleSubset([]string{"foo", "bar"}))
+	require.True(t, tk.RoleSubset([]string{"foo"}))
+	require.True(t, tk.RoleSubset([]string{}))
+	require.False(t, tk.RoleSubset([]string{"foo", "new"}))
+
+	tk = &ACLToken{
+		Type: ACLManagementToken,
+	}
+	require.True(t, tk.RoleSubset([]string{"foo", "new"}))
+}
+
+func TestACLRoleValidate(t *testing.T) {
+	require := require.New(t)
+	role := &ACLRole{}
+
+	err := role.Validate()
+	require.Error(err)
+	require.Contains(err.Error(), "invalid name")
+	require.Contains(err.Error(), "missing policies")
+
+	role.Name = "my-role"
+	role.Policies = []*ACLRolePolicyLink{{Name: ""}}
+	err = role.Validate()
+	require.Error(err)
+	require.Contains(err.Error(), "missing name")
+
+	role.Description = strings.Repeat("a", maxRoleDescriptionLength+1)
+	role.Policies = []*ACLRolePolicyLink{{Name: "foo"}}
+	err = role.Validate()
+	require.Error(err)
+	require.Contains(err.Error(), "description longer")
+
+	role.Description = "my role"
+	require.NoError(role.Validate())
+}
+
+func TestACLRoleSetHash(t *testing.T) {
+	role := &ACLRole{
+		Name:     "foo",
+		Policies: []*ACLRolePolicyLink{{Name: "foo"}, {Name: "bar"}},
+	}
+	out1 := role.SetHash()
+	require.NotNil(t, out1)
+	require.Equal(t, out1, role.Hash)
+
+	role.Policies = []*ACLRolePolicyLink{{Name: "foo"}}
+	out2 := role.SetHash()
+	require.Equal(t, out2, role.Hash)
+	require.NotEqual(t, out1, out2)
+}
+
+func TestACLAuthMethodValidate(t *testing.T) {
+	require := require.New(t)
+	method := &ACLAuthMethod{}
+
+	err := method.Validate()
+	require.Error(err)
+	require.Contains(err.Error(), "invalid name")
+	require.Contains(err.Error(), "invalid type")
+	require.Contains(err.Error(), "invalid token locality")
+	require.Contains(err.Error(), "max token TTL")
+	require.Contains(err.Error(), "missing config")
+
+	method = &ACLAuthMethod{
+		Name:          "sso",
+		Type:          ACLAuthMethodTypeOIDC,
+		TokenLocality: ACLAuthMethodTokenLocalityLocal,
+		MaxTokenTTL:   time.Hour,
+		Config: &ACLAuthMethodConfig{
+			SigningAlgs: []string{"HS256"},
+		},
+	}
+	err = method.Validate()
+	require.Error(err)
+	require.Contains(err.Error(), "discovery URL")
+	require.Contains(err.Error(), "client ID")
+	require.Contains(err.Error(), "redirect URIs")
+	require.Contains(err.Error(), "signing algorithm")
+
+	method.Config = &ACLAuthMethodConfig{
+		OIDCDiscoveryURL:    "https://idp.example.com",
+		OIDCClientID:        "nomad",
+		OIDCClientSecret:    "secret",
+		AllowedRedirectURIs: []string{"http://localhost:4649/oidc/callback"},
+		SigningAlgs:         []string{"RS256", "ES256"},
+	}
+	require.NoError(method.Validate())
+
+	method.Type = ACLAuthMethodTypeJWT
+	method.Config = &ACLAuthMethodConfig{}
+	err = method.Validate()
+	require.Error(err)
+	require.Contains(err.Error(), "JWKS URL")
+
+	method.Config.JWKSURL = "https://idp.example.com/jwks"
+	require.NoError(method.Validate())
+}
+
+func TestACLAuthMethodSetHash(t *testing.T) {
+	method := &ACLAuthMethod{
+		Name: "sso",
+		Type: ACLAuthMethodTypeJWT,
+		Config: &ACLAuthMethodConfig{
+			JWKSURL:       "https://idp.example.com/jwks",
+			ClaimMappings: map[string]string{"sub": "user"},
+		},
+	}
+	out1 := method.SetHash()
+	require.NotNil(t, out1)
+	require.Equal(t, out1, method.Hash)
+
+	method.Config.ClaimMappings["team"] = "team"
+	out2 := method.SetHash()
+	require.Equal(t, out2, method.Hash)
+	require.NotEqual(t, out1, out2)
+}
+
+func TestACLBindingRuleValidate(t *testing.T) {
+	require := require.New(t)
+	rule := &ACLBindingRule{Selector: "value.team =="}
+
+	err := rule.Validate()
+	require.Error(err)
+	require.Contains(err.Error(), "missing auth method")
+	require.Contains(err.Error(), "invalid bind type")
+	require.Contains(err.Error(), "missing bind name")
+	require.Contains(err.Error(), "invalid selector")
+
+	rule = &ACLBindingRule{
+		Description: strings.Repeat("a", maxBindingRuleDescriptionLength+1),
+		AuthMethod:  "sso",
+		Selector:    `"engineering" in list.groups`,
+		BindType:    ACLBindingRuleBindTypeRole,
+		BindName:    "engineering",
+	}
+	err = rule.Validate()
+	require.Error(err)
+	require.Contains(err.Error(), "description longer")
+
+	rule.Description = "engineers"
+	require.NoError(rule.Validate())
+
+	rule.Selector = ""
+	require.NoError(rule.Validate())
+}
+
 func TestACLPolicySetHash(t *testing.T) {
 	ap := &ACLPolicy{
 		Name:        "foo",
@@ -4771,6 +5248,71 @@ func TestSpread_Validate(t *testing.T) {
 			err:  nil,
 			name: "Valid spread",
 		},
+		{
+			spread: &Spread{
+				Attribute: "${node.datacenter}",
+				Weight:    50,
+				Nested: &Spread{
+					SpreadTarget: []*SpreadTarget{
+						{
+							Value:   "r1",
+							Percent: 50,
+						},
+					},
+				},
+			},
+			err:  fmt.Errorf("Missing spread attribute"),
+			name: "Nested spread missing attribute",
+		},
+		{
+			spread: &Spread{
+				Attribute: "${node.datacenter}",
+				Weight:    50,
+				Nested: &Spread{
+					Attribute: "${meta.rack}",
+					SpreadTarget: []*SpreadTarget{
+						{
+							Value:   "r1",
+							Percent: 75,
+						},
+						{
+							Value:   "r2",
+							Percent: 75,
+						},
+					},
+				},
+			},
+			err:  fmt.Errorf("Nested spread \"${meta.rack}\": Sum of spread target percentages must not be greater than 100%%; got %d%%", 150),
+			name: "Nested spread invalid percentages",
+		},
+		{
+			spread: &Spread{
+				Attribute: "${node.datacenter}",
+				Weight:    50,
+				Nested: &Spread{
+					Attribute: "${node.datacenter}",
+				},
+			},
+			err:  fmt.Errorf("Spread attribute \"${node.datacenter}\" used at multiple levels"),
+			name: "Nested spread duplicate attribute",
+		},
+		{
+			spread: &Spread{
+				Attribute: "${node.datacenter}",
+				Weight:    50,
+				Nested: &Spread{
+					Attribute: "${meta.rack}",
+					SpreadTarget: []*SpreadTarget{
+						{
+							Value:   "r1",
+							Percent: 50,
+						},
+					},
+				},
+			},
+			err:  nil,
+			name: "Valid nested spread",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -4786,6 +5328,93 @@ func TestSpread_Validate(t *testing.T) {
 	}
 }
 
+func TestJob_Validate_SchedulerAlgorithm(t *testing.T) {
+	job := testJob()
+	require.NoError(t, job.Validate())
+
+	job.SchedulerAlgorithm = SchedulerAlgorithmSpread
+	require.NoError(t, job.Validate())
+
+	job.SchedulerAlgorithm = "random"
+	err := job.Validate()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `Invalid scheduler algorithm: "random"`)
+}
+
+func TestJob_Validate_DatacenterPreferences(t *testing.T) {
+	type tc struct {
+		prefs []*DatacenterPreference
+		err   string
+		name  string
+	}
+
+	testCases := []tc{
+		{
+			prefs: []*DatacenterPreference{
+				{Datacenter: "dc1", Weight: 100},
+				{Datacenter: "dc2", Weight: 50},
+			},
+			name: "valid preferences",
+		},
+		{
+			prefs: []*DatacenterPreference{{Weight: 100}},
+			err:   "Missing datacenter",
+			name:  "missing datacenter",
+		},
+		{
+			prefs: []*DatacenterPreference{{Datacenter: "dc1"}},
+			err:   "Datacenter preference must have a positive weight from 1 to 100",
+			name:  "missing weight",
+		},
+		{
+			prefs: []*DatacenterPreference{{Datacenter: "dc1", Weight: 101}},
+			err:   "Datacenter preference must have a positive weight from 1 to 100",
+			name:  "invalid weight",
+		},
+		{
+			prefs: []*DatacenterPreference{
+				{Datacenter: "dc1", Weight: 100},
+				{Datacenter: "dc1", Weight: 50},
+			},
+			err:  `Datacenter preference for "dc1" already defined`,
+			name: "duplicate datacenter",
+		},
+		{
+			prefs: []*DatacenterPreference{{Datacenter: "dc3", Weight: 100}},
+			err:   `Datacenter preference for "dc3" is not one of the job's datacenters`,
+			name:  "unknown datacenter",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Validate the preferences at the job and task group level
+			job := testJob()
+			job.Datacenters = []string{"dc1", "dc2"}
+			job.DatacenterPreferences = tc.prefs
+			tg := job.TaskGroups[0]
+			tg.DatacenterPreferences = tc.prefs
+
+			for _, err := range []error{job.Validate(), tg.Validate(job)} {
+				if tc.err != "" {
+					require.Error(t, err)
+					require.Contains(t, err.Error(), tc.err)
+				} else {
+					require.NoError(t, err)
+				}
+			}
+		})
+	}
+
+	// System jobs may not have datacenter preferences
+	job := testJob()
+	job.Type = JobTypeSystem
+	job.DatacenterPreferences = []*DatacenterPreference{{Datacenter: "dc1", Weight: 100}}
+	err := job.Validate()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "System jobs may not have a datacenter_preference stanza")
+}
+
 func TestNodeReservedNetworkResources_ParseReserved(t *testing.T) {
 	require := require.New(t)
 	cases := []struct {
@@ -0,0 +1,171 @@
+package structs
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/nomad/helper/uuid"
+)
+
+const (
+	// workloadIdentityAlgorithm is the JWT signing algorithm of workload
+	// identities
+	workloadIdentityAlgorithm = "HS256"
+
+	// workloadIdentityKeySize is the size in bytes of the workload identity
+	// signing key
+	workloadIdentityKeySize = 32
+)
+
+var (
+	// ErrInvalidWorkloadIdentity is returned when a workload identity is
+	// malformed or its signature doesn't match the signing key
+	ErrInvalidWorkloadIdentity = errors.New("invalid workload identity")
+)
+
+// WorkloadIdentityKey is the key the servers sign the workload identities of
+// tasks with. It never leaves the servers.
+type WorkloadIdentityKey struct {
+	// KeyID identifies the key in the header of the tokens it signed
+	KeyID string
+
+	// Key is the secret HMAC key
+	Key []byte
+
+	// CreateTime is the time the key was generated
+	CreateTime int64
+
+	CreateIndex uint64
+	ModifyIndex uint64
+}
+
+// NewWorkloadIdentityKey generates a new random workload identity key.
+func NewWorkloadIdentityKey() (*WorkloadIdentityKey, error) {
+	key := make([]byte, workloadIdentityKeySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, fmt.Errorf("failed to generate workload identity key: %v", err)
+	}
+	return &WorkloadIdentityKey{
+		KeyID:      uuid.Generate(),
+		Key:        key,
+		CreateTime: time.Now().UTC().UnixNano(),
+	}, nil
+}
+
+// WorkloadIdentityKeyRequest is used to set the workload identity key
+type WorkloadIdentityKeyRequest struct {
+	Key *WorkloadIdentityKey
+	WriteRequest
+}
+
+// WorkloadIdentityClaims are the claims of the workload identity of a task.
+// They are encoded as the payload of a JWT.
+type WorkloadIdentityClaims struct {
+	Namespace    string `json:"nomad_namespace"`
+	JobID        string `json:"nomad_job_id"`
+	TaskGroup    string `json:"nomad_task_group"`
+	Task         string `json:"nomad_task"`
+	AllocationID string `json:"nomad_allocation_id"`
+	IssuedAt     int64  `json:"iat"`
+}
+
+// NewWorkloadIdentityClaims returns the workload identity claims of the given
+// task of an allocation.
+func NewWorkloadIdentityClaims(alloc *Allocation, task string, now time.Time) *WorkloadIdentityClaims {
+	return &WorkloadIdentityClaims{
+		Namespace:    alloc.Namespace,
+		JobID:        alloc.JobID,
+		TaskGroup:    alloc.TaskGroup,
+		Task:         task,
+		AllocationID: alloc.ID,
+		IssuedAt:     now.Unix(),
+	}
+}
+
+// workloadIdentityHeader is the JOSE header of a workload identity
+type workloadIdentityHeader struct {
+	Algorithm string `json:"alg"`
+	Type      string `json:"typ"`
+	KeyID     string `json:"kid"`
+}
+
+// SignWorkloadIdentity encodes the claims as a JWT signed with the key.
+func SignWorkloadIdentity(key *WorkloadIdentityKey, claims *WorkloadIdentityClaims) (string, error) {
+	header, err := json.Marshal(&workloadIdentityHeader{
+		Algorithm: workloadIdentityAlgorithm,
+		Type:      "JWT",
+		KeyID:     key.KeyID,
+	})
+	if err != nil {
+		return "", err
+	}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." +
+		base64.RawURLEncoding.EncodeToString(payload)
+	return signed + "." + workloadIdentitySignature(key, signed), nil
+}
+
+// VerifyWorkloadIdentity checks that the token was signed with the key and
+// returns its claims.
+func VerifyWorkloadIdentity(key *WorkloadIdentityKey, token string) (*WorkloadIdentityClaims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, ErrInvalidWorkloadIdentity
+	}
+
+	var header workloadIdentityHeader
+	if err := decodeWorkloadIdentityPart(parts[0], &header); err != nil {
+		return nil, err
+	}
+	if header.Algorithm != workloadIdentityAlgorithm || header.KeyID != key.KeyID {
+		return nil, ErrInvalidWorkloadIdentity
+	}
+
+	expected := workloadIdentitySignature(key, parts[0]+"."+parts[1])
+	if !hmac.Equal([]byte(expected), []byte(parts[2])) {
+		return nil, ErrInvalidWorkloadIdentity
+	}
+
+	var claims WorkloadIdentityClaims
+	if err := decodeWorkloadIdentityPart(parts[1], &claims); err != nil {
+		return nil, err
+	}
+	return &claims, nil
+}
+
+// IsWorkloadIdentity returns whether the secret presented with a request
+// looks like a workload identity rather than the secret ID of an ACL token.
+func IsWorkloadIdentity(secretID string) bool {
+	return strings.Count(secretID, ".") == 2
+}
+
+// workloadIdentitySignature returns the encoded HMAC signature of the signed
+// part of a token
+func workloadIdentitySignature(key *WorkloadIdentityKey, signed string) string {
+	mac := hmac.New(sha256.New, key.Key)
+	mac.Write([]byte(signed))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// decodeWorkloadIdentityPart decodes a base64 encoded JSON part of a token
+func decodeWorkloadIdentityPart(part string, out interface{}) error {
+	raw, err := base64.RawURLEncoding.DecodeString(part)
+	if err != nil {
+		return ErrInvalidWorkloadIdentity
+	}
+	if err := json.Unmarshal(raw, out); err != nil {
+		return ErrInvalidWorkloadIdentity
+	}
+	return nil
+}
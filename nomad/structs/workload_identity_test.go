@@ -0,0 +1,59 @@
+package structs
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkloadIdentity_SignVerify(t *testing.T) {
+	require := require.New(t)
+
+	key, err := NewWorkloadIdentityKey()
+	require.NoError(err)
+
+	alloc := &Allocation{
+		ID:        uuid.Generate(),
+		Namespace: DefaultNamespace,
+		JobID:     "example",
+		TaskGroup: "web",
+	}
+	claims := NewWorkloadIdentityClaims(alloc, "server", time.Now())
+
+	token, err := SignWorkloadIdentity(key, claims)
+	require.NoError(err)
+	require.True(IsWorkloadIdentity(token))
+	require.False(IsWorkloadIdentity(uuid.Generate()))
+
+	out, err := VerifyWorkloadIdentity(key, token)
+	require.NoError(err)
+	require.Equal(claims, out)
+	require.Equal("server", out.Task)
+	require.Equal(alloc.ID, out.AllocationID)
+
+	// A tampered payload is rejected
+	parts := strings.Split(token, ".")
+	tampered, err := SignWorkloadIdentity(key, NewWorkloadIdentityClaims(alloc, "other", time.Now()))
+	require.NoError(err)
+	forged := parts[0] + "." + strings.Split(tampered, ".")[1] + "." + parts[2]
+	_, err = VerifyWorkloadIdentity(key, forged)
+	require.Equal(ErrInvalidWorkloadIdentity, err)
+
+	// A token signed with another key is rejected
+	other, err := NewWorkloadIdentityKey()
+	require.NoError(err)
+	_, err = VerifyWorkloadIdentity(other, token)
+	require.Equal(ErrInvalidWorkloadIdentity, err)
+	other.KeyID = key.KeyID
+	_, err = VerifyWorkloadIdentity(other, token)
+	require.Equal(ErrInvalidWorkloadIdentity, err)
+
+	// Malformed tokens are rejected
+	_, err = VerifyWorkloadIdentity(key, "foo.bar")
+	require.Equal(ErrInvalidWorkloadIdentity, err)
+	_, err = VerifyWorkloadIdentity(key, "!.!.!")
+	require.Equal(ErrInvalidWorkloadIdentity, err)
+}
@@ -2198,6 +2198,7 @@ func (m *AllocatedCpuResources) GetCpuShares() int64 {
 
 type AllocatedMemoryResources struct {
 	MemoryMb             int64    `protobuf:"varint,2,opt,name=memory_mb,json=memoryMb,proto3" json:"memory_mb,omitempty"`
+	MemoryMaxMb          int64    `protobuf:"varint,3,opt,name=memory_max_mb,json=memoryMaxMb,proto3" json:"memory_max_mb,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -2234,6 +2235,13 @@ func (m *AllocatedMemoryResources) GetMemoryMb() int64 {
 	return 0
 }
 
+func (m *AllocatedMemoryResources) GetMemoryMaxMb() int64 {
+	if m != nil {
+		return m.MemoryMaxMb
+	}
+	return 0
+}
+
 type NetworkResource struct {
 	Device               string         `protobuf:"bytes,1,opt,name=device,proto3" json:"device,omitempty"`
 	Cidr                 string         `protobuf:"bytes,2,opt,name=cidr,proto3" json:"cidr,omitempty"`
@@ -4102,226 +4110,226 @@ func init() {
 }
 
 var fileDescriptor_driver_8edefdede9e0ed2d = []byte{
-	// 3525 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x5a, 0x4f, 0x6f, 0x23, 0x47,
-	0x76, 0x57, 0xf3, 0x9f, 0xc8, 0x47, 0x89, 0x6a, 0x95, 0xa4, 0x31, 0x87, 0x4e, 0xe2, 0xd9, 0x06,
-	0x36, 0x10, 0x76, 0x6d, 0xca, 0xd6, 0x22, 0x1e, 0xcb, 0x3b, 0xde, 0x31, 0x4d, 0x71, 0x24, 0x79,
-	0x24, 0x4a, 0x29, 0x52, 0x98, 0x9d, 0x38, 0xeb, 0x4e, 0xab, 0xbb, 0x86, 0xec, 0x11, 0xfb, 0x8f,
-	0xbb, 0x8b, 0x1a, 0x69, 0x83, 0x20, 0xc1, 0x06, 0x08, 0x36, 0x40, 0x82, 0xe4, 0xe2, 0xec, 0x25,
-	0x87, 0x60, 0x73, 0x4c, 0x3e, 0x40, 0x90, 0x60, 0xcf, 0xf9, 0x10, 0xc9, 0x25, 0xb7, 0x5c, 0x72,
-	0xc8, 0x37, 0x58, 0xd4, 0x9f, 0x6e, 0x76, 0x8b, 0x1c, 0x4f, 0x93, 0x9a, 0x53, 0x77, 0xbd, 0xaa,
-	0xfa, 0xd5, 0xab, 0xf7, 0x5e, 0xd5, 0x7b, 0x55, 0xf5, 0x40, 0xf3, 0x47, 0xe3, 0x81, 0xed, 0x86,
-	0x3b, 0x56, 0x60, 0x5f, 0x91, 0x20, 0xdc, 0xf1, 0x03, 0x8f, 0x7a, 0xb2, 0xd4, 0xe4, 0x05, 0xf4,
-	0xfd, 0xa1, 0x11, 0x0e, 0x6d, 0xd3, 0x0b, 0xfc, 0xa6, 0xeb, 0x39, 0x86, 0xd5, 0x94, 0x7d, 0x9a,
-	0xb2, 0x8f, 0x68, 0xd6, 0xf8, 0xbd, 0x81, 0xe7, 0x0d, 0x46, 0x44, 0x20, 0x5c, 0x8c, 0x5f, 0xec,
-	0x58, 0xe3, 0xc0, 0xa0, 0xb6, 0xe7, 0xca, 0xfa, 0xf7, 0x6e, 0xd7, 0x53, 0xdb, 0x21, 0x21, 0x35,
-	0x1c, 0x5f, 0x36, 0xf8, 0x7c, 0x60, 0xd3, 0xe1, 0xf8, 0xa2, 0x69, 0x7a, 0xce, 0x4e, 0x3c, 0xe4,
-	0x0e, 0x1f, 0x72, 0x27, 0x62, 0x33, 0x1c, 0x1a, 0x01, 0xb1, 0x76, 0x86, 0xe6, 0x28, 0xf4, 0x89,
-	0xc9, 0xbe, 0x3a, 0xfb, 0x91, 0x08, 0x07, 0xd9, 0x11, 0x42, 0x1a, 0x8c, 0x4d, 0x1a, 0xcd, 0xd7,
-	0xa0, 0x34, 0xb0, 0x2f, 0xc6, 0x94, 0x08, 0x20, 0xed, 0x3e, 0xbc, 0xd3, 0x37, 0xc2, 0xcb, 0xb6,
-	0xe7, 0xbe, 0xb0, 0x07, 0x3d, 0x73, 0x48, 0x1c, 0x03, 0x93, 0x6f, 0xc6, 0x24, 0xa4, 0xda, 0x1f,
-	0x43, 0x7d, 0xba, 0x2a, 0xf4, 0x3d, 0x37, 0x24, 0xe8, 0x73, 0x28, 0x30, 0x6e, 0xea, 0xca, 0x03,
-	0x65, 0xbb, 0xba, 0xfb, 0x7e, 0xf3, 0x75, 0x82, 0x13, 0x3c, 0x34, 0xe5, 0x2c, 0x9a, 0x3d, 0x9f,
-	0x98, 0x98, 0xf7, 0xd4, 0xb6, 0x60, 0xa3, 0x6d, 0xf8, 0xc6, 0x85, 0x3d, 0xb2, 0xa9, 0x4d, 0xc2,
-	0x68, 0xd0, 0x31, 0x6c, 0xa6, 0xc9, 0x72, 0xc0, 0x9f, 0xc1, 0x8a, 0x99, 0xa0, 0xcb, 0x81, 0xf7,
-	0x9a, 0x99, 0x34, 0xd6, 0xdc, 0xe7, 0xa5, 0x14, 0x70, 0x0a, 0x4e, 0xdb, 0x04, 0xf4, 0xc4, 0x76,
-	0x07, 0x24, 0xf0, 0x03, 0xdb, 0xa5, 0x11, 0x33, 0xbf, 0xc9, 0xc3, 0x46, 0x8a, 0x2c, 0x99, 0x79,
-	0x09, 0x10, 0xcb, 0x91, 0xb1, 0x92, 0xdf, 0xae, 0xee, 0x7e, 0x99, 0x91, 0x95, 0x19, 0x78, 0xcd,
-	0x56, 0x0c, 0xd6, 0x71, 0x69, 0x70, 0x83, 0x13, 0xe8, 0xe8, 0x6b, 0x28, 0x0d, 0x89, 0x31, 0xa2,
-	0xc3, 0x7a, 0xee, 0x81, 0xb2, 0x5d, 0xdb, 0x7d, 0x72, 0x87, 0x71, 0x0e, 0x39, 0x50, 0x8f, 0x1a,
-	0x94, 0x60, 0x89, 0x8a, 0x3e, 0x00, 0x24, 0xfe, 0x74, 0x8b, 0x84, 0x66, 0x60, 0xfb, 0xcc, 0x90,
-	0xeb, 0xf9, 0x07, 0xca, 0x76, 0x05, 0xaf, 0x8b, 0x9a, 0xfd, 0x49, 0x45, 0xc3, 0x87, 0xb5, 0x5b,
-	0xdc, 0x22, 0x15, 0xf2, 0x97, 0xe4, 0x86, 0x6b, 0xa4, 0x82, 0xd9, 0x2f, 0x3a, 0x80, 0xe2, 0x95,
-	0x31, 0x1a, 0x13, 0xce, 0x72, 0x75, 0xf7, 0xa3, 0x37, 0x99, 0x87, 0x34, 0xd1, 0x89, 0x1c, 0xb0,
-	0xe8, 0xff, 0x69, 0xee, 0x13, 0x45, 0xdb, 0x83, 0x6a, 0x82, 0x6f, 0x54, 0x03, 0x38, 0xef, 0xee,
-	0x77, 0xfa, 0x9d, 0x76, 0xbf, 0xb3, 0xaf, 0x2e, 0xa1, 0x55, 0xa8, 0x9c, 0x77, 0x0f, 0x3b, 0xad,
-	0xe3, 0xfe, 0xe1, 0x73, 0x55, 0x41, 0x55, 0x58, 0x8e, 0x0a, 0x39, 0xed, 0x1a, 0x10, 0x26, 0xa6,
-	0x77, 0x45, 0x02, 0x66, 0xc8, 0x52, 0xab, 0xe8, 0x1d, 0x58, 0xa6, 0x46, 0x78, 0xa9, 0xdb, 0x96,
-	0xe4, 0xb9, 0xc4, 0x8a, 0x47, 0x16, 0x3a, 0x82, 0xd2, 0xd0, 0x70, 0xad, 0xd1, 0x9b, 0xf9, 0x4e,
-	0x8b, 0x9a, 0x81, 0x1f, 0xf2, 0x8e, 0x58, 0x02, 0x30, 0xeb, 0x4e, 0x8d, 0x2c, 0x14, 0xa0, 0x3d,
-	0x07, 0xb5, 0x47, 0x8d, 0x80, 0x26, 0xd9, 0xe9, 0x40, 0x81, 0x8d, 0x2f, 0x2d, 0x7a, 0x9e, 0x31,
-	0xc5, 0xca, 0xc4, 0xbc, 0xbb, 0xf6, 0xff, 0x39, 0x58, 0x4f, 0x60, 0x4b, 0x4b, 0x7d, 0x06, 0xa5,
-	0x80, 0x84, 0xe3, 0x11, 0xe5, 0xf0, 0xb5, 0xdd, 0xc7, 0x19, 0xe1, 0xa7, 0x90, 0x9a, 0x98, 0xc3,
-	0x60, 0x09, 0x87, 0xb6, 0x41, 0x15, 0x3d, 0x74, 0x12, 0x04, 0x5e, 0xa0, 0x3b, 0xe1, 0x80, 0x4b,
-	0xad, 0x82, 0x6b, 0x82, 0xde, 0x61, 0xe4, 0x93, 0x70, 0x90, 0x90, 0x6a, 0xfe, 0x8e, 0x52, 0x45,
-	0x06, 0xa8, 0x2e, 0xa1, 0xaf, 0xbc, 0xe0, 0x52, 0x67, 0xa2, 0x0d, 0x6c, 0x8b, 0xd4, 0x0b, 0x1c,
-	0xf4, 0xe3, 0x8c, 0xa0, 0x5d, 0xd1, 0xfd, 0x54, 0xf6, 0xc6, 0x6b, 0x6e, 0x9a, 0xa0, 0xfd, 0x10,
-	0x4a, 0x62, 0xa6, 0xcc, 0x92, 0x7a, 0xe7, 0xed, 0x76, 0xa7, 0xd7, 0x53, 0x97, 0x50, 0x05, 0x8a,
-	0xb8, 0xd3, 0xc7, 0xcc, 0xc2, 0x2a, 0x50, 0x7c, 0xd2, 0xea, 0xb7, 0x8e, 0xd5, 0x9c, 0xf6, 0x03,
-	0x58, 0x7b, 0x66, 0xd8, 0x34, 0x8b, 0x71, 0x69, 0x1e, 0xa8, 0x93, 0xb6, 0x52, 0x3b, 0x47, 0x29,
-	0xed, 0x64, 0x17, 0x4d, 0xe7, 0xda, 0xa6, 0xb7, 0xf4, 0xa1, 0x42, 0x9e, 0x04, 0x81, 0x54, 0x01,
-	0xfb, 0xd5, 0x5e, 0xc1, 0x5a, 0x8f, 0x7a, 0x7e, 0x26, 0xcb, 0xff, 0x11, 0x2c, 0x33, 0x1f, 0xe5,
-	0x8d, 0xa9, 0x34, 0xfd, 0xfb, 0x4d, 0xe1, 0xc3, 0x9a, 0x91, 0x0f, 0x6b, 0xee, 0x4b, 0x1f, 0x87,
-	0xa3, 0x96, 0xe8, 0x1e, 0x94, 0x42, 0x7b, 0xe0, 0x1a, 0x23, 0xb9, 0x5b, 0xc8, 0x92, 0x86, 0x98,
-	0x91, 0x47, 0x03, 0x4b, 0xc3, 0x6f, 0x03, 0xda, 0x27, 0x21, 0x0d, 0xbc, 0x9b, 0x4c, 0xfc, 0x6c,
-	0x42, 0xf1, 0x85, 0x17, 0x98, 0x62, 0x21, 0x96, 0xb1, 0x28, 0xb0, 0x45, 0x95, 0x02, 0x91, 0xd8,
-	0x1f, 0x00, 0x3a, 0x72, 0x99, 0x4f, 0xc9, 0xa6, 0x88, 0xbf, 0xcf, 0xc1, 0x46, 0xaa, 0xbd, 0x54,
-	0xc6, 0xe2, 0xeb, 0x90, 0x6d, 0x4c, 0xe3, 0x50, 0xac, 0x43, 0x74, 0x0a, 0x25, 0xd1, 0x42, 0x4a,
-	0xf2, 0xe1, 0x1c, 0x40, 0xc2, 0x4d, 0x49, 0x38, 0x09, 0x33, 0xd3, 0xe8, 0xf3, 0x6f, 0xd7, 0xe8,
-	0x5f, 0x81, 0x1a, 0xcd, 0x23, 0x7c, 0xa3, 0x6e, 0xbe, 0x84, 0x0d, 0xd3, 0x1b, 0x8d, 0x88, 0xc9,
-	0xac, 0x41, 0xb7, 0x5d, 0x4a, 0x82, 0x2b, 0x63, 0xf4, 0x66, 0xbb, 0x41, 0x93, 0x5e, 0x47, 0xb2,
-	0x93, 0xf6, 0x15, 0xac, 0x27, 0x06, 0x96, 0x8a, 0x78, 0x02, 0xc5, 0x90, 0x11, 0xa4, 0x26, 0x3e,
-	0x9c, 0x53, 0x13, 0x21, 0x16, 0xdd, 0xb5, 0x0d, 0x01, 0xde, 0xb9, 0x22, 0x6e, 0x3c, 0x2d, 0x6d,
-	0x1f, 0xd6, 0x7b, 0xdc, 0x4c, 0x33, 0xd9, 0xe1, 0xc4, 0xc4, 0x73, 0x29, 0x13, 0xdf, 0x04, 0x94,
-	0x44, 0x91, 0x86, 0x78, 0x03, 0x6b, 0x9d, 0x6b, 0x62, 0x66, 0x42, 0xae, 0xc3, 0xb2, 0xe9, 0x39,
-	0x8e, 0xe1, 0x5a, 0xf5, 0xdc, 0x83, 0xfc, 0x76, 0x05, 0x47, 0xc5, 0xe4, 0x5a, 0xcc, 0x67, 0x5d,
-	0x8b, 0xda, 0xdf, 0x2a, 0xa0, 0x4e, 0xc6, 0x96, 0x82, 0x64, 0xdc, 0x53, 0x8b, 0x01, 0xb1, 0xb1,
-	0x57, 0xb0, 0x2c, 0x49, 0x7a, 0xb4, 0x5d, 0x08, 0x3a, 0x09, 0x82, 0xc4, 0x76, 0x94, 0xbf, 0xe3,
-	0x76, 0xa4, 0x1d, 0xc2, 0xef, 0x44, 0xec, 0xf4, 0x68, 0x40, 0x0c, 0xc7, 0x76, 0x07, 0x47, 0xa7,
-	0xa7, 0x3e, 0x11, 0x8c, 0x23, 0x04, 0x05, 0xcb, 0xa0, 0x86, 0x64, 0x8c, 0xff, 0xb3, 0x45, 0x6f,
-	0x8e, 0xbc, 0x30, 0x5e, 0xf4, 0xbc, 0xa0, 0xfd, 0x67, 0x1e, 0xea, 0x53, 0x50, 0x91, 0x78, 0xbf,
-	0x82, 0x62, 0x48, 0xe8, 0xd8, 0x97, 0xa6, 0xd2, 0xc9, 0xcc, 0xf0, 0x6c, 0xbc, 0x66, 0x8f, 0x81,
-	0x61, 0x81, 0x89, 0x06, 0x50, 0xa6, 0xf4, 0x46, 0x0f, 0xed, 0x9f, 0x47, 0x01, 0xc1, 0xf1, 0x5d,
-	0xf1, 0xfb, 0x24, 0x70, 0x6c, 0xd7, 0x18, 0xf5, 0xec, 0x9f, 0x13, 0xbc, 0x4c, 0xe9, 0x0d, 0xfb,
-	0x41, 0xcf, 0x99, 0xc1, 0x5b, 0xb6, 0x2b, 0xc5, 0xde, 0x5e, 0x74, 0x94, 0x84, 0x80, 0xb1, 0x40,
-	0x6c, 0x1c, 0x43, 0x91, 0xcf, 0x69, 0x11, 0x43, 0x54, 0x21, 0x4f, 0xe9, 0x0d, 0x67, 0xaa, 0x8c,
-	0xd9, 0x6f, 0xe3, 0x11, 0xac, 0x24, 0x67, 0xc0, 0x0c, 0x69, 0x48, 0xec, 0xc1, 0x50, 0x18, 0x58,
-	0x11, 0xcb, 0x12, 0xd3, 0xe4, 0x2b, 0xdb, 0x92, 0x21, 0x6b, 0x11, 0x8b, 0x82, 0xf6, 0x6f, 0x39,
-	0xb8, 0x3f, 0x43, 0x32, 0xd2, 0x58, 0xbf, 0x4a, 0x19, 0xeb, 0x5b, 0x92, 0x42, 0x64, 0xf1, 0x5f,
-	0xa5, 0x2c, 0xfe, 0x2d, 0x82, 0xb3, 0x65, 0x73, 0x0f, 0x4a, 0xe4, 0xda, 0xa6, 0xc4, 0x92, 0xa2,
-	0x92, 0xa5, 0xc4, 0x72, 0x2a, 0xdc, 0x75, 0x39, 0x7d, 0x04, 0x9b, 0xed, 0x80, 0x18, 0x94, 0xc8,
-	0xad, 0x3c, 0xb2, 0xff, 0xfb, 0x50, 0x36, 0x46, 0x23, 0xcf, 0x9c, 0xa8, 0x75, 0x99, 0x97, 0x8f,
-	0x2c, 0xed, 0x5b, 0x05, 0xb6, 0x6e, 0xf5, 0x91, 0x92, 0xbe, 0x80, 0x9a, 0x1d, 0x7a, 0x23, 0x3e,
-	0x09, 0x3d, 0x71, 0x8a, 0xfb, 0xf1, 0x7c, 0xee, 0xe4, 0x28, 0xc2, 0xe0, 0x87, 0xba, 0x55, 0x3b,
-	0x59, 0xe4, 0x56, 0xc5, 0x07, 0xb7, 0xe4, 0x6a, 0x8e, 0x8a, 0xda, 0x3f, 0x28, 0xb0, 0x25, 0xbd,
-	0x78, 0xe6, 0xc9, 0xcc, 0x60, 0x39, 0xf7, 0xb6, 0x59, 0xd6, 0xea, 0x70, 0xef, 0x36, 0x5f, 0x72,
-	0x5f, 0xff, 0xa7, 0x3c, 0xa0, 0xe9, 0x13, 0x24, 0xfa, 0x1e, 0xac, 0x84, 0xc4, 0xb5, 0x74, 0xe1,
-	0x13, 0x84, 0xbb, 0x2a, 0xe3, 0x2a, 0xa3, 0x09, 0xe7, 0x10, 0xb2, 0x6d, 0x8e, 0x5c, 0x4b, 0x6e,
-	0xcb, 0x98, 0xff, 0xa3, 0x21, 0xac, 0xbc, 0x08, 0xf5, 0x78, 0x6c, 0x6e, 0x34, 0xb5, 0xcc, 0x5b,
-	0xd7, 0x34, 0x1f, 0xcd, 0x27, 0xbd, 0x78, 0x5e, 0xb8, 0xfa, 0x22, 0x8c, 0x0b, 0xe8, 0x97, 0x0a,
-	0xbc, 0x13, 0x85, 0x0e, 0x13, 0xf1, 0x39, 0x9e, 0x45, 0xc2, 0x7a, 0xe1, 0x41, 0x7e, 0xbb, 0xb6,
-	0x7b, 0x76, 0x07, 0xf9, 0x4d, 0x11, 0x4f, 0x3c, 0x8b, 0xe0, 0x2d, 0x77, 0x06, 0x35, 0x44, 0x4d,
-	0xd8, 0x70, 0xc6, 0x21, 0xd5, 0x85, 0x15, 0xe8, 0xb2, 0x51, 0xbd, 0xc8, 0xe5, 0xb2, 0xce, 0xaa,
-	0x52, 0xb6, 0xaa, 0x35, 0xa1, 0x9a, 0x98, 0x16, 0x2a, 0x43, 0xa1, 0x7b, 0xda, 0xed, 0xa8, 0x4b,
-	0x08, 0xa0, 0xd4, 0x3e, 0xc4, 0xa7, 0xa7, 0x7d, 0x11, 0x89, 0x1f, 0x9d, 0xb4, 0x0e, 0x3a, 0x6a,
-	0x4e, 0xfb, 0xbf, 0x1c, 0x6c, 0xce, 0x62, 0x12, 0x59, 0x50, 0x60, 0x13, 0x96, 0xc7, 0x9f, 0xb7,
-	0x3f, 0x5f, 0x8e, 0xce, 0xf4, 0xec, 0x1b, 0x72, 0xbf, 0xab, 0x60, 0xfe, 0x8f, 0x74, 0x28, 0x8d,
-	0x8c, 0x0b, 0x32, 0x0a, 0xeb, 0x79, 0x7e, 0x41, 0x70, 0x70, 0x97, 0xb1, 0x8f, 0x39, 0x92, 0xb8,
-	0x1d, 0x90, 0xb0, 0x8d, 0x3d, 0xa8, 0x26, 0xc8, 0x33, 0x8e, 0xe1, 0x9b, 0xc9, 0x63, 0x78, 0x25,
-	0x79, 0xa6, 0x7e, 0x3c, 0x2d, 0x2d, 0x36, 0x1b, 0x26, 0xe7, 0xc3, 0xd3, 0x5e, 0x5f, 0x1c, 0x78,
-	0x0e, 0xf0, 0xe9, 0xf9, 0x99, 0xaa, 0x30, 0x62, 0xbf, 0xd5, 0x7b, 0xaa, 0xe6, 0x62, 0x35, 0xe4,
-	0xb5, 0x7f, 0x5d, 0x06, 0x98, 0x1c, 0x41, 0x51, 0x0d, 0x72, 0xf1, 0xa2, 0xcd, 0xd9, 0x16, 0x93,
-	0x87, 0x6b, 0x38, 0xd1, 0xc0, 0xfc, 0x1f, 0xed, 0xc2, 0x96, 0x13, 0x0e, 0x7c, 0xc3, 0xbc, 0xd4,
-	0xe5, 0xc9, 0xd1, 0xe4, 0x9d, 0xf9, 0x02, 0x58, 0xc1, 0x1b, 0xb2, 0x52, 0x1a, 0xb8, 0xc0, 0x3d,
-	0x86, 0x3c, 0x71, 0xaf, 0xb8, 0xb1, 0x56, 0x77, 0x3f, 0x9d, 0xfb, 0x68, 0xdc, 0xec, 0xb8, 0x57,
-	0x42, 0x66, 0x0c, 0x06, 0xe9, 0x00, 0x16, 0xb9, 0xb2, 0x4d, 0xa2, 0x33, 0xd0, 0x22, 0x07, 0xfd,
-	0x7c, 0x7e, 0xd0, 0x7d, 0x8e, 0x11, 0x43, 0x57, 0xac, 0xa8, 0x8c, 0xba, 0x50, 0x09, 0x48, 0xe8,
-	0x8d, 0x03, 0x93, 0x84, 0xf5, 0xd2, 0x5c, 0xd1, 0x2b, 0x8e, 0xfa, 0xe1, 0x09, 0x04, 0xda, 0x87,
-	0x92, 0xe3, 0x8d, 0x5d, 0x1a, 0xd6, 0x97, 0x39, 0xb3, 0xef, 0x67, 0x04, 0x3b, 0x61, 0x9d, 0xb0,
-	0xec, 0x8b, 0x0e, 0x60, 0x59, 0xb0, 0x18, 0xd6, 0xcb, 0x1c, 0xe6, 0x83, 0xac, 0x7b, 0x0d, 0xef,
-	0x85, 0xa3, 0xde, 0x4c, 0xab, 0xe3, 0x90, 0x04, 0xf5, 0x8a, 0xd0, 0x2a, 0xfb, 0x47, 0xef, 0x42,
-	0x45, 0x6c, 0xda, 0x96, 0x1d, 0xd4, 0x81, 0x57, 0x88, 0x5d, 0x7c, 0xdf, 0x0e, 0xd0, 0x7b, 0x50,
-	0x15, 0x0e, 0x58, 0xe7, 0xab, 0xa3, 0xca, 0xab, 0x41, 0x90, 0xce, 0xd8, 0x1a, 0x11, 0x0d, 0x48,
-	0x10, 0x88, 0x06, 0x2b, 0x71, 0x03, 0x12, 0x04, 0xbc, 0xc1, 0xef, 0xc3, 0x1a, 0x0f, 0x5b, 0x06,
-	0x81, 0x37, 0xf6, 0x75, 0x6e, 0x53, 0xab, 0xbc, 0xd1, 0x2a, 0x23, 0x1f, 0x30, 0x6a, 0x97, 0x19,
-	0xd7, 0x7d, 0x28, 0xbf, 0xf4, 0x2e, 0x44, 0x83, 0x9a, 0xf0, 0x1d, 0x2f, 0xbd, 0x8b, 0xa8, 0x2a,
-	0x76, 0x2b, 0x6b, 0x69, 0xb7, 0xf2, 0x0d, 0xdc, 0x9b, 0xde, 0x1f, 0xb9, 0x7b, 0x51, 0xef, 0xee,
-	0x5e, 0x36, 0xdd, 0x19, 0xd4, 0xc6, 0xc7, 0x50, 0x8e, 0x2c, 0x67, 0x9e, 0x15, 0xdb, 0x78, 0x04,
-	0xb5, 0xb4, 0xdd, 0xcd, 0xb5, 0xde, 0xff, 0x4b, 0x81, 0x4a, 0x6c, 0x61, 0xc8, 0x85, 0x0d, 0x2e,
-	0x01, 0xe6, 0x8f, 0xf5, 0x89, 0xc1, 0x8a, 0x28, 0xe0, 0xb3, 0x8c, 0x73, 0x6e, 0x45, 0x08, 0xf2,
-	0xc8, 0x21, 0xad, 0x17, 0xc5, 0xc8, 0x93, 0xf1, 0xbe, 0x86, 0xb5, 0x91, 0xed, 0x8e, 0xaf, 0x13,
-	0x63, 0x09, 0xf7, 0xfd, 0x07, 0x19, 0xc7, 0x3a, 0x66, 0xbd, 0x27, 0x63, 0xd4, 0x46, 0xa9, 0xb2,
-	0xf6, 0x6d, 0x0e, 0xee, 0xcd, 0x66, 0x07, 0x75, 0x21, 0x6f, 0xfa, 0x63, 0x39, 0xb5, 0x47, 0xf3,
-	0x4e, 0xad, 0xed, 0x8f, 0x27, 0xa3, 0x32, 0x20, 0xf4, 0x0c, 0x4a, 0x0e, 0x71, 0xbc, 0xe0, 0x46,
-	0xce, 0xe0, 0xf1, 0xbc, 0x90, 0x27, 0xbc, 0xf7, 0x04, 0x55, 0xc2, 0x21, 0x0c, 0x65, 0x69, 0x2f,
-	0xa1, 0xdc, 0x99, 0xe6, 0x3c, 0xdd, 0x47, 0x90, 0x38, 0xc6, 0xd1, 0x3e, 0x86, 0xad, 0x99, 0x53,
-	0x41, 0xbf, 0x0b, 0x60, 0xfa, 0x63, 0x9d, 0xdf, 0xbe, 0x0a, 0xbd, 0xe7, 0x71, 0xc5, 0xf4, 0xc7,
-	0x3d, 0x4e, 0xd0, 0x1e, 0x42, 0xfd, 0x75, 0xfc, 0xb2, 0xf5, 0x2e, 0x38, 0xd6, 0x9d, 0x0b, 0x2e,
-	0x83, 0x3c, 0x2e, 0x0b, 0xc2, 0xc9, 0x85, 0xf6, 0xab, 0x1c, 0xac, 0xdd, 0x62, 0x87, 0x45, 0xc7,
-	0x62, 0xff, 0x88, 0xce, 0x1d, 0xa2, 0xc4, 0x36, 0x13, 0xd3, 0xb6, 0xa2, 0x1b, 0x2b, 0xfe, 0xcf,
-	0xdd, 0x88, 0x2f, 0x6f, 0x93, 0x72, 0xb6, 0xcf, 0x0c, 0xda, 0xb9, 0xb0, 0x69, 0xc8, 0x03, 0xe8,
-	0x22, 0x16, 0x05, 0xf4, 0x1c, 0x6a, 0x01, 0x09, 0x49, 0x70, 0x45, 0x2c, 0xdd, 0xf7, 0x02, 0x1a,
-	0x09, 0x6c, 0x77, 0x3e, 0x81, 0x9d, 0x79, 0x01, 0xc5, 0xab, 0x11, 0x12, 0x2b, 0x85, 0xe8, 0x19,
-	0xac, 0x5a, 0x37, 0xae, 0xe1, 0xd8, 0xa6, 0x44, 0x2e, 0x2d, 0x8c, 0xbc, 0x22, 0x81, 0x38, 0xb0,
-	0xb6, 0x07, 0xd5, 0x44, 0x25, 0x9b, 0x18, 0x77, 0xe2, 0x52, 0x26, 0xa2, 0x90, 0x5e, 0xbf, 0x45,
-	0xb9, 0x7e, 0xb5, 0x7f, 0xce, 0x41, 0x2d, 0xbd, 0x00, 0x22, 0xfd, 0xf9, 0x24, 0xb0, 0x3d, 0x2b,
-	0xa1, 0xbf, 0x33, 0x4e, 0x60, 0x3a, 0x62, 0xd5, 0xdf, 0x8c, 0x3d, 0x6a, 0x44, 0x3a, 0x32, 0xfd,
-	0xf1, 0x1f, 0xb2, 0xf2, 0x2d, 0xdd, 0xe7, 0x6f, 0xe9, 0x1e, 0xbd, 0x0f, 0x48, 0xea, 0x77, 0x64,
-	0x3b, 0x36, 0xd5, 0x2f, 0x6e, 0x28, 0x11, 0xf2, 0xcf, 0x63, 0x55, 0xd4, 0x1c, 0xb3, 0x8a, 0x2f,
-	0x18, 0x1d, 0x69, 0xb0, 0xea, 0x79, 0x8e, 0x1e, 0x9a, 0x5e, 0x40, 0x74, 0xc3, 0x7a, 0xc9, 0x03,
-	0xba, 0x3c, 0xae, 0x7a, 0x9e, 0xd3, 0x63, 0xb4, 0x96, 0xf5, 0x92, 0xed, 0xf1, 0xa6, 0x3f, 0x0e,
-	0x09, 0xd5, 0xd9, 0x87, 0xbb, 0xc5, 0x0a, 0x06, 0x41, 0x6a, 0xfb, 0xe3, 0x30, 0xd1, 0xc0, 0x21,
-	0x0e, 0x73, 0x75, 0x89, 0x06, 0x27, 0xc4, 0x61, 0xa3, 0xac, 0x9c, 0x91, 0xc0, 0x24, 0x2e, 0xed,
-	0xdb, 0xe6, 0x25, 0xf3, 0x62, 0xca, 0xb6, 0x82, 0x53, 0x34, 0xed, 0x67, 0x50, 0xe4, 0x5e, 0x8f,
-	0x4d, 0x9e, 0x7b, 0x0c, 0xee, 0x50, 0x84, 0x78, 0xcb, 0x8c, 0xc0, 0xdd, 0xc9, 0xbb, 0x50, 0x19,
-	0x7a, 0xa1, 0x74, 0x47, 0xc2, 0xf2, 0xca, 0x8c, 0xc0, 0x2b, 0x1b, 0x50, 0x0e, 0x88, 0x61, 0x79,
-	0xee, 0x28, 0x3a, 0xf4, 0xc6, 0x65, 0xed, 0x1b, 0x28, 0x89, 0xed, 0xf7, 0x0e, 0xf8, 0x1f, 0x00,
-	0x32, 0x85, 0x1f, 0xf3, 0xd9, 0x21, 0x3a, 0x0c, 0x6d, 0xcf, 0x0d, 0xa3, 0x97, 0x16, 0x51, 0x73,
-	0x36, 0xa9, 0xd0, 0xfe, 0x5b, 0x11, 0x21, 0x96, 0xb8, 0x03, 0x67, 0x27, 0x2a, 0x66, 0x69, 0xec,
-	0xc4, 0x20, 0x0e, 0xdb, 0x51, 0x91, 0x9d, 0x33, 0x65, 0x24, 0x95, 0x5b, 0xf4, 0x09, 0x41, 0x02,
-	0x44, 0x57, 0x6f, 0x44, 0x1e, 0x4a, 0xe6, 0xbd, 0x7a, 0x23, 0xe2, 0xea, 0x8d, 0xb0, 0xa3, 0x91,
-	0x8c, 0xf1, 0x04, 0x5c, 0x81, 0x87, 0x78, 0x55, 0x2b, 0xbe, 0xdf, 0x24, 0xda, 0xff, 0x2a, 0xf1,
-	0x5e, 0x11, 0xdd, 0x43, 0xa2, 0xaf, 0xa1, 0xcc, 0x96, 0x9d, 0xee, 0x18, 0xbe, 0x7c, 0x55, 0x6b,
-	0x2f, 0x76, 0xc5, 0xd9, 0x64, 0xab, 0xec, 0xc4, 0xf0, 0x45, 0x84, 0xb6, 0xec, 0x8b, 0x12, 0xdb,
-	0x73, 0x0c, 0x6b, 0xb2, 0xe7, 0xb0, 0x7f, 0xf4, 0x7d, 0xa8, 0x19, 0x63, 0xea, 0xe9, 0x86, 0x75,
-	0x45, 0x02, 0x6a, 0x87, 0x44, 0xea, 0x7e, 0x95, 0x51, 0x5b, 0x11, 0xb1, 0xf1, 0x29, 0xac, 0x24,
-	0x31, 0xdf, 0xe4, 0x7d, 0x8b, 0x49, 0xef, 0xfb, 0x27, 0x00, 0x93, 0x33, 0x3d, 0xb3, 0x11, 0x72,
-	0x6d, 0x53, 0xdd, 0x8c, 0x8e, 0x25, 0x45, 0x5c, 0x66, 0x84, 0x36, 0x0b, 0xc0, 0xd3, 0x17, 0x8e,
-	0xc5, 0xe8, 0xc2, 0x91, 0xad, 0x5a, 0xb6, 0xd0, 0x2e, 0xed, 0xd1, 0x28, 0xbe, 0x67, 0xa8, 0x78,
-	0x9e, 0xf3, 0x94, 0x13, 0xb4, 0xdf, 0xe4, 0x84, 0xad, 0x88, 0xab, 0xe3, 0x4c, 0xe1, 0xf8, 0xdb,
-	0x52, 0xf5, 0x1e, 0x40, 0x48, 0x8d, 0x80, 0x85, 0x12, 0x46, 0x74, 0xd3, 0xd1, 0x98, 0xba, 0xb1,
-	0xec, 0x47, 0x2f, 0xe0, 0xb8, 0x22, 0x5b, 0xb7, 0x28, 0xfa, 0x0c, 0x56, 0x4c, 0xcf, 0xf1, 0x47,
-	0x44, 0x76, 0x2e, 0xbe, 0xb1, 0x73, 0x35, 0x6e, 0xdf, 0xa2, 0x89, 0xfb, 0x95, 0xd2, 0x5d, 0xef,
-	0x57, 0xfe, 0x5d, 0x11, 0x37, 0xe0, 0xc9, 0x0b, 0x78, 0x34, 0x98, 0xf1, 0xca, 0x7b, 0xb0, 0xe0,
-	0x6d, 0xfe, 0x77, 0x3d, 0xf1, 0x36, 0x3e, 0xcb, 0xf2, 0xa6, 0xfa, 0xfa, 0xe0, 0xee, 0x3f, 0xf2,
-	0x50, 0x89, 0x2f, 0xbf, 0xa7, 0x74, 0xff, 0x09, 0x54, 0xe2, 0xf4, 0x03, 0xb9, 0x41, 0x7c, 0xa7,
-	0x7a, 0xe2, 0xc6, 0xe8, 0x05, 0x20, 0x63, 0x30, 0x88, 0x83, 0x36, 0x7d, 0x1c, 0x1a, 0x83, 0xe8,
-	0xe9, 0xe1, 0x93, 0x39, 0xe4, 0x10, 0xf9, 0xad, 0x73, 0xd6, 0x1f, 0xab, 0xc6, 0x60, 0x90, 0xa2,
-	0xa0, 0x3f, 0x85, 0xad, 0xf4, 0x18, 0xfa, 0xc5, 0x8d, 0xee, 0xdb, 0x96, 0x3c, 0xf6, 0x1d, 0xce,
-	0x7b, 0xff, 0xdf, 0x4c, 0xc1, 0x7f, 0x71, 0x73, 0x66, 0x5b, 0x42, 0xe6, 0x28, 0x98, 0xaa, 0x68,
-	0xfc, 0x39, 0xbc, 0xf3, 0x9a, 0xe6, 0x33, 0x74, 0xd0, 0x4d, 0xbf, 0x6b, 0x2f, 0x2e, 0x84, 0x84,
-	0xf6, 0x7e, 0xad, 0x88, 0x67, 0x8a, 0xb4, 0x4c, 0x5a, 0xc9, 0xb8, 0x75, 0x27, 0xe3, 0x38, 0xed,
-	0xb3, 0x73, 0x01, 0xcf, 0x43, 0xd5, 0x2f, 0x6f, 0x85, 0xaa, 0x59, 0x83, 0x18, 0x11, 0xf1, 0x09,
-	0x20, 0x89, 0xa0, 0xfd, 0x4b, 0x1e, 0xca, 0x11, 0x3a, 0x3f, 0xb4, 0xdd, 0x84, 0x94, 0x38, 0x7a,
-	0x7c, 0xb3, 0xa2, 0x60, 0x10, 0x24, 0x7e, 0x8b, 0xf0, 0x2e, 0x54, 0xd8, 0xd9, 0x50, 0x54, 0xe7,
-	0x78, 0x75, 0x99, 0x11, 0x78, 0xe5, 0x7b, 0x50, 0xa5, 0x1e, 0x35, 0x46, 0x3a, 0xe5, 0xbe, 0x3c,
-	0x2f, 0x7a, 0x73, 0x12, 0xf7, 0xe4, 0xe8, 0x87, 0xb0, 0x4e, 0x87, 0x81, 0x47, 0xe9, 0x88, 0xc5,
-	0x77, 0x3c, 0xa2, 0x11, 0x01, 0x48, 0x01, 0xab, 0x71, 0x85, 0x88, 0x74, 0x42, 0xb6, 0x7b, 0x4f,
-	0x1a, 0x33, 0xd3, 0xe5, 0x9b, 0x48, 0x01, 0xaf, 0xc6, 0x54, 0x66, 0xda, 0xcc, 0x79, 0xfa, 0x22,
-	0x5a, 0xe0, 0x7b, 0x85, 0x82, 0xa3, 0x22, 0xd2, 0x61, 0xcd, 0x21, 0x46, 0x38, 0x0e, 0x88, 0xa5,
-	0xbf, 0xb0, 0xc9, 0xc8, 0x12, 0x67, 0xed, 0x5a, 0xe6, 0xf0, 0x3b, 0x12, 0x4b, 0xf3, 0x09, 0xef,
-	0x8d, 0x6b, 0x11, 0x9c, 0x28, 0xb3, 0xc8, 0x41, 0xfc, 0xa1, 0x35, 0xa8, 0xf6, 0x9e, 0xf7, 0xfa,
-	0x9d, 0x13, 0xfd, 0xe4, 0x74, 0xbf, 0x23, 0x53, 0x17, 0x7a, 0x1d, 0x2c, 0x8a, 0x0a, 0xab, 0xef,
-	0x9f, 0xf6, 0x5b, 0xc7, 0x7a, 0xff, 0xa8, 0xfd, 0xb4, 0xa7, 0xe6, 0xd0, 0x16, 0xac, 0xf7, 0x0f,
-	0xf1, 0x69, 0xbf, 0x7f, 0xdc, 0xd9, 0xd7, 0xcf, 0x3a, 0xf8, 0xe8, 0x74, 0xbf, 0xa7, 0xe6, 0x11,
-	0x82, 0xda, 0x84, 0xdc, 0x3f, 0x3a, 0xe9, 0xa8, 0x05, 0x54, 0x85, 0xe5, 0xb3, 0x0e, 0x6e, 0x77,
-	0xba, 0x7d, 0xb5, 0xa8, 0xfd, 0x2a, 0x0f, 0xd5, 0x84, 0x16, 0x99, 0x21, 0x07, 0xa1, 0x88, 0xf3,
-	0x0b, 0x98, 0xfd, 0xf2, 0xa7, 0x16, 0xc3, 0x1c, 0x0a, 0xed, 0x14, 0xb0, 0x28, 0xf0, 0xd8, 0xde,
-	0xb8, 0x4e, 0xac, 0xf3, 0x02, 0x2e, 0x3b, 0xc6, 0xb5, 0x00, 0xf9, 0x1e, 0xac, 0x5c, 0x92, 0xc0,
-	0x25, 0x23, 0x59, 0x2f, 0x34, 0x52, 0x15, 0x34, 0xd1, 0x64, 0x1b, 0x54, 0xd9, 0x64, 0x02, 0x23,
-	0xd4, 0x51, 0x13, 0xf4, 0x93, 0x08, 0x6c, 0x13, 0x8a, 0xa2, 0x7a, 0x59, 0x8c, 0xcf, 0x0b, 0xcc,
-	0x4d, 0x85, 0xaf, 0x0c, 0x9f, 0xc7, 0x77, 0x05, 0xcc, 0xff, 0xd1, 0xc5, 0xb4, 0x7e, 0x4a, 0x5c,
-	0x3f, 0x7b, 0xf3, 0x9b, 0xf3, 0xeb, 0x54, 0x34, 0x8c, 0x55, 0xb4, 0x0c, 0x79, 0x1c, 0xbd, 0xf7,
-	0xb7, 0x5b, 0xed, 0x43, 0xa6, 0x96, 0x55, 0xa8, 0x9c, 0xb4, 0x7e, 0xaa, 0x9f, 0xf7, 0xf8, 0x4d,
-	0x23, 0x52, 0x61, 0xe5, 0x69, 0x07, 0x77, 0x3b, 0xc7, 0x92, 0x92, 0x47, 0x9b, 0xa0, 0x4a, 0xca,
-	0xa4, 0x5d, 0x81, 0x21, 0x88, 0xdf, 0x22, 0x2a, 0x43, 0xa1, 0xf7, 0xac, 0x75, 0xa6, 0x96, 0xb4,
-	0xff, 0xc9, 0xc1, 0x9a, 0x70, 0x0b, 0xf1, 0xcb, 0xe4, 0xeb, 0x5f, 0x66, 0x92, 0x17, 0x17, 0xb9,
-	0xf4, 0xc5, 0x45, 0x14, 0x84, 0x72, 0xaf, 0x9e, 0x9f, 0x04, 0xa1, 0xfc, 0xc2, 0x23, 0xb5, 0xe3,
-	0x17, 0xe6, 0xd9, 0xf1, 0xeb, 0xb0, 0xec, 0x90, 0x30, 0xd6, 0x5b, 0x05, 0x47, 0x45, 0x64, 0x43,
-	0xd5, 0x70, 0x5d, 0x8f, 0xf2, 0x8b, 0x8c, 0xe8, 0x58, 0x74, 0x30, 0xd7, 0x9d, 0x75, 0x3c, 0xe3,
-	0x66, 0x6b, 0x82, 0x24, 0x36, 0xe6, 0x24, 0x76, 0xe3, 0x27, 0xa0, 0xde, 0x6e, 0x30, 0x8f, 0x3b,
-	0xfc, 0xc1, 0x47, 0x13, 0x6f, 0x48, 0xd8, 0xba, 0x38, 0xef, 0x3e, 0xed, 0x9e, 0x3e, 0xeb, 0xaa,
-	0x4b, 0xac, 0x80, 0xcf, 0xbb, 0xdd, 0xa3, 0xee, 0x81, 0xaa, 0x20, 0x80, 0x52, 0xe7, 0xa7, 0x47,
-	0xfd, 0xce, 0xbe, 0x9a, 0xdb, 0xfd, 0xf5, 0x3a, 0x94, 0x04, 0x93, 0xe8, 0x5b, 0x19, 0x09, 0x24,
-	0xb3, 0xde, 0xd0, 0x4f, 0xe6, 0x8e, 0xa8, 0x53, 0x99, 0x74, 0x8d, 0xc7, 0x0b, 0xf7, 0x97, 0x2f,
-	0x10, 0x4b, 0xe8, 0xaf, 0x15, 0x58, 0x49, 0xbd, 0x3e, 0x64, 0xbd, 0x0d, 0x9d, 0x91, 0x64, 0xd7,
-	0xf8, 0xf1, 0x42, 0x7d, 0x63, 0x5e, 0x7e, 0xa9, 0x40, 0x35, 0x91, 0x5e, 0x86, 0xf6, 0x16, 0x49,
-	0x49, 0x13, 0x9c, 0x7c, 0xba, 0x78, 0x36, 0x9b, 0xb6, 0xf4, 0xa1, 0x82, 0xfe, 0x4a, 0x81, 0x6a,
-	0x22, 0xd1, 0x2a, 0x33, 0x2b, 0xd3, 0x69, 0x61, 0x99, 0x59, 0x99, 0x95, 0xd7, 0xb5, 0x84, 0xfe,
-	0x42, 0x81, 0x4a, 0x9c, 0x34, 0x85, 0x1e, 0xce, 0x9f, 0x66, 0x25, 0x98, 0xf8, 0x64, 0xd1, 0xfc,
-	0x2c, 0x6d, 0x09, 0xfd, 0x19, 0x94, 0xa3, 0x0c, 0x23, 0x94, 0xd5, 0x7b, 0xdd, 0x4a, 0x5f, 0x6a,
-	0x3c, 0x9c, 0xbb, 0x5f, 0x72, 0xf8, 0x28, 0xed, 0x27, 0xf3, 0xf0, 0xb7, 0x12, 0x94, 0x1a, 0x0f,
-	0xe7, 0xee, 0x17, 0x0f, 0xcf, 0x2c, 0x21, 0x91, 0x1d, 0x94, 0xd9, 0x12, 0xa6, 0xd3, 0x92, 0x32,
-	0x5b, 0xc2, 0xac, 0x64, 0x24, 0xc1, 0x48, 0x22, 0xbf, 0x28, 0x33, 0x23, 0xd3, 0x39, 0x4c, 0x99,
-	0x19, 0x99, 0x91, 0xce, 0xa4, 0x2d, 0xa1, 0x5f, 0x28, 0xc9, 0x73, 0xc1, 0xc3, 0xb9, 0xd3, 0x68,
-	0xe6, 0x34, 0xc9, 0xa9, 0x44, 0x1e, 0xbe, 0x40, 0x7f, 0x21, 0x6f, 0x31, 0x44, 0x16, 0x0e, 0x9a,
-	0x07, 0x2c, 0x95, 0xb8, 0xd3, 0xf8, 0x78, 0x31, 0x67, 0xc3, 0x99, 0xf8, 0x4b, 0x05, 0x60, 0x92,
-	0xaf, 0x93, 0x99, 0x89, 0xa9, 0x44, 0xa1, 0xc6, 0xde, 0x02, 0x3d, 0x93, 0x0b, 0x24, 0xca, 0x27,
-	0xc8, 0xbc, 0x40, 0x6e, 0xe5, 0x13, 0x65, 0x5e, 0x20, 0xb7, 0x73, 0x81, 0xb4, 0x25, 0xf4, 0x8f,
-	0x0a, 0xac, 0x4f, 0xe5, 0x33, 0xa0, 0xc7, 0x77, 0x4c, 0x69, 0x69, 0x7c, 0xbe, 0x38, 0x40, 0xc4,
-	0xda, 0xb6, 0xf2, 0xa1, 0x82, 0xfe, 0x46, 0x81, 0xd5, 0xd4, 0x1b, 0x30, 0xca, 0xec, 0xa5, 0x66,
-	0x64, 0x46, 0x34, 0x1e, 0x2d, 0xd6, 0x39, 0x96, 0xd6, 0xdf, 0x29, 0x50, 0x4b, 0xa7, 0x03, 0xa0,
-	0x47, 0xf3, 0x6d, 0x0b, 0xb7, 0x18, 0xfa, 0x6c, 0xc1, 0xde, 0x11, 0x47, 0x5f, 0x2c, 0xff, 0x51,
-	0x51, 0x44, 0x6f, 0x25, 0xfe, 0xf9, 0xd1, 0x6f, 0x03, 0x00, 0x00, 0xff, 0xff, 0x81, 0xc8, 0x42,
-	0x4f, 0xd2, 0x30, 0x00, 0x00,
+	// 3527 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x5a, 0x4f, 0x73, 0xdb, 0x48,
+	0x76, 0x37, 0x08, 0x92, 0x22, 0x1f, 0x25, 0x0a, 0x6a, 0x49, 0x36, 0xcd, 0x49, 0x32, 0x5e, 0x54,
+	0x6d, 0x4a, 0xb5, 0xbb, 0xa6, 0x66, 0xb4, 0x15, 0xff, 0x5b, 0x7b, 0x6d, 0x0e, 0x45, 0x4b, 0x1a,
+	0x4b, 0x94, 0xd2, 0xa4, 0xca, 0xeb, 0x38, 0x33, 0x08, 0x04, 0xb4, 0x49, 0x58, 0xc4, 0x1f, 0x03,
+	0xa0, 0x2c, 0x4d, 0x2a, 0x95, 0xd4, 0xa4, 0x2a, 0x35, 0xa9, 0x4a, 0x2a, 0xb9, 0x4c, 0xe6, 0x92,
+	0x43, 0x6a, 0x72, 0x4c, 0x3e, 0x40, 0x2a, 0xa9, 0x39, 0xe7, 0x43, 0x24, 0x97, 0xdc, 0x72, 0xc9,
+	0x21, 0xdf, 0x60, 0xab, 0xff, 0x00, 0x04, 0x44, 0x7a, 0x0c, 0x52, 0x3e, 0x91, 0xfd, 0xba, 0xdf,
+	0xaf, 0x5f, 0xbf, 0xf7, 0xba, 0xdf, 0xeb, 0x87, 0x06, 0xd5, 0x1b, 0x8e, 0xfa, 0x96, 0x13, 0x6c,
+	0x9a, 0xbe, 0x75, 0x46, 0xfc, 0x60, 0xd3, 0xf3, 0xdd, 0xd0, 0x15, 0xad, 0x06, 0x6b, 0xa0, 0x9f,
+	0x0e, 0xf4, 0x60, 0x60, 0x19, 0xae, 0xef, 0x35, 0x1c, 0xd7, 0xd6, 0xcd, 0x86, 0xe0, 0x69, 0x08,
+	0x1e, 0x3e, 0xac, 0xfe, 0x7b, 0x7d, 0xd7, 0xed, 0x0f, 0x09, 0x47, 0x38, 0x19, 0xbd, 0xda, 0x34,
+	0x47, 0xbe, 0x1e, 0x5a, 0xae, 0x23, 0xfa, 0x3f, 0xbe, 0xdc, 0x1f, 0x5a, 0x36, 0x09, 0x42, 0xdd,
+	0xf6, 0xc4, 0x80, 0x27, 0x7d, 0x2b, 0x1c, 0x8c, 0x4e, 0x1a, 0x86, 0x6b, 0x6f, 0xc6, 0x53, 0x6e,
+	0xb2, 0x29, 0x37, 0x23, 0x31, 0x83, 0x81, 0xee, 0x13, 0x73, 0x73, 0x60, 0x0c, 0x03, 0x8f, 0x18,
+	0xf4, 0x57, 0xa3, 0x7f, 0x04, 0xc2, 0x4e, 0x76, 0x84, 0x20, 0xf4, 0x47, 0x46, 0x18, 0xad, 0x57,
+	0x0f, 0x43, 0xdf, 0x3a, 0x19, 0x85, 0x84, 0x03, 0xa9, 0x37, 0xe1, 0x46, 0x4f, 0x0f, 0x4e, 0x5b,
+	0xae, 0xf3, 0xca, 0xea, 0x77, 0x8d, 0x01, 0xb1, 0x75, 0x4c, 0xde, 0x8c, 0x48, 0x10, 0xaa, 0x7f,
+	0x0c, 0xb5, 0xc9, 0xae, 0xc0, 0x73, 0x9d, 0x80, 0xa0, 0x27, 0x90, 0xa7, 0xd2, 0xd4, 0xa4, 0x5b,
+	0xd2, 0x46, 0x65, 0xeb, 0x17, 0x8d, 0x77, 0x29, 0x8e, 0xcb, 0xd0, 0x10, 0xab, 0x68, 0x74, 0x3d,
+	0x62, 0x60, 0xc6, 0xa9, 0xae, 0xc3, 0x6a, 0x4b, 0xf7, 0xf4, 0x13, 0x6b, 0x68, 0x85, 0x16, 0x09,
+	0xa2, 0x49, 0x47, 0xb0, 0x96, 0x26, 0x8b, 0x09, 0xbf, 0x80, 0x45, 0x23, 0x41, 0x17, 0x13, 0xdf,
+	0x6f, 0x64, 0xb2, 0x58, 0x63, 0x9b, 0xb5, 0x52, 0xc0, 0x29, 0x38, 0x75, 0x0d, 0xd0, 0x53, 0xcb,
+	0xe9, 0x13, 0xdf, 0xf3, 0x2d, 0x27, 0x8c, 0x84, 0xf9, 0x41, 0x86, 0xd5, 0x14, 0x59, 0x08, 0xf3,
+	0x1a, 0x20, 0xd6, 0x23, 0x15, 0x45, 0xde, 0xa8, 0x6c, 0x7d, 0x9e, 0x51, 0x94, 0x29, 0x78, 0x8d,
+	0x66, 0x0c, 0xd6, 0x76, 0x42, 0xff, 0x02, 0x27, 0xd0, 0xd1, 0x97, 0x50, 0x1c, 0x10, 0x7d, 0x18,
+	0x0e, 0x6a, 0xb9, 0x5b, 0xd2, 0x46, 0x75, 0xeb, 0xe9, 0x15, 0xe6, 0xd9, 0x65, 0x40, 0xdd, 0x50,
+	0x0f, 0x09, 0x16, 0xa8, 0xe8, 0x36, 0x20, 0xfe, 0x4f, 0x33, 0x49, 0x60, 0xf8, 0x96, 0x47, 0x1d,
+	0xb9, 0x26, 0xdf, 0x92, 0x36, 0xca, 0x78, 0x85, 0xf7, 0x6c, 0x8f, 0x3b, 0xea, 0x1e, 0x2c, 0x5f,
+	0x92, 0x16, 0x29, 0x20, 0x9f, 0x92, 0x0b, 0x66, 0x91, 0x32, 0xa6, 0x7f, 0xd1, 0x0e, 0x14, 0xce,
+	0xf4, 0xe1, 0x88, 0x30, 0x91, 0x2b, 0x5b, 0x9f, 0xbe, 0xcf, 0x3d, 0x84, 0x8b, 0x8e, 0xf5, 0x80,
+	0x39, 0xff, 0x83, 0xdc, 0x3d, 0x49, 0xbd, 0x0f, 0x95, 0x84, 0xdc, 0xa8, 0x0a, 0x70, 0xdc, 0xd9,
+	0x6e, 0xf7, 0xda, 0xad, 0x5e, 0x7b, 0x5b, 0xb9, 0x86, 0x96, 0xa0, 0x7c, 0xdc, 0xd9, 0x6d, 0x37,
+	0xf7, 0x7b, 0xbb, 0x2f, 0x14, 0x09, 0x55, 0x60, 0x21, 0x6a, 0xe4, 0xd4, 0x73, 0x40, 0x98, 0x18,
+	0xee, 0x19, 0xf1, 0xa9, 0x23, 0x0b, 0xab, 0xa2, 0x1b, 0xb0, 0x10, 0xea, 0xc1, 0xa9, 0x66, 0x99,
+	0x42, 0xe6, 0x22, 0x6d, 0xee, 0x99, 0x68, 0x0f, 0x8a, 0x03, 0xdd, 0x31, 0x87, 0xef, 0x97, 0x3b,
+	0xad, 0x6a, 0x0a, 0xbe, 0xcb, 0x18, 0xb1, 0x00, 0xa0, 0xde, 0x9d, 0x9a, 0x99, 0x1b, 0x40, 0x7d,
+	0x01, 0x4a, 0x37, 0xd4, 0xfd, 0x30, 0x29, 0x4e, 0x1b, 0xf2, 0x74, 0xfe, 0x9a, 0x34, 0xf3, 0x9c,
+	0x7c, 0x67, 0x62, 0xc6, 0xae, 0xfe, 0x7f, 0x0e, 0x56, 0x12, 0xd8, 0xc2, 0x53, 0x9f, 0x43, 0xd1,
+	0x27, 0xc1, 0x68, 0x18, 0x32, 0xf8, 0xea, 0xd6, 0xe3, 0x8c, 0xf0, 0x13, 0x48, 0x0d, 0xcc, 0x60,
+	0xb0, 0x80, 0x43, 0x1b, 0xa0, 0x70, 0x0e, 0x8d, 0xf8, 0xbe, 0xeb, 0x6b, 0x76, 0xd0, 0x67, 0x5a,
+	0x2b, 0xe3, 0x2a, 0xa7, 0xb7, 0x29, 0xf9, 0x20, 0xe8, 0x27, 0xb4, 0x2a, 0x5f, 0x51, 0xab, 0x48,
+	0x07, 0xc5, 0x21, 0xe1, 0x5b, 0xd7, 0x3f, 0xd5, 0xa8, 0x6a, 0x7d, 0xcb, 0x24, 0xb5, 0x3c, 0x03,
+	0xbd, 0x93, 0x11, 0xb4, 0xc3, 0xd9, 0x0f, 0x05, 0x37, 0x5e, 0x76, 0xd2, 0x04, 0xf5, 0xe7, 0x50,
+	0xe4, 0x2b, 0xa5, 0x9e, 0xd4, 0x3d, 0x6e, 0xb5, 0xda, 0xdd, 0xae, 0x72, 0x0d, 0x95, 0xa1, 0x80,
+	0xdb, 0x3d, 0x4c, 0x3d, 0xac, 0x0c, 0x85, 0xa7, 0xcd, 0x5e, 0x73, 0x5f, 0xc9, 0xa9, 0x3f, 0x83,
+	0xe5, 0xe7, 0xba, 0x15, 0x66, 0x71, 0x2e, 0xd5, 0x05, 0x65, 0x3c, 0x56, 0x58, 0x67, 0x2f, 0x65,
+	0x9d, 0xec, 0xaa, 0x69, 0x9f, 0x5b, 0xe1, 0x25, 0x7b, 0x28, 0x20, 0x13, 0xdf, 0x17, 0x26, 0xa0,
+	0x7f, 0xd5, 0xb7, 0xb0, 0xdc, 0x0d, 0x5d, 0x2f, 0x93, 0xe7, 0xff, 0x12, 0x16, 0x68, 0x8c, 0x72,
+	0x47, 0xa1, 0x70, 0xfd, 0x9b, 0x0d, 0x1e, 0xc3, 0x1a, 0x51, 0x0c, 0x6b, 0x6c, 0x8b, 0x18, 0x87,
+	0xa3, 0x91, 0xe8, 0x3a, 0x14, 0x03, 0xab, 0xef, 0xe8, 0x43, 0x71, 0x5a, 0x88, 0x96, 0x8a, 0x40,
+	0x19, 0x4f, 0x2c, 0x1c, 0xbf, 0x05, 0x68, 0x9b, 0x04, 0xa1, 0xef, 0x5e, 0x64, 0x92, 0x67, 0x0d,
+	0x0a, 0xaf, 0x5c, 0xdf, 0xe0, 0x1b, 0xb1, 0x84, 0x79, 0x83, 0x6e, 0xaa, 0x14, 0x88, 0xc0, 0xbe,
+	0x0d, 0x68, 0xcf, 0xa1, 0x31, 0x25, 0x9b, 0x21, 0xfe, 0x3e, 0x07, 0xab, 0xa9, 0xf1, 0xc2, 0x18,
+	0xf3, 0xef, 0x43, 0x7a, 0x30, 0x8d, 0x02, 0xbe, 0x0f, 0xd1, 0x21, 0x14, 0xf9, 0x08, 0xa1, 0xc9,
+	0xbb, 0x33, 0x00, 0xf1, 0x30, 0x25, 0xe0, 0x04, 0xcc, 0x54, 0xa7, 0x97, 0x3f, 0xac, 0xd3, 0xbf,
+	0x05, 0x25, 0x5a, 0x47, 0xf0, 0x5e, 0xdb, 0x7c, 0x0e, 0xab, 0x86, 0x3b, 0x1c, 0x12, 0x83, 0x7a,
+	0x83, 0x66, 0x39, 0x21, 0xf1, 0xcf, 0xf4, 0xe1, 0xfb, 0xfd, 0x06, 0x8d, 0xb9, 0xf6, 0x04, 0x93,
+	0xfa, 0x12, 0x56, 0x12, 0x13, 0x0b, 0x43, 0x3c, 0x85, 0x42, 0x40, 0x09, 0xc2, 0x12, 0x9f, 0xcc,
+	0x68, 0x89, 0x00, 0x73, 0x76, 0x75, 0x95, 0x83, 0xb7, 0xcf, 0x88, 0x13, 0x2f, 0x4b, 0xdd, 0x86,
+	0x95, 0x2e, 0x73, 0xd3, 0x4c, 0x7e, 0x38, 0x76, 0xf1, 0x5c, 0xca, 0xc5, 0xd7, 0x00, 0x25, 0x51,
+	0x84, 0x23, 0x5e, 0xc0, 0x72, 0xfb, 0x9c, 0x18, 0x99, 0x90, 0x6b, 0xb0, 0x60, 0xb8, 0xb6, 0xad,
+	0x3b, 0x66, 0x2d, 0x77, 0x4b, 0xde, 0x28, 0xe3, 0xa8, 0x99, 0xdc, 0x8b, 0x72, 0xd6, 0xbd, 0xa8,
+	0xfe, 0xad, 0x04, 0xca, 0x78, 0x6e, 0xa1, 0x48, 0x2a, 0x7d, 0x68, 0x52, 0x20, 0x3a, 0xf7, 0x22,
+	0x16, 0x2d, 0x41, 0x8f, 0x8e, 0x0b, 0x4e, 0x27, 0xbe, 0x9f, 0x38, 0x8e, 0xe4, 0x2b, 0x1e, 0x47,
+	0xea, 0x2e, 0xfc, 0x4e, 0x24, 0x4e, 0x37, 0xf4, 0x89, 0x6e, 0x5b, 0x4e, 0x7f, 0xef, 0xf0, 0xd0,
+	0x23, 0x5c, 0x70, 0x84, 0x20, 0x6f, 0xea, 0xa1, 0x2e, 0x04, 0x63, 0xff, 0xe9, 0xa6, 0x37, 0x86,
+	0x6e, 0x10, 0x6f, 0x7a, 0xd6, 0x50, 0xff, 0x53, 0x86, 0xda, 0x04, 0x54, 0xa4, 0xde, 0x97, 0x50,
+	0x08, 0x48, 0x38, 0xf2, 0x84, 0xab, 0xb4, 0x33, 0x0b, 0x3c, 0x1d, 0xaf, 0xd1, 0xa5, 0x60, 0x98,
+	0x63, 0xa2, 0x3e, 0x94, 0xc2, 0xf0, 0x42, 0x0b, 0xac, 0xaf, 0xa2, 0x84, 0x60, 0xff, 0xaa, 0xf8,
+	0x3d, 0xe2, 0xdb, 0x96, 0xa3, 0x0f, 0xbb, 0xd6, 0x57, 0x04, 0x2f, 0x84, 0xe1, 0x05, 0xfd, 0x83,
+	0x5e, 0x50, 0x87, 0x37, 0x2d, 0x47, 0xa8, 0xbd, 0x35, 0xef, 0x2c, 0x09, 0x05, 0x63, 0x8e, 0x58,
+	0xdf, 0x87, 0x02, 0x5b, 0xd3, 0x3c, 0x8e, 0xa8, 0x80, 0x1c, 0x86, 0x17, 0x4c, 0xa8, 0x12, 0xa6,
+	0x7f, 0xeb, 0x0f, 0x61, 0x31, 0xb9, 0x02, 0xea, 0x48, 0x03, 0x62, 0xf5, 0x07, 0xdc, 0xc1, 0x0a,
+	0x58, 0xb4, 0xa8, 0x25, 0xdf, 0x5a, 0xa6, 0x48, 0x59, 0x0b, 0x98, 0x37, 0xd4, 0x7f, 0xcb, 0xc1,
+	0xcd, 0x29, 0x9a, 0x11, 0xce, 0xfa, 0x32, 0xe5, 0xac, 0x1f, 0x48, 0x0b, 0x91, 0xc7, 0xbf, 0x4c,
+	0x79, 0xfc, 0x07, 0x04, 0xa7, 0xdb, 0xe6, 0x3a, 0x14, 0xc9, 0xb9, 0x15, 0x12, 0x53, 0xa8, 0x4a,
+	0xb4, 0x12, 0xdb, 0x29, 0x7f, 0xd5, 0xed, 0xf4, 0x29, 0xac, 0xb5, 0x7c, 0xa2, 0x87, 0x44, 0x1c,
+	0xe5, 0x91, 0xff, 0xdf, 0x84, 0x92, 0x3e, 0x1c, 0xba, 0xc6, 0xd8, 0xac, 0x0b, 0xac, 0xbd, 0x67,
+	0xaa, 0xdf, 0x4a, 0xb0, 0x7e, 0x89, 0x47, 0x68, 0xfa, 0x04, 0xaa, 0x56, 0xe0, 0x0e, 0xd9, 0x22,
+	0xb4, 0xc4, 0x2d, 0xee, 0x57, 0xb3, 0x85, 0x93, 0xbd, 0x08, 0x83, 0x5d, 0xea, 0x96, 0xac, 0x64,
+	0x93, 0x79, 0x15, 0x9b, 0xdc, 0x14, 0xbb, 0x39, 0x6a, 0xaa, 0xff, 0x20, 0xc1, 0xba, 0x88, 0xe2,
+	0x99, 0x17, 0x33, 0x45, 0xe4, 0xdc, 0x87, 0x16, 0x59, 0xad, 0xc1, 0xf5, 0xcb, 0x72, 0x89, 0x73,
+	0xfd, 0x9f, 0x64, 0x40, 0x93, 0x37, 0x48, 0xf4, 0x13, 0x58, 0x0c, 0x88, 0x63, 0x6a, 0x3c, 0x26,
+	0xf0, 0x70, 0x55, 0xc2, 0x15, 0x4a, 0xe3, 0xc1, 0x21, 0xa0, 0xc7, 0x1c, 0x39, 0x17, 0xd2, 0x96,
+	0x30, 0xfb, 0x8f, 0x06, 0xb0, 0xf8, 0x2a, 0xd0, 0xe2, 0xb9, 0x99, 0xd3, 0x54, 0x33, 0x1f, 0x5d,
+	0x93, 0x72, 0x34, 0x9e, 0x76, 0xe3, 0x75, 0xe1, 0xca, 0xab, 0x20, 0x6e, 0xa0, 0x6f, 0x24, 0xb8,
+	0x11, 0xa5, 0x0e, 0x63, 0xf5, 0xd9, 0xae, 0x49, 0x82, 0x5a, 0xfe, 0x96, 0xbc, 0x51, 0xdd, 0x3a,
+	0xba, 0x82, 0xfe, 0x26, 0x88, 0x07, 0xae, 0x49, 0xf0, 0xba, 0x33, 0x85, 0x1a, 0xa0, 0x06, 0xac,
+	0xda, 0xa3, 0x20, 0xd4, 0xb8, 0x17, 0x68, 0x62, 0x50, 0xad, 0xc0, 0xf4, 0xb2, 0x42, 0xbb, 0x52,
+	0xbe, 0xaa, 0x36, 0xa0, 0x92, 0x58, 0x16, 0x2a, 0x41, 0xbe, 0x73, 0xd8, 0x69, 0x2b, 0xd7, 0x10,
+	0x40, 0xb1, 0xb5, 0x8b, 0x0f, 0x0f, 0x7b, 0x3c, 0x13, 0xdf, 0x3b, 0x68, 0xee, 0xb4, 0x95, 0x9c,
+	0xfa, 0x7f, 0x39, 0x58, 0x9b, 0x26, 0x24, 0x32, 0x21, 0x4f, 0x17, 0x2c, 0xae, 0x3f, 0x1f, 0x7e,
+	0xbd, 0x0c, 0x9d, 0xda, 0xd9, 0xd3, 0xc5, 0x79, 0x57, 0xc6, 0xec, 0x3f, 0xd2, 0xa0, 0x38, 0xd4,
+	0x4f, 0xc8, 0x30, 0xa8, 0xc9, 0xac, 0x40, 0xb0, 0x73, 0x95, 0xb9, 0xf7, 0x19, 0x12, 0xaf, 0x0e,
+	0x08, 0xd8, 0xfa, 0x7d, 0xa8, 0x24, 0xc8, 0x53, 0xae, 0xe1, 0x6b, 0xc9, 0x6b, 0x78, 0x39, 0x79,
+	0xa7, 0x7e, 0x0c, 0x6b, 0xd3, 0x56, 0x43, 0xf5, 0xbc, 0x7b, 0xd8, 0xed, 0xf1, 0x0b, 0xcf, 0x0e,
+	0x3e, 0x3c, 0x3e, 0x52, 0x24, 0x4a, 0xec, 0x35, 0xbb, 0xcf, 0x94, 0x5c, 0x6c, 0x06, 0x59, 0xfd,
+	0xd7, 0x05, 0x80, 0xf1, 0x15, 0x14, 0x55, 0x21, 0x17, 0x6f, 0xda, 0x9c, 0x65, 0x52, 0x7d, 0x38,
+	0xba, 0x1d, 0x4d, 0xcc, 0xfe, 0xa3, 0x2d, 0x58, 0xb7, 0x83, 0xbe, 0xa7, 0x1b, 0xa7, 0x9a, 0xb8,
+	0x39, 0x1a, 0x8c, 0x99, 0x6d, 0x80, 0x45, 0xbc, 0x2a, 0x3a, 0x85, 0x83, 0x73, 0xdc, 0x7d, 0x90,
+	0x89, 0x73, 0xc6, 0x9c, 0xb5, 0xb2, 0xf5, 0x60, 0xe6, 0xab, 0x71, 0xa3, 0xed, 0x9c, 0x71, 0x9d,
+	0x51, 0x18, 0xa4, 0x01, 0x98, 0xe4, 0xcc, 0x32, 0x88, 0x46, 0x41, 0x0b, 0x0c, 0xf4, 0xc9, 0xec,
+	0xa0, 0xdb, 0x0c, 0x23, 0x86, 0x2e, 0x9b, 0x51, 0x1b, 0x75, 0xa0, 0xec, 0x93, 0xc0, 0x1d, 0xf9,
+	0x06, 0x09, 0x6a, 0xc5, 0x99, 0xb2, 0x57, 0x1c, 0xf1, 0xe1, 0x31, 0x04, 0xda, 0x86, 0xa2, 0xed,
+	0x8e, 0x9c, 0x30, 0xa8, 0x2d, 0xdc, 0x92, 0x7f, 0xb4, 0xce, 0x96, 0x06, 0x3b, 0xa0, 0x4c, 0x58,
+	0xf0, 0xa2, 0x1d, 0x58, 0xe0, 0x22, 0x06, 0xb5, 0x12, 0x83, 0xb9, 0x9d, 0xf5, 0xac, 0x61, 0x5c,
+	0x38, 0xe2, 0xa6, 0x56, 0x1d, 0x05, 0xc4, 0xaf, 0x95, 0xb9, 0x55, 0xe9, 0x7f, 0xf4, 0x11, 0x94,
+	0xf9, 0xa1, 0x6d, 0x5a, 0x7e, 0x0d, 0x58, 0x07, 0x3f, 0xc5, 0xb7, 0x2d, 0x1f, 0x7d, 0x0c, 0x15,
+	0x1e, 0x80, 0x35, 0xb6, 0x3b, 0x2a, 0xac, 0x1b, 0x38, 0xe9, 0x88, 0xee, 0x11, 0x3e, 0x80, 0xf8,
+	0x3e, 0x1f, 0xb0, 0x18, 0x0f, 0x20, 0xbe, 0xcf, 0x06, 0xfc, 0x3e, 0x2c, 0xb3, 0xb4, 0xa5, 0xef,
+	0xbb, 0x23, 0x4f, 0x63, 0x3e, 0xb5, 0xc4, 0x06, 0x2d, 0x51, 0xf2, 0x0e, 0xa5, 0x76, 0xa8, 0x73,
+	0xdd, 0x84, 0xd2, 0x6b, 0xf7, 0x84, 0x0f, 0xa8, 0xf2, 0xd8, 0xf1, 0xda, 0x3d, 0x89, 0xba, 0xe2,
+	0xb0, 0xb2, 0x9c, 0x0e, 0x2b, 0x6f, 0xe0, 0xfa, 0xe4, 0xf9, 0xc8, 0xc2, 0x8b, 0x72, 0xf5, 0xf0,
+	0xb2, 0xe6, 0x4c, 0xa1, 0xd6, 0xef, 0x40, 0x29, 0xf2, 0x9c, 0x59, 0x76, 0x6c, 0xfd, 0x21, 0x54,
+	0xd3, 0x7e, 0x37, 0xd3, 0x7e, 0xff, 0x2f, 0x09, 0xca, 0xb1, 0x87, 0x21, 0x07, 0x56, 0x99, 0x06,
+	0x68, 0x3c, 0xd6, 0xc6, 0x0e, 0xcb, 0xb3, 0x80, 0x47, 0x19, 0xd7, 0xdc, 0x8c, 0x10, 0xc4, 0x95,
+	0x43, 0x78, 0x2f, 0x8a, 0x91, 0xc7, 0xf3, 0x7d, 0x09, 0xcb, 0x43, 0xcb, 0x19, 0x9d, 0x27, 0xe6,
+	0xe2, 0xe1, 0xfb, 0x0f, 0x32, 0xce, 0xb5, 0x4f, 0xb9, 0xc7, 0x73, 0x54, 0x87, 0xa9, 0xb6, 0xfa,
+	0x6d, 0x0e, 0xae, 0x4f, 0x17, 0x07, 0x75, 0x40, 0x36, 0xbc, 0x91, 0x58, 0xda, 0xc3, 0x59, 0x97,
+	0xd6, 0xf2, 0x46, 0xe3, 0x59, 0x29, 0x10, 0xad, 0xa7, 0xd9, 0xc4, 0x76, 0xfd, 0x0b, 0xb1, 0x82,
+	0xc7, 0xb3, 0x42, 0x1e, 0x30, 0xee, 0x31, 0xaa, 0x80, 0x43, 0x18, 0x4a, 0xc2, 0x5f, 0x02, 0x71,
+	0x32, 0xcd, 0x78, 0xbb, 0x8f, 0x20, 0x71, 0x8c, 0xa3, 0xde, 0x81, 0xf5, 0xa9, 0x4b, 0x41, 0xbf,
+	0x0b, 0x60, 0x78, 0x23, 0x8d, 0x55, 0x5f, 0xb9, 0xdd, 0x65, 0x5c, 0x36, 0xbc, 0x51, 0x97, 0x11,
+	0xd4, 0x97, 0x50, 0x7b, 0x97, 0xbc, 0x74, 0xbf, 0x73, 0x89, 0x35, 0xfb, 0x84, 0xe9, 0x40, 0xc6,
+	0x25, 0x4e, 0x38, 0x38, 0x41, 0x2a, 0x2c, 0x45, 0x9d, 0xfa, 0x39, 0x1d, 0x20, 0xb3, 0x01, 0x15,
+	0x31, 0x40, 0x3f, 0x3f, 0x38, 0x51, 0xbf, 0xcb, 0xc1, 0xf2, 0x25, 0x91, 0x69, 0x06, 0xcd, 0xcf,
+	0x98, 0xe8, 0x6e, 0xc2, 0x5b, 0xf4, 0xc0, 0x31, 0x2c, 0x33, 0xaa, 0x6a, 0xb1, 0xff, 0x2c, 0xd4,
+	0x78, 0xa2, 0xe2, 0x94, 0xb3, 0x3c, 0xea, 0xf4, 0xf6, 0x89, 0x15, 0x06, 0x2c, 0xc9, 0x2e, 0x60,
+	0xde, 0x40, 0x2f, 0xa0, 0xea, 0x93, 0x80, 0xf8, 0x67, 0xc4, 0xd4, 0x3c, 0xd7, 0x0f, 0x23, 0xa5,
+	0x6e, 0xcd, 0xa6, 0xd4, 0x23, 0xd7, 0x0f, 0xf1, 0x52, 0x84, 0x44, 0x5b, 0x01, 0x7a, 0x0e, 0x4b,
+	0xe6, 0x85, 0xa3, 0xdb, 0x96, 0x21, 0x90, 0x8b, 0x73, 0x23, 0x2f, 0x0a, 0x20, 0x06, 0x4c, 0x0b,
+	0xdd, 0x89, 0x4e, 0xba, 0x30, 0x16, 0xe8, 0x85, 0x4e, 0x78, 0x23, 0xbd, 0xc7, 0x0b, 0x62, 0x8f,
+	0xab, 0xff, 0x9c, 0x83, 0x6a, 0x7a, 0x93, 0x44, 0x36, 0xf6, 0x88, 0x6f, 0xb9, 0x66, 0xc2, 0xc6,
+	0x47, 0x8c, 0x40, 0xed, 0x48, 0xbb, 0xdf, 0x8c, 0xdc, 0x50, 0x8f, 0xec, 0x68, 0x78, 0xa3, 0x3f,
+	0xa4, 0xed, 0x4b, 0xfe, 0x21, 0x5f, 0xf2, 0x0f, 0xf4, 0x0b, 0x40, 0xc2, 0xcc, 0x43, 0xcb, 0xb6,
+	0x42, 0xed, 0xe4, 0x22, 0x24, 0x5c, 0xff, 0x32, 0x56, 0x78, 0xcf, 0x3e, 0xed, 0xf8, 0x8c, 0xd2,
+	0xa9, 0x53, 0xb8, 0xae, 0xad, 0x05, 0x86, 0xeb, 0x13, 0x4d, 0x37, 0x5f, 0xb3, 0xa4, 0x4f, 0xc6,
+	0x15, 0xd7, 0xb5, 0xbb, 0x94, 0xd6, 0x34, 0x5f, 0xd3, 0x38, 0x60, 0x78, 0xa3, 0x80, 0x84, 0x1a,
+	0xfd, 0x61, 0xa1, 0xb3, 0x8c, 0x81, 0x93, 0x5a, 0xde, 0x28, 0x48, 0x0c, 0xb0, 0x89, 0x4d, 0xc3,
+	0x61, 0x62, 0xc0, 0x01, 0xb1, 0xe9, 0x2c, 0x8b, 0x47, 0xc4, 0x37, 0x88, 0x13, 0xf6, 0x2c, 0xe3,
+	0x94, 0x46, 0x3a, 0x69, 0x43, 0xc2, 0x29, 0x9a, 0xfa, 0x05, 0x14, 0x58, 0x64, 0xa4, 0x8b, 0x67,
+	0x51, 0x85, 0x05, 0x1d, 0xae, 0xde, 0x12, 0x25, 0xb0, 0x90, 0xf3, 0x11, 0x94, 0x07, 0x6e, 0x20,
+	0x42, 0x16, 0xf7, 0xbc, 0x12, 0x25, 0xb0, 0xce, 0x3a, 0x94, 0x7c, 0xa2, 0x9b, 0xae, 0x33, 0x8c,
+	0x2e, 0xc6, 0x71, 0x5b, 0x7d, 0x03, 0x45, 0x7e, 0x44, 0x5f, 0x01, 0xff, 0x36, 0x20, 0x83, 0xc7,
+	0x3a, 0x8f, 0x5e, 0xb4, 0x83, 0xc0, 0x72, 0x9d, 0x20, 0xfa, 0x1a, 0xc3, 0x7b, 0x8e, 0xc6, 0x1d,
+	0xea, 0x7f, 0x4b, 0x00, 0xe3, 0x3a, 0x39, 0xbd, 0x75, 0x51, 0x4f, 0xa3, 0xb7, 0x0a, 0x7e, 0x21,
+	0x8f, 0x9a, 0xf4, 0x2e, 0x2a, 0xb2, 0xad, 0xdc, 0xbc, 0x9f, 0x19, 0x04, 0x40, 0x54, 0x9e, 0x23,
+	0xe2, 0xe2, 0x32, 0x6b, 0x79, 0x8e, 0xf0, 0xf2, 0x1c, 0xa1, 0xd7, 0x27, 0x91, 0x07, 0x72, 0xb8,
+	0x3c, 0x4b, 0x03, 0x2b, 0x66, 0x5c, 0x03, 0x25, 0xea, 0xff, 0x4a, 0xf1, 0x59, 0x11, 0xd5, 0x2a,
+	0xd1, 0x97, 0x50, 0xa2, 0xdb, 0x4e, 0xb3, 0x75, 0x4f, 0x7c, 0x79, 0x6b, 0xcd, 0x57, 0x06, 0x6d,
+	0xd0, 0x5d, 0x76, 0xa0, 0x7b, 0x3c, 0x8b, 0x5b, 0xf0, 0x78, 0x8b, 0x9e, 0x39, 0xba, 0x39, 0x3e,
+	0x73, 0xe8, 0x7f, 0xf4, 0x53, 0xa8, 0xea, 0xa3, 0xd0, 0xd5, 0x74, 0xf3, 0x8c, 0xf8, 0xa1, 0x15,
+	0x10, 0x61, 0xfb, 0x25, 0x4a, 0x6d, 0x46, 0xc4, 0xfa, 0x03, 0x58, 0x4c, 0x62, 0xbe, 0x2f, 0x42,
+	0x17, 0x92, 0x11, 0xfa, 0x4f, 0x00, 0xc6, 0xf7, 0x7e, 0xea, 0x23, 0xb4, 0x88, 0xa0, 0x19, 0xd1,
+	0xd5, 0xa5, 0x80, 0x4b, 0x94, 0xd0, 0xa2, 0x49, 0x7a, 0xba, 0x28, 0x59, 0x88, 0x8a, 0x92, 0x74,
+	0xd7, 0xd2, 0x8d, 0x76, 0x6a, 0x0d, 0x87, 0x71, 0x2d, 0xa2, 0xec, 0xba, 0xf6, 0x33, 0x46, 0x50,
+	0x7f, 0xc8, 0x71, 0x5f, 0xe1, 0xe5, 0xe5, 0x4c, 0x29, 0xfb, 0x87, 0x32, 0xf5, 0x7d, 0x80, 0x20,
+	0xd4, 0x7d, 0x9a, 0x6e, 0xe8, 0x51, 0x35, 0xa4, 0x3e, 0x51, 0xd5, 0xec, 0x45, 0x5f, 0xc9, 0x71,
+	0x59, 0x8c, 0x6e, 0x86, 0xe8, 0x11, 0x2c, 0x1a, 0xae, 0xed, 0x0d, 0x89, 0x60, 0x2e, 0xbc, 0x97,
+	0xb9, 0x12, 0x8f, 0x6f, 0x86, 0x89, 0x1a, 0x4c, 0xf1, 0xaa, 0x35, 0x98, 0x7f, 0x97, 0x78, 0x95,
+	0x3c, 0x59, 0xa4, 0x47, 0xfd, 0x29, 0x5f, 0x82, 0x77, 0xe6, 0xac, 0xf8, 0xff, 0xd8, 0x67, 0xe0,
+	0xfa, 0xa3, 0x2c, 0xdf, 0x5d, 0xdf, 0x9d, 0x00, 0xfe, 0x87, 0x0c, 0xe5, 0xc8, 0x2c, 0x93, 0xb6,
+	0xbf, 0x07, 0xe5, 0xf8, 0x89, 0x42, 0x2d, 0xf7, 0x5e, 0x0d, 0x8f, 0x07, 0xa3, 0x57, 0x80, 0xf4,
+	0x7e, 0x3f, 0x4e, 0xec, 0xb4, 0x51, 0xa0, 0xf7, 0xa3, 0xcf, 0x13, 0xf7, 0x66, 0xd0, 0x43, 0x14,
+	0xb7, 0x8e, 0x29, 0x3f, 0x56, 0xf4, 0x7e, 0x3f, 0x45, 0x41, 0x7f, 0x0a, 0xeb, 0xe9, 0x39, 0xb4,
+	0x93, 0x0b, 0xcd, 0xb3, 0x4c, 0x71, 0x35, 0xdc, 0x9d, 0xf5, 0x1b, 0x41, 0x23, 0x05, 0xff, 0xd9,
+	0xc5, 0x91, 0x65, 0x72, 0x9d, 0x23, 0x7f, 0xa2, 0xa3, 0xfe, 0xe7, 0x70, 0xe3, 0x1d, 0xc3, 0xa7,
+	0xd8, 0xa0, 0x93, 0xfe, 0xf6, 0x3d, 0xbf, 0x12, 0x12, 0xd6, 0xfb, 0x5e, 0x82, 0x95, 0x89, 0x01,
+	0xa8, 0x99, 0xcc, 0x6d, 0x37, 0x33, 0xce, 0xd3, 0x3a, 0x3a, 0xe6, 0xf0, 0x94, 0x17, 0x7d, 0x7e,
+	0x29, 0x9d, 0xcd, 0x9a, 0xc4, 0xf0, 0xac, 0x90, 0x03, 0x09, 0x04, 0xf5, 0x5f, 0x64, 0x28, 0x45,
+	0xe8, 0xec, 0x62, 0x77, 0x11, 0x84, 0xc4, 0xd6, 0xe2, 0xea, 0x8b, 0x84, 0x81, 0x93, 0x58, 0xa5,
+	0xe1, 0x23, 0x28, 0x8f, 0x02, 0xe2, 0xf3, 0xee, 0x1c, 0xeb, 0x2e, 0x51, 0x02, 0xeb, 0xfc, 0x18,
+	0x2a, 0xa1, 0x1b, 0xea, 0x43, 0x2d, 0x64, 0xb1, 0x5c, 0xe6, 0xdc, 0x8c, 0xc4, 0x22, 0x39, 0xfa,
+	0x39, 0xac, 0x84, 0x03, 0xdf, 0x0d, 0xc3, 0x21, 0xcd, 0xef, 0x58, 0x46, 0xc3, 0x13, 0x90, 0x3c,
+	0x56, 0xe2, 0x0e, 0x9e, 0xe9, 0x04, 0xf4, 0xf4, 0x1e, 0x0f, 0xa6, 0xae, 0xcb, 0x0e, 0x91, 0x3c,
+	0x5e, 0x8a, 0xa9, 0xd4, 0xb5, 0x69, 0xf0, 0xf4, 0x78, 0xb6, 0xc0, 0xce, 0x0a, 0x09, 0x47, 0x4d,
+	0xa4, 0xc1, 0xb2, 0x4d, 0xf4, 0x60, 0xe4, 0x13, 0x53, 0x7b, 0x65, 0x91, 0xa1, 0xc9, 0xef, 0xe3,
+	0xd5, 0xcc, 0x29, 0x7a, 0xa4, 0x96, 0xc6, 0x53, 0xc6, 0x8d, 0xab, 0x11, 0x1c, 0x6f, 0xd3, 0xcc,
+	0x81, 0xff, 0x43, 0xcb, 0x50, 0xe9, 0xbe, 0xe8, 0xf6, 0xda, 0x07, 0xda, 0xc1, 0xe1, 0x76, 0x5b,
+	0x3c, 0x6f, 0xe8, 0xb6, 0x31, 0x6f, 0x4a, 0xb4, 0xbf, 0x77, 0xd8, 0x6b, 0xee, 0x6b, 0xbd, 0xbd,
+	0xd6, 0xb3, 0xae, 0x92, 0x43, 0xeb, 0xb0, 0xd2, 0xdb, 0xc5, 0x87, 0xbd, 0xde, 0x7e, 0x7b, 0x5b,
+	0x3b, 0x6a, 0xe3, 0xbd, 0xc3, 0xed, 0xae, 0x22, 0x23, 0x04, 0xd5, 0x31, 0xb9, 0xb7, 0x77, 0xd0,
+	0x56, 0xf2, 0xf4, 0x83, 0xf6, 0x51, 0x1b, 0xb7, 0xda, 0x9d, 0x9e, 0x52, 0x50, 0xbf, 0x93, 0xa1,
+	0x92, 0xb0, 0x22, 0x75, 0x64, 0x3f, 0xe0, 0x77, 0x81, 0x3c, 0xa6, 0x7f, 0xd9, 0xe7, 0x18, 0xdd,
+	0x18, 0x70, 0xeb, 0xe4, 0x31, 0x6f, 0xb0, 0xfc, 0x5f, 0x3f, 0x4f, 0xec, 0xf3, 0x3c, 0x2e, 0xd9,
+	0xfa, 0x39, 0x07, 0xf9, 0x09, 0x2c, 0x9e, 0x12, 0xdf, 0x21, 0x43, 0xd1, 0xcf, 0x2d, 0x52, 0xe1,
+	0x34, 0x3e, 0x64, 0x03, 0x14, 0x31, 0x64, 0x0c, 0xc3, 0xcd, 0x51, 0xe5, 0xf4, 0x83, 0x08, 0x6c,
+	0x0d, 0x0a, 0xbc, 0x7b, 0x81, 0xcf, 0xcf, 0x1a, 0x34, 0x4c, 0x05, 0x6f, 0x75, 0x8f, 0xe5, 0x77,
+	0x79, 0xcc, 0xfe, 0xa3, 0x93, 0x49, 0xfb, 0x14, 0x99, 0x7d, 0xee, 0xcf, 0xee, 0xce, 0xef, 0x32,
+	0xd1, 0x20, 0x36, 0xd1, 0x02, 0xc8, 0x38, 0x7a, 0x13, 0xd0, 0x6a, 0xb6, 0x76, 0xa9, 0x59, 0x96,
+	0xa0, 0x7c, 0xd0, 0xfc, 0x8d, 0x76, 0xdc, 0x65, 0xd5, 0x48, 0xa4, 0xc0, 0xe2, 0xb3, 0x36, 0xee,
+	0xb4, 0xf7, 0x05, 0x45, 0x46, 0x6b, 0xa0, 0x08, 0xca, 0x78, 0x5c, 0x9e, 0x22, 0xf0, 0xbf, 0x05,
+	0x5a, 0x5a, 0xeb, 0x3e, 0x6f, 0x1e, 0x29, 0x45, 0xf5, 0x7f, 0x72, 0xb0, 0xcc, 0xc3, 0x42, 0xfc,
+	0xf5, 0xf2, 0xdd, 0x5f, 0x6f, 0x92, 0xc5, 0x8d, 0x5c, 0xba, 0xb8, 0x11, 0x25, 0xa1, 0x2c, 0xaa,
+	0xcb, 0xe3, 0x24, 0x94, 0x15, 0x45, 0x52, 0x27, 0x7e, 0x7e, 0x96, 0x13, 0xbf, 0x06, 0x0b, 0x36,
+	0x09, 0x62, 0xbb, 0x95, 0x71, 0xd4, 0x44, 0x16, 0x54, 0x74, 0xc7, 0x71, 0x43, 0x56, 0xec, 0x88,
+	0xae, 0x45, 0x3b, 0x33, 0xd5, 0xb5, 0xe3, 0x15, 0x37, 0x9a, 0x63, 0x24, 0x7e, 0x30, 0x27, 0xb1,
+	0xeb, 0xbf, 0x06, 0xe5, 0xf2, 0x80, 0x59, 0xc2, 0xe1, 0xcf, 0x3e, 0x1d, 0x47, 0x43, 0x42, 0xf7,
+	0xc5, 0x71, 0xe7, 0x59, 0xe7, 0xf0, 0x79, 0x47, 0xb9, 0x46, 0x1b, 0xf8, 0xb8, 0xd3, 0xd9, 0xeb,
+	0xec, 0x28, 0x12, 0x2d, 0x36, 0xb7, 0x7f, 0xb3, 0x47, 0xdf, 0x19, 0xe5, 0xb6, 0xbe, 0x5f, 0x81,
+	0x22, 0x17, 0x12, 0x7d, 0x2b, 0x32, 0x81, 0xe4, 0xcb, 0x38, 0xf4, 0xeb, 0x99, 0x33, 0xea, 0xd4,
+	0x6b, 0xbb, 0xfa, 0xe3, 0xb9, 0xf9, 0xc5, 0x57, 0x8a, 0x6b, 0xe8, 0xaf, 0x25, 0x58, 0x4c, 0x7d,
+	0xa1, 0xc8, 0x5a, 0x31, 0x9d, 0xf2, 0x10, 0xaf, 0xfe, 0xab, 0xb9, 0x78, 0x63, 0x59, 0xbe, 0x91,
+	0xa0, 0x92, 0x78, 0x82, 0x86, 0xee, 0xcf, 0xf3, 0x6c, 0x8d, 0x4b, 0xf2, 0x60, 0xfe, 0x17, 0x6f,
+	0xea, 0xb5, 0x4f, 0x24, 0xf4, 0x57, 0x12, 0x54, 0x12, 0x8f, 0xb1, 0x32, 0x8b, 0x32, 0xf9, 0x74,
+	0xac, 0xfe, 0x60, 0x1e, 0xd6, 0x58, 0x27, 0x7f, 0x21, 0x41, 0x39, 0x7e, 0x58, 0x85, 0xee, 0xce,
+	0xfe, 0x14, 0x8b, 0x0b, 0x71, 0x6f, 0xde, 0x37, 0x5c, 0xea, 0x35, 0xf4, 0x67, 0x50, 0x8a, 0x5e,
+	0x21, 0xa1, 0xac, 0xd1, 0xeb, 0xd2, 0x13, 0xa7, 0xfa, 0xdd, 0x99, 0xf9, 0x92, 0xd3, 0x47, 0x4f,
+	0x83, 0x32, 0x4f, 0x7f, 0xe9, 0x11, 0x53, 0xfd, 0xee, 0xcc, 0x7c, 0xf1, 0xf4, 0xd4, 0x13, 0x12,
+	0x2f, 0x88, 0x32, 0x7b, 0xc2, 0xe4, 0xd3, 0xa5, 0xfa, 0x83, 0x79, 0x58, 0x53, 0x82, 0x24, 0xde,
+	0x20, 0x65, 0x16, 0x64, 0xf2, 0x9d, 0x53, 0xfd, 0xc1, 0x3c, 0xac, 0xb1, 0x20, 0x5f, 0x4b, 0xc9,
+	0x7b, 0xc1, 0xdd, 0x99, 0x9f, 0xda, 0xcc, 0xe8, 0x92, 0x13, 0x8f, 0x7d, 0xd8, 0x06, 0xfd, 0x5a,
+	0x54, 0x31, 0xf8, 0x4b, 0x1d, 0x34, 0x0b, 0x58, 0xea, 0x71, 0x4f, 0xfd, 0xce, 0x7c, 0xc1, 0x86,
+	0x09, 0xf1, 0x97, 0x12, 0xc0, 0xf8, 0x4d, 0x4f, 0x66, 0x21, 0x26, 0x1e, 0x13, 0xd5, 0xef, 0xcf,
+	0xc1, 0x99, 0xdc, 0x20, 0xd1, 0x9b, 0x83, 0xcc, 0x1b, 0xe4, 0xd2, 0x9b, 0xa3, 0xfa, 0xdd, 0x99,
+	0xf9, 0xe2, 0xe9, 0xff, 0x51, 0x82, 0x95, 0x89, 0x37, 0x0f, 0xe8, 0xf1, 0x15, 0x9f, 0xbd, 0xd4,
+	0x9f, 0xcc, 0x0f, 0x10, 0x89, 0xb6, 0x21, 0x7d, 0x22, 0xa1, 0xbf, 0x91, 0x60, 0x29, 0xf5, 0x9d,
+	0x18, 0x65, 0x8e, 0x52, 0x53, 0x5e, 0x4f, 0xd4, 0x1f, 0xce, 0xc7, 0x1c, 0x6b, 0xeb, 0xef, 0x24,
+	0xa8, 0x8a, 0xfd, 0x1d, 0xc9, 0xf3, 0x70, 0xb6, 0x63, 0xe1, 0x92, 0x40, 0x8f, 0xe6, 0xe4, 0x8e,
+	0x24, 0xfa, 0x6c, 0xe1, 0x8f, 0x0a, 0x3c, 0x7b, 0x2b, 0xb2, 0x9f, 0x5f, 0xfe, 0x76, 0x00, 0x76,
+	0x3f, 0xc0, 0xd0, 0xf6, 0x30, 0x00, 0x00,
 }
@@ -42,6 +42,10 @@ type Context interface {
 	// Eligibility returns a tracker for node eligibility in the context of the
 	// eval.
 	Eligibility() *EvalEligibility
+
+	// Explain returns whether the placement decisions should be explained per
+	// node.
+	Explain() bool
 }
 
 // EvalCache is used to cache certain things during an evaluation
@@ -80,6 +84,7 @@ type EvalContext struct {
 	logger      log.Logger
 	metrics     *structs.AllocMetric
 	eligibility *EvalEligibility
+	explain     bool
 }
 
 // NewEvalContext constructs a new EvalContext
@@ -115,6 +120,22 @@ func (e *EvalContext) SetState(s State) {
 
 func (e *EvalContext) Reset() {
 	e.metrics = new(structs.AllocMetric)
+	if e.explain {
+		e.metrics.ExplainNodes()
+	}
+}
+
+func (e *EvalContext) Explain() bool {
+	return e.explain
+}
+
+// SetExplain toggles recording a per node explanation of the placement
+// decisions in the metrics.
+func (e *EvalContext) SetExplain(explain bool) {
+	e.explain = explain
+	if explain {
+		e.metrics.ExplainNodes()
+	}
 }
 
 func (e *EvalContext) ProposedAllocs(nodeID string) ([]*structs.Allocation, error) {
@@ -819,7 +819,13 @@ OUTER:
 
 		// Check if the job has been marked as eligible or ineligible.
 		jobEscaped, jobUnknown := false, false
-		switch evalElig.JobStatus(option.ComputedClass) {
+		jobStatus := evalElig.JobStatus(option.ComputedClass)
+		if w.ctx.Explain() {
+			// Run every check when explaining so each node records the
+			// reason it was filtered rather than its computed class'.
+			jobStatus = EvalComputedClassEscaped
+		}
+		switch jobStatus {
 		case EvalComputedClassIneligible:
 			// Fast path the ineligible case
 			metrics.FilterNode(option, "computed class ineligible")
@@ -851,7 +857,11 @@ OUTER:
 
 		// Check if the task group has been marked as eligible or ineligible.
 		tgEscaped, tgUnknown := false, false
-		switch evalElig.TaskGroupStatus(w.tg, option.ComputedClass) {
+		tgStatus := evalElig.TaskGroupStatus(w.tg, option.ComputedClass)
+		if w.ctx.Explain() {
+			tgStatus = EvalComputedClassEscaped
+		}
+		switch tgStatus {
 		case EvalComputedClassIneligible:
 			// Fast path the ineligible case
 			metrics.FilterNode(option, "computed class ineligible")
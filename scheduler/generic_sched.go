@@ -42,6 +42,10 @@ const (
 	// allocRescheduled is the status used when an allocation failed and was rescheduled
 	allocRescheduled = "alloc was rescheduled because it failed"
 
+	// allocReconnected is the status used when stopping one of the duplicate
+	// allocations left behind by a client that reconnected
+	allocReconnected = "alloc not needed due to disconnected client reconnect"
+
 	// blockedEvalMaxPlanDesc is the description used for blocked evals that are
 	// a result of hitting the max number of plan attempts
 	blockedEvalMaxPlanDesc = "created due to placement conflicts"
@@ -54,6 +58,10 @@ const (
 	// up evals for delayed rescheduling
 	reschedulingFollowupEvalDesc = "created for delayed rescheduling"
 
+	// disconnectTimeoutFollowupEvalDesc is the description used when creating
+	// follow up evals for allocations of disconnected clients
+	disconnectTimeoutFollowupEvalDesc = "created for delayed disconnect timeout"
+
 	// maxPastRescheduleEvents is the maximum number of past reschedule event
 	// that we track when unlimited rescheduling is enabled
 	maxPastRescheduleEvents = 5
@@ -242,6 +250,7 @@ func (s *GenericScheduler) process() (bool, error) {
 
 	// Create an evaluation context
 	s.ctx = NewEvalContext(s.state, s.plan, s.logger)
+	s.ctx.SetExplain(s.eval.ExplainPlacement)
 
 	// Construct the placement stack
 	s.stack = NewGenericStack(s.batch, s.ctx)
@@ -387,6 +396,11 @@ func (s *GenericScheduler) computeJobAllocs() error {
 		s.ctx.Plan().AppendAlloc(update)
 	}
 
+	// Mark the allocations of disconnected clients as unknown
+	for _, update := range results.disconnectUpdates {
+		s.ctx.Plan().AppendUnknownAlloc(update)
+	}
+
 	// Nothing remaining to do if placement is not required
 	if len(results.place)+len(results.destructiveUpdate) == 0 {
 		// If the job has been purged we don't have access to the job. Otherwise
@@ -576,6 +590,12 @@ func getSelectOptions(prevAllocation *structs.Allocation, preferredNode *structs
 		if prevAllocation.ClientStatus == structs.AllocClientStatusFailed {
 			penaltyNodes[prevAllocation.NodeID] = struct{}{}
 		}
+
+		// If alloc is migrating, penalize the node it is migrating away
+		// from so it is not placed back on it.
+		if prevAllocation.DesiredTransition.ShouldMigrate() {
+			penaltyNodes[prevAllocation.NodeID] = struct{}{}
+		}
 		if prevAllocation.RescheduleTracker != nil {
 			for _, reschedEvent := range prevAllocation.RescheduleTracker.Events {
 				penaltyNodes[reschedEvent.PrevNodeID] = struct{}{}
@@ -4,12 +4,54 @@ package scheduler
 
 import "github.com/hashicorp/nomad/nomad/structs"
 
-// selectNextOption calls the stack to get a node for placement
+// selectNextOption calls the stack to get a node for placement. If no node
+// fits and preemption is enabled for the job's scheduler, the stack is run
+// again allowing lower priority allocations to be evicted.
 func (s *GenericScheduler) selectNextOption(tg *structs.TaskGroup, selectOptions *SelectOptions) *RankedNode {
+	option := s.stack.Select(tg, selectOptions)
+	if option != nil || !s.preemptionEnabled() {
+		return option
+	}
+
+	// Run the stack again with preemption enabled
+	selectOptions.Preempt = true
 	return s.stack.Select(tg, selectOptions)
 }
 
-// handlePreemptions sets relevant preeemption related fields. In OSS this is a no op.
+// preemptionEnabled returns whether the scheduler configuration allows
+// preemption for the type of job being scheduled.
+func (s *GenericScheduler) preemptionEnabled() bool {
+	_, schedConfig, err := s.ctx.State().SchedulerConfig()
+	if err != nil || schedConfig == nil {
+		return false
+	}
+
+	if s.batch {
+		return schedConfig.PreemptionConfig.BatchSchedulerEnabled
+	}
+	return schedConfig.PreemptionConfig.ServiceSchedulerEnabled
+}
+
+// handlePreemptions sets relevant preeemption related fields.
 func (s *GenericScheduler) handlePreemptions(option *RankedNode, alloc *structs.Allocation, missing placementResult) {
+	if option.PreemptedAllocs == nil {
+		return
+	}
+
+	// If this placement involves preemption, set DesiredState to evict for
+	// those allocations
+	var preemptedAllocIDs []string
+	for _, stop := range option.PreemptedAllocs {
+		s.plan.AppendPreemptedAlloc(stop, alloc.ID)
+		preemptedAllocIDs = append(preemptedAllocIDs, stop.ID)
+
+		if s.eval.AnnotatePlan && s.plan.Annotations != nil {
+			s.plan.Annotations.PreemptedAllocs = append(s.plan.Annotations.PreemptedAllocs, stop.Stub())
+			if desired, ok := s.plan.Annotations.DesiredTGUpdates[missing.TaskGroup().Name]; ok {
+				desired.Preemptions += 1
+			}
+		}
+	}
 
+	alloc.PreemptedAllocations = preemptedAllocIDs
 }
@@ -705,6 +705,56 @@ func TestServiceSched_Spread(t *testing.T) {
 	}
 }
 
+// Test job registration with datacenter preferences filling the preferred
+// datacenter before spilling over into the next one
+func TestServiceSched_DatacenterPreference(t *testing.T) {
+	require := require.New(t)
+	h := NewHarness(t)
+
+	// Create a single node in dc1 with room for 7 allocations, and nodes in
+	// dc2 and dc3
+	nodeDCs := make(map[string]string)
+	for i, dc := range []string{"dc1", "dc2", "dc2", "dc2", "dc3", "dc3", "dc3"} {
+		node := mock.Node()
+		node.Name = fmt.Sprintf("%s-%d", dc, i)
+		node.Datacenter = dc
+		require.NoError(h.State.UpsertNode(h.NextIndex(), node))
+		nodeDCs[node.ID] = dc
+	}
+
+	job := mock.Job()
+	job.Datacenters = []string{"dc1", "dc2", "dc3"}
+	job.DatacenterPreferences = []*structs.DatacenterPreference{
+		{Datacenter: "dc1", Weight: 100},
+		{Datacenter: "dc2", Weight: 50},
+	}
+	require.NoError(h.State.UpsertJob(h.NextIndex(), job))
+
+	// Create a mock evaluation to register the job
+	eval := &structs.Evaluation{
+		Namespace:   structs.DefaultNamespace,
+		ID:          uuid.Generate(),
+		Priority:    job.Priority,
+		TriggeredBy: structs.EvalTriggerJobRegister,
+		JobID:       job.ID,
+		Status:      structs.EvalStatusPending,
+	}
+	require.NoError(h.State.UpsertEvals(h.NextIndex(), []*structs.Evaluation{eval}))
+
+	// Process the evaluation
+	require.NoError(h.Process(NewServiceScheduler, eval))
+	require.Len(h.Plans, 1)
+
+	// dc1 is filled and the remaining allocations are placed in dc2
+	dcAllocs := make(map[string]int)
+	for nodeID, allocs := range h.Plans[0].NodeAllocation {
+		dcAllocs[nodeDCs[nodeID]] += len(allocs)
+	}
+	require.Equal(map[string]int{"dc1": 7, "dc2": 3}, dcAllocs)
+
+	h.AssertEvalStatus(t, structs.EvalStatusComplete)
+}
+
 // Test job registration with even spread across dc
 func TestServiceSched_EvenSpread(t *testing.T) {
 	assert := assert.New(t)
@@ -858,6 +908,58 @@ func TestServiceSched_JobRegister_Annotate(t *testing.T) {
 	}
 }
 
+func TestServiceSched_JobRegister_ExplainPlacement(t *testing.T) {
+	h := NewHarness(t)
+
+	// Create a feasible node, an exhausted node and an infeasible node
+	feasible := mock.Node()
+	exhausted := mock.Node()
+	exhausted.NodeResources.Cpu.CpuShares = 100
+	infeasible := mock.Node()
+	infeasible.Attributes["kernel.name"] = "windows"
+	infeasible.ComputeClass()
+	for _, node := range []*structs.Node{feasible, exhausted, infeasible} {
+		require.NoError(t, h.State.UpsertNode(h.NextIndex(), node))
+	}
+
+	// Create a job
+	job := mock.Job()
+	job.TaskGroups[0].Count = 1
+	require.NoError(t, h.State.UpsertJob(h.NextIndex(), job))
+
+	// Create a mock evaluation that explains the placements
+	eval := &structs.Evaluation{
+		Namespace:        structs.DefaultNamespace,
+		ID:               uuid.Generate(),
+		Priority:         job.Priority,
+		TriggeredBy:      structs.EvalTriggerJobRegister,
+		JobID:            job.ID,
+		Status:           structs.EvalStatusPending,
+		ExplainPlacement: true,
+	}
+	require.NoError(t, h.State.UpsertEvals(h.NextIndex(), []*structs.Evaluation{eval}))
+
+	// Process the evaluation
+	require.NoError(t, h.Process(NewServiceScheduler, eval))
+	require.Len(t, h.Plans, 1)
+
+	// Ensure the placement explains every node
+	var planned []*structs.Allocation
+	for _, allocList := range h.Plans[0].NodeAllocation {
+		planned = append(planned, allocList...)
+	}
+	require.Len(t, planned, 1)
+	require.Equal(t, feasible.ID, planned[0].NodeID)
+
+	explanations := planned[0].Metrics.NodeExplanations
+	require.Len(t, explanations, 3)
+	require.Empty(t, explanations[feasible.ID].Filtered)
+	require.Empty(t, explanations[feasible.ID].Exhausted)
+	require.Contains(t, explanations[feasible.ID].Scores, "binpack")
+	require.Equal(t, "cpu", explanations[exhausted.ID].Exhausted)
+	require.Equal(t, "${attr.kernel.name} = linux", explanations[infeasible.ID].Filtered)
+}
+
 func TestServiceSched_JobRegister_CountZero(t *testing.T) {
 	h := NewHarness(t)
 
@@ -4858,3 +4960,108 @@ func Test_updateRescheduleTracker(t *testing.T) {
 	}
 
 }
+
+func TestServiceSched_Preemption(t *testing.T) {
+	cases := []struct {
+		name    string
+		enabled bool
+	}{
+		{
+			name:    "preemption disabled",
+			enabled: false,
+		},
+		{
+			name:    "preemption enabled",
+			enabled: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			require := require.New(t)
+			h := NewHarness(t)
+
+			// Create a node
+			node := mock.Node()
+			require.NoError(h.State.UpsertNode(h.NextIndex(), node))
+
+			// Configure preemption for the service scheduler
+			require.NoError(h.State.SchedulerSetConfig(h.NextIndex(), &structs.SchedulerConfiguration{
+				PreemptionConfig: structs.PreemptionConfig{
+					ServiceSchedulerEnabled: tc.enabled,
+				},
+			}))
+
+			// Create a low priority batch job using most of the node
+			lowJob := mock.BatchJob()
+			lowJob.Priority = 20
+			require.NoError(h.State.UpsertJob(h.NextIndex(), lowJob))
+
+			lowAlloc := mock.Alloc()
+			lowAlloc.Job = lowJob
+			lowAlloc.JobID = lowJob.ID
+			lowAlloc.NodeID = node.ID
+			lowAlloc.TaskGroup = lowJob.TaskGroups[0].Name
+			lowAlloc.AllocatedResources = &structs.AllocatedResources{
+				Tasks: map[string]*structs.AllocatedTaskResources{
+					"web": {
+						Cpu: structs.AllocatedCpuResources{
+							CpuShares: 3500,
+						},
+						Memory: structs.AllocatedMemoryResources{
+							MemoryMB: 7000,
+						},
+					},
+				},
+			}
+			require.NoError(h.State.UpsertAllocs(h.NextIndex(), []*structs.Allocation{lowAlloc}))
+
+			// Create a high priority service job that doesn't fit
+			job := mock.Job()
+			job.Priority = 70
+			job.TaskGroups[0].Count = 1
+			require.NoError(h.State.UpsertJob(h.NextIndex(), job))
+
+			eval := &structs.Evaluation{
+				Namespace:    structs.DefaultNamespace,
+				ID:           uuid.Generate(),
+				Priority:     job.Priority,
+				TriggeredBy:  structs.EvalTriggerJobRegister,
+				JobID:        job.ID,
+				Status:       structs.EvalStatusPending,
+				AnnotatePlan: true,
+			}
+			require.NoError(h.State.UpsertEvals(h.NextIndex(), []*structs.Evaluation{eval}))
+
+			// Process the evaluation
+			require.NoError(h.Process(NewServiceScheduler, eval))
+
+			require.Len(h.Plans, 1)
+			plan := h.Plans[0]
+
+			if !tc.enabled {
+				// The placement fails and nothing is preempted
+				require.Empty(plan.NodeAllocation)
+				require.Empty(plan.NodePreemptions)
+				require.Len(h.Evals, 1)
+				require.Contains(h.Evals[0].FailedTGAllocs, job.TaskGroups[0].Name)
+				return
+			}
+
+			// Ensure the low priority allocation was preempted
+			require.Len(plan.NodePreemptions[node.ID], 1)
+			require.Equal(lowAlloc.ID, plan.NodePreemptions[node.ID][0].ID)
+
+			// Ensure the new allocation records the preemption
+			require.Len(plan.NodeAllocation[node.ID], 1)
+			newAlloc := plan.NodeAllocation[node.ID][0]
+			require.Equal([]string{lowAlloc.ID}, newAlloc.PreemptedAllocations)
+
+			// Ensure the plan is annotated with the preemption
+			require.NotNil(plan.Annotations)
+			require.Len(plan.Annotations.PreemptedAllocs, 1)
+			require.Equal(lowAlloc.ID, plan.Annotations.PreemptedAllocs[0].ID)
+			require.EqualValues(1, plan.Annotations.DesiredTGUpdates[job.TaskGroups[0].Name].Preemptions)
+		})
+	}
+}
@@ -3,6 +3,7 @@ package scheduler
 import (
 	"fmt"
 	"strconv"
+	"strings"
 
 	log "github.com/hashicorp/go-hclog"
 	memdb "github.com/hashicorp/go-memdb"
@@ -10,6 +11,10 @@ import (
 	"github.com/hashicorp/nomad/nomad/structs"
 )
 
+// propertyPathSeparator separates the values of the levels of a nested spread
+// in the values tracked by a property set.
+const propertyPathSeparator = "/"
+
 // propertySet is used to track the values used for a particular property.
 type propertySet struct {
 	// ctx is used to lookup the plan and state
@@ -30,6 +35,11 @@ type propertySet struct {
 	// targetAttribute is the attribute this property set is checking
 	targetAttribute string
 
+	// parentAttributes are the attributes of the enclosing levels of a nested
+	// spread. When set, the tracked value is the path of the parent values
+	// followed by the value of the target attribute.
+	parentAttributes []string
+
 	// allowedCount is the allowed number of allocations that can have the
 	// distinct property
 	allowedCount uint64
@@ -103,6 +113,15 @@ func (p *propertySet) SetTargetAttribute(targetAttribute string, taskGroup strin
 	p.setTargetAttributeWithCount(targetAttribute, 0, taskGroup)
 }
 
+// SetNestedTargetAttribute is used to populate this property set for a level
+// of a nested spread. Values are tracked by their path within the hierarchy
+// formed by the parent attributes so that equal values under different
+// parents are counted separately.
+func (p *propertySet) SetNestedTargetAttribute(parentAttributes []string, targetAttribute string, taskGroup string) {
+	p.parentAttributes = parentAttributes
+	p.setTargetAttributeWithCount(targetAttribute, 0, taskGroup)
+}
+
 // setTargetAttributeWithCount is a shared helper for setting a job or task group attribute and allowedCount
 // allowedCount can be zero when this is used in evaluating spread stanzas
 func (p *propertySet) setTargetAttributeWithCount(targetAttribute string, allowedCount uint64, taskGroup string) {
@@ -235,7 +254,7 @@ func (p *propertySet) UsedCount(option *structs.Node, tg string) (string, string
 	}
 
 	// Get the nodes property value
-	nValue, ok := getProperty(option, p.targetAttribute)
+	nValue, ok := p.nodeProperty(option)
 	if !ok {
 		return nValue, fmt.Sprintf("missing property %q", p.targetAttribute), 0
 	}
@@ -327,7 +346,7 @@ func (p *propertySet) populateProperties(allocs []*structs.Allocation, nodes map
 	properties map[string]uint64) {
 
 	for _, alloc := range allocs {
-		nProperty, ok := getProperty(nodes[alloc.NodeID], p.targetAttribute)
+		nProperty, ok := p.nodeProperty(nodes[alloc.NodeID])
 		if !ok {
 			continue
 		}
@@ -336,6 +355,31 @@ func (p *propertySet) populateProperties(allocs []*structs.Allocation, nodes map
 	}
 }
 
+// nodeProperty returns the value tracked by the property set for the node. It
+// is the value of the target attribute, prefixed by the values of any parent
+// attributes joined by the propertyPathSeparator.
+func (p *propertySet) nodeProperty(n *structs.Node) (string, bool) {
+	if len(p.parentAttributes) == 0 {
+		return getProperty(n, p.targetAttribute)
+	}
+
+	values := make([]string, 0, len(p.parentAttributes)+1)
+	for _, attr := range p.parentAttributes {
+		value, ok := getProperty(n, attr)
+		if !ok {
+			return "", false
+		}
+		values = append(values, value)
+	}
+
+	value, ok := getProperty(n, p.targetAttribute)
+	if !ok {
+		return "", false
+	}
+	values = append(values, value)
+	return strings.Join(values, propertyPathSeparator), true
+}
+
 // getProperty is used to lookup the property value on the node
 func getProperty(n *structs.Node, property string) (string, bool) {
 	if n == nil || property == "" {
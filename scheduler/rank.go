@@ -3,6 +3,8 @@ package scheduler
 import (
 	"fmt"
 	"math"
+	"sort"
+	"strings"
 
 	"github.com/hashicorp/nomad/nomad/structs"
 )
@@ -150,23 +152,56 @@ type BinPackIterator struct {
 	priority  int
 	jobId     *structs.NamespacedID
 	taskGroup *structs.TaskGroup
+	scoreFit  func(*structs.Node, *structs.ComparableResources) float64
+
+	// algorithm is the scheduler algorithm of the scheduler configuration,
+	// which jobs may override
+	algorithm structs.SchedulerAlgorithm
+
+	// memoryOversubscription allows tasks to set a memory limit above
+	// their reservation
+	memoryOversubscription bool
 }
 
 // NewBinPackIterator returns a BinPackIterator which tries to fit tasks
-// potentially evicting other tasks based on a given priority.
-func NewBinPackIterator(ctx Context, source RankIterator, evict bool, priority int) *BinPackIterator {
+// potentially evicting other tasks based on a given priority. The scheduler
+// algorithm of the configuration determines whether nodes are packed tightly
+// or allocations are spread across the least utilized nodes.
+func NewBinPackIterator(ctx Context, source RankIterator, evict bool, priority int, schedConfig *structs.SchedulerConfiguration) *BinPackIterator {
+	algorithm := schedConfig.EffectiveSchedulerAlgorithm()
 	iter := &BinPackIterator{
-		ctx:      ctx,
-		source:   source,
-		evict:    evict,
-		priority: priority,
+		ctx:       ctx,
+		source:    source,
+		evict:     evict,
+		priority:  priority,
+		scoreFit:  scoreFitFor(algorithm),
+		algorithm: algorithm,
+	}
+	if schedConfig != nil {
+		iter.memoryOversubscription = schedConfig.MemoryOversubscriptionEnabled
 	}
 	return iter
 }
 
+// scoreFitFor returns the function scoring the fit of a node for the scheduler
+// algorithm.
+func scoreFitFor(algorithm structs.SchedulerAlgorithm) func(*structs.Node, *structs.ComparableResources) float64 {
+	if algorithm == structs.SchedulerAlgorithmSpread {
+		return structs.ScoreFitSpread
+	}
+	return structs.ScoreFitBinPack
+}
+
 func (iter *BinPackIterator) SetJob(job *structs.Job) {
 	iter.priority = job.Priority
 	iter.jobId = job.NamespacedID()
+
+	// The scheduler algorithm of the job overrides that of the cluster
+	algorithm := iter.algorithm
+	if job.SchedulerAlgorithm != "" {
+		algorithm = job.SchedulerAlgorithm
+	}
+	iter.scoreFit = scoreFitFor(algorithm)
 }
 
 func (iter *BinPackIterator) SetTaskGroup(taskGroup *structs.TaskGroup) {
@@ -288,6 +323,23 @@ OUTER:
 					MemoryMB: int64(task.Resources.MemoryMB),
 				},
 			}
+			if iter.memoryOversubscription {
+				taskResources.Memory.MemoryMaxMB = int64(task.Resources.MemoryMaxMB)
+			}
+
+			// Check if we need to reserve CPU cores
+			if task.Resources.Cores > 0 {
+				cores := selectFreeCores(option.Node, proposed, total, task.Resources.Cores)
+				if cores == nil {
+					iter.ctx.Metrics().ExhaustedNode(option.Node, "cores")
+					netIdx.Release()
+					continue OUTER
+				}
+
+				// The task is given the full shares of its reserved cores
+				taskResources.Cpu.ReservedCores = cores
+				taskResources.Cpu.CpuShares = int64(len(cores)) * option.Node.NodeResources.Cpu.SharesPerCore()
+			}
 
 			// Check if we need a network resource
 			if len(task.Resources.Networks) > 0 {
@@ -430,7 +482,7 @@ OUTER:
 		}
 
 		// Score the fit normally otherwise
-		fitness := structs.ScoreFit(option.Node, util)
+		fitness := iter.scoreFit(option.Node, util)
 		normalizedFit := fitness / binPackingMaxFitScore
 		option.Scores = append(option.Scores, normalizedFit)
 		iter.ctx.Metrics().ScoreNode(option.Node, "binpack", normalizedFit)
@@ -450,6 +502,46 @@ func (iter *BinPackIterator) Reset() {
 	iter.source.Reset()
 }
 
+// selectFreeCores returns the lowest numbered cores of the node that are not
+// reserved by the proposed allocations or by the tasks already placed in
+// total. If fewer than count cores are free, nil is returned.
+func selectFreeCores(node *structs.Node, proposed []*structs.Allocation,
+	total *structs.AllocatedResources, count int) []uint16 {
+	if node.NodeResources == nil {
+		return nil
+	}
+
+	used := make(map[uint16]struct{})
+	for _, alloc := range proposed {
+		if alloc.TerminalStatus() || alloc.AllocatedResources == nil {
+			continue
+		}
+		for _, tr := range alloc.AllocatedResources.Tasks {
+			for _, core := range tr.Cpu.ReservedCores {
+				used[core] = struct{}{}
+			}
+		}
+	}
+	for _, tr := range total.Tasks {
+		for _, core := range tr.Cpu.ReservedCores {
+			used[core] = struct{}{}
+		}
+	}
+
+	available := make([]uint16, 0, len(node.NodeResources.Cpu.ReservableCpuCores))
+	for _, core := range node.NodeResources.Cpu.ReservableCpuCores {
+		if _, ok := used[core]; !ok {
+			available = append(available, core)
+		}
+	}
+	if len(available) < count {
+		return nil
+	}
+
+	sort.Slice(available, func(i, j int) bool { return available[i] < available[j] })
+	return available[:count]
+}
+
 // JobAntiAffinityIterator is used to apply an anti-affinity to allocating
 // along side other allocations from this job. This is used to help distribute
 // load across the cluster.
@@ -590,20 +682,30 @@ func (iter *NodeAffinityIterator) SetJob(job *structs.Job) {
 }
 
 func (iter *NodeAffinityIterator) SetTaskGroup(tg *structs.TaskGroup) {
-	// Merge job affinities
-	if iter.jobAffinities != nil {
-		iter.affinities = append(iter.affinities, iter.jobAffinities...)
-	}
+	// Merge the job, task group and task affinities. Job affinities are
+	// scored by the JobAffinityIterator.
+	iter.affinities = mergeAffinities(iter.affinities, iter.jobAffinities, tg, false)
+}
 
-	// Merge task group affinities and task affinities
-	if tg.Affinities != nil {
-		iter.affinities = append(iter.affinities, tg.Affinities...)
+// mergeAffinities appends the given job level affinities and the affinities
+// of the task group and its tasks to dst, keeping only the affinities that
+// target jobs if jobAffinities is set or only those that target nodes
+// otherwise.
+func mergeAffinities(dst, jobLevel []*structs.Affinity, tg *structs.TaskGroup, jobAffinities bool) []*structs.Affinity {
+	appendMatching := func(affinities []*structs.Affinity) {
+		for _, a := range affinities {
+			if a.IsJobAffinity() == jobAffinities {
+				dst = append(dst, a)
+			}
+		}
 	}
+
+	appendMatching(jobLevel)
+	appendMatching(tg.Affinities)
 	for _, task := range tg.Tasks {
-		if task.Affinities != nil {
-			iter.affinities = append(iter.affinities, task.Affinities...)
-		}
+		appendMatching(task.Affinities)
 	}
+	return dst
 }
 
 func (iter *NodeAffinityIterator) Reset() {
@@ -655,6 +757,238 @@ func matchesAffinity(ctx Context, affinity *structs.Affinity, option *structs.No
 	return checkAffinity(ctx, affinity.Operand, lVal, rVal, lOk, rOk)
 }
 
+// JobAffinityIterator is used to resolve any affinity rules in the job or
+// task group that target other jobs, and apply a weighted score to nodes
+// running allocations of jobs that match. A positive weight co-locates the
+// task group with the matching jobs while a negative weight keeps it away
+// from them.
+type JobAffinityIterator struct {
+	ctx           Context
+	source        RankIterator
+	namespace     string
+	jobID         string
+	jobAffinities []*structs.Affinity
+	affinities    []*structs.Affinity
+}
+
+// NewJobAffinityIterator is used to create a JobAffinityIterator that
+// applies a weighted score according to whether nodes run allocations of
+// jobs matching any job affinities in the job or task group.
+func NewJobAffinityIterator(ctx Context, source RankIterator) *JobAffinityIterator {
+	return &JobAffinityIterator{
+		ctx:    ctx,
+		source: source,
+	}
+}
+
+func (iter *JobAffinityIterator) SetJob(job *structs.Job) {
+	iter.namespace = job.Namespace
+	iter.jobID = job.ID
+	iter.jobAffinities = job.Affinities
+}
+
+func (iter *JobAffinityIterator) SetTaskGroup(tg *structs.TaskGroup) {
+	iter.affinities = mergeAffinities(iter.affinities, iter.jobAffinities, tg, true)
+}
+
+func (iter *JobAffinityIterator) Reset() {
+	iter.source.Reset()
+	// This method is called between each task group, so only reset the merged list
+	iter.affinities = nil
+}
+
+func (iter *JobAffinityIterator) hasAffinities() bool {
+	return len(iter.affinities) > 0
+}
+
+func (iter *JobAffinityIterator) Next() *RankedNode {
+	for {
+		option := iter.source.Next()
+		if option == nil {
+			return nil
+		}
+		if !iter.hasAffinities() {
+			iter.ctx.Metrics().ScoreNode(option.Node, "job-affinity", 0)
+			return option
+		}
+
+		// Get the proposed allocations
+		proposed, err := option.ProposedAllocs(iter.ctx)
+		if err != nil {
+			iter.ctx.Logger().Named("job_affinity").Error("failed retrieving proposed allocations", "error", err)
+			continue
+		}
+
+		sumWeight := 0.0
+		totalAffinityScore := 0.0
+		for _, affinity := range iter.affinities {
+			sumWeight += math.Abs(float64(affinity.Weight))
+			if iter.matchesJobAffinity(affinity, proposed) {
+				totalAffinityScore += float64(affinity.Weight)
+			}
+		}
+		normScore := totalAffinityScore / sumWeight
+		if totalAffinityScore != 0.0 {
+			option.Scores = append(option.Scores, normScore)
+			iter.ctx.Metrics().ScoreNode(option.Node, "job-affinity", normScore)
+		}
+		return option
+	}
+}
+
+// matchesJobAffinity returns whether any of the proposed allocations belongs
+// to another job in the same namespace that satisfies the affinity.
+func (iter *JobAffinityIterator) matchesJobAffinity(affinity *structs.Affinity, proposed []*structs.Allocation) bool {
+	for _, alloc := range proposed {
+		if alloc.Namespace != iter.namespace || alloc.JobID == iter.jobID || alloc.TerminalStatus() {
+			continue
+		}
+
+		lVal, lOk := resolveJobTarget(affinity.LTarget, alloc)
+		if checkAffinity(iter.ctx, affinity.Operand, lVal, affinity.RTarget, lOk, true) {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveJobTarget resolves a job affinity target against the job of the
+// given allocation.
+func resolveJobTarget(target string, alloc *structs.Allocation) (interface{}, bool) {
+	switch {
+	case target == structs.AffinityTargetJobID:
+		return alloc.JobID, true
+
+	case strings.HasPrefix(target, structs.AffinityTargetJobMetaPrefix):
+		if alloc.Job == nil {
+			return nil, false
+		}
+		meta := strings.TrimSuffix(strings.TrimPrefix(target, structs.AffinityTargetJobMetaPrefix), "}")
+		val, ok := alloc.Job.Meta[meta]
+		return val, ok
+
+	default:
+		return nil, false
+	}
+}
+
+// DatacenterPreferenceIterator is used to place allocations in the most
+// preferred datacenters of the job or task group. It only returns the options
+// in the datacenters with the highest preference weight, so a less preferred
+// datacenter is only used once the more preferred ones have no feasible node
+// with capacity left. The options of the datacenters with the highest weight
+// of the preferences are returned as soon as they are found, so that the
+// source is only consumed entirely when none of them has capacity.
+type DatacenterPreferenceIterator struct {
+	ctx       Context
+	source    RankIterator
+	jobPrefs  []*structs.DatacenterPreference
+	weights   map[string]int8
+	maxWeight int8
+
+	// topFound is set once an option of a datacenter with the highest
+	// weight is found, after which only these options are returned
+	topFound bool
+
+	// options are the options of the most preferred datacenters found once
+	// the source is drained without finding an option of the highest weight
+	options []*RankedNode
+	offset  int
+	drained bool
+}
+
+// NewDatacenterPreferenceIterator is used to create a
+// DatacenterPreferenceIterator that filters options to the most preferred
+// datacenters.
+func NewDatacenterPreferenceIterator(ctx Context, source RankIterator) *DatacenterPreferenceIterator {
+	return &DatacenterPreferenceIterator{
+		ctx:    ctx,
+		source: source,
+	}
+}
+
+func (iter *DatacenterPreferenceIterator) SetJob(job *structs.Job) {
+	iter.jobPrefs = job.DatacenterPreferences
+}
+
+func (iter *DatacenterPreferenceIterator) SetTaskGroup(tg *structs.TaskGroup) {
+	// The preferences of the task group override those of the job
+	prefs := iter.jobPrefs
+	if len(tg.DatacenterPreferences) > 0 {
+		prefs = tg.DatacenterPreferences
+	}
+
+	iter.weights = nil
+	iter.maxWeight = 0
+	if len(prefs) == 0 {
+		return
+	}
+	iter.weights = make(map[string]int8, len(prefs))
+	for _, pref := range prefs {
+		iter.weights[pref.Datacenter] = pref.Weight
+		if pref.Weight > iter.maxWeight {
+			iter.maxWeight = pref.Weight
+		}
+	}
+}
+
+func (iter *DatacenterPreferenceIterator) hasPreferences() bool {
+	return len(iter.weights) > 0
+}
+
+func (iter *DatacenterPreferenceIterator) Next() *RankedNode {
+	if !iter.hasPreferences() {
+		return iter.source.Next()
+	}
+
+	if !iter.drained {
+		if option := iter.nextTop(); option != nil {
+			return option
+		}
+	}
+	if iter.topFound || iter.offset == len(iter.options) {
+		return nil
+	}
+	option := iter.options[iter.offset]
+	iter.offset++
+	return option
+}
+
+// nextTop consumes the source until it finds an option of a datacenter with
+// the highest weight. Until one is found, it keeps the options of the
+// datacenters with the highest weight seen. Datacenters without a preference
+// have a weight of 0.
+func (iter *DatacenterPreferenceIterator) nextTop() *RankedNode {
+	for {
+		option := iter.source.Next()
+		if option == nil {
+			iter.drained = true
+			return nil
+		}
+
+		weight := iter.weights[option.Node.Datacenter]
+		switch {
+		case weight == iter.maxWeight:
+			iter.topFound = true
+			iter.options = nil
+			return option
+		case iter.topFound:
+		case len(iter.options) == 0 || weight > iter.weights[iter.options[0].Node.Datacenter]:
+			iter.options = append(iter.options[:0], option)
+		case weight == iter.weights[iter.options[0].Node.Datacenter]:
+			iter.options = append(iter.options, option)
+		}
+	}
+}
+
+func (iter *DatacenterPreferenceIterator) Reset() {
+	iter.source.Reset()
+	iter.topFound = false
+	iter.options = nil
+	iter.offset = 0
+	iter.drained = false
+}
+
 // ScoreNormalizationIterator is used to combine scores from various prior
 // iterators and combine them into one final score. The current implementation
 // averages the scores together.
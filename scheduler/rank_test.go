@@ -9,6 +9,11 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+var testSchedulerConfig = &structs.SchedulerConfiguration{
+	SchedulerAlgorithm:            structs.SchedulerAlgorithmBinpack,
+	MemoryOversubscriptionEnabled: true,
+}
+
 func TestFeasibleRankIterator(t *testing.T) {
 	_, ctx := testContext(t)
 	var nodes []*structs.Node
@@ -106,7 +111,7 @@ func TestBinPackIterator_NoExistingAlloc(t *testing.T) {
 			},
 		},
 	}
-	binp := NewBinPackIterator(ctx, static, false, 0)
+	binp := NewBinPackIterator(ctx, static, false, 0, testSchedulerConfig)
 	binp.SetTaskGroup(taskGroup)
 
 	scoreNorm := NewScoreNormalizationIterator(ctx, binp)
@@ -127,6 +132,329 @@ func TestBinPackIterator_NoExistingAlloc(t *testing.T) {
 	}
 }
 
+// Tests that the bin packing iterator scores nodes according to the
+// configured scheduler algorithm
+func TestBinPackIterator_MemoryOversubscription(t *testing.T) {
+	cases := []struct {
+		name        string
+		enabled     bool
+		expectedMax int64
+	}{
+		{
+			name:        "oversubscription disabled",
+			enabled:     false,
+			expectedMax: 0,
+		},
+		{
+			name:        "oversubscription enabled",
+			enabled:     true,
+			expectedMax: 4096,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, ctx := testContext(t)
+			nodes := []*RankedNode{
+				{
+					Node: &structs.Node{
+						ID: uuid.Generate(),
+						NodeResources: &structs.NodeResources{
+							Cpu: structs.NodeCpuResources{
+								CpuShares: 2048,
+							},
+							Memory: structs.NodeMemoryResources{
+								MemoryMB: 2048,
+							},
+						},
+					},
+				},
+			}
+			static := NewStaticRankIterator(ctx, nodes)
+
+			// The memory max exceeds the node's memory but only the
+			// reservation is used for scheduling
+			taskGroup := &structs.TaskGroup{
+				EphemeralDisk: &structs.EphemeralDisk{},
+				Tasks: []*structs.Task{
+					{
+						Name: "web",
+						Resources: &structs.Resources{
+							CPU:         1024,
+							MemoryMB:    1024,
+							MemoryMaxMB: 4096,
+						},
+					},
+				},
+			}
+			binp := NewBinPackIterator(ctx, static, false, 0, &structs.SchedulerConfiguration{
+				MemoryOversubscriptionEnabled: tc.enabled,
+			})
+			binp.SetTaskGroup(taskGroup)
+
+			out := collectRanked(binp)
+			require.Len(t, out, 1)
+
+			memory := out[0].TaskResources["web"].Memory
+			require.EqualValues(t, 1024, memory.MemoryMB)
+			require.Equal(t, tc.expectedMax, memory.MemoryMaxMB)
+		})
+	}
+}
+
+func TestBinPackIterator_ReservedCores(t *testing.T) {
+	state, ctx := testContext(t)
+	nodes := []*RankedNode{
+		{
+			Node: &structs.Node{
+				ID: uuid.Generate(),
+				NodeResources: &structs.NodeResources{
+					Cpu: structs.NodeCpuResources{
+						CpuShares:          4000,
+						ReservableCpuCores: []uint16{0, 1, 2, 3},
+					},
+					Memory: structs.NodeMemoryResources{
+						MemoryMB: 4096,
+					},
+				},
+			},
+		},
+		{
+			Node: &structs.Node{
+				ID: uuid.Generate(),
+				NodeResources: &structs.NodeResources{
+					Cpu: structs.NodeCpuResources{
+						CpuShares:          4000,
+						ReservableCpuCores: []uint16{0, 1, 2, 3},
+					},
+					Memory: structs.NodeMemoryResources{
+						MemoryMB: 4096,
+					},
+				},
+			},
+		},
+	}
+	static := NewStaticRankIterator(ctx, nodes)
+
+	// Reserve cores 0 and 1 on the first node and all cores on the second
+	j1, j2 := mock.Job(), mock.Job()
+	alloc1 := &structs.Allocation{
+		Namespace: structs.DefaultNamespace,
+		ID:        uuid.Generate(),
+		EvalID:    uuid.Generate(),
+		NodeID:    nodes[0].Node.ID,
+		JobID:     j1.ID,
+		Job:       j1,
+		AllocatedResources: &structs.AllocatedResources{
+			Tasks: map[string]*structs.AllocatedTaskResources{
+				"web": {
+					Cpu: structs.AllocatedCpuResources{
+						CpuShares:     2000,
+						ReservedCores: []uint16{0, 1},
+					},
+					Memory: structs.AllocatedMemoryResources{
+						MemoryMB: 1024,
+					},
+				},
+			},
+		},
+		DesiredStatus: structs.AllocDesiredStatusRun,
+		ClientStatus:  structs.AllocClientStatusPending,
+		TaskGroup:     "web",
+	}
+	alloc2 := &structs.Allocation{
+		Namespace: structs.DefaultNamespace,
+		ID:        uuid.Generate(),
+		EvalID:    uuid.Generate(),
+		NodeID:    nodes[1].Node.ID,
+		JobID:     j2.ID,
+		Job:       j2,
+		AllocatedResources: &structs.AllocatedResources{
+			Tasks: map[string]*structs.AllocatedTaskResources{
+				"web": {
+					Cpu: structs.AllocatedCpuResources{
+						CpuShares:     4000,
+						ReservedCores: []uint16{0, 1, 2, 3},
+					},
+					Memory: structs.AllocatedMemoryResources{
+						MemoryMB: 1024,
+					},
+				},
+			},
+		},
+		DesiredStatus: structs.AllocDesiredStatusRun,
+		ClientStatus:  structs.AllocClientStatusPending,
+		TaskGroup:     "web",
+	}
+	require.NoError(t, state.UpsertJobSummary(998, mock.JobSummary(alloc1.JobID)))
+	require.NoError(t, state.UpsertJobSummary(999, mock.JobSummary(alloc2.JobID)))
+	require.NoError(t, state.UpsertAllocs(1000, []*structs.Allocation{alloc1, alloc2}))
+
+	taskGroup := &structs.TaskGroup{
+		EphemeralDisk: &structs.EphemeralDisk{},
+		Tasks: []*structs.Task{
+			{
+				Name: "web",
+				Resources: &structs.Resources{
+					Cores:    1,
+					MemoryMB: 1024,
+				},
+			},
+			{
+				Name: "sidecar",
+				Resources: &structs.Resources{
+					Cores:    1,
+					MemoryMB: 256,
+				},
+			},
+		},
+	}
+	binp := NewBinPackIterator(ctx, static, false, 0, testSchedulerConfig)
+	binp.SetTaskGroup(taskGroup)
+
+	out := collectRanked(binp)
+	require.Len(t, out, 1)
+	require.Equal(t, nodes[0].Node.ID, out[0].Node.ID)
+
+	// Each task is given a distinct free core and the shares of that core
+	web := out[0].TaskResources["web"].Cpu
+	sidecar := out[0].TaskResources["sidecar"].Cpu
+	require.Equal(t, []uint16{2}, web.ReservedCores)
+	require.Equal(t, []uint16{3}, sidecar.ReservedCores)
+	require.EqualValues(t, 1000, web.CpuShares)
+	require.EqualValues(t, 1000, sidecar.CpuShares)
+
+	// The second node is exhausted
+	require.Equal(t, 1, ctx.metrics.DimensionExhausted["cores"])
+}
+
+func TestBinPackIterator_SchedulerAlgorithm(t *testing.T) {
+	cases := []struct {
+		name      string
+		algorithm structs.SchedulerAlgorithm
+		// jobAlgorithm overrides the algorithm of the cluster for the job
+		jobAlgorithm structs.SchedulerAlgorithm
+		// expectedBest is the index of the node expected to score highest
+		expectedBest int
+	}{
+		{
+			name:         "binpack prefers the utilized node",
+			algorithm:    structs.SchedulerAlgorithmBinpack,
+			expectedBest: 0,
+		},
+		{
+			name:         "spread prefers the empty node",
+			algorithm:    structs.SchedulerAlgorithmSpread,
+			expectedBest: 1,
+		},
+		{
+			name:         "job overrides binpack with spread",
+			algorithm:    structs.SchedulerAlgorithmBinpack,
+			jobAlgorithm: structs.SchedulerAlgorithmSpread,
+			expectedBest: 1,
+		},
+		{
+			name:         "job overrides spread with binpack",
+			algorithm:    structs.SchedulerAlgorithmSpread,
+			jobAlgorithm: structs.SchedulerAlgorithmBinpack,
+			expectedBest: 0,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			state, ctx := testContext(t)
+			nodes := []*RankedNode{
+				{
+					Node: &structs.Node{
+						ID: uuid.Generate(),
+						NodeResources: &structs.NodeResources{
+							Cpu: structs.NodeCpuResources{
+								CpuShares: 4096,
+							},
+							Memory: structs.NodeMemoryResources{
+								MemoryMB: 4096,
+							},
+						},
+					},
+				},
+				{
+					Node: &structs.Node{
+						ID: uuid.Generate(),
+						NodeResources: &structs.NodeResources{
+							Cpu: structs.NodeCpuResources{
+								CpuShares: 4096,
+							},
+							Memory: structs.NodeMemoryResources{
+								MemoryMB: 4096,
+							},
+						},
+					},
+				},
+			}
+			static := NewStaticRankIterator(ctx, nodes)
+
+			// Add an existing allocation to the first node
+			j1 := mock.Job()
+			alloc1 := &structs.Allocation{
+				Namespace: structs.DefaultNamespace,
+				ID:        uuid.Generate(),
+				EvalID:    uuid.Generate(),
+				NodeID:    nodes[0].Node.ID,
+				JobID:     j1.ID,
+				Job:       j1,
+				AllocatedResources: &structs.AllocatedResources{
+					Tasks: map[string]*structs.AllocatedTaskResources{
+						"web": {
+							Cpu: structs.AllocatedCpuResources{
+								CpuShares: 2048,
+							},
+							Memory: structs.AllocatedMemoryResources{
+								MemoryMB: 2048,
+							},
+						},
+					},
+				},
+				DesiredStatus: structs.AllocDesiredStatusRun,
+				ClientStatus:  structs.AllocClientStatusPending,
+				TaskGroup:     "web",
+			}
+			require.NoError(t, state.UpsertJobSummary(998, mock.JobSummary(alloc1.JobID)))
+			require.NoError(t, state.UpsertAllocs(1000, []*structs.Allocation{alloc1}))
+
+			taskGroup := &structs.TaskGroup{
+				EphemeralDisk: &structs.EphemeralDisk{},
+				Tasks: []*structs.Task{
+					{
+						Name: "web",
+						Resources: &structs.Resources{
+							CPU:      1024,
+							MemoryMB: 1024,
+						},
+					},
+				},
+			}
+			job := mock.Job()
+			job.SchedulerAlgorithm = tc.jobAlgorithm
+			binp := NewBinPackIterator(ctx, static, false, 0, &structs.SchedulerConfiguration{SchedulerAlgorithm: tc.algorithm})
+			binp.SetJob(job)
+			binp.SetTaskGroup(taskGroup)
+
+			scoreNorm := NewScoreNormalizationIterator(ctx, binp)
+
+			out := collectRanked(scoreNorm)
+			require.Len(t, out, 2)
+
+			best, other := out[0], out[1]
+			if other.FinalScore > best.FinalScore {
+				best, other = other, best
+			}
+			require.Equal(t, nodes[tc.expectedBest], best)
+			require.True(t, best.FinalScore > other.FinalScore)
+		})
+	}
+}
+
 // Tests bin packing iterator with network resources at task and task group level
 func TestBinPackIterator_Network_Success(t *testing.T) {
 	_, ctx := testContext(t)
@@ -223,7 +551,7 @@ func TestBinPackIterator_Network_Success(t *testing.T) {
 			},
 		},
 	}
-	binp := NewBinPackIterator(ctx, static, false, 0)
+	binp := NewBinPackIterator(ctx, static, false, 0, testSchedulerConfig)
 	binp.SetTaskGroup(taskGroup)
 
 	scoreNorm := NewScoreNormalizationIterator(ctx, binp)
@@ -353,7 +681,7 @@ func TestBinPackIterator_Network_Failure(t *testing.T) {
 		},
 	}
 
-	binp := NewBinPackIterator(ctx, static, false, 0)
+	binp := NewBinPackIterator(ctx, static, false, 0, testSchedulerConfig)
 	binp.SetTaskGroup(taskGroup)
 
 	scoreNorm := NewScoreNormalizationIterator(ctx, binp)
@@ -451,7 +779,7 @@ func TestBinPackIterator_PlannedAlloc(t *testing.T) {
 		},
 	}
 
-	binp := NewBinPackIterator(ctx, static, false, 0)
+	binp := NewBinPackIterator(ctx, static, false, 0, testSchedulerConfig)
 	binp.SetTaskGroup(taskGroup)
 
 	scoreNorm := NewScoreNormalizationIterator(ctx, binp)
@@ -567,7 +895,7 @@ func TestBinPackIterator_ExistingAlloc(t *testing.T) {
 			},
 		},
 	}
-	binp := NewBinPackIterator(ctx, static, false, 0)
+	binp := NewBinPackIterator(ctx, static, false, 0, testSchedulerConfig)
 	binp.SetTaskGroup(taskGroup)
 
 	scoreNorm := NewScoreNormalizationIterator(ctx, binp)
@@ -687,7 +1015,7 @@ func TestBinPackIterator_ExistingAlloc_PlannedEvict(t *testing.T) {
 		},
 	}
 
-	binp := NewBinPackIterator(ctx, static, false, 0)
+	binp := NewBinPackIterator(ctx, static, false, 0, testSchedulerConfig)
 	binp.SetTaskGroup(taskGroup)
 
 	scoreNorm := NewScoreNormalizationIterator(ctx, binp)
@@ -992,7 +1320,7 @@ func TestBinPackIterator_Devices(t *testing.T) {
 			}
 
 			static := NewStaticRankIterator(ctx, []*RankedNode{{Node: c.Node}})
-			binp := NewBinPackIterator(ctx, static, false, 0)
+			binp := NewBinPackIterator(ctx, static, false, 0, testSchedulerConfig)
 			binp.SetTaskGroup(c.TaskGroup)
 
 			out := binp.Next()
@@ -1146,6 +1474,85 @@ func TestNodeAntiAffinity_PenaltyNodes(t *testing.T) {
 
 }
 
+func TestJobAffinityIterator(t *testing.T) {
+	_, ctx := testContext(t)
+	nodes := []*RankedNode{
+		{Node: mock.Node()},
+		{Node: mock.Node()},
+		{Node: mock.Node()},
+		{Node: mock.Node()},
+	}
+
+	job := mock.Job()
+	job.ID = "web"
+	tg := job.TaskGroups[0]
+	tg.Affinities = []*structs.Affinity{
+		{
+			Operand: "=",
+			LTarget: "${job.id}",
+			RTarget: "cache",
+			Weight:  100,
+		},
+		{
+			Operand: "=",
+			LTarget: "${job.meta.tier}",
+			RTarget: "batch",
+			Weight:  -50,
+		},
+		{
+			Operand: "=",
+			LTarget: "${node.datacenter}",
+			RTarget: "dc2",
+			Weight:  50,
+		},
+	}
+
+	cacheJob := mock.Job()
+	cacheJob.ID = "cache"
+	batchJob := mock.Job()
+	batchJob.Meta = map[string]string{"tier": "batch"}
+	otherNamespace := mock.Job()
+	otherNamespace.ID = "cache"
+	otherNamespace.Namespace = "other"
+
+	// Node 0 runs the cache job, node 1 runs a batch job, node 2 only runs
+	// the job being placed and node 3 runs the cache job in another namespace
+	plan := ctx.Plan()
+	for i, j := range []*structs.Job{cacheJob, batchJob, job, otherNamespace} {
+		plan.NodeAllocation[nodes[i].Node.ID] = []*structs.Allocation{
+			{
+				ID:        uuid.Generate(),
+				Namespace: j.Namespace,
+				JobID:     j.ID,
+				Job:       j,
+			},
+		}
+	}
+
+	static := NewStaticRankIterator(ctx, nodes)
+
+	jobAffinity := NewJobAffinityIterator(ctx, static)
+	jobAffinity.SetJob(job)
+	jobAffinity.SetTaskGroup(tg)
+	require.True(t, jobAffinity.hasAffinities())
+
+	scoreNorm := NewScoreNormalizationIterator(ctx, jobAffinity)
+
+	out := collectRanked(scoreNorm)
+	require.Len(t, out, 4)
+
+	// Total weight of the job affinities = 150; the node affinity is ignored
+	expectedScores := map[string]float64{
+		nodes[0].Node.ID: 2.0 / 3.0,
+		nodes[1].Node.ID: -(1.0 / 3.0),
+		nodes[2].Node.ID: 0,
+		nodes[3].Node.ID: 0,
+	}
+	for _, n := range out {
+		require.Equal(t, expectedScores[n.Node.ID], n.FinalScore)
+	}
+}
+
 func TestScoreNormalizationIterator(t *testing.T) {
 	// Test normalized scores when there is more than one scorer
 	_, ctx := testContext(t)
@@ -1285,3 +1692,89 @@ func TestNodeAffinityIterator(t *testing.T) {
 	}
 
 }
+
+func TestDatacenterPreferenceIterator(t *testing.T) {
+	require := require.New(t)
+	_, ctx := testContext(t)
+
+	nodes := []*RankedNode{
+		{Node: mock.Node()},
+		{Node: mock.Node()},
+		{Node: mock.Node()},
+		{Node: mock.Node()},
+	}
+	nodes[0].Node.Datacenter = "dc3"
+	nodes[1].Node.Datacenter = "dc2"
+	nodes[2].Node.Datacenter = "dc1"
+	nodes[3].Node.Datacenter = "dc2"
+
+	job := mock.Job()
+	job.Datacenters = []string{"dc1", "dc2", "dc3"}
+	job.DatacenterPreferences = []*structs.DatacenterPreference{
+		{Datacenter: "dc1", Weight: 100},
+		{Datacenter: "dc2", Weight: 50},
+	}
+	tg := job.TaskGroups[0]
+
+	collect := func(options ...*RankedNode) []*RankedNode {
+		static := NewStaticRankIterator(ctx, options)
+		dcPreference := NewDatacenterPreferenceIterator(ctx, static)
+		dcPreference.SetJob(job)
+		dcPreference.SetTaskGroup(tg)
+		return collectRanked(dcPreference)
+	}
+
+	// Only the nodes of the most preferred datacenter are returned
+	require.Equal([]*RankedNode{nodes[2]}, collect(nodes...))
+
+	// Spill over into the next datacenter when the preferred one has no
+	// feasible nodes
+	require.Equal([]*RankedNode{nodes[1], nodes[3]}, collect(nodes[0], nodes[1], nodes[3]))
+
+	// Datacenters without a preference are used last
+	require.Equal([]*RankedNode{nodes[0]}, collect(nodes[0]))
+
+	// The task group preferences override those of the job
+	tg.DatacenterPreferences = []*structs.DatacenterPreference{
+		{Datacenter: "dc3", Weight: 100},
+	}
+	require.Equal([]*RankedNode{nodes[0]}, collect(nodes...))
+
+	// Every node is returned without preferences
+	job.DatacenterPreferences = nil
+	tg.DatacenterPreferences = nil
+	require.Equal(nodes, collect(nodes...))
+}
+
+func TestDatacenterPreferenceIterator_Limit(t *testing.T) {
+	require := require.New(t)
+	_, ctx := testContext(t)
+
+	var nodes []*RankedNode
+	for i := 0; i < 10; i++ {
+		node := mock.Node()
+		node.Datacenter = "dc2"
+		if i%2 == 1 {
+			node.Datacenter = "dc1"
+		}
+		nodes = append(nodes, &RankedNode{Node: node})
+	}
+
+	job := mock.Job()
+	job.Datacenters = []string{"dc1", "dc2"}
+	job.DatacenterPreferences = []*structs.DatacenterPreference{
+		{Datacenter: "dc1", Weight: 100},
+		{Datacenter: "dc2", Weight: 50},
+	}
+
+	static := NewStaticRankIterator(ctx, nodes)
+	dcPreference := NewDatacenterPreferenceIterator(ctx, static)
+	dcPreference.SetJob(job)
+	dcPreference.SetTaskGroup(job.TaskGroups[0])
+	limit := NewLimitIterator(ctx, dcPreference, 2, 0, 0)
+
+	// The options of the most preferred datacenter are returned as soon as
+	// they are found, so the source is only consumed up to the limit
+	require.Equal([]*RankedNode{nodes[1], nodes[3]}, collectRanked(limit))
+	require.Equal(4, static.seen)
+}
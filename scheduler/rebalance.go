@@ -0,0 +1,283 @@
+package scheduler
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	log "github.com/hashicorp/go-hclog"
+	memdb "github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+const (
+	// rebalanceMinScoreImprovement is the minimum improvement of the placement
+	// score an allocation must get from moving to another node for the move
+	// to be worth the disruption of migrating it.
+	rebalanceMinScoreImprovement = 0.05
+
+	// rebalanceStopDesc is the description used for the allocations stopped
+	// in the scratch plan used to score their current node.
+	rebalanceStopDesc = "alloc is being rebalanced"
+)
+
+// ComputeRebalancePlan computes a set of allocation migrations that reduces
+// the fragmentation of the cluster by moving the allocations of service jobs
+// away from the least utilized nodes onto nodes where they score higher
+// according to the bin packing score. The allocations of a task group are
+// never migrated more than its migrate stanza's max_parallel at a time, and
+// at most maxMigrations migrations are returned.
+func ComputeRebalancePlan(state State, logger log.Logger, maxMigrations int) ([]*structs.RebalanceMigration, error) {
+	r := &rebalancer{
+		state:        state,
+		logger:       logger.Named("rebalance"),
+		plan:         &structs.Plan{NodeUpdate: make(map[string][]*structs.Allocation), NodeAllocation: make(map[string][]*structs.Allocation)},
+		sources:      make(map[string]struct{}),
+		targets:      make(map[string]struct{}),
+		migrating:    make(map[string]int),
+		readyNodes:   make(map[string][]*structs.Node),
+		fitness:      make(map[string]float64),
+		jobs:         make(map[structs.NamespacedID]*structs.Job),
+		maxMigration: maxMigrations,
+	}
+	r.ctx = NewEvalContext(state, r.plan, r.logger)
+	return r.compute()
+}
+
+// rebalancer holds the state used while computing a rebalance plan.
+type rebalancer struct {
+	state  State
+	logger log.Logger
+	ctx    *EvalContext
+
+	// plan is a scratch plan holding the migrations proposed so far, so that
+	// each decision accounts for the previous ones.
+	plan *structs.Plan
+
+	// sources and targets track the nodes allocations were moved away from
+	// and onto, so an allocation is never moved onto a node being emptied
+	sources map[string]struct{}
+	targets map[string]struct{}
+
+	// fitness is the bin packing score of each node before rebalancing.
+	// Allocations are only moved onto nodes that were more utilized than
+	// their current one.
+	fitness map[string]float64
+
+	// migrating counts the allocations of each task group that are migrating
+	migrating map[string]int
+
+	// readyNodes and jobs cache the ready nodes of each set of datacenters and
+	// the jobs of the allocations being considered
+	readyNodes map[string][]*structs.Node
+	jobs       map[structs.NamespacedID]*structs.Job
+
+	maxMigration int
+	migrations   []*structs.RebalanceMigration
+}
+
+// nodeFitness is a node and how well its allocations fill it.
+type nodeFitness struct {
+	node   *structs.Node
+	allocs []*structs.Allocation
+	score  float64
+}
+
+func (r *rebalancer) compute() ([]*structs.RebalanceMigration, error) {
+	ws := memdb.NewWatchSet()
+	iter, err := r.state.Nodes(ws)
+	if err != nil {
+		return nil, err
+	}
+
+	// Score how full each ready node is and count the allocations already
+	// migrating
+	var fitness []*nodeFitness
+	for {
+		raw := iter.Next()
+		if raw == nil {
+			break
+		}
+
+		node := raw.(*structs.Node)
+		if !node.Ready() {
+			continue
+		}
+
+		allocs, err := r.state.AllocsByNodeTerminal(ws, node.ID, false)
+		if err != nil {
+			return nil, err
+		}
+
+		_, _, util, err := structs.AllocsFit(node, allocs, nil, false)
+		if err != nil {
+			return nil, err
+		}
+		score := structs.ScoreFitBinPack(node, util)
+		r.fitness[node.ID] = score
+		fitness = append(fitness, &nodeFitness{
+			node:   node,
+			allocs: allocs,
+			score:  score,
+		})
+
+		for _, alloc := range allocs {
+			if alloc.DesiredTransition.ShouldMigrate() {
+				r.migrating[taskGroupKey(alloc)]++
+			}
+		}
+	}
+
+	// Try emptying the least utilized nodes first
+	sort.SliceStable(fitness, func(i, j int) bool {
+		return fitness[i].score < fitness[j].score
+	})
+
+	for _, nf := range fitness {
+		if _, ok := r.targets[nf.node.ID]; ok {
+			continue
+		}
+
+		for _, alloc := range nf.allocs {
+			if len(r.migrations) >= r.maxMigration {
+				return r.migrations, nil
+			}
+
+			if err := r.rebalanceAlloc(nf.node, alloc); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return r.migrations, nil
+}
+
+// rebalanceAlloc proposes migrating the allocation if another node scores
+// sufficiently higher than its current one.
+func (r *rebalancer) rebalanceAlloc(node *structs.Node, alloc *structs.Allocation) error {
+	if alloc.ClientStatus != structs.AllocClientStatusRunning ||
+		alloc.DesiredStatus != structs.AllocDesiredStatusRun ||
+		alloc.DesiredTransition.ShouldMigrate() {
+		return nil
+	}
+
+	job, err := r.job(alloc)
+	if err != nil || job == nil {
+		return err
+	}
+	if job.Type != structs.JobTypeService || job.Stopped() {
+		return nil
+	}
+
+	tg := job.LookupTaskGroup(alloc.TaskGroup)
+	if tg == nil || (tg.EphemeralDisk != nil && tg.EphemeralDisk.Sticky) {
+		return nil
+	}
+
+	// Respect the number of allocations of the task group that may migrate
+	// at the same time
+	maxParallel := structs.DefaultMigrateStrategy().MaxParallel
+	if tg.Migrate != nil {
+		maxParallel = tg.Migrate.MaxParallel
+	}
+	if r.migrating[taskGroupKey(alloc)] >= maxParallel {
+		return nil
+	}
+
+	candidates, err := r.candidateNodes(job)
+	if err != nil {
+		return err
+	}
+
+	stack := NewGenericStack(false, r.ctx)
+	stack.SetNodes(candidates)
+	stack.SetJob(job)
+
+	// Always score by bin packing regardless of the scheduler algorithm as
+	// the goal is to reduce fragmentation, and consider every node.
+	stack.binPack.scoreFit = structs.ScoreFitBinPack
+	stack.limit.SetLimit(math.MaxInt32)
+
+	// Score the current node and the best node with the allocation removed
+	r.plan.AppendStoppedAlloc(alloc, rebalanceStopDesc, "")
+	current := stack.Select(tg, &SelectOptions{PreferredNodes: []*structs.Node{node}})
+	best := stack.Select(tg, nil)
+
+	if current == nil || current.Node.ID != node.ID || best == nil || best.Node.ID == node.ID ||
+		r.fitness[best.Node.ID] <= r.fitness[node.ID] ||
+		best.FinalScore-current.FinalScore < rebalanceMinScoreImprovement {
+		r.plan.PopUpdate(alloc)
+		return nil
+	}
+
+	// Keep the proposed placement in the plan so the next decisions see it
+	placed := alloc.Copy()
+	placed.ID = uuid.Generate()
+	placed.NodeID = best.Node.ID
+	placed.AllocatedResources = &structs.AllocatedResources{
+		Tasks: best.TaskResources,
+	}
+	if alloc.AllocatedResources != nil {
+		placed.AllocatedResources.Shared = alloc.AllocatedResources.Shared
+	}
+	r.plan.AppendAlloc(placed)
+
+	r.sources[node.ID] = struct{}{}
+	r.targets[best.Node.ID] = struct{}{}
+	r.migrating[taskGroupKey(alloc)]++
+	r.migrations = append(r.migrations, &structs.RebalanceMigration{
+		AllocID:          alloc.ID,
+		AllocName:        alloc.Name,
+		Namespace:        alloc.Namespace,
+		JobID:            alloc.JobID,
+		TaskGroup:        alloc.TaskGroup,
+		SourceNodeID:     node.ID,
+		TargetNodeID:     best.Node.ID,
+		ScoreImprovement: best.FinalScore - current.FinalScore,
+	})
+	return nil
+}
+
+// job returns the latest version of the allocation's job.
+func (r *rebalancer) job(alloc *structs.Allocation) (*structs.Job, error) {
+	id := structs.NamespacedID{Namespace: alloc.Namespace, ID: alloc.JobID}
+	if job, ok := r.jobs[id]; ok {
+		return job, nil
+	}
+
+	job, err := r.state.JobByID(nil, alloc.Namespace, alloc.JobID)
+	if err != nil {
+		return nil, err
+	}
+	r.jobs[id] = job
+	return job, nil
+}
+
+// candidateNodes returns the ready nodes in the job's datacenters that are
+// not being emptied by the rebalance plan.
+func (r *rebalancer) candidateNodes(job *structs.Job) ([]*structs.Node, error) {
+	key := strings.Join(job.Datacenters, ",")
+	nodes, ok := r.readyNodes[key]
+	if !ok {
+		var err error
+		nodes, _, err = readyNodesInDCs(r.state, job.Datacenters)
+		if err != nil {
+			return nil, err
+		}
+		r.readyNodes[key] = nodes
+	}
+
+	candidates := make([]*structs.Node, 0, len(nodes))
+	for _, node := range nodes {
+		if _, ok := r.sources[node.ID]; !ok {
+			candidates = append(candidates, node)
+		}
+	}
+	return candidates, nil
+}
+
+// taskGroupKey returns a key identifying the task group of the allocation.
+func taskGroupKey(alloc *structs.Allocation) string {
+	return alloc.Namespace + "/" + alloc.JobID + "/" + alloc.TaskGroup
+}
@@ -0,0 +1,70 @@
+package scheduler
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputeRebalancePlan(t *testing.T) {
+	h := NewHarness(t)
+
+	var nodes []*structs.Node
+	for i := 0; i < 3; i++ {
+		node := mock.Node()
+		nodes = append(nodes, node)
+		require.NoError(t, h.State.UpsertNode(h.NextIndex(), node))
+	}
+
+	// job1 has two allocations alone on node 0, job2 fills node 1 and node 2
+	// is empty
+	job1 := mock.Job()
+	job1.TaskGroups[0].Migrate.MaxParallel = 1
+	require.NoError(t, h.State.UpsertJob(h.NextIndex(), job1))
+	job2 := mock.Job()
+	require.NoError(t, h.State.UpsertJob(h.NextIndex(), job2))
+
+	var allocs []*structs.Allocation
+	newAlloc := func(job *structs.Job, node *structs.Node, i int) {
+		alloc := mock.Alloc()
+		alloc.Job = job
+		alloc.JobID = job.ID
+		alloc.NodeID = node.ID
+		alloc.Name = fmt.Sprintf("my-job.web[%d]", i)
+		alloc.ClientStatus = structs.AllocClientStatusRunning
+		allocs = append(allocs, alloc)
+	}
+	for i := 0; i < 2; i++ {
+		newAlloc(job1, nodes[0], i)
+	}
+	for i := 0; i < 4; i++ {
+		newAlloc(job2, nodes[1], i)
+	}
+	require.NoError(t, h.State.UpsertAllocs(h.NextIndex(), allocs))
+
+	migrations, err := ComputeRebalancePlan(h.State, testlog.HCLogger(t), 10)
+	require.NoError(t, err)
+
+	// Only one allocation of job1 may migrate at a time and it should move
+	// onto the most utilized node
+	require.Len(t, migrations, 1)
+	m := migrations[0]
+	require.Equal(t, job1.ID, m.JobID)
+	require.Equal(t, nodes[0].ID, m.SourceNodeID)
+	require.Equal(t, nodes[1].ID, m.TargetNodeID)
+	require.True(t, m.ScoreImprovement >= rebalanceMinScoreImprovement)
+
+	// Allocations already migrating count against max_parallel
+	migrating := allocs[0].Copy()
+	migrating.DesiredTransition.Migrate = helper.BoolToPtr(true)
+	require.NoError(t, h.State.UpsertAllocs(h.NextIndex(), []*structs.Allocation{migrating}))
+
+	migrations, err = ComputeRebalancePlan(h.State, testlog.HCLogger(t), 10)
+	require.NoError(t, err)
+	require.Empty(t, migrations)
+}
@@ -112,6 +112,10 @@ type reconcileResults struct {
 	// jobspec change.
 	attributeUpdates map[string]*structs.Allocation
 
+	// disconnectUpdates are the allocations of disconnected clients that
+	// should be marked as unknown.
+	disconnectUpdates map[string]*structs.Allocation
+
 	// desiredTGUpdates captures the desired set of changes to make for each
 	// task group.
 	desiredTGUpdates map[string]*structs.DesiredUpdates
@@ -135,8 +139,8 @@ type delayedRescheduleInfo struct {
 }
 
 func (r *reconcileResults) GoString() string {
-	base := fmt.Sprintf("Total changes: (place %d) (destructive %d) (inplace %d) (stop %d)",
-		len(r.place), len(r.destructiveUpdate), len(r.inplaceUpdate), len(r.stop))
+	base := fmt.Sprintf("Total changes: (place %d) (destructive %d) (inplace %d) (stop %d) (disconnect %d)",
+		len(r.place), len(r.destructiveUpdate), len(r.inplaceUpdate), len(r.stop), len(r.disconnectUpdates))
 
 	if r.deployment != nil {
 		base += fmt.Sprintf("\nCreated Deployment: %q", r.deployment.ID)
@@ -347,19 +351,39 @@ func (a *allocReconciler) computeGroup(group string, all allocSet) bool {
 	// allocs including the canaries
 	canaries, all := a.handleGroupCanaries(all, desiredChanges)
 
+	// Split out the allocations of disconnected clients. Disconnected
+	// allocations that are already unknown have been replaced and are only
+	// waiting for their client to reconnect.
+	all, disconnecting, disconnected, reconnecting := all.filterByDisconnect(tg, a.taintedNodes, a.now)
+	desiredChanges.Ignore += uint64(len(disconnected))
+
+	// Stop the duplicates left behind by reconnecting clients
+	reconnecting, reconnectStop := a.reconcileReconnecting(reconnecting, all)
+	a.markStop(reconnectStop, "", allocReconnected)
+	desiredChanges.Stop += uint64(len(reconnectStop))
+	all = all.difference(reconnectStop)
+
 	// Determine what set of allocations are on tainted nodes
 	untainted, migrate, lost := all.filterByTainted(a.taintedNodes)
 
 	// Determine what set of terminal allocations need to be rescheduled
 	untainted, rescheduleNow, rescheduleLater := untainted.filterByRescheduleable(a.batch, a.now, a.evalID, a.deployment)
 
+	// Reconnecting allocations have been replaced, so they are added back
+	// after filtering out rescheduled allocations
+	untainted = untainted.union(reconnecting)
+
 	// Create batched follow up evaluations for allocations that are
 	// reschedulable later and mark the allocations for in place updating
 	a.handleDelayedReschedules(rescheduleLater, all, tg.Name)
 
+	// Mark the allocations of disconnected clients as unknown and create
+	// follow up evaluations to mark them as lost once they time out
+	a.handleDisconnecting(disconnecting, tg)
+
 	// Create a structure for choosing names. Seed with the taken names which is
 	// the union of untainted and migrating nodes (includes canaries)
-	nameIndex := newAllocNameIndex(a.jobID, group, tg.Count, untainted.union(migrate, rescheduleNow))
+	nameIndex := newAllocNameIndex(a.jobID, group, tg.Count, untainted.union(migrate, rescheduleNow, disconnecting))
 
 	// Stop any unneeded allocations and update the untainted set to not
 	// included stopped allocations.
@@ -377,6 +401,10 @@ func (a *allocReconciler) computeGroup(group string, all allocSet) bool {
 		dstate.DesiredTotal += len(destructive) + len(inplace)
 	}
 
+	// Update the reconnecting allocations that are kept so their clients
+	// resend their state
+	a.handleReconnecting(reconnecting.difference(stop, destructive))
+
 	// Remove the canaries now that we have handled rescheduling so that we do
 	// not consider them when making placement decisions.
 	if canaryState {
@@ -413,7 +441,7 @@ func (a *allocReconciler) computeGroup(group string, all allocSet) bool {
 	// * The deployment is not paused or failed
 	// * Not placing any canaries
 	// * If there are any canaries that they have been promoted
-	place := a.computePlacements(tg, nameIndex, untainted, migrate, rescheduleNow)
+	place := a.computePlacements(tg, nameIndex, untainted, migrate, rescheduleNow, disconnecting)
 	if !existingDeployment {
 		dstate.DesiredTotal += len(place)
 	}
@@ -434,10 +462,10 @@ func (a *allocReconciler) computeGroup(group string, all allocSet) bool {
 		limit -= min
 	} else if !deploymentPlaceReady {
 		// We do not want to place additional allocations but in the case we
-		// have lost allocations or allocations that require rescheduling now,
-		// we do so regardless to avoid odd user experiences.
-		if len(lost) != 0 {
-			allowed := helper.IntMin(len(lost), len(place))
+		// have lost or disconnected allocations or allocations that require
+		// rescheduling now, we do so regardless to avoid odd user experiences.
+		if replace := len(lost) + len(disconnecting); replace != 0 {
+			allowed := helper.IntMin(replace, len(place))
 			desiredChanges.Place += uint64(allowed)
 			for _, p := range place[:allowed] {
 				a.result.place = append(a.result.place, p)
@@ -658,9 +686,10 @@ func (a *allocReconciler) computeLimit(group *structs.TaskGroup, untainted, dest
 }
 
 // computePlacement returns the set of allocations to place given the group
-// definition, the set of untainted, migrating and reschedule allocations for the group.
+// definition, the set of untainted, migrating, reschedule and disconnecting
+// allocations for the group.
 func (a *allocReconciler) computePlacements(group *structs.TaskGroup,
-	nameIndex *allocNameIndex, untainted, migrate allocSet, reschedule allocSet) []allocPlaceResult {
+	nameIndex *allocNameIndex, untainted, migrate allocSet, reschedule allocSet, disconnecting allocSet) []allocPlaceResult {
 
 	// Add rescheduled placement results
 	var place []allocPlaceResult
@@ -674,8 +703,18 @@ func (a *allocReconciler) computePlacements(group *structs.TaskGroup,
 		})
 	}
 
+	// Add replacements for the allocations of disconnected clients
+	for _, alloc := range disconnecting {
+		place = append(place, allocPlaceResult{
+			name:          alloc.Name,
+			taskGroup:     group,
+			previousAlloc: alloc,
+			canary:        alloc.DeploymentStatus.IsCanary(),
+		})
+	}
+
 	// Hot path the nothing to do case
-	existing := len(untainted) + len(migrate) + len(reschedule)
+	existing := len(untainted) + len(migrate) + len(reschedule) + len(disconnecting)
 	if existing >= group.Count {
 		return place
 	}
@@ -898,3 +937,132 @@ func (a *allocReconciler) handleDelayedReschedules(rescheduleLater []*delayedRes
 		a.result.attributeUpdates[updatedAlloc.ID] = updatedAlloc
 	}
 }
+
+// reconcileReconnecting determines, for each allocation of a reconnecting
+// client, whether the allocation or the replacements placed while its client
+// was disconnected should be kept. The original allocation is preferred
+// unless a replacement runs a newer version of the job. It returns the
+// reconnecting allocations to keep and the allocations to stop.
+func (a *allocReconciler) reconcileReconnecting(reconnecting, all allocSet) (keep, stop allocSet) {
+	keep = make(map[string]*structs.Allocation)
+	stop = make(map[string]*structs.Allocation)
+
+	for _, alloc := range reconnecting {
+		var replacements []*structs.Allocation
+		replaced := false
+		for _, other := range all {
+			if other.Name != alloc.Name || other.TerminalStatus() {
+				continue
+			}
+			replacements = append(replacements, other)
+			if other.Job.Version > alloc.Job.Version || other.Job.CreateIndex != alloc.Job.CreateIndex {
+				replaced = true
+			}
+		}
+
+		if replaced {
+			stop[alloc.ID] = alloc
+			continue
+		}
+
+		keep[alloc.ID] = alloc
+		for _, other := range replacements {
+			stop[other.ID] = other
+		}
+	}
+
+	return keep, stop
+}
+
+// handleReconnecting updates the reconnecting allocations that are kept. The
+// update clears the link to the replacement that is being stopped and
+// notifies the client to resend the state of the allocation. Once the client
+// has reported the status of the allocation, the reconnect is recorded so the
+// allocation is no longer considered as reconnecting.
+func (a *allocReconciler) handleReconnecting(keep allocSet) {
+	if len(keep) == 0 {
+		return
+	}
+
+	// Allocations being updated inplace already notify the client
+	for _, alloc := range a.result.inplaceUpdate {
+		if existing, ok := keep[alloc.ID]; ok {
+			alloc.NextAllocation = ""
+			appendReconnectState(alloc, existing.ClientStatus)
+			delete(keep, alloc.ID)
+		}
+	}
+
+	if len(keep) != 0 && a.result.attributeUpdates == nil {
+		a.result.attributeUpdates = make(map[string]*structs.Allocation)
+	}
+
+	for _, alloc := range keep {
+		updatedAlloc := alloc.Copy()
+		updatedAlloc.NextAllocation = ""
+		appendReconnectState(updatedAlloc, alloc.ClientStatus)
+		a.result.attributeUpdates[updatedAlloc.ID] = updatedAlloc
+	}
+}
+
+// appendReconnectState records that the client of the allocation reconnected
+// if it has reported the status of the allocation since.
+func appendReconnectState(alloc *structs.Allocation, clientStatus string) {
+	if clientStatus == structs.AllocClientStatusUnknown || !alloc.NeedsToReconnect() {
+		return
+	}
+	alloc.AppendState(structs.AllocStateFieldClientStatus, clientStatus)
+}
+
+// handleDisconnecting marks the allocations of disconnected clients as
+// unknown and creates batched follow up evaluations to mark them as lost once
+// their client has been disconnected for longer than max_client_disconnect.
+func (a *allocReconciler) handleDisconnecting(disconnecting allocSet, tg *structs.TaskGroup) {
+	if len(disconnecting) == 0 {
+		return
+	}
+
+	type disconnectTimeout struct {
+		alloc   *structs.Allocation
+		timeout time.Time
+	}
+
+	timeouts := make([]disconnectTimeout, 0, len(disconnecting))
+	for _, alloc := range disconnecting {
+		expiry, _ := disconnectExpiry(tg, a.taintedNodes[alloc.NodeID])
+		timeouts = append(timeouts, disconnectTimeout{alloc, expiry})
+	}
+
+	// Sort by time
+	sort.Slice(timeouts, func(i, j int) bool {
+		return timeouts[i].timeout.Before(timeouts[j].timeout)
+	})
+
+	if a.result.disconnectUpdates == nil {
+		a.result.disconnectUpdates = make(map[string]*structs.Allocation)
+	}
+
+	var eval *structs.Evaluation
+	for _, t := range timeouts {
+		// Start a new batch if the timeout is outside of the current one
+		if eval == nil || t.timeout.Sub(eval.WaitUntil) >= batchedFailedAllocWindowSize {
+			eval = &structs.Evaluation{
+				ID:                uuid.Generate(),
+				Namespace:         a.job.Namespace,
+				Priority:          a.job.Priority,
+				Type:              a.job.Type,
+				TriggeredBy:       structs.EvalTriggerMaxDisconnectTimeout,
+				JobID:             a.job.ID,
+				JobModifyIndex:    a.job.ModifyIndex,
+				Status:            structs.EvalStatusPending,
+				StatusDescription: disconnectTimeoutFollowupEvalDesc,
+				WaitUntil:         t.timeout,
+			}
+			a.result.desiredFollowupEvals[tg.Name] = append(a.result.desiredFollowupEvals[tg.Name], eval)
+		}
+
+		updatedAlloc := t.alloc.Copy()
+		updatedAlloc.FollowupEvalID = eval.ID
+		a.result.disconnectUpdates[updatedAlloc.ID] = updatedAlloc
+	}
+}
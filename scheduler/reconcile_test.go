@@ -256,6 +256,7 @@ type resultExpectation struct {
 	destructive       int
 	inplace           int
 	attributeUpdates  int
+	disconnectUpdates int
 	stop              int
 	desiredTGUpdates  map[string]*structs.DesiredUpdates
 }
@@ -282,6 +283,7 @@ func assertResults(t *testing.T, r *reconcileResults, exp *resultExpectation) {
 	assert.Len(r.destructiveUpdate, exp.destructive, "Expected Destructive")
 	assert.Len(r.inplaceUpdate, exp.inplace, "Expected Inplace Updates")
 	assert.Len(r.attributeUpdates, exp.attributeUpdates, "Expected Attribute Updates")
+	assert.Len(r.disconnectUpdates, exp.disconnectUpdates, "Expected Disconnect Updates")
 	assert.Len(r.stop, exp.stop, "Expected Stops")
 	assert.EqualValues(exp.desiredTGUpdates, r.desiredTGUpdates, "Expected Desired TG Update Annotations")
 }
@@ -769,6 +771,260 @@ func TestReconciler_LostNode(t *testing.T) {
 	assertNamesHaveIndexes(t, intRange(0, 1), placeResultsToNames(r.place))
 }
 
+// Tests the reconciler marks allocations of disconnected clients as unknown
+// and replaces them when the group supports disconnected clients
+func TestReconciler_DisconnectedNode(t *testing.T) {
+	job := mock.Job()
+	job.TaskGroups[0].MaxClientDisconnect = helper.TimeToPtr(time.Hour)
+
+	// Create 10 existing allocations
+	var allocs []*structs.Allocation
+	for i := 0; i < 10; i++ {
+		alloc := mock.Alloc()
+		alloc.Job = job
+		alloc.JobID = job.ID
+		alloc.NodeID = uuid.Generate()
+		alloc.Name = structs.AllocName(job.ID, job.TaskGroups[0].Name, uint(i))
+		alloc.ClientStatus = structs.AllocClientStatusRunning
+		allocs = append(allocs, alloc)
+	}
+
+	// Build a map of tainted nodes that went down a minute ago
+	now := time.Now()
+	tainted := make(map[string]*structs.Node, 2)
+	for i := 0; i < 2; i++ {
+		n := mock.Node()
+		n.ID = allocs[i].NodeID
+		n.Status = structs.NodeStatusDown
+		n.StatusUpdatedAt = now.Add(-time.Minute).Unix()
+		tainted[n.ID] = n
+	}
+
+	reconciler := NewAllocReconciler(testlog.HCLogger(t), allocUpdateFnIgnore, false, job.ID, job, nil, allocs, tainted, "")
+	reconciler.now = now
+	r := reconciler.Compute()
+
+	// Assert the correct results
+	assertResults(t, r, &resultExpectation{
+		createDeployment:  nil,
+		deploymentUpdates: nil,
+		place:             2,
+		disconnectUpdates: 2,
+		desiredTGUpdates: map[string]*structs.DesiredUpdates{
+			job.TaskGroups[0].Name: {
+				Place:  2,
+				Ignore: 8,
+			},
+		},
+	})
+	assertNamesHaveIndexes(t, intRange(0, 1), placeResultsToNames(r.place))
+
+	// The replacements are linked to the disconnected allocations
+	for _, p := range r.place {
+		require.NotNil(t, p.PreviousAllocation())
+		require.Contains(t, r.disconnectUpdates, p.PreviousAllocation().ID)
+	}
+
+	// A single follow up eval marks the allocations as lost once they expire
+	evals := r.desiredFollowupEvals[job.TaskGroups[0].Name]
+	require.Len(t, evals, 1)
+	require.Equal(t, structs.EvalTriggerMaxDisconnectTimeout, evals[0].TriggeredBy)
+	require.Equal(t, time.Unix(now.Add(-time.Minute).Unix(), 0).Add(time.Hour), evals[0].WaitUntil)
+	for _, alloc := range r.disconnectUpdates {
+		require.Equal(t, evals[0].ID, alloc.FollowupEvalID)
+	}
+}
+
+// Tests the reconciler ignores allocations of disconnected clients that have
+// already been replaced and marks them as lost once they expire
+func TestReconciler_DisconnectedNode_Unknown(t *testing.T) {
+	job := mock.Job()
+	job.TaskGroups[0].Count = 2
+	job.TaskGroups[0].MaxClientDisconnect = helper.TimeToPtr(time.Hour)
+
+	// Create an unknown allocation on a down node and its replacement
+	now := time.Now()
+	n := mock.Node()
+	n.Status = structs.NodeStatusDown
+	n.StatusUpdatedAt = now.Add(-time.Minute).Unix()
+	tainted := map[string]*structs.Node{n.ID: n}
+
+	var allocs []*structs.Allocation
+	for i := 0; i < 2; i++ {
+		alloc := mock.Alloc()
+		alloc.Job = job
+		alloc.JobID = job.ID
+		alloc.NodeID = uuid.Generate()
+		alloc.Name = structs.AllocName(job.ID, job.TaskGroups[0].Name, uint(i))
+		alloc.ClientStatus = structs.AllocClientStatusRunning
+		allocs = append(allocs, alloc)
+	}
+	unknown := allocs[0].Copy()
+	unknown.ID = uuid.Generate()
+	unknown.NodeID = n.ID
+	unknown.ClientStatus = structs.AllocClientStatusUnknown
+	unknown.NextAllocation = allocs[0].ID
+	allocs = append(allocs, unknown)
+
+	reconciler := NewAllocReconciler(testlog.HCLogger(t), allocUpdateFnIgnore, false, job.ID, job, nil, allocs, tainted, "")
+	reconciler.now = now
+	r := reconciler.Compute()
+
+	assertResults(t, r, &resultExpectation{
+		desiredTGUpdates: map[string]*structs.DesiredUpdates{
+			job.TaskGroups[0].Name: {
+				Ignore: 3,
+			},
+		},
+	})
+
+	// Once the window has expired the allocation is lost
+	reconciler = NewAllocReconciler(testlog.HCLogger(t), allocUpdateFnIgnore, false, job.ID, job, nil, allocs, tainted, "")
+	reconciler.now = now.Add(time.Hour)
+	r = reconciler.Compute()
+
+	assertResults(t, r, &resultExpectation{
+		stop: 1,
+		desiredTGUpdates: map[string]*structs.DesiredUpdates{
+			job.TaskGroups[0].Name: {
+				Stop:   1,
+				Ignore: 2,
+			},
+		},
+	})
+	require.Equal(t, unknown.ID, r.stop[0].alloc.ID)
+	require.Equal(t, structs.AllocClientStatusLost, r.stop[0].clientStatus)
+}
+
+// Tests the reconciler keeps the original allocation of a reconnecting client
+// and stops its replacement
+func TestReconciler_ReconnectingNode(t *testing.T) {
+	job := mock.Job()
+	job.TaskGroups[0].Count = 2
+	job.TaskGroups[0].MaxClientDisconnect = helper.TimeToPtr(time.Hour)
+
+	var allocs []*structs.Allocation
+	for i := 0; i < 2; i++ {
+		alloc := mock.Alloc()
+		alloc.Job = job
+		alloc.JobID = job.ID
+		alloc.NodeID = uuid.Generate()
+		alloc.Name = structs.AllocName(job.ID, job.TaskGroups[0].Name, uint(i))
+		alloc.ClientStatus = structs.AllocClientStatusRunning
+		allocs = append(allocs, alloc)
+	}
+
+	// The original allocation is still unknown although its node is up
+	original := allocs[0]
+	original.ClientStatus = structs.AllocClientStatusUnknown
+	replacement := original.Copy()
+	replacement.ID = uuid.Generate()
+	replacement.NodeID = uuid.Generate()
+	replacement.ClientStatus = structs.AllocClientStatusRunning
+	replacement.PreviousAllocation = original.ID
+	original.NextAllocation = replacement.ID
+	allocs = append(allocs, replacement)
+
+	reconciler := NewAllocReconciler(testlog.HCLogger(t), allocUpdateFnIgnore, false, job.ID, job, nil, allocs, nil, "")
+	r := reconciler.Compute()
+
+	assertResults(t, r, &resultExpectation{
+		stop:             1,
+		attributeUpdates: 1,
+		desiredTGUpdates: map[string]*structs.DesiredUpdates{
+			job.TaskGroups[0].Name: {
+				Stop:   1,
+				Ignore: 2,
+			},
+		},
+	})
+	require.Equal(t, replacement.ID, r.stop[0].alloc.ID)
+	require.Equal(t, allocReconnected, r.stop[0].statusDescription)
+	require.Contains(t, r.attributeUpdates, original.ID)
+	require.Empty(t, r.attributeUpdates[original.ID].NextAllocation)
+
+	// If the replacement runs a newer job version the original is stopped
+	job2 := job.Copy()
+	job2.Version++
+	replacement.Job = job2
+
+	reconciler = NewAllocReconciler(testlog.HCLogger(t), allocUpdateFnIgnore, false, job.ID, job2, nil, allocs, nil, "")
+	r = reconciler.Compute()
+
+	require.Len(t, r.stop, 1)
+	require.Equal(t, original.ID, r.stop[0].alloc.ID)
+	require.Empty(t, r.place)
+	require.Empty(t, r.attributeUpdates)
+}
+
+// Tests the reconciler detects a reconnecting client whose allocation was
+// already reported as running again after having been marked as unknown
+func TestReconciler_ReconnectingNode_ClientReportedRunning(t *testing.T) {
+	job := mock.Job()
+	job.TaskGroups[0].Count = 2
+	job.TaskGroups[0].MaxClientDisconnect = helper.TimeToPtr(time.Hour)
+
+	var allocs []*structs.Allocation
+	for i := 0; i < 2; i++ {
+		alloc := mock.Alloc()
+		alloc.Job = job
+		alloc.JobID = job.ID
+		alloc.NodeID = uuid.Generate()
+		alloc.Name = structs.AllocName(job.ID, job.TaskGroups[0].Name, uint(i))
+		alloc.ClientStatus = structs.AllocClientStatusRunning
+		allocs = append(allocs, alloc)
+	}
+
+	// The original allocation was marked as unknown and replaced, then its
+	// client reconnected and reported it as running
+	original := allocs[0]
+	original.AppendState(structs.AllocStateFieldClientStatus, structs.AllocClientStatusUnknown)
+	replacement := original.Copy()
+	replacement.ID = uuid.Generate()
+	replacement.NodeID = uuid.Generate()
+	replacement.AllocStates = nil
+	replacement.PreviousAllocation = original.ID
+	original.NextAllocation = replacement.ID
+	allocs = append(allocs, replacement)
+
+	reconciler := NewAllocReconciler(testlog.HCLogger(t), allocUpdateFnIgnore, false, job.ID, job, nil, allocs, nil, "")
+	r := reconciler.Compute()
+
+	assertResults(t, r, &resultExpectation{
+		stop:             1,
+		attributeUpdates: 1,
+		desiredTGUpdates: map[string]*structs.DesiredUpdates{
+			job.TaskGroups[0].Name: {
+				Stop:   1,
+				Ignore: 2,
+			},
+		},
+	})
+	require.Equal(t, replacement.ID, r.stop[0].alloc.ID)
+	require.Equal(t, allocReconnected, r.stop[0].statusDescription)
+
+	// The reconnect is recorded so the allocation is reconciled only once
+	updated := r.attributeUpdates[original.ID]
+	require.NotNil(t, updated)
+	require.Empty(t, updated.NextAllocation)
+	require.False(t, updated.NeedsToReconnect())
+	require.True(t, original.NeedsToReconnect())
+
+	allocs[0] = updated
+	allocs[2] = replacement.Copy()
+	allocs[2].DesiredStatus = structs.AllocDesiredStatusStop
+	reconciler = NewAllocReconciler(testlog.HCLogger(t), allocUpdateFnIgnore, false, job.ID, job, nil, allocs, nil, "")
+	r = reconciler.Compute()
+
+	assertResults(t, r, &resultExpectation{
+		desiredTGUpdates: map[string]*structs.DesiredUpdates{
+			job.TaskGroups[0].Name: {
+				Ignore: 2,
+			},
+		},
+	})
+}
+
 // Tests the reconciler properly handles lost nodes with allocations while
 // scaling up
 func TestReconciler_LostNode_ScaleUp(t *testing.T) {
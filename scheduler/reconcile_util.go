@@ -230,6 +230,57 @@ func (a allocSet) filterByTainted(nodes map[string]*structs.Node) (untainted, mi
 	return
 }
 
+// filterByDisconnect splits out the allocations of disconnected and
+// reconnecting clients. An allocation is disconnecting if its node is down
+// but the task group's max_client_disconnect window has not yet expired, and
+// disconnected if it has already been marked as unknown in that window. An
+// allocation is reconnecting if it was marked as unknown and its node is up
+// again, whether or not the client already reported its new status. All other
+// allocations are returned as remaining.
+func (a allocSet) filterByDisconnect(group *structs.TaskGroup, nodes map[string]*structs.Node, now time.Time) (remaining, disconnecting, disconnected, reconnecting allocSet) {
+	remaining = make(map[string]*structs.Allocation)
+	disconnecting = make(map[string]*structs.Allocation)
+	disconnected = make(map[string]*structs.Allocation)
+	reconnecting = make(map[string]*structs.Allocation)
+	for _, alloc := range a {
+		if alloc.TerminalStatus() {
+			remaining[alloc.ID] = alloc
+			continue
+		}
+
+		n, tainted := nodes[alloc.NodeID]
+		if tainted && n != nil && n.Status == structs.NodeStatusDown {
+			// Allocs on nodes that have been down for longer than the
+			// window are lost
+			if expiry, ok := disconnectExpiry(group, n); ok && now.Before(expiry) {
+				if alloc.ClientStatus == structs.AllocClientStatusUnknown {
+					disconnected[alloc.ID] = alloc
+				} else {
+					disconnecting[alloc.ID] = alloc
+				}
+				continue
+			}
+		} else if (alloc.ClientStatus == structs.AllocClientStatusUnknown || alloc.NeedsToReconnect()) &&
+			(!tainted || (n != nil && !n.TerminalStatus())) {
+			reconnecting[alloc.ID] = alloc
+			continue
+		}
+
+		remaining[alloc.ID] = alloc
+	}
+	return
+}
+
+// disconnectExpiry returns the time at which the allocations of the group on
+// the disconnected node are considered lost. It returns false if the group
+// does not support disconnected clients.
+func disconnectExpiry(group *structs.TaskGroup, node *structs.Node) (time.Time, bool) {
+	if group.MaxClientDisconnect == nil {
+		return time.Time{}, false
+	}
+	return time.Unix(node.StatusUpdatedAt, 0).Add(*group.MaxClientDisconnect), true
+}
+
 // filterByRescheduleable filters the allocation set to return the set of allocations that are either
 // untainted or a set of allocations that must be rescheduled now. Allocations that can be rescheduled
 // at a future time are also returned so that we can create follow up evaluations for them. Allocs are
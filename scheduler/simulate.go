@@ -0,0 +1,191 @@
+package scheduler
+
+import (
+	"fmt"
+
+	log "github.com/hashicorp/go-hclog"
+	memdb "github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// Simulation runs the schedulers against a state store, such as one restored
+// from a snapshot of a cluster, to show the outcome of hypothetical changes to
+// the cluster without side effects on the cluster. The plans of the
+// schedulers are applied to the state store so that each simulated evaluation
+// accounts for the previous ones.
+type Simulation struct {
+	logger  log.Logger
+	harness *Harness
+}
+
+// SimulationResult is the outcome of a simulated evaluation of a job.
+type SimulationResult struct {
+	// Job is the evaluated job
+	Job *structs.Job
+
+	// Eval is the evaluation with its final status, queued allocations and
+	// failed task group allocations
+	Eval *structs.Evaluation
+
+	// Placed, Stopped and Preempted are the allocations the scheduler
+	// placed, stopped and preempted
+	Placed    []*structs.Allocation
+	Stopped   []*structs.Allocation
+	Preempted []*structs.Allocation
+}
+
+// NewSimulation returns a simulation modifying the given state store.
+func NewSimulation(state *state.StateStore, logger log.Logger) (*Simulation, error) {
+	index, err := state.LatestIndex()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Simulation{
+		logger: logger.Named("simulation"),
+		harness: &Harness{
+			State:     state,
+			nextIndex: index + 1,
+		},
+	}, nil
+}
+
+// AddNodes adds count ready and eligible copies of the template node.
+func (s *Simulation) AddNodes(template *structs.Node, count int) ([]*structs.Node, error) {
+	nodes := make([]*structs.Node, count)
+	for i := range nodes {
+		node := template.Copy()
+		node.ID = uuid.Generate()
+		node.SecretID = uuid.Generate()
+		node.Name = fmt.Sprintf("%s-simulated-%d", template.Name, i+1)
+		node.Status = structs.NodeStatusReady
+		node.SchedulingEligibility = structs.NodeSchedulingEligible
+		node.Drain = false
+		node.DrainStrategy = nil
+
+		if err := s.harness.State.UpsertNode(s.harness.NextIndex(), node); err != nil {
+			return nil, err
+		}
+		nodes[i] = node
+	}
+	return nodes, nil
+}
+
+// RegisterJob registers the job, replacing any existing version of it, and
+// evaluates it.
+func (s *Simulation) RegisterJob(job *structs.Job) (*SimulationResult, error) {
+	job.Canonicalize()
+	if err := job.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.harness.State.UpsertJob(s.harness.NextIndex(), job); err != nil {
+		return nil, err
+	}
+	return s.EvaluateJob(job.Namespace, job.ID, structs.EvalTriggerJobRegister)
+}
+
+// EvaluateJob runs the scheduler for an evaluation of the job.
+func (s *Simulation) EvaluateJob(namespace, jobID, triggeredBy string) (*SimulationResult, error) {
+	job, err := s.harness.State.JobByID(nil, namespace, jobID)
+	if err != nil {
+		return nil, err
+	}
+	if job == nil {
+		return nil, fmt.Errorf("job %q not found", jobID)
+	}
+
+	eval := &structs.Evaluation{
+		ID:             uuid.Generate(),
+		Namespace:      job.Namespace,
+		Priority:       job.Priority,
+		Type:           job.Type,
+		TriggeredBy:    triggeredBy,
+		JobID:          job.ID,
+		JobModifyIndex: job.JobModifyIndex,
+		Status:         structs.EvalStatusPending,
+	}
+	if err := s.harness.State.UpsertEvals(s.harness.NextIndex(), []*structs.Evaluation{eval}); err != nil {
+		return nil, err
+	}
+
+	// The snapshot is not modified by the plans applied to the state store,
+	// so it tells apart the allocations placed by the scheduler.
+	snap, err := s.harness.State.Snapshot()
+	if err != nil {
+		return nil, err
+	}
+
+	numPlans, numEvals := len(s.harness.Plans), len(s.harness.Evals)
+	sched, err := NewScheduler(eval.Type, s.logger, snap, s.harness)
+	if err != nil {
+		return nil, err
+	}
+	if err := sched.Process(eval); err != nil {
+		return nil, err
+	}
+
+	result := &SimulationResult{
+		Job:  job,
+		Eval: eval,
+	}
+	for _, update := range s.harness.Evals[numEvals:] {
+		if update.ID == eval.ID {
+			result.Eval = update
+		}
+	}
+
+	for _, plan := range s.harness.Plans[numPlans:] {
+		for _, allocs := range plan.NodeAllocation {
+			for _, alloc := range allocs {
+				existing, err := snap.AllocByID(nil, alloc.ID)
+				if err != nil {
+					return nil, err
+				}
+				if existing == nil {
+					result.Placed = append(result.Placed, alloc)
+				}
+			}
+		}
+		for _, allocs := range plan.NodeUpdate {
+			result.Stopped = append(result.Stopped, allocs...)
+		}
+		for _, allocs := range plan.NodePreemptions {
+			result.Preempted = append(result.Preempted, allocs...)
+		}
+	}
+	return result, nil
+}
+
+// BlockedJobs returns the jobs with blocked evaluations, which are waiting
+// for capacity to place allocations.
+func (s *Simulation) BlockedJobs() ([]structs.NamespacedID, error) {
+	iter, err := s.harness.State.Evals(memdb.NewWatchSet())
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[structs.NamespacedID]struct{})
+	var jobs []structs.NamespacedID
+	for {
+		raw := iter.Next()
+		if raw == nil {
+			break
+		}
+
+		eval := raw.(*structs.Evaluation)
+		if eval.Status != structs.EvalStatusBlocked {
+			continue
+		}
+
+		id := structs.NamespacedID{Namespace: eval.Namespace, ID: eval.JobID}
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		jobs = append(jobs, id)
+	}
+	return jobs, nil
+}
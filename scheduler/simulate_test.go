@@ -0,0 +1,85 @@
+package scheduler
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSimulation_RegisterJob(t *testing.T) {
+	require := require.New(t)
+	h := NewHarness(t)
+
+	for i := 0; i < 2; i++ {
+		require.NoError(h.State.UpsertNode(h.NextIndex(), mock.Node()))
+	}
+
+	sim, err := NewSimulation(h.State, testlog.HCLogger(t))
+	require.NoError(err)
+
+	job := mock.Job()
+	result, err := sim.RegisterJob(job)
+	require.NoError(err)
+	require.Len(result.Placed, 10)
+	require.Empty(result.Stopped)
+	require.Empty(result.Eval.FailedTGAllocs)
+	require.Equal(structs.EvalStatusComplete, result.Eval.Status)
+
+	// The placements are applied to the state store
+	allocs, err := h.State.AllocsByJob(nil, job.Namespace, job.ID, false)
+	require.NoError(err)
+	require.Len(allocs, 10)
+
+	// Registering the job again does not place anything
+	result, err = sim.RegisterJob(job.Copy())
+	require.NoError(err)
+	require.Empty(result.Placed)
+}
+
+func TestSimulation_AddNodes_BlockedJobs(t *testing.T) {
+	require := require.New(t)
+	h := NewHarness(t)
+
+	node := mock.Node()
+	node.NodeClass = "large"
+	node.ComputeClass()
+	require.NoError(h.State.UpsertNode(h.NextIndex(), node))
+
+	sim, err := NewSimulation(h.State, testlog.HCLogger(t))
+	require.NoError(err)
+
+	// Only 7 of the 10 allocations fit on the node
+	job := mock.Job()
+	result, err := sim.RegisterJob(job)
+	require.NoError(err)
+	require.Len(result.Placed, 7)
+	require.Contains(result.Eval.FailedTGAllocs, "web")
+
+	// Mark the job as waiting for capacity
+	blocked := mock.Eval()
+	blocked.JobID = job.ID
+	blocked.Status = structs.EvalStatusBlocked
+	require.NoError(h.State.UpsertEvals(h.NextIndex(), []*structs.Evaluation{blocked}))
+
+	jobs, err := sim.BlockedJobs()
+	require.NoError(err)
+	require.Equal([]structs.NamespacedID{{Namespace: job.Namespace, ID: job.ID}}, jobs)
+
+	nodes, err := sim.AddNodes(node, 1)
+	require.NoError(err)
+	require.Len(nodes, 1)
+	require.NotEqual(node.ID, nodes[0].ID)
+	require.Equal("large", nodes[0].NodeClass)
+
+	// The remaining allocations are placed on the added node
+	result, err = sim.EvaluateJob(job.Namespace, job.ID, structs.EvalTriggerNodeUpdate)
+	require.NoError(err)
+	require.Len(result.Placed, 3)
+	require.Empty(result.Eval.FailedTGAllocs)
+	for _, alloc := range result.Placed {
+		require.Equal(nodes[0].ID, alloc.NodeID)
+	}
+}
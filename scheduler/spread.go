@@ -1,6 +1,9 @@
 package scheduler
 
 import (
+	"strings"
+
+	"github.com/hashicorp/nomad/helper"
 	"github.com/hashicorp/nomad/nomad/structs"
 )
 
@@ -38,6 +41,11 @@ type SpreadIterator struct {
 	// existing allocs are computed once, and allocs from the plan are updated
 	// when Reset is called
 	groupPropertySets map[string][]*propertySet
+
+	// groupSpreadTrees is a memoized map from task group to the trees of
+	// nested spreads, which are balanced across every level of the hierarchy
+	// rather than scored per attribute.
+	groupSpreadTrees map[string][]*spreadTree
 }
 
 type spreadAttributeMap map[string]*spreadInfo
@@ -47,11 +55,62 @@ type spreadInfo struct {
 	desiredCounts map[string]float64
 }
 
+// spreadTree tracks a nested spread whose levels form a hierarchy, such as
+// racks within datacenters.
+type spreadTree struct {
+	weight int8
+	levels []*spreadLevel
+}
+
+// spreadLevel is a single attribute of a spreadTree.
+type spreadLevel struct {
+	attribute string
+
+	// pset tracks the allocations per value path of the level
+	pset *propertySet
+
+	// desiredPercents is the desired fraction of the allocations of the
+	// enclosing value for each attribute value. It is empty when the level
+	// is spread evenly.
+	desiredPercents map[string]float64
+}
+
+// newSpreadTree builds the tree of a nested spread for the task group.
+func newSpreadTree(ctx Context, job *structs.Job, tg string, spread *structs.Spread) *spreadTree {
+	tree := &spreadTree{weight: spread.Weight}
+
+	var parents []string
+	for _, s := range spread.Levels() {
+		level := &spreadLevel{
+			attribute:       s.Attribute,
+			pset:            NewPropertySet(ctx, job),
+			desiredPercents: make(map[string]float64),
+		}
+		level.pset.SetNestedTargetAttribute(helper.CopySliceString(parents), s.Attribute, tg)
+
+		sumPercent := 0.0
+		for _, st := range s.SpreadTarget {
+			percent := float64(st.Percent) / float64(100)
+			level.desiredPercents[st.Value] = percent
+			sumPercent += percent
+		}
+		// Account for the remaining values only if there are spread targets
+		if sumPercent > 0 && sumPercent < 1 {
+			level.desiredPercents[implicitTarget] = 1 - sumPercent
+		}
+
+		tree.levels = append(tree.levels, level)
+		parents = append(parents, s.Attribute)
+	}
+	return tree
+}
+
 func NewSpreadIterator(ctx Context, source RankIterator) *SpreadIterator {
 	iter := &SpreadIterator{
 		ctx:               ctx,
 		source:            source,
 		groupPropertySets: make(map[string][]*propertySet),
+		groupSpreadTrees:  make(map[string][]*spreadTree),
 		tgSpreadInfo:      make(map[string]spreadAttributeMap),
 	}
 	return iter
@@ -64,6 +123,13 @@ func (iter *SpreadIterator) Reset() {
 			ps.PopulateProposed()
 		}
 	}
+	for _, trees := range iter.groupSpreadTrees {
+		for _, tree := range trees {
+			for _, level := range tree.levels {
+				level.pset.PopulateProposed()
+			}
+		}
+	}
 }
 
 func (iter *SpreadIterator) SetJob(job *structs.Job) {
@@ -78,15 +144,19 @@ func (iter *SpreadIterator) SetTaskGroup(tg *structs.TaskGroup) {
 
 	// Build the property set at the taskgroup level
 	if _, ok := iter.groupPropertySets[tg.Name]; !ok {
-		// First add property sets that are at the job level for this task group
-		for _, spread := range iter.jobSpreads {
-			pset := NewPropertySet(iter.ctx, iter.job)
-			pset.SetTargetAttribute(spread.Attribute, tg.Name)
-			iter.groupPropertySets[tg.Name] = append(iter.groupPropertySets[tg.Name], pset)
-		}
+		// First add property sets that are at the job level for this task
+		// group, followed by the property sets at the task group level
+		combinedSpreads := make([]*structs.Spread, 0, len(iter.jobSpreads)+len(tg.Spreads))
+		combinedSpreads = append(combinedSpreads, iter.jobSpreads...)
+		combinedSpreads = append(combinedSpreads, tg.Spreads...)
+		for _, spread := range combinedSpreads {
+			// Nested spreads are balanced across their hierarchy
+			if spread.Nested != nil {
+				tree := newSpreadTree(iter.ctx, iter.job, tg.Name, spread)
+				iter.groupSpreadTrees[tg.Name] = append(iter.groupSpreadTrees[tg.Name], tree)
+				continue
+			}
 
-		// Include property sets at the task group level
-		for _, spread := range tg.Spreads {
 			pset := NewPropertySet(iter.ctx, iter.job)
 			pset.SetTargetAttribute(spread.Attribute, tg.Name)
 			iter.groupPropertySets[tg.Name] = append(iter.groupPropertySets[tg.Name], pset)
@@ -94,7 +164,7 @@ func (iter *SpreadIterator) SetTaskGroup(tg *structs.TaskGroup) {
 	}
 
 	// Check if there are any spreads configured
-	iter.hasSpread = len(iter.groupPropertySets[tg.Name]) != 0
+	iter.hasSpread = len(iter.groupPropertySets[tg.Name])+len(iter.groupSpreadTrees[tg.Name]) != 0
 
 	// Build tgSpreadInfo at the task group level
 	if _, ok := iter.tgSpreadInfo[tg.Name]; !ok {
@@ -165,6 +235,11 @@ func (iter *SpreadIterator) Next() *RankedNode {
 			}
 		}
 
+		// Add the score of each nested spread
+		for _, tree := range iter.groupSpreadTrees[tgName] {
+			totalSpreadScore += iter.spreadTreeScore(tree, option.Node)
+		}
+
 		if totalSpreadScore != 0.0 {
 			option.Scores = append(option.Scores, totalSpreadScore)
 			iter.ctx.Metrics().ScoreNode(option.Node, "allocation-spread", totalSpreadScore)
@@ -173,6 +248,74 @@ func (iter *SpreadIterator) Next() *RankedNode {
 	}
 }
 
+// spreadTreeScore scores the option against every level of a nested spread.
+// The desired count of a value is a fraction of the desired count of its
+// enclosing value, starting from the task group count, or for evenly spread
+// levels the values are balanced against their siblings. The scores of the
+// levels are averaged and weighted by the spread's weight.
+func (iter *SpreadIterator) spreadTreeScore(tree *spreadTree, option *structs.Node) float64 {
+	tgName := iter.tg.Name
+	desiredCount := float64(iter.tg.Count)
+	parentValue := ""
+	totalScore := 0.0
+	for _, level := range tree.levels {
+		nValue, errorMsg, usedCount := level.pset.UsedCount(option, tgName)
+
+		// Use the maximum possible penalty if the node is missing an attribute
+		// of the hierarchy or there were errors building it
+		if errorMsg != "" {
+			iter.ctx.Logger().Named("spread").Warn("error building spread attributes for task group", "task_group", tgName, "error", errorMsg)
+			return -1.0
+		}
+
+		// Add one to include placement on this node in the scoring calculation
+		usedCount += 1
+
+		if len(level.desiredPercents) == 0 {
+			siblings := siblingUseMap(level.pset.GetCombinedUseMap(), parentValue)
+			totalScore += evenSpreadBoost(siblings, nValue)
+
+			// Values of the next level are spread within this value's share
+			desiredCount = float64(usedCount)
+		} else {
+			value, _ := getProperty(option, level.attribute)
+			percent, ok := level.desiredPercents[value]
+			if !ok {
+				percent, ok = level.desiredPercents[implicitTarget]
+			}
+
+			// The desired count for this value is zero so use the maximum
+			// possible penalty
+			desiredCount *= percent
+			if !ok || desiredCount <= 0 {
+				return -1.0
+			}
+			totalScore += (desiredCount - float64(usedCount)) / desiredCount
+		}
+		parentValue = nValue
+	}
+
+	spreadWeight := float64(tree.weight) / float64(iter.sumSpreadWeights)
+	return totalScore / float64(len(tree.levels)) * spreadWeight
+}
+
+// siblingUseMap returns the used counts of the values of a nested spread
+// level that share the given parent value path.
+func siblingUseMap(combinedUseMap map[string]uint64, parentValue string) map[string]uint64 {
+	if parentValue == "" {
+		return combinedUseMap
+	}
+
+	prefix := parentValue + propertyPathSeparator
+	siblings := make(map[string]uint64)
+	for value, count := range combinedUseMap {
+		if strings.HasPrefix(value, prefix) {
+			siblings[value] = count
+		}
+	}
+	return siblings
+}
+
 // evenSpreadScoreBoost is a scoring helper that calculates the score
 // for the option when even spread is desired (all attribute values get equal preference)
 func evenSpreadScoreBoost(pset *propertySet, option *structs.Node) float64 {
@@ -182,12 +325,22 @@ func evenSpreadScoreBoost(pset *propertySet, option *structs.Node) float64 {
 		return 0.0
 	}
 	// Get the nodes property value
-	nValue, ok := getProperty(option, pset.targetAttribute)
+	nValue, ok := pset.nodeProperty(option)
 
 	// Maximum possible penalty when the attribute isn't set on the node
 	if !ok {
 		return -1.0
 	}
+	return evenSpreadBoost(combinedUseMap, nValue)
+}
+
+// evenSpreadBoost calculates the score of a value given the used counts of
+// all the values that should be spread evenly.
+func evenSpreadBoost(combinedUseMap map[string]uint64, nValue string) float64 {
+	if len(combinedUseMap) == 0 {
+		// Nothing placed yet, so return 0 as the score
+		return 0.0
+	}
 	currentAttributeCount := combinedUseMap[nValue]
 	minCount := uint64(0)
 	maxCount := uint64(0)
@@ -238,6 +391,13 @@ func (iter *SpreadIterator) computeSpreadInfo(tg *structs.TaskGroup) {
 	combinedSpreads = append(combinedSpreads, tg.Spreads...)
 	combinedSpreads = append(combinedSpreads, iter.jobSpreads...)
 	for _, spread := range combinedSpreads {
+		iter.sumSpreadWeights += int32(spread.Weight)
+
+		// The desired counts of nested spreads are tracked by their tree
+		if spread.Nested != nil {
+			continue
+		}
+
 		si := &spreadInfo{weight: spread.Weight, desiredCounts: make(map[string]float64)}
 		sumDesiredCounts := 0.0
 		for _, st := range spread.SpreadTarget {
@@ -251,7 +411,6 @@ func (iter *SpreadIterator) computeSpreadInfo(tg *structs.TaskGroup) {
 			si.desiredCounts[implicitTarget] = remainingCount
 		}
 		spreadInfos[spread.Attribute] = si
-		iter.sumSpreadWeights += int32(spread.Weight)
 	}
 	iter.tgSpreadInfo[tg.Name] = spreadInfos
 }
@@ -568,3 +568,166 @@ func Test_evenSpreadScoreBoost(t *testing.T) {
 	require.False(t, math.IsInf(boost, 1))
 	require.Equal(t, 1.0, boost)
 }
+
+func TestSpreadIterator_NestedEvenSpread(t *testing.T) {
+	state, ctx := testContext(t)
+	dcs := []string{"dc1", "dc1", "dc2", "dc2"}
+	racks := []string{"r1", "r2", "r1", "r2"}
+	var nodes []*RankedNode
+
+	// Add these nodes to the state store. Rack names are reused across
+	// datacenters.
+	for i, dc := range dcs {
+		node := mock.Node()
+		node.Datacenter = dc
+		node.Meta["rack"] = racks[i]
+		require.NoError(t, state.UpsertNode(uint64(100+i), node))
+		nodes = append(nodes, &RankedNode{Node: node})
+	}
+
+	static := NewStaticRankIterator(ctx, nodes)
+
+	job := mock.Job()
+	tg := job.TaskGroups[0]
+	tg.Count = 4
+
+	// add an alloc to dc1/r1
+	upserting := []*structs.Allocation{
+		{
+			Namespace: structs.DefaultNamespace,
+			TaskGroup: tg.Name,
+			JobID:     job.ID,
+			Job:       job,
+			ID:        uuid.Generate(),
+			EvalID:    uuid.Generate(),
+			NodeID:    nodes[0].Node.ID,
+		},
+	}
+	require.NoError(t, state.UpsertAllocs(1000, upserting))
+
+	// Spread evenly across the datacenters and the racks within them
+	tg.Spreads = []*structs.Spread{
+		{
+			Weight:    100,
+			Attribute: "${node.datacenter}",
+			SpreadTarget: []*structs.SpreadTarget{
+				{
+					Value:   "dc1",
+					Percent: 50,
+				},
+				{
+					Value:   "dc2",
+					Percent: 50,
+				},
+			},
+			Nested: &structs.Spread{
+				Attribute: "${meta.rack}",
+			},
+		},
+	}
+	spreadIter := NewSpreadIterator(ctx, static)
+	spreadIter.SetJob(job)
+	spreadIter.SetTaskGroup(tg)
+
+	scoreNorm := NewScoreNormalizationIterator(ctx, spreadIter)
+
+	out := collectRanked(scoreNorm)
+
+	// The rack in dc1 without allocs is preferred over the used one, and the
+	// r1 rack in dc2 isn't penalized by the alloc on the r1 rack of dc1
+	expectedScores := map[string]float64{
+		nodes[0].Node.ID: -0.5,
+		nodes[1].Node.ID: 0.5,
+		nodes[2].Node.ID: 0.25,
+		nodes[3].Node.ID: 0.25,
+	}
+	for _, rn := range out {
+		require.Equal(t, fmt.Sprintf("%.3f", expectedScores[rn.Node.ID]), fmt.Sprintf("%.3f", rn.FinalScore))
+	}
+}
+
+func TestSpreadIterator_NestedTargets(t *testing.T) {
+	state, ctx := testContext(t)
+	dcs := []string{"dc1", "dc1", "dc2", "dc2", "dc2"}
+	racks := []string{"r1", "r2", "r1", "r2", ""}
+	var nodes []*RankedNode
+
+	// Add these nodes to the state store. The last node has no rack.
+	for i, dc := range dcs {
+		node := mock.Node()
+		node.Datacenter = dc
+		if racks[i] != "" {
+			node.Meta["rack"] = racks[i]
+		}
+		require.NoError(t, state.UpsertNode(uint64(100+i), node))
+		nodes = append(nodes, &RankedNode{Node: node})
+	}
+
+	static := NewStaticRankIterator(ctx, nodes)
+
+	job := mock.Job()
+	tg := job.TaskGroups[0]
+	tg.Count = 4
+
+	// add an alloc to dc1/r1
+	upserting := []*structs.Allocation{
+		{
+			Namespace: structs.DefaultNamespace,
+			TaskGroup: tg.Name,
+			JobID:     job.ID,
+			Job:       job,
+			ID:        uuid.Generate(),
+			EvalID:    uuid.Generate(),
+			NodeID:    nodes[0].Node.ID,
+		},
+	}
+	require.NoError(t, state.UpsertAllocs(1000, upserting))
+
+	// Spread evenly across the datacenters with 75% of each datacenter's
+	// allocs on the r1 rack
+	job.Spreads = []*structs.Spread{
+		{
+			Weight:    100,
+			Attribute: "${node.datacenter}",
+			SpreadTarget: []*structs.SpreadTarget{
+				{
+					Value:   "dc1",
+					Percent: 50,
+				},
+				{
+					Value:   "dc2",
+					Percent: 50,
+				},
+			},
+			Nested: &structs.Spread{
+				Attribute: "${meta.rack}",
+				SpreadTarget: []*structs.SpreadTarget{
+					{
+						Value:   "r1",
+						Percent: 75,
+					},
+				},
+			},
+		},
+	}
+	spreadIter := NewSpreadIterator(ctx, static)
+	spreadIter.SetJob(job)
+	spreadIter.SetTaskGroup(tg)
+
+	scoreNorm := NewScoreNormalizationIterator(ctx, spreadIter)
+
+	out := collectRanked(scoreNorm)
+
+	// The scores of the datacenter and rack levels are averaged and a node
+	// missing the rack gets the maximum penalty
+	expectedScores := map[string]float64{
+		nodes[0].Node.ID: -0.167,
+		nodes[1].Node.ID: -0.5,
+		nodes[2].Node.ID: 0.417,
+		nodes[3].Node.ID: -0.25,
+		nodes[4].Node.ID: -1.0,
+	}
+	for _, rn := range out {
+		require.Equal(t, fmt.Sprintf("%.3f", expectedScores[rn.Node.ID]), fmt.Sprintf("%.3f", rn.FinalScore))
+	}
+}
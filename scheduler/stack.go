@@ -60,7 +60,9 @@ type GenericStack struct {
 	limit                      *LimitIterator
 	maxScore                   *MaxScoreIterator
 	nodeAffinity               *NodeAffinityIterator
+	jobAffinity                *JobAffinityIterator
 	spread                     *SpreadIterator
+	dcPreference               *DatacenterPreferenceIterator
 	scoreNorm                  *ScoreNormalizationIterator
 }
 
@@ -93,7 +95,9 @@ func (s *GenericStack) SetJob(job *structs.Job) {
 	s.binPack.SetJob(job)
 	s.jobAntiAff.SetJob(job)
 	s.nodeAffinity.SetJob(job)
+	s.jobAffinity.SetJob(job)
 	s.spread.SetJob(job)
+	s.dcPreference.SetJob(job)
 	s.ctx.Eligibility().SetJob(job)
 
 	if contextual, ok := s.quota.(ContextualIterator); ok {
@@ -143,9 +147,14 @@ func (s *GenericStack) Select(tg *structs.TaskGroup, options *SelectOptions) *Ra
 		s.nodeReschedulingPenalty.SetPenaltyNodes(options.PenaltyNodeIDs)
 	}
 	s.nodeAffinity.SetTaskGroup(tg)
+	s.jobAffinity.SetTaskGroup(tg)
 	s.spread.SetTaskGroup(tg)
+	s.dcPreference.SetTaskGroup(tg)
 
-	if s.nodeAffinity.hasAffinities() || s.spread.hasSpreads() {
+	// Evaluate every node when there are affinities or spreads to score, or
+	// when the placement is being explained.
+	if s.nodeAffinity.hasAffinities() || s.jobAffinity.hasAffinities() ||
+		s.spread.hasSpreads() || s.ctx.Explain() {
 		s.limit.SetLimit(math.MaxInt32)
 	}
 
@@ -230,7 +239,7 @@ func NewSystemStack(ctx Context) *SystemStack {
 	if schedConfig != nil {
 		enablePreemption = schedConfig.PreemptionConfig.SystemSchedulerEnabled
 	}
-	s.binPack = NewBinPackIterator(ctx, rankSource, enablePreemption, 0)
+	s.binPack = NewBinPackIterator(ctx, rankSource, enablePreemption, 0, schedConfig)
 
 	// Apply score normalization
 	s.scoreNorm = NewScoreNormalizationIterator(ctx, s.binPack)
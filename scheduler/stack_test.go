@@ -121,6 +121,35 @@ func TestServiceStack_Select_Size(t *testing.T) {
 	}
 }
 
+func TestServiceStack_Select_SchedulerAlgorithm(t *testing.T) {
+	state, ctx := testContext(t)
+
+	// Configure the spread scheduler algorithm
+	require.NoError(t, state.SchedulerSetConfig(10, &structs.SchedulerConfiguration{
+		SchedulerAlgorithm: structs.SchedulerAlgorithmSpread,
+	}))
+
+	// One node is already utilized by another job
+	nodes := []*structs.Node{
+		mock.Node(),
+		mock.Node(),
+	}
+	utilized, empty := nodes[0], nodes[1]
+	existing := mock.Alloc()
+	existing.NodeID = utilized.ID
+	require.NoError(t, state.UpsertJobSummary(11, mock.JobSummary(existing.JobID)))
+	require.NoError(t, state.UpsertAllocs(12, []*structs.Allocation{existing}))
+
+	stack := NewGenericStack(false, ctx)
+	stack.SetNodes(nodes)
+
+	job := mock.Job()
+	stack.SetJob(job)
+	node := stack.Select(job.TaskGroups[0], &SelectOptions{})
+	require.NotNil(t, node)
+	require.Equal(t, empty.ID, node.Node.ID)
+}
+
 func TestServiceStack_Select_PreferringNodes(t *testing.T) {
 	_, ctx := testContext(t)
 	nodes := []*structs.Node{
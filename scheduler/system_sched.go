@@ -119,6 +119,7 @@ func (s *SystemScheduler) process() (bool, error) {
 
 	// Create an evaluation context
 	s.ctx = NewEvalContext(s.state, s.plan, s.logger)
+	s.ctx.SetExplain(s.eval.ExplainPlacement)
 
 	// Construct the placement stack
 	s.stack = NewSystemStack(s.ctx)